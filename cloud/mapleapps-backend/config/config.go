@@ -2,6 +2,10 @@
 package config
 
 import (
+	"crypto/tls"
+	"log"
+	"net/url"
+	"strings"
 	"time"
 
 	sbytes "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/security/securebytes"
@@ -17,6 +21,8 @@ type Configuration struct {
 	PaperCloudMailgun MailgunConfig
 	Observability     ObservabilityConfig
 	Logging           LoggingConfig
+	TLS               TLSConfig
+	MapleFile         MapleFileConfig
 }
 
 type CacheConf struct {
@@ -66,6 +72,39 @@ type AWSConfig struct {
 	Endpoint   string
 	Region     string
 	BucketName string
+	// ListTimeout bounds how long listing every object of the bucket may take.
+	ListTimeout time.Duration
+	// Provider is one of the `StorageProvider*` constants, inferred from the endpoint when unset.
+	Provider string
+	// UsePathStyle addresses buckets as `endpoint/bucket/key` instead of `bucket.endpoint/key`,
+	// which self-hosted servers like MinIO need. It defaults to true for every provider except
+	// AWS and DigitalOcean Spaces.
+	UsePathStyle bool
+}
+
+// Object storage providers, used to pick the default bucket addressing style.
+const (
+	StorageProviderAWS    = "aws"
+	StorageProviderSpaces = "spaces"
+	StorageProviderMinIO  = "minio"
+	StorageProviderCustom = "custom"
+)
+
+// inferStorageProvider guesses the object storage provider from the host of its endpoint.
+func inferStorageProvider(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return StorageProviderCustom
+	}
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case strings.HasSuffix(host, ".amazonaws.com"):
+		return StorageProviderAWS
+	case strings.HasSuffix(host, ".digitaloceanspaces.com"):
+		return StorageProviderSpaces
+	default:
+		return StorageProviderCustom
+	}
 }
 
 // ObservabilityConfig contains configuration for health checks and metrics
@@ -86,6 +125,33 @@ type LoggingConfig struct {
 	EnableCaller     bool
 }
 
+// TLSConfig contains the TLS and HSTS policy of the HTTP server. TLS is only
+// terminated by the server when enabled, otherwise it is expected to be done by
+// a reverse proxy in front of it.
+type TLSConfig struct {
+	Enabled               bool
+	CertFile              string
+	KeyFile               string
+	MinVersion            uint16   // tls.VersionTLS12 unless overridden
+	CipherSuites          []uint16 // Only applies to TLS 1.2 and lower
+	HSTSEnabled           bool
+	HSTSMaxAge            time.Duration
+	HSTSIncludeSubdomains bool
+	HSTSPreload           bool
+}
+
+// MapleFileConfig contains the limits of the MapleFile service
+type MapleFileConfig struct {
+	// MaxHierarchyDepth and MaxHierarchyDescendants bound the descendants loaded for a collection,
+	// deeper or larger hierarchies are rejected instead of traversed.
+	MaxHierarchyDepth       int
+	MaxHierarchyDescendants int
+
+	// MaxCollectionMembers caps the members of a single collection, every member update rewrites
+	// the whole member list. Zero disables the limit.
+	MaxCollectionMembers int
+}
+
 func NewProvider() *Configuration {
 	var c Configuration
 
@@ -135,6 +201,25 @@ func NewProvider() *Configuration {
 	c.AWS.Endpoint = getEnv("BACKEND_AWS_ENDPOINT", true)
 	c.AWS.Region = getEnv("BACKEND_AWS_REGION", true)
 	c.AWS.BucketName = getEnv("BACKEND_AWS_BUCKET_NAME", true)
+	c.AWS.ListTimeout = getEnvDuration("BACKEND_AWS_LIST_TIMEOUT", false)
+	if c.AWS.ListTimeout == 0 {
+		c.AWS.ListTimeout = 2 * time.Minute
+	}
+	c.AWS.Provider = strings.ToLower(getEnv("BACKEND_AWS_PROVIDER", false))
+	switch c.AWS.Provider {
+	case "":
+		c.AWS.Provider = inferStorageProvider(c.AWS.Endpoint)
+	case StorageProviderAWS, StorageProviderSpaces, StorageProviderMinIO, StorageProviderCustom:
+	default:
+		log.Fatalf("Invalid value '%s' for environment variable BACKEND_AWS_PROVIDER, expected aws, spaces, minio or custom", c.AWS.Provider)
+	}
+	c.AWS.UsePathStyle = getEnvBool("BACKEND_AWS_USE_PATH_STYLE", false,
+		c.AWS.Provider != StorageProviderAWS && c.AWS.Provider != StorageProviderSpaces)
+
+	// --- MapleFile ---
+	c.MapleFile.MaxHierarchyDepth = getEnvInt("BACKEND_MAPLEFILE_MAX_HIERARCHY_DEPTH", false, 64)
+	c.MapleFile.MaxHierarchyDescendants = getEnvInt("BACKEND_MAPLEFILE_MAX_HIERARCHY_DESCENDANTS", false, 10000)
+	c.MapleFile.MaxCollectionMembers = getEnvInt("BACKEND_MAPLEFILE_MAX_COLLECTION_MEMBERS", false, 500)
 
 	// --- Observability ---
 	c.Observability.Enabled = getEnvBool("BACKEND_OBSERVABILITY_ENABLED", false, true)
@@ -170,6 +255,23 @@ func NewProvider() *Configuration {
 	c.Logging.EnableStacktrace = getEnvBool("LOG_ENABLE_STACKTRACE", false, c.App.Environment != "production")
 	c.Logging.EnableCaller = getEnvBool("LOG_ENABLE_CALLER", false, true)
 
+	// --- TLS ---
+	c.TLS.Enabled = getEnvBool("BACKEND_TLS_ENABLED", false, false)
+	c.TLS.CertFile = getEnv("BACKEND_TLS_CERT_FILE", c.TLS.Enabled)
+	c.TLS.KeyFile = getEnv("BACKEND_TLS_KEY_FILE", c.TLS.Enabled)
+	c.TLS.MinVersion = getTLSVersionEnv("BACKEND_TLS_MIN_VERSION", tls.VersionTLS12)
+	c.TLS.CipherSuites = getTLSCipherSuitesEnv("BACKEND_TLS_CIPHER_SUITES", c.App.Environment != "production")
+	if c.App.Environment == "production" && c.TLS.MinVersion < tls.VersionTLS12 {
+		log.Fatalf("BACKEND_TLS_MIN_VERSION below 1.2 is not allowed in production")
+	}
+	c.TLS.HSTSEnabled = getEnvBool("BACKEND_HSTS_ENABLED", false, c.App.Environment == "production")
+	c.TLS.HSTSMaxAge = getEnvDuration("BACKEND_HSTS_MAX_AGE", false)
+	if c.TLS.HSTSMaxAge == 0 {
+		c.TLS.HSTSMaxAge = 365 * 24 * time.Hour
+	}
+	c.TLS.HSTSIncludeSubdomains = getEnvBool("BACKEND_HSTS_INCLUDE_SUBDOMAINS", false, true)
+	c.TLS.HSTSPreload = getEnvBool("BACKEND_HSTS_PRELOAD", false, false)
+
 	//
 	// --------- MapleFile ------------
 	//
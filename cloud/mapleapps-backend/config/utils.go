@@ -1,6 +1,7 @@
 package config
 
 import (
+	"crypto/tls"
 	"log"
 	"os"
 	"strconv"
@@ -88,6 +89,18 @@ func getEnvBool(key string, required bool, defaultValue bool) bool {
 	return value
 }
 
+func getEnvInt(key string, required bool, defaultValue int) int {
+	valueStr := getEnv(key, required)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		log.Fatalf("Invalid integer value for environment variable %s", key)
+	}
+	return value
+}
+
 func getStringsArrEnv(key string, required bool) []string {
 	value := os.Getenv(key)
 	if required && value == "" {
@@ -131,3 +144,66 @@ func getEnvCassandraUUID(key string, required bool) gocql.UUID {
 	}
 	return objectID
 }
+
+// defaultTLSCipherSuites are the forward secret AEAD suites used for TLS 1.2
+// connections when no suites are configured.
+var defaultTLSCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+}
+
+func getTLSVersionEnv(key string, defaultValue uint16) uint16 {
+	value := os.Getenv(key)
+	switch value {
+	case "":
+		return defaultValue
+	case "1.0":
+		return tls.VersionTLS10
+	case "1.1":
+		return tls.VersionTLS11
+	case "1.2":
+		return tls.VersionTLS12
+	case "1.3":
+		return tls.VersionTLS13
+	}
+	log.Fatalf("Invalid TLS version '%s' for environment variable %s", value, key)
+	return 0
+}
+
+// getTLSCipherSuitesEnv parses a comma separated list of cipher suite names as
+// defined in the crypto/tls package. Suites which Go considers insecure are
+// only accepted if allowInsecure is true.
+func getTLSCipherSuitesEnv(key string, allowInsecure bool) []uint16 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultTLSCipherSuites
+	}
+
+	suites := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		suites[suite.Name] = suite.ID
+	}
+	if allowInsecure {
+		for _, suite := range tls.InsecureCipherSuites() {
+			suites[suite.Name] = suite.ID
+		}
+	}
+
+	var ids []uint16
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		id, ok := suites[name]
+		if !ok {
+			log.Fatalf("Invalid or disallowed TLS cipher suite '%s' for environment variable %s", name, key)
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -108,8 +108,11 @@ func (eck *EncryptedCollectionKey) NeedsRotation(policy KeyRotationPolicy) bool
 func (eck *EncryptedCollectionKey) UnmarshalJSON(data []byte) error {
 	// Temporary struct to unmarshal into string fields
 	type Alias struct {
-		Ciphertext string `json:"ciphertext"`
-		Nonce      string `json:"nonce"`
+		Ciphertext   string                   `json:"ciphertext"`
+		Nonce        string                   `json:"nonce"`
+		KeyVersion   int                      `json:"key_version"`
+		RotatedAt    *time.Time               `json:"rotated_at,omitempty"`
+		PreviousKeys []EncryptedHistoricalKey `json:"previous_keys,omitempty"`
 	}
 	var alias Alias
 
@@ -145,6 +148,10 @@ func (eck *EncryptedCollectionKey) UnmarshalJSON(data []byte) error {
 		eck.Nonce = nonceBytes
 	}
 
+	// The version and history are plain JSON
+	eck.KeyVersion = alias.KeyVersion
+	eck.RotatedAt = alias.RotatedAt
+	eck.PreviousKeys = alias.PreviousKeys
 	return nil
 }
 
@@ -176,8 +183,11 @@ func (eck *EncryptedFileKey) NeedsRotation(policy KeyRotationPolicy) bool {
 func (efk *EncryptedFileKey) UnmarshalJSON(data []byte) error {
 	// Temporary struct to unmarshal into string fields
 	type Alias struct {
-		Ciphertext string `json:"ciphertext"`
-		Nonce      string `json:"nonce"`
+		Ciphertext   string                   `json:"ciphertext"`
+		Nonce        string                   `json:"nonce"`
+		KeyVersion   int                      `json:"key_version"`
+		RotatedAt    *time.Time               `json:"rotated_at,omitempty"`
+		PreviousKeys []EncryptedHistoricalKey `json:"previous_keys,omitempty"`
 	}
 	var alias Alias
 
@@ -211,6 +221,10 @@ func (efk *EncryptedFileKey) UnmarshalJSON(data []byte) error {
 		efk.Nonce = nonceBytes
 	}
 
+	// The version and history are plain JSON
+	efk.KeyVersion = alias.KeyVersion
+	efk.RotatedAt = alias.RotatedAt
+	efk.PreviousKeys = alias.PreviousKeys
 	return nil
 }
 
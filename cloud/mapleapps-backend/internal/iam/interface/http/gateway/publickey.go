@@ -0,0 +1,60 @@
+// github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/interface/http/gateway/publickey.go
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	_ "time/tzdata"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/interface/http/middleware"
+	sv_gateway "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/service/gateway"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+)
+
+type GatewayUserPublicKeyHTTPHandler struct {
+	logger     *zap.Logger
+	service    sv_gateway.GatewayUserPublicKeyService
+	middleware middleware.Middleware
+}
+
+func NewGatewayUserPublicKeyHTTPHandler(
+	logger *zap.Logger,
+	service sv_gateway.GatewayUserPublicKeyService,
+	middleware middleware.Middleware,
+) *GatewayUserPublicKeyHTTPHandler {
+	logger = logger.Named("GatewayUserPublicKeyHTTPHandler")
+	return &GatewayUserPublicKeyHTTPHandler{
+		logger:     logger,
+		service:    service,
+		middleware: middleware,
+	}
+}
+
+func (*GatewayUserPublicKeyHTTPHandler) Pattern() string {
+	return "GET /iam/api/v1/users/public-key"
+}
+
+func (r *GatewayUserPublicKeyHTTPHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// Apply middleware before handling the request
+	r.middleware.Attach(r.Execute)(w, req)
+}
+
+func (h *GatewayUserPublicKeyHTTPHandler) Execute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// r.URL.Query().Get() already URL-decodes the parameter automatically
+	req := &sv_gateway.GatewayUserPublicKeyRequestDTO{
+		Email: r.URL.Query().Get("email"),
+	}
+
+	response, err := h.service.Execute(ctx, req)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
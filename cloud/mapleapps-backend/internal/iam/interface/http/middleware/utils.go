@@ -32,6 +32,8 @@ func init() {
 		// "/iam/api/v1/reset-password":      true,
 		// "/iam/api/v1/token/refresh": true, // This is counterintuitive to the token refresh api endpoint
 
+		"/iam/api/v1/users/public-key": true,
+
 		// "/iam/api/v1/recovery/initiate": true,
 		// "/iam/api/v1/recovery/verify":   true,
 		// "/iam/api/v1/recovery/complete": true,
@@ -27,6 +27,7 @@ func Module() fx.Option {
 			// unifiedhttp.AsRoute(gateway.NewGatewayResetPasswordHTTPHandler),
 			// unifiedhttp.AsRoute(gateway.NewGatewayForgotPasswordHTTPHandler),
 			unifiedhttp.AsRoute(gateway.NewGatewayFederatedUserPublicLookupHTTPHandler),
+			unifiedhttp.AsRoute(gateway.NewGatewayUserPublicKeyHTTPHandler),
 			unifiedhttp.AsRoute(gateway.NewInitiateRecoveryHTTPHandler),
 			unifiedhttp.AsRoute(gateway.NewVerifyRecoveryHTTPHandler),
 			unifiedhttp.AsRoute(gateway.NewCompleteRecoveryHTTPHandler),
@@ -3,11 +3,11 @@ package gateway
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	uc_emailer "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/usecase/emailer"
 	uc_user "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/usecase/federateduser"
+	pkg_email "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/email"
 	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
 	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/random"
 	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/security/jwt"
@@ -56,10 +56,7 @@ func (s *gatewayForgotPasswordServiceImpl) Execute(sessCtx context.Context, req
 	//
 
 	// Defensive Code: For security purposes we need to perform some sanitization on the inputs.
-	req.Email = strings.ToLower(req.Email)
-	req.Email = strings.ReplaceAll(req.Email, " ", "")
-	req.Email = strings.ReplaceAll(req.Email, "\t", "")
-	req.Email = strings.TrimSpace(req.Email)
+	req.Email = pkg_email.Normalize(req.Email)
 
 	//
 	// STEP 2: Validation of input.
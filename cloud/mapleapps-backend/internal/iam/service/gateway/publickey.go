@@ -0,0 +1,170 @@
+package gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	dom_user "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/domain/federateduser"
+	uc_user "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/usecase/federateduser"
+	pkg_email "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/email"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/security/crypto"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/storage/cache/cassandracache"
+)
+
+const (
+	// publicKeyLookupLimit is how many public keys one user can look up per window, which is plenty
+	// for sharing but makes enumerating the registered email addresses impractical.
+	publicKeyLookupLimit  = 30
+	publicKeyLookupWindow = time.Minute
+)
+
+type GatewayUserPublicKeyRequestDTO struct {
+	Email string `json:"email"`
+}
+
+type GatewayUserPublicKeyResponseDTO struct {
+	UserID            string `json:"user_id"`
+	Email             string `json:"email"`
+	PublicKeyInBase64 string `json:"public_key_in_base64"` // Base64 encoded
+	VerificationID    string `json:"verification_id"`
+	Fingerprint       string `json:"fingerprint"`
+}
+
+type GatewayUserPublicKeyService interface {
+	Execute(sessCtx context.Context, req *GatewayUserPublicKeyRequestDTO) (*GatewayUserPublicKeyResponseDTO, error)
+}
+
+type gatewayUserPublicKeyServiceImpl struct {
+	logger                *zap.Logger
+	cache                 cassandracache.CassandraCacher
+	userGetByEmailUseCase uc_user.FederatedUserGetByEmailUseCase
+}
+
+func NewGatewayUserPublicKeyService(
+	logger *zap.Logger,
+	cach cassandracache.CassandraCacher,
+	uc1 uc_user.FederatedUserGetByEmailUseCase,
+) GatewayUserPublicKeyService {
+	logger = logger.Named("GatewayUserPublicKeyService")
+	return &gatewayUserPublicKeyServiceImpl{logger, cach, uc1}
+}
+
+func (svc *gatewayUserPublicKeyServiceImpl) Execute(sessCtx context.Context, req *GatewayUserPublicKeyRequestDTO) (*GatewayUserPublicKeyResponseDTO, error) {
+	//
+	// STEP 1: Get the requesting user from the session.
+	//
+
+	requesterID, ok := sessCtx.Value(constants.SessionFederatedUserID).(gocql.UUID)
+	if !ok {
+		svc.logger.Error("Failed getting local federateduser id",
+			zap.Any("error", "Not found in context: user_id"))
+		return nil, errors.New("federateduser id not found in context")
+	}
+
+	//
+	// STEP 2: Sanitization and validation of the input.
+	//
+
+	req.Email = pkg_email.Normalize(req.Email)
+
+	e := make(map[string]string)
+	if req.Email == "" {
+		e["email"] = "Email is required"
+	} else if len(req.Email) > 255 {
+		e["email"] = "Email is too long"
+	} else if !strings.Contains(req.Email, "@") {
+		e["email"] = "Email is invalid"
+	}
+	if len(e) != 0 {
+		svc.logger.Warn("failed validating",
+			zap.Any("e", e))
+		return nil, httperror.NewForBadRequest(&e)
+	}
+
+	//
+	// STEP 3: Rate limit the lookups of the requesting user.
+	//
+
+	if err := svc.checkRateLimit(sessCtx, requesterID); err != nil {
+		return nil, err
+	}
+
+	//
+	// STEP 4: Lookup the recipient.
+	//
+
+	u, err := svc.userGetByEmailUseCase.Execute(sessCtx, req.Email)
+	if err != nil {
+		svc.logger.Error("failed getting user by email from database",
+			zap.Any("error", err))
+		return nil, err
+	}
+
+	// Unknown, unverified and inactive users all get the same answer so this endpoint can't be used
+	// to learn more about an email address than whether it can be shared with.
+	if !isShareableUser(u) {
+		svc.logger.Debug("no shareable user for email",
+			zap.String("email", req.Email))
+		return nil, httperror.NewForNotFoundWithSingleField("email", "No registered user with a verified account exists for this email")
+	}
+
+	fingerprint, err := crypto.GeneratePublicKeyFingerprint(u.SecurityData.PublicKey.Key)
+	if err != nil {
+		svc.logger.Error("failed generating public key fingerprint",
+			zap.String("user_id", u.ID.String()),
+			zap.Any("error", err))
+		return nil, err
+	}
+
+	return &GatewayUserPublicKeyResponseDTO{
+		UserID:            u.ID.String(),
+		Email:             u.Email,
+		PublicKeyInBase64: base64.StdEncoding.EncodeToString(u.SecurityData.PublicKey.Key),
+		VerificationID:    u.SecurityData.VerificationID,
+		Fingerprint:       fingerprint,
+	}, nil
+}
+
+// checkRateLimit counts the lookups of the user in the current window and rejects them once the
+// limit is exceeded. The count is incremented atomically so concurrent lookups can't all read the
+// same count and get past the limit together.
+func (svc *gatewayUserPublicKeyServiceImpl) checkRateLimit(ctx context.Context, requesterID gocql.UUID) error {
+	window := time.Now().Unix() / int64(publicKeyLookupWindow.Seconds())
+	cacheKey := fmt.Sprintf("public_key_lookup:%s:%d", requesterID.String(), window)
+
+	// Keep the counter a little longer than its window so it can't expire mid-window.
+	count, err := svc.cache.IncrementWithExpiry(ctx, cacheKey, 2*publicKeyLookupWindow)
+	if err != nil {
+		svc.logger.Error("failed incrementing public key lookup count",
+			zap.String("user_id", requesterID.String()),
+			zap.Any("error", err))
+		return err
+	}
+
+	if count > publicKeyLookupLimit {
+		svc.logger.Warn("public key lookup rate limit reached",
+			zap.String("user_id", requesterID.String()),
+			zap.Int64("count", count))
+		return httperror.NewForSingleField(http.StatusTooManyRequests, "email", "Too many public key lookups, please try again in a minute")
+	}
+	return nil
+}
+
+// isShareableUser returns true for users who finished registering and can receive shared collections
+func isShareableUser(u *dom_user.FederatedUser) bool {
+	return u != nil &&
+		u.Status == dom_user.FederatedUserStatusActive &&
+		u.SecurityData != nil &&
+		u.SecurityData.WasEmailVerified &&
+		len(u.SecurityData.PublicKey.Key) > 0
+}
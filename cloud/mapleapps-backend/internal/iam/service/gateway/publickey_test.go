@@ -0,0 +1,88 @@
+// internal/iam/service/gateway/publickey_test.go
+package gateway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	dom_user "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/domain/federateduser"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/domain/keys"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/mocks"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+	pkg_mocks "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/mocks"
+)
+
+func TestGatewayUserPublicKeyService_Execute(t *testing.T) {
+	requesterID := gocql.TimeUUID()
+	ctx := context.WithValue(context.Background(), constants.SessionFederatedUserID, requesterID)
+	recipient := &dom_user.FederatedUser{
+		ID:     gocql.TimeUUID(),
+		Email:  "alice@example.com",
+		Status: dom_user.FederatedUserStatusActive,
+		SecurityData: &dom_user.FederatedUserSecurityData{
+			WasEmailVerified: true,
+			PublicKey:        keys.PublicKey{Key: []byte("public key")},
+			VerificationID:   "verification-id",
+		},
+	}
+
+	t.Run("Success - Looks up the normalized email", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		cache := pkg_mocks.NewMockCassandraCacher(ctrl)
+		userGetByEmail := mocks.NewMockFederatedUserGetByEmailUseCase(ctrl)
+		svc := NewGatewayUserPublicKeyService(zap.NewNop(), cache, userGetByEmail)
+
+		cache.EXPECT().IncrementWithExpiry(gomock.Any(), gomock.Any(), 2*publicKeyLookupWindow).Return(int64(publicKeyLookupLimit), nil)
+		userGetByEmail.EXPECT().Execute(gomock.Any(), "alice@example.com").Return(recipient, nil)
+
+		resp, err := svc.Execute(ctx, &GatewayUserPublicKeyRequestDTO{Email: " Alice@Example.COM\t"})
+		require.NoError(t, err)
+		assert.Equal(t, recipient.ID.String(), resp.UserID)
+		assert.Equal(t, "verification-id", resp.VerificationID)
+	})
+
+	t.Run("Too Many Requests - Limit exceeded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		cache := pkg_mocks.NewMockCassandraCacher(ctrl)
+		userGetByEmail := mocks.NewMockFederatedUserGetByEmailUseCase(ctrl)
+		svc := NewGatewayUserPublicKeyService(zap.NewNop(), cache, userGetByEmail)
+
+		cache.EXPECT().IncrementWithExpiry(gomock.Any(), gomock.Any(), 2*publicKeyLookupWindow).Return(int64(publicKeyLookupLimit+1), nil)
+
+		resp, err := svc.Execute(ctx, &GatewayUserPublicKeyRequestDTO{Email: "alice@example.com"})
+		assert.Nil(t, resp)
+
+		var httpErr httperror.HTTPError
+		require.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, http.StatusTooManyRequests, httpErr.Code)
+	})
+
+	t.Run("Error - Counter unavailable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		cache := pkg_mocks.NewMockCassandraCacher(ctrl)
+		userGetByEmail := mocks.NewMockFederatedUserGetByEmailUseCase(ctrl)
+		svc := NewGatewayUserPublicKeyService(zap.NewNop(), cache, userGetByEmail)
+
+		cacheErr := errors.New("gocql: no hosts available in the pool")
+		cache.EXPECT().IncrementWithExpiry(gomock.Any(), gomock.Any(), 2*publicKeyLookupWindow).Return(int64(0), cacheErr)
+
+		resp, err := svc.Execute(ctx, &GatewayUserPublicKeyRequestDTO{Email: "alice@example.com"})
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, cacheErr)
+	})
+}
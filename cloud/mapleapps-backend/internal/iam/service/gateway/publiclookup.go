@@ -4,12 +4,12 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
-	"strings"
 
 	"go.uber.org/zap"
 
 	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
 	uc_user "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/usecase/federateduser"
+	pkg_email "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/email"
 	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
 	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/security/jwt"
 	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/security/password"
@@ -58,10 +58,7 @@ func (svc *gatewayFederatedUserPublicLookupServiceImpl) Execute(sessCtx context.
 	//
 
 	// Defensive Code: For security purposes we need to perform some sanitization on the inputs.
-	req.Email = strings.ToLower(req.Email)
-	req.Email = strings.ReplaceAll(req.Email, " ", "")
-	req.Email = strings.ReplaceAll(req.Email, "\t", "")
-	req.Email = strings.TrimSpace(req.Email)
+	req.Email = pkg_email.Normalize(req.Email)
 
 	svc.logger.Debug("sanitized email",
 		zap.Any("email", req.Email))
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
-	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -16,6 +15,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/domain/keys"
 	uc_emailer "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/usecase/emailer"
 	uc_user "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/usecase/federateduser"
+	pkg_email "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/email"
 	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
 	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/random"
 	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/security/crypto"
@@ -90,10 +90,7 @@ func (svc *gatewayFederatedUserRegisterServiceImpl) Execute(ctx context.Context,
 	//
 
 	// Defensive Code: For security purposes we need to perform some sanitization on the inputs.
-	req.Email = strings.ToLower(req.Email)
-	req.Email = strings.ReplaceAll(req.Email, " ", "")
-	req.Email = strings.ReplaceAll(req.Email, "\t", "")
-	req.Email = strings.TrimSpace(req.Email)
+	req.Email = pkg_email.Normalize(req.Email)
 
 	//
 	// STEP 2: Validation of input.
@@ -31,6 +31,7 @@ func Module() fx.Option {
 			gateway.NewGatewayLogoutService,
 			gateway.NewGatewayRefreshTokenService,
 			gateway.NewGatewayFederatedUserPublicLookupService,
+			gateway.NewGatewayUserPublicKeyService,
 			gateway.NewInitiateRecoveryService,
 			gateway.NewVerifyRecoveryService,
 			gateway.NewCompleteRecoveryService,
@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"math/big"
 	"time"
 
 	"github.com/gocql/gocql"
@@ -18,6 +19,14 @@ import (
 	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/security/crypto"
 )
 
+// InitiateRecoveryUseCase starts an account recovery by issuing a challenge encrypted with the public
+// key of the account.
+//
+// To prevent account enumeration the response is the same whether or not the email belongs to an
+// account with a recovery key: unknown emails get a session with a challenge encrypted for a
+// throwaway key pair, which is stored and rate limited like a real one but can never be verified.
+// Every call is also padded to a minimum duration with some jitter, so the database and crypto work
+// done only for real accounts does not show in the response time.
 type InitiateRecoveryUseCase interface {
 	Execute(ctx context.Context, email string, method dom_recovery.RecoveryMethod) (*InitiateRecoveryResult, error)
 }
@@ -38,6 +47,18 @@ type initiateRecoveryUseCaseImpl struct {
 	maxAttempts    int
 	attemptWindow  time.Duration
 	sessionTimeout time.Duration
+	minDuration    time.Duration
+	maxJitter      time.Duration
+}
+
+// recoveryIdentity is the account a recovery session is issued for, which is a decoy for emails
+// that cannot be recovered
+type recoveryIdentity struct {
+	userID                   gocql.UUID
+	publicKey                []byte
+	encryptedMasterKey       []byte
+	encryptedPrivateKey      []byte
+	masterKeyWithRecoveryKey []byte
 }
 
 func NewInitiateRecoveryUseCase(
@@ -55,10 +76,15 @@ func NewInitiateRecoveryUseCase(
 		maxAttempts:    5,                // Max 5 attempts
 		attemptWindow:  15 * time.Minute, // Within 15 minutes
 		sessionTimeout: 10 * time.Minute, // Session valid for 10 minutes
+		minDuration:    750 * time.Millisecond,
+		maxJitter:      250 * time.Millisecond,
 	}
 }
 
 func (uc *initiateRecoveryUseCaseImpl) Execute(ctx context.Context, email string, method dom_recovery.RecoveryMethod) (*InitiateRecoveryResult, error) {
+	// Take the same time whatever path the request takes
+	defer uc.padResponseTime(ctx, time.Now())
+
 	// Get client info from context
 	ipAddress, _ := ctx.Value(constants.SessionIPAddress).(string)
 	userAgent := "Unknown" // TODO: Get from request headers
@@ -77,29 +103,16 @@ func (uc *initiateRecoveryUseCaseImpl) Execute(ctx context.Context, email string
 		return nil, httperror.NewForBadRequestWithSingleField("email", "Too many recovery attempts. Please try again later.")
 	}
 
-	// Get user
-	user, err := uc.userRepo.GetByEmail(ctx, email)
+	// Get the account, or a decoy if it cannot be recovered
+	identity, err := uc.resolveIdentity(ctx, email)
 	if err != nil {
-		uc.logger.Error("Failed to get user", zap.Error(err))
-		return nil, httperror.NewForInternalServerError("Failed to process recovery request")
-	}
-
-	if user == nil {
-		// Don't reveal if user exists - create a fake attempt for security
-		uc.createFailedAttempt(ctx, gocql.UUID{}, email, method, ipAddress, userAgent, "User not found")
-		return nil, httperror.NewForBadRequestWithSingleField("email", "If this email exists, recovery instructions will be sent.")
-	}
-
-	// Verify user has recovery key set up
-	if user.SecurityData == nil || user.SecurityData.MasterKeyEncryptedWithRecoveryKey.Ciphertext == nil {
-		uc.createFailedAttempt(ctx, user.ID, email, method, ipAddress, userAgent, "No recovery key configured")
-		return nil, httperror.NewForBadRequestWithSingleField("recovery", "Recovery key not configured for this account")
+		return nil, err
 	}
 
 	// Create recovery attempt
 	attempt := &dom_recovery.RecoveryAttempt{
 		ID:          gocql.TimeUUID(),
-		UserID:      user.ID,
+		UserID:      identity.userID,
 		Email:       email,
 		Method:      method,
 		IPAddress:   ipAddress,
@@ -122,7 +135,7 @@ func (uc *initiateRecoveryUseCaseImpl) Execute(ctx context.Context, email string
 	}
 
 	// Encrypt challenge with user's public key
-	encryptedChallenge, err := crypto.EncryptWithPublicKey(challenge, user.SecurityData.PublicKey.Key)
+	encryptedChallenge, err := crypto.EncryptWithPublicKey(challenge, identity.publicKey)
 	if err != nil {
 		uc.logger.Error("Failed to encrypt challenge", zap.Error(err))
 		return nil, httperror.NewForInternalServerError("Failed to prepare security challenge")
@@ -134,15 +147,15 @@ func (uc *initiateRecoveryUseCaseImpl) Execute(ctx context.Context, email string
 
 	session := &dom_recovery.RecoverySession{
 		SessionID:                sessionID,
-		UserID:                   user.ID,
+		UserID:                   identity.userID,
 		Email:                    email,
 		Method:                   method,
 		EncryptedChallenge:       challenge, // Store original challenge for verification
 		ChallengeID:              challengeID,
-		PublicKey:                user.SecurityData.PublicKey.Key,
-		EncryptedMasterKey:       append(user.SecurityData.EncryptedMasterKey.Nonce, user.SecurityData.EncryptedMasterKey.Ciphertext...),
-		EncryptedPrivateKey:      append(user.SecurityData.EncryptedPrivateKey.Nonce, user.SecurityData.EncryptedPrivateKey.Ciphertext...),
-		MasterKeyWithRecoveryKey: append(user.SecurityData.MasterKeyEncryptedWithRecoveryKey.Nonce, user.SecurityData.MasterKeyEncryptedWithRecoveryKey.Ciphertext...),
+		PublicKey:                identity.publicKey,
+		EncryptedMasterKey:       identity.encryptedMasterKey,
+		EncryptedPrivateKey:      identity.encryptedPrivateKey,
+		MasterKeyWithRecoveryKey: identity.masterKeyWithRecoveryKey,
 		CreatedAt:                time.Now(),
 		ExpiresAt:                time.Now().Add(uc.sessionTimeout),
 		IsVerified:               false,
@@ -170,23 +183,62 @@ func (uc *initiateRecoveryUseCaseImpl) Execute(ctx context.Context, email string
 	}, nil
 }
 
-func (uc *initiateRecoveryUseCaseImpl) createFailedAttempt(ctx context.Context, userID gocql.UUID, email string, method dom_recovery.RecoveryMethod, ipAddress, userAgent, reason string) {
-	now := time.Now()
-	attempt := &dom_recovery.RecoveryAttempt{
-		ID:            gocql.TimeUUID(),
-		UserID:        userID,
-		Email:         email,
-		Method:        method,
-		IPAddress:     ipAddress,
-		UserAgent:     userAgent,
-		Status:        "failed",
-		FailureReason: reason,
-		AttemptedAt:   now,
-		CompletedAt:   &now,
-		ExpiresAt:     time.Now().Add(24 * time.Hour), // Keep for audit
+// resolveIdentity returns the account the email belongs to. Emails without an account, or whose
+// account has no recovery key, get a decoy identity with a freshly generated public key whose private
+// key is thrown away, so the challenge issued for it can never be answered.
+func (uc *initiateRecoveryUseCaseImpl) resolveIdentity(ctx context.Context, email string) (*recoveryIdentity, error) {
+	user, err := uc.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		uc.logger.Error("Failed to get user", zap.Error(err))
+		return nil, httperror.NewForInternalServerError("Failed to process recovery request")
 	}
 
-	if err := uc.recoveryRepo.CreateRecoveryAttempt(ctx, attempt); err != nil {
-		uc.logger.Warn("Failed to create failed recovery attempt", zap.Error(err))
+	if user != nil && user.SecurityData != nil && user.SecurityData.MasterKeyEncryptedWithRecoveryKey.Ciphertext != nil {
+		return &recoveryIdentity{
+			userID:                   user.ID,
+			publicKey:                user.SecurityData.PublicKey.Key,
+			encryptedMasterKey:       append(user.SecurityData.EncryptedMasterKey.Nonce, user.SecurityData.EncryptedMasterKey.Ciphertext...),
+			encryptedPrivateKey:      append(user.SecurityData.EncryptedPrivateKey.Nonce, user.SecurityData.EncryptedPrivateKey.Ciphertext...),
+			masterKeyWithRecoveryKey: append(user.SecurityData.MasterKeyEncryptedWithRecoveryKey.Nonce, user.SecurityData.MasterKeyEncryptedWithRecoveryKey.Ciphertext...),
+		}, nil
+	}
+
+	if user == nil {
+		uc.logger.Info("Issuing decoy recovery session for unknown email")
+	} else {
+		uc.logger.Info("Issuing decoy recovery session for account without recovery key",
+			zap.String("user_id", user.ID.String()))
+	}
+
+	decoyPublicKey, _, _, err := crypto.GenerateKeyPair()
+	if err != nil {
+		uc.logger.Error("Failed to generate decoy key pair", zap.Error(err))
+		return nil, httperror.NewForInternalServerError("Failed to generate security challenge")
+	}
+	return &recoveryIdentity{
+		userID:    gocql.UUID{},
+		publicKey: decoyPublicKey,
+	}, nil
+}
+
+// padResponseTime sleeps until a randomized minimum duration has passed since the request started
+func (uc *initiateRecoveryUseCaseImpl) padResponseTime(ctx context.Context, startedAt time.Time) {
+	target := uc.minDuration
+	if uc.maxJitter > 0 {
+		if jitter, err := rand.Int(rand.Reader, big.NewInt(int64(uc.maxJitter))); err == nil {
+			target += time.Duration(jitter.Int64())
+		}
+	}
+
+	remaining := target - time.Since(startedAt)
+	if remaining <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(remaining)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/gocql/gocql"
 	"go.uber.org/zap"
 
 	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
@@ -148,19 +149,38 @@ func (uc *verifyRecoveryUseCaseImpl) updateFailedAttempt(ctx context.Context, se
 	}
 
 	// Find the attempt with matching challenge ID
+	now := time.Now()
 	for _, attempt := range attempts {
 		if attempt.ChallengeID == session.ChallengeID {
 			attempt.Status = "failed"
 			attempt.FailureReason = reason
-			now := time.Now()
 			attempt.CompletedAt = &now
 
 			if err := uc.recoveryRepo.UpdateRecoveryAttempt(ctx, attempt); err != nil {
 				uc.logger.Warn("Failed to update recovery attempt", zap.Error(err))
 			}
-			break
+			return
 		}
 	}
+
+	// Decoy sessions of emails which can't be recovered all share the empty user ID, so their attempt
+	// may no longer be among the recent ones. Record the failure anyway, otherwise those emails would
+	// be rate limited differently from real accounts.
+	attempt := &dom_recovery.RecoveryAttempt{
+		ID:            gocql.TimeUUID(),
+		UserID:        session.UserID,
+		Email:         session.Email,
+		Method:        session.Method,
+		ChallengeID:   session.ChallengeID,
+		Status:        "failed",
+		FailureReason: reason,
+		AttemptedAt:   now,
+		CompletedAt:   &now,
+		ExpiresAt:     now.Add(24 * time.Hour), // Keep for audit
+	}
+	if err := uc.recoveryRepo.CreateRecoveryAttempt(ctx, attempt); err != nil {
+		uc.logger.Warn("Failed to create failed recovery attempt", zap.Error(err))
+	}
 }
 
 func (uc *verifyRecoveryUseCaseImpl) updateSuccessfulAttempt(ctx context.Context, session *dom_recovery.RecoverySession) {
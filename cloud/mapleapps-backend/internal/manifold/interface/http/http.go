@@ -3,6 +3,7 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 	"net/http"
 
@@ -20,14 +21,34 @@ func NewUnifiedHTTPServer(
 	mux *http.ServeMux,
 	mw middleware.Middleware, // Add middleware dependency
 ) *http.Server {
-	srv := &http.Server{Addr: ":8000", Handler: mux}
+	srv := &http.Server{
+		Addr:      ":8000",
+		Handler:   hstsHandler(config, mux),
+		TLSConfig: newTLSConfig(config),
+	}
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
+			if srv.TLSConfig != nil {
+				// Load the certificate before listening so a bad path fails startup.
+				cert, err := tls.LoadX509KeyPair(config.TLS.CertFile, config.TLS.KeyFile)
+				if err != nil {
+					return err
+				}
+				srv.TLSConfig.Certificates = []tls.Certificate{cert}
+			}
 			ln, err := net.Listen("tcp", srv.Addr)
 			if err != nil {
 				return err
 			}
-			log.Info("Starting HTTP server", zap.String("addr", srv.Addr))
+			if srv.TLSConfig != nil {
+				log.Info("Starting HTTPS server",
+					zap.String("addr", srv.Addr),
+					zap.String("min_tls_version", tls.VersionName(srv.TLSConfig.MinVersion)),
+					zap.Bool("hsts", config.TLS.HSTSEnabled))
+				go srv.ServeTLS(ln, "", "")
+				return nil
+			}
+			log.Info("Starting HTTP server", zap.String("addr", srv.Addr), zap.Bool("hsts", config.TLS.HSTSEnabled))
 			go srv.Serve(ln)
 			return nil
 		},
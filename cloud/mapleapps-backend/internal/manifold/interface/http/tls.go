@@ -0,0 +1,46 @@
+// internal/manifold/interface/http/tls.go
+package http
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+)
+
+// newTLSConfig returns the TLS policy of the server or nil if TLS is not
+// terminated by the server.
+func newTLSConfig(cfg *config.Configuration) *tls.Config {
+	if !cfg.TLS.Enabled {
+		return nil
+	}
+	return &tls.Config{
+		MinVersion:   cfg.TLS.MinVersion,
+		CipherSuites: cfg.TLS.CipherSuites,
+	}
+}
+
+// hstsHandler adds the Strict-Transport-Security header to every response sent
+// over HTTPS, including requests forwarded by a TLS terminating proxy.
+func hstsHandler(cfg *config.Configuration, next http.Handler) http.Handler {
+	if !cfg.TLS.HSTSEnabled {
+		return next
+	}
+
+	value := fmt.Sprintf("max-age=%d", int64(cfg.TLS.HSTSMaxAge.Seconds()))
+	if cfg.TLS.HSTSIncludeSubdomains {
+		value += "; includeSubDomains"
+	}
+	if cfg.TLS.HSTSPreload {
+		value += "; preload"
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Browsers ignore the header on plain HTTP so only send it for secure requests.
+		if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+			w.Header().Set("Strict-Transport-Security", value)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
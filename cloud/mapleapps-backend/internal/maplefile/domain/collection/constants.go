@@ -1,6 +1,37 @@
 // cloud/backend/internal/maplefile/domain/collection/constants.go
 package collection
 
+import "errors"
+
+// ErrHierarchyLimitExceeded is returned when the descendants of a collection are deeper or more
+// numerous than the configured limits allow, or when the ancestor data contains a cycle.
+var ErrHierarchyLimitExceeded = errors.New("collection hierarchy exceeds the traversal limits")
+
+// ErrMemberLimitExceeded is returned when adding a member to a collection which already has the
+// configured maximum number of members.
+var ErrMemberLimitExceeded = errors.New("collection has reached the maximum number of members")
+
+// ErrCollectionNotFound is returned when an operation targets a collection which does not exist.
+var ErrCollectionNotFound = errors.New("collection not found")
+
+// ErrAlreadyOwner is returned when transferring a collection to the user who already owns it.
+var ErrAlreadyOwner = errors.New("user is already the owner of this collection")
+
+// ErrNewOwnerNotMember is returned when transferring a collection to a user it was not shared
+// with, who holds no collection key to decrypt it.
+var ErrNewOwnerNotMember = errors.New("new owner must be a member of the collection before ownership can be transferred")
+
+// ErrMembersNotFound is returned when updating the permissions of recipients who are not members
+// of the collection.
+var ErrMembersNotFound = errors.New("members not found in collection")
+
+// ErrCannotDemoteOwner is returned when lowering the permission of the collection owner.
+var ErrCannotDemoteOwner = errors.New("cannot demote the collection owner")
+
+// ErrNewOwnerKeyInvalid is returned when transferring a collection to a member whose wrapped
+// collection key is missing or malformed.
+var ErrNewOwnerKeyInvalid = errors.New("new owner does not have a valid encrypted collection key")
+
 const (
 	CollectionTypeFolder = "folder"
 	CollectionTypeAlbum  = "album"
@@ -22,3 +53,20 @@ const (
 	CollectionAccessTypeOwner  = "owner"
 	CollectionAccessTypeMember = "member"
 )
+
+const ( // Kinds of discrepancy between the members table and the user access tables
+	MemberDiscrepancyMissingAccess  = "missing-access"  // The member has no access row for the collection
+	MemberDiscrepancyStaleAccess    = "stale-access"    // The access row has an outdated permission level, state or access type
+	MemberDiscrepancyOrphanedAccess = "orphaned-access" // The access row is keyed by an outdated modification time
+)
+
+const ( // Change types recorded in the history of a collection
+	CollectionChangeCreated              = "created"
+	CollectionChangeRenamed              = "renamed"
+	CollectionChangeMoved                = "moved"
+	CollectionChangeDeleted              = "deleted"
+	CollectionChangeMemberAdded          = "member-added"
+	CollectionChangeMemberRemoved        = "member-removed"
+	CollectionChangeOwnershipTransferred = "ownership-transferred"
+	CollectionChangeKeyRotated           = "key-rotated"
+)
@@ -3,6 +3,7 @@ package collection
 
 import (
 	"context"
+	"time"
 
 	"github.com/gocql/gocql"
 )
@@ -44,7 +45,23 @@ type CollectionRepository interface {
 	AddMember(ctx context.Context, collectionID gocql.UUID, membership *CollectionMembership) error
 	RemoveMember(ctx context.Context, collectionID, recipientID gocql.UUID) error
 	UpdateMemberPermission(ctx context.Context, collectionID, recipientID gocql.UUID, newPermission string) error
+	// UpdateMembersPermissions applies the permission of many recipients, keyed by recipient ID, in a single collection update
+	UpdateMembersPermissions(ctx context.Context, collectionID gocql.UUID, updates map[gocql.UUID]string) error
 	GetCollectionMembership(ctx context.Context, collectionID, recipientID gocql.UUID) (*CollectionMembership, error)
+	ListMembers(ctx context.Context, collectionID gocql.UUID, cursor MemberCursor, limit int) ([]*CollectionMembership, MemberCursor, error)
+	// DeduplicateMembers removes all but the most recent membership for each recipient and returns how many were removed
+	DeduplicateMembers(ctx context.Context, collectionID gocql.UUID) (int, error)
+	// ReconcileMembers compares the user access tables of the collection's members to the members table, repairs them and reports the discrepancies found
+	ReconcileMembers(ctx context.Context, collectionID gocql.UUID) (*MemberReconciliation, error)
+	// ListExpiredMembers returns the time-bounded memberships of all collections which expired before the given time
+	ListExpiredMembers(ctx context.Context, expiredBefore time.Time) ([]*CollectionMembership, error)
+
+	// Ownership
+	TransferOwnership(ctx context.Context, collectionID, newOwnerID gocql.UUID) error
+
+	// History
+	// GetCollectionHistory returns the recorded changes of a collection, most recent first
+	GetCollectionHistory(ctx context.Context, collectionID gocql.UUID) ([]*CollectionChange, error)
 
 	// Hierarchical sharing
 	AddMemberToHierarchy(ctx context.Context, rootID gocql.UUID, membership *CollectionMembership) error
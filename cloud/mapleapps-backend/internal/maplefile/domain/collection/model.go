@@ -79,6 +79,42 @@ type CollectionMembership struct {
 	// Sharing origin tracking
 	IsInherited     bool       `bson:"is_inherited" json:"is_inherited"`                               // Tracks whether access was granted directly or inherited from a parent
 	InheritedFromID gocql.UUID `bson:"inherited_from_id,omitempty" json:"inherited_from_id,omitempty"` // InheritedFromID identifies which parent collection granted this access
+
+	// Time-bounded access
+	ExpiresAt time.Time `bson:"expires_at,omitempty" json:"expires_at,omitzero"` // When the access ends, the zero value never expires
+}
+
+// IsExpired reports whether the membership is time-bounded and its expiry has passed
+func (m *CollectionMembership) IsExpired(now time.Time) bool {
+	return !m.ExpiresAt.IsZero() && !now.Before(m.ExpiresAt)
+}
+
+// MemberReconciliation reports how the user access tables of a collection's members compared to the
+// members table, which is authoritative, and whether the discrepancies were repaired.
+type MemberReconciliation struct {
+	CollectionID   gocql.UUID           `json:"collection_id"`
+	MembersChecked int                  `json:"members_checked"`
+	Discrepancies  []*MemberDiscrepancy `json:"discrepancies"`
+	Repaired       bool                 `json:"repaired"`
+}
+
+// MemberDiscrepancy describes a row of a user access table which disagrees with the members table
+type MemberDiscrepancy struct {
+	RecipientID gocql.UUID `json:"recipient_id"`
+	Kind        string     `json:"kind"`
+	Table       string     `json:"table"`
+	ModifiedAt  time.Time  `json:"modified_at"` // Clustering key of the row, or the collection's when it is missing
+}
+
+// MemberCursor represents cursor-based pagination through the members of a collection.
+// The zero value starts from the first member; a zero value returned from a listing means there are no more pages.
+type MemberCursor struct {
+	LastRecipientID gocql.UUID `json:"last_recipient_id" bson:"last_recipient_id"`
+}
+
+// IsZero reports whether the cursor points at the start of the listing
+func (c MemberCursor) IsZero() bool {
+	return c.LastRecipientID == (gocql.UUID{})
 }
 
 // CollectionSyncCursor represents cursor-based pagination for sync operations
@@ -104,3 +140,14 @@ type CollectionSyncResponse struct {
 	NextCursor  *CollectionSyncCursor `json:"next_cursor,omitempty"`
 	HasMore     bool                  `json:"has_more"`
 }
+
+// CollectionChange is an entry of the append-only change history of a collection
+type CollectionChange struct {
+	ID              gocql.UUID `json:"id" bson:"_id"`
+	CollectionID    gocql.UUID `json:"collection_id" bson:"collection_id"`
+	Version         uint64     `json:"version" bson:"version"`                       // Version of the collection after the change
+	ChangedAt       time.Time  `json:"changed_at" bson:"changed_at"`                 // When the change was persisted
+	ChangedByUserID gocql.UUID `json:"changed_by_user_id" bson:"changed_by_user_id"` // User who made the change
+	ChangeType      string     `json:"change_type" bson:"change_type"`               // One of the `CollectionChange*` constants
+	SubjectID       gocql.UUID `json:"subject_id,omitzero" bson:"subject_id"`        // Member added or removed, new parent or new owner
+}
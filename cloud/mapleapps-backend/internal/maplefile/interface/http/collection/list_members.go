@@ -0,0 +1,120 @@
+// cloud/backend/internal/maplefile/interface/http/collection/list_members.go
+package collection
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/interface/http/middleware"
+	svc_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/service/collection"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+)
+
+type ListCollectionMembersHTTPHandler struct {
+	config     *config.Configuration
+	logger     *zap.Logger
+	service    svc_collection.ListCollectionMembersService
+	middleware middleware.Middleware
+}
+
+func NewListCollectionMembersHTTPHandler(
+	config *config.Configuration,
+	logger *zap.Logger,
+	service svc_collection.ListCollectionMembersService,
+	middleware middleware.Middleware,
+) *ListCollectionMembersHTTPHandler {
+	logger = logger.Named("ListCollectionMembersHTTPHandler")
+	return &ListCollectionMembersHTTPHandler{
+		config:     config,
+		logger:     logger,
+		service:    service,
+		middleware: middleware,
+	}
+}
+
+func (*ListCollectionMembersHTTPHandler) Pattern() string {
+	return "GET /maplefile/api/v1/collections/{collection_id}/members"
+}
+
+func (h *ListCollectionMembersHTTPHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// Apply middleware before handling the request
+	h.middleware.Attach(h.Execute)(w, req)
+}
+
+func (h *ListCollectionMembersHTTPHandler) Execute(w http.ResponseWriter, r *http.Request) {
+	// Set response content type
+	w.Header().Set("Content-Type", "application/json")
+
+	ctx := r.Context()
+
+	// Extract collection ID from URL parameters
+	collectionIDStr := r.PathValue("collection_id")
+	if collectionIDStr == "" {
+		httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("collection_id", "Collection ID is required"))
+		return
+	}
+
+	// Convert string ID to ObjectID
+	collectionID, err := gocql.ParseUUID(collectionIDStr)
+	if err != nil {
+		h.logger.Error("invalid collection ID format",
+			zap.String("collection_id", collectionIDStr),
+			zap.Error(err))
+		httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("collection_id", "Invalid collection ID format"))
+		return
+	}
+
+	// Parse query parameters
+	queryParams := r.URL.Query()
+
+	// Parse limit parameter, the repository applies its default and maximum page size
+	limit := 0
+	if limitStr := queryParams.Get("limit"); limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil {
+			limit = parsedLimit
+		} else {
+			h.logger.Warn("Failed to parse limit parameter, using default",
+				zap.String("limit", limitStr),
+				zap.Error(err))
+		}
+	}
+
+	// Parse cursor parameter
+	var cursor dom_collection.MemberCursor
+	if cursorStr := queryParams.Get("cursor"); cursorStr != "" {
+		if err := json.Unmarshal([]byte(cursorStr), &cursor); err != nil {
+			h.logger.Error("Failed to parse cursor parameter",
+				zap.String("cursor", cursorStr),
+				zap.Error(err))
+			httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("cursor", "Invalid cursor format"))
+			return
+		}
+	}
+
+	resp, err := h.service.Execute(ctx, collectionID, cursor, limit)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	// Encode response
+	if resp != nil {
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			h.logger.Error("failed to encode response",
+				zap.Any("error", err))
+			httperror.ResponseError(w, err)
+			return
+		}
+	} else {
+		err := errors.New("no result")
+		httperror.ResponseError(w, err)
+		return
+	}
+}
@@ -0,0 +1,46 @@
+// internal/maplefile/interface/http/collection/update_test.go
+package collection
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUpdateCollectionUnmarshalKeepsKeyVersionAndHistory(t *testing.T) {
+	ciphertext := base64.RawURLEncoding.EncodeToString([]byte{0xfb, 0xff, 0x01})
+	nonce := base64.RawURLEncoding.EncodeToString([]byte{0xfe, 0x02})
+	body := `{
+		"encrypted_name": "bmFtZQ",
+		"version": 3,
+		"encrypted_collection_key": {
+			"ciphertext": "` + ciphertext + `",
+			"nonce": "` + nonce + `",
+			"key_version": 2,
+			"rotated_at": "2026-10-01T12:00:00Z",
+			"previous_keys": [{"key_version": 1, "ciphertext": "AQI=", "nonce": "AwQ=", "rotated_at": "2026-01-01T00:00:00Z", "algorithm": "chacha20poly1305"}]
+		}
+	}`
+	h := &UpdateCollectionHTTPHandler{logger: zap.NewNop()}
+
+	req, err := h.unmarshalRequest(context.Background(), httptest.NewRequest("PUT", "/", strings.NewReader(body)), gocql.TimeUUID())
+	require.NoError(t, err)
+
+	key := req.EncryptedCollectionKey
+	require.NotNil(t, key)
+	assert.Equal(t, []byte{0xfb, 0xff, 0x01}, key.Ciphertext)
+	assert.Equal(t, []byte{0xfe, 0x02}, key.Nonce)
+	assert.Equal(t, 2, key.KeyVersion)
+	require.NotNil(t, key.RotatedAt)
+	assert.Equal(t, 2026, key.RotatedAt.Year())
+	require.Len(t, key.PreviousKeys, 1)
+	assert.Equal(t, 1, key.PreviousKeys[0].KeyVersion)
+	assert.Equal(t, []byte{1, 2}, key.PreviousKeys[0].Ciphertext)
+}
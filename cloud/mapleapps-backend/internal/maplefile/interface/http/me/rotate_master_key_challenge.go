@@ -0,0 +1,74 @@
+// github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/interface/http/me/rotate_master_key_challenge.go
+package me
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/interface/http/middleware"
+	svc_me "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/service/me"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+)
+
+type PostRotateMasterKeyChallengeHTTPHandler struct {
+	config     *config.Configuration
+	logger     *zap.Logger
+	service    svc_me.RotateMasterKeyChallengeService
+	middleware middleware.Middleware
+}
+
+func NewPostRotateMasterKeyChallengeHTTPHandler(
+	config *config.Configuration,
+	logger *zap.Logger,
+	service svc_me.RotateMasterKeyChallengeService,
+	middleware middleware.Middleware,
+) *PostRotateMasterKeyChallengeHTTPHandler {
+	logger = logger.With(zap.String("module", "maplefile"))
+	logger = logger.Named("PostRotateMasterKeyChallengeHTTPHandler")
+	return &PostRotateMasterKeyChallengeHTTPHandler{
+		config:     config,
+		logger:     logger,
+		service:    service,
+		middleware: middleware,
+	}
+}
+
+func (*PostRotateMasterKeyChallengeHTTPHandler) Pattern() string {
+	return "POST /maplefile/api/v1/me/rotate-master-key/challenge"
+}
+
+func (r *PostRotateMasterKeyChallengeHTTPHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// Apply MaplesSend middleware before handling the request
+	r.middleware.Attach(r.Execute)(w, req)
+}
+
+func (h *PostRotateMasterKeyChallengeHTTPHandler) Execute(w http.ResponseWriter, r *http.Request) {
+	// Set response content type
+	w.Header().Set("Content-Type", "application/json")
+
+	ctx := r.Context()
+
+	resp, err := h.service.Execute(ctx)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	// Encode response
+	if resp != nil {
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			h.logger.Error("failed to encode response",
+				zap.Any("error", err))
+			httperror.ResponseError(w, err)
+			return
+		}
+	} else {
+		err := errors.New("no result")
+		httperror.ResponseError(w, err)
+		return
+	}
+}
@@ -20,30 +20,39 @@ var (
 func init() {
 	// Exact matches
 	exactPaths = map[string]bool{
-		"/maplefile/api/v1/me":                   true,
-		"/maplefile/api/v1/me/delete":            true,
-		"/maplefile/api/v1/dashboard":            true,
-		"/maplefile/api/v1/collections":          true,
-		"/maplefile/api/v1/collections/filtered": true,
-		"/maplefile/api/v1/collections/root":     true,
-		"/maplefile/api/v1/collections/shared":   true,
-		"/maplefile/api/v1/files":                true,
-		"/maplefile/api/v1/files/pending":        true, // Three-step workflow file-create endpoint: Start
-		"/maplefile/api/v1/sync/collections":     true,
-		"/maplefile/api/v1/sync/files":           true,
+		"/maplefile/api/v1/me":                             true,
+		"/maplefile/api/v1/me/delete":                      true,
+		"/maplefile/api/v1/me/rotate-master-key":           true,
+		"/maplefile/api/v1/me/rotate-master-key/challenge": true,
+		"/maplefile/api/v1/dashboard":                      true,
+		"/maplefile/api/v1/collections":                    true,
+		"/maplefile/api/v1/collections/filtered":           true,
+		"/maplefile/api/v1/collections/root":               true,
+		"/maplefile/api/v1/collections/shared":             true,
+		"/maplefile/api/v1/files":                          true,
+		"/maplefile/api/v1/files/pending":                  true, // Three-step workflow file-create endpoint: Start
+		"/maplefile/api/v1/files/batch":                    true,
+		"/maplefile/api/v1/files/batch/prepare":            true,
+		"/maplefile/api/v1/files/download-urls":            true,
+		"/maplefile/api/v1/sync/collections":               true,
+		"/maplefile/api/v1/sync/files":                     true,
 	}
 
 	// Pattern matches
 	patterns := []string{
 		// Collection patterns
-		"^/maplefile/api/v1/collections/[a-zA-Z0-9-]+$",           // Individual collection operations
-		"^/maplefile/api/v1/collections/[a-zA-Z0-9-]+/files$",     // Collection files
-		"^/maplefile/api/v1/collections/[a-zA-Z0-9-]+/move$",      // Move collection
-		"^/maplefile/api/v1/collections/[a-zA-Z0-9-]+/share$",     // Share collection
-		"^/maplefile/api/v1/collections/[a-zA-Z0-9-]+/members$",   // Collection members
-		"^/maplefile/api/v1/collections/[a-zA-Z0-9-]+/archive$",   // Archive collection
-		"^/maplefile/api/v1/collections/[a-zA-Z0-9-]+/restore$",   // Restore collection
-		"^/maplefile/api/v1/collections-by-parent/[a-zA-Z0-9-]+$", // Collections by parent
+		"^/maplefile/api/v1/collections/[a-zA-Z0-9-]+$",                     // Individual collection operations
+		"^/maplefile/api/v1/collections/[a-zA-Z0-9-]+/files$",               // Collection files
+		"^/maplefile/api/v1/collections/[a-zA-Z0-9-]+/move$",                // Move collection
+		"^/maplefile/api/v1/collections/[a-zA-Z0-9-]+/share$",               // Share collection
+		"^/maplefile/api/v1/collections/[a-zA-Z0-9-]+/transfer-ownership$",  // Transfer collection ownership
+		"^/maplefile/api/v1/collections/[a-zA-Z0-9-]+/members$",             // List, update and remove collection members
+		"^/maplefile/api/v1/collections/[a-zA-Z0-9-]+/members/public-keys$", // Collection member public keys
+		"^/maplefile/api/v1/collections/[a-zA-Z0-9-]+/archive$",             // Archive collection
+		"^/maplefile/api/v1/collections/[a-zA-Z0-9-]+/restore$",             // Restore collection
+		"^/maplefile/api/v1/collections/[a-zA-Z0-9-]+/reconcile-members$",   // Reconcile collection members
+		"^/maplefile/api/v1/collections/[a-zA-Z0-9-]+/history$",             // Collection history
+		"^/maplefile/api/v1/collections-by-parent/[a-zA-Z0-9-]+$",           // Collections by parent
 
 		// File patterns
 		"^/maplefile/api/v1/files/[a-zA-Z0-9-]+$",              // Individual file operations
@@ -53,6 +62,7 @@ func init() {
 		"^/maplefile/api/v1/files/[a-zA-Z0-9-]+/complete$",     // Complete file upload
 		"^/maplefile/api/v1/files/[a-zA-Z0-9-]+/archive$",      // Archive file
 		"^/maplefile/api/v1/files/[a-zA-Z0-9-]+/restore$",      // Restore file
+		"^/maplefile/api/v1/files/[a-zA-Z0-9-]+/move$",         // Move file
 	}
 
 	// Precompile patterns
@@ -24,11 +24,14 @@ func Module() fx.Option {
 			// Me handlers
 			unifiedhttp.AsRoute(me.NewGetMeHTTPHandler),
 			unifiedhttp.AsRoute(me.NewPutUpdateMeHTTPHandler),
+			unifiedhttp.AsRoute(me.NewPostRotateMasterKeyHTTPHandler),
+			unifiedhttp.AsRoute(me.NewPostRotateMasterKeyChallengeHTTPHandler),
 			unifiedhttp.AsRoute(me.NewDeleteMeHTTPHandler),
 
 			// Collection handlers - Basic CRUD
 			unifiedhttp.AsRoute(collection.NewCreateCollectionHTTPHandler),
 			unifiedhttp.AsRoute(collection.NewGetCollectionHTTPHandler),
+			unifiedhttp.AsRoute(collection.NewGetCollectionHistoryHTTPHandler),
 			unifiedhttp.AsRoute(collection.NewListUserCollectionsHTTPHandler),
 			unifiedhttp.AsRoute(collection.NewUpdateCollectionHTTPHandler),
 			unifiedhttp.AsRoute(collection.NewSoftDeleteCollectionHTTPHandler),
@@ -43,7 +46,12 @@ func Module() fx.Option {
 			// Collection handlers - Sharing
 			unifiedhttp.AsRoute(collection.NewShareCollectionHTTPHandler),
 			unifiedhttp.AsRoute(collection.NewRemoveMemberHTTPHandler),
+			unifiedhttp.AsRoute(collection.NewUpdateMembersPermissionsHTTPHandler),
+			unifiedhttp.AsRoute(collection.NewListCollectionMembersHTTPHandler),
+			unifiedhttp.AsRoute(collection.NewTransferOwnershipHTTPHandler),
+			unifiedhttp.AsRoute(collection.NewReconcileCollectionMembersHTTPHandler),
 			unifiedhttp.AsRoute(collection.NewListSharedCollectionsHTTPHandler),
+			unifiedhttp.AsRoute(collection.NewGetCollectionMemberPublicKeysHTTPHandler),
 
 			// Collection handlers - Filtered operations
 			unifiedhttp.AsRoute(collection.NewGetFilteredCollectionsHTTPHandler),
@@ -57,10 +65,14 @@ func Module() fx.Option {
 			unifiedhttp.AsRoute(file.NewGetFileHTTPHandler),
 			unifiedhttp.AsRoute(file.NewListFilesByCollectionHTTPHandler),
 			unifiedhttp.AsRoute(file.NewUpdateFileHTTPHandler),
+			unifiedhttp.AsRoute(file.NewMoveFileHTTPHandler),
 			unifiedhttp.AsRoute(file.NewCreatePendingFileHTTPHandler),
+			unifiedhttp.AsRoute(file.NewPrepareFileBatchUploadHTTPHandler),
+			unifiedhttp.AsRoute(file.NewCreateFileBatchHTTPHandler),
 			unifiedhttp.AsRoute(file.NewCompleteFileUploadHTTPHandler),
 			unifiedhttp.AsRoute(file.NewGetPresignedUploadURLHTTPHandler),
 			unifiedhttp.AsRoute(file.NewGetPresignedDownloadURLHTTPHandler),
+			unifiedhttp.AsRoute(file.NewGetPresignedDownloadURLsHTTPHandler),
 			unifiedhttp.AsRoute(file.NewArchiveFileHTTPHandler),
 			unifiedhttp.AsRoute(file.NewRestoreFileHTTPHandler),
 
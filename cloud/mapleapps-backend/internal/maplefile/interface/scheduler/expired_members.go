@@ -0,0 +1,62 @@
+// cloud/backend/internal/maplefile/interface/scheduler/expired_members.go
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	svc_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/service/collection"
+)
+
+// expiredMembersCleanupInterval is how often expired collection memberships are removed
+const expiredMembersCleanupInterval = 1 * time.Hour
+
+// RegisterExpiredMembersCleanupJob periodically removes the time-bounded collection memberships which expired,
+// for as long as the application runs.
+func RegisterExpiredMembersCleanupJob(
+	lc fx.Lifecycle,
+	logger *zap.Logger,
+	service svc_collection.RemoveExpiredMembersService,
+) {
+	logger = logger.Named("ExpiredMembersCleanupJob")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				logger.Info("Starting expired collection members cleanup job",
+					zap.Duration("interval", expiredMembersCleanupInterval))
+
+				ticker := time.NewTicker(expiredMembersCleanupInterval)
+				defer ticker.Stop()
+
+				for {
+					if _, err := service.Execute(ctx); err != nil && ctx.Err() == nil {
+						logger.Error("Failed to remove expired collection members", zap.Error(err))
+					}
+
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			logger.Info("Stopping expired collection members cleanup job")
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,14 @@
+// cloud/backend/internal/maplefile/interface/scheduler/module.go
+package scheduler
+
+import (
+	"go.uber.org/fx"
+)
+
+func Module() fx.Option {
+	return fx.Options(
+		fx.Invoke(
+			RegisterExpiredMembersCleanupJob,
+		),
+	)
+}
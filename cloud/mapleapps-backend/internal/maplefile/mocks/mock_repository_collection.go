@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gocql "github.com/gocql/gocql"
 	collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
@@ -114,6 +115,36 @@ func (mr *MockCollectionRepositoryMockRecorder) CheckIfExistsByID(ctx, id any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIfExistsByID", reflect.TypeOf((*MockCollectionRepository)(nil).CheckIfExistsByID), ctx, id)
 }
 
+// CountOwnedCollections mocks base method.
+func (m *MockCollectionRepository) CountOwnedCollections(ctx context.Context, userID gocql.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOwnedCollections", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOwnedCollections indicates an expected call of CountOwnedCollections.
+func (mr *MockCollectionRepositoryMockRecorder) CountOwnedCollections(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOwnedCollections", reflect.TypeOf((*MockCollectionRepository)(nil).CountOwnedCollections), ctx, userID)
+}
+
+// CountSharedCollections mocks base method.
+func (m *MockCollectionRepository) CountSharedCollections(ctx context.Context, userID gocql.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSharedCollections", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSharedCollections indicates an expected call of CountSharedCollections.
+func (mr *MockCollectionRepositoryMockRecorder) CountSharedCollections(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSharedCollections", reflect.TypeOf((*MockCollectionRepository)(nil).CountSharedCollections), ctx, userID)
+}
+
 // Create mocks base method.
 func (m *MockCollectionRepository) Create(ctx context.Context, arg1 *collection.Collection) error {
 	m.ctrl.T.Helper()
@@ -128,6 +159,21 @@ func (mr *MockCollectionRepositoryMockRecorder) Create(ctx, arg1 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCollectionRepository)(nil).Create), ctx, arg1)
 }
 
+// DeduplicateMembers mocks base method.
+func (m *MockCollectionRepository) DeduplicateMembers(ctx context.Context, collectionID gocql.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeduplicateMembers", ctx, collectionID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeduplicateMembers indicates an expected call of DeduplicateMembers.
+func (mr *MockCollectionRepositoryMockRecorder) DeduplicateMembers(ctx, collectionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeduplicateMembers", reflect.TypeOf((*MockCollectionRepository)(nil).DeduplicateMembers), ctx, collectionID)
+}
+
 // FindByParent mocks base method.
 func (m *MockCollectionRepository) FindByParent(ctx context.Context, parentID gocql.UUID) ([]*collection.Collection, error) {
 	m.ctrl.T.Helper()
@@ -203,19 +249,19 @@ func (mr *MockCollectionRepositoryMockRecorder) GetAllByUserID(ctx, ownerID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUserID", reflect.TypeOf((*MockCollectionRepository)(nil).GetAllByUserID), ctx, ownerID)
 }
 
-// GetAllByUserIDAndAnyType mocks base method.
-func (m *MockCollectionRepository) GetAllByUserIDAndAnyType(ctx context.Context, userID gocql.UUID, cursor *collection.CollectionSyncCursor, limit int64) (*collection.CollectionSyncResponse, error) {
+// GetCollectionHistory mocks base method.
+func (m *MockCollectionRepository) GetCollectionHistory(ctx context.Context, collectionID gocql.UUID) ([]*collection.CollectionChange, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllByUserIDAndAnyType", ctx, userID, cursor, limit)
-	ret0, _ := ret[0].(*collection.CollectionSyncResponse)
+	ret := m.ctrl.Call(m, "GetCollectionHistory", ctx, collectionID)
+	ret0, _ := ret[0].([]*collection.CollectionChange)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllByUserIDAndAnyType indicates an expected call of GetAllByUserIDAndAnyType.
-func (mr *MockCollectionRepositoryMockRecorder) GetAllByUserIDAndAnyType(ctx, userID, cursor, limit any) *gomock.Call {
+// GetCollectionHistory indicates an expected call of GetCollectionHistory.
+func (mr *MockCollectionRepositoryMockRecorder) GetCollectionHistory(ctx, collectionID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUserIDAndAnyType", reflect.TypeOf((*MockCollectionRepository)(nil).GetAllByUserIDAndAnyType), ctx, userID, cursor, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionHistory", reflect.TypeOf((*MockCollectionRepository)(nil).GetCollectionHistory), ctx, collectionID)
 }
 
 // GetCollectionMembership mocks base method.
@@ -248,6 +294,21 @@ func (mr *MockCollectionRepositoryMockRecorder) GetCollectionSyncData(ctx, userI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionSyncData", reflect.TypeOf((*MockCollectionRepository)(nil).GetCollectionSyncData), ctx, userID, cursor, limit)
 }
 
+// GetCollectionSyncDataByAccessType mocks base method.
+func (m *MockCollectionRepository) GetCollectionSyncDataByAccessType(ctx context.Context, userID gocql.UUID, cursor *collection.CollectionSyncCursor, limit int64, accessType string) (*collection.CollectionSyncResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectionSyncDataByAccessType", ctx, userID, cursor, limit, accessType)
+	ret0, _ := ret[0].(*collection.CollectionSyncResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectionSyncDataByAccessType indicates an expected call of GetCollectionSyncDataByAccessType.
+func (mr *MockCollectionRepositoryMockRecorder) GetCollectionSyncDataByAccessType(ctx, userID, cursor, limit, accessType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionSyncDataByAccessType", reflect.TypeOf((*MockCollectionRepository)(nil).GetCollectionSyncDataByAccessType), ctx, userID, cursor, limit, accessType)
+}
+
 // GetCollectionsSharedWithUser mocks base method.
 func (m *MockCollectionRepository) GetCollectionsSharedWithUser(ctx context.Context, userID gocql.UUID) ([]*collection.Collection, error) {
 	m.ctrl.T.Helper()
@@ -322,6 +383,37 @@ func (mr *MockCollectionRepositoryMockRecorder) IsCollectionOwner(ctx, collectio
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCollectionOwner", reflect.TypeOf((*MockCollectionRepository)(nil).IsCollectionOwner), ctx, collectionID, userID)
 }
 
+// ListExpiredMembers mocks base method.
+func (m *MockCollectionRepository) ListExpiredMembers(ctx context.Context, expiredBefore time.Time) ([]*collection.CollectionMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredMembers", ctx, expiredBefore)
+	ret0, _ := ret[0].([]*collection.CollectionMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredMembers indicates an expected call of ListExpiredMembers.
+func (mr *MockCollectionRepositoryMockRecorder) ListExpiredMembers(ctx, expiredBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredMembers", reflect.TypeOf((*MockCollectionRepository)(nil).ListExpiredMembers), ctx, expiredBefore)
+}
+
+// ListMembers mocks base method.
+func (m *MockCollectionRepository) ListMembers(ctx context.Context, collectionID gocql.UUID, cursor collection.MemberCursor, limit int) ([]*collection.CollectionMembership, collection.MemberCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", ctx, collectionID, cursor, limit)
+	ret0, _ := ret[0].([]*collection.CollectionMembership)
+	ret1, _ := ret[1].(collection.MemberCursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockCollectionRepositoryMockRecorder) ListMembers(ctx, collectionID, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockCollectionRepository)(nil).ListMembers), ctx, collectionID, cursor, limit)
+}
+
 // MoveCollection mocks base method.
 func (m *MockCollectionRepository) MoveCollection(ctx context.Context, collectionID, newParentID gocql.UUID, updatedAncestors []gocql.UUID, updatedPathSegments []string) error {
 	m.ctrl.T.Helper()
@@ -336,6 +428,21 @@ func (mr *MockCollectionRepositoryMockRecorder) MoveCollection(ctx, collectionID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCollection", reflect.TypeOf((*MockCollectionRepository)(nil).MoveCollection), ctx, collectionID, newParentID, updatedAncestors, updatedPathSegments)
 }
 
+// ReconcileMembers mocks base method.
+func (m *MockCollectionRepository) ReconcileMembers(ctx context.Context, collectionID gocql.UUID) (*collection.MemberReconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileMembers", ctx, collectionID)
+	ret0, _ := ret[0].(*collection.MemberReconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileMembers indicates an expected call of ReconcileMembers.
+func (mr *MockCollectionRepositoryMockRecorder) ReconcileMembers(ctx, collectionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileMembers", reflect.TypeOf((*MockCollectionRepository)(nil).ReconcileMembers), ctx, collectionID)
+}
+
 // RemoveMember mocks base method.
 func (m *MockCollectionRepository) RemoveMember(ctx context.Context, collectionID, recipientID gocql.UUID) error {
 	m.ctrl.T.Helper()
@@ -392,6 +499,20 @@ func (mr *MockCollectionRepositoryMockRecorder) SoftDelete(ctx, id any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDelete", reflect.TypeOf((*MockCollectionRepository)(nil).SoftDelete), ctx, id)
 }
 
+// TransferOwnership mocks base method.
+func (m *MockCollectionRepository) TransferOwnership(ctx context.Context, collectionID, newOwnerID gocql.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferOwnership", ctx, collectionID, newOwnerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferOwnership indicates an expected call of TransferOwnership.
+func (mr *MockCollectionRepositoryMockRecorder) TransferOwnership(ctx, collectionID, newOwnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferOwnership", reflect.TypeOf((*MockCollectionRepository)(nil).TransferOwnership), ctx, collectionID, newOwnerID)
+}
+
 // Update mocks base method.
 func (m *MockCollectionRepository) Update(ctx context.Context, arg1 *collection.Collection) error {
 	m.ctrl.T.Helper()
@@ -419,3 +540,17 @@ func (mr *MockCollectionRepositoryMockRecorder) UpdateMemberPermission(ctx, coll
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberPermission", reflect.TypeOf((*MockCollectionRepository)(nil).UpdateMemberPermission), ctx, collectionID, recipientID, newPermission)
 }
+
+// UpdateMembersPermissions mocks base method.
+func (m *MockCollectionRepository) UpdateMembersPermissions(ctx context.Context, collectionID gocql.UUID, updates map[gocql.UUID]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMembersPermissions", ctx, collectionID, updates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMembersPermissions indicates an expected call of UpdateMembersPermissions.
+func (mr *MockCollectionRepositoryMockRecorder) UpdateMembersPermissions(ctx, collectionID, updates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMembersPermissions", reflect.TypeOf((*MockCollectionRepository)(nil).UpdateMembersPermissions), ctx, collectionID, updates)
+}
@@ -87,6 +87,21 @@ func (mr *MockFileMetadataRepositoryMockRecorder) CheckIfUserHasAccess(fileID, u
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIfUserHasAccess", reflect.TypeOf((*MockFileMetadataRepository)(nil).CheckIfUserHasAccess), fileID, userID)
 }
 
+// CountFilesByUser mocks base method.
+func (m *MockFileMetadataRepository) CountFilesByUser(ctx context.Context, userID gocql.UUID, accessibleCollectionIDs []gocql.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFilesByUser", ctx, userID, accessibleCollectionIDs)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFilesByUser indicates an expected call of CountFilesByUser.
+func (mr *MockFileMetadataRepositoryMockRecorder) CountFilesByUser(ctx, userID, accessibleCollectionIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFilesByUser", reflect.TypeOf((*MockFileMetadataRepository)(nil).CountFilesByUser), ctx, userID, accessibleCollectionIDs)
+}
+
 // Create mocks base method.
 func (m *MockFileMetadataRepository) Create(arg0 *file.File) error {
 	m.ctrl.T.Helper()
@@ -190,6 +205,51 @@ func (mr *MockFileMetadataRepositoryMockRecorder) GetByOwnerID(ownerID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOwnerID", reflect.TypeOf((*MockFileMetadataRepository)(nil).GetByOwnerID), ownerID)
 }
 
+// GetTotalStorageSizeByCollection mocks base method.
+func (m *MockFileMetadataRepository) GetTotalStorageSizeByCollection(ctx context.Context, collectionID gocql.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotalStorageSizeByCollection", ctx, collectionID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTotalStorageSizeByCollection indicates an expected call of GetTotalStorageSizeByCollection.
+func (mr *MockFileMetadataRepositoryMockRecorder) GetTotalStorageSizeByCollection(ctx, collectionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalStorageSizeByCollection", reflect.TypeOf((*MockFileMetadataRepository)(nil).GetTotalStorageSizeByCollection), ctx, collectionID)
+}
+
+// GetTotalStorageSizeByOwner mocks base method.
+func (m *MockFileMetadataRepository) GetTotalStorageSizeByOwner(ctx context.Context, ownerID gocql.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotalStorageSizeByOwner", ctx, ownerID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTotalStorageSizeByOwner indicates an expected call of GetTotalStorageSizeByOwner.
+func (mr *MockFileMetadataRepositoryMockRecorder) GetTotalStorageSizeByOwner(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalStorageSizeByOwner", reflect.TypeOf((*MockFileMetadataRepository)(nil).GetTotalStorageSizeByOwner), ctx, ownerID)
+}
+
+// GetTotalStorageSizeByUser mocks base method.
+func (m *MockFileMetadataRepository) GetTotalStorageSizeByUser(ctx context.Context, userID gocql.UUID, accessibleCollectionIDs []gocql.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotalStorageSizeByUser", ctx, userID, accessibleCollectionIDs)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTotalStorageSizeByUser indicates an expected call of GetTotalStorageSizeByUser.
+func (mr *MockFileMetadataRepositoryMockRecorder) GetTotalStorageSizeByUser(ctx, userID, accessibleCollectionIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalStorageSizeByUser", reflect.TypeOf((*MockFileMetadataRepository)(nil).GetTotalStorageSizeByUser), ctx, userID, accessibleCollectionIDs)
+}
+
 // HardDelete mocks base method.
 func (m *MockFileMetadataRepository) HardDelete(id gocql.UUID) error {
 	m.ctrl.T.Helper()
@@ -5,6 +5,7 @@ import (
 	"go.uber.org/fx"
 
 	iface "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/interface/http"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/interface/scheduler"
 	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/repo"
 	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/service"
 	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/usecase"
@@ -16,5 +17,6 @@ func Module() fx.Option {
 		usecase.Module(),
 		service.Module(),
 		iface.Module(),
+		scheduler.Module(),
 	)
 }
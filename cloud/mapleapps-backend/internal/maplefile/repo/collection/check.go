@@ -4,6 +4,7 @@ package collection
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/gocql/gocql"
 	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
@@ -98,6 +99,15 @@ func (impl *collectionRepositoryImpl) CheckAccess(ctx context.Context, collectio
 		return false, fmt.Errorf("failed to check member access: %w", err)
 	}
 
+	// Time-bounded memberships stop granting access once they expire
+	expired, err := impl.isMembershipExpired(ctx, collectionID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check member access: %w", err)
+	}
+	if expired {
+		return false, nil
+	}
+
 	// Check if user's permission level meets requirement
 	return impl.hasPermission(permissionLevel, requiredPermission), nil
 }
@@ -128,6 +138,14 @@ func (impl *collectionRepositoryImpl) GetUserPermissionLevel(ctx context.Context
 		return "", fmt.Errorf("failed to get permission level: %w", err)
 	}
 
+	expired, err := impl.isMembershipExpired(ctx, collectionID, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get permission level: %w", err)
+	}
+	if expired {
+		return "", nil // Access has expired
+	}
+
 	return permissionLevel, nil
 }
 
@@ -152,9 +170,21 @@ func (impl *collectionRepositoryImpl) CheckAccessByCollectionLookup(ctx context.
 	// Check if user is a member with sufficient permissions
 	for _, member := range collection.Members {
 		if member.RecipientID == userID {
+			if member.IsExpired(time.Now()) {
+				return false, nil
+			}
 			return impl.hasPermission(member.PermissionLevel, requiredPermission), nil
 		}
 	}
 
 	return false, nil // User has no access
 }
+
+// isMembershipExpired reports whether the user has a time-bounded membership of the collection which has expired
+func (impl *collectionRepositoryImpl) isMembershipExpired(ctx context.Context, collectionID, userID gocql.UUID) (bool, error) {
+	membership, err := impl.GetCollectionMembership(ctx, collectionID, userID)
+	if err != nil {
+		return false, err
+	}
+	return membership != nil && membership.IsExpired(time.Now()), nil
+}
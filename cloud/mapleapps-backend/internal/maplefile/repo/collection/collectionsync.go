@@ -53,6 +53,21 @@ func (impl *collectionRepositoryImpl) GetCollectionSyncData(ctx context.Context,
 			continue
 		}
 
+		// Report collections shared through an expired membership as deleted so the device drops its
+		// local copy. The membership is kept for a while after it expires so offline devices see this.
+		if syncItem != nil && accessType == "member" {
+			expired, err := impl.isMembershipExpired(ctx, collectionID, userID)
+			if err != nil {
+				impl.Logger.Warn("failed to check membership expiry for collection",
+					zap.String("collection_id", collectionID.String()),
+					zap.Error(err))
+				continue
+			}
+			if expired {
+				syncItem.State = dom_collection.CollectionStateDeleted
+			}
+		}
+
 		if syncItem != nil {
 			syncItems = append(syncItems, *syncItem)
 			lastModified = modifiedAt
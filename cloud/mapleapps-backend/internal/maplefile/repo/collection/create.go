@@ -153,12 +153,12 @@ func (impl *collectionRepositoryImpl) Create(ctx context.Context, collection *do
 		batch.Query(`INSERT INTO maplefile_collection_members_by_collection_id_and_recipient_id
 			(collection_id, recipient_id, member_id, recipient_email, granted_by_id,
 			 encrypted_collection_key, permission_level, created_at,
-			 is_inherited, inherited_from_id)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			 is_inherited, inherited_from_id, expires_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 			collection.ID, member.RecipientID, member.ID, member.RecipientEmail,
 			member.GrantedByID, member.EncryptedCollectionKey,
 			member.PermissionLevel, member.CreatedAt,
-			member.IsInherited, member.InheritedFromID)
+			member.IsInherited, member.InheritedFromID, member.ExpiresAt)
 
 		// Add member access to BOTH user access tables
 		// Original table: supports all-access-types queries
@@ -174,6 +174,9 @@ func (impl *collectionRepositoryImpl) Create(ctx context.Context, collection *do
 			member.RecipientID, collection.ModifiedAt, collection.ID, member.PermissionLevel, collection.State)
 	}
 
+	// Record the creation in the collection history
+	impl.addChangesToBatch(batch, impl.detectChanges(ctx, nil, collection))
+
 	// Execute batch - this ensures all tables are updated atomically
 	if err := impl.Session.ExecuteBatch(batch.WithContext(ctx)); err != nil {
 		impl.Logger.Error("failed to create collection",
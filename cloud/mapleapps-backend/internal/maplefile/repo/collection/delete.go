@@ -104,6 +104,9 @@ func (impl *collectionRepositoryImpl) HardDelete(ctx context.Context, id gocql.U
 	// 7. Delete from members table
 	batch.Query(`DELETE FROM maplefile_collection_members_by_collection_id_and_recipient_id WHERE collection_id = ?`, id)
 
+	// 8. Delete the change history
+	batch.Query(`DELETE FROM maplefile_collection_changes_by_collection_id_with_desc_changed_at_and_asc_change_id WHERE collection_id = ?`, id)
+
 	// Execute batch - ensures atomic deletion across all tables
 	if err := impl.Session.ExecuteBatch(batch.WithContext(ctx)); err != nil {
 		impl.Logger.Error("failed to hard delete collection from all tables",
@@ -0,0 +1,62 @@
+// cloud/mapleapps-backend/internal/maplefile/repo/collection/expired_members.go
+package collection
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+)
+
+// expiredMembersPageSize is the number of member rows fetched per page while scanning for expired memberships
+const expiredMembersPageSize = 1000
+
+// ListExpiredMembers scans the members table for time-bounded memberships which expired before the given time.
+// There is no index on the expiry, so the whole table is paged through and filtered in memory; this is meant
+// for periodic cleanup rather than request handling.
+func (impl *collectionRepositoryImpl) ListExpiredMembers(ctx context.Context, expiredBefore time.Time) ([]*dom_collection.CollectionMembership, error) {
+	query := `SELECT collection_id, recipient_id, member_id, is_inherited, inherited_from_id, expires_at
+		FROM maplefile_collection_members_by_collection_id_and_recipient_id`
+
+	iter := impl.Session.Query(query).WithContext(ctx).PageSize(expiredMembersPageSize).Iter()
+
+	var (
+		collectionID, recipientID, memberID, inheritedFromID gocql.UUID
+		isInherited                                          bool
+		expiresAt                                            time.Time
+	)
+
+	var expired []*dom_collection.CollectionMembership
+	scanned := 0
+	for iter.Scan(&collectionID, &recipientID, &memberID, &isInherited, &inheritedFromID, &expiresAt) {
+		scanned++
+		if expiresAt.IsZero() || !expiresAt.Before(expiredBefore) {
+			continue
+		}
+
+		expired = append(expired, &dom_collection.CollectionMembership{
+			ID:              memberID,
+			CollectionID:    collectionID,
+			RecipientID:     recipientID,
+			IsInherited:     isInherited,
+			InheritedFromID: inheritedFromID,
+			ExpiresAt:       expiresAt,
+		})
+	}
+
+	if err := iter.Close(); err != nil {
+		impl.Logger.Error("failed to scan for expired collection members", zap.Error(err))
+		return nil, fmt.Errorf("failed to list expired collection members: %w", err)
+	}
+
+	impl.Logger.Debug("scanned for expired collection members",
+		zap.Time("expired_before", expiredBefore),
+		zap.Int("scanned", scanned),
+		zap.Int("expired", len(expired)))
+
+	return expired, nil
+}
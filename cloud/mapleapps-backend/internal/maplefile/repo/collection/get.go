@@ -94,7 +94,7 @@ func (impl *collectionRepositoryImpl) getCollectionMembers(ctx context.Context,
 
 	query := `SELECT recipient_id, member_id, recipient_email, granted_by_id,
 		encrypted_collection_key, permission_level, created_at,
-		is_inherited, inherited_from_id
+		is_inherited, inherited_from_id, expires_at
 		FROM maplefile_collection_members_by_collection_id_and_recipient_id WHERE collection_id = ?`
 
 	iter := impl.Session.Query(query, collectionID).WithContext(ctx).Iter()
@@ -103,13 +103,13 @@ func (impl *collectionRepositoryImpl) getCollectionMembers(ctx context.Context,
 		recipientID, memberID, grantedByID, inheritedFromID gocql.UUID
 		recipientEmail, permissionLevel                     string
 		encryptedCollectionKey                              []byte
-		createdAt                                           time.Time
+		createdAt, expiresAt                                time.Time
 		isInherited                                         bool
 	)
 
 	for iter.Scan(&recipientID, &memberID, &recipientEmail, &grantedByID,
 		&encryptedCollectionKey, &permissionLevel, &createdAt,
-		&isInherited, &inheritedFromID) {
+		&isInherited, &inheritedFromID, &expiresAt) {
 
 		member := dom_collection.CollectionMembership{
 			ID:                     memberID,
@@ -122,6 +122,7 @@ func (impl *collectionRepositoryImpl) getCollectionMembers(ctx context.Context,
 			CreatedAt:              createdAt,
 			IsInherited:            isInherited,
 			InheritedFromID:        inheritedFromID,
+			ExpiresAt:              expiresAt,
 		}
 		members = append(members, member)
 	}
@@ -223,10 +224,11 @@ func (impl *collectionRepositoryImpl) GetCollectionsSharedWithUser(ctx context.C
 		return nil, err
 	}
 
-	// Filter to only active collections
+	// Filter to only active collections the user still has access to
+	now := time.Now()
 	var activeCollections []*dom_collection.Collection
 	for _, collection := range allCollections {
-		if collection.State == dom_collection.CollectionStateActive {
+		if collection.State == dom_collection.CollectionStateActive && !isMembershipExpiredIn(collection, userID, now) {
 			activeCollections = append(activeCollections, collection)
 		}
 	}
@@ -239,6 +241,16 @@ func (impl *collectionRepositoryImpl) GetCollectionsSharedWithUser(ctx context.C
 	return activeCollections, nil
 }
 
+// isMembershipExpiredIn reports whether the membership of the user in the collection has expired
+func isMembershipExpiredIn(collection *dom_collection.Collection, userID gocql.UUID, now time.Time) bool {
+	for _, member := range collection.Members {
+		if member.RecipientID == userID {
+			return member.IsExpired(now)
+		}
+	}
+	return false
+}
+
 // NEW METHOD: Demonstrates querying across all access types when needed
 func (impl *collectionRepositoryImpl) GetAllUserCollections(ctx context.Context, userID gocql.UUID) ([]*dom_collection.Collection, error) {
 	var collectionIDs []gocql.UUID
@@ -352,23 +364,58 @@ func (impl *collectionRepositoryImpl) FindRootCollections(ctx context.Context, o
 	return activeCollections, nil
 }
 
-// No more recursive queries - single efficient query
+// No more recursive queries - single efficient query, bounded by the configured depth and size limits
 func (impl *collectionRepositoryImpl) FindDescendants(ctx context.Context, collectionID gocql.UUID) ([]*dom_collection.Collection, error) {
 	var descendantIDs []gocql.UUID
 
-	query := `SELECT collection_id FROM maplefile_collections_by_ancestor_id_with_asc_depth_and_asc_collection_id
-		WHERE ancestor_id = ?`
+	// Rows come ordered by depth, and one row more than the limit is enough to know it is exceeded.
+	query := `SELECT depth, collection_id FROM maplefile_collections_by_ancestor_id_with_asc_depth_and_asc_collection_id
+		WHERE ancestor_id = ? LIMIT ?`
 
-	iter := impl.Session.Query(query, collectionID).WithContext(ctx).Iter()
+	iter := impl.Session.Query(query, collectionID, impl.MaxHierarchyDescendants+1).WithContext(ctx).Iter()
 
+	var depth int
 	var descendantID gocql.UUID
-	for iter.Scan(&descendantID) {
+	var limitErr error
+	rowCount := 0
+	seen := make(map[gocql.UUID]bool)
+	for iter.Scan(&depth, &descendantID) {
+		rowCount++
+		if rowCount > impl.MaxHierarchyDescendants {
+			limitErr = fmt.Errorf("%w: more than %d descendants", dom_collection.ErrHierarchyLimitExceeded, impl.MaxHierarchyDescendants)
+			break
+		}
+		if depth > impl.MaxHierarchyDepth {
+			limitErr = fmt.Errorf("%w: descendants are deeper than %d levels", dom_collection.ErrHierarchyLimitExceeded, impl.MaxHierarchyDepth)
+			break
+		}
+		if descendantID == collectionID {
+			limitErr = fmt.Errorf("%w: collection is its own descendant", dom_collection.ErrHierarchyLimitExceeded)
+			break
+		}
+		if seen[descendantID] {
+			// Inconsistent ancestor data can list a collection at several depths, load it once.
+			continue
+		}
+		seen[descendantID] = true
 		descendantIDs = append(descendantIDs, descendantID)
 	}
 
 	if err := iter.Close(); err != nil {
 		return nil, fmt.Errorf("failed to find descendants: %w", err)
 	}
+	if limitErr != nil {
+		impl.Logger.Warn("collection hierarchy traversal stopped",
+			zap.String("collection_id", collectionID.String()),
+			zap.Int("descendants_count", len(descendantIDs)),
+			zap.Error(limitErr))
+		return nil, limitErr
+	}
+
+	impl.Logger.Debug("collection hierarchy traversed",
+		zap.String("collection_id", collectionID.String()),
+		zap.Int("descendants_count", len(descendantIDs)),
+		zap.Int("depth", depth))
 
 	// Load collections and filter by state in memory
 	allCollections, err := impl.loadMultipleCollectionsWithMembers(ctx, descendantIDs)
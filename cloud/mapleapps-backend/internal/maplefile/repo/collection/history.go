@@ -0,0 +1,142 @@
+// cloud/mapleapps-backend/internal/maplefile/repo/collection/history.go
+package collection
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+)
+
+// maxCollectionHistoryEntries bounds how many of the most recent changes are returned for a collection
+const maxCollectionHistoryEntries = 1000
+
+// detectChanges compares a collection before and after a write and returns the history entries
+// describing the difference, a nil `existing` collection records its creation.
+func (impl *collectionRepositoryImpl) detectChanges(ctx context.Context, existing, updated *dom_collection.Collection) []*dom_collection.CollectionChange {
+	changedBy := impl.changedByUserID(ctx, updated)
+	newChange := func(changeType string, subjectID gocql.UUID) *dom_collection.CollectionChange {
+		return &dom_collection.CollectionChange{
+			ID:              gocql.TimeUUID(),
+			CollectionID:    updated.ID,
+			Version:         updated.Version,
+			ChangedAt:       updated.ModifiedAt,
+			ChangedByUserID: changedBy,
+			ChangeType:      changeType,
+			SubjectID:       subjectID,
+		}
+	}
+
+	if existing == nil {
+		return []*dom_collection.CollectionChange{newChange(dom_collection.CollectionChangeCreated, gocql.UUID{})}
+	}
+
+	var changes []*dom_collection.CollectionChange
+	// The name is encrypted with the collection key, so rotating the key re-encrypts the name without
+	// renaming the collection.
+	if collectionKeyVersion(updated) > collectionKeyVersion(existing) {
+		changes = append(changes, newChange(dom_collection.CollectionChangeKeyRotated, gocql.UUID{}))
+	} else if existing.EncryptedName != updated.EncryptedName {
+		changes = append(changes, newChange(dom_collection.CollectionChangeRenamed, gocql.UUID{}))
+	}
+	if existing.ParentID != updated.ParentID {
+		changes = append(changes, newChange(dom_collection.CollectionChangeMoved, updated.ParentID))
+	}
+	if existing.OwnerID != updated.OwnerID {
+		changes = append(changes, newChange(dom_collection.CollectionChangeOwnershipTransferred, updated.OwnerID))
+	}
+	if existing.State != dom_collection.CollectionStateDeleted && updated.State == dom_collection.CollectionStateDeleted {
+		changes = append(changes, newChange(dom_collection.CollectionChangeDeleted, gocql.UUID{}))
+	}
+
+	existingRecipients := make(map[gocql.UUID]bool, len(existing.Members))
+	for _, member := range existing.Members {
+		existingRecipients[member.RecipientID] = true
+	}
+	updatedRecipients := make(map[gocql.UUID]bool, len(updated.Members))
+	for _, member := range updated.Members {
+		updatedRecipients[member.RecipientID] = true
+		if !existingRecipients[member.RecipientID] {
+			changes = append(changes, newChange(dom_collection.CollectionChangeMemberAdded, member.RecipientID))
+		}
+	}
+	for _, member := range existing.Members {
+		if !updatedRecipients[member.RecipientID] {
+			changes = append(changes, newChange(dom_collection.CollectionChangeMemberRemoved, member.RecipientID))
+		}
+	}
+
+	return changes
+}
+
+// collectionKeyVersion returns the version of the collection key, zero if the collection has no key
+func collectionKeyVersion(collection *dom_collection.Collection) int {
+	if collection.EncryptedCollectionKey == nil {
+		return 0
+	}
+	return collection.EncryptedCollectionKey.KeyVersion
+}
+
+// changedByUserID returns the user of the request making the change, falling back to the last
+// modifier recorded on the collection for writes made outside of a request, e.g. by background jobs.
+func (impl *collectionRepositoryImpl) changedByUserID(ctx context.Context, collection *dom_collection.Collection) gocql.UUID {
+	if userID, ok := ctx.Value(constants.SessionFederatedUserID).(gocql.UUID); ok {
+		return userID
+	}
+	return collection.ModifiedByUserID
+}
+
+// addChangesToBatch appends the history entries to the batch so they are persisted together with the change
+func (impl *collectionRepositoryImpl) addChangesToBatch(batch *gocql.Batch, changes []*dom_collection.CollectionChange) {
+	for _, change := range changes {
+		batch.Query(`INSERT INTO maplefile_collection_changes_by_collection_id_with_desc_changed_at_and_asc_change_id
+			(collection_id, changed_at, change_id, version, changed_by_user_id, change_type, subject_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			change.CollectionID, change.ChangedAt, change.ID, change.Version,
+			change.ChangedByUserID, change.ChangeType, change.SubjectID)
+	}
+}
+
+// GetCollectionHistory returns up to `maxCollectionHistoryEntries` of the most recent changes of a
+// collection, most recent first.
+func (impl *collectionRepositoryImpl) GetCollectionHistory(ctx context.Context, collectionID gocql.UUID) ([]*dom_collection.CollectionChange, error) {
+	query := `SELECT changed_at, change_id, version, changed_by_user_id, change_type, subject_id
+		FROM maplefile_collection_changes_by_collection_id_with_desc_changed_at_and_asc_change_id
+		WHERE collection_id = ? LIMIT ?`
+
+	iter := impl.Session.Query(query, collectionID, maxCollectionHistoryEntries).WithContext(ctx).Iter()
+
+	var (
+		changedAt                            time.Time
+		changeID, changedByUserID, subjectID gocql.UUID
+		version                              uint64
+		changeType                           string
+	)
+
+	var changes []*dom_collection.CollectionChange
+	for iter.Scan(&changedAt, &changeID, &version, &changedByUserID, &changeType, &subjectID) {
+		changes = append(changes, &dom_collection.CollectionChange{
+			ID:              changeID,
+			CollectionID:    collectionID,
+			Version:         version,
+			ChangedAt:       changedAt,
+			ChangedByUserID: changedByUserID,
+			ChangeType:      changeType,
+			SubjectID:       subjectID,
+		})
+	}
+
+	if err := iter.Close(); err != nil {
+		impl.Logger.Error("failed to get collection history",
+			zap.String("collection_id", collectionID.String()),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to get collection history: %w", err)
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,51 @@
+// internal/maplefile/repo/collection/history_test.go
+package collection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/domain/keys"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+)
+
+func TestDetectChanges_NameAndKeyRotation(t *testing.T) {
+	impl := &collectionRepositoryImpl{}
+	existing := &dom_collection.Collection{
+		ID:                     gocql.TimeUUID(),
+		EncryptedName:          "name encrypted with key v1",
+		EncryptedCollectionKey: &keys.EncryptedCollectionKey{KeyVersion: 1},
+		Version:                3,
+		ModifiedAt:             time.Now(),
+	}
+
+	tests := []struct {
+		name          string
+		encryptedName string
+		keyVersion    int
+		want          []string
+	}{
+		{name: "renamed", encryptedName: "new name encrypted with key v1", keyVersion: 1, want: []string{dom_collection.CollectionChangeRenamed}},
+		{name: "key rotation re-encrypts the name", encryptedName: "name encrypted with key v2", keyVersion: 2, want: []string{dom_collection.CollectionChangeKeyRotated}},
+		{name: "unchanged", encryptedName: existing.EncryptedName, keyVersion: 1, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := *existing
+			updated.EncryptedName = tt.encryptedName
+			updated.EncryptedCollectionKey = &keys.EncryptedCollectionKey{KeyVersion: tt.keyVersion}
+			updated.Version = existing.Version + 1
+
+			var got []string
+			for _, change := range impl.detectChanges(context.Background(), existing, &updated) {
+				got = append(got, change.ChangeType)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -13,16 +13,22 @@ import (
 )
 
 type collectionRepositoryImpl struct {
-	Logger  *zap.Logger
-	Session *gocql.Session
+	Logger                  *zap.Logger
+	Session                 *gocql.Session
+	MaxHierarchyDepth       int
+	MaxHierarchyDescendants int
+	MaxCollectionMembers    int
 }
 
 func NewRepository(appCfg *config.Configuration, session *gocql.Session, loggerp *zap.Logger) dom_collection.CollectionRepository {
 	loggerp = loggerp.Named("CollectionRepository")
 
 	return &collectionRepositoryImpl{
-		Logger:  loggerp,
-		Session: session,
+		Logger:                  loggerp,
+		Session:                 session,
+		MaxHierarchyDepth:       appCfg.MapleFile.MaxHierarchyDepth,
+		MaxHierarchyDescendants: appCfg.MapleFile.MaxHierarchyDescendants,
+		MaxCollectionMembers:    appCfg.MapleFile.MaxCollectionMembers,
 	}
 }
 
@@ -0,0 +1,38 @@
+// internal/maplefile/repo/collection/impl_test.go
+package collection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/domain/keys"
+)
+
+func TestEncryptedCollectionKeyRoundTrip(t *testing.T) {
+	impl := &collectionRepositoryImpl{}
+	rotatedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	key := &keys.EncryptedCollectionKey{
+		Ciphertext: []byte{0xfb, 0xff, 0x01},
+		Nonce:      []byte{0xfe, 0x02},
+		KeyVersion: 2,
+		RotatedAt:  &rotatedAt,
+		PreviousKeys: []keys.EncryptedHistoricalKey{
+			{KeyVersion: 1, Ciphertext: []byte{1, 2}, Nonce: []byte{3, 4}, RotatedAt: rotatedAt.AddDate(0, -9, 0), Algorithm: "chacha20poly1305"},
+		},
+	}
+
+	data, err := impl.serializeEncryptedCollectionKey(key)
+	require.NoError(t, err)
+	got, err := impl.deserializeEncryptedCollectionKey(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, key.Ciphertext, got.Ciphertext)
+	assert.Equal(t, key.Nonce, got.Nonce)
+	assert.Equal(t, key.KeyVersion, got.KeyVersion)
+	require.NotNil(t, got.RotatedAt)
+	assert.True(t, key.RotatedAt.Equal(*got.RotatedAt))
+	assert.Equal(t, key.PreviousKeys, got.PreviousKeys)
+}
@@ -0,0 +1,112 @@
+// cloud/mapleapps-backend/internal/maplefile/repo/collection/list_members.go
+package collection
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+)
+
+const (
+	defaultListMembersLimit = 50
+	maxListMembersLimit     = 1000
+)
+
+// ListMembers pages through the members table of a collection ordered by recipient ID. It returns the
+// next cursor to continue from, which is the zero value once the last page has been returned.
+func (impl *collectionRepositoryImpl) ListMembers(
+	ctx context.Context,
+	collectionID gocql.UUID,
+	cursor dom_collection.MemberCursor,
+	limit int,
+) ([]*dom_collection.CollectionMembership, dom_collection.MemberCursor, error) {
+	if limit <= 0 {
+		limit = defaultListMembersLimit
+	}
+	if limit > maxListMembersLimit {
+		limit = maxListMembersLimit
+	}
+
+	var query string
+	var args []any
+
+	// Fetch one extra row so we know whether another page exists.
+	if cursor.IsZero() {
+		query = `SELECT recipient_id, member_id, recipient_email, granted_by_id,
+			encrypted_collection_key, permission_level, created_at,
+			is_inherited, inherited_from_id, expires_at
+			FROM maplefile_collection_members_by_collection_id_and_recipient_id
+			WHERE collection_id = ? LIMIT ?`
+		args = []any{collectionID, limit + 1}
+	} else {
+		query = `SELECT recipient_id, member_id, recipient_email, granted_by_id,
+			encrypted_collection_key, permission_level, created_at,
+			is_inherited, inherited_from_id, expires_at
+			FROM maplefile_collection_members_by_collection_id_and_recipient_id
+			WHERE collection_id = ? AND recipient_id > ? LIMIT ?`
+		args = []any{collectionID, cursor.LastRecipientID, limit + 1}
+	}
+
+	iter := impl.Session.Query(query, args...).WithContext(ctx).Iter()
+
+	var (
+		recipientID, memberID, grantedByID, inheritedFromID gocql.UUID
+		recipientEmail, permissionLevel                     string
+		encryptedCollectionKey                              []byte
+		createdAt, expiresAt                                time.Time
+		isInherited                                         bool
+	)
+
+	members := make([]*dom_collection.CollectionMembership, 0, limit)
+	hasMore := false
+	for iter.Scan(&recipientID, &memberID, &recipientEmail, &grantedByID,
+		&encryptedCollectionKey, &permissionLevel, &createdAt,
+		&isInherited, &inheritedFromID, &expiresAt) {
+
+		if len(members) == limit {
+			hasMore = true
+			break
+		}
+
+		members = append(members, &dom_collection.CollectionMembership{
+			ID:                     memberID,
+			CollectionID:           collectionID,
+			RecipientID:            recipientID,
+			RecipientEmail:         recipientEmail,
+			GrantedByID:            grantedByID,
+			EncryptedCollectionKey: encryptedCollectionKey,
+			PermissionLevel:        permissionLevel,
+			CreatedAt:              createdAt,
+			IsInherited:            isInherited,
+			InheritedFromID:        inheritedFromID,
+			ExpiresAt:              expiresAt,
+		})
+
+		// Scan reuses the slice, so give the next row its own buffer.
+		encryptedCollectionKey = nil
+	}
+
+	if err := iter.Close(); err != nil {
+		impl.Logger.Error("failed to list collection members",
+			zap.String("collection_id", collectionID.String()),
+			zap.Error(err))
+		return nil, dom_collection.MemberCursor{}, fmt.Errorf("failed to list collection members: %w", err)
+	}
+
+	var nextCursor dom_collection.MemberCursor
+	if hasMore {
+		nextCursor.LastRecipientID = members[len(members)-1].RecipientID
+	}
+
+	impl.Logger.Debug("listed collection members page",
+		zap.String("collection_id", collectionID.String()),
+		zap.Int("count", len(members)),
+		zap.Bool("has_more", hasMore))
+
+	return members, nextCursor, nil
+}
@@ -0,0 +1,196 @@
+// cloud/mapleapps-backend/internal/maplefile/repo/collection/reconcile.go
+package collection
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+)
+
+const (
+	userAccessTable            = "maplefile_collections_by_user_id_with_desc_modified_at_and_asc_collection_id"
+	userAccessByTypeTable      = "maplefile_collections_by_user_id_and_access_type_with_desc_modified_at_and_asc_collection_id"
+	userAccessTableQuery       = `SELECT modified_at, collection_id, access_type, permission_level, state FROM maplefile_collections_by_user_id_with_desc_modified_at_and_asc_collection_id WHERE user_id = ?`
+	userAccessByTypeTableQuery = `SELECT modified_at, collection_id, access_type, permission_level, state FROM maplefile_collections_by_user_id_and_access_type_with_desc_modified_at_and_asc_collection_id WHERE user_id = ? AND access_type = 'member'`
+)
+
+// userAccessRow is a row of one of the user access tables
+type userAccessRow struct {
+	modifiedAt      time.Time
+	accessType      string
+	permissionLevel string
+	state           string
+}
+
+// ReconcileMembers compares the user access rows of every member of the collection to the members table.
+// The members table is authoritative, it is what access checks read, while the user access tables drive
+// the listings and sync of shared collections. Every member must have a single access row in each table,
+// keyed by the modification time of the collection and matching its permission level and state. Missing
+// and stale rows are written again and rows keyed by an outdated modification time are deleted, all in a
+// single batch.
+//
+// The user access tables are partitioned by user, so rows left behind for users who are no longer
+// members can't be found from the collection and aren't reported.
+func (impl *collectionRepositoryImpl) ReconcileMembers(ctx context.Context, collectionID gocql.UUID) (*dom_collection.MemberReconciliation, error) {
+	collection, err := impl.Get(ctx, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection: %w", err)
+	}
+
+	if collection == nil {
+		return nil, fmt.Errorf("collection not found")
+	}
+
+	result := &dom_collection.MemberReconciliation{
+		CollectionID:  collectionID,
+		Discrepancies: []*dom_collection.MemberDiscrepancy{},
+	}
+	batch := impl.Session.NewBatch(gocql.LoggedBatch)
+
+	for _, member := range collection.Members {
+		// The owner's access rows are written with the owner access type.
+		if member.RecipientID == collection.OwnerID {
+			continue
+		}
+		result.MembersChecked++
+
+		for _, table := range []struct{ name, query string }{
+			{userAccessTable, userAccessTableQuery},
+			{userAccessByTypeTable, userAccessByTypeTableQuery},
+		} {
+			rows, err := impl.findUserAccessRows(ctx, table.query, member.RecipientID, collectionID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s for recipient %s: %w", table.name, member.RecipientID.String(), err)
+			}
+
+			discrepancies, rewrite, orphaned := compareMemberAccessRows(collection, &member, table.name, rows)
+			result.Discrepancies = append(result.Discrepancies, discrepancies...)
+			for _, modifiedAt := range orphaned {
+				impl.addDeleteUserAccessRow(batch, table.name, member.RecipientID, modifiedAt, collectionID)
+			}
+			if rewrite {
+				impl.addInsertMemberAccessRow(batch, table.name, collection, &member)
+			}
+		}
+	}
+
+	if len(result.Discrepancies) == 0 {
+		impl.Logger.Debug("collection members are consistent",
+			zap.String("collection_id", collectionID.String()),
+			zap.Int("members_checked", result.MembersChecked))
+		return result, nil
+	}
+
+	impl.Logger.Warn("collection members drifted from the user access tables, repairing",
+		zap.String("collection_id", collectionID.String()),
+		zap.Int("members_checked", result.MembersChecked),
+		zap.Int("discrepancies", len(result.Discrepancies)))
+
+	if err := impl.Session.ExecuteBatch(batch.WithContext(ctx)); err != nil {
+		impl.Logger.Error("failed to repair collection members",
+			zap.String("collection_id", collectionID.String()),
+			zap.Error(err))
+		return result, fmt.Errorf("failed to repair collection members: %w", err)
+	}
+	result.Repaired = true
+
+	impl.Logger.Info("repaired collection members",
+		zap.String("collection_id", collectionID.String()),
+		zap.Int("discrepancies", len(result.Discrepancies)))
+
+	return result, nil
+}
+
+// compareMemberAccessRows compares a member's rows of a user access table to the members table. It
+// reports the discrepancies, whether the member's current access row has to be written again and the
+// modification times of the rows to delete.
+func compareMemberAccessRows(
+	collection *dom_collection.Collection,
+	member *dom_collection.CollectionMembership,
+	table string,
+	rows []userAccessRow,
+) (discrepancies []*dom_collection.MemberDiscrepancy, rewrite bool, orphaned []time.Time) {
+	current := false
+	for _, row := range rows {
+		if !row.modifiedAt.Equal(collection.ModifiedAt) {
+			discrepancies = append(discrepancies, &dom_collection.MemberDiscrepancy{
+				RecipientID: member.RecipientID,
+				Kind:        dom_collection.MemberDiscrepancyOrphanedAccess,
+				Table:       table,
+				ModifiedAt:  row.modifiedAt,
+			})
+			orphaned = append(orphaned, row.modifiedAt)
+			continue
+		}
+
+		current = true
+		if row.accessType != dom_collection.CollectionAccessTypeMember ||
+			row.permissionLevel != member.PermissionLevel ||
+			row.state != collection.State {
+			discrepancies = append(discrepancies, &dom_collection.MemberDiscrepancy{
+				RecipientID: member.RecipientID,
+				Kind:        dom_collection.MemberDiscrepancyStaleAccess,
+				Table:       table,
+				ModifiedAt:  row.modifiedAt,
+			})
+			rewrite = true
+		}
+	}
+
+	if !current {
+		discrepancies = append(discrepancies, &dom_collection.MemberDiscrepancy{
+			RecipientID: member.RecipientID,
+			Kind:        dom_collection.MemberDiscrepancyMissingAccess,
+			Table:       table,
+			ModifiedAt:  collection.ModifiedAt,
+		})
+		rewrite = true
+	}
+
+	return discrepancies, rewrite, orphaned
+}
+
+// findUserAccessRows returns the rows of a user access table which give the user access to the collection.
+// The tables are clustered by modification time first, so the user's whole partition is read.
+func (impl *collectionRepositoryImpl) findUserAccessRows(ctx context.Context, query string, userID, collectionID gocql.UUID) ([]userAccessRow, error) {
+	iter := impl.Session.Query(query, userID).WithContext(ctx).Iter()
+
+	var (
+		row           userAccessRow
+		rowCollection gocql.UUID
+		rows          []userAccessRow
+	)
+	for iter.Scan(&row.modifiedAt, &rowCollection, &row.accessType, &row.permissionLevel, &row.state) {
+		if rowCollection == collectionID {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, iter.Close()
+}
+
+// addInsertMemberAccessRow adds the write of a member's current access row of a user access table to the batch
+func (impl *collectionRepositoryImpl) addInsertMemberAccessRow(batch *gocql.Batch, table string, collection *dom_collection.Collection, member *dom_collection.CollectionMembership) {
+	batch.Query(`INSERT INTO `+table+`
+		(user_id, access_type, modified_at, collection_id, permission_level, state)
+		VALUES (?, 'member', ?, ?, ?, ?)`,
+		member.RecipientID, collection.ModifiedAt, collection.ID, member.PermissionLevel, collection.State)
+}
+
+// addDeleteUserAccessRow adds the deletion of a member's access row of a user access table to the batch
+func (impl *collectionRepositoryImpl) addDeleteUserAccessRow(batch *gocql.Batch, table string, userID gocql.UUID, modifiedAt time.Time, collectionID gocql.UUID) {
+	if table == userAccessByTypeTable {
+		batch.Query(`DELETE FROM `+table+`
+			WHERE user_id = ? AND access_type = 'member' AND modified_at = ? AND collection_id = ?`,
+			userID, modifiedAt, collectionID)
+		return
+	}
+	batch.Query(`DELETE FROM `+table+`
+		WHERE user_id = ? AND modified_at = ? AND collection_id = ?`,
+		userID, modifiedAt, collectionID)
+}
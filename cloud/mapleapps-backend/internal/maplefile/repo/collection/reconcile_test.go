@@ -0,0 +1,109 @@
+// internal/maplefile/repo/collection/reconcile_test.go
+package collection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+)
+
+func TestCompareMemberAccessRows(t *testing.T) {
+	modifiedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	outdated := modifiedAt.Add(-time.Hour)
+	collection := &dom_collection.Collection{
+		ID:         gocql.TimeUUID(),
+		OwnerID:    gocql.TimeUUID(),
+		ModifiedAt: modifiedAt,
+		State:      dom_collection.CollectionStateActive,
+	}
+	member := &dom_collection.CollectionMembership{
+		RecipientID:     gocql.TimeUUID(),
+		PermissionLevel: dom_collection.CollectionPermissionReadWrite,
+	}
+	currentRow := func() userAccessRow {
+		return userAccessRow{
+			modifiedAt:      modifiedAt,
+			accessType:      dom_collection.CollectionAccessTypeMember,
+			permissionLevel: dom_collection.CollectionPermissionReadWrite,
+			state:           dom_collection.CollectionStateActive,
+		}
+	}
+	kinds := func(discrepancies []*dom_collection.MemberDiscrepancy) []string {
+		result := []string{}
+		for _, discrepancy := range discrepancies {
+			assert.Equal(t, member.RecipientID, discrepancy.RecipientID)
+			assert.Equal(t, userAccessTable, discrepancy.Table)
+			result = append(result, discrepancy.Kind)
+		}
+		return result
+	}
+
+	t.Run("Consistent access row", func(t *testing.T) {
+		discrepancies, rewrite, orphaned := compareMemberAccessRows(collection, member, userAccessTable, []userAccessRow{currentRow()})
+
+		assert.Empty(t, discrepancies)
+		assert.False(t, rewrite)
+		assert.Empty(t, orphaned)
+	})
+
+	t.Run("Missing access row", func(t *testing.T) {
+		discrepancies, rewrite, orphaned := compareMemberAccessRows(collection, member, userAccessTable, nil)
+
+		assert.Equal(t, []string{dom_collection.MemberDiscrepancyMissingAccess}, kinds(discrepancies))
+		assert.Equal(t, modifiedAt, discrepancies[0].ModifiedAt)
+		assert.True(t, rewrite)
+		assert.Empty(t, orphaned)
+	})
+
+	t.Run("Stale permission level", func(t *testing.T) {
+		row := currentRow()
+		row.permissionLevel = dom_collection.CollectionPermissionReadOnly
+
+		discrepancies, rewrite, orphaned := compareMemberAccessRows(collection, member, userAccessTable, []userAccessRow{row})
+
+		assert.Equal(t, []string{dom_collection.MemberDiscrepancyStaleAccess}, kinds(discrepancies))
+		assert.True(t, rewrite)
+		assert.Empty(t, orphaned)
+	})
+
+	t.Run("Stale state and access type", func(t *testing.T) {
+		row := currentRow()
+		row.state = dom_collection.CollectionStateArchived
+		row.accessType = dom_collection.CollectionAccessTypeOwner
+
+		discrepancies, rewrite, _ := compareMemberAccessRows(collection, member, userAccessTable, []userAccessRow{row})
+
+		assert.Equal(t, []string{dom_collection.MemberDiscrepancyStaleAccess}, kinds(discrepancies))
+		assert.True(t, rewrite)
+	})
+
+	t.Run("Row keyed by an outdated modification time", func(t *testing.T) {
+		row := currentRow()
+		row.modifiedAt = outdated
+
+		discrepancies, rewrite, orphaned := compareMemberAccessRows(collection, member, userAccessTable, []userAccessRow{row})
+
+		// The outdated row is deleted and the current one written in its place.
+		assert.Equal(t, []string{
+			dom_collection.MemberDiscrepancyOrphanedAccess,
+			dom_collection.MemberDiscrepancyMissingAccess,
+		}, kinds(discrepancies))
+		assert.True(t, rewrite)
+		assert.Equal(t, []time.Time{outdated}, orphaned)
+	})
+
+	t.Run("Outdated row next to the current one", func(t *testing.T) {
+		row := currentRow()
+		row.modifiedAt = outdated
+
+		discrepancies, rewrite, orphaned := compareMemberAccessRows(collection, member, userAccessTable, []userAccessRow{row, currentRow()})
+
+		assert.Equal(t, []string{dom_collection.MemberDiscrepancyOrphanedAccess}, kinds(discrepancies))
+		assert.False(t, rewrite)
+		assert.Equal(t, []time.Time{outdated}, orphaned)
+	})
+}
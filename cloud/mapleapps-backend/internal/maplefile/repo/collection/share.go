@@ -4,6 +4,8 @@ package collection
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gocql/gocql"
@@ -89,37 +91,8 @@ func (impl *collectionRepositoryImpl) AddMember(ctx context.Context, collectionI
 	// Set collection ID (ensure it matches)
 	membership.CollectionID = collectionID
 
-	// Check if member already exists and update or add
-	memberExists := false
-	for i, existingMember := range collection.Members {
-		if existingMember.RecipientID == membership.RecipientID {
-			impl.Logger.Info("updating existing collection member",
-				zap.String("collection_id", collectionID.String()),
-				zap.String("recipient_id", membership.RecipientID.String()),
-				zap.String("old_permission", existingMember.PermissionLevel),
-				zap.String("new_permission", membership.PermissionLevel))
-
-			// IMPORTANT: Preserve the existing member ID to avoid creating a new one
-			membership.ID = existingMember.ID
-			collection.Members[i] = *membership
-			memberExists = true
-			break
-		}
-	}
-
-	if !memberExists {
-		impl.Logger.Info("adding new collection member",
-			zap.String("collection_id", collectionID.String()),
-			zap.String("recipient_id", membership.RecipientID.String()),
-			zap.String("permission_level", membership.PermissionLevel))
-
-		collection.Members = append(collection.Members, *membership)
-
-		impl.Logger.Info("DEBUGGING: Member added to collection.Members slice",
-			zap.String("collection_id", collectionID.String()),
-			zap.String("new_member_id", membership.ID.String()),
-			zap.String("recipient_id", membership.RecipientID.String()),
-			zap.Int("total_members_now", len(collection.Members)))
+	if err := impl.putMember(collection, membership); err != nil {
+		return err
 	}
 
 	// Update version
@@ -201,14 +174,14 @@ func (impl *collectionRepositoryImpl) testDirectMemberInsert(ctx context.Context
 	query := `INSERT INTO maplefile_collection_members_by_collection_id_and_recipient_id
 		(collection_id, recipient_id, member_id, recipient_email, granted_by_id,
 		 encrypted_collection_key, permission_level, created_at,
-		 is_inherited, inherited_from_id)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		 is_inherited, inherited_from_id, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	err := impl.Session.Query(query,
 		collectionID, membership.RecipientID, membership.ID, membership.RecipientEmail,
 		membership.GrantedByID, membership.EncryptedCollectionKey,
 		membership.PermissionLevel, membership.CreatedAt,
-		membership.IsInherited, membership.InheritedFromID).WithContext(ctx).Exec()
+		membership.IsInherited, membership.InheritedFromID, membership.ExpiresAt).WithContext(ctx).Exec()
 
 	if err != nil {
 		impl.Logger.Error("DEBUGGING: Direct insert failed",
@@ -300,6 +273,140 @@ func (impl *collectionRepositoryImpl) RemoveMember(ctx context.Context, collecti
 	return impl.Update(ctx, collection)
 }
 
+// putMember adds the membership to the collection, or replaces the recipient's existing membership.
+// Duplicate memberships are removed first, so the recipient's membership replaced is the one kept and
+// saving the collection persists the deduplicated members.
+func (impl *collectionRepositoryImpl) putMember(collection *dom_collection.Collection, membership *dom_collection.CollectionMembership) error {
+	if removed := impl.removeDuplicateMembers(collection); removed > 0 {
+		impl.Logger.Info("deduplicated collection members before adding member",
+			zap.String("collection_id", collection.ID.String()),
+			zap.Int("removed", removed))
+	}
+
+	// Check if member already exists and update or add
+	memberExists := false
+	for i, existingMember := range collection.Members {
+		if existingMember.RecipientID == membership.RecipientID {
+			impl.Logger.Info("updating existing collection member",
+				zap.String("collection_id", collection.ID.String()),
+				zap.String("recipient_id", membership.RecipientID.String()),
+				zap.String("old_permission", existingMember.PermissionLevel),
+				zap.String("new_permission", membership.PermissionLevel))
+
+			// IMPORTANT: Preserve the existing member ID to avoid creating a new one
+			membership.ID = existingMember.ID
+			collection.Members[i] = *membership
+			memberExists = true
+			break
+		}
+	}
+
+	if !memberExists {
+		// Updating an existing member is always allowed, only new members count against the limit
+		if impl.MaxCollectionMembers > 0 && len(collection.Members) >= impl.MaxCollectionMembers {
+			impl.Logger.Warn("refusing to add member, collection has reached the member limit",
+				zap.String("collection_id", collection.ID.String()),
+				zap.Int("members", len(collection.Members)),
+				zap.Int("max_members", impl.MaxCollectionMembers))
+			return fmt.Errorf("%w: collection already has %d members", dom_collection.ErrMemberLimitExceeded, len(collection.Members))
+		}
+
+		impl.Logger.Info("adding new collection member",
+			zap.String("collection_id", collection.ID.String()),
+			zap.String("recipient_id", membership.RecipientID.String()),
+			zap.String("permission_level", membership.PermissionLevel))
+
+		collection.Members = append(collection.Members, *membership)
+
+		impl.Logger.Info("DEBUGGING: Member added to collection.Members slice",
+			zap.String("collection_id", collection.ID.String()),
+			zap.String("new_member_id", membership.ID.String()),
+			zap.String("recipient_id", membership.RecipientID.String()),
+			zap.Int("total_members_now", len(collection.Members)))
+	}
+
+	return nil
+}
+
+// DeduplicateMembers detects recipients which appear in more than one membership of the collection,
+// keeps the most recently created membership for each of them and removes the rest.
+func (impl *collectionRepositoryImpl) DeduplicateMembers(ctx context.Context, collectionID gocql.UUID) (int, error) {
+	collection, err := impl.Get(ctx, collectionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get collection: %w", err)
+	}
+
+	if collection == nil {
+		return 0, fmt.Errorf("collection not found")
+	}
+
+	removed := impl.removeDuplicateMembers(collection)
+	if removed == 0 {
+		return 0, nil
+	}
+
+	collection.Version++
+	collection.ModifiedAt = time.Now()
+
+	if err := impl.Update(ctx, collection); err != nil {
+		return 0, fmt.Errorf("failed to update collection: %w", err)
+	}
+
+	impl.Logger.Info("deduplicated collection members",
+		zap.String("collection_id", collectionID.String()),
+		zap.Int("removed", removed),
+		zap.Int("remaining", len(collection.Members)))
+
+	return removed, nil
+}
+
+// removeDuplicateMembers keeps the most recently created membership of every recipient of the
+// collection, in the original member order, and returns how many memberships were removed.
+func (impl *collectionRepositoryImpl) removeDuplicateMembers(collection *dom_collection.Collection) int {
+	if len(findDuplicateRecipients(collection.Members)) == 0 {
+		return 0
+	}
+
+	latest := make(map[gocql.UUID]int, len(collection.Members))
+	for i, member := range collection.Members {
+		if j, ok := latest[member.RecipientID]; !ok || member.CreatedAt.After(collection.Members[j].CreatedAt) {
+			latest[member.RecipientID] = i
+		}
+	}
+
+	dedupedMembers := make([]dom_collection.CollectionMembership, 0, len(latest))
+	for i, member := range collection.Members {
+		if latest[member.RecipientID] == i {
+			dedupedMembers = append(dedupedMembers, member)
+			continue
+		}
+
+		impl.Logger.Warn("removing duplicate collection membership",
+			zap.String("collection_id", collection.ID.String()),
+			zap.String("recipient_id", member.RecipientID.String()),
+			zap.String("removed_member_id", member.ID.String()),
+			zap.String("kept_member_id", collection.Members[latest[member.RecipientID]].ID.String()),
+			zap.Time("removed_created_at", member.CreatedAt))
+	}
+
+	removed := len(collection.Members) - len(dedupedMembers)
+	collection.Members = dedupedMembers
+	return removed
+}
+
+// findDuplicateRecipients returns the recipient IDs which appear in more than one membership
+func findDuplicateRecipients(members []dom_collection.CollectionMembership) []gocql.UUID {
+	seen := make(map[gocql.UUID]int, len(members))
+	var duplicates []gocql.UUID
+	for _, member := range members {
+		seen[member.RecipientID]++
+		if seen[member.RecipientID] == 2 {
+			duplicates = append(duplicates, member.RecipientID)
+		}
+	}
+	return duplicates
+}
+
 func (impl *collectionRepositoryImpl) UpdateMemberPermission(ctx context.Context, collectionID, recipientID gocql.UUID, newPermission string) error {
 	// Load collection, update member permission, and save
 	collection, err := impl.Get(ctx, collectionID)
@@ -329,19 +436,96 @@ func (impl *collectionRepositoryImpl) UpdateMemberPermission(ctx context.Context
 	return impl.Update(ctx, collection)
 }
 
+// UpdateMembersPermissions applies the permission changes of many recipients, keyed by recipient ID,
+// in a single read-modify-write of the collection. Nothing is changed unless every update is valid.
+func (impl *collectionRepositoryImpl) UpdateMembersPermissions(ctx context.Context, collectionID gocql.UUID, updates map[gocql.UUID]string) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	collection, err := impl.Get(ctx, collectionID)
+	if err != nil {
+		return fmt.Errorf("failed to get collection: %w", err)
+	}
+
+	if collection == nil {
+		return dom_collection.ErrCollectionNotFound
+	}
+
+	// Validate every update before touching the members.
+	var invalid, notFound []string
+	for recipientID, newPermission := range updates {
+		if !isValidPermissionLevel(newPermission) {
+			invalid = append(invalid, recipientID.String())
+		}
+	}
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return fmt.Errorf("invalid permission level for recipients: %s", strings.Join(invalid, ", "))
+	}
+
+	if newPermission, ok := updates[collection.OwnerID]; ok && newPermission != dom_collection.CollectionPermissionAdmin {
+		return fmt.Errorf("%w %s", dom_collection.ErrCannotDemoteOwner, collection.OwnerID.String())
+	}
+
+	indexes := make(map[gocql.UUID]int, len(collection.Members))
+	for i, member := range collection.Members {
+		indexes[member.RecipientID] = i
+	}
+	for recipientID := range updates {
+		if _, ok := indexes[recipientID]; !ok {
+			notFound = append(notFound, recipientID.String())
+		}
+	}
+	if len(notFound) > 0 {
+		sort.Strings(notFound)
+		return fmt.Errorf("%w: %s", dom_collection.ErrMembersNotFound, strings.Join(notFound, ", "))
+	}
+
+	for recipientID, newPermission := range updates {
+		collection.Members[indexes[recipientID]].PermissionLevel = newPermission
+	}
+
+	collection.Version++
+	collection.ModifiedAt = time.Now()
+
+	if err := impl.Update(ctx, collection); err != nil {
+		return fmt.Errorf("failed to update collection: %w", err)
+	}
+
+	impl.Logger.Info("updated collection member permissions",
+		zap.String("collection_id", collectionID.String()),
+		zap.Int("updated", len(updates)),
+		zap.Uint64("version", collection.Version))
+
+	return nil
+}
+
+// isValidPermissionLevel returns true if the permission is one of the collection permission levels
+func isValidPermissionLevel(permission string) bool {
+	switch permission {
+	case dom_collection.CollectionPermissionReadOnly,
+		dom_collection.CollectionPermissionReadWrite,
+		dom_collection.CollectionPermissionAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
 func (impl *collectionRepositoryImpl) GetCollectionMembership(ctx context.Context, collectionID, recipientID gocql.UUID) (*dom_collection.CollectionMembership, error) {
 	var membership dom_collection.CollectionMembership
 
 	query := `SELECT recipient_id, member_id, recipient_email, granted_by_id,
 		encrypted_collection_key, permission_level, created_at,
-		is_inherited, inherited_from_id
+		is_inherited, inherited_from_id, expires_at
 		FROM maplefile_collection_members_by_collection_id_and_recipient_id
 		WHERE collection_id = ? AND recipient_id = ?`
 
 	err := impl.Session.Query(query, collectionID, recipientID).WithContext(ctx).Scan(
 		&membership.RecipientID, &membership.ID, &membership.RecipientEmail, &membership.GrantedByID,
 		&membership.EncryptedCollectionKey, &membership.PermissionLevel,
-		&membership.CreatedAt, &membership.IsInherited, &membership.InheritedFromID)
+		&membership.CreatedAt, &membership.IsInherited, &membership.InheritedFromID, &membership.ExpiresAt)
 
 	if err != nil {
 		if err == gocql.ErrNotFound {
@@ -0,0 +1,108 @@
+// internal/maplefile/repo/collection/share_test.go
+package collection
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+)
+
+func TestRemoveDuplicateMembers(t *testing.T) {
+	impl := &collectionRepositoryImpl{Logger: zap.NewNop()}
+	ownerID, memberID, otherID := gocql.TimeUUID(), gocql.TimeUUID(), gocql.TimeUUID()
+	older, newer := time.Now().Add(-time.Hour), time.Now()
+
+	t.Run("No duplicates", func(t *testing.T) {
+		collection := &dom_collection.Collection{Members: []dom_collection.CollectionMembership{
+			{RecipientID: ownerID},
+			{RecipientID: memberID},
+		}}
+
+		assert.Equal(t, 0, impl.removeDuplicateMembers(collection))
+		assert.Len(t, collection.Members, 2)
+	})
+
+	t.Run("Most recent membership is kept in the original order", func(t *testing.T) {
+		keptID := gocql.TimeUUID()
+		collection := &dom_collection.Collection{Members: []dom_collection.CollectionMembership{
+			{RecipientID: memberID, CreatedAt: older},
+			{RecipientID: ownerID},
+			{ID: keptID, RecipientID: memberID, CreatedAt: newer},
+			{RecipientID: otherID},
+			{RecipientID: memberID, CreatedAt: older},
+		}}
+
+		assert.Equal(t, 2, impl.removeDuplicateMembers(collection))
+		recipients := []gocql.UUID{}
+		for _, member := range collection.Members {
+			recipients = append(recipients, member.RecipientID)
+		}
+		assert.Equal(t, []gocql.UUID{ownerID, memberID, otherID}, recipients)
+		assert.Equal(t, keptID, collection.Members[1].ID)
+	})
+}
+
+func TestPutMember(t *testing.T) {
+	wrappedKey := bytes.Repeat([]byte{1}, 80)
+	ownerID, memberID := gocql.TimeUUID(), gocql.TimeUUID()
+	staleID, keptID := gocql.TimeUUID(), gocql.TimeUUID()
+
+	newCollection := func() *dom_collection.Collection {
+		return &dom_collection.Collection{
+			ID:      gocql.TimeUUID(),
+			OwnerID: ownerID,
+			Members: []dom_collection.CollectionMembership{
+				{ID: gocql.TimeUUID(), RecipientID: ownerID, PermissionLevel: dom_collection.CollectionPermissionAdmin},
+				{ID: staleID, RecipientID: memberID, PermissionLevel: dom_collection.CollectionPermissionReadOnly, CreatedAt: time.Now().Add(-time.Hour)},
+				{ID: keptID, RecipientID: memberID, PermissionLevel: dom_collection.CollectionPermissionReadOnly, CreatedAt: time.Now()},
+			},
+		}
+	}
+
+	t.Run("Duplicated member is updated once", func(t *testing.T) {
+		impl := &collectionRepositoryImpl{Logger: zap.NewNop()}
+		collection := newCollection()
+		membership := &dom_collection.CollectionMembership{
+			ID:                     gocql.TimeUUID(),
+			RecipientID:            memberID,
+			PermissionLevel:        dom_collection.CollectionPermissionReadWrite,
+			EncryptedCollectionKey: wrappedKey,
+		}
+
+		assert.NoError(t, impl.putMember(collection, membership))
+		assert.Len(t, collection.Members, 2)
+		assert.Equal(t, keptID, collection.Members[1].ID)
+		assert.Equal(t, dom_collection.CollectionPermissionReadWrite, collection.Members[1].PermissionLevel)
+	})
+
+	t.Run("Duplicates don't count against the member limit", func(t *testing.T) {
+		impl := &collectionRepositoryImpl{Logger: zap.NewNop(), MaxCollectionMembers: 3}
+		collection := newCollection()
+		membership := &dom_collection.CollectionMembership{
+			RecipientID:            gocql.TimeUUID(),
+			EncryptedCollectionKey: wrappedKey,
+		}
+
+		assert.NoError(t, impl.putMember(collection, membership))
+		assert.Len(t, collection.Members, 3)
+	})
+
+	t.Run("New member beyond the member limit", func(t *testing.T) {
+		impl := &collectionRepositoryImpl{Logger: zap.NewNop(), MaxCollectionMembers: 2}
+		collection := newCollection()
+		membership := &dom_collection.CollectionMembership{
+			RecipientID:            gocql.TimeUUID(),
+			EncryptedCollectionKey: wrappedKey,
+		}
+
+		err := impl.putMember(collection, membership)
+		assert.True(t, errors.Is(err, dom_collection.ErrMemberLimitExceeded))
+	})
+}
@@ -0,0 +1,114 @@
+// cloud/mapleapps-backend/internal/maplefile/repo/collection/transfer.go
+package collection
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+)
+
+// TransferOwnership reassigns the owner of a collection. The new owner must already be a member
+// holding a collection key wrapped for them (i.e. the collection was shared with them first) and is
+// promoted to admin. The former owner keeps read-write access if they hold a wrapped key, otherwise
+// their membership is removed because they can no longer decrypt the collection as a member.
+//
+// The collection's own EncryptedCollectionKey stays wrapped under the master key of the former owner,
+// which nobody but the new owner could re-wrap. The new owner's membership is kept for that reason:
+// clients decrypt a collection they own with the membership key when the owner key isn't theirs.
+func (impl *collectionRepositoryImpl) TransferOwnership(ctx context.Context, collectionID, newOwnerID gocql.UUID) error {
+	if !impl.isValidUUID(newOwnerID) {
+		return fmt.Errorf("invalid new owner ID")
+	}
+
+	collection, err := impl.Get(ctx, collectionID)
+	if err != nil {
+		return fmt.Errorf("failed to get collection: %w", err)
+	}
+
+	if collection == nil {
+		return dom_collection.ErrCollectionNotFound
+	}
+
+	formerOwnerID := collection.OwnerID
+
+	impl.Logger.Info("starting collection ownership transfer",
+		zap.String("collection_id", collectionID.String()),
+		zap.String("former_owner_id", formerOwnerID.String()),
+		zap.String("new_owner_id", newOwnerID.String()))
+
+	if err := transferMembers(collection, newOwnerID); err != nil {
+		impl.Logger.Error("cannot transfer collection ownership",
+			zap.String("collection_id", collectionID.String()),
+			zap.String("new_owner_id", newOwnerID.String()),
+			zap.Error(err))
+		return err
+	}
+
+	collection.Version++
+	collection.ModifiedAt = time.Now()
+
+	if err := impl.Update(ctx, collection); err != nil {
+		impl.Logger.Error("failed to update collection during ownership transfer",
+			zap.String("collection_id", collectionID.String()),
+			zap.Error(err))
+		return fmt.Errorf("failed to update collection: %w", err)
+	}
+
+	impl.Logger.Info("successfully transferred collection ownership",
+		zap.String("collection_id", collectionID.String()),
+		zap.String("former_owner_id", formerOwnerID.String()),
+		zap.String("new_owner_id", newOwnerID.String()),
+		zap.Uint64("version", collection.Version))
+
+	return nil
+}
+
+// transferMembers makes the new owner the owner of the collection and updates the memberships: the
+// new owner is promoted to admin and keeps the membership holding their wrapped key, the former owner
+// is downgraded to read-write or removed if they hold no wrapped key.
+func transferMembers(collection *dom_collection.Collection, newOwnerID gocql.UUID) error {
+	formerOwnerID := collection.OwnerID
+	if formerOwnerID == newOwnerID {
+		return dom_collection.ErrAlreadyOwner
+	}
+
+	// Promote the new owner to admin, making sure they can decrypt the collection.
+	newOwnerFound := false
+	for i, member := range collection.Members {
+		if member.RecipientID != newOwnerID {
+			continue
+		}
+		if len(member.EncryptedCollectionKey) < 32 {
+			return dom_collection.ErrNewOwnerKeyInvalid
+		}
+		collection.Members[i].PermissionLevel = dom_collection.CollectionPermissionAdmin
+		newOwnerFound = true
+		break
+	}
+
+	if !newOwnerFound {
+		return dom_collection.ErrNewOwnerNotMember
+	}
+
+	// Downgrade the former owner.
+	updatedMembers := make([]dom_collection.CollectionMembership, 0, len(collection.Members))
+	for _, member := range collection.Members {
+		if member.RecipientID == formerOwnerID {
+			if len(member.EncryptedCollectionKey) < 32 {
+				// Without a wrapped key the former owner can't decrypt the collection as a member.
+				continue
+			}
+			member.PermissionLevel = dom_collection.CollectionPermissionReadWrite
+		}
+		updatedMembers = append(updatedMembers, member)
+	}
+
+	collection.OwnerID = newOwnerID
+	collection.Members = updatedMembers
+	return nil
+}
@@ -0,0 +1,90 @@
+// internal/maplefile/repo/collection/transfer_test.go
+package collection
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+)
+
+func TestTransferMembers(t *testing.T) {
+	wrappedKey := bytes.Repeat([]byte{1}, 80)
+	formerOwnerID, newOwnerID, otherID := gocql.TimeUUID(), gocql.TimeUUID(), gocql.TimeUUID()
+
+	newCollection := func(formerOwnerKey []byte) *dom_collection.Collection {
+		return &dom_collection.Collection{
+			ID:      gocql.TimeUUID(),
+			OwnerID: formerOwnerID,
+			Members: []dom_collection.CollectionMembership{
+				{RecipientID: formerOwnerID, PermissionLevel: dom_collection.CollectionPermissionAdmin, EncryptedCollectionKey: formerOwnerKey},
+				{RecipientID: newOwnerID, PermissionLevel: dom_collection.CollectionPermissionReadOnly, EncryptedCollectionKey: wrappedKey},
+				{RecipientID: otherID, PermissionLevel: dom_collection.CollectionPermissionReadWrite, EncryptedCollectionKey: wrappedKey},
+			},
+		}
+	}
+	permissions := func(collection *dom_collection.Collection) map[gocql.UUID]string {
+		levels := make(map[gocql.UUID]string)
+		for _, member := range collection.Members {
+			levels[member.RecipientID] = member.PermissionLevel
+		}
+		return levels
+	}
+
+	t.Run("Former owner with a wrapped key stays as read-write member", func(t *testing.T) {
+		collection := newCollection(wrappedKey)
+
+		assert.NoError(t, transferMembers(collection, newOwnerID))
+		assert.Equal(t, newOwnerID, collection.OwnerID)
+		assert.Equal(t, map[gocql.UUID]string{
+			formerOwnerID: dom_collection.CollectionPermissionReadWrite,
+			newOwnerID:    dom_collection.CollectionPermissionAdmin,
+			otherID:       dom_collection.CollectionPermissionReadWrite,
+		}, permissions(collection))
+	})
+
+	t.Run("New owner keeps the membership holding their wrapped key", func(t *testing.T) {
+		collection := newCollection(wrappedKey)
+
+		assert.NoError(t, transferMembers(collection, newOwnerID))
+		for _, member := range collection.Members {
+			if member.RecipientID == newOwnerID {
+				assert.Equal(t, wrappedKey, member.EncryptedCollectionKey)
+				return
+			}
+		}
+		t.Fatal("new owner membership was removed")
+	})
+
+	t.Run("Former owner without a wrapped key is removed", func(t *testing.T) {
+		collection := newCollection(nil)
+
+		assert.NoError(t, transferMembers(collection, newOwnerID))
+		assert.NotContains(t, permissions(collection), formerOwnerID)
+		assert.Len(t, collection.Members, 2)
+	})
+
+	t.Run("New owner must be a member", func(t *testing.T) {
+		collection := newCollection(wrappedKey)
+
+		assert.ErrorIs(t, transferMembers(collection, gocql.TimeUUID()), dom_collection.ErrNewOwnerNotMember)
+		assert.Equal(t, formerOwnerID, collection.OwnerID)
+	})
+
+	t.Run("New owner must hold a wrapped key", func(t *testing.T) {
+		collection := newCollection(wrappedKey)
+		collection.Members[1].EncryptedCollectionKey = nil
+
+		assert.ErrorIs(t, transferMembers(collection, newOwnerID), dom_collection.ErrNewOwnerKeyInvalid)
+		assert.Equal(t, formerOwnerID, collection.OwnerID)
+	})
+
+	t.Run("Transfer to the current owner fails", func(t *testing.T) {
+		collection := newCollection(wrappedKey)
+
+		assert.ErrorIs(t, transferMembers(collection, formerOwnerID), dom_collection.ErrAlreadyOwner)
+	})
+}
@@ -40,6 +40,67 @@ func (impl *collectionRepositoryImpl) Update(ctx context.Context, collection *do
 		zap.Uint64("existing_version", existing.Version),
 		zap.Int("existing_members_count", len(existing.Members)))
 
+	// Validate every member before batching anything, so the members table and the user access
+	// tables are either all written or not at all
+	for i := range collection.Members {
+		member := &collection.Members[i]
+
+		if !impl.isValidUUID(member.RecipientID) {
+			return fmt.Errorf("invalid recipient ID for member %d", i)
+		}
+		if member.RecipientEmail == "" {
+			return fmt.Errorf("recipient email is required for member %d", i)
+		}
+		if member.PermissionLevel == "" {
+			return fmt.Errorf("permission level is required for member %d", i)
+		}
+
+		// FIXED: Only require encrypted collection key for non-owner members
+		// The owner has access to the collection key through their master key
+		isOwner := member.RecipientID == collection.OwnerID
+		if !isOwner && len(member.EncryptedCollectionKey) == 0 {
+			impl.Logger.Error("CRITICAL: encrypted collection key missing for shared member",
+				zap.String("collection_id", collection.ID.String()),
+				zap.Int("member_index", i),
+				zap.String("recipient_id", member.RecipientID.String()),
+				zap.String("recipient_email", member.RecipientEmail),
+				zap.String("owner_id", collection.OwnerID.String()),
+				zap.Bool("is_owner", isOwner),
+				zap.Int("encrypted_key_length", len(member.EncryptedCollectionKey)))
+			return fmt.Errorf("VALIDATION ERROR: encrypted collection key is required for shared member %d (recipient: %s, email: %s). This indicates a frontend bug or API misuse.", i, member.RecipientID.String(), member.RecipientEmail)
+		}
+
+		// Additional validation for shared members
+		if !isOwner && len(member.EncryptedCollectionKey) > 0 && len(member.EncryptedCollectionKey) < 32 {
+			impl.Logger.Error("encrypted collection key appears invalid for shared member",
+				zap.String("collection_id", collection.ID.String()),
+				zap.Int("member_index", i),
+				zap.String("recipient_id", member.RecipientID.String()),
+				zap.Int("encrypted_key_length", len(member.EncryptedCollectionKey)))
+			return fmt.Errorf("encrypted collection key appears invalid for member %d (too short: %d bytes)", i, len(member.EncryptedCollectionKey))
+		}
+
+		// Log key status for debugging
+		impl.Logger.Debug("member key validation passed",
+			zap.String("collection_id", collection.ID.String()),
+			zap.Int("member_index", i),
+			zap.String("recipient_id", member.RecipientID.String()),
+			zap.Bool("is_owner", isOwner),
+			zap.Int("encrypted_key_length", len(member.EncryptedCollectionKey)))
+
+		// Ensure member has an ID - but don't regenerate if it already exists
+		if !impl.isValidUUID(member.ID) {
+			member.ID = gocql.TimeUUID()
+			impl.Logger.Debug("generated member ID",
+				zap.String("member_id", member.ID.String()),
+				zap.String("recipient_id", member.RecipientID.String()))
+		} else {
+			impl.Logger.Debug("using existing member ID",
+				zap.String("member_id", member.ID.String()),
+				zap.String("recipient_id", member.RecipientID.String()))
+		}
+	}
+
 	// Update modified timestamp
 	collection.ModifiedAt = time.Now()
 
@@ -222,62 +283,6 @@ func (impl *collectionRepositoryImpl) Update(ctx context.Context, collection *do
 			zap.String("permission_level", member.PermissionLevel),
 			zap.Bool("is_inherited", member.IsInherited))
 
-		// Validate member data before insertion
-		if !impl.isValidUUID(member.RecipientID) {
-			return fmt.Errorf("invalid recipient ID for member %d", i)
-		}
-		if member.RecipientEmail == "" {
-			return fmt.Errorf("recipient email is required for member %d", i)
-		}
-		if member.PermissionLevel == "" {
-			return fmt.Errorf("permission level is required for member %d", i)
-		}
-
-		// FIXED: Only require encrypted collection key for non-owner members
-		// The owner has access to the collection key through their master key
-		isOwner := member.RecipientID == collection.OwnerID
-		if !isOwner && len(member.EncryptedCollectionKey) == 0 {
-			impl.Logger.Error("CRITICAL: encrypted collection key missing for shared member",
-				zap.String("collection_id", collection.ID.String()),
-				zap.Int("member_index", i),
-				zap.String("recipient_id", member.RecipientID.String()),
-				zap.String("recipient_email", member.RecipientEmail),
-				zap.String("owner_id", collection.OwnerID.String()),
-				zap.Bool("is_owner", isOwner),
-				zap.Int("encrypted_key_length", len(member.EncryptedCollectionKey)))
-			return fmt.Errorf("VALIDATION ERROR: encrypted collection key is required for shared member %d (recipient: %s, email: %s). This indicates a frontend bug or API misuse.", i, member.RecipientID.String(), member.RecipientEmail)
-		}
-
-		// Additional validation for shared members
-		if !isOwner && len(member.EncryptedCollectionKey) > 0 && len(member.EncryptedCollectionKey) < 32 {
-			impl.Logger.Error("encrypted collection key appears invalid for shared member",
-				zap.String("collection_id", collection.ID.String()),
-				zap.Int("member_index", i),
-				zap.String("recipient_id", member.RecipientID.String()),
-				zap.Int("encrypted_key_length", len(member.EncryptedCollectionKey)))
-			return fmt.Errorf("encrypted collection key appears invalid for member %d (too short: %d bytes)", i, len(member.EncryptedCollectionKey))
-		}
-
-		// Log key status for debugging
-		impl.Logger.Debug("member key validation passed",
-			zap.String("collection_id", collection.ID.String()),
-			zap.Int("member_index", i),
-			zap.String("recipient_id", member.RecipientID.String()),
-			zap.Bool("is_owner", isOwner),
-			zap.Int("encrypted_key_length", len(member.EncryptedCollectionKey)))
-
-		// Ensure member has an ID - but don't regenerate if it already exists
-		if !impl.isValidUUID(member.ID) {
-			member.ID = gocql.TimeUUID()
-			impl.Logger.Debug("generated member ID",
-				zap.String("member_id", member.ID.String()),
-				zap.String("recipient_id", member.RecipientID.String()))
-		} else {
-			impl.Logger.Debug("using existing member ID",
-				zap.String("member_id", member.ID.String()),
-				zap.String("recipient_id", member.RecipientID.String()))
-		}
-
 		// Insert into normalized members table
 		impl.Logger.Info("DEBUGGING: Inserting member into members table",
 			zap.String("collection_id", collection.ID.String()),
@@ -290,12 +295,12 @@ func (impl *collectionRepositoryImpl) Update(ctx context.Context, collection *do
 		batch.Query(`INSERT INTO maplefile_collection_members_by_collection_id_and_recipient_id
 			(collection_id, recipient_id, member_id, recipient_email, granted_by_id,
 			 encrypted_collection_key, permission_level, created_at,
-			 is_inherited, inherited_from_id)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			 is_inherited, inherited_from_id, expires_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 			collection.ID, member.RecipientID, member.ID, member.RecipientEmail,
 			member.GrantedByID, member.EncryptedCollectionKey,
 			member.PermissionLevel, member.CreatedAt,
-			member.IsInherited, member.InheritedFromID)
+			member.IsInherited, member.InheritedFromID, member.ExpiresAt)
 
 		impl.Logger.Info("DEBUGGING: Added member insert query to batch",
 			zap.String("collection_id", collection.ID.String()),
@@ -317,7 +322,13 @@ func (impl *collectionRepositoryImpl) Update(ctx context.Context, collection *do
 	}
 
 	//
-	// 6. Execute the batch
+	// 6. Record the changes in the collection history
+	//
+
+	impl.addChangesToBatch(batch, impl.detectChanges(ctx, existing, collection))
+
+	//
+	// 7. Execute the batch
 	//
 
 	impl.Logger.Info("executing batch update",
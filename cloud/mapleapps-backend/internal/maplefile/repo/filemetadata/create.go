@@ -53,13 +53,13 @@ func (impl *fileMetadataRepositoryImpl) Create(file *dom_file.File) error {
 	// 1. Insert into main table
 	batch.Query(`INSERT INTO mapleapps.maplefile_files_by_id
 		(id, collection_id, owner_id, encrypted_metadata, encrypted_file_key, encryption_version,
-		 encrypted_hash, encrypted_file_object_key, encrypted_file_size_in_bytes,
+		 encrypted_hash, encrypted_hash_algorithm, encrypted_file_object_key, encrypted_file_size_in_bytes,
 		 encrypted_thumbnail_object_key, encrypted_thumbnail_size_in_bytes,
 		 created_at, created_by_user_id, modified_at, modified_by_user_id, version,
 		 state, tombstone_version, tombstone_expiry)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		file.ID, file.CollectionID, file.OwnerID, file.EncryptedMetadata, encryptedKeyJSON,
-		file.EncryptionVersion, file.EncryptedHash, file.EncryptedFileObjectKey,
+		file.EncryptionVersion, file.EncryptedHash, file.EncryptedHashAlgorithm, file.EncryptedFileObjectKey,
 		file.EncryptedFileSizeInBytes, file.EncryptedThumbnailObjectKey,
 		file.EncryptedThumbnailSizeInBytes, file.CreatedAt, file.CreatedByUserID,
 		file.ModifiedAt, file.ModifiedByUserID, file.Version, file.State,
@@ -166,13 +166,13 @@ func (impl *fileMetadataRepositoryImpl) CreateMany(files []*dom_file.File) error
 		// Add to all 5 tables (same as Create but in batch)
 		batch.Query(`INSERT INTO mapleapps.maplefile_files_by_id
 			(id, collection_id, owner_id, encrypted_metadata, encrypted_file_key, encryption_version,
-			 encrypted_hash, encrypted_file_object_key, encrypted_file_size_in_bytes,
+			 encrypted_hash, encrypted_hash_algorithm, encrypted_file_object_key, encrypted_file_size_in_bytes,
 			 encrypted_thumbnail_object_key, encrypted_thumbnail_size_in_bytes,
 			 created_at, created_by_user_id, modified_at, modified_by_user_id, version,
 			 state, tombstone_version, tombstone_expiry)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 			file.ID, file.CollectionID, file.OwnerID, file.EncryptedMetadata, encryptedKeyJSON,
-			file.EncryptionVersion, file.EncryptedHash, file.EncryptedFileObjectKey,
+			file.EncryptionVersion, file.EncryptedHash, file.EncryptedHashAlgorithm, file.EncryptedFileObjectKey,
 			file.EncryptedFileSizeInBytes, file.EncryptedThumbnailObjectKey,
 			file.EncryptedThumbnailSizeInBytes, file.CreatedAt, file.CreatedByUserID,
 			file.ModifiedAt, file.ModifiedByUserID, file.Version, file.State,
@@ -16,7 +16,8 @@ func (impl *fileMetadataRepositoryImpl) Get(id gocql.UUID) (*dom_file.File, erro
 	var (
 		collectionID, ownerID, createdByUserID, modifiedByUserID gocql.UUID
 		encryptedMetadata, encryptedKeyJSON, encryptionVersion   string
-		encryptedHash, encryptedFileObjectKey                    string
+		encryptedHash, encryptedHashAlgorithm                    string
+		encryptedFileObjectKey                                   string
 		encryptedThumbnailObjectKey                              string
 		encryptedFileSizeInBytes, encryptedThumbnailSizeInBytes  int64
 		createdAt, modifiedAt, tombstoneExpiry                   time.Time
@@ -25,7 +26,7 @@ func (impl *fileMetadataRepositoryImpl) Get(id gocql.UUID) (*dom_file.File, erro
 	)
 
 	query := `SELECT id, collection_id, owner_id, encrypted_metadata, encrypted_file_key,
-		encryption_version, encrypted_hash, encrypted_file_object_key, encrypted_file_size_in_bytes,
+		encryption_version, encrypted_hash, encrypted_hash_algorithm, encrypted_file_object_key, encrypted_file_size_in_bytes,
 		encrypted_thumbnail_object_key, encrypted_thumbnail_size_in_bytes,
 		created_at, created_by_user_id, modified_at, modified_by_user_id, version,
 		state, tombstone_version, tombstone_expiry
@@ -33,7 +34,7 @@ func (impl *fileMetadataRepositoryImpl) Get(id gocql.UUID) (*dom_file.File, erro
 
 	err := impl.Session.Query(query, id).Scan(
 		&id, &collectionID, &ownerID, &encryptedMetadata, &encryptedKeyJSON,
-		&encryptionVersion, &encryptedHash, &encryptedFileObjectKey, &encryptedFileSizeInBytes,
+		&encryptionVersion, &encryptedHash, &encryptedHashAlgorithm, &encryptedFileObjectKey, &encryptedFileSizeInBytes,
 		&encryptedThumbnailObjectKey, &encryptedThumbnailSizeInBytes,
 		&createdAt, &createdByUserID, &modifiedAt, &modifiedByUserID, &version,
 		&state, &tombstoneVersion, &tombstoneExpiry)
@@ -59,6 +60,7 @@ func (impl *fileMetadataRepositoryImpl) Get(id gocql.UUID) (*dom_file.File, erro
 		EncryptedFileKey:              encryptedFileKey,
 		EncryptionVersion:             encryptionVersion,
 		EncryptedHash:                 encryptedHash,
+		EncryptedHashAlgorithm:        encryptedHashAlgorithm,
 		EncryptedFileObjectKey:        encryptedFileObjectKey,
 		EncryptedFileSizeInBytes:      encryptedFileSizeInBytes,
 		EncryptedThumbnailObjectKey:   encryptedThumbnailObjectKey,
@@ -44,14 +44,14 @@ func (impl *fileMetadataRepositoryImpl) Update(file *dom_file.File) error {
 	// 1. Update main table
 	batch.Query(`UPDATE mapleapps.maplefile_files_by_id SET
 		collection_id = ?, owner_id = ?, encrypted_metadata = ?, encrypted_file_key = ?,
-		encryption_version = ?, encrypted_hash = ?, encrypted_file_object_key = ?,
+		encryption_version = ?, encrypted_hash = ?, encrypted_hash_algorithm = ?, encrypted_file_object_key = ?,
 		encrypted_file_size_in_bytes = ?, encrypted_thumbnail_object_key = ?,
 		encrypted_thumbnail_size_in_bytes = ?, created_at = ?, created_by_user_id = ?,
 		modified_at = ?, modified_by_user_id = ?, version = ?, state = ?,
 		tombstone_version = ?, tombstone_expiry = ?
 		WHERE id = ?`,
 		file.CollectionID, file.OwnerID, file.EncryptedMetadata, encryptedKeyJSON,
-		file.EncryptionVersion, file.EncryptedHash, file.EncryptedFileObjectKey,
+		file.EncryptionVersion, file.EncryptedHash, file.EncryptedHashAlgorithm, file.EncryptedFileObjectKey,
 		file.EncryptedFileSizeInBytes, file.EncryptedThumbnailObjectKey,
 		file.EncryptedThumbnailSizeInBytes, file.CreatedAt, file.CreatedByUserID,
 		file.ModifiedAt, file.ModifiedByUserID, file.Version, file.State,
@@ -84,6 +84,9 @@ type MembershipResponseDTO struct {
 	// Sharing origin tracking
 	IsInherited     bool       `bson:"is_inherited" json:"is_inherited"`                               // Tracks whether access was granted directly or inherited from a parent
 	InheritedFromID gocql.UUID `bson:"inherited_from_id,omitempty" json:"inherited_from_id,omitempty"` // InheritedFromID identifies which parent collection granted this access
+
+	// Time-bounded access
+	ExpiresAt time.Time `bson:"expires_at,omitempty" json:"expires_at,omitzero"` // When the access ends, the zero value never expires
 }
 
 type CreateCollectionService interface {
@@ -125,24 +128,7 @@ func (svc *createCollectionServiceImpl) Execute(ctx context.Context, req *Create
 	}
 
 	e := make(map[string]string)
-	if req.ID.String() == "" {
-		e["encrypted_name"] = "Client-side generated ID is required"
-	}
-	if req.EncryptedName == "" {
-		e["encrypted_name"] = "Collection name is required"
-	}
-	if req.CollectionType == "" {
-		e["collection_type"] = "Collection type is required"
-	} else if req.CollectionType != dom_collection.CollectionTypeFolder && req.CollectionType != dom_collection.CollectionTypeAlbum {
-		e["collection_type"] = "Collection type must be either 'folder' or 'album'"
-	}
-	// Check pointer and then content
-	if req.EncryptedCollectionKey == nil || req.EncryptedCollectionKey.Ciphertext == nil || len(req.EncryptedCollectionKey.Ciphertext) == 0 {
-		e["encrypted_collection_key"] = "Encrypted collection key ciphertext is required"
-	}
-	if req.EncryptedCollectionKey == nil || req.EncryptedCollectionKey.Nonce == nil || len(req.EncryptedCollectionKey.Nonce) == 0 {
-		e["encrypted_collection_key"] = "Encrypted collection key nonce is required"
-	}
+	validateCreateCollectionRequest(req, "", e)
 
 	if len(e) != 0 {
 		svc.logger.Warn("Failed validation",
@@ -172,67 +158,10 @@ func (svc *createCollectionServiceImpl) Execute(ctx context.Context, req *Create
 	//
 	now := time.Now()
 
-	// Map all fields from the request DTO to the domain object.
-	// This copies client-provided values including potential ID, OwnerID, timestamps, etc.
-	collection := mapCollectionDTOToDomain(req, userID, now)
-
-	// Apply server-side mandatory fields/overrides for the top-level collection.
-	// These values are managed by the backend regardless of what the client provides in the DTO.
-	// This ensures data integrity and reflects the server's perspective of the creation event.
-	collection.ID = gocql.TimeUUID()                        // Always generate a new ID on the server for a new creation
-	collection.OwnerID = userID                             // The authenticated user is the authoritative owner
-	collection.CreatedAt = now                              // Server timestamp for creation
-	collection.ModifiedAt = now                             // Server timestamp for modification
-	collection.CreatedByUserID = userID                     // The authenticated user is the creator
-	collection.ModifiedByUserID = userID                    // The authenticated user is the initial modifier
-	collection.Version = 1                                  // Collection creation **always** starts mutation version at 1.
-	collection.State = dom_collection.CollectionStateActive // Collection creation **always** starts in active state.
-
-	// Ensure owner membership exists with Admin permissions.
-	// Check if the owner is already present in the members list copied from the DTO.
-	ownerAlreadyMember := false
-	for i := range collection.Members { // Iterate by index to allow modification if needed
-		if collection.Members[i].RecipientID == userID {
-			// Owner is found. Ensure they have Admin permission and correct granted_by/is_inherited status.
-			collection.Members[i].RecipientEmail = federateduser.Email
-			collection.Members[i].PermissionLevel = dom_collection.CollectionPermissionAdmin
-			collection.Members[i].GrantedByID = userID
-			collection.Members[i].IsInherited = false
-			// NOTE: We intentionally do NOT set EncryptedCollectionKey here for the owner
-			// The owner accesses the collection key through their master key, not through
-			// the encrypted member key. This is validated in the repository layer.
-			collection.Members[i].EncryptedCollectionKey = nil
-			// Optionally update membership CreatedAt here if server should control it, otherwise keep DTO value.
-			// collection.Members[i].CreatedAt = now
-			ownerAlreadyMember = true
-			svc.logger.Debug("✅ Owner membership updated with Admin permissions (no encrypted key needed)")
-			break
-		}
-	}
-
-	// If owner is not in the members list, add their mandatory membership.
-	if !ownerAlreadyMember {
-		svc.logger.Debug("☑️ Owner is not in the members list, add their mandatory membership now")
-		ownerMembership := dom_collection.CollectionMembership{
-			ID:              gocql.TimeUUID(), // Unique ID for this specific membership record
-			RecipientID:     userID,
-			RecipientEmail:  federateduser.Email,
-			CollectionID:    collection.ID,                            // Link to the newly created collection ID
-			PermissionLevel: dom_collection.CollectionPermissionAdmin, // Owner must have Admin
-			GrantedByID:     userID,                                   // Owner implicitly grants themselves permission
-			IsInherited:     false,                                    // Owner membership is never inherited
-			CreatedAt:       now,                                      // Server timestamp for membership creation
-			// NOTE: EncryptedCollectionKey is intentionally nil for owner memberships
-			// The owner has access to the collection key through their master key
-			// This is validated in the repository layer which allows nil encrypted keys for owners
-			EncryptedCollectionKey: nil,
-			// InheritedFromID is nil for direct membership.
-		}
-		// Append the mandatory owner membership. If req.Members was empty, this initializes the slice.
-		collection.Members = append(collection.Members, ownerMembership)
-
-		svc.logger.Debug("✅ Owner membership added with Admin permissions (no encrypted key needed)")
-	}
+	// Map every collection of the tree from the request DTO and apply the server-managed fields.
+	// Children are created as subcollections of the collection they are nested in.
+	root := svc.buildCollectionTree(req, nil, userID, federateduser.Email, now)
+	collection := root.collection
 
 	svc.logger.Debug("🔍 Collection debugging info",
 		zap.String("collectionID", collection.ID.String()),
@@ -260,20 +189,16 @@ func (svc *createCollectionServiceImpl) Execute(ctx context.Context, req *Create
 		zap.String("currentUserEmail", federateduser.Email),
 		zap.String("currentUserName", federateduser.Name))
 
-	// Note: Fields like ParentID, AncestorIDs, EncryptedCollectionKey,
-	// EncryptedName, CollectionType, and recursively mapped Children are copied directly from the DTO
-	// by the mapCollectionDTOToDomain function before server overrides. This fulfills the
-	// prompt's requirement to copy these fields from the DTO.
+	// Note: Fields like ParentID, AncestorIDs, EncryptedCollectionKey, EncryptedName and
+	// CollectionType are copied directly from the DTO by the mapCollectionDTOToDomain function
+	// before server overrides. Children take their ParentID and AncestorIDs from the collection
+	// they are nested in instead.
 
 	//
-	// STEP 4: Create collection in repository
+	// STEP 4: Create collection and its children in repository
 	//
 
-	if err := svc.repo.Create(ctx, collection); err != nil {
-		svc.logger.Error("Failed to create collection",
-			zap.Any("error", err),
-			zap.Any("owner_id", collection.OwnerID),
-			zap.String("name", collection.EncryptedName))
+	if err := svc.createCollectionTree(ctx, root); err != nil {
 		return nil, err
 	}
 
@@ -283,11 +208,164 @@ func (svc *createCollectionServiceImpl) Execute(ctx context.Context, req *Create
 	// The mapCollectionToDTO helper is used here to convert the created domain object back
 	// into the response DTO format, potentially excluding sensitive fields like keys
 	// or specific membership details not meant for the general response.
-	response := mapCollectionToDTO(collection)
+	response := mapCollectionTreeToDTO(root)
 
 	svc.logger.Debug("Collection created successfully",
 		zap.Any("collection_id", collection.ID),
-		zap.Any("owner_id", collection.OwnerID))
+		zap.Any("owner_id", collection.OwnerID),
+		zap.Int("children", len(root.children)))
 
 	return response, nil
 }
+
+// collectionTreeNode is a collection to create along with the subcollections nested in it
+type collectionTreeNode struct {
+	collection *dom_collection.Collection
+	children   []*collectionTreeNode
+}
+
+// validateCreateCollectionRequest validates the collection and every child nested in it, keying the
+// errors of children by their path in the tree, such as "children[0].encrypted_name".
+func validateCreateCollectionRequest(req *CreateCollectionRequestDTO, prefix string, e map[string]string) {
+	if req.ID.String() == "" {
+		e[prefix+"encrypted_name"] = "Client-side generated ID is required"
+	}
+	if req.EncryptedName == "" {
+		e[prefix+"encrypted_name"] = "Collection name is required"
+	}
+	if req.CollectionType == "" {
+		e[prefix+"collection_type"] = "Collection type is required"
+	} else if req.CollectionType != dom_collection.CollectionTypeFolder && req.CollectionType != dom_collection.CollectionTypeAlbum {
+		e[prefix+"collection_type"] = "Collection type must be either 'folder' or 'album'"
+	}
+	// Check pointer and then content
+	if req.EncryptedCollectionKey == nil || req.EncryptedCollectionKey.Ciphertext == nil || len(req.EncryptedCollectionKey.Ciphertext) == 0 {
+		e[prefix+"encrypted_collection_key"] = "Encrypted collection key ciphertext is required"
+	}
+	if req.EncryptedCollectionKey == nil || req.EncryptedCollectionKey.Nonce == nil || len(req.EncryptedCollectionKey.Nonce) == 0 {
+		e[prefix+"encrypted_collection_key"] = "Encrypted collection key nonce is required"
+	}
+
+	for i, child := range req.Children {
+		childPrefix := fmt.Sprintf("%schildren[%d].", prefix, i)
+		if child == nil {
+			e[childPrefix+"non_field_error"] = "Collection details are required"
+			continue
+		}
+		validateCreateCollectionRequest(child, childPrefix, e)
+	}
+}
+
+// buildCollectionTree maps the request DTO and its children to collections with the server-managed
+// fields applied. Children are placed under parent, ignoring the hierarchy proposed by the client.
+func (svc *createCollectionServiceImpl) buildCollectionTree(
+	dto *CreateCollectionRequestDTO,
+	parent *dom_collection.Collection,
+	userID gocql.UUID,
+	userEmail string,
+	now time.Time,
+) *collectionTreeNode {
+	collection := mapCollectionDTOToDomain(dto, userID, now)
+
+	// Apply server-side mandatory fields/overrides.
+	// These values are managed by the backend regardless of what the client provides in the DTO.
+	// This ensures data integrity and reflects the server's perspective of the creation event.
+	collection.ID = gocql.TimeUUID()                        // Always generate a new ID on the server for a new creation
+	collection.OwnerID = userID                             // The authenticated user is the authoritative owner
+	collection.CreatedAt = now                              // Server timestamp for creation
+	collection.ModifiedAt = now                             // Server timestamp for modification
+	collection.CreatedByUserID = userID                     // The authenticated user is the creator
+	collection.ModifiedByUserID = userID                    // The authenticated user is the initial modifier
+	collection.Version = 1                                  // Collection creation **always** starts mutation version at 1.
+	collection.State = dom_collection.CollectionStateActive // Collection creation **always** starts in active state.
+
+	if parent != nil {
+		collection.ParentID = parent.ID
+		collection.AncestorIDs = append(append([]gocql.UUID{}, parent.AncestorIDs...), parent.ID)
+	}
+
+	// Memberships proposed by the client refer to the client-side ID.
+	for i := range collection.Members {
+		collection.Members[i].CollectionID = collection.ID
+	}
+
+	svc.ensureOwnerMembership(collection, userID, userEmail, now)
+
+	node := &collectionTreeNode{collection: collection}
+	for _, childDTO := range dto.Children {
+		node.children = append(node.children, svc.buildCollectionTree(childDTO, collection, userID, userEmail, now))
+	}
+	return node
+}
+
+// ensureOwnerMembership ensures the owner is a member of the collection with Admin permissions
+func (svc *createCollectionServiceImpl) ensureOwnerMembership(collection *dom_collection.Collection, userID gocql.UUID, userEmail string, now time.Time) {
+	// Check if the owner is already present in the members list copied from the DTO.
+	for i := range collection.Members { // Iterate by index to allow modification if needed
+		if collection.Members[i].RecipientID == userID {
+			// Owner is found. Ensure they have Admin permission and correct granted_by/is_inherited status.
+			collection.Members[i].RecipientEmail = userEmail
+			collection.Members[i].PermissionLevel = dom_collection.CollectionPermissionAdmin
+			collection.Members[i].GrantedByID = userID
+			collection.Members[i].IsInherited = false
+			// NOTE: We intentionally do NOT set EncryptedCollectionKey here for the owner
+			// The owner accesses the collection key through their master key, not through
+			// the encrypted member key. This is validated in the repository layer.
+			collection.Members[i].EncryptedCollectionKey = nil
+			// Optionally update membership CreatedAt here if server should control it, otherwise keep DTO value.
+			// collection.Members[i].CreatedAt = now
+			svc.logger.Debug("✅ Owner membership updated with Admin permissions (no encrypted key needed)")
+			return
+		}
+	}
+
+	// If owner is not in the members list, add their mandatory membership.
+	svc.logger.Debug("☑️ Owner is not in the members list, add their mandatory membership now")
+	ownerMembership := dom_collection.CollectionMembership{
+		ID:              gocql.TimeUUID(), // Unique ID for this specific membership record
+		RecipientID:     userID,
+		RecipientEmail:  userEmail,
+		CollectionID:    collection.ID,                            // Link to the newly created collection ID
+		PermissionLevel: dom_collection.CollectionPermissionAdmin, // Owner must have Admin
+		GrantedByID:     userID,                                   // Owner implicitly grants themselves permission
+		IsInherited:     false,                                    // Owner membership is never inherited
+		CreatedAt:       now,                                      // Server timestamp for membership creation
+		// NOTE: EncryptedCollectionKey is intentionally nil for owner memberships
+		// The owner has access to the collection key through their master key
+		// This is validated in the repository layer which allows nil encrypted keys for owners
+		EncryptedCollectionKey: nil,
+		// InheritedFromID is nil for direct membership.
+	}
+	// Append the mandatory owner membership. If req.Members was empty, this initializes the slice.
+	collection.Members = append(collection.Members, ownerMembership)
+
+	svc.logger.Debug("✅ Owner membership added with Admin permissions (no encrypted key needed)")
+}
+
+// createCollectionTree creates the collection and then its children, so every parent exists before
+// its subcollections.
+func (svc *createCollectionServiceImpl) createCollectionTree(ctx context.Context, node *collectionTreeNode) error {
+	if err := svc.repo.Create(ctx, node.collection); err != nil {
+		svc.logger.Error("Failed to create collection",
+			zap.Any("error", err),
+			zap.Any("owner_id", node.collection.OwnerID),
+			zap.String("name", node.collection.EncryptedName),
+			zap.Any("parent_id", node.collection.ParentID))
+		return err
+	}
+	for _, child := range node.children {
+		if err := svc.createCollectionTree(ctx, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mapCollectionTreeToDTO maps the created collection and its children to the response DTO
+func mapCollectionTreeToDTO(node *collectionTreeNode) *CollectionResponseDTO {
+	response := mapCollectionToDTO(node.collection)
+	for _, child := range node.children {
+		response.Children = append(response.Children, mapCollectionTreeToDTO(child))
+	}
+	return response
+}
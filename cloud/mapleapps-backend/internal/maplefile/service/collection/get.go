@@ -3,6 +3,7 @@ package collection
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -77,11 +78,11 @@ func (svc *getCollectionServiceImpl) Execute(ctx context.Context, collectionID g
 	// First check if user is owner
 	hasAccess := collection.OwnerID == userID
 
-	// If not owner, check if user is a member
+	// If not owner, check if user is a member whose access has not expired
 	if !hasAccess {
 		for _, member := range collection.Members {
 			if member.RecipientID == userID {
-				hasAccess = true
+				hasAccess = !member.IsExpired(time.Now())
 				break
 			}
 		}
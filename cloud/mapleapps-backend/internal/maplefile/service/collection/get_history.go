@@ -0,0 +1,123 @@
+// cloud/backend/internal/maplefile/service/collection/get_history.go
+package collection
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+)
+
+type CollectionChangeDTO struct {
+	Version         uint64     `json:"version"`
+	ChangedAt       time.Time  `json:"changed_at"`
+	ChangedByUserID gocql.UUID `json:"changed_by_user_id"`
+	ChangeType      string     `json:"change_type"`
+	SubjectID       gocql.UUID `json:"subject_id,omitzero"`
+}
+
+type GetCollectionHistoryResponseDTO struct {
+	CollectionID gocql.UUID             `json:"collection_id"`
+	Changes      []*CollectionChangeDTO `json:"changes"`
+}
+
+type GetCollectionHistoryService interface {
+	Execute(ctx context.Context, collectionID gocql.UUID) (*GetCollectionHistoryResponseDTO, error)
+}
+
+type getCollectionHistoryServiceImpl struct {
+	config *config.Configuration
+	logger *zap.Logger
+	repo   dom_collection.CollectionRepository
+}
+
+func NewGetCollectionHistoryService(
+	config *config.Configuration,
+	logger *zap.Logger,
+	repo dom_collection.CollectionRepository,
+) GetCollectionHistoryService {
+	logger = logger.Named("GetCollectionHistoryService")
+	return &getCollectionHistoryServiceImpl{
+		config: config,
+		logger: logger,
+		repo:   repo,
+	}
+}
+
+func (svc *getCollectionHistoryServiceImpl) Execute(ctx context.Context, collectionID gocql.UUID) (*GetCollectionHistoryResponseDTO, error) {
+	//
+	// STEP 1: Validation
+	//
+	if collectionID.String() == "" {
+		svc.logger.Warn("Empty collection ID provided")
+		return nil, httperror.NewForBadRequestWithSingleField("collection_id", "Collection ID is required")
+	}
+
+	//
+	// STEP 2: Get user ID from context
+	//
+	userID, ok := ctx.Value(constants.SessionFederatedUserID).(gocql.UUID)
+	if !ok {
+		svc.logger.Error("Failed getting user ID from context")
+		return nil, httperror.NewForInternalServerErrorWithSingleField("message", "Authentication context error")
+	}
+
+	//
+	// STEP 3: Check if the user has access to this collection
+	//
+	hasAccess, err := svc.repo.CheckAccess(ctx, collectionID, userID, dom_collection.CollectionPermissionReadOnly)
+	if err != nil {
+		svc.logger.Error("Failed to check collection access",
+			zap.Any("error", err),
+			zap.Any("collection_id", collectionID),
+			zap.Any("user_id", userID))
+		return nil, err
+	}
+
+	if !hasAccess {
+		svc.logger.Warn("Unauthorized collection history access attempt",
+			zap.Any("user_id", userID),
+			zap.Any("collection_id", collectionID))
+		return nil, httperror.NewForForbiddenWithSingleField("message", "You don't have access to this collection")
+	}
+
+	//
+	// STEP 4: Get the history from repository
+	//
+	changes, err := svc.repo.GetCollectionHistory(ctx, collectionID)
+	if err != nil {
+		svc.logger.Error("Failed to get collection history",
+			zap.Any("error", err),
+			zap.Any("collection_id", collectionID))
+		return nil, err
+	}
+
+	//
+	// STEP 5: Map domain models to response DTO
+	//
+	response := &GetCollectionHistoryResponseDTO{
+		CollectionID: collectionID,
+		Changes:      make([]*CollectionChangeDTO, 0, len(changes)),
+	}
+	for _, change := range changes {
+		response.Changes = append(response.Changes, &CollectionChangeDTO{
+			Version:         change.Version,
+			ChangedAt:       change.ChangedAt,
+			ChangedByUserID: change.ChangedByUserID,
+			ChangeType:      change.ChangeType,
+			SubjectID:       change.SubjectID,
+		})
+	}
+
+	svc.logger.Debug("Collection history retrieved",
+		zap.Any("collection_id", collectionID),
+		zap.Int("count", len(response.Changes)))
+
+	return response, nil
+}
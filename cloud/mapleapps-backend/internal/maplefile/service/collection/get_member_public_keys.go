@@ -0,0 +1,211 @@
+// cloud/backend/internal/maplefile/service/collection/get_member_public_keys.go
+package collection
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	uc_federateduser "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/usecase/federateduser"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/security/crypto"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/storage/cache/cassandracache"
+)
+
+const (
+	// memberPublicKeysLimit is how many member key exports one user can request per window. Re-wrapping
+	// a collection key only needs one export, so this mostly protects the per-member user lookups.
+	memberPublicKeysLimit  = 10
+	memberPublicKeysWindow = time.Minute
+)
+
+type CollectionMemberPublicKeyDTO struct {
+	RecipientID     gocql.UUID `json:"recipient_id"`
+	RecipientEmail  string     `json:"recipient_email"`
+	PermissionLevel string     `json:"permission_level"`
+	PublicKey       []byte     `json:"public_key"`
+	Fingerprint     string     `json:"fingerprint"`
+}
+
+type GetCollectionMemberPublicKeysResponseDTO struct {
+	CollectionID gocql.UUID                      `json:"collection_id"`
+	Members      []*CollectionMemberPublicKeyDTO `json:"members"`
+}
+
+// GetCollectionMemberPublicKeysService returns the public key of every member of a collection in one
+// call, so the owner or an admin can re-wrap a new collection key for all of them at once.
+type GetCollectionMemberPublicKeysService interface {
+	Execute(ctx context.Context, collectionID gocql.UUID) (*GetCollectionMemberPublicKeysResponseDTO, error)
+}
+
+type getCollectionMemberPublicKeysServiceImpl struct {
+	config                      *config.Configuration
+	logger                      *zap.Logger
+	cache                       cassandracache.CassandraCacher
+	repo                        dom_collection.CollectionRepository
+	federatedUserGetByIDUseCase uc_federateduser.FederatedUserGetByIDUseCase
+}
+
+func NewGetCollectionMemberPublicKeysService(
+	config *config.Configuration,
+	logger *zap.Logger,
+	cache cassandracache.CassandraCacher,
+	repo dom_collection.CollectionRepository,
+	federatedUserGetByIDUseCase uc_federateduser.FederatedUserGetByIDUseCase,
+) GetCollectionMemberPublicKeysService {
+	logger = logger.Named("GetCollectionMemberPublicKeysService")
+	return &getCollectionMemberPublicKeysServiceImpl{
+		config:                      config,
+		logger:                      logger,
+		cache:                       cache,
+		repo:                        repo,
+		federatedUserGetByIDUseCase: federatedUserGetByIDUseCase,
+	}
+}
+
+func (svc *getCollectionMemberPublicKeysServiceImpl) Execute(ctx context.Context, collectionID gocql.UUID) (*GetCollectionMemberPublicKeysResponseDTO, error) {
+	//
+	// STEP 1: Validation
+	//
+	if collectionID.String() == "" {
+		svc.logger.Warn("Empty collection ID provided")
+		return nil, httperror.NewForBadRequestWithSingleField("collection_id", "Collection ID is required")
+	}
+
+	//
+	// STEP 2: Get user ID from context
+	//
+	userID, ok := ctx.Value(constants.SessionFederatedUserID).(gocql.UUID)
+	if !ok {
+		svc.logger.Error("Failed getting user ID from context")
+		return nil, httperror.NewForInternalServerErrorWithSingleField("message", "Authentication context error")
+	}
+
+	//
+	// STEP 3: Rate limit the exports of the user
+	//
+	if err := svc.checkRateLimit(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	//
+	// STEP 4: Get the collection and check the user may re-wrap its key
+	//
+	collection, err := svc.repo.Get(ctx, collectionID)
+	if err != nil {
+		svc.logger.Error("Failed to get collection",
+			zap.Any("error", err),
+			zap.Any("collection_id", collectionID))
+		return nil, err
+	}
+	if collection == nil {
+		svc.logger.Debug("Collection not found",
+			zap.Any("collection_id", collectionID))
+		return nil, httperror.NewForNotFoundWithSingleField("message", "Collection not found")
+	}
+
+	if !canManageMemberKeys(collection, userID) {
+		svc.logger.Warn("Unauthorized member public keys access attempt",
+			zap.Any("user_id", userID),
+			zap.Any("collection_id", collectionID))
+		return nil, httperror.NewForForbiddenWithSingleField("message", "Only the collection owner or an admin can export the member keys")
+	}
+
+	//
+	// STEP 5: Look up the public key of every member
+	//
+	response := &GetCollectionMemberPublicKeysResponseDTO{
+		CollectionID: collectionID,
+		Members:      make([]*CollectionMemberPublicKeyDTO, 0, len(collection.Members)),
+	}
+	seen := make(map[gocql.UUID]bool, len(collection.Members))
+	for _, member := range collection.Members {
+		// The owner's copy of the key is wrapped with their master key instead of their public key.
+		if member.RecipientID == collection.OwnerID || seen[member.RecipientID] {
+			continue
+		}
+		seen[member.RecipientID] = true
+
+		recipient, err := svc.federatedUserGetByIDUseCase.Execute(ctx, member.RecipientID)
+		if err != nil {
+			svc.logger.Error("Failed getting member from database",
+				zap.Any("error", err),
+				zap.Any("recipient_id", member.RecipientID))
+			return nil, err
+		}
+		if recipient == nil || recipient.SecurityData == nil || len(recipient.SecurityData.PublicKey.Key) == 0 {
+			// A member whose account is gone can't receive the new key, the client skips them.
+			svc.logger.Warn("Member has no public key",
+				zap.Any("collection_id", collectionID),
+				zap.Any("recipient_id", member.RecipientID))
+			continue
+		}
+
+		fingerprint, err := crypto.GeneratePublicKeyFingerprint(recipient.SecurityData.PublicKey.Key)
+		if err != nil {
+			svc.logger.Error("Failed generating public key fingerprint",
+				zap.Any("error", err),
+				zap.Any("recipient_id", member.RecipientID))
+			return nil, err
+		}
+
+		response.Members = append(response.Members, &CollectionMemberPublicKeyDTO{
+			RecipientID:     member.RecipientID,
+			RecipientEmail:  member.RecipientEmail,
+			PermissionLevel: member.PermissionLevel,
+			PublicKey:       recipient.SecurityData.PublicKey.Key,
+			Fingerprint:     fingerprint,
+		})
+	}
+
+	svc.logger.Debug("Exported member public keys",
+		zap.Any("collection_id", collectionID),
+		zap.Int("member_count", len(response.Members)))
+
+	return response, nil
+}
+
+// checkRateLimit counts the exports of the user in the current window and rejects them once the
+// limit is exceeded. The count is incremented atomically so concurrent exports can't all get past
+// the limit together.
+func (svc *getCollectionMemberPublicKeysServiceImpl) checkRateLimit(ctx context.Context, userID gocql.UUID) error {
+	window := time.Now().Unix() / int64(memberPublicKeysWindow.Seconds())
+	cacheKey := fmt.Sprintf("member_public_keys:%s:%d", userID.String(), window)
+
+	// Keep the counter a little longer than its window so it can't expire mid-window.
+	count, err := svc.cache.IncrementWithExpiry(ctx, cacheKey, 2*memberPublicKeysWindow)
+	if err != nil {
+		svc.logger.Error("Failed incrementing member public keys export count",
+			zap.Any("error", err),
+			zap.Any("user_id", userID))
+		return err
+	}
+
+	if count > memberPublicKeysLimit {
+		svc.logger.Warn("Member public keys rate limit reached",
+			zap.Any("user_id", userID),
+			zap.Int64("count", count))
+		return httperror.NewForSingleField(http.StatusTooManyRequests, "message", "Too many member key exports, please try again in a minute")
+	}
+	return nil
+}
+
+// canManageMemberKeys returns true for the owner and the admins of the collection
+func canManageMemberKeys(collection *dom_collection.Collection, userID gocql.UUID) bool {
+	if collection.OwnerID == userID {
+		return true
+	}
+	for _, member := range collection.Members {
+		if member.RecipientID == userID && member.PermissionLevel == dom_collection.CollectionPermissionAdmin && !member.IsExpired(time.Now()) {
+			return true
+		}
+	}
+	return false
+}
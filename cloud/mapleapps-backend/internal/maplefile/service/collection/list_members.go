@@ -0,0 +1,138 @@
+// cloud/backend/internal/maplefile/service/collection/list_members.go
+package collection
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+)
+
+type CollectionMemberDTO struct {
+	RecipientID     gocql.UUID `json:"recipient_id"`
+	RecipientEmail  string     `json:"recipient_email"`
+	PermissionLevel string     `json:"permission_level"`
+	GrantedByID     gocql.UUID `json:"granted_by_id"`
+	CreatedAt       time.Time  `json:"created_at"`
+	IsInherited     bool       `json:"is_inherited"`
+	InheritedFromID gocql.UUID `json:"inherited_from_id,omitzero"`
+	ExpiresAt       time.Time  `json:"expires_at,omitzero"`
+}
+
+type ListCollectionMembersResponseDTO struct {
+	CollectionID gocql.UUID             `json:"collection_id"`
+	Members      []*CollectionMemberDTO `json:"members"`
+	// NextCursor continues the listing, it is omitted once the last page has been returned
+	NextCursor *dom_collection.MemberCursor `json:"next_cursor,omitempty"`
+}
+
+type ListCollectionMembersService interface {
+	Execute(ctx context.Context, collectionID gocql.UUID, cursor dom_collection.MemberCursor, limit int) (*ListCollectionMembersResponseDTO, error)
+}
+
+type listCollectionMembersServiceImpl struct {
+	config *config.Configuration
+	logger *zap.Logger
+	repo   dom_collection.CollectionRepository
+}
+
+func NewListCollectionMembersService(
+	config *config.Configuration,
+	logger *zap.Logger,
+	repo dom_collection.CollectionRepository,
+) ListCollectionMembersService {
+	logger = logger.Named("ListCollectionMembersService")
+	return &listCollectionMembersServiceImpl{
+		config: config,
+		logger: logger,
+		repo:   repo,
+	}
+}
+
+// Execute returns a page of the members of a collection, so heavily shared collections can be
+// managed without loading every membership at once. The wrapped collection keys are left out as
+// they are only of use to their recipient.
+func (svc *listCollectionMembersServiceImpl) Execute(ctx context.Context, collectionID gocql.UUID, cursor dom_collection.MemberCursor, limit int) (*ListCollectionMembersResponseDTO, error) {
+	//
+	// STEP 1: Validation
+	//
+	if collectionID.String() == "" {
+		svc.logger.Warn("Empty collection ID provided")
+		return nil, httperror.NewForBadRequestWithSingleField("collection_id", "Collection ID is required")
+	}
+
+	//
+	// STEP 2: Get user ID from context
+	//
+	userID, ok := ctx.Value(constants.SessionFederatedUserID).(gocql.UUID)
+	if !ok {
+		svc.logger.Error("Failed getting user ID from context")
+		return nil, httperror.NewForInternalServerErrorWithSingleField("message", "Authentication context error")
+	}
+
+	//
+	// STEP 3: Check if the user has access to this collection
+	//
+	hasAccess, err := svc.repo.CheckAccess(ctx, collectionID, userID, dom_collection.CollectionPermissionReadOnly)
+	if err != nil {
+		svc.logger.Error("Failed to check collection access",
+			zap.Any("error", err),
+			zap.Any("collection_id", collectionID),
+			zap.Any("user_id", userID))
+		return nil, err
+	}
+
+	if !hasAccess {
+		svc.logger.Warn("Unauthorized collection members access attempt",
+			zap.Any("user_id", userID),
+			zap.Any("collection_id", collectionID))
+		return nil, httperror.NewForForbiddenWithSingleField("message", "You don't have access to this collection")
+	}
+
+	//
+	// STEP 4: Get the page of members from repository
+	//
+	members, nextCursor, err := svc.repo.ListMembers(ctx, collectionID, cursor, limit)
+	if err != nil {
+		svc.logger.Error("Failed to list collection members",
+			zap.Any("error", err),
+			zap.Any("collection_id", collectionID))
+		return nil, httperror.NewForInternalServerErrorWithSingleField("message", "Failed to list collection members")
+	}
+
+	//
+	// STEP 5: Map domain models to response DTO
+	//
+	response := &ListCollectionMembersResponseDTO{
+		CollectionID: collectionID,
+		Members:      make([]*CollectionMemberDTO, 0, len(members)),
+	}
+	for _, member := range members {
+		response.Members = append(response.Members, &CollectionMemberDTO{
+			RecipientID:     member.RecipientID,
+			RecipientEmail:  member.RecipientEmail,
+			PermissionLevel: member.PermissionLevel,
+			GrantedByID:     member.GrantedByID,
+			CreatedAt:       member.CreatedAt,
+			IsInherited:     member.IsInherited,
+			InheritedFromID: member.InheritedFromID,
+			ExpiresAt:       member.ExpiresAt,
+		})
+	}
+	if !nextCursor.IsZero() {
+		response.NextCursor = &nextCursor
+	}
+
+	svc.logger.Debug("Collection members listed",
+		zap.Any("collection_id", collectionID),
+		zap.Int("count", len(response.Members)),
+		zap.Bool("has_more", response.NextCursor != nil))
+
+	return response, nil
+}
@@ -0,0 +1,91 @@
+// internal/maplefile/service/collection/list_members_test.go
+package collection
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/mocks"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+)
+
+func TestListCollectionMembersService_Execute(t *testing.T) {
+	collectionID, userID := gocql.TimeUUID(), gocql.TimeUUID()
+	ctx := context.WithValue(context.Background(), constants.SessionFederatedUserID, userID)
+
+	t.Run("Success - Pages through the members", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		collectionRepo := mocks.NewMockCollectionRepository(ctrl)
+		svc := NewListCollectionMembersService(&config.Configuration{}, zap.NewNop(), collectionRepo)
+
+		member := &dom_collection.CollectionMembership{
+			RecipientID:            gocql.TimeUUID(),
+			RecipientEmail:         "alice@example.com",
+			PermissionLevel:        dom_collection.CollectionPermissionReadWrite,
+			EncryptedCollectionKey: []byte("sealed key"),
+			CreatedAt:              time.Now(),
+		}
+		cursor := dom_collection.MemberCursor{LastRecipientID: gocql.TimeUUID()}
+		nextCursor := dom_collection.MemberCursor{LastRecipientID: member.RecipientID}
+
+		collectionRepo.EXPECT().CheckAccess(gomock.Any(), collectionID, userID, dom_collection.CollectionPermissionReadOnly).Return(true, nil)
+		collectionRepo.EXPECT().ListMembers(gomock.Any(), collectionID, cursor, 10).
+			Return([]*dom_collection.CollectionMembership{member}, nextCursor, nil)
+
+		resp, err := svc.Execute(ctx, collectionID, cursor, 10)
+		require.NoError(t, err)
+		require.Len(t, resp.Members, 1)
+		assert.Equal(t, member.RecipientID, resp.Members[0].RecipientID)
+		assert.Equal(t, member.PermissionLevel, resp.Members[0].PermissionLevel)
+		require.NotNil(t, resp.NextCursor)
+		assert.Equal(t, nextCursor, *resp.NextCursor)
+	})
+
+	t.Run("Success - Last page has no cursor", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		collectionRepo := mocks.NewMockCollectionRepository(ctrl)
+		svc := NewListCollectionMembersService(&config.Configuration{}, zap.NewNop(), collectionRepo)
+
+		collectionRepo.EXPECT().CheckAccess(gomock.Any(), collectionID, userID, dom_collection.CollectionPermissionReadOnly).Return(true, nil)
+		collectionRepo.EXPECT().ListMembers(gomock.Any(), collectionID, dom_collection.MemberCursor{}, 0).
+			Return([]*dom_collection.CollectionMembership{}, dom_collection.MemberCursor{}, nil)
+
+		resp, err := svc.Execute(ctx, collectionID, dom_collection.MemberCursor{}, 0)
+		require.NoError(t, err)
+		assert.Empty(t, resp.Members)
+		assert.Nil(t, resp.NextCursor)
+	})
+
+	t.Run("Forbidden - No access to the collection", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		collectionRepo := mocks.NewMockCollectionRepository(ctrl)
+		svc := NewListCollectionMembersService(&config.Configuration{}, zap.NewNop(), collectionRepo)
+
+		collectionRepo.EXPECT().CheckAccess(gomock.Any(), collectionID, userID, dom_collection.CollectionPermissionReadOnly).Return(false, nil)
+
+		resp, err := svc.Execute(ctx, collectionID, dom_collection.MemberCursor{}, 0)
+		assert.Nil(t, resp)
+
+		var httpErr httperror.HTTPError
+		require.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, http.StatusForbidden, httpErr.Code)
+	})
+}
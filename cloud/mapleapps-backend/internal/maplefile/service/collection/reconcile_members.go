@@ -0,0 +1,129 @@
+// cloud/backend/internal/maplefile/service/collection/reconcile_members.go
+package collection
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	dom_user "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/user"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+)
+
+type ReconcileCollectionMembersRequestDTO struct {
+	ID gocql.UUID `json:"id"`
+}
+
+type ReconcileCollectionMembersResponseDTO struct {
+	Reconciliation *dom_collection.MemberReconciliation `json:"reconciliation"`
+	Success        bool                                 `json:"success"`
+	Message        string                               `json:"message"`
+}
+
+type ReconcileCollectionMembersService interface {
+	Execute(ctx context.Context, req *ReconcileCollectionMembersRequestDTO) (*ReconcileCollectionMembersResponseDTO, error)
+}
+
+type reconcileCollectionMembersServiceImpl struct {
+	config *config.Configuration
+	logger *zap.Logger
+	repo   dom_collection.CollectionRepository
+}
+
+func NewReconcileCollectionMembersService(
+	config *config.Configuration,
+	logger *zap.Logger,
+	repo dom_collection.CollectionRepository,
+) ReconcileCollectionMembersService {
+	logger = logger.Named("ReconcileCollectionMembersService")
+	return &reconcileCollectionMembersServiceImpl{
+		config: config,
+		logger: logger,
+		repo:   repo,
+	}
+}
+
+func (svc *reconcileCollectionMembersServiceImpl) Execute(ctx context.Context, req *ReconcileCollectionMembersRequestDTO) (*ReconcileCollectionMembersResponseDTO, error) {
+	//
+	// STEP 1: Validation
+	//
+	if req == nil {
+		svc.logger.Warn("Failed validation with nil request")
+		return nil, httperror.NewForBadRequestWithSingleField("non_field_error", "Collection ID is required")
+	}
+
+	if req.ID.String() == "" {
+		svc.logger.Warn("Empty collection ID")
+		return nil, httperror.NewForBadRequestWithSingleField("id", "Collection ID is required")
+	}
+
+	//
+	// STEP 2: Get user ID and role from context
+	//
+	userID, ok := ctx.Value(constants.SessionFederatedUserID).(gocql.UUID)
+	if !ok {
+		svc.logger.Error("Failed getting user ID from context")
+		return nil, httperror.NewForInternalServerErrorWithSingleField("message", "Authentication context error")
+	}
+	userRole, _ := ctx.Value(constants.SessionFederatedUserRole).(int8)
+
+	//
+	// STEP 3: Only a root administrator may repair the user access tables
+	//
+	if userRole != dom_user.UserRoleRoot {
+		svc.logger.Warn("Unauthorized member reconciliation attempt",
+			zap.Any("user_id", userID),
+			zap.Any("collection_id", req.ID))
+		return nil, httperror.NewForForbiddenWithSingleField("message", "Only an administrator can reconcile collection members")
+	}
+
+	//
+	// STEP 4: Make sure the collection exists
+	//
+	collection, err := svc.repo.Get(ctx, req.ID)
+	if err != nil {
+		svc.logger.Error("Failed to get collection",
+			zap.Any("error", err),
+			zap.Any("collection_id", req.ID))
+		return nil, err
+	}
+
+	if collection == nil {
+		svc.logger.Debug("Collection not found",
+			zap.Any("collection_id", req.ID))
+		return nil, httperror.NewForNotFoundWithSingleField("message", "Collection not found")
+	}
+
+	//
+	// STEP 5: Compare the user access tables to the members table and repair them
+	//
+	reconciliation, err := svc.repo.ReconcileMembers(ctx, req.ID)
+	if err != nil {
+		svc.logger.Error("Failed to reconcile collection members",
+			zap.Any("error", err),
+			zap.Any("collection_id", req.ID))
+		return nil, err
+	}
+
+	svc.logger.Info("Collection members reconciled",
+		zap.Any("collection_id", req.ID),
+		zap.Int("members_checked", reconciliation.MembersChecked),
+		zap.Int("discrepancies", len(reconciliation.Discrepancies)),
+		zap.Bool("repaired", reconciliation.Repaired),
+		zap.Any("initiated_by", userID))
+
+	message := "Collection members are consistent"
+	if len(reconciliation.Discrepancies) > 0 {
+		message = "Collection members were repaired"
+	}
+
+	return &ReconcileCollectionMembersResponseDTO{
+		Reconciliation: reconciliation,
+		Success:        true,
+		Message:        message,
+	}, nil
+}
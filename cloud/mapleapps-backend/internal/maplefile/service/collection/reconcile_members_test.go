@@ -0,0 +1,99 @@
+// internal/maplefile/service/collection/reconcile_members_test.go
+package collection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	dom_user "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/user"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/mocks"
+)
+
+func TestReconcileCollectionMembersService_Execute(t *testing.T) {
+	collectionID, memberID := gocql.TimeUUID(), gocql.TimeUUID()
+
+	tests := []struct {
+		name            string
+		userRole        int8
+		collection      *dom_collection.Collection
+		reconciliation  *dom_collection.MemberReconciliation
+		expectedError   string
+		expectedMessage string
+	}{
+		{
+			name:       "Success - Consistent members",
+			userRole:   dom_user.UserRoleRoot,
+			collection: &dom_collection.Collection{ID: collectionID},
+			reconciliation: &dom_collection.MemberReconciliation{
+				CollectionID:   collectionID,
+				MembersChecked: 1,
+				Discrepancies:  []*dom_collection.MemberDiscrepancy{},
+			},
+			expectedMessage: "Collection members are consistent",
+		},
+		{
+			name:       "Success - Drifted members are repaired",
+			userRole:   dom_user.UserRoleRoot,
+			collection: &dom_collection.Collection{ID: collectionID},
+			reconciliation: &dom_collection.MemberReconciliation{
+				CollectionID:   collectionID,
+				MembersChecked: 1,
+				Discrepancies: []*dom_collection.MemberDiscrepancy{
+					{RecipientID: memberID, Kind: dom_collection.MemberDiscrepancyMissingAccess},
+				},
+				Repaired: true,
+			},
+			expectedMessage: "Collection members were repaired",
+		},
+		{
+			name:          "Forbidden - Not an administrator",
+			userRole:      dom_user.UserRoleIndividual,
+			expectedError: "Only an administrator can reconcile collection members",
+		},
+		{
+			name:          "Not Found - Collection does not exist",
+			userRole:      dom_user.UserRoleRoot,
+			expectedError: "Collection not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			collectionRepo := mocks.NewMockCollectionRepository(ctrl)
+			svc := NewReconcileCollectionMembersService(&config.Configuration{}, zap.NewNop(), collectionRepo)
+
+			ctx := context.WithValue(context.Background(), constants.SessionFederatedUserID, gocql.TimeUUID())
+			ctx = context.WithValue(ctx, constants.SessionFederatedUserRole, tt.userRole)
+
+			if tt.userRole == dom_user.UserRoleRoot {
+				collectionRepo.EXPECT().Get(gomock.Any(), collectionID).Return(tt.collection, nil)
+			}
+			if tt.reconciliation != nil {
+				collectionRepo.EXPECT().ReconcileMembers(gomock.Any(), collectionID).Return(tt.reconciliation, nil)
+			}
+
+			resp, err := svc.Execute(ctx, &ReconcileCollectionMembersRequestDTO{ID: collectionID})
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, resp)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.reconciliation, resp.Reconciliation)
+			assert.Equal(t, tt.expectedMessage, resp.Message)
+		})
+	}
+}
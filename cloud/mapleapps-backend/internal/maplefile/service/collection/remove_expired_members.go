@@ -0,0 +1,83 @@
+// cloud/backend/internal/maplefile/service/collection/remove_expired_members.go
+package collection
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+)
+
+// ExpiredMembershipRetention is how long an expired membership is kept before it is removed. Until then the
+// collection is reported as deleted in the sync data of the recipient, so devices which were offline when
+// the access expired still drop their local copy.
+const ExpiredMembershipRetention = 30 * 24 * time.Hour
+
+type RemoveExpiredMembersResponseDTO struct {
+	Removed int `json:"removed"`
+	Failed  int `json:"failed"`
+}
+
+type RemoveExpiredMembersService interface {
+	Execute(ctx context.Context) (*RemoveExpiredMembersResponseDTO, error)
+}
+
+type removeExpiredMembersServiceImpl struct {
+	config *config.Configuration
+	logger *zap.Logger
+	repo   dom_collection.CollectionRepository
+}
+
+func NewRemoveExpiredMembersService(
+	config *config.Configuration,
+	logger *zap.Logger,
+	repo dom_collection.CollectionRepository,
+) RemoveExpiredMembersService {
+	logger = logger.Named("RemoveExpiredMembersService")
+	return &removeExpiredMembersServiceImpl{
+		config: config,
+		logger: logger,
+		repo:   repo,
+	}
+}
+
+func (svc *removeExpiredMembersServiceImpl) Execute(ctx context.Context) (*RemoveExpiredMembersResponseDTO, error) {
+	//
+	// STEP 1: Find the memberships which expired before the retention period
+	//
+	expiredBefore := time.Now().Add(-ExpiredMembershipRetention)
+	members, err := svc.repo.ListExpiredMembers(ctx, expiredBefore)
+	if err != nil {
+		svc.logger.Error("Failed to list expired members",
+			zap.Any("error", err))
+		return nil, err
+	}
+
+	//
+	// STEP 2: Remove every expired membership, continuing past failures so one bad collection doesn't block the rest
+	//
+	response := &RemoveExpiredMembersResponseDTO{}
+	for _, member := range members {
+		if err := svc.repo.RemoveMember(ctx, member.CollectionID, member.RecipientID); err != nil {
+			svc.logger.Warn("Failed to remove expired member",
+				zap.Any("collection_id", member.CollectionID),
+				zap.Any("recipient_id", member.RecipientID),
+				zap.Time("expires_at", member.ExpiresAt),
+				zap.Any("error", err))
+			response.Failed++
+			continue
+		}
+		response.Removed++
+	}
+
+	if len(members) > 0 {
+		svc.logger.Info("Removed expired collection members",
+			zap.Int("removed", response.Removed),
+			zap.Int("failed", response.Failed))
+	}
+
+	return response, nil
+}
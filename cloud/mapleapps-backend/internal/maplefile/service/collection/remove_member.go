@@ -3,6 +3,7 @@ package collection
 
 import (
 	"context"
+	"errors"
 
 	"go.uber.org/zap"
 
@@ -120,6 +121,9 @@ func (svc *removeMemberServiceImpl) Execute(ctx context.Context, req *RemoveMemb
 			zap.Any("collection_id", req.CollectionID),
 			zap.Any("recipient_id", req.RecipientID),
 			zap.Bool("remove_from_descendants", req.RemoveFromDescendants))
+		if errors.Is(err2, dom_collection.ErrHierarchyLimitExceeded) {
+			return nil, httperror.NewForBadRequestWithSingleField("remove_from_descendants", "This collection has too many sub-collections to remove the member from them all at once")
+		}
 		return nil, err2
 	}
 
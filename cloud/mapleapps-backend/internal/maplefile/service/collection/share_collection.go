@@ -3,6 +3,7 @@ package collection
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"go.uber.org/zap"
@@ -21,6 +22,8 @@ type ShareCollectionRequestDTO struct {
 	PermissionLevel        string     `json:"permission_level"`
 	EncryptedCollectionKey []byte     `json:"encrypted_collection_key"`
 	ShareWithDescendants   bool       `json:"share_with_descendants"`
+	// ExpiresAt optionally ends the access of the recipient at the given time
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
 }
 
 type ShareCollectionResponseDTO struct {
@@ -68,7 +71,8 @@ func (svc *shareCollectionServiceImpl) Execute(ctx context.Context, req *ShareCo
 		zap.String("recipient_email", req.RecipientEmail),
 		zap.String("permission_level", req.PermissionLevel),
 		zap.Int("encrypted_key_length", len(req.EncryptedCollectionKey)),
-		zap.Bool("share_with_descendants", req.ShareWithDescendants))
+		zap.Bool("share_with_descendants", req.ShareWithDescendants),
+		zap.Time("expires_at", req.ExpiresAt))
 
 	e := make(map[string]string)
 	if req.CollectionID.String() == "" {
@@ -106,6 +110,10 @@ func (svc *shareCollectionServiceImpl) Execute(ctx context.Context, req *ShareCo
 		e["encrypted_collection_key"] = "Encrypted collection key appears to be invalid (too short)"
 	}
 
+	if !req.ExpiresAt.IsZero() && !req.ExpiresAt.After(time.Now()) {
+		e["expires_at"] = "Expiry must be in the future"
+	}
+
 	if len(e) != 0 {
 		svc.logger.Warn("Failed validation",
 			zap.Any("error", e))
@@ -149,7 +157,7 @@ func (svc *shareCollectionServiceImpl) Execute(ctx context.Context, req *ShareCo
 	} else {
 		// Check if user is an admin member
 		for _, member := range collection.Members {
-			if member.RecipientID == userID && member.PermissionLevel == dom_collection.CollectionPermissionAdmin {
+			if member.RecipientID == userID && member.PermissionLevel == dom_collection.CollectionPermissionAdmin && !member.IsExpired(time.Now()) {
 				hasSharePermission = true
 				break
 			}
@@ -193,6 +201,7 @@ func (svc *shareCollectionServiceImpl) Execute(ctx context.Context, req *ShareCo
 		PermissionLevel:        req.PermissionLevel,
 		CreatedAt:              time.Now(),
 		IsInherited:            false,
+		ExpiresAt:              req.ExpiresAt,
 	}
 
 	// DOUBLE-CHECK: Verify the membership has the encrypted key before proceeding
@@ -223,6 +232,12 @@ func (svc *shareCollectionServiceImpl) Execute(ctx context.Context, req *ShareCo
 				zap.Any("error", err),
 				zap.Any("collection_id", req.CollectionID),
 				zap.Any("recipient_id", req.RecipientID))
+			if errors.Is(err, dom_collection.ErrHierarchyLimitExceeded) {
+				return nil, httperror.NewForBadRequestWithSingleField("share_with_descendants", "This collection has too many sub-collections to share them all at once")
+			}
+			if errors.Is(err, dom_collection.ErrMemberLimitExceeded) {
+				return nil, httperror.NewForBadRequestWithSingleField("recipient_id", "This collection has reached the maximum number of members")
+			}
 			return nil, err
 		}
 
@@ -239,6 +254,9 @@ func (svc *shareCollectionServiceImpl) Execute(ctx context.Context, req *ShareCo
 				zap.Any("error", err),
 				zap.Any("collection_id", req.CollectionID),
 				zap.Any("recipient_id", req.RecipientID))
+			if errors.Is(err, dom_collection.ErrMemberLimitExceeded) {
+				return nil, httperror.NewForBadRequestWithSingleField("recipient_id", "This collection has reached the maximum number of members")
+			}
 			return nil, err
 		}
 	}
@@ -249,7 +267,8 @@ func (svc *shareCollectionServiceImpl) Execute(ctx context.Context, req *ShareCo
 		zap.Any("granted_by", userID),
 		zap.String("permission_level", req.PermissionLevel),
 		zap.Bool("shared_with_descendants", req.ShareWithDescendants),
-		zap.Int("memberships_created", membershipsCreated))
+		zap.Int("memberships_created", membershipsCreated),
+		zap.Time("expires_at", req.ExpiresAt))
 
 	return &ShareCollectionResponseDTO{
 		Success:            true,
@@ -3,6 +3,7 @@ package collection
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"go.uber.org/zap"
@@ -132,6 +133,9 @@ func (svc *softDeleteCollectionServiceImpl) Execute(ctx context.Context, req *So
 		svc.logger.Error("Failed to check for descendant collections",
 			zap.Any("error", err),
 			zap.Any("collection_id", req.ID))
+		if errors.Is(err, dom_collection.ErrHierarchyLimitExceeded) {
+			return nil, httperror.NewForBadRequestWithSingleField("id", "This collection has too many sub-collections to delete them all at once")
+		}
 		return nil, err
 	}
 
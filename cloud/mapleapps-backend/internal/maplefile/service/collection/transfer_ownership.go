@@ -0,0 +1,133 @@
+// cloud/backend/internal/maplefile/service/collection/transfer_ownership.go
+package collection
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	dom_user "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/user"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+)
+
+type TransferOwnershipRequestDTO struct {
+	CollectionID gocql.UUID `json:"collection_id"`
+	NewOwnerID   gocql.UUID `json:"new_owner_id"`
+}
+
+type TransferOwnershipResponseDTO struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type TransferOwnershipService interface {
+	Execute(ctx context.Context, req *TransferOwnershipRequestDTO) (*TransferOwnershipResponseDTO, error)
+}
+
+type transferOwnershipServiceImpl struct {
+	config *config.Configuration
+	logger *zap.Logger
+	repo   dom_collection.CollectionRepository
+}
+
+func NewTransferOwnershipService(
+	config *config.Configuration,
+	logger *zap.Logger,
+	repo dom_collection.CollectionRepository,
+) TransferOwnershipService {
+	logger = logger.Named("TransferOwnershipService")
+	return &transferOwnershipServiceImpl{
+		config: config,
+		logger: logger,
+		repo:   repo,
+	}
+}
+
+func (svc *transferOwnershipServiceImpl) Execute(ctx context.Context, req *TransferOwnershipRequestDTO) (*TransferOwnershipResponseDTO, error) {
+	//
+	// STEP 1: Validation
+	//
+	if req == nil {
+		svc.logger.Warn("Failed validation with nil request")
+		return nil, httperror.NewForBadRequestWithSingleField("non_field_error", "Transfer ownership details are required")
+	}
+
+	e := make(map[string]string)
+	if req.CollectionID.String() == "" {
+		e["collection_id"] = "Collection ID is required"
+	}
+	if req.NewOwnerID.String() == "" {
+		e["new_owner_id"] = "New owner ID is required"
+	}
+
+	if len(e) != 0 {
+		svc.logger.Warn("Failed validation",
+			zap.Any("error", e))
+		return nil, httperror.NewForBadRequest(&e)
+	}
+
+	//
+	// STEP 2: Get user ID and role from context
+	//
+	userID, ok := ctx.Value(constants.SessionFederatedUserID).(gocql.UUID)
+	if !ok {
+		svc.logger.Error("Failed getting user ID from context")
+		return nil, httperror.NewForInternalServerErrorWithSingleField("message", "Authentication context error")
+	}
+	userRole, _ := ctx.Value(constants.SessionFederatedUserRole).(int8)
+
+	//
+	// STEP 3: Only the current owner or a root administrator may transfer ownership
+	//
+	isOwner, err := svc.repo.IsCollectionOwner(ctx, req.CollectionID, userID)
+	if err != nil {
+		svc.logger.Error("Failed to check collection ownership",
+			zap.Any("error", err),
+			zap.Any("collection_id", req.CollectionID),
+			zap.Any("user_id", userID))
+		return nil, err
+	}
+
+	if !isOwner && userRole != dom_user.UserRoleRoot {
+		svc.logger.Warn("Unauthorized ownership transfer attempt",
+			zap.Any("user_id", userID),
+			zap.Any("collection_id", req.CollectionID))
+		return nil, httperror.NewForForbiddenWithSingleField("message", "Only the collection owner can transfer ownership")
+	}
+
+	//
+	// STEP 4: Transfer ownership
+	//
+	if err := svc.repo.TransferOwnership(ctx, req.CollectionID, req.NewOwnerID); err != nil {
+		svc.logger.Error("Failed to transfer collection ownership",
+			zap.Any("error", err),
+			zap.Any("collection_id", req.CollectionID),
+			zap.Any("new_owner_id", req.NewOwnerID))
+		switch {
+		case errors.Is(err, dom_collection.ErrCollectionNotFound):
+			return nil, httperror.NewForNotFoundWithSingleField("message", "Collection not found")
+		case errors.Is(err, dom_collection.ErrAlreadyOwner):
+			return nil, httperror.NewForBadRequestWithSingleField("new_owner_id", "This user already owns the collection")
+		case errors.Is(err, dom_collection.ErrNewOwnerNotMember):
+			return nil, httperror.NewForBadRequestWithSingleField("new_owner_id", "Share the collection with the new owner before transferring ownership")
+		case errors.Is(err, dom_collection.ErrNewOwnerKeyInvalid):
+			return nil, httperror.NewForBadRequestWithSingleField("new_owner_id", "The new owner's access to the collection is invalid, share it with them again")
+		}
+		return nil, httperror.NewForInternalServerErrorWithSingleField("message", "Failed to transfer collection ownership")
+	}
+
+	svc.logger.Info("Collection ownership transferred successfully",
+		zap.Any("collection_id", req.CollectionID),
+		zap.Any("new_owner_id", req.NewOwnerID),
+		zap.Any("initiated_by", userID))
+
+	return &TransferOwnershipResponseDTO{
+		Success: true,
+		Message: "Collection ownership transferred successfully",
+	}, nil
+}
@@ -0,0 +1,74 @@
+// internal/maplefile/service/collection/transfer_ownership_test.go
+package collection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	dom_user "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/user"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/mocks"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+)
+
+func TestTransferOwnershipService_Execute(t *testing.T) {
+	collectionID, newOwnerID := gocql.TimeUUID(), gocql.TimeUUID()
+	databaseErr := errors.New("gocql: no hosts available in the pool")
+
+	tests := []struct {
+		name         string
+		transferErr  error
+		expectedCode int
+	}{
+		{name: "Success"},
+		{name: "Not Found - Collection does not exist", transferErr: dom_collection.ErrCollectionNotFound, expectedCode: http.StatusNotFound},
+		{name: "Bad Request - Already the owner", transferErr: dom_collection.ErrAlreadyOwner, expectedCode: http.StatusBadRequest},
+		{name: "Bad Request - New owner is not a member", transferErr: dom_collection.ErrNewOwnerNotMember, expectedCode: http.StatusBadRequest},
+		{name: "Bad Request - New owner key is invalid", transferErr: dom_collection.ErrNewOwnerKeyInvalid, expectedCode: http.StatusBadRequest},
+		{name: "Server Error - Repository failure", transferErr: fmt.Errorf("failed to update collection: %w", databaseErr), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			collectionRepo := mocks.NewMockCollectionRepository(ctrl)
+			svc := NewTransferOwnershipService(&config.Configuration{}, zap.NewNop(), collectionRepo)
+
+			userID := gocql.TimeUUID()
+			ctx := context.WithValue(context.Background(), constants.SessionFederatedUserID, userID)
+			ctx = context.WithValue(ctx, constants.SessionFederatedUserRole, dom_user.UserRoleIndividual)
+
+			collectionRepo.EXPECT().IsCollectionOwner(gomock.Any(), collectionID, userID).Return(true, nil)
+			collectionRepo.EXPECT().TransferOwnership(gomock.Any(), collectionID, newOwnerID).Return(tt.transferErr)
+
+			resp, err := svc.Execute(ctx, &TransferOwnershipRequestDTO{CollectionID: collectionID, NewOwnerID: newOwnerID})
+			if tt.transferErr == nil {
+				require.NoError(t, err)
+				assert.True(t, resp.Success)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Nil(t, resp)
+
+			// The response must not expose the internal error text
+			var httpErr httperror.HTTPError
+			require.True(t, errors.As(err, &httpErr))
+			assert.Equal(t, tt.expectedCode, httpErr.Code)
+			assert.NotContains(t, fmt.Sprint(*httpErr.Errors), tt.transferErr.Error())
+		})
+	}
+}
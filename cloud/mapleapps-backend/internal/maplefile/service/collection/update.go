@@ -108,7 +108,7 @@ func (svc *updateCollectionServiceImpl) Execute(ctx context.Context, req *Update
 		// Check if user is a member with admin permissions
 		isAdmin := false
 		for _, member := range collection.Members {
-			if member.RecipientID == userID && member.PermissionLevel == dom_collection.CollectionPermissionAdmin {
+			if member.RecipientID == userID && member.PermissionLevel == dom_collection.CollectionPermissionAdmin && !member.IsExpired(time.Now()) {
 				isAdmin = true
 				break
 			}
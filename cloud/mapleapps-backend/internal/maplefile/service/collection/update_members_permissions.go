@@ -0,0 +1,159 @@
+// cloud/backend/internal/maplefile/service/collection/update_members_permissions.go
+package collection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	uc_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/usecase/collection"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+)
+
+type MemberPermissionUpdateDTO struct {
+	RecipientID     gocql.UUID `json:"recipient_id"`
+	PermissionLevel string     `json:"permission_level"`
+}
+
+type UpdateMembersPermissionsRequestDTO struct {
+	CollectionID gocql.UUID                  `json:"collection_id"`
+	Members      []MemberPermissionUpdateDTO `json:"members"`
+}
+
+type UpdateMembersPermissionsResponseDTO struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type UpdateMembersPermissionsService interface {
+	Execute(ctx context.Context, req *UpdateMembersPermissionsRequestDTO) (*UpdateMembersPermissionsResponseDTO, error)
+}
+
+type updateMembersPermissionsServiceImpl struct {
+	config                          *config.Configuration
+	logger                          *zap.Logger
+	repo                            dom_collection.CollectionRepository
+	updateMembersPermissionsUseCase uc_collection.UpdateMembersPermissionsUseCase
+}
+
+func NewUpdateMembersPermissionsService(
+	config *config.Configuration,
+	logger *zap.Logger,
+	repo dom_collection.CollectionRepository,
+	updateMembersPermissionsUseCase uc_collection.UpdateMembersPermissionsUseCase,
+) UpdateMembersPermissionsService {
+	logger = logger.Named("UpdateMembersPermissionsService")
+	return &updateMembersPermissionsServiceImpl{
+		config:                          config,
+		logger:                          logger,
+		repo:                            repo,
+		updateMembersPermissionsUseCase: updateMembersPermissionsUseCase,
+	}
+}
+
+// Execute changes the permission of many members of a collection in a single collection update,
+// so reorganizing the access of a team doesn't rewrite the collection once per member.
+func (svc *updateMembersPermissionsServiceImpl) Execute(ctx context.Context, req *UpdateMembersPermissionsRequestDTO) (*UpdateMembersPermissionsResponseDTO, error) {
+	//
+	// STEP 1: Validation
+	//
+	if req == nil {
+		svc.logger.Warn("Failed validation with nil request")
+		return nil, httperror.NewForBadRequestWithSingleField("non_field_error", "Permission update details are required")
+	}
+
+	e := make(map[string]string)
+	if req.CollectionID.String() == "" {
+		e["collection_id"] = "Collection ID is required"
+	}
+	if len(req.Members) == 0 {
+		e["members"] = "At least one permission update is required"
+	}
+	updates := make(map[gocql.UUID]string, len(req.Members))
+	for i, member := range req.Members {
+		if member.RecipientID.String() == "" {
+			e[fmt.Sprintf("members[%d].recipient_id", i)] = "Recipient ID is required"
+		} else if _, ok := updates[member.RecipientID]; ok {
+			e[fmt.Sprintf("members[%d].recipient_id", i)] = "Recipient is duplicated in the request"
+		}
+		updates[member.RecipientID] = member.PermissionLevel
+	}
+
+	if len(e) != 0 {
+		svc.logger.Warn("Failed validation",
+			zap.Any("error", e))
+		return nil, httperror.NewForBadRequest(&e)
+	}
+
+	//
+	// STEP 2: Get user ID from context
+	//
+	userID, ok := ctx.Value(constants.SessionFederatedUserID).(gocql.UUID)
+	if !ok {
+		svc.logger.Error("Failed getting user ID from context")
+		return nil, httperror.NewForInternalServerErrorWithSingleField("message", "Authentication context error")
+	}
+
+	//
+	// STEP 3: Check if user has admin access to the collection
+	//
+	hasAccess, err := svc.repo.CheckAccess(ctx, req.CollectionID, userID, dom_collection.CollectionPermissionAdmin)
+	if err != nil {
+		svc.logger.Error("Failed to check access",
+			zap.Any("error", err),
+			zap.Any("collection_id", req.CollectionID),
+			zap.Any("user_id", userID))
+		return nil, err
+	}
+
+	// Collection owners and admin members can change member permissions
+	if !hasAccess {
+		isOwner, _ := svc.repo.IsCollectionOwner(ctx, req.CollectionID, userID)
+
+		if !isOwner {
+			svc.logger.Warn("Unauthorized member permissions update attempt",
+				zap.Any("user_id", userID),
+				zap.Any("collection_id", req.CollectionID))
+			return nil, httperror.NewForForbiddenWithSingleField("message", "You don't have permission to change member permissions of this collection")
+		}
+	}
+
+	//
+	// STEP 4: Update the permissions
+	//
+	if err := svc.updateMembersPermissionsUseCase.Execute(ctx, req.CollectionID, updates); err != nil {
+		svc.logger.Error("Failed to update member permissions",
+			zap.Any("error", err),
+			zap.Any("collection_id", req.CollectionID),
+			zap.Int("updates", len(updates)))
+
+		var httpErr httperror.HTTPError
+		switch {
+		case errors.As(err, &httpErr):
+			return nil, err
+		case errors.Is(err, dom_collection.ErrCollectionNotFound):
+			return nil, httperror.NewForNotFoundWithSingleField("message", "Collection not found")
+		case errors.Is(err, dom_collection.ErrMembersNotFound):
+			return nil, httperror.NewForBadRequestWithSingleField("members", err.Error())
+		case errors.Is(err, dom_collection.ErrCannotDemoteOwner):
+			return nil, httperror.NewForBadRequestWithSingleField("members", "The collection owner must keep admin permission")
+		default:
+			return nil, httperror.NewForInternalServerErrorWithSingleField("message", "Failed to update member permissions")
+		}
+	}
+
+	svc.logger.Info("Member permissions updated successfully",
+		zap.Any("collection_id", req.CollectionID),
+		zap.Int("updates", len(updates)))
+
+	return &UpdateMembersPermissionsResponseDTO{
+		Success: true,
+		Message: "Member permissions updated successfully",
+	}, nil
+}
@@ -0,0 +1,115 @@
+// internal/maplefile/service/collection/update_members_permissions_test.go
+package collection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/mocks"
+	uc_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/usecase/collection"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+)
+
+func TestUpdateMembersPermissionsService_Execute(t *testing.T) {
+	collectionID, memberID := gocql.TimeUUID(), gocql.TimeUUID()
+	databaseErr := errors.New("gocql: no hosts available in the pool")
+
+	tests := []struct {
+		name         string
+		permission   string
+		isAdmin      bool
+		isOwner      bool
+		updateErr    error
+		expectUpdate bool
+		expectedCode int
+	}{
+		{name: "Success - Admin member", permission: dom_collection.CollectionPermissionReadWrite, isAdmin: true, expectUpdate: true},
+		{name: "Success - Owner", permission: dom_collection.CollectionPermissionReadOnly, isOwner: true, expectUpdate: true},
+		{name: "Forbidden - Not an admin", permission: dom_collection.CollectionPermissionReadOnly, expectedCode: http.StatusForbidden},
+		{name: "Bad Request - Invalid permission", permission: "superuser", isAdmin: true, expectedCode: http.StatusBadRequest},
+		{name: "Not Found - Collection does not exist", permission: dom_collection.CollectionPermissionReadOnly, isAdmin: true, expectUpdate: true, updateErr: dom_collection.ErrCollectionNotFound, expectedCode: http.StatusNotFound},
+		{name: "Bad Request - Recipient is not a member", permission: dom_collection.CollectionPermissionReadOnly, isAdmin: true, expectUpdate: true, updateErr: fmt.Errorf("%w: %s", dom_collection.ErrMembersNotFound, memberID), expectedCode: http.StatusBadRequest},
+		{name: "Bad Request - Owner demoted", permission: dom_collection.CollectionPermissionReadOnly, isAdmin: true, expectUpdate: true, updateErr: dom_collection.ErrCannotDemoteOwner, expectedCode: http.StatusBadRequest},
+		{name: "Server Error - Repository failure", permission: dom_collection.CollectionPermissionReadOnly, isAdmin: true, expectUpdate: true, updateErr: fmt.Errorf("failed to update collection: %w", databaseErr), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			collectionRepo := mocks.NewMockCollectionRepository(ctrl)
+			useCase := uc_collection.NewUpdateMembersPermissionsUseCase(&config.Configuration{}, zap.NewNop(), collectionRepo)
+			svc := NewUpdateMembersPermissionsService(&config.Configuration{}, zap.NewNop(), collectionRepo, useCase)
+
+			userID := gocql.TimeUUID()
+			ctx := context.WithValue(context.Background(), constants.SessionFederatedUserID, userID)
+
+			collectionRepo.EXPECT().CheckAccess(gomock.Any(), collectionID, userID, dom_collection.CollectionPermissionAdmin).Return(tt.isAdmin, nil)
+			if !tt.isAdmin {
+				collectionRepo.EXPECT().IsCollectionOwner(gomock.Any(), collectionID, userID).Return(tt.isOwner, nil)
+			}
+			if tt.expectUpdate {
+				collectionRepo.EXPECT().
+					UpdateMembersPermissions(gomock.Any(), collectionID, map[gocql.UUID]string{memberID: tt.permission}).
+					Return(tt.updateErr)
+			}
+
+			resp, err := svc.Execute(ctx, &UpdateMembersPermissionsRequestDTO{
+				CollectionID: collectionID,
+				Members:      []MemberPermissionUpdateDTO{{RecipientID: memberID, PermissionLevel: tt.permission}},
+			})
+			if tt.expectedCode == 0 {
+				require.NoError(t, err)
+				assert.True(t, resp.Success)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Nil(t, resp)
+
+			var httpErr httperror.HTTPError
+			require.True(t, errors.As(err, &httpErr))
+			assert.Equal(t, tt.expectedCode, httpErr.Code)
+			assert.NotContains(t, fmt.Sprint(*httpErr.Errors), databaseErr.Error())
+		})
+	}
+}
+
+func TestUpdateMembersPermissionsService_RejectsDuplicateRecipients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	collectionRepo := mocks.NewMockCollectionRepository(ctrl)
+	useCase := uc_collection.NewUpdateMembersPermissionsUseCase(&config.Configuration{}, zap.NewNop(), collectionRepo)
+	svc := NewUpdateMembersPermissionsService(&config.Configuration{}, zap.NewNop(), collectionRepo, useCase)
+
+	memberID := gocql.TimeUUID()
+	resp, err := svc.Execute(context.Background(), &UpdateMembersPermissionsRequestDTO{
+		CollectionID: gocql.TimeUUID(),
+		Members: []MemberPermissionUpdateDTO{
+			{RecipientID: memberID, PermissionLevel: dom_collection.CollectionPermissionReadOnly},
+			{RecipientID: memberID, PermissionLevel: dom_collection.CollectionPermissionAdmin},
+		},
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, resp)
+
+	var httpErr httperror.HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+	assert.Contains(t, *httpErr.Errors, "members[1].recipient_id")
+}
@@ -27,9 +27,10 @@ func mapMembershipDTOToDomain(dto *CollectionMembershipDTO) dom_collection.Colle
 }
 
 // Helper function to map a CreateCollectionRequestDTO to a Collection domain model.
-// This function recursively maps all fields, including nested members and children,
-// copying values directly from the DTO. Server-side overrides for fields like
-// ID, OwnerID, timestamps, and version are applied *after* this mapping in the Execute method.
+// This function maps all fields, including nested members, copying values directly from
+// the DTO. Children are not mapped here; the create service maps each of them separately.
+// Server-side overrides for fields like ID, OwnerID, timestamps, and version are applied
+// *after* this mapping by the create service, for the root and every child alike.
 // userID and now are passed for potential use in recursive calls if needed for consistency,
 // though the primary goal here is to copy DTO values.
 func mapCollectionDTOToDomain(dto *CreateCollectionRequestDTO, userID gocql.UUID, now time.Time) *dom_collection.Collection {
@@ -105,6 +106,7 @@ func mapCollectionToDTO(collection *dom_collection.Collection) *CollectionRespon
 			IsInherited:     member.IsInherited,
 			InheritedFromID: member.InheritedFromID,
 			CreatedAt:       member.CreatedAt,
+			ExpiresAt:       member.ExpiresAt,
 			// Note: EncryptedCollectionKey for this member is recipient-specific
 			// and should NOT be included in a general response DTO unless
 			// filtered for the specific recipient receiving the response.
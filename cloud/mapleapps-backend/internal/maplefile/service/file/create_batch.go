@@ -0,0 +1,305 @@
+// cloud/backend/internal/maplefile/service/file/create_batch.go
+package file
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	dom_file "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/file"
+	uc_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/usecase/collection"
+	uc_filemetadata "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/usecase/filemetadata"
+	uc_fileobjectstorage "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/usecase/fileobjectstorage"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+)
+
+type CreateFileBatchRequestDTO struct {
+	CollectionID gocql.UUID                     `json:"collection_id"`
+	Files        []*CreatePendingFileRequestDTO `json:"files"`
+}
+
+type CreateFileBatchResponseDTO struct {
+	Files   []*FileResponseDTO `json:"files"`
+	Success bool               `json:"success"`
+	Message string             `json:"message"`
+}
+
+type CreateFileBatchService interface {
+	Execute(ctx context.Context, req *CreateFileBatchRequestDTO) (*CreateFileBatchResponseDTO, error)
+}
+
+type createFileBatchServiceImpl struct {
+	config                       *config.Configuration
+	logger                       *zap.Logger
+	checkCollectionAccessUseCase uc_collection.CheckCollectionAccessUseCase
+	getCollectionUseCase         uc_collection.GetCollectionUseCase
+	checkFileExistsUseCase       uc_filemetadata.CheckFileExistsUseCase
+	createManyMetadataUseCase    uc_filemetadata.CreateManyFileMetadataUseCase
+	verifyObjectExistsUseCase    uc_fileobjectstorage.VerifyObjectExistsUseCase
+	getObjectSizeUseCase         uc_fileobjectstorage.GetObjectSizeUseCase
+	deleteMultipleDataUseCase    uc_fileobjectstorage.DeleteMultipleEncryptedDataUseCase
+}
+
+func NewCreateFileBatchService(
+	config *config.Configuration,
+	logger *zap.Logger,
+	checkCollectionAccessUseCase uc_collection.CheckCollectionAccessUseCase,
+	getCollectionUseCase uc_collection.GetCollectionUseCase,
+	checkFileExistsUseCase uc_filemetadata.CheckFileExistsUseCase,
+	createManyMetadataUseCase uc_filemetadata.CreateManyFileMetadataUseCase,
+	verifyObjectExistsUseCase uc_fileobjectstorage.VerifyObjectExistsUseCase,
+	getObjectSizeUseCase uc_fileobjectstorage.GetObjectSizeUseCase,
+	deleteMultipleDataUseCase uc_fileobjectstorage.DeleteMultipleEncryptedDataUseCase,
+) CreateFileBatchService {
+	logger = logger.Named("CreateFileBatchService")
+	return &createFileBatchServiceImpl{
+		config:                       config,
+		logger:                       logger,
+		checkCollectionAccessUseCase: checkCollectionAccessUseCase,
+		getCollectionUseCase:         getCollectionUseCase,
+		checkFileExistsUseCase:       checkFileExistsUseCase,
+		createManyMetadataUseCase:    createManyMetadataUseCase,
+		verifyObjectExistsUseCase:    verifyObjectExistsUseCase,
+		getObjectSizeUseCase:         getObjectSizeUseCase,
+		deleteMultipleDataUseCase:    deleteMultipleDataUseCase,
+	}
+}
+
+// Execute registers the metadata of a batch of already uploaded files in a single logged batch, so
+// either every file in the batch becomes active or none of them does. If the batch cannot be
+// registered the uploaded objects are deleted so they are not orphaned in object storage.
+func (svc *createFileBatchServiceImpl) Execute(ctx context.Context, req *CreateFileBatchRequestDTO) (*CreateFileBatchResponseDTO, error) {
+	//
+	// STEP 1: Validation
+	//
+	if req == nil {
+		svc.logger.Warn("⚠️ Failed validation with nil request")
+		return nil, httperror.NewForBadRequestWithSingleField("non_field_error", "Batch creation details are required")
+	}
+
+	e := make(map[string]string)
+	if req.CollectionID.String() == "" {
+		e["collection_id"] = "Collection ID is required"
+	}
+	if len(req.Files) == 0 {
+		e["files"] = "Files are required"
+	} else if len(req.Files) > MaxFileBatchSize {
+		e["files"] = fmt.Sprintf("A batch cannot contain more than %d files", MaxFileBatchSize)
+	} else {
+		seen := make(map[gocql.UUID]bool, len(req.Files))
+		for i, item := range req.Files {
+			if item == nil {
+				e[fmt.Sprintf("files[%d]", i)] = "File is required"
+				continue
+			}
+			if item.ID.String() == "" {
+				e[fmt.Sprintf("files[%d].id", i)] = "Client-side generated ID is required"
+			} else if seen[item.ID] {
+				e[fmt.Sprintf("files[%d].id", i)] = "Client-side generated ID is duplicated in the batch"
+			} else {
+				seen[item.ID] = true
+				doesExist, err := svc.checkFileExistsUseCase.Execute(item.ID)
+				if err != nil {
+					e[fmt.Sprintf("files[%d].id", i)] = fmt.Sprintf("Client-side generated ID causes error: %v", item.ID)
+				} else if doesExist {
+					e[fmt.Sprintf("files[%d].id", i)] = "Client-side generated ID already exists"
+				}
+			}
+			if item.CollectionID.String() != "" && item.CollectionID != req.CollectionID {
+				e[fmt.Sprintf("files[%d].collection_id", i)] = "Every file in a batch must belong to the batch collection"
+			}
+			if item.EncryptedMetadata == "" {
+				e[fmt.Sprintf("files[%d].encrypted_metadata", i)] = "Encrypted metadata is required"
+			}
+			if len(item.EncryptedFileKey.Ciphertext) == 0 {
+				e[fmt.Sprintf("files[%d].encrypted_file_key", i)] = "Encrypted file key is required"
+			}
+			if item.EncryptionVersion == "" {
+				e[fmt.Sprintf("files[%d].encryption_version", i)] = "Encryption version is required"
+			}
+			if item.EncryptedHash == "" {
+				e[fmt.Sprintf("files[%d].encrypted_hash", i)] = "Encrypted hash is required"
+			}
+		}
+	}
+	if len(e) != 0 {
+		svc.logger.Warn("⚠️ Failed validation",
+			zap.Any("error", e))
+		return nil, httperror.NewForBadRequest(&e)
+	}
+
+	//
+	// STEP 2: Get user ID from context
+	//
+	userID, ok := ctx.Value(constants.SessionFederatedUserID).(gocql.UUID)
+	if !ok {
+		svc.logger.Error("❌ Failed getting user ID from context")
+		return nil, httperror.NewForInternalServerErrorWithSingleField("message", "Authentication context error")
+	}
+
+	//
+	// STEP 3: Check the collection exists and the user has write access to it
+	//
+	if err := validateCollectionAcceptsFiles(ctx, svc.getCollectionUseCase, req.CollectionID); err != nil {
+		svc.logger.Warn("⚠️ Collection does not accept new files",
+			zap.Any("error", err),
+			zap.Any("collection_id", req.CollectionID),
+			zap.Any("user_id", userID))
+		return nil, err
+	}
+
+	hasAccess, err := svc.checkCollectionAccessUseCase.Execute(ctx, req.CollectionID, userID, dom_collection.CollectionPermissionReadWrite)
+	if err != nil {
+		svc.logger.Error("❌ Failed to check collection access",
+			zap.Any("error", err),
+			zap.Any("collection_id", req.CollectionID),
+			zap.Any("user_id", userID))
+		return nil, err
+	}
+	if !hasAccess {
+		svc.logger.Warn("⚠️ Unauthorized batch file creation attempt",
+			zap.Any("user_id", userID),
+			zap.Any("collection_id", req.CollectionID))
+		return nil, httperror.NewForForbiddenWithSingleField("message", "You don't have permission to create files in this collection")
+	}
+
+	//
+	// STEP 4: Verify every file was uploaded and get the actual sizes
+	//
+	// Every object the client may have uploaded for this batch, deleted if the batch fails so
+	// nothing is left behind which no metadata refers to. The client has to prepare a new batch.
+	batchObjectKeys := make([]string, 0, len(req.Files)*2)
+	for _, item := range req.Files {
+		batchObjectKeys = append(batchObjectKeys, generateStoragePath(userID.String(), item.ID.String()))
+		if item.ExpectedThumbnailSizeInBytes > 0 {
+			batchObjectKeys = append(batchObjectKeys, generateThumbnailStoragePath(userID.String(), item.ID.String()))
+		}
+	}
+
+	now := time.Now()
+	files := make([]*dom_file.File, 0, len(req.Files))
+	for i, item := range req.Files {
+		storagePath := generateStoragePath(userID.String(), item.ID.String())
+		fileExists, err := svc.verifyObjectExistsUseCase.Execute(storagePath)
+		if err != nil {
+			svc.logger.Error("❌ Failed to verify file exists in storage",
+				zap.Any("error", err),
+				zap.Any("file_id", item.ID),
+				zap.String("storage_path", storagePath))
+			svc.deleteBatchObjects(batchObjectKeys)
+			return nil, httperror.NewForInternalServerErrorWithSingleField("message", "Failed to verify file upload")
+		}
+		if !fileExists {
+			svc.logger.Warn("⚠️ File does not exist in storage",
+				zap.Any("file_id", item.ID),
+				zap.String("storage_path", storagePath))
+			svc.deleteBatchObjects(batchObjectKeys)
+			return nil, httperror.NewForBadRequestWithSingleField(fmt.Sprintf("files[%d].id", i), "File has not been uploaded yet")
+		}
+
+		actualFileSize, err := svc.getObjectSizeUseCase.Execute(storagePath)
+		if err != nil {
+			svc.logger.Error("❌ Failed to get file size from storage",
+				zap.Any("error", err),
+				zap.Any("file_id", item.ID),
+				zap.String("storage_path", storagePath))
+			svc.deleteBatchObjects(batchObjectKeys)
+			return nil, httperror.NewForInternalServerErrorWithSingleField("message", "Failed to verify file size")
+		}
+
+		// The thumbnail is optional, a missing one does not fail the batch.
+		var thumbnailStoragePath string
+		var actualThumbnailSize int64
+		if item.ExpectedThumbnailSizeInBytes > 0 {
+			candidatePath := generateThumbnailStoragePath(userID.String(), item.ID.String())
+			thumbnailExists, err := svc.verifyObjectExistsUseCase.Execute(candidatePath)
+			if err == nil && thumbnailExists {
+				actualThumbnailSize, err = svc.getObjectSizeUseCase.Execute(candidatePath)
+				if err == nil {
+					thumbnailStoragePath = candidatePath
+				}
+			}
+			if thumbnailStoragePath == "" {
+				svc.logger.Warn("⚠️ Thumbnail could not be verified, continuing without it",
+					zap.Any("error", err),
+					zap.Any("file_id", item.ID),
+					zap.String("thumbnail_storage_path", candidatePath))
+				actualThumbnailSize = 0
+			}
+		}
+
+		files = append(files, &dom_file.File{
+			ID:                            item.ID,
+			CollectionID:                  req.CollectionID,
+			OwnerID:                       userID,
+			EncryptedMetadata:             item.EncryptedMetadata,
+			EncryptedFileKey:              item.EncryptedFileKey,
+			EncryptionVersion:             item.EncryptionVersion,
+			EncryptedHash:                 item.EncryptedHash,
+			EncryptedHashAlgorithm:        item.EncryptedHashAlgorithm,
+			EncryptedFileObjectKey:        storagePath,
+			EncryptedFileSizeInBytes:      actualFileSize,
+			EncryptedThumbnailObjectKey:   thumbnailStoragePath,
+			EncryptedThumbnailSizeInBytes: actualThumbnailSize,
+			CreatedAt:                     now,
+			CreatedByUserID:               userID,
+			ModifiedAt:                    now,
+			ModifiedByUserID:              userID,
+			Version:                       1,                        // File creation always starts mutation version at 1.
+			State:                         dom_file.FileStateActive, // The content is already uploaded so no pending state is needed.
+		})
+	}
+
+	//
+	// STEP 5: Register all the file metadata in a single transaction
+	//
+	if err := svc.createManyMetadataUseCase.Execute(files); err != nil {
+		svc.logger.Error("❌ Failed to create file metadata batch",
+			zap.Any("error", err),
+			zap.Any("collection_id", req.CollectionID),
+			zap.Int("file_count", len(files)))
+		svc.deleteBatchObjects(batchObjectKeys)
+		return nil, err
+	}
+
+	//
+	// STEP 6: Prepare response
+	//
+	fileDTOs := make([]*FileResponseDTO, 0, len(files))
+	for _, file := range files {
+		fileDTOs = append(fileDTOs, mapFileToDTO(file))
+	}
+
+	svc.logger.Info("✅ File batch created successfully",
+		zap.Any("collection_id", req.CollectionID),
+		zap.Any("owner_id", userID),
+		zap.Int("file_count", len(files)))
+
+	return &CreateFileBatchResponseDTO{
+		Files:   fileDTOs,
+		Success: true,
+		Message: fmt.Sprintf("%d files created successfully", len(files)),
+	}, nil
+}
+
+// deleteBatchObjects removes the objects of a batch which could not be registered. Failures are
+// only logged since the original error is more useful to the client.
+func (svc *createFileBatchServiceImpl) deleteBatchObjects(storagePaths []string) {
+	if len(storagePaths) == 0 {
+		return
+	}
+	if err := svc.deleteMultipleDataUseCase.Execute(storagePaths); err != nil {
+		svc.logger.Error("❌ Failed to delete uploaded objects of failed batch",
+			zap.Any("error", err),
+			zap.Strings("storage_paths", storagePaths))
+		return
+	}
+	svc.logger.Info("🗑️ Deleted uploaded objects of failed batch",
+		zap.Int("object_count", len(storagePaths)))
+}
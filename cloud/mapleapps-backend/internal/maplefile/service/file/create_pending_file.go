@@ -27,6 +27,8 @@ type CreatePendingFileRequestDTO struct {
 	EncryptedFileKey  keys.EncryptedFileKey `json:"encrypted_file_key"`
 	EncryptionVersion string                `json:"encryption_version"`
 	EncryptedHash     string                `json:"encrypted_hash"`
+	// Optional: algorithm used to compute the encrypted hash, empty means the default algorithm
+	EncryptedHashAlgorithm string `json:"encrypted_hash_algorithm,omitempty"`
 	// Optional: expected file size for validation (in bytes)
 	ExpectedFileSizeInBytes int64 `json:"expected_file_size_in_bytes,omitempty"`
 	// Optional: expected thumbnail size for validation (in bytes)
@@ -41,6 +43,7 @@ type FileResponseDTO struct {
 	EncryptedFileKey              keys.EncryptedFileKey `json:"encrypted_file_key"`
 	EncryptionVersion             string                `json:"encryption_version"`
 	EncryptedHash                 string                `json:"encrypted_hash"`
+	EncryptedHashAlgorithm        string                `json:"encrypted_hash_algorithm,omitempty"`
 	EncryptedFileSizeInBytes      int64                 `json:"encrypted_file_size_in_bytes"`
 	EncryptedThumbnailSizeInBytes int64                 `json:"encrypted_thumbnail_size_in_bytes"`
 	CreatedAt                     time.Time             `json:"created_at"`
@@ -68,6 +71,7 @@ type createPendingFileServiceImpl struct {
 	config                            *config.Configuration
 	logger                            *zap.Logger
 	checkCollectionAccessUseCase      uc_collection.CheckCollectionAccessUseCase
+	getCollectionUseCase              uc_collection.GetCollectionUseCase
 	checkFileExistsUseCase            uc_filemetadata.CheckFileExistsUseCase
 	createMetadataUseCase             uc_filemetadata.CreateFileMetadataUseCase
 	generatePresignedUploadURLUseCase uc_fileobjectstorage.GeneratePresignedUploadURLUseCase
@@ -77,6 +81,7 @@ func NewCreatePendingFileService(
 	config *config.Configuration,
 	logger *zap.Logger,
 	checkCollectionAccessUseCase uc_collection.CheckCollectionAccessUseCase,
+	getCollectionUseCase uc_collection.GetCollectionUseCase,
 	checkFileExistsUseCase uc_filemetadata.CheckFileExistsUseCase,
 	createMetadataUseCase uc_filemetadata.CreateFileMetadataUseCase,
 	generatePresignedUploadURLUseCase uc_fileobjectstorage.GeneratePresignedUploadURLUseCase,
@@ -86,6 +91,7 @@ func NewCreatePendingFileService(
 		config:                            config,
 		logger:                            logger,
 		checkCollectionAccessUseCase:      checkCollectionAccessUseCase,
+		getCollectionUseCase:              getCollectionUseCase,
 		checkFileExistsUseCase:            checkFileExistsUseCase,
 		createMetadataUseCase:             createMetadataUseCase,
 		generatePresignedUploadURLUseCase: generatePresignedUploadURLUseCase,
@@ -144,8 +150,16 @@ func (svc *createPendingFileServiceImpl) Execute(ctx context.Context, req *Creat
 	}
 
 	//
-	// STEP 3: Check if user has write access to the collection
+	// STEP 3: Check the collection exists and the user has write access to it
 	//
+	if err := validateCollectionAcceptsFiles(ctx, svc.getCollectionUseCase, req.CollectionID); err != nil {
+		svc.logger.Warn("⚠️ Collection does not accept new files",
+			zap.Any("error", err),
+			zap.Any("collection_id", req.CollectionID),
+			zap.Any("user_id", userID))
+		return nil, err
+	}
+
 	hasAccess, err := svc.checkCollectionAccessUseCase.Execute(ctx, req.CollectionID, userID, dom_collection.CollectionPermissionReadWrite)
 	if err != nil {
 		svc.logger.Error("❌ Failed to check collection access",
@@ -207,6 +221,7 @@ func (svc *createPendingFileServiceImpl) Execute(ctx context.Context, req *Creat
 		EncryptedFileKey:              req.EncryptedFileKey,
 		EncryptionVersion:             req.EncryptionVersion,
 		EncryptedHash:                 req.EncryptedHash,
+		EncryptedHashAlgorithm:        req.EncryptedHashAlgorithm,
 		EncryptedFileObjectKey:        storagePath,
 		EncryptedFileSizeInBytes:      req.ExpectedFileSizeInBytes, // Will be updated when upload completes
 		EncryptedThumbnailObjectKey:   thumbnailStoragePath,
@@ -0,0 +1,232 @@
+// cloud/backend/internal/maplefile/service/file/get_presigned_download_urls.go
+package file
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	dom_file "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/file"
+	uc_filemetadata "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/usecase/filemetadata"
+	uc_fileobjectstorage "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/usecase/fileobjectstorage"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+)
+
+// MaxDownloadURLBatchSize is the maximum number of files in one presigned download URLs request
+const MaxDownloadURLBatchSize = 100
+
+type GetPresignedDownloadURLsRequestDTO struct {
+	FileIDs     []gocql.UUID  `json:"file_ids"`
+	URLDuration time.Duration `json:"url_duration,omitempty"` // Optional, defaults to 1 hour
+}
+
+type GetPresignedDownloadURLsResponseDTO struct {
+	DownloadURLs  map[gocql.UUID]string `json:"download_urls"`
+	ThumbnailURLs map[gocql.UUID]string `json:"thumbnail_urls,omitempty"`
+	// Errors explains why no URL was generated for a file, such as it not existing or the user
+	// lacking access to its collection
+	Errors                    map[gocql.UUID]string `json:"errors,omitempty"`
+	DownloadURLExpirationTime time.Time             `json:"download_url_expiration_time"`
+	Success                   bool                  `json:"success"`
+	Message                   string                `json:"message"`
+}
+
+type GetPresignedDownloadURLsService interface {
+	Execute(ctx context.Context, req *GetPresignedDownloadURLsRequestDTO) (*GetPresignedDownloadURLsResponseDTO, error)
+}
+
+type getPresignedDownloadURLsServiceImpl struct {
+	config                               *config.Configuration
+	logger                               *zap.Logger
+	collectionRepo                       dom_collection.CollectionRepository
+	getMetadataByIDsUseCase              uc_filemetadata.GetFileMetadataByIDsUseCase
+	generatePresignedDownloadURLsUseCase uc_fileobjectstorage.GeneratePresignedDownloadURLsUseCase
+}
+
+func NewGetPresignedDownloadURLsService(
+	config *config.Configuration,
+	logger *zap.Logger,
+	collectionRepo dom_collection.CollectionRepository,
+	getMetadataByIDsUseCase uc_filemetadata.GetFileMetadataByIDsUseCase,
+	generatePresignedDownloadURLsUseCase uc_fileobjectstorage.GeneratePresignedDownloadURLsUseCase,
+) GetPresignedDownloadURLsService {
+	logger = logger.Named("GetPresignedDownloadURLsService")
+	return &getPresignedDownloadURLsServiceImpl{
+		config:                               config,
+		logger:                               logger,
+		collectionRepo:                       collectionRepo,
+		getMetadataByIDsUseCase:              getMetadataByIDsUseCase,
+		generatePresignedDownloadURLsUseCase: generatePresignedDownloadURLsUseCase,
+	}
+}
+
+// Execute generates the presigned download URLs of many files in one request, so batch onloads
+// don't need a round-trip per file. Files which don't exist or which the user can't read are
+// reported in the errors of the response instead of failing the whole request.
+func (svc *getPresignedDownloadURLsServiceImpl) Execute(ctx context.Context, req *GetPresignedDownloadURLsRequestDTO) (*GetPresignedDownloadURLsResponseDTO, error) {
+	//
+	// STEP 1: Validation
+	//
+	if req == nil {
+		svc.logger.Warn("⚠️ Failed validation with nil request")
+		return nil, httperror.NewForBadRequestWithSingleField("non_field_error", "Request details are required")
+	}
+
+	e := make(map[string]string)
+	if len(req.FileIDs) == 0 {
+		e["file_ids"] = "File IDs are required"
+	} else if len(req.FileIDs) > MaxDownloadURLBatchSize {
+		e["file_ids"] = fmt.Sprintf("A request cannot contain more than %d files", MaxDownloadURLBatchSize)
+	} else {
+		seen := make(map[gocql.UUID]bool, len(req.FileIDs))
+		for i, fileID := range req.FileIDs {
+			if fileID.String() == "" {
+				e[fmt.Sprintf("file_ids[%d]", i)] = "File ID is required"
+			} else if seen[fileID] {
+				e[fmt.Sprintf("file_ids[%d]", i)] = "File ID is duplicated in the request"
+			}
+			seen[fileID] = true
+		}
+	}
+	if len(e) != 0 {
+		svc.logger.Warn("⚠️ Failed validation",
+			zap.Any("error", e))
+		return nil, httperror.NewForBadRequest(&e)
+	}
+
+	// Set default URL duration if not provided
+	if req.URLDuration == 0 {
+		req.URLDuration = 1 * time.Hour
+	}
+
+	//
+	// STEP 2: Get user ID from context
+	//
+	userID, ok := ctx.Value(constants.SessionFederatedUserID).(gocql.UUID)
+	if !ok {
+		svc.logger.Error("🔴 Failed getting user ID from context")
+		return nil, httperror.NewForInternalServerErrorWithSingleField("message", "Authentication context error")
+	}
+
+	//
+	// STEP 3: Get the metadata of the files
+	//
+	files, err := svc.getMetadataByIDsUseCase.Execute(req.FileIDs)
+	if err != nil {
+		svc.logger.Error("🔴 Failed to get file metadata",
+			zap.Any("error", err),
+			zap.Int("file_count", len(req.FileIDs)))
+		return nil, err
+	}
+
+	filesByID := make(map[gocql.UUID]*dom_file.File, len(files))
+	for _, file := range files {
+		filesByID[file.ID] = file
+	}
+
+	//
+	// STEP 4: Check the user has read access to the collection of every file
+	//
+	errs := make(map[gocql.UUID]string)
+	accessByCollection := make(map[gocql.UUID]bool)
+	authorized := make([]*dom_file.File, 0, len(files))
+	for _, fileID := range req.FileIDs {
+		file, ok := filesByID[fileID]
+		if !ok {
+			errs[fileID] = "File not found"
+			continue
+		}
+
+		hasAccess, checked := accessByCollection[file.CollectionID]
+		if !checked {
+			hasAccess, err = svc.collectionRepo.CheckAccess(ctx, file.CollectionID, userID, dom_collection.CollectionPermissionReadOnly)
+			if err != nil {
+				svc.logger.Error("🔴 Failed to check collection access",
+					zap.Any("error", err),
+					zap.Any("collection_id", file.CollectionID),
+					zap.Any("user_id", userID))
+				return nil, err
+			}
+			accessByCollection[file.CollectionID] = hasAccess
+		}
+		if !hasAccess {
+			svc.logger.Warn("⚠️ Unauthorized presigned download URL request",
+				zap.Any("user_id", userID),
+				zap.Any("file_id", fileID),
+				zap.Any("collection_id", file.CollectionID))
+			errs[fileID] = "You don't have permission to download this file"
+			continue
+		}
+
+		authorized = append(authorized, file)
+	}
+
+	//
+	// STEP 5: Generate presigned download URLs
+	//
+	expirationTime := time.Now().Add(req.URLDuration)
+	downloadURLs := make(map[gocql.UUID]string, len(authorized))
+	thumbnailURLs := make(map[gocql.UUID]string)
+
+	if len(authorized) > 0 {
+		storagePaths := make([]string, 0, len(authorized))
+		thumbnailStoragePaths := make([]string, 0, len(authorized))
+		for _, file := range authorized {
+			storagePaths = append(storagePaths, file.EncryptedFileObjectKey)
+			if file.EncryptedThumbnailObjectKey != "" {
+				thumbnailStoragePaths = append(thumbnailStoragePaths, file.EncryptedThumbnailObjectKey)
+			}
+		}
+
+		urlsByPath, err := svc.generatePresignedDownloadURLsUseCase.Execute(ctx, storagePaths, req.URLDuration)
+		if err != nil {
+			svc.logger.Error("🔴 Failed to generate presigned download URLs",
+				zap.Any("error", err),
+				zap.Int("file_count", len(storagePaths)))
+			return nil, err
+		}
+
+		// Thumbnails are optional, so the files are still downloadable without them
+		thumbnailURLsByPath := map[string]string{}
+		if len(thumbnailStoragePaths) > 0 {
+			thumbnailURLsByPath, err = svc.generatePresignedDownloadURLsUseCase.Execute(ctx, thumbnailStoragePaths, req.URLDuration)
+			if err != nil {
+				svc.logger.Warn("⚠️ Failed to generate thumbnail presigned download URLs, continuing without them",
+					zap.Any("error", err),
+					zap.Int("thumbnail_count", len(thumbnailStoragePaths)))
+			}
+		}
+
+		for _, file := range authorized {
+			downloadURLs[file.ID] = urlsByPath[file.EncryptedFileObjectKey]
+			if url, ok := thumbnailURLsByPath[file.EncryptedThumbnailObjectKey]; ok && file.EncryptedThumbnailObjectKey != "" {
+				thumbnailURLs[file.ID] = url
+			}
+		}
+	}
+
+	//
+	// STEP 6: Prepare response
+	//
+	svc.logger.Info("✅ Presigned download URLs generated successfully",
+		zap.Any("user_id", userID),
+		zap.Int("requested", len(req.FileIDs)),
+		zap.Int("generated", len(downloadURLs)),
+		zap.Int("failed", len(errs)),
+		zap.Time("url_expiration", expirationTime))
+
+	return &GetPresignedDownloadURLsResponseDTO{
+		DownloadURLs:              downloadURLs,
+		ThumbnailURLs:             thumbnailURLs,
+		Errors:                    errs,
+		DownloadURLExpirationTime: expirationTime,
+		Success:                   true,
+		Message:                   "Presigned download URLs generated successfully",
+	}, nil
+}
@@ -0,0 +1,204 @@
+// cloud/backend/internal/maplefile/service/file/move.go
+package file
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/domain/keys"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	dom_file "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/file"
+	uc_filemetadata "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/usecase/filemetadata"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+)
+
+type MoveFileRequestDTO struct {
+	ID           gocql.UUID `json:"id"`
+	CollectionID gocql.UUID `json:"collection_id"`
+	// EncryptedFileKey is the file key re-wrapped by the client with the key of the target collection.
+	EncryptedFileKey keys.EncryptedFileKey `json:"encrypted_file_key"`
+	Version          uint64                `json:"version"`
+}
+
+type MoveFileService interface {
+	Execute(ctx context.Context, req *MoveFileRequestDTO) (*FileResponseDTO, error)
+}
+
+type moveFileServiceImpl struct {
+	config                *config.Configuration
+	logger                *zap.Logger
+	collectionRepo        dom_collection.CollectionRepository
+	getMetadataUseCase    uc_filemetadata.GetFileMetadataUseCase
+	updateMetadataUseCase uc_filemetadata.UpdateFileMetadataUseCase
+}
+
+func NewMoveFileService(
+	config *config.Configuration,
+	logger *zap.Logger,
+	collectionRepo dom_collection.CollectionRepository,
+	getMetadataUseCase uc_filemetadata.GetFileMetadataUseCase,
+	updateMetadataUseCase uc_filemetadata.UpdateFileMetadataUseCase,
+) MoveFileService {
+	logger = logger.Named("MoveFileService")
+	return &moveFileServiceImpl{
+		config:                config,
+		logger:                logger,
+		collectionRepo:        collectionRepo,
+		getMetadataUseCase:    getMetadataUseCase,
+		updateMetadataUseCase: updateMetadataUseCase,
+	}
+}
+
+// Execute moves a file into another collection. The file key is re-wrapped by the client, so the
+// server only swaps the collection and the wrapped key; the file content is not touched.
+func (svc *moveFileServiceImpl) Execute(ctx context.Context, req *MoveFileRequestDTO) (*FileResponseDTO, error) {
+	//
+	// STEP 1: Validation
+	//
+	if req == nil {
+		svc.logger.Warn("Failed validation with nil request")
+		return nil, httperror.NewForBadRequestWithSingleField("non_field_error", "Move details are required")
+	}
+
+	e := make(map[string]string)
+	if req.ID.String() == "" {
+		e["id"] = "File ID is required"
+	}
+	if req.CollectionID.String() == "" {
+		e["collection_id"] = "Collection ID is required"
+	}
+	if len(req.EncryptedFileKey.Ciphertext) == 0 {
+		e["encrypted_file_key"] = "Encrypted file key is required"
+	}
+
+	if len(e) != 0 {
+		svc.logger.Warn("Failed validation",
+			zap.Any("error", e))
+		return nil, httperror.NewForBadRequest(&e)
+	}
+
+	//
+	// STEP 2: Get user ID from context
+	//
+	userID, ok := ctx.Value(constants.SessionFederatedUserID).(gocql.UUID)
+	if !ok {
+		svc.logger.Error("Failed getting user ID from context")
+		return nil, httperror.NewForInternalServerErrorWithSingleField("message", "Authentication context error")
+	}
+
+	//
+	// STEP 3: Get existing file metadata
+	//
+	file, err := svc.getMetadataUseCase.Execute(req.ID)
+	if err != nil {
+		svc.logger.Error("Failed to get file metadata",
+			zap.Any("error", err),
+			zap.Any("file_id", req.ID))
+		return nil, err
+	}
+
+	if file.State == dom_file.FileStateDeleted {
+		svc.logger.Warn("Attempt to move a deleted file",
+			zap.Any("file_id", req.ID))
+		return nil, httperror.NewForBadRequestWithSingleField("message", "Cannot move a deleted file")
+	}
+
+	if file.CollectionID == req.CollectionID {
+		svc.logger.Warn("File is already in the target collection",
+			zap.Any("file_id", req.ID),
+			zap.Any("collection_id", req.CollectionID))
+		return nil, httperror.NewForBadRequestWithSingleField("collection_id", "File is already in this collection")
+	}
+
+	//
+	// STEP 4: Check if user has write access to both collections
+	//
+	hasAccess, err := svc.collectionRepo.CheckAccess(ctx, file.CollectionID, userID, dom_collection.CollectionPermissionReadWrite)
+	if err != nil {
+		svc.logger.Error("Failed to check source collection access",
+			zap.Any("error", err),
+			zap.Any("collection_id", file.CollectionID),
+			zap.Any("user_id", userID))
+		return nil, err
+	}
+
+	if !hasAccess {
+		svc.logger.Warn("Unauthorized file move attempt",
+			zap.Any("user_id", userID),
+			zap.Any("file_id", req.ID),
+			zap.Any("collection_id", file.CollectionID))
+		return nil, httperror.NewForForbiddenWithSingleField("message", "You don't have permission to move this file")
+	}
+
+	hasTargetAccess, err := svc.collectionRepo.CheckAccess(ctx, req.CollectionID, userID, dom_collection.CollectionPermissionReadWrite)
+	if err != nil {
+		svc.logger.Error("Failed to check target collection access",
+			zap.Any("error", err),
+			zap.Any("collection_id", req.CollectionID),
+			zap.Any("user_id", userID))
+		return nil, err
+	}
+
+	if !hasTargetAccess {
+		svc.logger.Warn("Unauthorized destination collection access",
+			zap.Any("user_id", userID),
+			zap.Any("file_id", req.ID),
+			zap.Any("collection_id", req.CollectionID))
+		return nil, httperror.NewForForbiddenWithSingleField("message", "You don't have permission to move files to this collection")
+	}
+
+	targetCollection, err := svc.collectionRepo.Get(ctx, req.CollectionID)
+	if err != nil {
+		svc.logger.Error("Failed to get target collection",
+			zap.Any("error", err),
+			zap.Any("collection_id", req.CollectionID))
+		return nil, err
+	}
+
+	if targetCollection == nil || targetCollection.State != dom_collection.CollectionStateActive {
+		svc.logger.Warn("Target collection is not active",
+			zap.Any("collection_id", req.CollectionID))
+		return nil, httperror.NewForBadRequestWithSingleField("collection_id", "Files can only be moved to an active collection")
+	}
+
+	//
+	// STEP 5: Check if submitted file request is in-sync with our backend's file copy.
+	//
+	if file.Version != req.Version {
+		svc.logger.Warn("Outdated file move attempt",
+			zap.Any("user_id", userID),
+			zap.Any("file_id", req.ID),
+			zap.Any("submitted_version", req.Version),
+			zap.Any("current_version", file.Version))
+		return nil, httperror.NewForBadRequestWithSingleField("message", "File has been updated since you last fetched it")
+	}
+
+	//
+	// STEP 6: Move the file
+	//
+	sourceCollectionID := file.CollectionID
+	file.CollectionID = req.CollectionID
+	file.EncryptedFileKey = req.EncryptedFileKey
+	file.Version++ // Mutation means we increment version.
+	file.ModifiedAt = time.Now()
+	file.ModifiedByUserID = userID
+
+	if err := svc.updateMetadataUseCase.Execute(ctx, file); err != nil {
+		svc.logger.Error("Failed to move file",
+			zap.Any("error", err),
+			zap.Any("file_id", file.ID))
+		return nil, err
+	}
+
+	svc.logger.Info("File moved successfully",
+		zap.Any("file_id", file.ID),
+		zap.Any("source_collection_id", sourceCollectionID),
+		zap.Any("target_collection_id", file.CollectionID))
+
+	return mapFileToDTO(file), nil
+}
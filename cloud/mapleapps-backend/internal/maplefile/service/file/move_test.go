@@ -0,0 +1,180 @@
+// internal/maplefile/service/file/move_test.go
+package file
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/domain/keys"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	dom_file "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/file"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/mocks"
+	uc_filemetadata "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/usecase/filemetadata"
+)
+
+func TestMoveFileService_Execute(t *testing.T) {
+	userID := gocql.TimeUUID()
+	sourceID, targetID := gocql.TimeUUID(), gocql.TimeUUID()
+	ctx := context.WithValue(context.Background(), constants.SessionFederatedUserID, userID)
+	rewrappedKey := keys.EncryptedFileKey{Ciphertext: []byte("rewrapped"), Nonce: []byte("nonce"), KeyVersion: 1}
+
+	newFile := func() *dom_file.File {
+		return &dom_file.File{
+			ID:                       gocql.TimeUUID(),
+			CollectionID:             sourceID,
+			OwnerID:                  userID,
+			EncryptedMetadata:        "metadata",
+			EncryptedFileKey:         keys.EncryptedFileKey{Ciphertext: []byte("original"), Nonce: []byte("nonce"), KeyVersion: 1},
+			EncryptionVersion:        "1.0",
+			EncryptedHash:            "hash",
+			EncryptedFileObjectKey:   "object-key",
+			EncryptedFileSizeInBytes: 1024,
+			State:                    dom_file.FileStateActive,
+			Version:                  3,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		sourceAccess   bool
+		targetAccess   bool
+		targetState    string
+		version        uint64
+		expectedError  string
+		expectedUpdate bool
+	}{
+		{
+			name:           "Success - Write access to both collections",
+			sourceAccess:   true,
+			targetAccess:   true,
+			targetState:    dom_collection.CollectionStateActive,
+			version:        3,
+			expectedUpdate: true,
+		},
+		{
+			name:          "Forbidden - No write access to the source collection",
+			sourceAccess:  false,
+			targetAccess:  true,
+			targetState:   dom_collection.CollectionStateActive,
+			version:       3,
+			expectedError: "You don't have permission to move this file",
+		},
+		{
+			name:          "Forbidden - No write access to the target collection",
+			sourceAccess:  true,
+			targetAccess:  false,
+			targetState:   dom_collection.CollectionStateActive,
+			version:       3,
+			expectedError: "You don't have permission to move files to this collection",
+		},
+		{
+			name:          "Bad Request - Target collection is deleted",
+			sourceAccess:  true,
+			targetAccess:  true,
+			targetState:   dom_collection.CollectionStateDeleted,
+			version:       3,
+			expectedError: "Files can only be moved to an active collection",
+		},
+		{
+			name:          "Bad Request - Outdated version",
+			sourceAccess:  true,
+			targetAccess:  true,
+			targetState:   dom_collection.CollectionStateActive,
+			version:       2,
+			expectedError: "File has been updated since you last fetched it",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := zap.NewNop()
+			cfg := &config.Configuration{}
+			fileRepo := mocks.NewMockFileMetadataRepository(ctrl)
+			collectionRepo := mocks.NewMockCollectionRepository(ctrl)
+			svc := NewMoveFileService(cfg, logger, collectionRepo,
+				uc_filemetadata.NewGetFileMetadataUseCase(cfg, logger, fileRepo),
+				uc_filemetadata.NewUpdateFileMetadataUseCase(cfg, logger, fileRepo))
+
+			file := newFile()
+			fileRepo.EXPECT().Get(file.ID).Return(file, nil)
+			collectionRepo.EXPECT().
+				CheckAccess(gomock.Any(), sourceID, userID, dom_collection.CollectionPermissionReadWrite).
+				Return(tt.sourceAccess, nil)
+			if tt.sourceAccess {
+				collectionRepo.EXPECT().
+					CheckAccess(gomock.Any(), targetID, userID, dom_collection.CollectionPermissionReadWrite).
+					Return(tt.targetAccess, nil)
+			}
+			if tt.sourceAccess && tt.targetAccess {
+				collectionRepo.EXPECT().
+					Get(gomock.Any(), targetID).
+					Return(&dom_collection.Collection{ID: targetID, State: tt.targetState}, nil)
+			}
+			if tt.expectedUpdate {
+				fileRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(updated *dom_file.File) error {
+					assert.Equal(t, targetID, updated.CollectionID)
+					assert.Equal(t, rewrappedKey, updated.EncryptedFileKey)
+					assert.Equal(t, uint64(4), updated.Version)
+					assert.Equal(t, userID, updated.ModifiedByUserID)
+					return nil
+				})
+			}
+
+			resp, err := svc.Execute(ctx, &MoveFileRequestDTO{
+				ID:               file.ID,
+				CollectionID:     targetID,
+				EncryptedFileKey: rewrappedKey,
+				Version:          tt.version,
+			})
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, resp)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, targetID, resp.CollectionID)
+			assert.Equal(t, uint64(4), resp.Version)
+		})
+	}
+}
+
+func TestMoveFileService_ExecuteValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := zap.NewNop()
+	cfg := &config.Configuration{}
+	fileRepo := mocks.NewMockFileMetadataRepository(ctrl)
+	svc := NewMoveFileService(cfg, logger, mocks.NewMockCollectionRepository(ctrl),
+		uc_filemetadata.NewGetFileMetadataUseCase(cfg, logger, fileRepo),
+		uc_filemetadata.NewUpdateFileMetadataUseCase(cfg, logger, fileRepo))
+
+	ctx := context.WithValue(context.Background(), constants.SessionFederatedUserID, gocql.TimeUUID())
+
+	_, err := svc.Execute(ctx, &MoveFileRequestDTO{ID: gocql.TimeUUID(), CollectionID: gocql.TimeUUID()})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Encrypted file key is required")
+
+	// A file cannot be moved into the collection it is already in
+	file := &dom_file.File{ID: gocql.TimeUUID(), CollectionID: gocql.TimeUUID(), State: dom_file.FileStateActive}
+	fileRepo.EXPECT().Get(file.ID).Return(file, nil)
+	_, err = svc.Execute(ctx, &MoveFileRequestDTO{
+		ID:               file.ID,
+		CollectionID:     file.CollectionID,
+		EncryptedFileKey: keys.EncryptedFileKey{Ciphertext: []byte("rewrapped")},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "File is already in this collection")
+}
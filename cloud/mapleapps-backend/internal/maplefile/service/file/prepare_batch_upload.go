@@ -0,0 +1,211 @@
+// cloud/backend/internal/maplefile/service/file/prepare_batch_upload.go
+package file
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	uc_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/usecase/collection"
+	uc_filemetadata "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/usecase/filemetadata"
+	uc_fileobjectstorage "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/usecase/fileobjectstorage"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+)
+
+// MaxFileBatchSize is the maximum number of files registered in one batch. Every file is written to
+// five tables in a single logged batch, so this keeps the batch below Cassandra's batch size limit.
+const MaxFileBatchSize = 10
+
+type PrepareFileBatchUploadItemDTO struct {
+	ID gocql.UUID `json:"id"`
+	// Optional: expected thumbnail size, a thumbnail upload URL is only generated if set
+	ExpectedThumbnailSizeInBytes int64 `json:"expected_thumbnail_size_in_bytes,omitempty"`
+}
+
+type PrepareFileBatchUploadRequestDTO struct {
+	CollectionID gocql.UUID                       `json:"collection_id"`
+	Files        []*PrepareFileBatchUploadItemDTO `json:"files"`
+}
+
+type PreparedFileUploadDTO struct {
+	FileID                gocql.UUID `json:"file_id"`
+	PresignedUploadURL    string     `json:"presigned_upload_url"`
+	PresignedThumbnailURL string     `json:"presigned_thumbnail_url,omitempty"`
+}
+
+type PrepareFileBatchUploadResponseDTO struct {
+	Uploads                 []*PreparedFileUploadDTO `json:"uploads"`
+	UploadURLExpirationTime time.Time                `json:"upload_url_expiration_time"`
+	Success                 bool                     `json:"success"`
+	Message                 string                   `json:"message"`
+}
+
+type PrepareFileBatchUploadService interface {
+	Execute(ctx context.Context, req *PrepareFileBatchUploadRequestDTO) (*PrepareFileBatchUploadResponseDTO, error)
+}
+
+type prepareFileBatchUploadServiceImpl struct {
+	config                            *config.Configuration
+	logger                            *zap.Logger
+	checkCollectionAccessUseCase      uc_collection.CheckCollectionAccessUseCase
+	getCollectionUseCase              uc_collection.GetCollectionUseCase
+	checkFileExistsUseCase            uc_filemetadata.CheckFileExistsUseCase
+	generatePresignedUploadURLUseCase uc_fileobjectstorage.GeneratePresignedUploadURLUseCase
+}
+
+func NewPrepareFileBatchUploadService(
+	config *config.Configuration,
+	logger *zap.Logger,
+	checkCollectionAccessUseCase uc_collection.CheckCollectionAccessUseCase,
+	getCollectionUseCase uc_collection.GetCollectionUseCase,
+	checkFileExistsUseCase uc_filemetadata.CheckFileExistsUseCase,
+	generatePresignedUploadURLUseCase uc_fileobjectstorage.GeneratePresignedUploadURLUseCase,
+) PrepareFileBatchUploadService {
+	logger = logger.Named("PrepareFileBatchUploadService")
+	return &prepareFileBatchUploadServiceImpl{
+		config:                            config,
+		logger:                            logger,
+		checkCollectionAccessUseCase:      checkCollectionAccessUseCase,
+		getCollectionUseCase:              getCollectionUseCase,
+		checkFileExistsUseCase:            checkFileExistsUseCase,
+		generatePresignedUploadURLUseCase: generatePresignedUploadURLUseCase,
+	}
+}
+
+// Execute generates upload URLs for a batch of files without creating any metadata. The metadata is
+// registered in a single transaction by the `CreateFileBatchService` once all the uploads finished.
+func (svc *prepareFileBatchUploadServiceImpl) Execute(ctx context.Context, req *PrepareFileBatchUploadRequestDTO) (*PrepareFileBatchUploadResponseDTO, error) {
+	//
+	// STEP 1: Validation
+	//
+	if req == nil {
+		svc.logger.Warn("⚠️ Failed validation with nil request")
+		return nil, httperror.NewForBadRequestWithSingleField("non_field_error", "Batch upload details are required")
+	}
+
+	e := make(map[string]string)
+	if req.CollectionID.String() == "" {
+		e["collection_id"] = "Collection ID is required"
+	}
+	if len(req.Files) == 0 {
+		e["files"] = "Files are required"
+	} else if len(req.Files) > MaxFileBatchSize {
+		e["files"] = fmt.Sprintf("A batch cannot contain more than %d files", MaxFileBatchSize)
+	} else {
+		seen := make(map[gocql.UUID]bool, len(req.Files))
+		for i, item := range req.Files {
+			if item == nil || item.ID.String() == "" {
+				e[fmt.Sprintf("files[%d].id", i)] = "Client-side generated ID is required"
+				continue
+			}
+			if seen[item.ID] {
+				e[fmt.Sprintf("files[%d].id", i)] = "Client-side generated ID is duplicated in the batch"
+				continue
+			}
+			seen[item.ID] = true
+
+			doesExist, err := svc.checkFileExistsUseCase.Execute(item.ID)
+			if err != nil {
+				e[fmt.Sprintf("files[%d].id", i)] = fmt.Sprintf("Client-side generated ID causes error: %v", item.ID)
+			} else if doesExist {
+				e[fmt.Sprintf("files[%d].id", i)] = "Client-side generated ID already exists"
+			}
+		}
+	}
+	if len(e) != 0 {
+		svc.logger.Warn("⚠️ Failed validation",
+			zap.Any("error", e))
+		return nil, httperror.NewForBadRequest(&e)
+	}
+
+	//
+	// STEP 2: Get user ID from context
+	//
+	userID, ok := ctx.Value(constants.SessionFederatedUserID).(gocql.UUID)
+	if !ok {
+		svc.logger.Error("❌ Failed getting user ID from context")
+		return nil, httperror.NewForInternalServerErrorWithSingleField("message", "Authentication context error")
+	}
+
+	//
+	// STEP 3: Check the collection exists and the user has write access to it
+	//
+	if err := validateCollectionAcceptsFiles(ctx, svc.getCollectionUseCase, req.CollectionID); err != nil {
+		svc.logger.Warn("⚠️ Collection does not accept new files",
+			zap.Any("error", err),
+			zap.Any("collection_id", req.CollectionID),
+			zap.Any("user_id", userID))
+		return nil, err
+	}
+
+	hasAccess, err := svc.checkCollectionAccessUseCase.Execute(ctx, req.CollectionID, userID, dom_collection.CollectionPermissionReadWrite)
+	if err != nil {
+		svc.logger.Error("❌ Failed to check collection access",
+			zap.Any("error", err),
+			zap.Any("collection_id", req.CollectionID),
+			zap.Any("user_id", userID))
+		return nil, err
+	}
+	if !hasAccess {
+		svc.logger.Warn("⚠️ Unauthorized batch upload attempt",
+			zap.Any("user_id", userID),
+			zap.Any("collection_id", req.CollectionID))
+		return nil, httperror.NewForForbiddenWithSingleField("message", "You don't have permission to create files in this collection")
+	}
+
+	//
+	// STEP 4: Generate presigned upload URLs
+	//
+	uploadURLDuration := 1 * time.Hour // URLs valid for 1 hour
+	expirationTime := time.Now().Add(uploadURLDuration)
+
+	uploads := make([]*PreparedFileUploadDTO, 0, len(req.Files))
+	for _, item := range req.Files {
+		storagePath := generateStoragePath(userID.String(), item.ID.String())
+		presignedUploadURL, err := svc.generatePresignedUploadURLUseCase.Execute(ctx, storagePath, uploadURLDuration)
+		if err != nil {
+			svc.logger.Error("❌ Failed to generate presigned upload URL",
+				zap.Any("error", err),
+				zap.Any("file_id", item.ID),
+				zap.String("storage_path", storagePath))
+			return nil, err
+		}
+
+		upload := &PreparedFileUploadDTO{
+			FileID:             item.ID,
+			PresignedUploadURL: presignedUploadURL,
+		}
+
+		if item.ExpectedThumbnailSizeInBytes > 0 {
+			thumbnailStoragePath := generateThumbnailStoragePath(userID.String(), item.ID.String())
+			upload.PresignedThumbnailURL, err = svc.generatePresignedUploadURLUseCase.Execute(ctx, thumbnailStoragePath, uploadURLDuration)
+			if err != nil {
+				svc.logger.Warn("⚠️ Failed to generate thumbnail presigned upload URL, continuing without it",
+					zap.Any("error", err),
+					zap.Any("file_id", item.ID),
+					zap.String("thumbnail_storage_path", thumbnailStoragePath))
+			}
+		}
+
+		uploads = append(uploads, upload)
+	}
+
+	svc.logger.Info("✅ Batch upload prepared successfully",
+		zap.Any("collection_id", req.CollectionID),
+		zap.Any("user_id", userID),
+		zap.Int("file_count", len(uploads)),
+		zap.Time("url_expiration", expirationTime))
+
+	return &PrepareFileBatchUploadResponseDTO{
+		Uploads:                 uploads,
+		UploadURLExpirationTime: expirationTime,
+		Success:                 true,
+		Message:                 "Batch upload prepared successfully. Upload every file and then create the batch.",
+	}, nil
+}
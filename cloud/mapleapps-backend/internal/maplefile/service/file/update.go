@@ -22,7 +22,9 @@ type UpdateFileRequestDTO struct {
 	EncryptedFileKey  keys.EncryptedFileKey `json:"encrypted_file_key,omitempty"`
 	EncryptionVersion string                `json:"encryption_version,omitempty"`
 	EncryptedHash     string                `json:"encrypted_hash,omitempty"`
-	Version           uint64                `json:"version,omitempty"`
+	// EncryptedHashAlgorithm is only applied with a new EncryptedHash, empty means the default algorithm.
+	EncryptedHashAlgorithm string `json:"encrypted_hash_algorithm,omitempty"`
+	Version                uint64 `json:"version,omitempty"`
 }
 
 type UpdateFileService interface {
@@ -143,6 +145,7 @@ func (svc *updateFileServiceImpl) Execute(ctx context.Context, req *UpdateFileRe
 	}
 	if req.EncryptedHash != "" {
 		file.EncryptedHash = req.EncryptedHash
+		file.EncryptedHashAlgorithm = req.EncryptedHashAlgorithm
 		updated = true
 	}
 
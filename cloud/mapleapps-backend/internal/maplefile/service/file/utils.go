@@ -2,9 +2,30 @@
 package file
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/gocql/gocql"
+
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
 	dom_file "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/file"
+	uc_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/usecase/collection"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
 )
 
+// validateCollectionAcceptsFiles returns an error unless the collection exists and is active, so no
+// file gets registered in a collection it would be orphaned from
+func validateCollectionAcceptsFiles(ctx context.Context, getCollectionUseCase uc_collection.GetCollectionUseCase, collectionID gocql.UUID) error {
+	collection, err := getCollectionUseCase.Execute(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+	if collection.State != dom_collection.CollectionStateActive {
+		return httperror.NewForBadRequestWithSingleField("collection_id", fmt.Sprintf("Files cannot be added to a collection which is %s", collection.State))
+	}
+	return nil
+}
+
 // Helper function to map a File domain model to a FileResponseDTO
 func mapFileToDTO(file *dom_file.File) *FileResponseDTO {
 	return &FileResponseDTO{
@@ -15,6 +36,7 @@ func mapFileToDTO(file *dom_file.File) *FileResponseDTO {
 		EncryptedFileKey:              file.EncryptedFileKey,
 		EncryptionVersion:             file.EncryptionVersion,
 		EncryptedHash:                 file.EncryptedHash,
+		EncryptedHashAlgorithm:        file.EncryptedHashAlgorithm,
 		EncryptedFileSizeInBytes:      file.EncryptedFileSizeInBytes,
 		EncryptedThumbnailSizeInBytes: file.EncryptedThumbnailSizeInBytes,
 		CreatedAt:                     file.CreatedAt,
@@ -0,0 +1,502 @@
+// github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/service/me/rotate_master_key.go
+package me
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/domain/keys"
+	uc_user "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/usecase/federateduser"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/security/crypto"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/storage/cache/cassandracache"
+)
+
+// RotateMasterKeyRequestDTO carries the keys of the account wrapped under a new master key. Every
+// wrapped account key is the base64 URL encoding of the nonce followed by the ciphertext.
+type RotateMasterKeyRequestDTO struct {
+	// ChallengeID identifies the challenge issued by the rotate-master-key/challenge endpoint.
+	ChallengeID string `json:"challenge_id"`
+	// DecryptedChallenge is the challenge opened with the current private key, base64 encoded. It
+	// proves the client holds the current keys of the account and not only a session.
+	DecryptedChallenge string `json:"decrypted_challenge"`
+	// NewEncryptedMasterKey is the new master key encrypted with the key encryption key derived
+	// from the unchanged password.
+	NewEncryptedMasterKey string `json:"new_encrypted_master_key"`
+	// NewEncryptedPrivateKey is the unchanged private key encrypted with the new master key.
+	NewEncryptedPrivateKey string `json:"new_encrypted_private_key"`
+	// NewEncryptedRecoveryKey is the unchanged recovery key encrypted with the new master key.
+	NewEncryptedRecoveryKey string `json:"new_encrypted_recovery_key"`
+	// NewMasterKeyEncryptedWithRecoveryKey is the new master key encrypted with the recovery key.
+	NewMasterKeyEncryptedWithRecoveryKey string `json:"new_master_key_encrypted_with_recovery_key"`
+	// CollectionKeys are the keys of the collections owned by the user wrapped under the new master
+	// key. They are applied with the account keys so no device sees one without the other.
+	CollectionKeys []*RotatedCollectionKeyDTO `json:"collection_keys,omitempty"`
+}
+
+// RotatedCollectionKeyDTO is the key of an owned collection wrapped under the new master key
+type RotatedCollectionKeyDTO struct {
+	CollectionID           gocql.UUID                   `json:"collection_id"`
+	EncryptedCollectionKey *keys.EncryptedCollectionKey `json:"encrypted_collection_key"`
+	// Version is the version of the collection the key was re-wrapped from.
+	Version uint64 `json:"version"`
+}
+
+// RotatedCollectionDTO is the version of a collection after its key was replaced
+type RotatedCollectionDTO struct {
+	CollectionID gocql.UUID `json:"collection_id"`
+	Version      uint64     `json:"version"`
+}
+
+type RotateMasterKeyResponseDTO struct {
+	Success     bool                    `json:"success"`
+	Message     string                  `json:"message"`
+	KeyVersion  int                     `json:"key_version"`
+	Collections []*RotatedCollectionDTO `json:"collections,omitempty"`
+}
+
+type RotateMasterKeyService interface {
+	Execute(sessCtx context.Context, req *RotateMasterKeyRequestDTO) (*RotateMasterKeyResponseDTO, error)
+}
+
+type rotateMasterKeyServiceImpl struct {
+	config             *config.Configuration
+	logger             *zap.Logger
+	cache              cassandracache.CassandraCacher
+	userGetByIDUseCase uc_user.FederatedUserGetByIDUseCase
+	userUpdateUseCase  uc_user.FederatedUserUpdateUseCase
+	collectionRepo     dom_collection.CollectionRepository
+}
+
+func NewRotateMasterKeyService(
+	config *config.Configuration,
+	logger *zap.Logger,
+	cache cassandracache.CassandraCacher,
+	userGetByIDUseCase uc_user.FederatedUserGetByIDUseCase,
+	userUpdateUseCase uc_user.FederatedUserUpdateUseCase,
+	collectionRepo dom_collection.CollectionRepository,
+) RotateMasterKeyService {
+	logger = logger.Named("RotateMasterKeyService")
+	return &rotateMasterKeyServiceImpl{
+		config:             config,
+		logger:             logger,
+		cache:              cache,
+		userGetByIDUseCase: userGetByIDUseCase,
+		userUpdateUseCase:  userUpdateUseCase,
+		collectionRepo:     collectionRepo,
+	}
+}
+
+// Execute replaces the wrapped keys of the authenticated user with the ones wrapped under a new
+// master key, keeping the outgoing master key in the key history. The client must answer a
+// challenge sealed with its public key, proving it holds the current keys, and submits the re-wrapped
+// keys of its collections in the same request. Every collection is checked before anything is
+// written, then the collections are updated before the account.
+//
+// The collections and the account live in different tables and can't be written in one batch. If a
+// collection or the account fails to update, the collection keys already replaced are put back so
+// no device sees collection keys wrapped under a master key the account doesn't have. Should that
+// fail too, the error is logged and the client retries: submitting keys which are already current
+// succeeds without changing them, so a rotation interrupted part-way completes with the same request.
+func (svc *rotateMasterKeyServiceImpl) Execute(sessCtx context.Context, req *RotateMasterKeyRequestDTO) (*RotateMasterKeyResponseDTO, error) {
+	//
+	// STEP 1: Get required from context.
+	//
+
+	userID, ok := sessCtx.Value(constants.SessionFederatedUserID).(gocql.UUID)
+	if !ok {
+		svc.logger.Error("Failed getting local federateduser id",
+			zap.Any("error", "Not found in context: user_id"))
+		return nil, errors.New("federateduser id not found in context")
+	}
+
+	//
+	// STEP 2: Validation
+	//
+
+	if req == nil {
+		svc.logger.Warn("Failed validation with nothing received")
+		return nil, httperror.NewForBadRequestWithSingleField("non_field_error", "Request is required in submission")
+	}
+
+	e := make(map[string]string)
+	masterKey := decodeWrappedKey(req.NewEncryptedMasterKey, "new_encrypted_master_key", "Encrypted master key", e)
+	privateKey := decodeWrappedKey(req.NewEncryptedPrivateKey, "new_encrypted_private_key", "Encrypted private key", e)
+	recoveryKey := decodeWrappedKey(req.NewEncryptedRecoveryKey, "new_encrypted_recovery_key", "Encrypted recovery key", e)
+	masterKeyWithRecoveryKey := decodeWrappedKey(req.NewMasterKeyEncryptedWithRecoveryKey, "new_master_key_encrypted_with_recovery_key", "Master key encrypted with recovery key", e)
+	if req.ChallengeID == "" {
+		e["challenge_id"] = "Challenge ID is required"
+	}
+	if req.DecryptedChallenge == "" {
+		e["decrypted_challenge"] = "Decrypted challenge is required"
+	}
+	seen := make(map[gocql.UUID]bool, len(req.CollectionKeys))
+	for _, collectionKey := range req.CollectionKeys {
+		if collectionKey == nil || collectionKey.CollectionID.String() == "" {
+			e["collection_keys"] = "Collection ID is required"
+			break
+		}
+		if collectionKey.EncryptedCollectionKey == nil || len(collectionKey.EncryptedCollectionKey.Ciphertext) == 0 || len(collectionKey.EncryptedCollectionKey.Nonce) == 0 {
+			e["collection_keys"] = fmt.Sprintf("Encrypted collection key is required for collection %s", collectionKey.CollectionID.String())
+			break
+		}
+		if seen[collectionKey.CollectionID] {
+			e["collection_keys"] = fmt.Sprintf("Collection %s is listed more than once", collectionKey.CollectionID.String())
+			break
+		}
+		seen[collectionKey.CollectionID] = true
+	}
+	if len(e) != 0 {
+		svc.logger.Warn("Failed validation",
+			zap.Any("error", e))
+		return nil, httperror.NewForBadRequest(&e)
+	}
+
+	//
+	// STEP 3: Verify the answer to the challenge, which can only be used once.
+	//
+
+	if err := svc.verifyChallenge(sessCtx, userID, req.ChallengeID, req.DecryptedChallenge); err != nil {
+		return nil, err
+	}
+
+	//
+	// STEP 4: Get related records.
+	//
+
+	federateduser, err := svc.userGetByIDUseCase.Execute(sessCtx, userID)
+	if err != nil {
+		svc.logger.Error("Failed getting federateduser by ID", zap.Any("error", err))
+		return nil, err
+	}
+	if federateduser == nil {
+		err := fmt.Errorf("federateduser is nil after lookup for id: %v", userID.String())
+		svc.logger.Error("Failed getting federateduser", zap.Any("error", err))
+		return nil, err
+	}
+
+	collections, err := svc.getOwnedCollections(sessCtx, userID, req.CollectionKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	//
+	// STEP 5: Replace the keys of the collections.
+	//
+
+	rotatedCollections := make([]*RotatedCollectionDTO, 0, len(req.CollectionKeys))
+	replaced := make([]*replacedCollectionKey, 0, len(req.CollectionKeys))
+	for i, collectionKey := range req.CollectionKeys {
+		collection := collections[i]
+		if !isCollectionKeyCurrent(collection, collectionKey) {
+			original := collection.EncryptedCollectionKey
+			collection.EncryptedCollectionKey = rewrappedCollectionKey(original, collectionKey.EncryptedCollectionKey)
+			collection.ModifiedAt = time.Now()
+			collection.ModifiedByUserID = userID
+			collection.Version++ // Update mutation means we increment version.
+			if err := svc.collectionRepo.Update(sessCtx, collection); err != nil {
+				svc.logger.Error("Failed updating collection key",
+					zap.Any("error", err),
+					zap.String("collection_id", collection.ID.String()))
+				svc.restoreCollectionKeys(sessCtx, userID, replaced)
+				return nil, err
+			}
+			replaced = append(replaced, &replacedCollectionKey{collection: collection, original: original})
+		}
+		rotatedCollections = append(rotatedCollections, &RotatedCollectionDTO{
+			CollectionID: collection.ID,
+			Version:      collection.Version,
+		})
+	}
+
+	current := federateduser.SecurityData.EncryptedMasterKey
+	if bytes.Equal(current.Nonce, masterKey[:crypto.NonceSize]) && bytes.Equal(current.Ciphertext, masterKey[crypto.NonceSize:]) {
+		svc.logger.Info("Master key rotation already applied",
+			zap.String("user_id", userID.String()),
+			zap.Int("key_version", current.KeyVersion))
+		return &RotateMasterKeyResponseDTO{
+			Success:     true,
+			Message:     "Master key already rotated",
+			KeyVersion:  current.KeyVersion,
+			Collections: rotatedCollections,
+		}, nil
+	}
+
+	//
+	// STEP 6: Apply the new wrapped keys, keeping the outgoing master key in the history.
+	//
+
+	currentTime := time.Now()
+	newKeyVersion := federateduser.SecurityData.CurrentKeyVersion + 1
+
+	previousKeys := append(current.PreviousKeys, keys.EncryptedHistoricalKey{
+		KeyVersion:    current.KeyVersion,
+		Nonce:         current.Nonce,
+		Ciphertext:    current.Ciphertext,
+		RotatedAt:     currentTime,
+		RotatedReason: "Master key rotation",
+		Algorithm:     crypto.ChaCha20Poly1305Algorithm,
+	})
+	if len(previousKeys) > 5 { // Keep only last 5 keys
+		previousKeys = previousKeys[len(previousKeys)-5:]
+	}
+
+	federateduser.SecurityData.EncryptedMasterKey = keys.EncryptedMasterKey{
+		Nonce:        masterKey[:crypto.NonceSize],
+		Ciphertext:   masterKey[crypto.NonceSize:],
+		KeyVersion:   newKeyVersion,
+		RotatedAt:    &currentTime,
+		PreviousKeys: previousKeys,
+	}
+	federateduser.SecurityData.EncryptedPrivateKey = keys.EncryptedPrivateKey{
+		Nonce:      privateKey[:crypto.NonceSize],
+		Ciphertext: privateKey[crypto.NonceSize:],
+	}
+	federateduser.SecurityData.EncryptedRecoveryKey = keys.EncryptedRecoveryKey{
+		Nonce:      recoveryKey[:crypto.NonceSize],
+		Ciphertext: recoveryKey[crypto.NonceSize:],
+	}
+	federateduser.SecurityData.MasterKeyEncryptedWithRecoveryKey = keys.MasterKeyEncryptedWithRecoveryKey{
+		Nonce:      masterKeyWithRecoveryKey[:crypto.NonceSize],
+		Ciphertext: masterKeyWithRecoveryKey[crypto.NonceSize:],
+	}
+	federateduser.SecurityData.CurrentKeyVersion = newKeyVersion
+	federateduser.SecurityData.LastKeyRotation = &currentTime
+	federateduser.ModifiedAt = currentTime
+
+	if err := svc.userUpdateUseCase.Execute(sessCtx, federateduser); err != nil {
+		svc.logger.Error("Failed updating federateduser", zap.Any("error", err), zap.String("user_id", federateduser.ID.String()))
+		svc.restoreCollectionKeys(sessCtx, userID, replaced)
+		return nil, err
+	}
+
+	svc.logger.Info("Master key rotated successfully",
+		zap.String("user_id", federateduser.ID.String()),
+		zap.Int("key_version", newKeyVersion),
+		zap.Int("collections", len(rotatedCollections)))
+
+	return &RotateMasterKeyResponseDTO{
+		Success:     true,
+		Message:     "Master key rotated successfully",
+		KeyVersion:  newKeyVersion,
+		Collections: rotatedCollections,
+	}, nil
+}
+
+// verifyChallenge checks the decrypted challenge against the one issued to the user and deletes it,
+// so an answer can't be replayed.
+func (svc *rotateMasterKeyServiceImpl) verifyChallenge(ctx context.Context, userID gocql.UUID, challengeID, decryptedChallenge string) error {
+	cacheKey := rotateMasterKeyChallengeCacheKey(challengeID)
+	challengeDataJSON, err := svc.cache.Get(ctx, cacheKey)
+	if err != nil {
+		svc.logger.Error("Failed to retrieve challenge data", zap.Error(err))
+		return httperror.NewForBadRequestWithSingleField("challenge_id", "Invalid or expired challenge")
+	}
+	if challengeDataJSON == nil {
+		svc.logger.Warn("Challenge data not found in cache", zap.String("challenge_id", challengeID))
+		return httperror.NewForBadRequestWithSingleField("challenge_id", "Invalid or expired challenge")
+	}
+
+	var challengeData RotateMasterKeyChallengeData
+	if err := json.Unmarshal(challengeDataJSON, &challengeData); err != nil {
+		svc.logger.Error("Failed to unmarshal challenge data", zap.Error(err))
+		return httperror.NewForBadRequestWithSingleField("challenge_id", "Invalid challenge")
+	}
+
+	if err := svc.cache.Delete(ctx, cacheKey); err != nil {
+		svc.logger.Error("Failed to delete challenge from cache", zap.Error(err))
+		return fmt.Errorf("failed to verify challenge: %w", err)
+	}
+
+	if challengeData.FederatedUserID != userID.String() {
+		svc.logger.Warn("Challenge was issued to another user",
+			zap.String("user_id", userID.String()),
+			zap.String("challenge_id", challengeID))
+		return httperror.NewForBadRequestWithSingleField("challenge_id", "Invalid or expired challenge")
+	}
+	if time.Now().After(challengeData.ExpiresAt) {
+		return httperror.NewForBadRequestWithSingleField("challenge_id", "Challenge has expired")
+	}
+
+	storedChallenge, err := base64.StdEncoding.DecodeString(challengeData.Challenge)
+	if err != nil {
+		svc.logger.Error("Failed to decode stored challenge", zap.Error(err))
+		return httperror.NewForInternalServerError("Failed to process challenge due to internal data error")
+	}
+	receivedChallenge, err := base64.StdEncoding.DecodeString(decryptedChallenge)
+	if err != nil {
+		if receivedChallenge, err = base64.RawURLEncoding.DecodeString(decryptedChallenge); err != nil {
+			return httperror.NewForBadRequestWithSingleField("decrypted_challenge", "Invalid format for decrypted challenge")
+		}
+	}
+
+	if subtle.ConstantTimeCompare(storedChallenge, receivedChallenge) != 1 {
+		svc.logger.Warn("Master key rotation challenge verification failed",
+			zap.String("user_id", userID.String()),
+			zap.String("challenge_id", challengeID))
+		return httperror.NewForBadRequestWithSingleField("decrypted_challenge", "Invalid challenge response")
+	}
+
+	return nil
+}
+
+// getOwnedCollections returns the collection of every submitted key, in the same order. It fails
+// before anything is written if a collection is missing, not owned by the user, or was updated since
+// its key was re-wrapped.
+func (svc *rotateMasterKeyServiceImpl) getOwnedCollections(ctx context.Context, userID gocql.UUID, collectionKeys []*RotatedCollectionKeyDTO) ([]*dom_collection.Collection, error) {
+	collections := make([]*dom_collection.Collection, 0, len(collectionKeys))
+	for _, collectionKey := range collectionKeys {
+		collection, err := svc.collectionRepo.Get(ctx, collectionKey.CollectionID)
+		if err != nil {
+			svc.logger.Error("Failed to get collection",
+				zap.Any("error", err),
+				zap.String("collection_id", collectionKey.CollectionID.String()))
+			return nil, err
+		}
+		if collection == nil {
+			return nil, httperror.NewForNotFoundWithSingleField("collection_keys", fmt.Sprintf("Collection %s not found", collectionKey.CollectionID.String()))
+		}
+		if collection.OwnerID != userID {
+			svc.logger.Warn("Master key rotation submitted a collection the user does not own",
+				zap.String("user_id", userID.String()),
+				zap.String("collection_id", collection.ID.String()))
+			return nil, httperror.NewForForbiddenWithSingleField("collection_keys", fmt.Sprintf("You don't own collection %s", collection.ID.String()))
+		}
+
+		// A key which is already current was applied by an earlier attempt of this rotation.
+		if !isCollectionKeyCurrent(collection, collectionKey) {
+			if collection.Version != collectionKey.Version {
+				svc.logger.Warn("Outdated collection key in master key rotation",
+					zap.String("collection_id", collection.ID.String()),
+					zap.Uint64("submitted_version", collectionKey.Version),
+					zap.Uint64("current_version", collection.Version))
+				return nil, httperror.NewForBadRequestWithSingleField("collection_keys", fmt.Sprintf("Collection %s has been updated since you last fetched it", collection.ID.String()))
+			}
+			// Re-wrapping never changes the key itself, only what it is wrapped with.
+			if err := validateRewrappedCollectionKey(collection.EncryptedCollectionKey, collectionKey.EncryptedCollectionKey); err != nil {
+				svc.logger.Warn("Collection key in master key rotation does not match the stored key",
+					zap.String("collection_id", collection.ID.String()),
+					zap.Error(err))
+				return nil, httperror.NewForBadRequestWithSingleField("collection_keys", fmt.Sprintf("Collection %s: %v", collection.ID.String(), err))
+			}
+		}
+		collections = append(collections, collection)
+	}
+	return collections, nil
+}
+
+// replacedCollectionKey is a collection whose key was replaced, with the key it had before
+type replacedCollectionKey struct {
+	collection *dom_collection.Collection
+	original   *keys.EncryptedCollectionKey
+}
+
+// restoreCollectionKeys puts back the keys of collections updated before the rotation failed. It
+// runs even if the request was cancelled, as the collections must not be left half rotated.
+func (svc *rotateMasterKeyServiceImpl) restoreCollectionKeys(sessCtx context.Context, userID gocql.UUID, replaced []*replacedCollectionKey) {
+	ctx := context.WithoutCancel(sessCtx)
+	for _, r := range replaced {
+		r.collection.EncryptedCollectionKey = r.original
+		r.collection.ModifiedAt = time.Now()
+		r.collection.ModifiedByUserID = userID
+		r.collection.Version++ // Update mutation means we increment version.
+		if err := svc.collectionRepo.Update(ctx, r.collection); err != nil {
+			svc.logger.Error("Failed restoring collection key after failed master key rotation, the client must retry the rotation",
+				zap.Any("error", err),
+				zap.String("collection_id", r.collection.ID.String()))
+			continue
+		}
+		svc.logger.Info("Restored collection key after failed master key rotation",
+			zap.String("collection_id", r.collection.ID.String()))
+	}
+}
+
+// validateRewrappedCollectionKey checks a re-wrapped key against the stored one: the key version
+// must be the same and only versions in the stored history can be re-wrapped.
+func validateRewrappedCollectionKey(stored, submitted *keys.EncryptedCollectionKey) error {
+	if stored == nil {
+		return errors.New("collection has no key to re-wrap")
+	}
+	if collectionKeyVersion(submitted) != collectionKeyVersion(stored) {
+		return fmt.Errorf("key version %d does not match the current key version %d", collectionKeyVersion(submitted), collectionKeyVersion(stored))
+	}
+	history := make(map[int]bool, len(stored.PreviousKeys))
+	for _, previous := range stored.PreviousKeys {
+		history[previous.KeyVersion] = true
+	}
+	for _, previous := range submitted.PreviousKeys {
+		if !history[previous.KeyVersion] {
+			return fmt.Errorf("previous key version %d is not in the key history", previous.KeyVersion)
+		}
+		if len(previous.Ciphertext) == 0 || len(previous.Nonce) == 0 {
+			return fmt.Errorf("previous key version %d is missing its encrypted key", previous.KeyVersion)
+		}
+	}
+	return nil
+}
+
+// rewrappedCollectionKey returns the stored key with only its wrapped key, and the wrapped keys of
+// the history entries the client re-wrapped, replaced. The version, rotation time and the rest of
+// the history stay as stored.
+func rewrappedCollectionKey(stored, submitted *keys.EncryptedCollectionKey) *keys.EncryptedCollectionKey {
+	rewrapped := *stored
+	rewrapped.Ciphertext = submitted.Ciphertext
+	rewrapped.Nonce = submitted.Nonce
+	rewrapped.PreviousKeys = make([]keys.EncryptedHistoricalKey, len(stored.PreviousKeys))
+	copy(rewrapped.PreviousKeys, stored.PreviousKeys)
+	for _, previous := range submitted.PreviousKeys {
+		for i := range rewrapped.PreviousKeys {
+			if rewrapped.PreviousKeys[i].KeyVersion == previous.KeyVersion {
+				rewrapped.PreviousKeys[i].Ciphertext = previous.Ciphertext
+				rewrapped.PreviousKeys[i].Nonce = previous.Nonce
+			}
+		}
+	}
+	return &rewrapped
+}
+
+// collectionKeyVersion returns the version of a collection key, keys stored before versioning
+// count as the first version
+func collectionKeyVersion(key *keys.EncryptedCollectionKey) int {
+	if key.KeyVersion == 0 {
+		return 1
+	}
+	return key.KeyVersion
+}
+
+// isCollectionKeyCurrent reports whether the collection already has the submitted key
+func isCollectionKeyCurrent(collection *dom_collection.Collection, collectionKey *RotatedCollectionKeyDTO) bool {
+	return collection.EncryptedCollectionKey != nil &&
+		bytes.Equal(collection.EncryptedCollectionKey.Ciphertext, collectionKey.EncryptedCollectionKey.Ciphertext)
+}
+
+// decodeWrappedKey decodes a base64 URL encoded nonce and ciphertext, recording a validation
+// error under field if it is missing or malformed.
+func decodeWrappedKey(value, field, label string, e map[string]string) []byte {
+	if value == "" {
+		e[field] = label + " is required"
+		return nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		e[field] = label + " has an invalid format"
+		return nil
+	}
+	if len(decoded) <= crypto.NonceSize {
+		e[field] = label + " too short"
+		return nil
+	}
+	return decoded
+}
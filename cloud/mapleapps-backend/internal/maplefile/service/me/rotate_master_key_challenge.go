@@ -0,0 +1,156 @@
+// github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/service/me/rotate_master_key_challenge.go
+package me
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/nacl/box"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	uc_user "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/usecase/federateduser"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/security/crypto"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/storage/cache/cassandracache"
+)
+
+// rotateMasterKeyChallengeExpiry is how long a client has to answer a rotation challenge, like login.
+const rotateMasterKeyChallengeExpiry = 5 * time.Minute
+
+// RotateMasterKeyChallengeData is the challenge a master key rotation must answer, stored in the cache.
+type RotateMasterKeyChallengeData struct {
+	ChallengeID     string    `json:"challenge_id"`
+	Challenge       string    `json:"challenge"`
+	FederatedUserID string    `json:"federated_user_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	ExpiresAt       time.Time `json:"expires_at"`
+}
+
+type RotateMasterKeyChallengeResponseDTO struct {
+	ChallengeID string `json:"challenge_id"`
+	// EncryptedChallenge is the challenge sealed with the public key of the user, base64 encoded.
+	EncryptedChallenge string `json:"encrypted_challenge"`
+}
+
+// RotateMasterKeyChallengeService issues the challenge a client answers with the current keys of the
+// account before it may replace them.
+type RotateMasterKeyChallengeService interface {
+	Execute(sessCtx context.Context) (*RotateMasterKeyChallengeResponseDTO, error)
+}
+
+type rotateMasterKeyChallengeServiceImpl struct {
+	config             *config.Configuration
+	logger             *zap.Logger
+	cache              cassandracache.CassandraCacher
+	userGetByIDUseCase uc_user.FederatedUserGetByIDUseCase
+}
+
+func NewRotateMasterKeyChallengeService(
+	config *config.Configuration,
+	logger *zap.Logger,
+	cache cassandracache.CassandraCacher,
+	userGetByIDUseCase uc_user.FederatedUserGetByIDUseCase,
+) RotateMasterKeyChallengeService {
+	logger = logger.Named("RotateMasterKeyChallengeService")
+	return &rotateMasterKeyChallengeServiceImpl{
+		config:             config,
+		logger:             logger,
+		cache:              cache,
+		userGetByIDUseCase: userGetByIDUseCase,
+	}
+}
+
+// Execute generates a random challenge for the authenticated user and seals it with their public
+// key. Only a client which can unlock the private key with the current master key can open it.
+func (svc *rotateMasterKeyChallengeServiceImpl) Execute(sessCtx context.Context) (*RotateMasterKeyChallengeResponseDTO, error) {
+	//
+	// STEP 1: Get required from context.
+	//
+
+	userID, ok := sessCtx.Value(constants.SessionFederatedUserID).(gocql.UUID)
+	if !ok {
+		svc.logger.Error("Failed getting local federateduser id",
+			zap.Any("error", "Not found in context: user_id"))
+		return nil, errors.New("federateduser id not found in context")
+	}
+
+	federateduser, err := svc.userGetByIDUseCase.Execute(sessCtx, userID)
+	if err != nil {
+		svc.logger.Error("Failed getting federateduser by ID", zap.Any("error", err))
+		return nil, err
+	}
+	if federateduser == nil {
+		err := fmt.Errorf("federateduser is nil after lookup for id: %v", userID.String())
+		svc.logger.Error("Failed getting federateduser", zap.Any("error", err))
+		return nil, err
+	}
+
+	//
+	// STEP 2: Generate the challenge and store it for the rotation.
+	//
+
+	challenge := make([]byte, 32)
+	if _, err := rand.Read(challenge); err != nil {
+		svc.logger.Error("Failed to generate challenge", zap.Error(err))
+		return nil, fmt.Errorf("failed to generate challenge: %w", err)
+	}
+
+	now := time.Now()
+	challengeData := RotateMasterKeyChallengeData{
+		ChallengeID:     gocql.TimeUUID().String(),
+		Challenge:       base64.StdEncoding.EncodeToString(challenge),
+		FederatedUserID: userID.String(),
+		CreatedAt:       now,
+		ExpiresAt:       now.Add(rotateMasterKeyChallengeExpiry),
+	}
+	challengeDataJSON, err := json.Marshal(challengeData)
+	if err != nil {
+		svc.logger.Error("Failed to marshal challenge data", zap.Error(err))
+		return nil, fmt.Errorf("failed to generate challenge: %w", err)
+	}
+	if err := svc.cache.SetWithExpiry(sessCtx, rotateMasterKeyChallengeCacheKey(challengeData.ChallengeID), challengeDataJSON, rotateMasterKeyChallengeExpiry); err != nil {
+		svc.logger.Error("Failed to store challenge in cache", zap.Error(err))
+		return nil, fmt.Errorf("failed to generate challenge: %w", err)
+	}
+
+	//
+	// STEP 3: Seal the challenge with the public key of the user.
+	//
+
+	publicKeyBytes := federateduser.SecurityData.PublicKey.Key
+	if len(publicKeyBytes) != crypto.PublicKeySize {
+		svc.logger.Error("Invalid public key length",
+			zap.String("user_id", userID.String()),
+			zap.Int("length", len(publicKeyBytes)))
+		return nil, fmt.Errorf("invalid public key length: got %d, want %d", len(publicKeyBytes), crypto.PublicKeySize)
+	}
+	var publicKey [crypto.PublicKeySize]byte
+	copy(publicKey[:], publicKeyBytes)
+
+	sealedChallenge, err := box.SealAnonymous(nil, challenge, &publicKey, rand.Reader)
+	if err != nil {
+		svc.logger.Error("Failed to seal challenge", zap.Error(err))
+		return nil, fmt.Errorf("failed to seal challenge: %w", err)
+	}
+
+	svc.logger.Debug("Issued master key rotation challenge",
+		zap.String("user_id", userID.String()),
+		zap.String("challenge_id", challengeData.ChallengeID))
+
+	return &RotateMasterKeyChallengeResponseDTO{
+		ChallengeID:        challengeData.ChallengeID,
+		EncryptedChallenge: base64.StdEncoding.EncodeToString(sealedChallenge),
+	}, nil
+}
+
+// rotateMasterKeyChallengeCacheKey returns the cache key of a rotation challenge
+func rotateMasterKeyChallengeCacheKey(challengeID string) string {
+	return fmt.Sprintf("rotate_master_key_challenge:%s", challengeID)
+}
@@ -0,0 +1,247 @@
+// internal/maplefile/service/me/rotate_master_key_test.go
+package me
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config/constants"
+	dom_federateduser "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/domain/federateduser"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/domain/keys"
+	iam_mocks "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/iam/mocks"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/mocks"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/storage/cache/cassandracache"
+)
+
+// challengeCache holds the challenges issued to the user
+type challengeCache struct {
+	cassandracache.CassandraCacher
+	entries map[string][]byte
+}
+
+func (c *challengeCache) Get(ctx context.Context, key string) ([]byte, error) {
+	return c.entries[key], nil
+}
+
+func (c *challengeCache) Delete(ctx context.Context, key string) error {
+	delete(c.entries, key)
+	return nil
+}
+
+// wrappedKey returns a base64 encoded nonce and ciphertext filled with b
+func wrappedKey(b byte) string {
+	return base64.RawURLEncoding.EncodeToString(bytes.Repeat([]byte{b}, 64))
+}
+
+func TestRotateMasterKeyService_Execute(t *testing.T) {
+	userID := gocql.TimeUUID()
+	rotatedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// storedCollection is an owned collection on its second key, with both versions in the history
+	storedCollection := func() *dom_collection.Collection {
+		return &dom_collection.Collection{
+			ID:      gocql.TimeUUID(),
+			OwnerID: userID,
+			Version: 4,
+			EncryptedCollectionKey: &keys.EncryptedCollectionKey{
+				Ciphertext: []byte("old ciphertext"),
+				Nonce:      []byte("old nonce"),
+				KeyVersion: 2,
+				RotatedAt:  &rotatedAt,
+				PreviousKeys: []keys.EncryptedHistoricalKey{
+					{KeyVersion: 1, Ciphertext: []byte("old v1"), Nonce: []byte("old v1 nonce"), RotatedAt: rotatedAt.AddDate(-1, 0, 0), Algorithm: "chacha20poly1305"},
+					{KeyVersion: 2, Ciphertext: []byte("former owner v2"), Nonce: []byte("former owner v2 nonce"), RotatedAt: rotatedAt, Algorithm: "chacha20poly1305"},
+				},
+			},
+		}
+	}
+
+	// rewrapped is the key a client submits for the stored collection, with only version 1 re-wrapped
+	rewrapped := func(collection *dom_collection.Collection, keyVersion int) *RotatedCollectionKeyDTO {
+		return &RotatedCollectionKeyDTO{
+			CollectionID: collection.ID,
+			Version:      collection.Version,
+			EncryptedCollectionKey: &keys.EncryptedCollectionKey{
+				Ciphertext: []byte("new ciphertext"),
+				Nonce:      []byte("new nonce"),
+				KeyVersion: keyVersion,
+				PreviousKeys: []keys.EncryptedHistoricalKey{
+					{KeyVersion: 1, Ciphertext: []byte("new v1"), Nonce: []byte("new v1 nonce")},
+				},
+			},
+		}
+	}
+
+	setup := func(t *testing.T) (RotateMasterKeyService, context.Context, *RotateMasterKeyRequestDTO, *mocks.MockCollectionRepository, *iam_mocks.MockFederatedUserGetByIDUseCase, *iam_mocks.MockFederatedUserUpdateUseCase) {
+		ctrl := gomock.NewController(t)
+		collectionRepo := mocks.NewMockCollectionRepository(ctrl)
+		userGetByID := iam_mocks.NewMockFederatedUserGetByIDUseCase(ctrl)
+		userUpdate := iam_mocks.NewMockFederatedUserUpdateUseCase(ctrl)
+
+		challenge := []byte("challenge")
+		challengeData, err := json.Marshal(&RotateMasterKeyChallengeData{
+			ChallengeID:     "challenge-id",
+			Challenge:       base64.StdEncoding.EncodeToString(challenge),
+			FederatedUserID: userID.String(),
+			ExpiresAt:       time.Now().Add(time.Minute),
+		})
+		require.NoError(t, err)
+		cache := &challengeCache{entries: map[string][]byte{rotateMasterKeyChallengeCacheKey("challenge-id"): challengeData}}
+
+		svc := NewRotateMasterKeyService(&config.Configuration{}, zap.NewNop(), cache, userGetByID, userUpdate, collectionRepo)
+		ctx := context.WithValue(context.Background(), constants.SessionFederatedUserID, userID)
+		req := &RotateMasterKeyRequestDTO{
+			ChallengeID:                          "challenge-id",
+			DecryptedChallenge:                   base64.StdEncoding.EncodeToString(challenge),
+			NewEncryptedMasterKey:                wrappedKey(1),
+			NewEncryptedPrivateKey:               wrappedKey(2),
+			NewEncryptedRecoveryKey:              wrappedKey(3),
+			NewMasterKeyEncryptedWithRecoveryKey: wrappedKey(4),
+		}
+		return svc, ctx, req, collectionRepo, userGetByID, userUpdate
+	}
+
+	newUser := func() *dom_federateduser.FederatedUser {
+		return &dom_federateduser.FederatedUser{
+			ID: userID,
+			SecurityData: &dom_federateduser.FederatedUserSecurityData{
+				EncryptedMasterKey: keys.EncryptedMasterKey{Ciphertext: []byte("old master key"), Nonce: []byte("old master nonce"), KeyVersion: 1},
+				CurrentKeyVersion:  1,
+			},
+		}
+	}
+
+	t.Run("Success - Only the wrapped keys of the collection change", func(t *testing.T) {
+		svc, ctx, req, collectionRepo, userGetByID, userUpdate := setup(t)
+		collection := storedCollection()
+		req.CollectionKeys = []*RotatedCollectionKeyDTO{rewrapped(collection, 2)}
+
+		userGetByID.EXPECT().Execute(gomock.Any(), userID).Return(newUser(), nil)
+		collectionRepo.EXPECT().Get(gomock.Any(), collection.ID).Return(collection, nil)
+		var saved *keys.EncryptedCollectionKey
+		collectionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, c *dom_collection.Collection) error {
+			saved = c.EncryptedCollectionKey
+			return nil
+		})
+		userUpdate.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := svc.Execute(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, 2, resp.KeyVersion)
+
+		require.NotNil(t, saved)
+		assert.Equal(t, []byte("new ciphertext"), saved.Ciphertext)
+		assert.Equal(t, []byte("new nonce"), saved.Nonce)
+		assert.Equal(t, 2, saved.KeyVersion)
+		assert.Equal(t, &rotatedAt, saved.RotatedAt)
+		require.Len(t, saved.PreviousKeys, 2)
+		assert.Equal(t, []byte("new v1"), saved.PreviousKeys[0].Ciphertext)
+		assert.Equal(t, rotatedAt.AddDate(-1, 0, 0), saved.PreviousKeys[0].RotatedAt)
+		assert.Equal(t, "chacha20poly1305", saved.PreviousKeys[0].Algorithm)
+		assert.Equal(t, []byte("former owner v2"), saved.PreviousKeys[1].Ciphertext)
+	})
+
+	t.Run("Bad Request - Key version differs from the stored one", func(t *testing.T) {
+		svc, ctx, req, collectionRepo, userGetByID, _ := setup(t)
+		collection := storedCollection()
+		req.CollectionKeys = []*RotatedCollectionKeyDTO{rewrapped(collection, 0)}
+
+		userGetByID.EXPECT().Execute(gomock.Any(), userID).Return(newUser(), nil)
+		collectionRepo.EXPECT().Get(gomock.Any(), collection.ID).Return(collection, nil)
+
+		_, err := svc.Execute(ctx, req)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "key version")
+	})
+
+	t.Run("Bad Request - Previous key not in the history", func(t *testing.T) {
+		svc, ctx, req, collectionRepo, userGetByID, _ := setup(t)
+		collection := storedCollection()
+		collectionKey := rewrapped(collection, 2)
+		collectionKey.EncryptedCollectionKey.PreviousKeys[0].KeyVersion = 7
+		req.CollectionKeys = []*RotatedCollectionKeyDTO{collectionKey}
+
+		userGetByID.EXPECT().Execute(gomock.Any(), userID).Return(newUser(), nil)
+		collectionRepo.EXPECT().Get(gomock.Any(), collection.ID).Return(collection, nil)
+
+		_, err := svc.Execute(ctx, req)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not in the key history")
+	})
+
+	t.Run("Failure - Collection keys are restored when the account fails to update", func(t *testing.T) {
+		svc, ctx, req, collectionRepo, userGetByID, userUpdate := setup(t)
+		first, second := storedCollection(), storedCollection()
+		original := first.EncryptedCollectionKey
+		req.CollectionKeys = []*RotatedCollectionKeyDTO{rewrapped(first, 2), rewrapped(second, 2)}
+
+		userGetByID.EXPECT().Execute(gomock.Any(), userID).Return(newUser(), nil)
+		collectionRepo.EXPECT().Get(gomock.Any(), first.ID).Return(first, nil)
+		collectionRepo.EXPECT().Get(gomock.Any(), second.ID).Return(second, nil)
+		var savedKeys []*keys.EncryptedCollectionKey
+		collectionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, c *dom_collection.Collection) error {
+			savedKeys = append(savedKeys, c.EncryptedCollectionKey)
+			return nil
+		}).Times(4)
+		userUpdate.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(errors.New("unavailable"))
+
+		_, err := svc.Execute(ctx, req)
+		require.Error(t, err)
+
+		require.Len(t, savedKeys, 4)
+		assert.Equal(t, original, savedKeys[2])
+		assert.Equal(t, []byte("old ciphertext"), first.EncryptedCollectionKey.Ciphertext)
+		assert.Equal(t, []byte("old ciphertext"), second.EncryptedCollectionKey.Ciphertext)
+		assert.Equal(t, uint64(6), first.Version)
+	})
+
+	t.Run("Failure - Earlier collections are restored when a collection fails to update", func(t *testing.T) {
+		svc, ctx, req, collectionRepo, userGetByID, _ := setup(t)
+		first, second := storedCollection(), storedCollection()
+		req.CollectionKeys = []*RotatedCollectionKeyDTO{rewrapped(first, 2), rewrapped(second, 2)}
+
+		userGetByID.EXPECT().Execute(gomock.Any(), userID).Return(newUser(), nil)
+		collectionRepo.EXPECT().Get(gomock.Any(), first.ID).Return(first, nil)
+		collectionRepo.EXPECT().Get(gomock.Any(), second.ID).Return(second, nil)
+		gomock.InOrder(
+			collectionRepo.EXPECT().Update(gomock.Any(), first).Return(nil),
+			collectionRepo.EXPECT().Update(gomock.Any(), second).Return(errors.New("unavailable")),
+			collectionRepo.EXPECT().Update(gomock.Any(), first).Return(nil),
+		)
+
+		_, err := svc.Execute(ctx, req)
+		require.Error(t, err)
+		assert.Equal(t, []byte("old ciphertext"), first.EncryptedCollectionKey.Ciphertext)
+	})
+
+	t.Run("Success - Retry skips collection keys which are already current", func(t *testing.T) {
+		svc, ctx, req, collectionRepo, userGetByID, userUpdate := setup(t)
+		collection := storedCollection()
+		collectionKey := rewrapped(collection, 2)
+		collection.EncryptedCollectionKey.Ciphertext = collectionKey.EncryptedCollectionKey.Ciphertext
+		collectionKey.Version = 3 // Re-wrapped before the earlier attempt updated the collection
+		req.CollectionKeys = []*RotatedCollectionKeyDTO{collectionKey}
+
+		userGetByID.EXPECT().Execute(gomock.Any(), userID).Return(newUser(), nil)
+		collectionRepo.EXPECT().Get(gomock.Any(), collection.ID).Return(collection, nil)
+		userUpdate.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := svc.Execute(ctx, req)
+		require.NoError(t, err)
+		require.Len(t, resp.Collections, 1)
+		assert.Equal(t, collection.Version, resp.Collections[0].Version)
+	})
+}
@@ -16,11 +16,14 @@ func Module() fx.Option {
 			me.NewDeleteMeService,
 			me.NewGetMeService,
 			me.NewUpdateMeService,
+			me.NewRotateMasterKeyService,
+			me.NewRotateMasterKeyChallengeService,
 			me.NewVerifyProfileService,
 
 			// Collection services - Basic CRUD
 			collection.NewCreateCollectionService,
 			collection.NewGetCollectionService,
+			collection.NewGetCollectionHistoryService,
 			collection.NewUpdateCollectionService,
 			collection.NewSoftDeleteCollectionService,
 			collection.NewArchiveCollectionService,
@@ -35,7 +38,13 @@ func Module() fx.Option {
 			// Collection services - Sharing
 			collection.NewShareCollectionService,
 			collection.NewRemoveMemberService,
+			collection.NewUpdateMembersPermissionsService,
+			collection.NewListCollectionMembersService,
+			collection.NewTransferOwnershipService,
+			collection.NewReconcileCollectionMembersService,
 			collection.NewListSharedCollectionsService,
+			collection.NewGetCollectionMemberPublicKeysService,
+			collection.NewRemoveExpiredMembersService,
 
 			// Collection services - Filtered operations
 			collection.NewGetFilteredCollectionsService,
@@ -49,10 +58,14 @@ func Module() fx.Option {
 			file.NewGetFileService,
 			file.NewListFilesByCollectionService,
 			file.NewUpdateFileService,
+			file.NewMoveFileService,
 			file.NewCreatePendingFileService,
+			file.NewPrepareFileBatchUploadService,
+			file.NewCreateFileBatchService,
 			file.NewCompleteFileUploadService,
 			file.NewGetPresignedUploadURLService,
 			file.NewGetPresignedDownloadURLService,
+			file.NewGetPresignedDownloadURLsService,
 			file.NewListFilesByCreatedByUserIDService,
 			file.NewListFilesByOwnerIDService,
 			file.NewArchiveFileService,
@@ -0,0 +1,64 @@
+// cloud/backend/internal/maplefile/usecase/collection/update_members_permissions.go
+package collection
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	dom_collection "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/collection"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+)
+
+type UpdateMembersPermissionsUseCase interface {
+	Execute(ctx context.Context, collectionID gocql.UUID, updates map[gocql.UUID]string) error
+}
+
+type updateMembersPermissionsUseCaseImpl struct {
+	config *config.Configuration
+	logger *zap.Logger
+	repo   dom_collection.CollectionRepository
+}
+
+func NewUpdateMembersPermissionsUseCase(
+	config *config.Configuration,
+	logger *zap.Logger,
+	repo dom_collection.CollectionRepository,
+) UpdateMembersPermissionsUseCase {
+	logger = logger.Named("UpdateMembersPermissionsUseCase")
+	return &updateMembersPermissionsUseCaseImpl{config, logger, repo}
+}
+
+func (uc *updateMembersPermissionsUseCaseImpl) Execute(ctx context.Context, collectionID gocql.UUID, updates map[gocql.UUID]string) error {
+	//
+	// STEP 1: Validation.
+	//
+
+	e := make(map[string]string)
+	if collectionID.String() == "" {
+		e["collection_id"] = "Collection ID is required"
+	}
+	if len(updates) == 0 {
+		e["updates"] = "At least one permission update is required"
+	}
+	for recipientID, newPermission := range updates {
+		if newPermission != dom_collection.CollectionPermissionReadOnly &&
+			newPermission != dom_collection.CollectionPermissionReadWrite &&
+			newPermission != dom_collection.CollectionPermissionAdmin {
+			e["permission_level."+recipientID.String()] = "Invalid permission level"
+		}
+	}
+	if len(e) != 0 {
+		uc.logger.Warn("Failed validating update members permissions",
+			zap.Any("error", e))
+		return httperror.NewForBadRequest(&e)
+	}
+
+	//
+	// STEP 2: Update every member permission in a single collection update.
+	//
+
+	return uc.repo.UpdateMembersPermissions(ctx, collectionID, updates)
+}
@@ -0,0 +1,110 @@
+// cloud/backend/internal/maplefile/usecase/fileobjectstorage/presigned_download_urls.go
+package fileobjectstorage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/config"
+	dom_file "github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/internal/maplefile/domain/file"
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/httperror"
+)
+
+// presignConcurrency is the number of presigned URLs generated at the same time
+const presignConcurrency = 16
+
+type GeneratePresignedDownloadURLsUseCase interface {
+	// Execute returns the presigned download URL of every storage path, keyed by storage path
+	Execute(ctx context.Context, storagePaths []string, duration time.Duration) (map[string]string, error)
+}
+
+type generatePresignedDownloadURLsUseCaseImpl struct {
+	config *config.Configuration
+	logger *zap.Logger
+	repo   dom_file.FileObjectStorageRepository
+}
+
+func NewGeneratePresignedDownloadURLsUseCase(
+	config *config.Configuration,
+	logger *zap.Logger,
+	repo dom_file.FileObjectStorageRepository,
+) GeneratePresignedDownloadURLsUseCase {
+	logger = logger.Named("GeneratePresignedDownloadURLsUseCase")
+	return &generatePresignedDownloadURLsUseCaseImpl{config, logger, repo}
+}
+
+func (uc *generatePresignedDownloadURLsUseCaseImpl) Execute(ctx context.Context, storagePaths []string, duration time.Duration) (map[string]string, error) {
+	//
+	// STEP 1: Validation.
+	//
+
+	e := make(map[string]string)
+	if len(storagePaths) == 0 {
+		e["storage_paths"] = "Storage paths are required"
+	}
+	for i, storagePath := range storagePaths {
+		if storagePath == "" {
+			e[fmt.Sprintf("storage_paths[%d]", i)] = "Storage path is required"
+		}
+	}
+	if duration <= 0 {
+		e["duration"] = "Duration must be greater than 0"
+	}
+	// Set reasonable limits for presigned URL duration
+	maxDuration := 24 * time.Hour // 24 hours max
+	if duration > maxDuration {
+		e["duration"] = "Duration cannot exceed 24 hours"
+	}
+	if len(e) != 0 {
+		uc.logger.Warn("Failed validating generate presigned download URLs",
+			zap.Any("error", e))
+		return nil, httperror.NewForBadRequest(&e)
+	}
+
+	//
+	// STEP 2: Generate the presigned download URLs concurrently.
+	//
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	urls := make(map[string]string, len(storagePaths))
+	limiter := make(chan struct{}, presignConcurrency)
+
+	for _, storagePath := range storagePaths {
+		wg.Add(1)
+		go func(storagePath string) {
+			defer wg.Done()
+			limiter <- struct{}{}
+			defer func() { <-limiter }()
+
+			url, err := uc.repo.GeneratePresignedDownloadURL(storagePath, duration)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				uc.logger.Error("Failed to generate presigned download URL",
+					zap.String("storage_path", storagePath),
+					zap.Duration("duration", duration),
+					zap.Error(err))
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			urls[storagePath] = url
+		}(storagePath)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return urls, nil
+}
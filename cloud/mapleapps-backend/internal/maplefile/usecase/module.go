@@ -45,6 +45,7 @@ func Module() fx.Option {
 			collection.NewRemoveMemberFromHierarchyUseCase,
 			collection.NewUpdateCollectionUseCase,
 			collection.NewUpdateMemberPermissionUseCase,
+			collection.NewUpdateMembersPermissionsUseCase,
 			collection.NewGetCollectionSyncDataUseCase,
 			collection.NewCountUserCollectionsUseCase,
 
@@ -75,6 +76,7 @@ func Module() fx.Option {
 			fileobjectstorage.NewStoreMultipleEncryptedDataUseCase,
 			fileobjectstorage.NewGeneratePresignedUploadURLUseCase,
 			fileobjectstorage.NewGeneratePresignedDownloadURLUseCase,
+			fileobjectstorage.NewGeneratePresignedDownloadURLsUseCase,
 			fileobjectstorage.NewVerifyObjectExistsUseCase,
 			fileobjectstorage.NewGetObjectSizeUseCase,
 		),
@@ -59,6 +59,22 @@ var (
 	LTTAdjustmentHighestTier = decimal.NewFromFloat(3525)
 )
 
+// Provinces with land transfer tax tables
+const (
+	ProvinceOntario = "ON"
+)
+
+// landTransferTaxBrackets holds the marginal brackets of each province, ordered by threshold. A
+// rate applies to the part of the purchase price above its threshold and below the next one.
+var landTransferTaxBrackets = map[string][]landTransferTaxBracket{
+	ProvinceOntario: {
+		{Threshold: DecimalZero, Rate: LTTRateLowerTier},
+		{Threshold: LTTLowerThreshold, Rate: LTTRateMiddleTier},
+		{Threshold: LTTMiddleThreshold, Rate: LTTRateUpperTier},
+		{Threshold: LTTUpperThreshold, Rate: LTTRateHighestTier},
+	},
+}
+
 // Loan-to-Value Thresholds
 var (
 	LTVNinetyPercent     = decimal.NewFromInt(90)
@@ -1,7 +1,11 @@
 package main
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -10,6 +14,24 @@ import (
 )
 
 func main() {
+	jsonOutput := flag.Bool("json", false, "Print the land transfer tax breakdown as JSON")
+	investmentExport := flag.String("investment-export", "", "Print the initial investment breakdown as json or csv")
+	currency := flag.String("currency", incomepropertykit.CurrencyCAD, "Currency of the initial investment breakdown amounts")
+	flag.Parse()
+
+	taxCalc := incomepropertykit.TaxCalculator{}
+	purchasePrice := decimal.NewFromFloat(250000.00)
+	breakdown := taxCalc.LandTransferTaxBreakdown(purchasePrice, incomepropertykit.ProvinceOntario)
+	if *jsonOutput {
+		output, err := json.MarshalIndent(breakdown, "", "  ")
+		if err != nil {
+			fmt.Printf("Error encoding land transfer tax breakdown: %v\n", err)
+			return
+		}
+		fmt.Println(string(output))
+		return
+	}
+
 	// Create a mortgage
 	mortgage := &incomepropertykit.Mortgage{
 		LoanPurchaseAmount:     decimal.NewFromFloat(250000.00),
@@ -36,11 +58,14 @@ func main() {
 	mortgage.PercentFinanced = mortgageCalc.PercentOfLoanFinanced()
 	mortgage.InsuranceAmount = mortgageCalc.MortgageInsurancePremium()
 
-	fmt.Printf("Mortgage Payment: $%s\n", mortgage.MortgagePayment.StringFixed(2))
-	fmt.Printf("Interest Rate Per Payment: %s\n", mortgage.InterestRatePerPayment.StringFixed(4))
-	fmt.Printf("Total Number of Payments: %s\n", mortgage.TotalNumberOfPayments.StringFixed(0))
-	fmt.Printf("Percent Financed: %s%%\n", mortgage.PercentFinanced.StringFixed(2))
-	fmt.Printf("Insurance Amount: $%s\n", mortgage.InsuranceAmount.StringFixed(2))
+	// Exports print nothing but the export
+	if *investmentExport == "" {
+		fmt.Printf("Mortgage Payment: $%s\n", mortgage.MortgagePayment.StringFixed(2))
+		fmt.Printf("Interest Rate Per Payment: %s\n", mortgage.InterestRatePerPayment.StringFixed(4))
+		fmt.Printf("Total Number of Payments: %s\n", mortgage.TotalNumberOfPayments.StringFixed(0))
+		fmt.Printf("Percent Financed: %s%%\n", mortgage.PercentFinanced.StringFixed(2))
+		fmt.Printf("Insurance Amount: $%s\n", mortgage.InsuranceAmount.StringFixed(2))
+	}
 
 	// Create a financial analysis
 	analysis := &incomepropertykit.FinancialAnalysis{
@@ -77,6 +102,27 @@ func main() {
 	analysis.CapRateWithMortgage = financialCalc.CapRateWithMortgageExpenseIncluded()
 	analysis.CapRateWithoutMortgage = financialCalc.CapRateWithMortgageExpenseExcluded()
 	analysis.InitialInvestmentAmount = financialCalc.TotalInitialInvestmentAmount()
+	investmentBreakdown := financialCalc.InitialInvestmentBreakdown()
+
+	switch *investmentExport {
+	case "":
+	case "json":
+		output, err := json.MarshalIndent(investmentBreakdown, "", "  ")
+		if err != nil {
+			fmt.Printf("Error encoding initial investment breakdown: %v\n", err)
+			return
+		}
+		fmt.Println(string(output))
+		return
+	case "csv":
+		if err := csv.NewWriter(os.Stdout).WriteAll(investmentBreakdown.CSVRecords()); err != nil {
+			fmt.Printf("Error encoding initial investment breakdown: %v\n", err)
+		}
+		return
+	default:
+		fmt.Printf("Unknown investment export format %q, expected json or csv\n", *investmentExport)
+		return
+	}
 
 	fmt.Printf("\nFinancial Analysis:\n")
 	fmt.Printf("Annual Net Income (without mortgage): $%s\n", analysis.AnnualNetIncome.StringFixed(2))
@@ -85,7 +131,10 @@ func main() {
 	fmt.Printf("Monthly Cash Flow (with mortgage): $%s\n", analysis.MonthlyCashFlow.StringFixed(2))
 	fmt.Printf("Cap Rate (with mortgage): %s%%\n", analysis.CapRateWithMortgage.StringFixed(2))
 	fmt.Printf("Cap Rate (without mortgage): %s%%\n", analysis.CapRateWithoutMortgage.StringFixed(2))
-	fmt.Printf("Initial Investment: $%s\n", analysis.InitialInvestmentAmount.StringFixed(2))
+	fmt.Printf("Initial Investment: %s\n", incomepropertykit.FormatMoney(analysis.InitialInvestmentAmount, *currency))
+	for _, component := range investmentBreakdown.Components {
+		fmt.Printf("  %s: %s\n", component.Label, incomepropertykit.FormatMoney(component.Amount, *currency))
+	}
 
 	// Generate annual projections
 	projections := financialCalc.GenerateAnnualProjections()
@@ -120,7 +169,13 @@ func main() {
 	fmt.Printf("  ROI: %s%%\n", projections[24].ReturnOnInvestmentPercent.StringFixed(2))
 
 	// Calculate land transfer tax
-	taxCalc := incomepropertykit.TaxCalculator{}
-	landTransferTax := taxCalc.CalculateLandTransferTax(decimal.NewFromFloat(250000.00))
+	landTransferTax := taxCalc.CalculateLandTransferTax(purchasePrice)
 	fmt.Printf("\nLand Transfer Tax: $%s\n", landTransferTax.StringFixed(2))
+	for _, line := range breakdown {
+		fmt.Printf("  %s%% of $%s above $%s: $%s\n",
+			line.Rate.Mul(incomepropertykit.DecimalHundred).StringFixed(2),
+			line.TaxableAmount.StringFixed(2),
+			line.Threshold.StringFixed(2),
+			line.Amount.StringFixed(2))
+	}
 }
@@ -4,6 +4,14 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// Labels of the initial investment components
+const (
+	InvestmentComponentDownPayment         = "down_payment"
+	InvestmentComponentBuyingFees          = "buying_fees"
+	InvestmentComponentOtherPurchaseFees   = "other_purchase_fees"
+	InvestmentComponentCapitalImprovements = "capital_improvements"
+)
+
 // FinancialAnalysisCalculator handles property financial calculations
 type FinancialAnalysisCalculator struct {
 	Analysis *FinancialAnalysis
@@ -61,6 +69,50 @@ func (calc *FinancialAnalysisCalculator) TotalInitialInvestmentAmount() decimal.
 	return calc.Analysis.PurchaseFeesAmount.Add(calc.Analysis.CapitalImprovementsAmount)
 }
 
+// InvestmentComponent is one labeled part of the initial investment
+type InvestmentComponent struct {
+	Label  string          `json:"label"`  // One of the InvestmentComponent* labels
+	Amount decimal.Decimal `json:"amount"` // Cash put into this component upfront
+}
+
+// InvestmentBreakdown is the initial investment split into where the upfront cash goes. The
+// amounts of the components sum to the total.
+type InvestmentBreakdown struct {
+	Components []InvestmentComponent `json:"components"`
+	Total      decimal.Decimal       `json:"total"`
+}
+
+// CSVRecords returns the breakdown as CSV records, a header, one record per component and the total
+func (b InvestmentBreakdown) CSVRecords() [][]string {
+	records := [][]string{{"component", "amount"}}
+	for _, component := range b.Components {
+		records = append(records, []string{component.Label, component.Amount.StringFixed(2)})
+	}
+	return append(records, []string{"total", b.Total.StringFixed(2)})
+}
+
+// InitialInvestmentBreakdown returns the components of TotalInitialInvestmentAmount. The purchase
+// fees are the cash paid at closing, so the down payment of the mortgage and the fees charged at
+// BuyingFeeRate are split out of them and the rest is reported as other purchase fees.
+func (calc *FinancialAnalysisCalculator) InitialInvestmentBreakdown() InvestmentBreakdown {
+	downPayment := DecimalZero
+	if calc.Analysis.Mortgage != nil {
+		downPayment = calc.Analysis.Mortgage.DownPayment
+	}
+	buyingFees := calc.Analysis.PurchasePrice.Mul(calc.Analysis.BuyingFeeRate).Round(2)
+	otherPurchaseFees := calc.TotalPurchaseFeesAmount().Sub(downPayment).Sub(buyingFees)
+
+	return InvestmentBreakdown{
+		Components: []InvestmentComponent{
+			{Label: InvestmentComponentDownPayment, Amount: downPayment},
+			{Label: InvestmentComponentBuyingFees, Amount: buyingFees},
+			{Label: InvestmentComponentOtherPurchaseFees, Amount: otherPurchaseFees},
+			{Label: InvestmentComponentCapitalImprovements, Amount: calc.TotalCapitalImprovementsAmount()},
+		},
+		Total: calc.TotalInitialInvestmentAmount(),
+	}
+}
+
 // TotalMonthlyExpensesAmount calculates the total monthly expenses
 func (calc *FinancialAnalysisCalculator) TotalMonthlyExpensesAmount() decimal.Decimal {
 	return calc.Analysis.MonthlyExpense
@@ -103,9 +155,14 @@ func (calc *FinancialAnalysisCalculator) MonthlyNetIncomeWithMortgage() decimal.
 
 // AnnualNetIncomeWithMortgage calculates the annual net income with mortgage
 func (calc *FinancialAnalysisCalculator) AnnualNetIncomeWithMortgage() decimal.Decimal {
+	return calc.annualNetIncomeWith(calc.Analysis.Mortgage)
+}
+
+// annualNetIncomeWith calculates the annual net income after the payments of the given mortgage
+func (calc *FinancialAnalysisCalculator) annualNetIncomeWith(mortgage *Mortgage) decimal.Decimal {
 	netIncome := calc.AnnualNetIncomeWithoutMortgage()
-	paymentFreq := decimal.NewFromInt(int64(calc.Analysis.Mortgage.PaymentFrequency))
-	annualMortgagePayment := calc.Analysis.Mortgage.MortgagePayment.Mul(paymentFreq)
+	paymentFreq := decimal.NewFromInt(int64(mortgage.PaymentFrequency))
+	annualMortgagePayment := mortgage.MortgagePayment.Mul(paymentFreq)
 	return netIncome.Sub(annualMortgagePayment)
 }
 
@@ -42,6 +42,32 @@ func TestFinancialAnalysisCalculator_TotalInitialInvestmentAmount(t *testing.T)
 	assert.True(t, expected.Equal(actual), "Initial investment should be 58100.00")
 }
 
+func TestFinancialAnalysisCalculator_InitialInvestmentBreakdown(t *testing.T) {
+	analysis := CreateFinancialAnalysisForTests()
+	calculator := NewFinancialAnalysisCalculator(analysis)
+
+	breakdown := calculator.InitialInvestmentBreakdown()
+
+	expected := map[string]decimal.Decimal{
+		InvestmentComponentDownPayment:         decimal.NewFromFloat(50000.00),
+		InvestmentComponentBuyingFees:          decimal.NewFromFloat(1500.00), // 250000 * 0.006
+		InvestmentComponentOtherPurchaseFees:   decimal.NewFromFloat(6600.00), // 58100 - 50000 - 1500
+		InvestmentComponentCapitalImprovements: decimal.NewFromFloat(0.00),
+	}
+	sum := decimal.Zero
+	for _, component := range breakdown.Components {
+		assert.True(t, expected[component.Label].Equal(component.Amount), "%s should be %s, got %s", component.Label, expected[component.Label], component.Amount)
+		sum = sum.Add(component.Amount)
+	}
+	assert.Len(t, breakdown.Components, len(expected))
+	assert.True(t, breakdown.Total.Equal(calculator.TotalInitialInvestmentAmount()), "Total should match the initial investment")
+	assert.True(t, sum.Equal(breakdown.Total), "Components should sum to the total")
+
+	records := breakdown.CSVRecords()
+	assert.Equal(t, []string{"component", "amount"}, records[0])
+	assert.Equal(t, []string{"total", "58100.00"}, records[len(records)-1])
+}
+
 func TestFinancialAnalysisCalculator_TotalExpensesAmount(t *testing.T) {
 	analysis := CreateFinancialAnalysisForTests()
 	calculator := NewFinancialAnalysisCalculator(analysis)
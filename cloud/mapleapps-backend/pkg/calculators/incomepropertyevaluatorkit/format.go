@@ -0,0 +1,71 @@
+package incomepropertyevaluatorkit
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Currency codes supported by FormatMoney
+const (
+	CurrencyCAD = "CAD"
+	CurrencyUSD = "USD"
+	CurrencyEUR = "EUR"
+	CurrencyGBP = "GBP"
+)
+
+// currencySymbols are the symbols written before amounts, as a Canadian reader expects them, so
+// the dollar sign alone means Canadian dollars
+var currencySymbols = map[string]string{
+	CurrencyCAD: "$",
+	CurrencyUSD: "US$",
+	CurrencyEUR: "€",
+	CurrencyGBP: "£",
+}
+
+// FormatMoney formats an amount rounded to cents with thousands separators, such as "$1,234.56" for
+// CAD or "-US$1,234.56" for USD. Unknown currencies are written with their code, as in "MXN 1,234.56".
+func FormatMoney(d decimal.Decimal, currency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	symbol, ok := currencySymbols[currency]
+	if !ok {
+		symbol = currency + " "
+	}
+
+	sign := ""
+	rounded := d.Round(2)
+	if rounded.IsNegative() {
+		sign = "-"
+		rounded = rounded.Neg()
+	}
+
+	return sign + symbol + groupThousands(rounded.StringFixed(2))
+}
+
+// FormatPercent formats a percentage such as 5.25 as "5.25%". Rates such as 0.0525 must be multiplied
+// by DecimalHundred first.
+func FormatPercent(d decimal.Decimal) string {
+	return groupThousands(d.StringFixed(2)) + "%"
+}
+
+// groupThousands inserts commas between the thousands of the integer part of a formatted number
+func groupThousands(s string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	integer, fraction, hasFraction := strings.Cut(s, ".")
+
+	var b strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(digit)
+	}
+	if hasFraction {
+		b.WriteString("." + fraction)
+	}
+
+	return sign + b.String()
+}
@@ -0,0 +1,42 @@
+package incomepropertyevaluatorkit
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatMoney(t *testing.T) {
+	for _, tc := range []struct {
+		amount   string
+		currency string
+		expected string
+	}{
+		{"1234.56", CurrencyCAD, "$1,234.56"},
+		{"250000", CurrencyCAD, "$250,000.00"},
+		{"1234567.891", "usd", "US$1,234,567.89"},
+		{"-58100", CurrencyUSD, "-US$58,100.00"},
+		{"999.995", CurrencyEUR, "€1,000.00"},
+		{"12.5", CurrencyGBP, "£12.50"},
+		{"0", CurrencyCAD, "$0.00"},
+		{"-0.001", CurrencyCAD, "$0.00"},
+		{"1500", "MXN", "MXN 1,500.00"},
+	} {
+		actual := FormatMoney(decimal.RequireFromString(tc.amount), tc.currency)
+		assert.Equal(t, tc.expected, actual, "FormatMoney(%s, %q)", tc.amount, tc.currency)
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	for amount, expected := range map[string]string{
+		"5.25":    "5.25%",
+		"80":      "80.00%",
+		"-3.456":  "-3.46%",
+		"1234.5":  "1,234.50%",
+		"0.00001": "0.00%",
+	} {
+		actual := FormatPercent(decimal.RequireFromString(amount))
+		assert.Equal(t, expected, actual, "FormatPercent(%s)", amount)
+	}
+}
@@ -57,28 +57,40 @@ type MortgageInterval struct {
 
 // FinancialAnalysis holds financial data for property analysis
 type FinancialAnalysis struct {
-	PurchasePrice             decimal.Decimal // Purchase price of the property
-	InflationRate             decimal.Decimal // Annual inflation rate as a decimal (e.g., 0.025 for 2.5%)
-	BuyingFeeRate             decimal.Decimal // Rate for buying fees as a decimal
-	SellingFeeRate            decimal.Decimal // Rate for selling fees as a decimal
-	AnnualRentalIncome        decimal.Decimal // Annual rental income
-	MonthlyRentalIncome       decimal.Decimal // Monthly rental income
-	AnnualFacilityIncome      decimal.Decimal // Annual income from facilities
-	MonthlyFacilityIncome     decimal.Decimal // Monthly income from facilities
-	AnnualGrossIncome         decimal.Decimal // Total annual gross income
-	MonthlyGrossIncome        decimal.Decimal // Total monthly gross income
-	AnnualExpense             decimal.Decimal // Annual expenses
-	MonthlyExpense            decimal.Decimal // Monthly expenses
-	AnnualNetIncome           decimal.Decimal // Annual net income without mortgage
-	MonthlyNetIncome          decimal.Decimal // Monthly net income without mortgage
-	AnnualCashFlow            decimal.Decimal // Annual cash flow with mortgage
-	MonthlyCashFlow           decimal.Decimal // Monthly cash flow with mortgage
-	CapRateWithMortgage       decimal.Decimal // Cap rate with mortgage included
-	CapRateWithoutMortgage    decimal.Decimal // Cap rate without mortgage
-	PurchaseFeesAmount        decimal.Decimal // Amount of purchase fees
-	CapitalImprovementsAmount decimal.Decimal // Amount spent on capital improvements
-	InitialInvestmentAmount   decimal.Decimal // Total initial investment
-	Mortgage                  *Mortgage       // Associated mortgage
+	PurchasePrice             decimal.Decimal  // Purchase price of the property
+	InflationRate             decimal.Decimal  // Annual inflation rate as a decimal (e.g., 0.025 for 2.5%)
+	BuyingFeeRate             decimal.Decimal  // Rate for buying fees as a decimal
+	SellingFeeRate            decimal.Decimal  // Rate for selling fees as a decimal
+	AnnualRentalIncome        decimal.Decimal  // Annual rental income
+	MonthlyRentalIncome       decimal.Decimal  // Monthly rental income
+	AnnualFacilityIncome      decimal.Decimal  // Annual income from facilities
+	MonthlyFacilityIncome     decimal.Decimal  // Monthly income from facilities
+	AnnualGrossIncome         decimal.Decimal  // Total annual gross income
+	MonthlyGrossIncome        decimal.Decimal  // Total monthly gross income
+	AnnualExpense             decimal.Decimal  // Annual expenses
+	MonthlyExpense            decimal.Decimal  // Monthly expenses
+	AnnualNetIncome           decimal.Decimal  // Annual net income without mortgage
+	MonthlyNetIncome          decimal.Decimal  // Monthly net income without mortgage
+	AnnualCashFlow            decimal.Decimal  // Annual cash flow with mortgage
+	MonthlyCashFlow           decimal.Decimal  // Monthly cash flow with mortgage
+	CapRateWithMortgage       decimal.Decimal  // Cap rate with mortgage included
+	CapRateWithoutMortgage    decimal.Decimal  // Cap rate without mortgage
+	PurchaseFeesAmount        decimal.Decimal  // Amount of purchase fees
+	CapitalImprovementsAmount decimal.Decimal  // Amount spent on capital improvements
+	InitialInvestmentAmount   decimal.Decimal  // Total initial investment
+	Mortgage                  *Mortgage        // Associated mortgage
+	RefinanceEvents           []RefinanceEvent // Refinances which replace the mortgage during the projections
+}
+
+// RefinanceEvent replaces the current mortgage with a new one at the start of a projection year,
+// paying off the remaining debt and returning the extracted equity as cash
+type RefinanceEvent struct {
+	Year               int             // Projection year the new mortgage starts in
+	AppraisedValue     decimal.Decimal // Appraised value of the property at the refinance
+	LoanToValue        decimal.Decimal // Loan-to-value ratio of the new mortgage (as a decimal, e.g., 0.8 for 80%)
+	AnnualInterestRate decimal.Decimal // Annual interest rate of the new mortgage, zero keeps the current rate
+	AmortizationYears  decimal.Decimal // Years to amortize the new mortgage, zero keeps the current amortization
+	Fees               decimal.Decimal // Legal, appraisal and penalty fees paid out of the extracted equity
 }
 
 // AnnualProjection represents financial projections for a specific year
@@ -95,6 +107,7 @@ type AnnualProjection struct {
 	ReturnOnInvestmentPercent decimal.Decimal // ROI as a percentage
 	AnnualizedROIRate         decimal.Decimal // Annualized ROI as a rate
 	AnnualizedROIPercent      decimal.Decimal // Annualized ROI as a percentage
+	RefinanceCashOut          decimal.Decimal // Equity extracted by refinancing this year, net of fees
 }
 
 // RentalIncome represents rental income for a property
@@ -0,0 +1,65 @@
+package incomepropertyevaluatorkit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Errors returned when parsing user supplied amounts and rates, wrapped with the offending input
+var (
+	ErrInvalidMoney = errors.New("invalid monetary amount")
+	ErrInvalidRate  = errors.New("invalid rate")
+)
+
+// moneyReplacer removes currency symbols, thousands separators and spaces from amounts
+var moneyReplacer = strings.NewReplacer("$", "", "€", "", "£", "", "¥", "", ",", "", "_", "", " ", "")
+
+// ParseMoney parses a non-negative monetary amount such as "250000", "$250,000.00" or "1 200.50"
+func ParseMoney(s string) (decimal.Decimal, error) {
+	cleaned := moneyReplacer.Replace(strings.TrimSpace(s))
+	if cleaned == "" {
+		return decimal.Zero, fmt.Errorf("%w %q: value is empty", ErrInvalidMoney, s)
+	}
+
+	amount, err := decimal.NewFromString(cleaned)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("%w %q: not a number", ErrInvalidMoney, s)
+	}
+	if amount.IsNegative() {
+		return decimal.Zero, fmt.Errorf("%w %q: must not be negative", ErrInvalidMoney, s)
+	}
+
+	return amount, nil
+}
+
+// ParseRate parses a rate between 0 and 1, either as a decimal such as "0.05" or as a percentage
+// such as "5%", which is returned as 0.05.
+func ParseRate(s string) (decimal.Decimal, error) {
+	cleaned := strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+	isPercent := strings.HasSuffix(cleaned, "%")
+	cleaned = strings.TrimSuffix(cleaned, "%")
+	if cleaned == "" {
+		return decimal.Zero, fmt.Errorf("%w %q: value is empty", ErrInvalidRate, s)
+	}
+
+	rate, err := decimal.NewFromString(cleaned)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("%w %q: not a number", ErrInvalidRate, s)
+	}
+	if isPercent {
+		rate = rate.Div(DecimalHundred)
+	}
+
+	if rate.IsNegative() {
+		return decimal.Zero, fmt.Errorf("%w %q: must not be negative", ErrInvalidRate, s)
+	}
+	if rate.GreaterThan(DecimalOne) {
+		// A bare "5" most likely meant 5%, say so instead of silently projecting a 500% rate
+		return decimal.Zero, fmt.Errorf("%w %q: must be between 0 and 1, use a percent sign for percentages like \"5%%\"", ErrInvalidRate, s)
+	}
+
+	return rate, nil
+}
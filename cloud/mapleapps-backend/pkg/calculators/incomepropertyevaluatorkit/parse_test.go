@@ -0,0 +1,50 @@
+package incomepropertyevaluatorkit
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney(t *testing.T) {
+	for input, expected := range map[string]string{
+		"250000":        "250000",
+		"$250,000.00":   "250000",
+		" 1 200.50 ":    "1200.5",
+		"€99":           "99",
+		"0":             "0",
+		"1_000_000.125": "1000000.125",
+	} {
+		actual, err := ParseMoney(input)
+		if assert.NoError(t, err, "ParseMoney(%q)", input) {
+			assert.True(t, decimal.RequireFromString(expected).Equal(actual), "ParseMoney(%q) = %s, expected %s", input, actual, expected)
+		}
+	}
+
+	for _, input := range []string{"", "  ", "$", "-5", "$-1,000", "abc", "12.3.4", "NaN"} {
+		_, err := ParseMoney(input)
+		assert.ErrorIs(t, err, ErrInvalidMoney, "ParseMoney(%q)", input)
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	for input, expected := range map[string]string{
+		"0.05":   "0.05",
+		"5%":     "0.05",
+		" 4.5 %": "0.045",
+		"0":      "0",
+		"1":      "1",
+		"100%":   "1",
+	} {
+		actual, err := ParseRate(input)
+		if assert.NoError(t, err, "ParseRate(%q)", input) {
+			assert.True(t, decimal.RequireFromString(expected).Equal(actual), "ParseRate(%q) = %s, expected %s", input, actual, expected)
+		}
+	}
+
+	for _, input := range []string{"", "%", "5", "101%", "-0.01", "-1%", "five", "$5"} {
+		_, err := ParseRate(input)
+		assert.ErrorIs(t, err, ErrInvalidRate, "ParseRate(%q)", input)
+	}
+}
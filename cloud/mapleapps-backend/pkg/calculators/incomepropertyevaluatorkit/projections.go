@@ -1,10 +1,35 @@
 package incomepropertyevaluatorkit
 
 import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
 	"github.com/shopspring/decimal"
 )
 
-// GenerateAnnualProjections generates financial projections for each year
+// ErrInvalidRefinanceYear is returned for a refinance event which does not start in a projection year
+var ErrInvalidRefinanceYear = errors.New("invalid refinance year")
+
+// GenerateAnnualProjectionsWithRefinances validates the refinance events and generates the financial
+// projections for each year. It returns ErrInvalidRefinanceYear for an event which does not start in
+// a projection year.
+func (calc *FinancialAnalysisCalculator) GenerateAnnualProjectionsWithRefinances() ([]AnnualProjection, error) {
+	for _, event := range calc.Analysis.RefinanceEvents {
+		if event.Year < 1 {
+			return nil, fmt.Errorf("%w %d: projections start in year 1", ErrInvalidRefinanceYear, event.Year)
+		}
+	}
+	return calc.GenerateAnnualProjections(), nil
+}
+
+// GenerateAnnualProjections generates financial projections for each year. Refinance events replace
+// the mortgage from their year on, so the projections of the years before are unchanged. The cash
+// extracted by a refinance is part of the total return of its year and of every year after it.
+// Refinance events which do not start in a projection year are ignored, use
+// GenerateAnnualProjectionsWithRefinances to reject them instead.
 func (calc *FinancialAnalysisCalculator) GenerateAnnualProjections() []AnnualProjection {
 	// Create a slice to hold all projections
 	projections := []AnnualProjection{}
@@ -23,6 +48,14 @@ func (calc *FinancialAnalysisCalculator) GenerateAnnualProjections() []AnnualPro
 	negInitialInvestment := initialInvestment.Neg() // Initial investment is negative
 	cashFlowArray := []decimal.Decimal{negInitialInvestment}
 
+	// Refinances in the order they happen, and the year the current mortgage started in
+	refinances := slices.DeleteFunc(slices.Clone(calc.Analysis.RefinanceEvents), func(event RefinanceEvent) bool { return event.Year < 1 })
+	slices.SortStableFunc(refinances, func(a, b RefinanceEvent) int { return cmp.Compare(a.Year, b.Year) })
+	nextRefinance := 0
+	mortgageStartYear := 1
+	// Cash extracted by the refinances of the years before, which was already received
+	previousRefinanceCashOut := decimal.Zero
+
 	// Previous year's cash flow
 	var previousYearsCashFlow decimal.Decimal
 	previousYearsCashFlow = decimal.Zero
@@ -32,8 +65,31 @@ func (calc *FinancialAnalysisCalculator) GenerateAnnualProjections() []AnnualPro
 
 	// Generate projections for 30 years
 	for year := 1; year <= 30; year++ {
+		// Replace the mortgage when a refinance starts this year
+		refinanceCashOut := zero
+		for nextRefinance < len(refinances) && refinances[nextRefinance].Year <= year {
+			event := refinances[nextRefinance]
+			nextRefinance++
+
+			// Debt paid off by the new mortgage
+			previousLoanBalance := mortgage.LoanAmount
+			if year > mortgageStartYear {
+				previousLoanBalance = DebtRemainingAtEndOfYear(year-mortgageStartYear, paymentSchedule, mortgage)
+			}
+			if previousLoanBalance.LessThan(zero) {
+				previousLoanBalance = zero
+			}
+
+			mortgage = refinancedMortgage(mortgage, event, calc.Analysis.Mortgage.FirstPaymentDate.AddDate(year-1, 0, 0))
+			paymentSchedule = NewMortgageCalculator(mortgage).GeneratePaymentSchedule()
+			annualNetIncomeWithMortgage = calc.annualNetIncomeWith(mortgage)
+			mortgageStartYear = year
+
+			refinanceCashOut = refinanceCashOut.Add(mortgage.LoanAmount.Sub(previousLoanBalance).Sub(event.Fees))
+		}
+
 		// Calculate remaining debt at end of year
-		loanBalance := DebtRemainingAtEndOfYear(year, paymentSchedule, mortgage)
+		loanBalance := DebtRemainingAtEndOfYear(year-mortgageStartYear+1, paymentSchedule, mortgage)
 
 		// Handle case where loan is paid off
 		if loanBalance.LessThan(zero) {
@@ -50,6 +106,10 @@ func (calc *FinancialAnalysisCalculator) GenerateAnnualProjections() []AnnualPro
 			appreciatedCashFlow = appreciatedDecimalNumber(annualNetIncomeWithoutMortgage, year, inflationRate)
 		}
 
+		// The extracted equity is received in cash this year
+		cashFlow = cashFlow.Add(refinanceCashOut)
+		appreciatedCashFlow = appreciatedCashFlow.Add(refinanceCashOut)
+
 		// Calculate appreciated sales price
 		appreciatedSalesPrice := appreciatedDecimalNumber(salesPrice, year, inflationRate)
 
@@ -60,8 +120,9 @@ func (calc *FinancialAnalysisCalculator) GenerateAnnualProjections() []AnnualPro
 		// Calculate proceeds of sale
 		proceedsOfSale := appreciatedSalesPrice.Sub(appreciatedFees).Sub(loanBalance)
 
-		// Calculate total return
-		totalReturn := proceedsOfSale.Add(appreciatedCashFlow)
+		// Calculate total return. The debt includes the extracted equity, so the cash received for it
+		// counts as well, or a refinance would look like a loss in the years after it.
+		totalReturn := proceedsOfSale.Add(appreciatedCashFlow).Add(previousRefinanceCashOut)
 
 		// Calculate ROI
 		roiRate := returnOnInvestmentRate(initialInvestment, totalReturn)
@@ -96,6 +157,7 @@ func (calc *FinancialAnalysisCalculator) GenerateAnnualProjections() []AnnualPro
 			ReturnOnInvestmentPercent: roiPercent,
 			AnnualizedROIRate:         irr,
 			AnnualizedROIPercent:      irrPercent,
+			RefinanceCashOut:          refinanceCashOut,
 		}
 
 		projections = append(projections, projection)
@@ -106,11 +168,38 @@ func (calc *FinancialAnalysisCalculator) GenerateAnnualProjections() []AnnualPro
 		cashFlowArray = append(cashFlowArray, previousYearsCashFlow)
 
 		previousYearsCashFlow = appreciatedCashFlow
+		previousRefinanceCashOut = previousRefinanceCashOut.Add(refinanceCashOut)
 	}
 
 	return projections
 }
 
+// refinancedMortgage returns the mortgage which replaces the current one at a refinance. Mortgage
+// insurance only covers purchases, so the new mortgage is uninsured.
+func refinancedMortgage(current *Mortgage, event RefinanceEvent, firstPaymentDate time.Time) *Mortgage {
+	refinanced := *current
+	refinanced.LoanPurchaseAmount = event.AppraisedValue
+	refinanced.LoanAmount = event.AppraisedValue.Mul(event.LoanToValue).Round(2)
+	refinanced.DownPayment = event.AppraisedValue.Sub(refinanced.LoanAmount)
+	if !event.AnnualInterestRate.IsZero() {
+		refinanced.AnnualInterestRate = event.AnnualInterestRate
+	}
+	if !event.AmortizationYears.IsZero() {
+		refinanced.AmortizationYears = event.AmortizationYears
+	}
+	refinanced.FirstPaymentDate = firstPaymentDate
+	refinanced.Insurance = ""
+	refinanced.InsuranceAmount = decimal.Zero
+
+	calc := NewMortgageCalculator(&refinanced)
+	refinanced.MortgagePayment = calc.CalculateMortgagePayment()
+	refinanced.InterestRatePerPayment = calc.InterestRatePerPaymentFrequency()
+	refinanced.TotalNumberOfPayments = calc.TotalNumberOfPayments()
+	refinanced.PercentFinanced = calc.PercentOfLoanFinanced()
+
+	return &refinanced
+}
+
 // appreciatedDecimalNumber calculates the appreciated value of a number over a number of years
 func appreciatedDecimalNumber(value decimal.Decimal, year int, inflationRate decimal.Decimal) decimal.Decimal {
 	one := decimal.NewFromInt(1)
@@ -53,6 +53,70 @@ func TestFinancialAnalysisCalculator_GenerateAnnualProjections(t *testing.T) {
 		"Year 10 debt remaining should be close to 141481.42")
 }
 
+func TestFinancialAnalysisCalculator_GenerateAnnualProjectionsWithRefinance(t *testing.T) {
+	analysis := CreateFinancialAnalysisForTests()
+	analysis.Mortgage.MortgagePayment = NewMortgageCalculator(analysis.Mortgage).CalculateMortgagePayment()
+	calculator := NewFinancialAnalysisCalculator(analysis)
+	withoutRefinance := calculator.GenerateAnnualProjections()
+
+	analysis.RefinanceEvents = []RefinanceEvent{{
+		Year:           6,
+		AppraisedValue: decimal.NewFromFloat(300000.00),
+		LoanToValue:    decimal.NewFromFloat(0.8),
+		Fees:           decimal.NewFromFloat(2000.00),
+	}}
+	withRefinance, err := calculator.GenerateAnnualProjectionsWithRefinances()
+	assert.NoError(t, err)
+	assert.Equal(t, calculator.GenerateAnnualProjections(), withRefinance, "Valid refinances should project the same either way")
+
+	assert.Equal(t, 30, len(withRefinance), "Should have 30 years of projections")
+	assert.Equal(t, withoutRefinance[:5], withRefinance[:5], "Years before the refinance should be unchanged")
+
+	// The new mortgage of 240000 pays off the year 5 debt and the fees, the rest is cash out
+	expectedCashOut := decimal.NewFromFloat(240000.00).Sub(withoutRefinance[4].DebtRemaining).Sub(decimal.NewFromFloat(2000.00))
+	year6 := withRefinance[5]
+	assert.True(t, expectedCashOut.Equal(year6.RefinanceCashOut), "Year 6 cash out should be %s, got %s", expectedCashOut, year6.RefinanceCashOut)
+	assert.True(t, year6.DebtRemaining.GreaterThan(withoutRefinance[5].DebtRemaining), "Year 6 debt should grow with the new mortgage")
+	assert.True(t, year6.DebtRemaining.LessThan(decimal.NewFromFloat(240000.00)), "Year 6 debt should be paid down from the new principal")
+	assert.True(t, year6.CashFlow.Sub(year6.RefinanceCashOut).LessThan(withoutRefinance[5].CashFlow), "Year 6 cash flow should include the cash out and pay the larger mortgage")
+
+	// The larger mortgage costs more each year after the refinance
+	year7 := withRefinance[6]
+	assert.True(t, year7.RefinanceCashOut.IsZero(), "Cash out should only be received in the refinance year")
+	assert.True(t, year7.CashFlow.LessThan(withoutRefinance[6].CashFlow), "Year 7 cash flow should pay the larger mortgage")
+
+	// The cash out was received in year 6, so it stays in the total return of every year after it
+	assert.True(t, year6.TotalReturn.Equal(year6.ProceedsOfSale.Add(year6.CashFlow)), "Year 6 total return should include the cash out once")
+	for _, projection := range withRefinance[6:] {
+		expectedTotalReturn := projection.ProceedsOfSale.Add(projection.CashFlow).Add(year6.RefinanceCashOut)
+		assert.True(t, expectedTotalReturn.Equal(projection.TotalReturn),
+			"Year %d total return should include the year 6 cash out, want %s got %s", projection.Year, expectedTotalReturn, projection.TotalReturn)
+	}
+}
+
+func TestFinancialAnalysisCalculator_GenerateAnnualProjectionsWithInvalidRefinanceYear(t *testing.T) {
+	analysis := CreateFinancialAnalysisForTests()
+	analysis.Mortgage.MortgagePayment = NewMortgageCalculator(analysis.Mortgage).CalculateMortgagePayment()
+	calculator := NewFinancialAnalysisCalculator(analysis)
+
+	for _, year := range []int{0, -1} {
+		analysis.RefinanceEvents = []RefinanceEvent{{
+			Year:           year,
+			AppraisedValue: decimal.NewFromFloat(300000.00),
+			LoanToValue:    decimal.NewFromFloat(0.8),
+		}}
+		projections, err := calculator.GenerateAnnualProjectionsWithRefinances()
+		assert.ErrorIs(t, err, ErrInvalidRefinanceYear, "Refinance in year %d should be rejected", year)
+		assert.Nil(t, projections)
+
+		// The unchecked projections ignore the refinance
+		analysis.RefinanceEvents = nil
+		withoutRefinance := calculator.GenerateAnnualProjections()
+		analysis.RefinanceEvents = []RefinanceEvent{{Year: year, AppraisedValue: decimal.NewFromFloat(300000.00), LoanToValue: decimal.NewFromFloat(0.8)}}
+		assert.Equal(t, withoutRefinance, calculator.GenerateAnnualProjections(), "Refinance in year %d should be ignored", year)
+	}
+}
+
 func TestAppreciatedDecimalNumber(t *testing.T) {
 	// Test a sample value with inflation over various years
 	value := decimal.NewFromFloat(100.00)
@@ -1,6 +1,8 @@
 package incomepropertyevaluatorkit
 
 import (
+	"strings"
+
 	"github.com/shopspring/decimal"
 )
 
@@ -24,3 +26,50 @@ func (t *TaxCalculator) CalculateLandTransferTax(purchasePrice decimal.Decimal)
 
 	return landTransferTax.Round(2)
 }
+
+// landTransferTaxBracket is one marginal bracket of a land transfer tax table
+type landTransferTaxBracket struct {
+	Threshold decimal.Decimal
+	Rate      decimal.Decimal
+}
+
+// TaxBracketLine is the part of the land transfer tax contributed by one bracket
+type TaxBracketLine struct {
+	Threshold      decimal.Decimal  `json:"threshold"`                 // Purchase price the bracket starts at
+	UpperThreshold *decimal.Decimal `json:"upper_threshold,omitempty"` // Purchase price the next bracket starts at, nil for the highest bracket
+	Rate           decimal.Decimal  `json:"rate"`                      // Rate (as a decimal, e.g., 0.01 for 1%)
+	TaxableAmount  decimal.Decimal  `json:"taxable_amount"`            // Part of the purchase price taxed at the rate
+	Amount         decimal.Decimal  `json:"amount"`                    // Tax contributed by the bracket
+}
+
+// LandTransferTaxBreakdown returns how much each bracket of the province contributes to the land
+// transfer tax, so the total can be audited. Brackets above the purchase price are omitted and
+// unknown provinces return nil.
+func (t *TaxCalculator) LandTransferTaxBreakdown(purchasePrice decimal.Decimal, province string) []TaxBracketLine {
+	brackets, ok := landTransferTaxBrackets[strings.ToUpper(strings.TrimSpace(province))]
+	if !ok {
+		return nil
+	}
+
+	var lines []TaxBracketLine
+	for i, bracket := range brackets {
+		if i > 0 && purchasePrice.LessThanOrEqual(bracket.Threshold) {
+			break
+		}
+
+		line := TaxBracketLine{
+			Threshold:     bracket.Threshold,
+			Rate:          bracket.Rate,
+			TaxableAmount: purchasePrice.Sub(bracket.Threshold),
+		}
+		if i+1 < len(brackets) {
+			upperThreshold := brackets[i+1].Threshold
+			line.UpperThreshold = &upperThreshold
+			line.TaxableAmount = decimal.Min(purchasePrice, upperThreshold).Sub(bracket.Threshold)
+		}
+		line.Amount = line.TaxableAmount.Mul(bracket.Rate).Round(2)
+		lines = append(lines, line)
+	}
+
+	return lines
+}
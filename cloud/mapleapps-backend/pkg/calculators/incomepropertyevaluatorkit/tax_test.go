@@ -77,3 +77,37 @@ func TestTaxCalculator_CalculateLandTransferTaxEdgeCases(t *testing.T) {
 
 	assert.True(t, expectedTax400k.Equal(actualTax400k), "Land transfer tax for $400,000 should be $4,475.00")
 }
+
+func TestTaxCalculator_LandTransferTaxBreakdown(t *testing.T) {
+	taxCalc := TaxCalculator{}
+
+	// Test case 1: The brackets add up to the total for every tier
+	for _, price := range []float64{0, 50000, 55000, 100000, 250000, 300000, 400000, 500000} {
+		purchasePrice := decimal.NewFromFloat(price)
+		lines := taxCalc.LandTransferTaxBreakdown(purchasePrice, ProvinceOntario)
+
+		total := decimal.Zero
+		for _, line := range lines {
+			total = total.Add(line.Amount)
+		}
+		expected := taxCalc.CalculateLandTransferTax(purchasePrice)
+		assert.True(t, expected.Equal(total), "Breakdown for $%.2f should add up to $%s, got $%s", price, expected, total)
+	}
+
+	// Test case 2: Each bracket of $300,000 is listed with its contribution
+	lines := taxCalc.LandTransferTaxBreakdown(decimal.NewFromFloat(300000.00), "on")
+	assert.Len(t, lines, 3)
+	assert.True(t, decimal.NewFromFloat(275.00).Equal(lines[0].Amount), "First $55,000 at 0.5%")
+	assert.True(t, decimal.NewFromFloat(1950.00).Equal(lines[1].Amount), "Next $195,000 at 1.0%")
+	assert.True(t, decimal.NewFromFloat(50000.00).Equal(lines[2].TaxableAmount), "Last $50,000 at 1.5%")
+	assert.True(t, decimal.NewFromFloat(750.00).Equal(lines[2].Amount), "Last $50,000 at 1.5%")
+	assert.True(t, LTTUpperThreshold.Equal(*lines[2].UpperThreshold))
+
+	// Test case 3: The highest bracket has no upper threshold
+	lines = taxCalc.LandTransferTaxBreakdown(decimal.NewFromFloat(500000.00), ProvinceOntario)
+	assert.Len(t, lines, 4)
+	assert.Nil(t, lines[3].UpperThreshold)
+
+	// Test case 4: Provinces without a tax table have no breakdown
+	assert.Nil(t, taxCalc.LandTransferTaxBreakdown(decimal.NewFromFloat(300000.00), "XX"))
+}
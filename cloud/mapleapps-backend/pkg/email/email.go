@@ -0,0 +1,12 @@
+package email
+
+import "strings"
+
+// Normalize lowercases the address and strips any whitespace from it. This is the form the email
+// addresses are stored in at registration, so every lookup by email must normalize with it first.
+func Normalize(address string) string {
+	address = strings.ToLower(address)
+	address = strings.ReplaceAll(address, " ", "")
+	address = strings.ReplaceAll(address, "\t", "")
+	return strings.TrimSpace(address)
+}
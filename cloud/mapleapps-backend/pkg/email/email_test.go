@@ -0,0 +1,25 @@
+package email
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		want    string
+	}{
+		{name: "already normalized", address: "alice@example.com", want: "alice@example.com"},
+		{name: "uppercase", address: "Alice@Example.COM", want: "alice@example.com"},
+		{name: "surrounding whitespace", address: " alice@example.com\n", want: "alice@example.com"},
+		{name: "inner spaces and tabs", address: "al ice@exa\tmple.com", want: "alice@example.com"},
+		{name: "empty", address: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.address); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.address, got, tt.want)
+			}
+		})
+	}
+}
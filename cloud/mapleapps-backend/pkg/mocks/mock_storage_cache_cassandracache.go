@@ -70,6 +70,21 @@ func (mr *MockCassandraCacherMockRecorder) Get(ctx, key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCassandraCacher)(nil).Get), ctx, key)
 }
 
+// IncrementWithExpiry mocks base method.
+func (m *MockCassandraCacher) IncrementWithExpiry(ctx context.Context, key string, expiry time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementWithExpiry", ctx, key, expiry)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementWithExpiry indicates an expected call of IncrementWithExpiry.
+func (mr *MockCassandraCacherMockRecorder) IncrementWithExpiry(ctx, key, expiry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementWithExpiry", reflect.TypeOf((*MockCassandraCacher)(nil).IncrementWithExpiry), ctx, key, expiry)
+}
+
 // PurgeExpired mocks base method.
 func (m *MockCassandraCacher) PurgeExpired(ctx context.Context) error {
 	m.ctrl.T.Helper()
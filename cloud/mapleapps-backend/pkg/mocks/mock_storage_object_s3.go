@@ -247,6 +247,21 @@ func (mr *MockS3ObjectStorageMockRecorder) ListAllObjects(ctx any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllObjects", reflect.TypeOf((*MockS3ObjectStorage)(nil).ListAllObjects), ctx)
 }
 
+// ListObjectsByPage mocks base method.
+func (m *MockS3ObjectStorage) ListObjectsByPage(ctx context.Context, fn func(*s3.ListObjectsOutput, bool) bool) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListObjectsByPage", ctx, fn)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListObjectsByPage indicates an expected call of ListObjectsByPage.
+func (mr *MockS3ObjectStorageMockRecorder) ListObjectsByPage(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjectsByPage", reflect.TypeOf((*MockS3ObjectStorage)(nil).ListObjectsByPage), ctx, fn)
+}
+
 // ObjectExists mocks base method.
 func (m *MockS3ObjectStorage) ObjectExists(ctx context.Context, key string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/gocql/gocql"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
@@ -359,8 +360,10 @@ func S3HealthCheck(s3Storage s3.S3ObjectStorage, logger *zap.Logger) HealthCheck
 			}
 		}
 
-		// Test basic S3 connectivity by listing objects (lightweight operation)
-		_, err := s3Storage.ListAllObjects(ctx)
+		// Test basic S3 connectivity by listing the first page of objects (lightweight operation)
+		_, err := s3Storage.ListObjectsByPage(ctx, func(page *awss3.ListObjectsOutput, lastPage bool) bool {
+			return false
+		})
 		duration := time.Since(start)
 
 		if err != nil {
@@ -0,0 +1,89 @@
+package retry
+
+// This package retries operations which fail transiently, waiting longer after every failed attempt
+// so a struggling server or network gets time to recover.
+//
+// The CLI carries its own retry package rather than importing this one, since the backend image is
+// built from this module alone. Changes here don't need to be mirrored there.
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Policy controls how often and how quickly an operation is retried
+type Policy struct {
+	// MaxAttempts is the number of attempts including the first one, values below 1 mean 1.
+	MaxAttempts int
+	// BaseDelay is the delay before the second attempt, doubled before every further attempt.
+	BaseDelay time.Duration
+	// MaxDelay bounds the delay between attempts, zero leaves it unbounded.
+	MaxDelay time.Duration
+	// Jitter is the fraction of every delay, between 0 and 1, which is randomized so clients failing
+	// together don't retry in lockstep.
+	Jitter float64
+	// Retryable reports whether an error may succeed on another attempt, nil retries every error.
+	Retryable func(err error) bool
+	// OnRetry is called before waiting for the next attempt, typically to log the failure.
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+// DefaultPolicy returns a policy of 3 attempts waiting around 500ms then 1s, retrying every error
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// Do calls fn until it succeeds, returns an error the policy doesn't retry, or runs out of attempts,
+// in which case the error of the last attempt is returned. If the context is done while waiting
+// for the next attempt, the returned error wraps both the context error and the last error.
+func Do(ctx context.Context, policy Policy, fn func() error) error {
+	maxAttempts := max(policy.MaxAttempts, 1)
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if attempt >= maxAttempts || (policy.Retryable != nil && !policy.Retryable(err)) {
+			return err
+		}
+
+		delay := policy.Delay(attempt)
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err, delay)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
+
+// Delay returns how long to wait after the given failed attempt, counting from 1
+func (p Policy) Delay(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < math.MaxInt64/2 && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	// Jitter only shortens the delay, so it never exceeds the maximum
+	if jitter := min(max(p.Jitter, 0), 1); jitter > 0 && delay > 0 {
+		delay -= time.Duration(rand.Float64() * jitter * float64(delay))
+	}
+	return delay
+}
@@ -3,10 +3,12 @@ package crypto
 import (
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"log"
+	"strings"
 
 	"github.com/tyler-smith/go-bip39"
 	"golang.org/x/crypto/argon2"
@@ -100,6 +102,23 @@ func GenerateVerificationID(publicKey []byte) (string, error) {
 	return mnemonic, nil
 }
 
+// GeneratePublicKeyFingerprint creates a short representation of a public key which users can compare
+// out of band, the SHA256 hash of the key in groups of four uppercase hex digits
+func GeneratePublicKeyFingerprint(publicKey []byte) (string, error) {
+	if len(publicKey) == 0 {
+		return "", errors.New("public key cannot be empty")
+	}
+
+	hash := sha256.Sum256(publicKey)
+	encoded := strings.ToUpper(hex.EncodeToString(hash[:]))
+
+	groups := make([]string, 0, len(encoded)/4)
+	for i := 0; i < len(encoded); i += 4 {
+		groups = append(groups, encoded[i:i+4])
+	}
+	return strings.Join(groups, " "), nil
+}
+
 // VerifyVerificationID checks if a verification ID matches a public key
 func VerifyVerificationID(publicKey []byte, verificationID string) bool {
 	expectedID, err := GenerateVerificationID(publicKey)
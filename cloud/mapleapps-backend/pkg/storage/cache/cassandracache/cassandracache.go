@@ -3,6 +3,8 @@ package cassandracache
 
 import (
 	"context"
+	"errors"
+	"strconv"
 	"time"
 
 	"github.com/gocql/gocql"
@@ -15,9 +17,15 @@ type CassandraCacher interface {
 	Set(ctx context.Context, key string, val []byte) error
 	SetWithExpiry(ctx context.Context, key string, val []byte, expiry time.Duration) error
 	Delete(ctx context.Context, key string) error
+	IncrementWithExpiry(ctx context.Context, key string, expiry time.Duration) (int64, error)
 	PurgeExpired(ctx context.Context) error
 }
 
+// maxIncrementAttempts is how many times an increment is retried when it races with another one.
+const maxIncrementAttempts = 10
+
+var ErrIncrementContention = errors.New("cache counter is under too much contention")
+
 type cache struct {
 	Session *gocql.Session
 	Logger  *zap.Logger
@@ -78,6 +86,53 @@ func (s *cache) Delete(ctx context.Context, key string) error {
 		key).WithContext(ctx).Consistency(gocql.LocalQuorum).Exec()
 }
 
+// IncrementWithExpiry atomically adds one to the counter stored at the key and returns the new count.
+// A missing or expired counter starts again at one and expires after the given duration, later
+// increments keep that expiry. The counter is updated with lightweight transactions so concurrent
+// increments are never lost.
+func (s *cache) IncrementWithExpiry(ctx context.Context, key string, expiry time.Duration) (int64, error) {
+	for attempt := 0; attempt < maxIncrementAttempts; attempt++ {
+		var value []byte
+		var expiresAt time.Time
+
+		err := s.Session.Query(`SELECT value, expires_at FROM pkg_cache_by_key_with_asc_expire_at WHERE key=?`,
+			key).WithContext(ctx).Consistency(gocql.LocalQuorum).SerialConsistency(gocql.LocalSerial).Scan(&value, &expiresAt)
+
+		var applied bool
+		var count int64
+		switch {
+		case err == gocql.ErrNotFound:
+			count = 1
+			applied, err = s.Session.Query(`INSERT INTO pkg_cache_by_key_with_asc_expire_at (key, expires_at, value) VALUES (?, ?, ?) IF NOT EXISTS`,
+				key, time.Now().Add(expiry), []byte("1")).WithContext(ctx).SerialConsistency(gocql.LocalSerial).MapScanCAS(map[string]interface{}{})
+		case err != nil:
+			return 0, err
+		case time.Now().After(expiresAt):
+			// The counter of a previous window is still stored, start over from it
+			count = 1
+			applied, err = s.Session.Query(`UPDATE pkg_cache_by_key_with_asc_expire_at SET value=?, expires_at=? WHERE key=? IF value=?`,
+				[]byte("1"), time.Now().Add(expiry), key, value).WithContext(ctx).SerialConsistency(gocql.LocalSerial).MapScanCAS(map[string]interface{}{})
+		default:
+			current, _ := strconv.ParseInt(string(value), 10, 64)
+			count = current + 1
+			applied, err = s.Session.Query(`UPDATE pkg_cache_by_key_with_asc_expire_at SET value=? WHERE key=? IF value=?`,
+				[]byte(strconv.FormatInt(count, 10)), key, value).WithContext(ctx).SerialConsistency(gocql.LocalSerial).MapScanCAS(map[string]interface{}{})
+		}
+		if err != nil {
+			return 0, err
+		}
+		if applied {
+			return count, nil
+		}
+
+		// Another increment won the race, read the counter again
+		s.Logger.Debug("cache counter changed concurrently, retrying",
+			zap.String("key", key),
+			zap.Int("attempt", attempt+1))
+	}
+	return 0, ErrIncrementContention
+}
+
 func (s *cache) PurgeExpired(ctx context.Context) error {
 	now := time.Now()
 
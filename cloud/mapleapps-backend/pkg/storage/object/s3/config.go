@@ -1,5 +1,7 @@
 package s3
 
+import "time"
+
 type S3ObjectStorageConfigurationProvider interface {
 	GetAccessKey() string
 	GetSecretKey() string
@@ -7,6 +9,8 @@ type S3ObjectStorageConfigurationProvider interface {
 	GetRegion() string
 	GetBucketName() string
 	GetIsPublicBucket() bool
+	GetListTimeout() time.Duration
+	GetUsePathStyle() bool
 }
 
 type s3ObjectStorageConfigurationProviderImpl struct {
@@ -16,9 +20,11 @@ type s3ObjectStorageConfigurationProviderImpl struct {
 	region         string `env:"AWS_REGION,required"`
 	bucketName     string `env:"AWS_BUCKET_NAME,required"`
 	isPublicBucket bool   `env:"AWS_IS_PUBLIC_BUCKET"`
+	listTimeout    time.Duration
+	usePathStyle   bool
 }
 
-func NewS3ObjectStorageConfigurationProvider(accessKey, secretKey, endpoint, region, bucketName string, isPublicBucket bool) S3ObjectStorageConfigurationProvider {
+func NewS3ObjectStorageConfigurationProvider(accessKey, secretKey, endpoint, region, bucketName string, isPublicBucket bool, listTimeout time.Duration, usePathStyle bool) S3ObjectStorageConfigurationProvider {
 	return &s3ObjectStorageConfigurationProviderImpl{
 		accessKey:      accessKey,
 		secretKey:      secretKey,
@@ -26,6 +32,8 @@ func NewS3ObjectStorageConfigurationProvider(accessKey, secretKey, endpoint, reg
 		region:         region,
 		bucketName:     bucketName,
 		isPublicBucket: isPublicBucket,
+		listTimeout:    listTimeout,
+		usePathStyle:   usePathStyle,
 	}
 }
 
@@ -52,3 +60,11 @@ func (me *s3ObjectStorageConfigurationProviderImpl) GetBucketName() string {
 func (me *s3ObjectStorageConfigurationProviderImpl) GetIsPublicBucket() bool {
 	return me.isPublicBucket
 }
+
+func (me *s3ObjectStorageConfigurationProviderImpl) GetListTimeout() time.Duration {
+	return me.listTimeout
+}
+
+func (me *s3ObjectStorageConfigurationProviderImpl) GetUsePathStyle() bool {
+	return me.usePathStyle
+}
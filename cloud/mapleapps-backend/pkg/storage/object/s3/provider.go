@@ -14,6 +14,8 @@ func NewS3ObjectStorageProvider(cfg *config.Configuration, logger *zap.Logger) S
 		cfg.AWS.Region,
 		cfg.AWS.BucketName,
 		false,
+		cfg.AWS.ListTimeout,
+		cfg.AWS.UsePathStyle,
 	)
 
 	return NewObjectStorage(configProvider, logger)
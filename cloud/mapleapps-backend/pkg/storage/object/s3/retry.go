@@ -0,0 +1,33 @@
+package s3
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsretry "github.com/aws/aws-sdk-go-v2/aws/retry"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/cloud/mapleapps-backend/pkg/retry"
+)
+
+// isTransientError reports whether an S3 request failed in a way another attempt may not, such as
+// throttling, a timeout, a 5xx response or a dropped connection
+func isTransientError(err error) bool {
+	return awsretry.IsErrorRetryables(awsretry.DefaultRetryables).IsErrorRetryable(err) == aws.TrueTernary
+}
+
+// withRetry calls fn until it succeeds or fails with an error which is not transient. The SDK
+// already retries single requests, this covers outages lasting longer than its own backoff.
+func (s *s3ObjectStorage) withRetry(ctx context.Context, operation string, fn func() error) error {
+	policy := retry.DefaultPolicy()
+	policy.Retryable = isTransientError
+	policy.OnRetry = func(attempt int, err error, delay time.Duration) {
+		s.Logger.Warn("⚠️ S3 request failed, retrying",
+			zap.String("operation", operation),
+			zap.Int("attempt", attempt),
+			zap.Duration("delay", delay),
+			zap.Error(err))
+	}
+	return retry.Do(ctx, policy, fn)
+}
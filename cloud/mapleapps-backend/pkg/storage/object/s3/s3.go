@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log"
 	"mime/multipart"
@@ -26,6 +27,13 @@ const (
 	ACLPublicRead = "public-read"
 )
 
+// DefaultListTimeout is used when no list timeout is configured
+const DefaultListTimeout = 2 * time.Minute
+
+// ErrListTimedOut is wrapped by the error returned when listing objects is interrupted by the list
+// timeout or by the cancellation of the context, the objects listed until then are still returned.
+var ErrListTimedOut = errors.New("listing timed out")
+
 type S3ObjectStorage interface {
 	UploadContent(ctx context.Context, objectKey string, content []byte) error
 	UploadContentWithVisibility(ctx context.Context, objectKey string, content []byte, isPublic bool) error
@@ -40,6 +48,9 @@ type S3ObjectStorage interface {
 	GetBinaryData(ctx context.Context, objectKey string) (io.ReadCloser, error)
 	DownloadToLocalfile(ctx context.Context, objectKey string, filePath string) (string, error)
 	ListAllObjects(ctx context.Context) (*s3.ListObjectsOutput, error)
+	// ListObjectsByPage calls fn with every page of objects until fn returns false or the last page
+	// was listed, and returns the number of pages fetched.
+	ListObjectsByPage(ctx context.Context, fn func(page *s3.ListObjectsOutput, lastPage bool) bool) (int, error)
 	FindMatchingObjectKey(s3Objects *s3.ListObjectsOutput, partialKey string) string
 	IsPublicBucket() bool
 	// GeneratePresignedUploadURL creates a presigned URL for uploading objects
@@ -55,6 +66,7 @@ type s3ObjectStorage struct {
 	Logger        *zap.Logger
 	BucketName    string
 	IsPublic      bool
+	ListTimeout   time.Duration
 }
 
 // NewObjectStorage connects to a specific S3 bucket instance and returns a connected
@@ -84,8 +96,11 @@ func NewObjectStorage(s3Config S3ObjectStorageConfigurationProvider, logger *zap
 		log.Fatalf("S3ObjectStorage failed loading default config with error: %v", err) // We need to crash the program at start to satisfy google wire requirement of having no errors.
 	}
 
-	// STEP 3\: Load up s3 instance.
-	s3Client := s3.NewFromConfig(sdkConfig)
+	// STEP 3\: Load up s3 instance, path-style addressing is needed by servers without
+	// wildcard DNS for `bucket.endpoint` hosts, e.g. MinIO.
+	s3Client := s3.NewFromConfig(sdkConfig, func(o *s3.Options) {
+		o.UsePathStyle = s3Config.GetUsePathStyle()
+	})
 
 	// Create our storage handler.
 	s3Storage := &s3ObjectStorage{
@@ -94,9 +109,10 @@ func NewObjectStorage(s3Config S3ObjectStorageConfigurationProvider, logger *zap
 		Logger:        logger,
 		BucketName:    s3Config.GetBucketName(),
 		IsPublic:      s3Config.GetIsPublicBucket(),
+		ListTimeout:   s3Config.GetListTimeout(),
 	}
 
-	logger.Debug("s3 checking remote connection...")
+	logger.Debug("s3 checking remote connection...", zap.Bool("use_path_style", s3Config.GetUsePathStyle()))
 
 	// STEP 4: Connect to the s3 bucket instance and confirm that bucket exists.
 	doesExist, err := s3Storage.BucketExists(context.TODO(), s3Config.GetBucketName())
@@ -135,11 +151,14 @@ func (s *s3ObjectStorage) UploadContentWithVisibility(ctx context.Context, objec
 		zap.Bool("isPublic", isPublic),
 		zap.String("acl", acl))
 
-	_, err := s.S3Client.PutObject(ctx, &s3.PutObjectInput{
-		Bucket: aws.String(s.BucketName),
-		Key:    aws.String(objectKey),
-		Body:   bytes.NewReader(content),
-		ACL:    types.ObjectCannedACL(acl),
+	err := s.withRetry(ctx, "PutObject", func() error {
+		_, err := s.S3Client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(s.BucketName),
+			Key:    aws.String(objectKey),
+			Body:   bytes.NewReader(content),
+			ACL:    types.ObjectCannedACL(acl),
+		})
+		return err
 	})
 	if err != nil {
 		s.Logger.Error("Failed to upload content",
@@ -238,9 +257,12 @@ func (s *s3ObjectStorage) DeleteByKeys(ctx context.Context, objectKeys []string)
 	for _, key := range objectKeys {
 		objectIds = append(objectIds, types.ObjectIdentifier{Key: aws.String(key)})
 	}
-	_, err := s.S3Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
-		Bucket: aws.String(s.BucketName),
-		Delete: &types.Delete{Objects: objectIds},
+	err := s.withRetry(ctx, "DeleteObjects", func() error {
+		_, err := s.S3Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(s.BucketName),
+			Delete: &types.Delete{Objects: objectIds},
+		})
+		return err
 	})
 	if err != nil {
 		log.Printf("Couldn't delete objects from bucket %v. Here's why: %v\n", s.BucketName, err)
@@ -264,9 +286,12 @@ func (s *s3ObjectStorage) CutWithVisibility(ctx context.Context, sourceObjectKey
 	}
 
 	// Delete the original object
-	_, deleteErr := s.S3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
-		Bucket: aws.String(s.BucketName),
-		Key:    aws.String(sourceObjectKey),
+	deleteErr := s.withRetry(ctx, "DeleteObject", func() error {
+		_, err := s.S3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(s.BucketName),
+			Key:    aws.String(sourceObjectKey),
+		})
+		return err
 	})
 	if deleteErr != nil {
 		s.Logger.Error("Failed to delete original object:", zap.Any("deleteErr", deleteErr))
@@ -299,11 +324,14 @@ func (s *s3ObjectStorage) CopyWithVisibility(ctx context.Context, sourceObjectKe
 		zap.Bool("isPublic", isPublic),
 		zap.String("acl", acl))
 
-	_, copyErr := s.S3Client.CopyObject(ctx, &s3.CopyObjectInput{
-		Bucket:     aws.String(s.BucketName),
-		CopySource: aws.String(s.BucketName + "/" + sourceObjectKey),
-		Key:        aws.String(destinationObjectKey),
-		ACL:        types.ObjectCannedACL(acl),
+	copyErr := s.withRetry(ctx, "CopyObject", func() error {
+		_, err := s.S3Client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:     aws.String(s.BucketName),
+			CopySource: aws.String(s.BucketName + "/" + sourceObjectKey),
+			Key:        aws.String(destinationObjectKey),
+			ACL:        types.ObjectCannedACL(acl),
+		})
+		return err
 	})
 	if copyErr != nil {
 		s.Logger.Error("Failed to copy object:",
@@ -326,7 +354,12 @@ func (s *s3ObjectStorage) GetBinaryData(ctx context.Context, objectKey string) (
 		Key:    aws.String(objectKey),
 	}
 
-	s3object, err := s.S3Client.GetObject(ctx, input)
+	var s3object *s3.GetObjectOutput
+	err := s.withRetry(ctx, "GetObject", func() error {
+		var err error
+		s3object, err = s.S3Client.GetObject(ctx, input)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -351,17 +384,74 @@ func (s *s3ObjectStorage) DownloadToLocalfile(ctx context.Context, objectKey str
 	return filePath, err
 }
 
+// ListAllObjects lists every object of the bucket. If the listing times out, the objects of the pages
+// fetched so far are returned together with an error wrapping ErrListTimedOut.
 func (s *s3ObjectStorage) ListAllObjects(ctx context.Context) (*s3.ListObjectsOutput, error) {
-	input := &s3.ListObjectsInput{
-		Bucket: aws.String(s.BucketName),
+	objects := &s3.ListObjectsOutput{
+		Name: aws.String(s.BucketName),
 	}
 
-	objects, err := s.S3Client.ListObjects(ctx, input)
-	if err != nil {
-		return nil, err
+	_, err := s.ListObjectsByPage(ctx, func(page *s3.ListObjectsOutput, lastPage bool) bool {
+		objects.Contents = append(objects.Contents, page.Contents...)
+		return true
+	})
+	return objects, err
+}
+
+// ListObjectsByPage walks the pages of the bucket within the configured list timeout. Once the
+// timeout expires or the context is cancelled no further page is fetched.
+func (s *s3ObjectStorage) ListObjectsByPage(ctx context.Context, fn func(page *s3.ListObjectsOutput, lastPage bool) bool) (int, error) {
+	timeout := s.ListTimeout
+	if timeout <= 0 {
+		timeout = DefaultListTimeout
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pages := 0
+	var marker *string
+	for {
+		if err := ctx.Err(); err != nil {
+			return pages, s.listInterrupted(pages, timeout, err)
+		}
+
+		var page *s3.ListObjectsOutput
+		err := s.withRetry(ctx, "ListObjects", func() error {
+			var err error
+			page, err = s.S3Client.ListObjects(ctx, &s3.ListObjectsInput{
+				Bucket: aws.String(s.BucketName),
+				Marker: marker,
+			})
+			return err
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return pages, s.listInterrupted(pages, timeout, ctx.Err())
+			}
+			return pages, err
+		}
+		pages++
 
-	return objects, nil
+		lastPage := !aws.ToBool(page.IsTruncated) || len(page.Contents) == 0
+		if !fn(page, lastPage) || lastPage {
+			return pages, nil
+		}
+
+		// Without a delimiter S3 does not return the next marker, the last key is used instead.
+		marker = page.NextMarker
+		if marker == nil {
+			marker = page.Contents[len(page.Contents)-1].Key
+		}
+	}
+}
+
+// listInterrupted builds the error returned when listing stopped before the last page
+func (s *s3ObjectStorage) listInterrupted(pages int, timeout time.Duration, cause error) error {
+	s.Logger.Warn("Listing objects interrupted, returning partial results",
+		zap.Int("pages", pages),
+		zap.Duration("timeout", timeout),
+		zap.Error(cause))
+	return fmt.Errorf("%w after %d pages: %w", ErrListTimedOut, pages, cause)
 }
 
 // Function will iterate over all the s3 objects to match the partial key with
@@ -413,9 +503,12 @@ func (s *s3ObjectStorage) ObjectExists(ctx context.Context, key string) (bool, e
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	_, err := s.S3Client.HeadObject(ctx, &s3.HeadObjectInput{
-		Bucket: aws.String(s.BucketName),
-		Key:    aws.String(key),
+	err := s.withRetry(ctx, "HeadObject", func() error {
+		_, err := s.S3Client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: aws.String(s.BucketName),
+			Key:    aws.String(key),
+		})
+		return err
 	})
 
 	if err != nil {
@@ -457,9 +550,14 @@ func (s *s3ObjectStorage) GetObjectSize(ctx context.Context, key string) (int64,
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	result, err := s.S3Client.HeadObject(ctx, &s3.HeadObjectInput{
-		Bucket: aws.String(s.BucketName),
-		Key:    aws.String(key),
+	var result *s3.HeadObjectOutput
+	err := s.withRetry(ctx, "HeadObject", func() error {
+		var err error
+		result, err = s.S3Client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: aws.String(s.BucketName),
+			Key:    aws.String(key),
+		})
+		return err
 	})
 
 	if err != nil {
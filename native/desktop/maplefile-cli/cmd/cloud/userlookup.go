@@ -2,7 +2,6 @@
 package cloud
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -37,7 +36,7 @@ func PublicUserLookupCmd(
 			}
 
 			// Execute use case
-			ctx := context.Background()
+			ctx := cmd.Context()
 			response, err := getPublicLookupFromCloudUseCase.Execute(ctx, req)
 			if err != nil {
 				fmt.Printf("❌ Error performing lookup: %v\n", err)
@@ -48,9 +47,12 @@ func PublicUserLookupCmd(
 			fmt.Println("✅ User found!")
 			fmt.Printf("User ID: %s\n", response.UserID)
 			fmt.Printf("Email: %s\n", response.Email)
-			fmt.Printf("Name: %s\n", response.Name)
+			if response.Name != "" {
+				fmt.Printf("Name: %s\n", response.Name)
+			}
 			fmt.Printf("PublicKey (Base64 encoded): %s\n", response.PublicKeyInBase64)
 			fmt.Printf("VerificationID: %s\n", response.VerificationID)
+			fmt.Printf("Fingerprint: %s\n", response.Fingerprint)
 		},
 	}
 
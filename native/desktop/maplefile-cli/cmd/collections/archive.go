@@ -0,0 +1,130 @@
+// cmd/collections/archive.go - Archive and unarchive commands
+package collections
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	svc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collection"
+)
+
+// archiveCmd creates a command for hiding collections without deleting them
+func archiveCmd(
+	archiveService svc_collection.ArchiveService,
+	logger *zap.Logger,
+) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "archive COLLECTION_ID",
+		Short: "Archive a collection (hide it without deleting)",
+		Long: `
+Archive a collection to declutter your listings without deleting anything.
+
+Archiving is not deleting: the collection keeps its files and its members, and
+shared users keep their access. Archived collections are hidden from
+'collections list' unless --include-archived is passed, and the change is
+synced to your other devices. Bring a collection back with 'unarchive'.
+
+Examples:
+  # Archive a collection
+  maplefile-cli collections archive 507f1f77bcf86cd799439011
+
+  # Show archived collections
+  maplefile-cli collections list --state archived
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			collectionID, err := gocql.ParseUUID(args[0])
+			if err != nil {
+				fmt.Printf("🐞 Error: Invalid collection ID format: %v\n", err)
+				return
+			}
+
+			fmt.Printf("📦 Archiving collection: %s\n", collectionID.String())
+
+			archived, err := archiveService.Archive(cmd.Context(), collectionID)
+			if err != nil {
+				fmt.Printf("🐞 Error archiving collection: %v\n", err)
+				printArchiveErrorTip(err, collectionID)
+				return
+			}
+
+			fmt.Printf("✅ Successfully archived collection!\n")
+			fmt.Printf("🆔 Collection ID: %s\n", archived.ID.String())
+			fmt.Printf("📊 State: %s (version %d)\n", archived.State, archived.Version)
+			fmt.Printf("💡 To unarchive: maplefile-cli collections unarchive %s\n", archived.ID.String())
+
+			logger.Info("Collection archived successfully",
+				zap.String("collectionID", archived.ID.String()),
+				zap.Uint64("version", archived.Version))
+		},
+	}
+
+	return cmd
+}
+
+// unarchiveCmd creates a command for making archived collections active again
+func unarchiveCmd(
+	archiveService svc_collection.ArchiveService,
+	logger *zap.Logger,
+) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "unarchive COLLECTION_ID",
+		Short: "Unarchive a collection",
+		Long: `
+Unarchive a collection so it shows up in listings again.
+
+Deleted collections are brought back with 'collections restore' instead.
+
+Examples:
+  # Unarchive a collection
+  maplefile-cli collections unarchive 507f1f77bcf86cd799439011
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			collectionID, err := gocql.ParseUUID(args[0])
+			if err != nil {
+				fmt.Printf("🐞 Error: Invalid collection ID format: %v\n", err)
+				return
+			}
+
+			fmt.Printf("📂 Unarchiving collection: %s\n", collectionID.String())
+
+			unarchived, err := archiveService.Unarchive(cmd.Context(), collectionID)
+			if err != nil {
+				fmt.Printf("🐞 Error unarchiving collection: %v\n", err)
+				printArchiveErrorTip(err, collectionID)
+				return
+			}
+
+			fmt.Printf("✅ Successfully unarchived collection!\n")
+			fmt.Printf("🆔 Collection ID: %s\n", unarchived.ID.String())
+			fmt.Printf("📊 State: %s (version %d)\n", unarchived.State, unarchived.Version)
+
+			logger.Info("Collection unarchived successfully",
+				zap.String("collectionID", unarchived.ID.String()),
+				zap.Uint64("version", unarchived.Version))
+		},
+	}
+
+	return cmd
+}
+
+// printArchiveErrorTip prints a hint for the common archive and unarchive errors
+func printArchiveErrorTip(err error, collectionID gocql.UUID) {
+	switch {
+	case strings.Contains(err.Error(), "which is deleted"):
+		fmt.Printf("💡 The collection is deleted, restore it with: maplefile-cli collections restore %s\n", collectionID.String())
+	case strings.Contains(err.Error(), "which is archived"):
+		fmt.Printf("💡 The collection is already archived.\n")
+	case strings.Contains(err.Error(), "which is active"):
+		fmt.Printf("💡 The collection is not archived.\n")
+	case strings.Contains(err.Error(), "not found"):
+		fmt.Printf("💡 Collection not found. Check the ID and try again.\n")
+	case strings.Contains(err.Error(), "permission"):
+		fmt.Printf("💡 You don't have permission to archive this collection.\n")
+	}
+}
@@ -15,6 +15,9 @@ func CollectionsCmd(
 	createService collection.CreateService,
 	listService collection.ListService,
 	softDeleteService collection.SoftDeleteService,
+	archiveService collection.ArchiveService,
+	historyService collection.HistoryService,
+	syncPreferenceService collection.SyncPreferenceService,
 	listFromCloudService collectionsyncer.ListFromCloudService,
 	sharingService collectionsharing.CollectionSharingService,
 	getMembersService collectionsharing.CollectionSharingGetMembersService,
@@ -22,6 +25,7 @@ func CollectionsCmd(
 	removeMemberService collectionsharing.CollectionSharingRemoveMembersService,
 	synchronizedSharingService collectionsharing.SynchronizedCollectionSharingService,
 	originalSharingService collectionsharing.CollectionSharingService,
+	rewrapMemberKeyService collectionsharing.RewrapMemberKeyService,
 	logger *zap.Logger,
 ) *cobra.Command {
 	var cmd = &cobra.Command{
@@ -36,9 +40,15 @@ root-level collections or sub-collections within existing collections.
 Available commands:
   create    Create new collections (root or sub-collections)
   list      List collections with various filters
-  delete    Delete or archive collections (can be restored)
-  restore   Restore deleted/archived collections
+  delete    Delete collections (can be restored until the tombstone expires)
+  restore   Restore deleted collections
+  archive   Hide collections without deleting them
+  unarchive Show archived collections again
+  history   Show who changed a collection and when
+  sync-enable   Sync the file content of a collection to this device
+  sync-disable  Stop syncing the file content of a collection
   share     Share collections with other users
+  rewrap-key    Repair the collection key of a member
 
 Examples:
   # Create a new collection
@@ -68,12 +78,18 @@ For detailed help: maplefile-cli collections COMMAND --help
 	cmd.AddCommand(listCmd(listService, logger))
 	cmd.AddCommand(deleteCmd(softDeleteService, logger))
 	cmd.AddCommand(restoreCmd(softDeleteService, logger))
+	cmd.AddCommand(archiveCmd(archiveService, logger))
+	cmd.AddCommand(unarchiveCmd(archiveService, logger))
+	cmd.AddCommand(historyCmd(historyService, logger))
+	cmd.AddCommand(syncEnableCmd(syncPreferenceService, logger))
+	cmd.AddCommand(syncDisableCmd(syncPreferenceService, logger))
 
 	// Sharing commands (keep as-is - well designed)
 	cmd.AddCommand(share.ShareCmdWithSync(synchronizedSharingService, originalSharingService, logger))
 	cmd.AddCommand(share.UnshareCmd(removeMemberService, logger))
 	cmd.AddCommand(share.MembersCmd(getMembersService, logger))
 	cmd.AddCommand(share.ListSharedCmd(listSharedService, logger))
+	cmd.AddCommand(share.RewrapKeyCmd(rewrapMemberKeyService, logger))
 
 	return cmd
 }
@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collection"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
 )
 
 // createCmd creates a unified command for creating both root and sub-collections
@@ -152,7 +153,7 @@ Examples:
 	cmd.Flags().StringVarP(&collectionType, "type", "t", "folder", "Type of collection ('folder' or 'album')")
 	cmd.Flags().StringVarP(&parentID, "parent", "p", "", "Parent collection ID (creates sub-collection)")
 	cmd.Flags().StringVar(&password, "password", "", "Your account password (required for E2EE)")
-	cmd.MarkFlagRequired("password")
+	promptpassword.AddStdinFlag(cmd, &password, true)
 
 	return cmd
 }
@@ -13,31 +13,28 @@ import (
 	svc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collection"
 )
 
-// deleteCmd creates a unified command for deleting/archiving collections
+// deleteCmd creates a unified command for deleting collections
 func deleteCmd(
 	softDeleteService svc_collection.SoftDeleteService,
 	logger *zap.Logger,
 ) *cobra.Command {
-	var archive bool
 	var withChildren bool
 	var force bool
 
 	var cmd = &cobra.Command{
 		Use:   "delete COLLECTION_ID",
-		Short: "Delete or archive a collection",
+		Short: "Delete a collection",
 		Long: `
-Delete or archive a collection.
+Delete a collection.
 
-By default, performs a soft delete (can be restored). Use --archive to archive
-instead of deleting.
+Performs a soft delete: the collection becomes a tombstone which can be
+restored until it expires. To only hide a collection while keeping its data
+and members, use 'maplefile-cli collections archive' instead.
 
 Examples:
   # Soft delete a collection (can be restored)
   maplefile-cli collections delete 507f1f77bcf86cd799439011
 
-  # Archive a collection (hidden but not deleted)
-  maplefile-cli collections delete 507f1f77bcf86cd799439011 --archive
-
   # Delete a collection and all its children
   maplefile-cli collections delete 507f1f77bcf86cd799439011 --with-children
 
@@ -51,17 +48,8 @@ Examples:
 		Run: func(cmd *cobra.Command, args []string) {
 			collectionID := args[0]
 
-			// Determine operation type
-			var operation string
-			var operationIcon string
-
-			if archive {
-				operation = "archive"
-				operationIcon = "📦"
-			} else {
-				operation = "soft delete"
-				operationIcon = "🗑️"
-			}
+			operation := "soft delete"
+			operationIcon := "🗑️"
 
 			// Confirmation prompt (unless --force)
 			if !force {
@@ -88,23 +76,18 @@ Examples:
 				log.Fatalf("invalid collection ID format (expected UUID): %v\n", err)
 			}
 
-			if archive {
-				fmt.Printf("📦 Archiving collection: %s\n", collectionObjectID.String())
-				err = softDeleteService.Archive(cmd.Context(), collectionObjectID)
+			if withChildren {
+				fmt.Printf("🗑️ Soft deleting collection and children: %s\n", collectionObjectID.String())
+				err = softDeleteService.SoftDeleteWithChildren(cmd.Context(), collectionObjectID)
 			} else {
-				if withChildren {
-					fmt.Printf("🗑️ Soft deleting collection and children: %s\n", collectionObjectID.String())
-					err = softDeleteService.SoftDeleteWithChildren(cmd.Context(), collectionObjectID)
-				} else {
-					fmt.Printf("🗑️ Soft deleting collection: %s\n", collectionObjectID.String())
-					err = softDeleteService.SoftDelete(cmd.Context(), collectionObjectID)
-				}
+				fmt.Printf("🗑️ Soft deleting collection: %s\n", collectionObjectID.String())
+				err = softDeleteService.SoftDelete(cmd.Context(), collectionObjectID)
 			}
 
 			if err != nil {
 				fmt.Printf("🐞 Error performing %s: %v\n", operation, err)
 				if strings.Contains(err.Error(), "invalid state transition") {
-					fmt.Printf("💡 The collection may already be deleted/archived.\n")
+					fmt.Printf("💡 The collection may already be deleted.\n")
 				} else if strings.Contains(err.Error(), "not found") {
 					fmt.Printf("💡 Collection not found. Check the ID and try again.\n")
 				} else if strings.Contains(err.Error(), "permission") {
@@ -114,34 +97,29 @@ Examples:
 			}
 
 			// Success message
-			if archive {
-				fmt.Printf("✅ Successfully archived collection!\n")
+			if withChildren {
+				fmt.Printf("✅ Successfully soft deleted collection and its children!\n")
 			} else {
-				if withChildren {
-					fmt.Printf("✅ Successfully soft deleted collection and its children!\n")
-				} else {
-					fmt.Printf("✅ Successfully soft deleted collection!\n")
-				}
+				fmt.Printf("✅ Successfully soft deleted collection!\n")
 			}
 
 			fmt.Printf("🆔 Collection ID: %s\n", collectionID)
 			fmt.Printf("💡 To restore: maplefile-cli collections restore %s\n", collectionID)
 
-			logger.Info("Collection deleted/archived successfully",
+			logger.Info("Collection deleted successfully",
 				zap.String("collectionID", collectionID),
 				zap.String("operation", operation),
 				zap.Bool("withChildren", withChildren))
 		},
 	}
 
-	cmd.Flags().BoolVar(&archive, "archive", false, "Archive the collection instead of deleting")
-	cmd.Flags().BoolVar(&withChildren, "with-children", false, "Also delete/archive all child collections")
+	cmd.Flags().BoolVar(&withChildren, "with-children", false, "Also delete all child collections")
 	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt")
 
 	return cmd
 }
 
-// restoreCmd creates a command for restoring deleted/archived collections
+// restoreCmd creates a command for restoring deleted collections
 func restoreCmd(
 	softDeleteService svc_collection.SoftDeleteService,
 	logger *zap.Logger,
@@ -150,11 +128,13 @@ func restoreCmd(
 
 	var cmd = &cobra.Command{
 		Use:   "restore COLLECTION_ID",
-		Short: "Restore a deleted or archived collection",
+		Short: "Restore a deleted collection",
 		Long: `
-Restore a collection that was previously soft deleted or archived.
+Restore a collection that was previously soft deleted.
 This marks the collection as active again.
 
+Archived collections are brought back with 'maplefile-cli collections unarchive'.
+
 Examples:
   # Restore a collection
   maplefile-cli collections restore 507f1f77bcf86cd799439011
@@ -194,6 +174,8 @@ Examples:
 				fmt.Printf("🐞 Error restoring collection: %v\n", err)
 				if strings.Contains(err.Error(), "invalid state transition") {
 					fmt.Printf("💡 The collection may already be active.\n")
+				} else if strings.Contains(err.Error(), "archived") {
+					fmt.Printf("💡 Use: maplefile-cli collections unarchive %s\n", collectionID)
 				} else if strings.Contains(err.Error(), "not found") {
 					fmt.Printf("💡 Collection not found.\n")
 				}
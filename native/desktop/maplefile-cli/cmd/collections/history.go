@@ -0,0 +1,92 @@
+// cmd/collections/history.go - Collection change history command
+package collections
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	svc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collection"
+)
+
+// historyCmd creates a command for showing what changed in a collection and who changed it
+func historyCmd(
+	historyService svc_collection.HistoryService,
+	logger *zap.Logger,
+) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "history COLLECTION_ID",
+		Short: "Show the change history of a collection",
+		Long: `
+Show what changed in a collection, when, and by whom, most recent change first.
+
+The history is kept by the cloud and records when the collection was created,
+renamed, moved or deleted, when members were added or removed, when its
+ownership was transferred and when its key was rotated. Users are shown by
+email when they are members of your local copy of the collection, and by user
+ID otherwise.
+
+Examples:
+  # Show the history of a shared collection
+  maplefile-cli collections history 507f1f77bcf86cd799439011
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			collectionID, err := gocql.ParseUUID(args[0])
+			if err != nil {
+				fmt.Printf("🐞 Error: Invalid collection ID format: %v\n", err)
+				return
+			}
+
+			output, err := historyService.GetHistory(cmd.Context(), collectionID)
+			if err != nil {
+				fmt.Printf("🐞 Error getting collection history: %v\n", err)
+				return
+			}
+
+			if len(output.Entries) == 0 {
+				fmt.Printf("📭 No recorded changes for collection %s.\n", collectionID.String())
+				fmt.Printf("💡 Changes made before history was recorded are not shown.\n")
+				return
+			}
+
+			fmt.Printf("📜 History of collection %s (%d changes):\n\n", collectionID.String(), len(output.Entries))
+
+			fmt.Printf("%-8s %-20s %-22s %-36s %s\n", "VERSION", "WHEN", "CHANGE", "BY", "DETAILS")
+			for _, entry := range output.Entries {
+				fmt.Printf("%-8d %-20s %-22s %-36s %s\n",
+					entry.Version,
+					entry.ChangedAt.Local().Format(time.DateTime),
+					entry.ChangeType,
+					entry.ChangedBy,
+					historyEntryDetails(entry))
+			}
+
+			logger.Debug("Collection history shown",
+				zap.String("collectionID", collectionID.String()),
+				zap.Int("changeCount", len(output.Entries)))
+		},
+	}
+
+	return cmd
+}
+
+// historyEntryDetails describes the subject of a change in terms of its change type
+func historyEntryDetails(entry *svc_collection.HistoryEntry) string {
+	if entry.Subject == "" {
+		return ""
+	}
+	switch entry.ChangeType {
+	case "member-added", "member-removed":
+		return "member " + entry.Subject
+	case "moved":
+		return "to parent " + entry.Subject
+	case "ownership-transferred":
+		return "new owner " + entry.Subject
+	default:
+		return entry.Subject
+	}
+}
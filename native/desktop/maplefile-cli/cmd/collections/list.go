@@ -24,6 +24,7 @@ func listCmd(
 	var parentID string
 	var state string
 	var showModified bool
+	var includeArchived bool
 	var verbose bool
 
 	var cmd = &cobra.Command{
@@ -32,7 +33,8 @@ func listCmd(
 		Long: `
 List collections with various filtering options.
 
-By default, lists all active root-level collections. Use flags to filter by:
+By default, lists all active root-level collections. Archived collections are
+hidden unless --include-archived is passed. Use flags to filter by:
   • Parent collection (sub-collections)
   • State (active, deleted, archived)
   • Modification status (locally modified)
@@ -49,6 +51,9 @@ Examples:
   maplefile-cli collections list --state deleted
   maplefile-cli collections list --state archived
 
+  # List root collections including archived ones
+  maplefile-cli collections list --include-archived
+
   # List locally modified collections
   maplefile-cli collections list --modified
 
@@ -59,7 +64,7 @@ Examples:
   maplefile-cli collections list --parent 507f1f77bcf86cd799439011 --verbose
 `,
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 			defer cancel()
 
 			var output *svc_collection.ListOutput
@@ -81,13 +86,7 @@ Examples:
 				}
 				filterDescription = fmt.Sprintf("%s collections", state)
 
-				// Note: This requires extending ListService for state filtering
-				if state == collection.CollectionStateActive {
-					output, err = listService.ListRoots(ctx)
-				} else {
-					fmt.Printf("⚠️  State filtering for '%s' requires service layer enhancement.\n", state)
-					return
-				}
+				output, err = listService.ListByState(ctx, state)
 			} else if parentID != "" {
 				parenObjectID, err := gocql.ParseUUID(parentID)
 				if err != nil {
@@ -95,7 +94,14 @@ Examples:
 				}
 
 				filterDescription = fmt.Sprintf("sub-collections under parent %s", parenObjectID.String())
-				output, err = listService.ListByParent(ctx, parenObjectID)
+				if includeArchived {
+					output, err = listService.ListByParentIncludingArchived(ctx, parenObjectID)
+				} else {
+					output, err = listService.ListByParent(ctx, parenObjectID)
+				}
+			} else if includeArchived {
+				filterDescription = "root collections (including archived)"
+				output, err = listService.ListRootsIncludingArchived(ctx)
 			} else {
 				filterDescription = "root collections"
 				output, err = listService.ListRoots(ctx)
@@ -139,6 +145,7 @@ Examples:
 	cmd.Flags().StringVarP(&parentID, "parent", "p", "", "List sub-collections of this parent collection")
 	cmd.Flags().StringVarP(&state, "state", "s", "", "Filter by state (active, deleted, archived)")
 	cmd.Flags().BoolVarP(&showModified, "modified", "m", false, "Show only locally modified collections")
+	cmd.Flags().BoolVar(&includeArchived, "include-archived", false, "Also show archived collections")
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show detailed information")
 
 	return cmd
@@ -227,6 +234,8 @@ func getSyncStatusString(status collection.SyncStatus) string {
 		return "✅ Synced"
 	case collection.SyncStatusModifiedLocally:
 		return "📝 Modified"
+	case collection.SyncStatusKeyProblem:
+		return "🔑 Key Problem"
 	default:
 		return "❓ Unknown"
 	}
@@ -0,0 +1,97 @@
+// cmd/collections/share/rewrap.go
+package share
+
+import (
+	"fmt"
+
+	"github.com/gocql/gocql"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectionsharing"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
+)
+
+// RewrapKeyCmd creates a command for repairing the collection key of a member
+func RewrapKeyCmd(
+	rewrapMemberKeyService collectionsharing.RewrapMemberKeyService,
+	logger *zap.Logger,
+) *cobra.Command {
+	var recipientEmail, password string
+
+	var cmd = &cobra.Command{
+		Use:   "rewrap-key COLLECTION_ID",
+		Short: "Repair the collection key of a member",
+		Long: `
+Encrypt the collection key again for an existing member of a collection.
+
+Use this when a member cannot open a shared collection because their copy of
+the collection key is stale or missing, for example after they reset their
+account keys. The collection key is decrypted on this device and encrypted
+with the recipient's current public key. The member keeps their permission
+level and expiry, so there is no need to remove and share again.
+
+Only the owner and admin members can re-wrap keys.
+
+Examples:
+  # Repair the collection key of a member
+  maplefile-cli collections rewrap-key 507f1f77bcf86cd799439011 --recipient user@example.com --password mypassword
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if recipientEmail == "" {
+				fmt.Println("🐞 Error: Recipient email is required.")
+				fmt.Println("Use --recipient flag to specify the member's email address.")
+				return
+			}
+
+			if password == "" {
+				fmt.Println("❌ Error: Password is required for E2EE operations.")
+				fmt.Println("Use --password flag to specify your account password.")
+				return
+			}
+
+			collectionID, err := gocql.ParseUUID(args[0])
+			if err != nil {
+				fmt.Printf("🐞 Error: Invalid collection ID format: %v\n", err)
+				return
+			}
+
+			output, err := rewrapMemberKeyService.Execute(cmd.Context(), collectionID, recipientEmail, password)
+			if err != nil {
+				fmt.Printf("🐞 Error re-wrapping collection key: %v\n", err)
+				logger.Error("Failed to re-wrap collection key",
+					zap.String("collectionID", collectionID.String()),
+					zap.String("recipientEmail", recipientEmail),
+					zap.Error(err))
+				return
+			}
+
+			fmt.Printf("✅ Successfully re-wrapped the collection key!\n\n")
+			fmt.Printf("Member Details:\n")
+			fmt.Printf("  Collection ID: %s\n", output.CollectionID.String())
+			fmt.Printf("  Recipient: %s\n", output.RecipientEmail)
+			fmt.Printf("  Permission Level: %s\n", output.PermissionLevel)
+			if !output.ExpiresAt.IsZero() {
+				fmt.Printf("  Access Expires: %s\n", output.ExpiresAt.Format("2006-01-02 15:04:05"))
+			}
+			if !output.HadKey {
+				fmt.Printf("💡 The member had no collection key before, they can open the collection now.\n")
+			}
+			printRecipientKeyTrust(output.RecipientEmail, output.RecipientKeyTrust)
+
+			logger.Info("Collection key re-wrapped for member",
+				zap.String("collectionID", output.CollectionID.String()),
+				zap.String("recipientID", output.RecipientID.String()),
+				zap.Bool("hadKey", output.HadKey))
+		},
+	}
+
+	cmd.Flags().StringVar(&recipientEmail, "recipient", "", "Email address of the member (required)")
+	cmd.Flags().StringVar(&password, "password", "", "Your account password (required for E2EE)")
+
+	cmd.MarkFlagRequired("recipient")
+	promptpassword.AddStdinFlag(cmd, &password, true)
+
+	return cmd
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/gocql/gocql"
 	"github.com/spf13/cobra"
@@ -12,6 +13,8 @@ import (
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collectionsharingdto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectionsharing"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/keytrust"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
 )
 
 // ShareCmd creates a command for sharing collections with automatic local sync
@@ -23,6 +26,7 @@ func ShareCmdWithSync(
 	var collectionID, recipientEmail, permissionLevel, password string
 	var shareWithDescendants bool
 	var syncStrategy string
+	var expiresIn time.Duration
 
 	var cmd = &cobra.Command{
 		Use:   "share",
@@ -45,6 +49,9 @@ Examples:
   # Share with cloud sync strategy (pulls fresh data from server)
   maplefile-cli collections share --id 507f1f77bcf86cd799439011 --email user@example.com --permission admin --password mypassword --sync-strategy cloud-pull
 
+  # Share with a guest for a week, after which their access ends
+  maplefile-cli collections share --id 507f1f77bcf86cd799439011 --email guest@example.com --permission read_only --expires-in 168h --password mypassword
+
   # Share without updating local state (original behavior)
   maplefile-cli collections share --id 507f1f77bcf86cd799439011 --email user@example.com --permission admin --password mypassword --sync-strategy none
 
@@ -94,6 +101,11 @@ Sync strategies:
 				return
 			}
 
+			if expiresIn < 0 {
+				fmt.Println("🐞 Error: Expiry duration must be positive.")
+				return
+			}
+
 			// Convert string ID to gocql.TimeUUID
 			// Note: This variable is named collectionObjectID but now holds a gocql.TimeUUID
 			collectionObjectID, err := gocql.ParseUUID(collectionID)
@@ -112,6 +124,9 @@ Sync strategies:
 				PermissionLevel:      permissionLevel,
 				ShareWithDescendants: shareWithDescendants,
 			}
+			if expiresIn > 0 {
+				input.ExpiresAt = time.Now().Add(expiresIn)
+			}
 
 			//
 			// STEP 3: Execute sharing with selected sync strategy
@@ -174,6 +189,10 @@ Sync strategies:
 			fmt.Printf("  Permission Level: %s\n", permissionLevel)
 			fmt.Printf("  Shared with Descendants: %t\n", shareWithDescendants)
 			fmt.Printf("  Memberships Created: %d\n", output.MembershipsCreated)
+			if !input.ExpiresAt.IsZero() {
+				fmt.Printf("  Access Expires: %s\n", input.ExpiresAt.Format("2006-01-02 15:04:05"))
+			}
+			printRecipientKeyTrust(recipientEmail, output.RecipientKeyTrust)
 			fmt.Printf("💡 Local collection updated with new member. Changes are immediately visible.\n")
 			fmt.Printf("  Sync Strategy: %s\n", getSyncStrategyDescription(syncStrategy))
 
@@ -202,17 +221,41 @@ Sync strategies:
 	cmd.Flags().StringVar(&permissionLevel, "permission", "", "Permission level for the recipient (read_only, read_write, or admin) (required)")
 	cmd.Flags().BoolVar(&shareWithDescendants, "descendants", false, "Also share all child collections")
 	cmd.Flags().StringVar(&password, "password", "", "Your account password (required for E2EE)")
+	cmd.Flags().DurationVar(&expiresIn, "expires-in", 0, "End the recipient's access after this duration, e.g. 168h (default: never)")
 	cmd.Flags().StringVar(&syncStrategy, "sync-strategy", "immediate", "Sync strategy after sharing (immediate, cloud-pull, none)")
 
 	// Mark required flags
 	cmd.MarkFlagRequired("id")
 	cmd.MarkFlagRequired("email")
 	cmd.MarkFlagRequired("permission")
-	cmd.MarkFlagRequired("password")
+	promptpassword.AddStdinFlag(cmd, &password, true)
 
 	return cmd
 }
 
+// printRecipientKeyTrust reports the verification ID of the recipient public key, warning loudly if
+// it differs from the one trusted the first time the recipient was shared with
+func printRecipientKeyTrust(recipientEmail string, keyTrust *keytrust.TrustCheckResult) {
+	if keyTrust == nil {
+		return
+	}
+
+	fmt.Printf("  Recipient Verification ID: %s\n", keyTrust.VerificationID)
+	switch {
+	case keyTrust.FirstUse:
+		fmt.Printf("🔑 First share with %s: their key is now trusted on this device.\n", recipientEmail)
+		fmt.Printf("💡 Compare the verification ID above with %s out of band to be sure it is theirs.\n", recipientEmail)
+	case keyTrust.Changed:
+		fmt.Printf("\n🚨🚨🚨 WARNING: THE PUBLIC KEY OF %s HAS CHANGED! 🚨🚨🚨\n", strings.ToUpper(recipientEmail))
+		fmt.Printf("  Trusted Verification ID: %s\n", keyTrust.TrustedVerificationID)
+		fmt.Printf("  Fetched Verification ID: %s\n", keyTrust.VerificationID)
+		fmt.Println("This happens when the recipient reset their account, or when the server substituted")
+		fmt.Println("their key to read what you share. Confirm the new verification ID with the recipient")
+		fmt.Println("out of band. If it isn't theirs, remove their access with 'collections unshare'.")
+		fmt.Printf("Once confirmed, trust the new key with: maplefile-cli keys reset-trust %s\n\n", recipientEmail)
+	}
+}
+
 // getSyncStrategyDescription returns a human-readable description of the sync strategy
 func getSyncStrategyDescription(strategy string) string {
 	switch strategy {
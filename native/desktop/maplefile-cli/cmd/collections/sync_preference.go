@@ -0,0 +1,101 @@
+// cmd/collections/sync_preference.go - Sync enable and disable commands
+package collections
+
+import (
+	"fmt"
+
+	"github.com/gocql/gocql"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	svc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collection"
+)
+
+// syncEnableCmd creates a command for syncing the file content of a collection again
+func syncEnableCmd(
+	syncPreferenceService svc_collection.SyncPreferenceService,
+	logger *zap.Logger,
+) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "sync-enable COLLECTION_ID",
+		Short: "Sync the file content of a collection to this device",
+		Long: `
+Sync the file content of a collection to this device again.
+
+Collections are enabled by default. Re-enabling does not fetch the files added
+while sync was disabled, onload them with
+'maplefile-cli files filesync onload'.
+
+Examples:
+  # Enable content sync for a collection
+  maplefile-cli collections sync-enable 507f1f77bcf86cd799439011
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runSetSyncEnabled(cmd, syncPreferenceService, logger, args[0], true)
+		},
+	}
+
+	return cmd
+}
+
+// syncDisableCmd creates a command for keeping the file content of a collection in the cloud
+func syncDisableCmd(
+	syncPreferenceService svc_collection.SyncPreferenceService,
+	logger *zap.Logger,
+) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "sync-disable COLLECTION_ID",
+		Short: "Stop syncing the file content of a collection to this device",
+		Long: `
+Stop syncing the file content of a collection to this device.
+
+Use this for large shared collections you don't want on this device. The
+collection and the metadata of its files still sync, so they show up in
+listings as cloud-only, but sync never onloads or refreshes their content.
+Files already onloaded are kept. The preference only applies to this device.
+
+Examples:
+  # Disable content sync for a collection
+  maplefile-cli collections sync-disable 507f1f77bcf86cd799439011
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runSetSyncEnabled(cmd, syncPreferenceService, logger, args[0], false)
+		},
+	}
+
+	return cmd
+}
+
+// runSetSyncEnabled parses the collection ID and saves its sync preference
+func runSetSyncEnabled(
+	cmd *cobra.Command,
+	syncPreferenceService svc_collection.SyncPreferenceService,
+	logger *zap.Logger,
+	rawCollectionID string,
+	enabled bool,
+) {
+	collectionID, err := gocql.ParseUUID(rawCollectionID)
+	if err != nil {
+		fmt.Printf("🐞 Error: Invalid collection ID format: %v\n", err)
+		return
+	}
+
+	updated, err := syncPreferenceService.SetSyncEnabled(cmd.Context(), collectionID, enabled)
+	if err != nil {
+		fmt.Printf("🐞 Error changing collection sync preference: %v\n", err)
+		return
+	}
+
+	if enabled {
+		fmt.Printf("✅ File content sync enabled for collection %s\n", updated.ID.String())
+	} else {
+		fmt.Printf("✅ File content sync disabled for collection %s\n", updated.ID.String())
+		fmt.Printf("💡 To enable it again: maplefile-cli collections sync-enable %s\n", updated.ID.String())
+	}
+
+	logger.Info("Collection sync preference changed",
+		zap.String("collectionID", updated.ID.String()),
+		zap.Bool("syncEnabled", enabled))
+}
@@ -0,0 +1,103 @@
+// monorepo/native/desktop/maplefile-cli/cmd/config/auto_onload.go
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/spf13/cobra"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/repo/filedto"
+)
+
+func autoOnloadConfigCmd(configService config.ConfigService) *cobra.Command {
+	var mode string
+	var collectionIDs []string
+	var maxSize string
+
+	var cmd = &cobra.Command{
+		Use:   "auto-onload",
+		Short: "Get or set which new files are onloaded after a sync",
+		Long: `
+Get or set which files added by a sync are onloaded, meaning downloaded and
+decrypted to disk, once the sync completes. New files are otherwise
+cloud-only and only their metadata is stored locally.
+
+Modes:
+  never           Keep new files cloud-only (default)
+  all             Onload every new file
+  by-collection   Onload new files of the given collections
+  under-size      Onload new files up to the maximum size
+
+The maximum size also limits the all and by-collection modes when set. Files
+which would leave less than 1GB of free disk space are never onloaded.
+
+Examples:
+  # Show the current policy
+  maplefile-cli config auto-onload
+
+  # Onload new files of two collections
+  maplefile-cli config auto-onload --mode by-collection --collection COLLECTION_ID_1 --collection COLLECTION_ID_2
+
+  # Onload new files of up to 50MB
+  maplefile-cli config auto-onload --mode under-size --max-size 50MB
+
+  # Keep new files cloud-only again
+  maplefile-cli config auto-onload --mode never
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if !cmd.Flags().Changed("mode") && (cmd.Flags().Changed("collection") || cmd.Flags().Changed("max-size")) {
+				fmt.Println("Error: --collection and --max-size require --mode")
+				return
+			}
+			if cmd.Flags().Changed("mode") {
+				policy := &config.AutoOnloadPolicy{
+					Mode:          mode,
+					CollectionIDs: collectionIDs,
+				}
+				for _, collectionID := range collectionIDs {
+					if _, err := gocql.ParseUUID(collectionID); err != nil {
+						fmt.Printf("Error: invalid collection ID format %s: %v\n", collectionID, err)
+						return
+					}
+				}
+				if maxSize != "" {
+					size, err := config.ParseByteSize(maxSize)
+					if err != nil {
+						fmt.Printf("Error: %v\n", err)
+						return
+					}
+					policy.MaxFileSize = size
+				}
+				if err := configService.SetAutoOnloadPolicy(ctx, policy); err != nil {
+					fmt.Printf("Error setting auto onload policy: %v\n", err)
+					return
+				}
+			}
+
+			policy, err := configService.GetAutoOnloadPolicy(ctx)
+			if err != nil {
+				fmt.Printf("Error getting auto onload policy: %v\n", err)
+				return
+			}
+			fmt.Println("Auto Onload Policy:")
+			fmt.Printf("  Mode: %s\n", policy.Mode)
+			if len(policy.CollectionIDs) > 0 {
+				fmt.Printf("  Collections: %s\n", strings.Join(policy.CollectionIDs, ", "))
+			}
+			if policy.MaxFileSize > 0 {
+				fmt.Printf("  Maximum file size: %s\n", filedto.FormatFileSize(policy.MaxFileSize))
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&mode, "mode", "", "Onload new files after a sync: never, all, by-collection or under-size")
+	cmd.Flags().StringArrayVar(&collectionIDs, "collection", nil, "Collection whose new files are onloaded by the by-collection mode (repeatable)")
+	cmd.Flags().StringVar(&maxSize, "max-size", "", "Largest file onloaded, such as 50MB (required by under-size)")
+
+	return cmd
+}
@@ -0,0 +1,83 @@
+// monorepo/native/desktop/maplefile-cli/cmd/config/clock.go
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
+)
+
+func clockConfigCmd(configService config.ConfigService) *cobra.Command {
+	var syncMode string
+
+	var cmd = &cobra.Command{
+		Use:   "clock",
+		Short: "Show the clock skew from the server and configure server time sync",
+		Long: `
+Show the difference between the local clock and the server clock, and enable or
+disable correcting expiry checks with it.
+
+Every response from the cloud carries the server time, which is used to measure
+how far off the local clock is. The last measured skew is saved so it is known
+before the first request of the next command. Access tokens, refresh tokens and
+recovery sessions expire at times set by the server, so a skewed local clock
+makes them look expired too early or too late. With server time sync enabled,
+those expiry checks use the local time corrected by the measured skew.
+
+Run 'maplefile-cli sync debug --network' to measure the skew again.
+
+Examples:
+  # Show the measured skew
+  maplefile-cli config clock
+
+  # Correct expiry checks with the measured skew
+  maplefile-cli config clock --sync on
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if syncMode != "" {
+				if syncMode != "on" && syncMode != "off" {
+					fmt.Println("Error: --sync must be either 'on' or 'off'")
+					return
+				}
+				if err := configService.SetServerTimeSync(ctx, syncMode == "on"); err != nil {
+					fmt.Printf("Error setting server time sync: %v\n", err)
+					return
+				}
+			}
+
+			offset, err := configService.GetServerClockOffset(ctx)
+			if err != nil {
+				fmt.Printf("Error getting server clock offset: %v\n", err)
+				return
+			}
+			enabled, err := configService.GetServerTimeSync(ctx)
+			if err != nil {
+				fmt.Printf("Error getting server time sync: %v\n", err)
+				return
+			}
+
+			fmt.Printf("Clock Skew: %v\n", offset)
+			fmt.Printf("Server Time Sync: %t\n", enabled)
+			if offset > httpclient.DefaultClockSkewThreshold || offset < -httpclient.DefaultClockSkewThreshold {
+				fmt.Printf("⚠️  Your clock is off by more than %v, synchronize your system clock", httpclient.DefaultClockSkewThreshold)
+				if !enabled {
+					fmt.Print(" or enable server time sync with --sync on")
+				}
+				fmt.Println(".")
+			}
+			if enabled {
+				fmt.Printf("Corrected Time: %s\n", time.Now().Add(offset).Format(time.RFC3339))
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&syncMode, "sync", "", "Enable or disable server time sync for expiry checks (on|off)")
+
+	return cmd
+}
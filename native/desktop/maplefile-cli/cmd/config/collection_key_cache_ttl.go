@@ -0,0 +1,59 @@
+// monorepo/native/desktop/maplefile-cli/cmd/config/collection_key_cache_ttl.go
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+)
+
+func collectionKeyCacheTTLConfigCmd(configService config.ConfigService) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "collection-key-cache-ttl [DURATION]",
+		Short: "Get or set how long decrypted collection keys are kept in memory",
+		Long: `
+Get or set how long decrypted collection keys are kept in memory.
+
+Batch operations on a collection reuse its decrypted key instead of decrypting
+the key chain for every file. A cached key is only used with the password it
+was decrypted with, and is zeroed once it expires, the operation ends or you log
+out. A longer TTL is faster but keeps keys in memory for longer.
+The default TTL is ` + config.DefaultCollectionKeyCacheTTL.String() + ` and it must be between ` + config.MinCollectionKeyCacheTTL.String() + ` and ` + config.MaxCollectionKeyCacheTTL.String() + `.
+
+Examples:
+  # Show the current TTL
+  maplefile-cli config collection-key-cache-ttl
+
+  # Keep decrypted collection keys for 30 seconds
+  maplefile-cli config collection-key-cache-ttl 30s
+`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if len(args) == 1 {
+				ttl, err := time.ParseDuration(args[0])
+				if err != nil {
+					fmt.Printf("Error: invalid duration %q, use a value such as 30s or 5m\n", args[0])
+					return
+				}
+				if err := configService.SetCollectionKeyCacheTTL(ctx, ttl); err != nil {
+					fmt.Printf("Error setting collection key cache TTL: %v\n", err)
+					return
+				}
+			}
+
+			ttl, err := configService.GetCollectionKeyCacheTTL(ctx)
+			if err != nil {
+				fmt.Printf("Error getting collection key cache TTL: %v\n", err)
+				return
+			}
+			fmt.Printf("Collection Key Cache TTL: %s\n", ttl)
+		},
+	}
+
+	return cmd
+}
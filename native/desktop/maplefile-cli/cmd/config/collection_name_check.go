@@ -0,0 +1,56 @@
+// monorepo/native/desktop/maplefile-cli/cmd/config/collection_name_check.go
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+)
+
+func collectionNameCheckConfigCmd(configService config.ConfigService) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "collection-name-check [MODE]",
+		Short: "Get or set how collections with undecryptable names are synced",
+		Long: `
+Get or set how a sync handles a collection whose name can't be decrypted with
+its collection key, which usually means the key on this device doesn't match
+the key the collection was encrypted with.
+
+Modes:
+  ` + config.CollectionNameCheckMark + `     Store the collection flagged with a key problem and a placeholder name (default)
+  ` + config.CollectionNameCheckStrict + `   Fail the sync of the collection without storing it
+
+Collections flagged with a key problem show as "Key Problem" when listed and
+are decrypted again by every sync until it succeeds.
+
+Examples:
+  # Show the current mode
+  maplefile-cli config collection-name-check
+
+  # Fail the sync of collections whose name can't be decrypted
+  maplefile-cli config collection-name-check strict
+`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if len(args) == 1 {
+				if err := configService.SetCollectionNameCheck(ctx, args[0]); err != nil {
+					fmt.Printf("Error setting collection name check: %v\n", err)
+					return
+				}
+			}
+
+			mode, err := configService.GetCollectionNameCheck(ctx)
+			if err != nil {
+				fmt.Printf("Error getting collection name check: %v\n", err)
+				return
+			}
+			fmt.Printf("Collection Name Check: %s\n", mode)
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,58 @@
+// monorepo/native/desktop/maplefile-cli/cmd/config/concurrent_onloads.go
+package config
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+)
+
+func concurrentOnloadsConfigCmd(configService config.ConfigService) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "concurrent-onloads [COUNT]",
+		Short: "Get or set how many files batch onloads and uploads process in parallel",
+		Long: `
+Get or set how many files batch onloads and batch uploads process in parallel.
+
+Every file is encrypted or decrypted in memory, so more files in parallel is
+faster on a fast connection but uses more CPU and memory. The --concurrency
+flag of a single command overrides this setting.
+The default is the number of CPUs and it must be between ` + strconv.Itoa(config.MinConcurrentOnloads) + ` and ` + strconv.Itoa(config.MaxConcurrentOnloads) + `.
+
+Examples:
+  # Show the current number of files processed in parallel
+  maplefile-cli config concurrent-onloads
+
+  # Process 8 files in parallel
+  maplefile-cli config concurrent-onloads 8
+`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if len(args) == 1 {
+				concurrency, err := strconv.Atoi(args[0])
+				if err != nil {
+					fmt.Printf("Error: invalid count %q, use a number between %d and %d\n", args[0], config.MinConcurrentOnloads, config.MaxConcurrentOnloads)
+					return
+				}
+				if err := configService.SetMaxConcurrentOnloads(ctx, concurrency); err != nil {
+					fmt.Printf("Error setting concurrent onloads: %v\n", err)
+					return
+				}
+			}
+
+			concurrency, err := configService.GetMaxConcurrentOnloads(ctx)
+			if err != nil {
+				fmt.Printf("Error getting concurrent onloads: %v\n", err)
+				return
+			}
+			fmt.Printf("Concurrent Onloads: %d\n", concurrency)
+		},
+	}
+
+	return cmd
+}
@@ -20,6 +20,21 @@ func ConfigCmd(configService config.ConfigService) *cobra.Command {
 	// Add configuration-related commands
 	cmd.AddCommand(getConfigCmd(configService))
 	cmd.AddCommand(setConfigCmd(configService))
+	cmd.AddCommand(failoverConfigCmd(configService))
+	cmd.AddCommand(clockConfigCmd(configService))
+	cmd.AddCommand(setDefaultCollectionConfigCmd(configService))
+	cmd.AddCommand(hashAlgorithmConfigCmd(configService))
+	cmd.AddCommand(uploadPolicyConfigCmd(configService))
+	cmd.AddCommand(autoOnloadConfigCmd(configService))
+	cmd.AddCommand(downloadMemoryConfigCmd(configService))
+	cmd.AddCommand(collectionNameCheckConfigCmd(configService))
+	cmd.AddCommand(storageQuotaConfigCmd(configService))
+	cmd.AddCommand(onloadFallbackExtensionConfigCmd(configService))
+	cmd.AddCommand(perceptualHashConfigCmd(configService))
+	cmd.AddCommand(syncWatchIntervalConfigCmd(configService))
+	cmd.AddCommand(retryBudgetConfigCmd(configService))
+	cmd.AddCommand(collectionKeyCacheTTLConfigCmd(configService))
+	cmd.AddCommand(concurrentOnloadsConfigCmd(configService))
 
 	return cmd
 }
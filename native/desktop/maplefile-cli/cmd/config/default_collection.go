@@ -0,0 +1,73 @@
+// monorepo/native/desktop/maplefile-cli/cmd/config/default_collection.go
+package config
+
+import (
+	"fmt"
+
+	"github.com/gocql/gocql"
+	"github.com/spf13/cobra"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+)
+
+func setDefaultCollectionConfigCmd(configService config.ConfigService) *cobra.Command {
+	var clear bool
+
+	var cmd = &cobra.Command{
+		Use:   "set-default-collection [COLLECTION_ID]",
+		Short: "Get or set the collection files are added to by default",
+		Long: `
+Get or set the collection files are added to when no --collection is given.
+
+Examples:
+  # Show the default collection
+  maplefile-cli config set-default-collection
+
+  # Add files to this collection by default
+  maplefile-cli config set-default-collection 507f1f77bcf86cd799439011
+
+  # Remove the default collection
+  maplefile-cli config set-default-collection --clear
+`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if clear {
+				if err := configService.SetDefaultCollectionID(ctx, ""); err != nil {
+					fmt.Printf("Error clearing default collection: %v\n", err)
+					return
+				}
+				fmt.Println("Default collection removed.")
+				return
+			}
+
+			if len(args) == 1 {
+				collectionID, err := gocql.ParseUUID(args[0])
+				if err != nil {
+					fmt.Printf("Error: invalid collection ID format: %v\n", err)
+					return
+				}
+				if err := configService.SetDefaultCollectionID(ctx, collectionID.String()); err != nil {
+					fmt.Printf("Error setting default collection: %v\n", err)
+					return
+				}
+			}
+
+			collectionID, err := configService.GetDefaultCollectionID(ctx)
+			if err != nil {
+				fmt.Printf("Error getting default collection: %v\n", err)
+				return
+			}
+			if collectionID == "" {
+				fmt.Println("No default collection set.")
+				return
+			}
+			fmt.Printf("Default Collection: %s\n", collectionID)
+		},
+	}
+
+	cmd.Flags().BoolVar(&clear, "clear", false, "Remove the default collection")
+
+	return cmd
+}
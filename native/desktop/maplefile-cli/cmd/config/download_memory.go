@@ -0,0 +1,59 @@
+// monorepo/native/desktop/maplefile-cli/cmd/config/download_memory.go
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/repo/filedto"
+)
+
+func downloadMemoryConfigCmd(configService config.ConfigService) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "download-memory [SIZE]",
+		Short: "Get or set the memory downloads may use at once",
+		Long: `
+Get or set how much memory in-flight downloads may buffer at once.
+
+Each download holds both the encrypted and the decrypted file in memory, so
+batch onloads of large files can exhaust the memory of the machine. Downloads
+wait until their files fit in the budget, and a file larger than the whole
+budget is downloaded on its own. Lower the budget on machines with little
+memory. The default is 512MB and the minimum is 16MB.
+
+Examples:
+  # Show the current budget
+  maplefile-cli config download-memory
+
+  # Allow downloads to buffer up to 256MB at once
+  maplefile-cli config download-memory 256MB
+`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if len(args) == 1 {
+				budget, err := config.ParseByteSize(args[0])
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					return
+				}
+				if err := configService.SetDownloadMemoryBudget(ctx, budget); err != nil {
+					fmt.Printf("Error setting download memory budget: %v\n", err)
+					return
+				}
+			}
+
+			budget, err := configService.GetDownloadMemoryBudget(ctx)
+			if err != nil {
+				fmt.Printf("Error getting download memory budget: %v\n", err)
+				return
+			}
+			fmt.Printf("Download Memory Budget: %s\n", filedto.FormatFileSize(budget))
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,73 @@
+// monorepo/native/desktop/maplefile-cli/cmd/config/failover.go
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
+)
+
+func failoverConfigCmd(configService config.ConfigService) *cobra.Command {
+	var clear bool
+
+	var cmd = &cobra.Command{
+		Use:   "failover [address...]",
+		Short: "Get or set failover cloud provider addresses",
+		Long: `
+Get or set the cloud provider addresses used while the primary address is
+unhealthy, in order of preference.
+
+Requests are sent to the primary address set with 'config set'. After repeated
+connection errors or server errors it is skipped for a minute and requests go
+to the first healthy failover address instead. Run 'maplefile-cli sync debug
+--network' to see which address is active.
+
+Examples:
+  # Show the failover addresses
+  maplefile-cli config failover
+
+  # Fail over to two other regions
+  maplefile-cli config failover https://ca-east.example.com https://ca-west.example.com
+
+  # Remove every failover address
+  maplefile-cli config failover --clear
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if clear || len(args) > 0 {
+				for _, address := range args {
+					if _, err := httpclient.ParseEndpoint(address); err != nil {
+						fmt.Printf("Error: %v\n", err)
+						return
+					}
+				}
+				if err := configService.SetCloudProviderFailoverAddresses(ctx, args); err != nil {
+					fmt.Printf("Error setting failover addresses: %v\n", err)
+					return
+				}
+			}
+
+			addresses, err := configService.GetCloudProviderFailoverAddresses(ctx)
+			if err != nil {
+				fmt.Printf("Error getting failover addresses: %v\n", err)
+				return
+			}
+			if len(addresses) == 0 {
+				fmt.Println("No failover addresses configured.")
+				return
+			}
+			fmt.Println("Failover Addresses:")
+			for i, address := range addresses {
+				fmt.Printf("  %d. %s\n", i+1, address)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&clear, "clear", false, "Remove every failover address")
+
+	return cmd
+}
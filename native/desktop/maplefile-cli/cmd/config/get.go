@@ -2,7 +2,6 @@
 package config
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -15,7 +14,7 @@ func getConfigCmd(configService config.ConfigService) *cobra.Command {
 		Use:   "get",
 		Short: "Get current cloud provider address",
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx := context.Background()
+			ctx := cmd.Context()
 			address, err := configService.GetCloudProviderAddress(ctx)
 			if err != nil {
 				fmt.Printf("Error getting cloud provider address: %v\n", err)
@@ -0,0 +1,54 @@
+// monorepo/native/desktop/maplefile-cli/cmd/config/hash_algorithm.go
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+)
+
+func hashAlgorithmConfigCmd(configService config.ConfigService) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "hash-algorithm [ALGORITHM]",
+		Short: "Get or set the algorithm used for file integrity hashes",
+		Long: `
+Get or set the algorithm the integrity hash of newly added files is computed with.
+
+The algorithm is recorded with every file, so downloads always verify a file
+with the algorithm it was hashed with and changing this setting only affects
+files added afterwards.
+
+Supported algorithms: ` + crypto.HashAlgorithmSHA3_256 + ` (default), ` + crypto.HashAlgorithmSHA256 + `, ` + crypto.HashAlgorithmBLAKE2b_256 + `
+
+Examples:
+  # Show the current algorithm
+  maplefile-cli config hash-algorithm
+
+  # Hash new files with BLAKE2b
+  maplefile-cli config hash-algorithm blake2b
+`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if len(args) == 1 {
+				if err := configService.SetFileHashAlgorithm(ctx, args[0]); err != nil {
+					fmt.Printf("Error setting hash algorithm: %v\n", err)
+					return
+				}
+			}
+
+			algorithm, err := configService.GetFileHashAlgorithm(ctx)
+			if err != nil {
+				fmt.Printf("Error getting hash algorithm: %v\n", err)
+				return
+			}
+			fmt.Printf("File Hash Algorithm: %s\n", algorithm)
+		},
+	}
+
+	return cmd
+}
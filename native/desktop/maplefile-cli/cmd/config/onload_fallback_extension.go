@@ -0,0 +1,59 @@
+// monorepo/native/desktop/maplefile-cli/cmd/config/onload_fallback_extension.go
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+)
+
+func onloadFallbackExtensionConfigCmd(configService config.ConfigService) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "onload-fallback-extension [EXTENSION]",
+		Short: "Get or set the extension of onloaded files with an unknown type",
+		Long: `
+Get or set the extension given to an onloaded file whose type can't be
+determined from its name, its extension or its MIME type.
+
+The default extension is ` + config.DefaultOnloadFallbackExtension + `. Set it to ` + config.OnloadFallbackExtensionNone + ` to save these files
+without an extension. Changing this setting only affects files onloaded
+afterwards.
+
+Examples:
+  # Show the current fallback extension
+  maplefile-cli config onload-fallback-extension
+
+  # Save files with an unknown type as .bin files
+  maplefile-cli config onload-fallback-extension bin
+
+  # Save files with an unknown type without an extension
+  maplefile-cli config onload-fallback-extension ` + config.OnloadFallbackExtensionNone + `
+`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if len(args) == 1 {
+				if err := configService.SetOnloadFallbackExtension(ctx, args[0]); err != nil {
+					fmt.Printf("Error setting onload fallback extension: %v\n", err)
+					return
+				}
+			}
+
+			extension, err := configService.GetOnloadFallbackExtension(ctx)
+			if err != nil {
+				fmt.Printf("Error getting onload fallback extension: %v\n", err)
+				return
+			}
+			if extension == "" {
+				fmt.Println("Onload Fallback Extension: none (files are saved without an extension)")
+				return
+			}
+			fmt.Printf("Onload Fallback Extension: %s\n", extension)
+		},
+	}
+
+	return cmd
+}
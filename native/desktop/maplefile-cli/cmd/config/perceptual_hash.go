@@ -0,0 +1,63 @@
+// monorepo/native/desktop/maplefile-cli/cmd/config/perceptual_hash.go
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+)
+
+func perceptualHashConfigCmd(configService config.ConfigService) *cobra.Command {
+	var enableMode string
+
+	var cmd = &cobra.Command{
+		Use:   "perceptual-hash",
+		Short: "Show or configure perceptual hashing of added images",
+		Long: `
+Show whether a perceptual hash is computed for images when they are added, and
+enable or disable it.
+
+A perceptual hash summarizes how an image looks, so images which were resized
+or re-encoded can be found with 'maplefile-cli files find-similar'. It is
+computed on this device from the unencrypted image and stored in the encrypted
+file metadata, so the server only ever sees it encrypted. JPEG, PNG and GIF
+images are hashed, other files are skipped.
+
+Changing this setting only affects files added afterwards.
+
+Examples:
+  # Show whether perceptual hashing is enabled
+  maplefile-cli config perceptual-hash
+
+  # Compute perceptual hashes of added images
+  maplefile-cli config perceptual-hash --enable on
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if enableMode != "" {
+				if enableMode != "on" && enableMode != "off" {
+					fmt.Println("Error: --enable must be either 'on' or 'off'")
+					return
+				}
+				if err := configService.SetPerceptualHash(ctx, enableMode == "on"); err != nil {
+					fmt.Printf("Error setting perceptual hashing: %v\n", err)
+					return
+				}
+			}
+
+			enabled, err := configService.GetPerceptualHash(ctx)
+			if err != nil {
+				fmt.Printf("Error getting perceptual hashing: %v\n", err)
+				return
+			}
+			fmt.Printf("Perceptual Hash: %t\n", enabled)
+		},
+	}
+
+	cmd.Flags().StringVar(&enableMode, "enable", "", "Enable or disable perceptual hashing of added images (on|off)")
+
+	return cmd
+}
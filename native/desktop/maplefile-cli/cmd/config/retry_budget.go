@@ -0,0 +1,83 @@
+// monorepo/native/desktop/maplefile-cli/cmd/config/retry_budget.go
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+)
+
+func retryBudgetConfigCmd(configService config.ConfigService) *cobra.Command {
+	var maxAttempts, breakerThreshold int
+	var breakerCooldown time.Duration
+
+	var cmd = &cobra.Command{
+		Use:   "retry-budget",
+		Short: "Get or set how hard requests to the cloud are retried",
+		Long: `
+Get or set the retry budget and the circuit breaker of requests to the cloud.
+
+A request which can't reach any cloud address is attempted up to --attempts
+times with backoff. After --breaker-threshold requests failed in a row, the
+circuit breaker opens: requests fail immediately for --breaker-cooldown instead
+of waiting on a cloud which is down. A single request is then let through to
+test whether the cloud recovered, closing the breaker if it succeeds. A
+threshold of 0 disables the breaker. Changes apply to the next command.
+
+Run 'maplefile-cli sync debug --network' to see the state of the breaker.
+
+Examples:
+  # Show the current retry budget
+  maplefile-cli config retry-budget
+
+  # Give up sooner and back off for two minutes after 3 failed requests
+  maplefile-cli config retry-budget --attempts 2 --breaker-threshold 3 --breaker-cooldown 2m
+
+  # Disable the circuit breaker
+  maplefile-cli config retry-budget --breaker-threshold 0
+`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			budget, err := configService.GetCloudRetryBudget(ctx)
+			if err != nil {
+				fmt.Printf("Error getting retry budget: %v\n", err)
+				return
+			}
+
+			if cmd.Flags().Changed("attempts") || cmd.Flags().Changed("breaker-threshold") || cmd.Flags().Changed("breaker-cooldown") {
+				if cmd.Flags().Changed("attempts") {
+					budget.MaxAttempts = maxAttempts
+				}
+				if cmd.Flags().Changed("breaker-threshold") {
+					budget.BreakerThreshold = breakerThreshold
+				}
+				if cmd.Flags().Changed("breaker-cooldown") {
+					budget.BreakerCooldownSeconds = int64(breakerCooldown / time.Second)
+				}
+				if err := configService.SetCloudRetryBudget(ctx, budget); err != nil {
+					fmt.Printf("Error setting retry budget: %v\n", err)
+					return
+				}
+			}
+
+			fmt.Printf("Max Attempts: %d\n", budget.MaxAttempts)
+			if budget.BreakerThreshold == 0 {
+				fmt.Println("Circuit Breaker: disabled")
+				return
+			}
+			fmt.Printf("Circuit Breaker Threshold: %d failed requests\n", budget.BreakerThreshold)
+			fmt.Printf("Circuit Breaker Cooldown: %s\n", budget.BreakerCooldown())
+		},
+	}
+
+	cmd.Flags().IntVar(&maxAttempts, "attempts", config.DefaultCloudMaxAttempts, fmt.Sprintf("Attempts of a request while the cloud is unreachable (1-%d)", config.MaxCloudMaxAttempts))
+	cmd.Flags().IntVar(&breakerThreshold, "breaker-threshold", config.DefaultCloudBreakerThreshold, "Consecutive failed requests which open the circuit breaker, 0 disables it")
+	cmd.Flags().DurationVar(&breakerCooldown, "breaker-cooldown", config.DefaultCloudBreakerCooldown, "How long the open circuit breaker fails requests fast")
+
+	return cmd
+}
@@ -2,7 +2,6 @@
 package config
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -16,7 +15,7 @@ func setConfigCmd(configService config.ConfigService) *cobra.Command {
 		Short: "Set cloud provider address",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx := context.Background()
+			ctx := cmd.Context()
 			address := args[0]
 			if err := configService.SetCloudProviderAddress(ctx, address); err != nil {
 				fmt.Printf("Error setting cloud provider address: %v\n", err)
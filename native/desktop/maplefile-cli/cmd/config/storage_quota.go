@@ -0,0 +1,99 @@
+// monorepo/native/desktop/maplefile-cli/cmd/config/storage_quota.go
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/repo/filedto"
+)
+
+func storageQuotaConfigCmd(configService config.ConfigService) *cobra.Command {
+	var autoOffload string
+
+	var cmd = &cobra.Command{
+		Use:   "storage-quota [SIZE]",
+		Short: "Get or set the disk space local files may use",
+		Long: `
+Get or set the disk space the onloaded files may use on this machine.
+
+After a sync or an onload, the CLI warns when the local copies of your files
+use more than the quota and suggests the least recently accessed files to
+offload. With --auto-offload on, those files are offloaded automatically
+instead; their content stays available in the cloud. A size of 0 removes the
+quota.
+
+Examples:
+  # Show the current quota
+  maplefile-cli config storage-quota
+
+  # Warn when local files use more than 10GB
+  maplefile-cli config storage-quota 10GB
+
+  # Offload the least recently accessed files automatically
+  maplefile-cli config storage-quota 10GB --auto-offload on
+
+  # Remove the quota
+  maplefile-cli config storage-quota 0
+`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			quota, err := configService.GetStorageQuota(ctx)
+			if err != nil {
+				fmt.Printf("Error getting storage quota: %v\n", err)
+				return
+			}
+
+			if len(args) == 1 || cmd.Flags().Changed("auto-offload") {
+				if len(args) == 1 {
+					if quota.MaxBytes, err = config.ParseByteSize(args[0]); err != nil {
+						fmt.Printf("Error: %v\n", err)
+						return
+					}
+				}
+				if cmd.Flags().Changed("auto-offload") {
+					switch autoOffload {
+					case "on":
+						quota.AutoOffload = true
+					case "off":
+						quota.AutoOffload = false
+					default:
+						fmt.Printf("Error: invalid --auto-offload value %q, must be on or off\n", autoOffload)
+						return
+					}
+				}
+				if quota.AutoOffload && quota.MaxBytes == 0 && cmd.Flags().Changed("auto-offload") {
+					fmt.Println("Error: --auto-offload requires a storage quota")
+					return
+				}
+				if err := configService.SetStorageQuota(ctx, quota); err != nil {
+					fmt.Printf("Error setting storage quota: %v\n", err)
+					return
+				}
+				if quota, err = configService.GetStorageQuota(ctx); err != nil {
+					fmt.Printf("Error getting storage quota: %v\n", err)
+					return
+				}
+			}
+
+			if quota.MaxBytes == 0 {
+				fmt.Println("Storage Quota: none")
+				return
+			}
+			fmt.Printf("Storage Quota: %s\n", filedto.FormatFileSize(quota.MaxBytes))
+			if quota.AutoOffload {
+				fmt.Println("Auto Offload: on")
+			} else {
+				fmt.Println("Auto Offload: off")
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&autoOffload, "auto-offload", "", "Offload the least recently accessed files when over the quota: on or off")
+
+	return cmd
+}
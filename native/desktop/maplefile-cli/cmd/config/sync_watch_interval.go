@@ -0,0 +1,56 @@
+// monorepo/native/desktop/maplefile-cli/cmd/config/sync_watch_interval.go
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+)
+
+func syncWatchIntervalConfigCmd(configService config.ConfigService) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "sync-watch-interval [DURATION]",
+		Short: "Get or set how often sync watch polls the cloud",
+		Long: `
+Get or set how often 'maplefile-cli sync watch' polls the cloud for changes.
+
+A shorter interval picks up changes sooner but sends more requests to the cloud.
+The default interval is ` + config.DefaultSyncWatchPollInterval.String() + ` and it can't be shorter than ` + config.MinSyncWatchPollInterval.String() + `.
+
+Examples:
+  # Show the current interval
+  maplefile-cli config sync-watch-interval
+
+  # Poll for changes every 30 seconds
+  maplefile-cli config sync-watch-interval 30s
+`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if len(args) == 1 {
+				interval, err := time.ParseDuration(args[0])
+				if err != nil {
+					fmt.Printf("Error: invalid duration %q, use a value such as 30s or 5m\n", args[0])
+					return
+				}
+				if err := configService.SetSyncWatchPollInterval(ctx, interval); err != nil {
+					fmt.Printf("Error setting sync watch interval: %v\n", err)
+					return
+				}
+			}
+
+			interval, err := configService.GetSyncWatchPollInterval(ctx)
+			if err != nil {
+				fmt.Printf("Error getting sync watch interval: %v\n", err)
+				return
+			}
+			fmt.Printf("Sync Watch Interval: %s\n", interval)
+		},
+	}
+
+	return cmd
+}
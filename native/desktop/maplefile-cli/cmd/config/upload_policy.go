@@ -0,0 +1,97 @@
+// monorepo/native/desktop/maplefile-cli/cmd/config/upload_policy.go
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+)
+
+func uploadPolicyConfigCmd(configService config.ConfigService) *cobra.Command {
+	var allowedExtensions, deniedExtensions, allowedMimeTypes, deniedMimeTypes []string
+	var clear bool
+
+	var cmd = &cobra.Command{
+		Use:   "upload-policy",
+		Short: "Get or set which types of files can be added",
+		Long: `
+Get or set the file types which can be added, for example to enforce an
+organizational policy against uploading executables.
+
+File contents are end to end encrypted, so the server can't inspect them and
+this client checks the type before encrypting a file. The type is detected from
+the file extension. Denied types are always rejected. When any allowed type is
+set, only allowed types are permitted. By default every type is permitted.
+
+Setting any list replaces the whole policy.
+
+Examples:
+  # Show the current policy
+  maplefile-cli config upload-policy
+
+  # Block executables
+  maplefile-cli config upload-policy --deny-ext exe,msi,bat,sh --deny-mime application/x-msdownload
+
+  # Only permit documents and images
+  maplefile-cli config upload-policy --allow-ext pdf,docx --allow-mime image/jpeg,image/png
+
+  # Permit every type again
+  maplefile-cli config upload-policy --clear
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			changed := cmd.Flags().Changed("allow-ext") || cmd.Flags().Changed("deny-ext") ||
+				cmd.Flags().Changed("allow-mime") || cmd.Flags().Changed("deny-mime")
+			if clear && changed {
+				fmt.Println("Error: --clear can't be combined with other flags")
+				return
+			}
+			if clear || changed {
+				policy := &config.UploadFileTypePolicy{
+					AllowedExtensions: allowedExtensions,
+					AllowedMimeTypes:  allowedMimeTypes,
+					DeniedExtensions:  deniedExtensions,
+					DeniedMimeTypes:   deniedMimeTypes,
+				}
+				if err := configService.SetUploadFileTypePolicy(ctx, policy); err != nil {
+					fmt.Printf("Error setting upload policy: %v\n", err)
+					return
+				}
+			}
+
+			policy, err := configService.GetUploadFileTypePolicy(ctx)
+			if err != nil {
+				fmt.Printf("Error getting upload policy: %v\n", err)
+				return
+			}
+			if policy.IsEmpty() {
+				fmt.Println("Every file type is permitted.")
+				return
+			}
+			fmt.Println("Upload File Type Policy:")
+			printPolicyList("Allowed extensions", policy.AllowedExtensions)
+			printPolicyList("Allowed MIME types", policy.AllowedMimeTypes)
+			printPolicyList("Denied extensions", policy.DeniedExtensions)
+			printPolicyList("Denied MIME types", policy.DeniedMimeTypes)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&allowedExtensions, "allow-ext", nil, "Only permit these file extensions (comma separated)")
+	cmd.Flags().StringSliceVar(&deniedExtensions, "deny-ext", nil, "Reject these file extensions (comma separated)")
+	cmd.Flags().StringSliceVar(&allowedMimeTypes, "allow-mime", nil, "Only permit these MIME types (comma separated)")
+	cmd.Flags().StringSliceVar(&deniedMimeTypes, "deny-mime", nil, "Reject these MIME types (comma separated)")
+	cmd.Flags().BoolVar(&clear, "clear", false, "Permit every file type")
+
+	return cmd
+}
+
+// printPolicyList prints one list of the upload policy, if it is set
+func printPolicyList(label string, entries []string) {
+	if len(entries) > 0 {
+		fmt.Printf("  %s: %s\n", label, strings.Join(entries, ", "))
+	}
+}
@@ -0,0 +1,100 @@
+// native/desktop/maplefile-cli/cmd/export/export.go
+package export
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	svc_export "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/export"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
+)
+
+// ExportCmd creates a command for exporting a read-only snapshot of the decrypted library
+func ExportCmd(
+	exportService svc_export.ExportService,
+	logger *zap.Logger,
+) *cobra.Command {
+	var outputDir string
+	var includeCloudOnly bool
+	var password string
+
+	var cmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export a read-only snapshot of your decrypted library",
+		Long: `
+Export a read-only snapshot of your decrypted library into a directory.
+
+The snapshot mirrors your collection hierarchy using human-readable names so
+you can point a backup tool or media application at a clean directory layout.
+Your local files and their sync status are not changed.
+
+Cloud-only files are skipped unless --include-cloud-only is given, in which
+case they are downloaded and decrypted straight into the snapshot.
+
+Examples:
+  # Export all locally available files
+  maplefile-cli export --output ~/MapleFileSnapshot
+
+  # Also include files which are only stored in the cloud
+  maplefile-cli export --output ~/MapleFileSnapshot --include-cloud-only --password PASSWORD
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if outputDir == "" {
+				fmt.Println("❌ Error: Output directory is required.")
+				fmt.Println("Use --output flag to specify where to write the snapshot.")
+				return
+			}
+
+			if includeCloudOnly && password == "" {
+				fmt.Println("❌ Error: Password is required to export cloud-only files.")
+				fmt.Println("Use --password flag to specify your account password.")
+				return
+			}
+
+			fmt.Printf("📦 Exporting library to: %s\n", outputDir)
+
+			input := &svc_export.ExportInput{
+				OutputDir:        outputDir,
+				IncludeCloudOnly: includeCloudOnly,
+				UserPassword:     password,
+			}
+
+			output, err := exportService.Export(cmd.Context(), input)
+			if err != nil {
+				fmt.Printf("❌ Error exporting library: %v\n", err)
+				return
+			}
+
+			fmt.Printf("\n✅ Export completed!\n")
+			fmt.Printf("📁 Location: %s\n", output.OutputDir)
+			fmt.Printf("🗂️  Collections: %d\n", output.CollectionsWritten)
+			fmt.Printf("📄 Files: %d\n", output.FilesWritten)
+			fmt.Printf("📏 Total size: %d bytes\n", output.BytesWritten)
+
+			if len(output.SkippedFiles) > 0 {
+				fmt.Printf("\n⚠️  Skipped files (%d):\n", len(output.SkippedFiles))
+				for _, skipped := range output.SkippedFiles {
+					fmt.Printf("   • %s (%s): %s\n", skipped.Name, skipped.FileID.String(), skipped.Reason)
+				}
+				if !includeCloudOnly {
+					fmt.Println("\n💡 Use --include-cloud-only --password PASSWORD to also export cloud-only files.")
+				}
+			}
+
+			logger.Info("Library export completed",
+				zap.String("outputDir", output.OutputDir),
+				zap.Int("filesWritten", output.FilesWritten),
+				zap.Int("skippedFiles", len(output.SkippedFiles)))
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputDir, "output", "o", "", "Directory to write the snapshot into (required)")
+	cmd.MarkFlagRequired("output")
+	cmd.Flags().BoolVar(&includeCloudOnly, "include-cloud-only", false, "Download and decrypt cloud-only files into the snapshot")
+	cmd.Flags().StringVar(&password, "password", "", "Your account password (required for --include-cloud-only)")
+	promptpassword.AddStdinFlag(cmd, &password, false)
+
+	return cmd
+}
@@ -2,7 +2,6 @@
 package files
 
 import (
-	"context"
 	"fmt"
 	"strings"
 
@@ -13,6 +12,7 @@ import (
 	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/fileupload"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/localfile"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
 )
 
 // addFileCmd creates a unified command for adding files with auto-upload
@@ -36,6 +36,9 @@ Add a file from your filesystem to a MapleFile collection.
 By default, the file is encrypted locally and automatically uploaded to the cloud.
 Use --local-only to skip the upload step.
 
+Without --collection the file is added to your default collection, set it with:
+  maplefile-cli config set-default-collection COLLECTION_ID
+
 Storage modes control how files are stored:
   • encrypted_only: Only encrypted version kept locally (most secure)
   • hybrid: Both encrypted and decrypted versions (convenient, default)
@@ -45,6 +48,9 @@ Examples:
   # Add file with auto-upload (recommended)
   maplefile-cli files add "/path/to/document.pdf" --collection 507f1f77bcf86cd799439011 --password mypass
 
+  # Add file to your default collection
+  maplefile-cli files add "/path/to/notes.txt" --password mypass
+
   # Add file locally only (upload later)
   maplefile-cli files add "/path/to/photo.jpg" --collection 507f1f77bcf86cd799439011 --local-only --password mypass
 
@@ -56,7 +62,7 @@ Examples:
 `,
 		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx := context.Background()
+			ctx := cmd.Context()
 			filePath := args[0]
 
 			// Validate required parameters
@@ -66,17 +72,15 @@ Examples:
 				return
 			}
 
-			if collectionID == "" {
-				fmt.Println("❌ Error: Collection ID is required.")
-				fmt.Println("Use --collection flag to specify the target collection.")
-				return
-			}
-
-			// Convert collection ID
-			collectionObjectID, err := gocql.ParseUUID(collectionID)
-			if err != nil {
-				fmt.Printf("❌ Error: Invalid collection ID format: %v\n", err)
-				return
+			// Convert collection ID, left empty the service uses the default collection
+			var collectionObjectID gocql.UUID
+			if collectionID != "" {
+				var err error
+				collectionObjectID, err = gocql.ParseUUID(collectionID)
+				if err != nil {
+					fmt.Printf("❌ Error: Invalid collection ID format: %v\n", err)
+					return
+				}
 			}
 
 			// Set default storage mode
@@ -117,12 +121,16 @@ Examples:
 					fmt.Printf("💡 Tip: Check your password and try again.\n")
 				} else if strings.Contains(err.Error(), "file not found") {
 					fmt.Printf("💡 Tip: Check the file path is correct.\n")
+				} else if strings.Contains(err.Error(), "set-default-collection") {
+					fmt.Printf("💡 Tip: Use --collection to specify the target collection.\n")
 				} else if strings.Contains(err.Error(), "collection not found") {
 					fmt.Printf("💡 Tip: Check the collection ID with: maplefile-cli collections list\n")
 				}
 				return
 			}
 
+			collectionID = output.File.CollectionID.String()
+
 			fmt.Printf("✅ File added locally!\n")
 			fmt.Printf("🆔 File ID: %s\n", output.File.ID.String())
 			fmt.Printf("📁 Name: %s\n", output.File.Name)
@@ -173,7 +181,7 @@ Examples:
 	}
 
 	// Define flags
-	cmd.Flags().StringVarP(&collectionID, "collection", "c", "", "Collection ID to store the file in (required)")
+	cmd.Flags().StringVarP(&collectionID, "collection", "c", "", "Collection ID to store the file in (defaults to the configured default collection)")
 	cmd.Flags().StringVarP(&name, "name", "n", "", "Custom name for the file (defaults to filename)")
 	cmd.Flags().StringVar(&storageMode, "storage-mode", dom_file.StorageModeHybrid,
 		"Storage mode: encrypted_only, hybrid, decrypted_only")
@@ -181,8 +189,7 @@ Examples:
 	cmd.Flags().BoolVar(&localOnly, "local-only", false, "Add locally without uploading to cloud")
 
 	// Mark required flags
-	cmd.MarkFlagRequired("collection")
-	cmd.MarkFlagRequired("password")
+	promptpassword.AddStdinFlag(cmd, &password, true)
 
 	return cmd
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filesyncer"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/localfile"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
 )
 
 // deleteFileCmd creates a unified command for deleting files
@@ -223,6 +224,7 @@ Examples:
 	cmd.Flags().BoolVar(&cloudOnly, "cloud-only", false, "Delete only cloud copy (keep local)")
 	cmd.Flags().StringVar(&password, "password", "", "Your account password (required for cloud operations)")
 	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt")
+	promptpassword.AddStdinFlag(cmd, &password, false)
 
 	return cmd
 }
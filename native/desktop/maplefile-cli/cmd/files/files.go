@@ -21,14 +21,19 @@ func FilesCmd(
 	logger *zap.Logger,
 	addService localfile.LocalFileAddService,
 	fileUploadService fileupload.FileUploadService,
+	batchFileUploadService fileupload.BatchFileUploadService,
 	listService localfile.ListService,
 	localOnlyDeleteService localfile.LocalOnlyDeleteService,
 	downloadService filedownload.DownloadService,
 	offloadService filesyncer.OffloadService,
 	onloadService filesyncer.OnloadService,
+	batchOnloadService filesyncer.BatchOnloadService,
 	cloudOnlyDeleteService filesyncer.CloudOnlyDeleteService,
+	storageUsageService filesyncer.StorageUsageService,
 	lockService localfile.LockService,
 	unlockService localfile.UnlockService,
+	moveFileService localfile.MoveService,
+	findSimilarService localfile.FindSimilarService,
 	getFileUseCase uc_file.GetFileUseCase,
 	getUserByIsLoggedInUseCase uc_user.GetByIsLoggedInUseCase,
 	getCollectionUseCase uc_collection.GetCollectionUseCase,
@@ -43,10 +48,13 @@ All file operations use E2EE to ensure your data remains private and secure.
 Files can be stored locally, in the cloud, or both depending on your needs.
 
 Available commands:
-  add      Add files to collections (auto-uploads by default)
-  list     List files in collections
-  get      Download and decrypt files
-  delete   Delete files (local, cloud, or both)
+  add           Add files to collections (auto-uploads by default)
+  upload        Upload local only files to the cloud
+  list          List files in collections
+  get           Download and decrypt files
+  delete        Delete files (local, cloud, or both)
+  move          Move files to another collection
+  find-similar  Find images which look like an image
 
 Examples:
   # Add a file to a collection (uploads automatically)
@@ -61,9 +69,15 @@ Examples:
   # Delete a file completely
   maplefile-cli files delete FILE_ID --password PASSWORD
 
+  # Move a file to another collection
+  maplefile-cli files move FILE_ID --collection COLLECTION_ID --password PASSWORD
+
   # Add file locally only (upload later)
   maplefile-cli files add "/path/to/file.txt" --collection COLLECTION_ID --local-only --password PASSWORD
 
+  # Upload files which were added locally only
+  maplefile-cli files upload FILE_ID FILE_ID --password PASSWORD
+
 For detailed help: maplefile-cli files COMMAND --help
 `,
 		Run: func(cmd *cobra.Command, args []string) {
@@ -73,10 +87,13 @@ For detailed help: maplefile-cli files COMMAND --help
 
 	// Core file management commands (clean and simple)
 	cmd.AddCommand(addFileCmd(logger, addService, fileUploadService))
+	cmd.AddCommand(uploadFilesCmd(logger, batchFileUploadService))
 	cmd.AddCommand(listFilesCmd(logger, listService))
 	cmd.AddCommand(getFileCmd(logger, downloadService, onloadService))
 	cmd.AddCommand(deleteFileCmd(logger, localOnlyDeleteService, cloudOnlyDeleteService))
-	cmd.AddCommand(filesync.FileSyncCmd(offloadService, onloadService, cloudOnlyDeleteService, logger))
+	cmd.AddCommand(moveFileCmd(logger, moveFileService))
+	cmd.AddCommand(findSimilarFilesCmd(logger, findSimilarService))
+	cmd.AddCommand(filesync.FileSyncCmd(offloadService, onloadService, batchOnloadService, cloudOnlyDeleteService, storageUsageService, logger))
 	cmd.AddCommand(misc.MiscFilesCmd(
 		logger,
 		localOnlyDeleteService,
@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filesyncer"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
 )
 
 // cloudOnlyDeleteCmd creates a command for deleting files from cloud storage
@@ -132,7 +133,7 @@ Examples:
 	cmd.Flags().StringVarP(&fileID, "file-id", "f", "", "ID of the file to delete from cloud (required)")
 	cmd.MarkFlagRequired("file-id")
 	cmd.Flags().StringVar(&password, "password", "", "Your account password (required for authentication)")
-	cmd.MarkFlagRequired("password")
+	promptpassword.AddStdinFlag(cmd, &password, true)
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Check file status without actually deleting it")
 
 	return cmd
@@ -12,7 +12,9 @@ import (
 func FileSyncCmd(
 	offloadService filesyncer.OffloadService,
 	onloadService filesyncer.OnloadService,
+	batchOnloadService filesyncer.BatchOnloadService,
 	cloudOnlyDeleteService filesyncer.CloudOnlyDeleteService,
+	storageUsageService filesyncer.StorageUsageService,
 	logger *zap.Logger,
 ) *cobra.Command {
 	var cmd = &cobra.Command{
@@ -27,7 +29,8 @@ func FileSyncCmd(
 
 	// Add file sync subcommands
 	cmd.AddCommand(offloadCmd(offloadService, logger))
-	cmd.AddCommand(onloadCmd(onloadService, logger))
+	cmd.AddCommand(onloadCmd(onloadService, storageUsageService, logger))
+	cmd.AddCommand(onloadBatchCmd(batchOnloadService, storageUsageService, logger))
 	cmd.AddCommand(cloudOnlyDeleteCmd(cloudOnlyDeleteService, logger))
 
 	return cmd
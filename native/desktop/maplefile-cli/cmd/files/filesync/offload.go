@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filesyncer"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
 )
 
 // offloadCmd creates a command for offloading files to cloud storage
@@ -92,7 +93,7 @@ Examples:
 	cmd.Flags().StringVarP(&fileID, "file-id", "f", "", "ID of the file to offload (required)")
 	cmd.MarkFlagRequired("file-id")
 	cmd.Flags().StringVar(&password, "password", "", "Your account password (required for E2EE)")
-	cmd.MarkFlagRequired("password")
+	promptpassword.AddStdinFlag(cmd, &password, true)
 
 	return cmd
 }
@@ -2,7 +2,10 @@
 package filesync
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/gocql/gocql"
@@ -10,15 +13,19 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filesyncer"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
 )
 
 // onloadCmd creates a command for onloading files from cloud storage
 func onloadCmd(
 	onloadService filesyncer.OnloadService,
+	storageUsageService filesyncer.StorageUsageService,
 	logger *zap.Logger,
 ) *cobra.Command {
 	var fileID string
 	var password string
+	var onExisting string
+	var outputFormat string
 
 	var cmd = &cobra.Command{
 		Use:   "onload",
@@ -34,11 +41,31 @@ The file must be in cloud-only status to be eligible for onloading.
 This command uses the integrated download service to handle all E2EE
 decryption automatically.
 
+If a local copy of the file already exists, --on-existing decides what
+happens to it:
+- overwrite: download the file again and replace the local copy
+- skip: keep the local copy if it passes integrity verification, and only
+  download the file again if it is corrupted
+- fail: abort without downloading
+- keep-both: keep the local copy and save the downloaded file under a new
+  name
+Without the flag, the local copy is overwritten.
+
+With --output json the result is printed as a JSON object with the file ID,
+the previous and new sync status, the decrypted path, the downloaded size and
+a message, or an error, so scripts can capture where the file landed.
+
 Examples:
   maplefile-cli filesync onload --file-id 507f1f77bcf86cd799439011 --password 1234567890
+  maplefile-cli filesync onload --file-id 507f1f77bcf86cd799439011 --password 1234567890 --on-existing skip
+  maplefile-cli filesync onload --file-id 507f1f77bcf86cd799439011 --password 1234567890 --output json
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			// Validate required fields
+			if outputFormat != "text" && outputFormat != "json" {
+				fmt.Printf("❌ Error: Unsupported output format: %s (use text or json)\n", outputFormat)
+				return
+			}
 			if fileID == "" {
 				fmt.Println("❌ Error: File ID is required.")
 				fmt.Println("Use --file-id flag to specify the file to onload.")
@@ -62,20 +89,36 @@ Examples:
 			input := &filesyncer.OnloadInput{
 				FileID:       fileObjectID,
 				UserPassword: password,
+				OnExisting:   onExisting,
 			}
 
 			// Execute onload
+			if outputFormat == "json" {
+				output, err := onloadService.Onload(cmd.Context(), input)
+				printOnloadJSON(newOnloadResult(fileObjectID, output, err))
+				if err == nil {
+					checkStorageUsage(cmd.Context(), os.Stderr, storageUsageService, password)
+				}
+				return
+			}
+
 			fmt.Printf("🔄 Onloading file: %s\n", fileID)
 			fmt.Println("📡 Downloading and decrypting file from cloud...")
 
 			output, err := onloadService.Onload(cmd.Context(), input)
 			if err != nil {
-				if strings.Contains(err.Error(), "incorrect password") {
+				if errors.Is(err, filesyncer.ErrDecryptionFailed) {
 					fmt.Printf("❌ Error: Incorrect password. Please check your password and try again.\n")
-				} else if strings.Contains(err.Error(), "not cloud-only") {
+				} else if errors.Is(err, filesyncer.ErrNotCloudOnly) {
 					fmt.Printf("❌ Error: File is not in cloud-only mode. Only cloud-only files can be onloaded.\n")
-				} else if strings.Contains(err.Error(), "file not found") {
+				} else if errors.Is(err, filesyncer.ErrFileNotFound) {
 					fmt.Printf("❌ Error: File not found. Please check the file ID and try again.\n")
+				} else if errors.Is(err, filesyncer.ErrDownloadFailed) {
+					fmt.Printf("❌ Error: Could not download the file from the cloud. Please check your connection and try again.\n")
+				} else if errors.Is(err, filesyncer.ErrLocalCopyExists) {
+					fmt.Printf("❌ Error: A local copy of the file already exists. Use --on-existing overwrite, skip or keep-both to onload it anyway.\n")
+				} else if errors.Is(err, filesyncer.ErrDiskWrite) {
+					fmt.Printf("❌ Error: Could not save the file locally. Please check your available disk space and permissions.\n")
 				} else if strings.Contains(err.Error(), "permission") {
 					fmt.Printf("❌ Error: You don't have permission to access this file.\n")
 				} else {
@@ -94,6 +137,8 @@ Examples:
 
 			fmt.Printf("\n🎉 Your file is now available locally!\n")
 			fmt.Printf("🔐 The file has been downloaded and decrypted using E2EE.\n")
+
+			checkStorageUsage(cmd.Context(), os.Stdout, storageUsageService, password)
 		},
 	}
 
@@ -101,7 +146,47 @@ Examples:
 	cmd.Flags().StringVarP(&fileID, "file-id", "f", "", "ID of the file to onload (required)")
 	cmd.MarkFlagRequired("file-id")
 	cmd.Flags().StringVar(&password, "password", "", "Your account password (required for E2EE)")
-	cmd.MarkFlagRequired("password")
+	cmd.Flags().StringVar(&onExisting, "on-existing", "", "What to do if a local copy already exists: overwrite, skip, fail or keep-both")
+	cmd.Flags().StringVar(&outputFormat, "output", "text", "Output format (text or json)")
+	promptpassword.AddStdinFlag(cmd, &password, true)
 
 	return cmd
 }
+
+// onloadResult is a file of the JSON output of the onload commands
+type onloadResult struct {
+	FileID         gocql.UUID `json:"file_id"`
+	PreviousStatus string     `json:"previous_status,omitempty"`
+	NewStatus      string     `json:"new_status,omitempty"`
+	DecryptedPath  string     `json:"decrypted_path,omitempty"`
+	DownloadedSize int64      `json:"downloaded_size"`
+	Message        string     `json:"message,omitempty"`
+	Error          string     `json:"error,omitempty"`
+}
+
+// newOnloadResult converts the outcome of onloading a file, statuses are written by name rather than number
+func newOnloadResult(fileID gocql.UUID, output *filesyncer.OnloadOutput, err error) *onloadResult {
+	result := &onloadResult{FileID: fileID}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if output != nil {
+		result.PreviousStatus = output.PreviousStatus.String()
+		result.NewStatus = output.NewStatus.String()
+		result.DecryptedPath = output.DecryptedPath
+		result.DownloadedSize = output.DownloadedSize
+		result.Message = output.Message
+	}
+	return result
+}
+
+// printOnloadJSON prints the JSON output of the onload commands
+func printOnloadJSON(v any) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		fmt.Printf("❌ Error encoding output: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
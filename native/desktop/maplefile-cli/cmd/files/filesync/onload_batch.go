@@ -0,0 +1,162 @@
+// native/desktop/maplefile-cli/cmd/filesync/onload_batch.go
+package filesync
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/gocql/gocql"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filesyncer"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
+)
+
+// onloadBatchCmd creates a command for onloading many files from cloud storage concurrently
+func onloadBatchCmd(
+	batchOnloadService filesyncer.BatchOnloadService,
+	storageUsageService filesyncer.StorageUsageService,
+	logger *zap.Logger,
+) *cobra.Command {
+	var fileIDs []string
+	var collectionID string
+	var password string
+	var concurrency int
+	var outputFormat string
+
+	var cmd = &cobra.Command{
+		Use:   "onload-batch",
+		Short: "Onload many cloud-only files to local storage",
+		Long: `
+Onload many cloud-only files to local storage at once, either by listing the
+files or by onloading every cloud-only file of a collection.
+
+Files are downloaded and decrypted in parallel. Use --concurrency (` + strconv.Itoa(config.MinConcurrentOnloads) + `-` + strconv.Itoa(config.MaxConcurrentOnloads) + `) to
+control how many files are processed at the same time; by default the value
+from your configuration is used, which defaults to your CPU count and can be
+changed with 'maplefile-cli config concurrent-onloads'. Higher values help on
+fast networks with many small files, but every worker holds a decrypted file in
+memory and writes it to disk, so on slow disks or with large files a lower value
+is usually faster.
+
+With --output json a JSON array is printed with one object per file, holding
+the file ID, the previous and new sync status, the decrypted path, the
+downloaded size and a message, or the error if the file failed to onload.
+
+Examples:
+  # Onload two files
+  maplefile-cli files filesync onload-batch --file-id FILE_ID_1 --file-id FILE_ID_2 --password 1234567890
+
+  # Onload every cloud-only file of a collection using 8 workers
+  maplefile-cli files filesync onload-batch --collection COLLECTION_ID --concurrency 8 --password 1234567890
+
+  # Print where every file of a collection landed as JSON
+  maplefile-cli files filesync onload-batch --collection COLLECTION_ID --password 1234567890 --output json
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			// Validate required fields
+			if outputFormat != "text" && outputFormat != "json" {
+				fmt.Printf("❌ Error: Unsupported output format: %s (use text or json)\n", outputFormat)
+				return
+			}
+			if len(fileIDs) == 0 && collectionID == "" {
+				fmt.Println("❌ Error: File IDs or a collection ID are required.")
+				fmt.Println("Use --file-id or --collection flags to specify the files to onload.")
+				return
+			}
+			if password == "" {
+				fmt.Println("❌ Error: Password is required for E2EE operations.")
+				fmt.Println("Use --password flag to specify your account password.")
+				return
+			}
+			if cmd.Flags().Changed("concurrency") && (concurrency < config.MinConcurrentOnloads || concurrency > config.MaxConcurrentOnloads) {
+				fmt.Printf("❌ Error: Concurrency must be between %d and %d.\n", config.MinConcurrentOnloads, config.MaxConcurrentOnloads)
+				return
+			}
+
+			input := &filesyncer.BatchOnloadInput{
+				UserPassword: password,
+				Concurrency:  concurrency,
+			}
+			if collectionID != "" {
+				collectionObjectID, err := gocql.ParseUUID(collectionID)
+				if err != nil {
+					fmt.Printf("❌ Error: Invalid collection ID format: %v\n", err)
+					return
+				}
+				input.CollectionID = &collectionObjectID
+			} else {
+				for _, fileID := range fileIDs {
+					fileObjectID, err := gocql.ParseUUID(fileID)
+					if err != nil {
+						fmt.Printf("❌ Error: Invalid file ID format %s: %v\n", fileID, err)
+						return
+					}
+					input.FileIDs = append(input.FileIDs, fileObjectID)
+				}
+			}
+
+			if outputFormat != "json" {
+				fmt.Println("📡 Downloading and decrypting files from cloud...")
+			}
+
+			output, err := batchOnloadService.OnloadBatch(cmd.Context(), input)
+			if err != nil {
+				fmt.Printf("❌ Error onloading files: %v\n", err)
+				return
+			}
+
+			if outputFormat == "json" {
+				results := make([]*onloadResult, 0, len(output.Results))
+				for _, result := range output.Results {
+					results = append(results, newOnloadResult(result.FileID, result.Output, result.Error))
+				}
+				printOnloadJSON(results)
+				if output.SuccessCount > 0 {
+					checkStorageUsage(cmd.Context(), os.Stderr, storageUsageService, password)
+				}
+				return
+			}
+
+			if len(output.Results) == 0 {
+				fmt.Println("ℹ️  No cloud-only files to onload.")
+				return
+			}
+
+			for _, result := range output.Results {
+				if result.Error != nil {
+					fmt.Printf("   ❌ %s: %v\n", result.FileID.String(), result.Error)
+					continue
+				}
+				fmt.Printf("   ✅ %s → %s\n", result.FileID.String(), result.Output.DecryptedPath)
+			}
+
+			fmt.Printf("\n📊 Onloaded %d of %d file(s) using %d worker(s)\n",
+				output.SuccessCount, len(output.Results), output.Concurrency)
+			if output.FailureCount > 0 {
+				fmt.Printf("⚠️  %d file(s) failed to onload.\n", output.FailureCount)
+			}
+			if output.SuccessCount > 0 {
+				checkStorageUsage(cmd.Context(), os.Stdout, storageUsageService, password)
+			}
+
+			logger.Info("Batch onload completed",
+				zap.Int("successCount", output.SuccessCount),
+				zap.Int("failureCount", output.FailureCount),
+				zap.Int("concurrency", output.Concurrency))
+		},
+	}
+
+	// Define command flags
+	cmd.Flags().StringArrayVarP(&fileIDs, "file-id", "f", nil, "ID of a file to onload (repeatable)")
+	cmd.Flags().StringVar(&collectionID, "collection", "", "Onload every cloud-only file of this collection")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 0, fmt.Sprintf("Number of files to onload in parallel (%d-%d, defaults to configured value)", config.MinConcurrentOnloads, config.MaxConcurrentOnloads))
+	cmd.Flags().StringVar(&password, "password", "", "Your account password (required for E2EE)")
+	cmd.Flags().StringVar(&outputFormat, "output", "text", "Output format (text or json)")
+	promptpassword.AddStdinFlag(cmd, &password, true)
+
+	return cmd
+}
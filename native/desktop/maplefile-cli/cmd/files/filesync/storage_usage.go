@@ -0,0 +1,44 @@
+// native/desktop/maplefile-cli/cmd/filesync/storage_usage.go
+package filesync
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/repo/filedto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filesyncer"
+)
+
+// checkStorageUsage warns when the onloaded files exceed the storage quota, listing the least
+// recently accessed files to offload or the files offloaded automatically. The warnings are written
+// to w so commands printing JSON can keep them off the standard output.
+func checkStorageUsage(ctx context.Context, w io.Writer, storageUsageService filesyncer.StorageUsageService, password string) {
+	output, err := storageUsageService.Check(ctx, &filesyncer.StorageUsageInput{UserPassword: password})
+	if err != nil {
+		fmt.Fprintf(w, "⚠️  Could not check the storage quota: %v\n", err)
+		return
+	}
+
+	if len(output.Offloaded) > 0 {
+		fmt.Fprintf(w, "\n🗑️  Auto offloaded %d file(s) to stay under the %s storage quota:\n",
+			len(output.Offloaded), filedto.FormatFileSize(output.QuotaBytes))
+		for _, file := range output.Offloaded {
+			fmt.Fprintf(w, "   • %s (%s)\n", file.Name, filedto.FormatFileSize(file.LocalSize))
+		}
+	}
+	if !output.OverQuota {
+		return
+	}
+
+	fmt.Fprintf(w, "\n⚠️  Local files use %s, over the %s storage quota.\n",
+		filedto.FormatFileSize(output.UsedBytes), filedto.FormatFileSize(output.QuotaBytes))
+	if len(output.Suggested) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "💡 Consider offloading these least recently accessed files:")
+	for _, file := range output.Suggested {
+		fmt.Fprintf(w, "   • %s (%s) - maplefile-cli files filesync offload --file-id %s\n",
+			file.Name, filedto.FormatFileSize(file.LocalSize), file.FileID.String())
+	}
+}
@@ -0,0 +1,93 @@
+// cmd/files/find_similar.go - Find images which look like a file
+package files
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/localfile"
+)
+
+// findSimilarFilesCmd creates a command for finding images visually similar to a file
+func findSimilarFilesCmd(
+	logger *zap.Logger,
+	findSimilarService localfile.FindSimilarService,
+) *cobra.Command {
+	var maxDistance int
+
+	var cmd = &cobra.Command{
+		Use:   "find-similar FILE_ID",
+		Short: "Find images which look like an image",
+		Long: `
+Find the images in your library which look like the given image, even when they
+were resized, re-encoded or saved in a different format.
+
+Similarity is measured by comparing perceptual hashes. They are computed for
+JPEG, PNG and GIF images when they are added with perceptual hashing enabled:
+  maplefile-cli config perceptual-hash --enable on
+
+The perceptual hash is computed on this device from the unencrypted image and is
+stored in the encrypted file metadata, so the server only ever sees it
+encrypted. The comparison runs locally against the files synced to this device.
+
+The distance is the number of differing bits between two hashes, from 0 for
+images which look the same to 64. Images at a distance of 10 or less usually
+look alike.
+
+Examples:
+  # Find images which look like a photo
+  maplefile-cli files find-similar 507f1f77bcf86cd799439011
+
+  # Only report near-identical images
+  maplefile-cli files find-similar 507f1f77bcf86cd799439011 --max-distance 4
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			fileID, err := gocql.ParseUUID(args[0])
+			if err != nil {
+				fmt.Println("❌ Error: File ID not correct format.")
+				return
+			}
+
+			output, err := findSimilarService.FindSimilar(cmd.Context(), &localfile.FindSimilarInput{
+				FileID:      fileID,
+				MaxDistance: maxDistance,
+			})
+			if err != nil {
+				fmt.Printf("❌ Error finding similar images: %v\n", err)
+				if strings.Contains(err.Error(), "no perceptual hash") {
+					fmt.Printf("💡 Tip: Enable perceptual hashing with: maplefile-cli config perceptual-hash --enable on\n")
+				}
+				logger.Debug("Failed to find similar images",
+					zap.String("fileID", fileID.String()),
+					zap.Error(err))
+				return
+			}
+
+			fmt.Printf("🖼️  Images similar to %s (compared with %d image(s))\n\n", displayFileName(output.File), output.Compared)
+			if len(output.Similar) == 0 {
+				fmt.Println("📭 No similar images found.")
+				return
+			}
+
+			fmt.Printf("%-10s %-30s %-12s %s\n", "DISTANCE", "NAME", "SIZE", "ID")
+			fmt.Println(strings.Repeat("-", 90))
+			for _, similar := range output.Similar {
+				name := displayFileName(similar.File)
+				if len(name) > 28 {
+					name = name[:25] + "..."
+				}
+				fmt.Printf("%-10d %-30s %-12s %s\n",
+					similar.Distance, name, formatFileSize(similar.File.FileSize), similar.File.ID.String())
+			}
+		},
+	}
+
+	cmd.Flags().IntVar(&maxDistance, "max-distance", localfile.DefaultSimilarityDistance, "Largest perceptual hash distance reported as similar (0-64)")
+
+	return cmd
+}
@@ -2,6 +2,7 @@
 package files
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -19,6 +20,8 @@ func listFilesCmd(
 	listService localfile.ListService,
 ) *cobra.Command {
 	var collectionID string
+	var status string
+	var outputFormat string
 	var verbose bool
 
 	var cmd = &cobra.Command{
@@ -28,7 +31,14 @@ func listFilesCmd(
 List files stored in your collections.
 
 By default, lists all files across all collections. Use --collection to filter
-by a specific collection.
+by a specific collection and --status to filter by sync status:
+  local-only         Added locally, not uploaded yet
+  cloud-only         Only in the cloud, can be onloaded
+  synced             Both locally and in the cloud
+  modified-locally   Has local changes which are not uploaded yet
+
+Listing cloud-only files shows which files can be onloaded with
+'maplefile-cli files filesync onload-batch'.
 
 Examples:
   # List all files across all collections
@@ -39,53 +49,151 @@ Examples:
 
   # List with detailed information
   maplefile-cli files list --collection 507f1f77bcf86cd799439011 --verbose
+
+  # List the files which can be onloaded, as JSON
+  maplefile-cli files list --status cloud-only --output json
 `,
 		Run: func(cmd *cobra.Command, args []string) {
-			if collectionID != "" {
-				// Convert collection ID
-				collectionObjectID, err := gocql.ParseUUID(collectionID)
-				if err != nil {
-					fmt.Printf("❌ Error: Invalid collection ID format: %v\n", err)
-					return
-				}
+			if outputFormat != "text" && outputFormat != "json" {
+				fmt.Printf("❌ Error: Unsupported output format: %s (use text or json)\n", outputFormat)
+				return
+			}
+			if status != "" || collectionID == "" || outputFormat == "json" {
+				listFilteredFiles(cmd, listService, collectionID, status, outputFormat, verbose)
+				return
+			}
 
-				// List files in specific collection
-				input := &localfile.ListInput{
-					CollectionID: collectionObjectID,
-				}
+			// Convert collection ID
+			collectionObjectID, err := gocql.ParseUUID(collectionID)
+			if err != nil {
+				fmt.Printf("❌ Error: Invalid collection ID format: %v\n", err)
+				return
+			}
 
-				fmt.Printf("📂 Listing files in collection: %s\n\n", collectionObjectID.String())
-
-				output, err := listService.ListByCollection(cmd.Context(), input)
-				if err != nil {
-					fmt.Printf("❌ Error listing files: %v\n", err)
-					if strings.Contains(err.Error(), "invalid collection ID format") {
-						fmt.Printf("💡 Tip: Check the collection ID format.\n")
-					} else if strings.Contains(err.Error(), "collection not found") {
-						fmt.Printf("💡 Tip: Check collection exists with: maplefile-cli collections list\n")
-					}
-					return
-				}
+			// List files in specific collection
+			input := &localfile.ListInput{
+				CollectionID: collectionObjectID,
+			}
 
-				displayFileResults(output.Files, output.Count, verbose, collectionID)
-			} else {
-				// List all files (would need service enhancement)
-				fmt.Printf("📋 Listing all files across collections...\n\n")
-				fmt.Printf("⚠️  Listing all files requires service enhancement.\n")
-				fmt.Printf("💡 For now, specify a collection: maplefile-cli files list --collection COLLECTION_ID\n")
-				fmt.Printf("💡 View collections: maplefile-cli collections list\n")
+			fmt.Printf("📂 Listing files in collection: %s\n\n", collectionObjectID.String())
+
+			output, err := listService.ListByCollection(cmd.Context(), input)
+			if err != nil {
+				fmt.Printf("❌ Error listing files: %v\n", err)
+				if strings.Contains(err.Error(), "invalid collection ID format") {
+					fmt.Printf("💡 Tip: Check the collection ID format.\n")
+				} else if strings.Contains(err.Error(), "collection not found") {
+					fmt.Printf("💡 Tip: Check collection exists with: maplefile-cli collections list\n")
+				}
 				return
 			}
+
+			displayFileResults(output.Files, output.Count, verbose, collectionID)
 		},
 	}
 
 	// Define flags
 	cmd.Flags().StringVarP(&collectionID, "collection", "c", "", "Collection ID to list files from")
+	cmd.Flags().StringVarP(&status, "status", "s", "", "Only list files with this sync status (local-only, cloud-only, synced or modified-locally)")
+	cmd.Flags().StringVar(&outputFormat, "output", "text", "Output format (text or json)")
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show detailed file information")
 
 	return cmd
 }
 
+// fileListEntry is a file of the JSON output of the list command
+type fileListEntry struct {
+	ID           gocql.UUID `json:"id"`
+	Name         string     `json:"name"`
+	Size         int64      `json:"size"`
+	MimeType     string     `json:"mime_type"`
+	CollectionID gocql.UUID `json:"collection_id"`
+	SyncStatus   string     `json:"sync_status"`
+}
+
+// listFilteredFiles lists files across collections, optionally filtered by collection and sync status
+func listFilteredFiles(cmd *cobra.Command, listService localfile.ListService, collectionID, status, outputFormat string, verbose bool) {
+	input := &localfile.ListFilteredInput{}
+	if collectionID != "" {
+		collectionObjectID, err := gocql.ParseUUID(collectionID)
+		if err != nil {
+			fmt.Printf("❌ Error: Invalid collection ID format: %v\n", err)
+			return
+		}
+		input.CollectionID = &collectionObjectID
+	}
+	if status != "" {
+		syncStatus, err := dom_file.ParseSyncStatus(status)
+		if err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			return
+		}
+		input.SyncStatus = &syncStatus
+	}
+
+	output, err := listService.ListFiltered(cmd.Context(), input)
+	if err != nil {
+		fmt.Printf("❌ Error listing files: %v\n", err)
+		return
+	}
+
+	if outputFormat == "json" {
+		entries := make([]fileListEntry, 0, len(output.Files))
+		for _, file := range output.Files {
+			entries = append(entries, fileListEntry{
+				ID:           file.ID,
+				Name:         displayFileName(file),
+				Size:         file.FileSize,
+				MimeType:     file.MimeType,
+				CollectionID: file.CollectionID,
+				SyncStatus:   file.SyncStatus.String(),
+			})
+		}
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			fmt.Printf("❌ Error encoding files: %v\n", err)
+			return
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	if output.Count == 0 {
+		fmt.Println("📭 No matching files found.")
+		return
+	}
+
+	fmt.Printf("📋 Found %d file(s):\n\n", output.Count)
+	if verbose {
+		displayDetailedFileList(output.Files)
+	} else {
+		fmt.Printf("%-8s %-30s %-12s %-15s %-36s %s\n", "STATUS", "NAME", "SIZE", "SYNC", "COLLECTION", "ID")
+		fmt.Println(strings.Repeat("-", 120))
+		for _, file := range output.Files {
+			name := displayFileName(file)
+			if len(name) > 28 {
+				name = name[:25] + "..."
+			}
+			fmt.Printf("%-8s %-30s %-12s %-15s %-36s %s\n",
+				getSyncStatusIcon(file.SyncStatus), name, formatFileSize(file.FileSize),
+				getSyncStatusString(file.SyncStatus), file.CollectionID.String(), file.ID.String())
+		}
+	}
+
+	if input.SyncStatus != nil && *input.SyncStatus == dom_file.SyncStatusCloudOnly {
+		fmt.Printf("\n💡 Onload the files you need:\n")
+		fmt.Printf("   maplefile-cli files filesync onload-batch --file-id FILE_ID --password PASSWORD\n")
+	}
+}
+
+// displayFileName returns the decrypted name of the file, or a placeholder while its metadata isn't decrypted
+func displayFileName(file *dom_file.File) string {
+	if file.Name == "" {
+		return "[Encrypted]"
+	}
+	return file.Name
+}
+
 // displayFileResults shows file listing results
 func displayFileResults(files []*dom_file.File, count int, verbose bool, collectionID string) {
 	if count == 0 {
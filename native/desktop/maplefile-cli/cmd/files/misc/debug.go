@@ -2,7 +2,6 @@
 package misc
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/gocql/gocql"
@@ -13,6 +12,7 @@ import (
 	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
 	uc_user "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/user"
 	pkg_crypto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
 )
 
 // debugE2EECmd creates a command for debugging E2EE key chain issues
@@ -30,7 +30,7 @@ func debugE2EECmd(
 		Short: "Debug E2EE key chain decryption",
 		Long:  `Debug tool to test E2EE key chain decryption step-by-step.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			if fileID == "" {
 				fmt.Println("❌ Error: File ID is required.")
@@ -164,7 +164,7 @@ func debugE2EECmd(
 	cmd.Flags().StringVarP(&fileID, "file-id", "f", "", "ID of the file to debug (required)")
 	cmd.MarkFlagRequired("file-id")
 	cmd.Flags().StringVar(&password, "password", "", "Your account password (required)")
-	cmd.MarkFlagRequired("password")
+	promptpassword.AddStdinFlag(cmd, &password, true)
 
 	return cmd
 }
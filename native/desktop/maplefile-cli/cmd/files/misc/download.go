@@ -2,7 +2,6 @@
 package misc
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,6 +13,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filedownload"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
 )
 
 // downloadFileCmd creates a command for downloading and decrypting a file from the cloud
@@ -46,7 +46,7 @@ Examples:
   maplefile-cli files download --file-id 507f1f77bcf86cd799439011 --duration 2h --password 1234567890
 `,
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			// Validate required inputs
 			if fileID == "" {
@@ -191,7 +191,7 @@ Examples:
 	cmd.MarkFlagRequired("file-id")
 	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output directory or file path (defaults to current directory with original filename)")
 	cmd.Flags().StringVar(&password, "password", "", "Your account password (required for E2EE decryption)")
-	cmd.MarkFlagRequired("password")
+	promptpassword.AddStdinFlag(cmd, &password, true)
 	cmd.Flags().DurationVar(&urlDuration, "duration", 1*time.Hour, "Duration for presigned URLs (e.g., 1h, 30m)")
 
 	return cmd
@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/localfile"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
 )
 
 // lockFileCmd creates a command for locking a file (encrypted-only mode)
@@ -104,7 +105,7 @@ Examples:
 	cmd.Flags().StringVarP(&fileID, "file-id", "f", "", "ID of the file to lock (required)")
 	cmd.MarkFlagRequired("file-id")
 	cmd.Flags().StringVar(&password, "password", "", "Your account password (required for E2EE)")
-	cmd.MarkFlagRequired("password")
+	promptpassword.AddStdinFlag(cmd, &password, true)
 
 	return cmd
 }
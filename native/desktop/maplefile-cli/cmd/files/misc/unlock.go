@@ -11,6 +11,7 @@ import (
 
 	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/localfile"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
 )
 
 // unlockFileCmd creates a command for unlocking a file
@@ -136,7 +137,7 @@ Examples:
 	cmd.Flags().StringVarP(&fileID, "file-id", "f", "", "ID of the file to unlock (required)")
 	cmd.MarkFlagRequired("file-id")
 	cmd.Flags().StringVar(&password, "password", "", "Your account password (required for E2EE)")
-	cmd.MarkFlagRequired("password")
+	promptpassword.AddStdinFlag(cmd, &password, true)
 	cmd.Flags().StringVarP(&storageMode, "mode", "m", "hybrid", "Storage mode: 'decrypted_only' or 'hybrid' (default: hybrid)")
 
 	return cmd
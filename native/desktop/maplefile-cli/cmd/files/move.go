@@ -0,0 +1,104 @@
+// cmd/files/move.go - Move a file to another collection
+package files
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/localfile"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
+)
+
+// moveFileCmd creates a command for moving a file to another collection
+func moveFileCmd(
+	logger *zap.Logger,
+	moveFileService localfile.MoveService,
+) *cobra.Command {
+	var collectionID string
+	var password string
+
+	var cmd = &cobra.Command{
+		Use:   "move FILE_ID",
+		Short: "Move a file to another collection",
+		Long: `
+Move a file from its current collection into another collection.
+
+The file key is re-encrypted with the target collection's key on this device,
+so the file content is never decrypted and the server never sees any keys.
+You need write access to both the current and the target collection.
+
+Files which exist in the cloud are moved there first, the cloud checks your
+access to both collections as well. Files which were never uploaded are only
+moved on this device.
+
+Examples:
+  # Move a file to another collection
+  maplefile-cli files move 507f1f77bcf86cd799439011 --collection 507f1f77bcf86cd799439012 --password mypass
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			fileID, err := gocql.ParseUUID(args[0])
+			if err != nil {
+				fmt.Println("❌ Error: File ID not correct format.")
+				return
+			}
+			if collectionID == "" {
+				fmt.Println("❌ Error: Target collection ID is required.")
+				fmt.Println("Use --collection flag to specify the collection to move the file into.")
+				return
+			}
+			targetCollectionID, err := gocql.ParseUUID(collectionID)
+			if err != nil {
+				fmt.Println("❌ Error: Collection ID not correct format.")
+				return
+			}
+			if password == "" {
+				fmt.Println("❌ Error: Password is required for E2EE operations.")
+				fmt.Println("Use --password flag to specify your account password.")
+				return
+			}
+
+			fmt.Printf("📦 Moving file %s to collection %s...\n", fileID.String(), targetCollectionID.String())
+
+			output, err := moveFileService.Move(cmd.Context(), &localfile.MoveInput{
+				FileID:             fileID,
+				TargetCollectionID: targetCollectionID,
+				Password:           password,
+			})
+			if err != nil {
+				if strings.Contains(err.Error(), "incorrect password") || strings.Contains(err.Error(), "failed to decrypt") {
+					fmt.Printf("❌ Error: Failed to decrypt E2EE keys. Please verify your password.\n")
+				} else if strings.Contains(err.Error(), "permission") {
+					fmt.Printf("❌ Error: %v\n", err)
+				} else {
+					fmt.Printf("❌ Error moving file: %v\n", err)
+				}
+				logger.Error("Failed to move file",
+					zap.String("fileID", fileID.String()),
+					zap.String("targetCollectionID", targetCollectionID.String()),
+					zap.Error(err))
+				return
+			}
+
+			fmt.Printf("\n✅ File successfully moved!\n")
+			fmt.Printf("🆔 File ID: %s\n", output.File.ID.String())
+			fmt.Printf("📁 Collection: %s → %s\n", output.SourceCollectionID.String(), output.TargetCollectionID.String())
+			fmt.Printf("🔢 Version: %d → %d\n", output.PreviousVersion, output.NewVersion)
+			if output.File.SyncStatus == dom_file.SyncStatusLocalOnly {
+				fmt.Printf("\n💻 The file has not been uploaded, it was only moved on this device.\n")
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&collectionID, "collection", "c", "", "ID of the collection to move the file into (required)")
+	cmd.MarkFlagRequired("collection")
+	cmd.Flags().StringVar(&password, "password", "", "Your account password (required for E2EE)")
+	promptpassword.AddStdinFlag(cmd, &password, true)
+
+	return cmd
+}
@@ -0,0 +1,111 @@
+// cmd/files/upload.go - Upload local only files to the cloud
+package files
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gocql/gocql"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/fileupload"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
+)
+
+// uploadFilesCmd creates a command for uploading many local only files at once
+func uploadFilesCmd(
+	logger *zap.Logger,
+	batchUploadService fileupload.BatchFileUploadService,
+) *cobra.Command {
+	var password string
+	var concurrency int
+
+	var cmd = &cobra.Command{
+		Use:   "upload FILE_ID [FILE_ID...]",
+		Short: "Upload local only files to the cloud",
+		Long: fmt.Sprintf(`
+Upload files which were added with --local-only to the cloud.
+
+Files are uploaded in batches of up to %d files of the same collection. The
+content of a batch is uploaded in parallel and then the whole batch is
+registered in the cloud at once: if any file of a batch fails, none of the
+files of that batch are created in the cloud and they stay local only, so you
+can simply run the command again.
+
+Use --concurrency (`+strconv.Itoa(config.MinConcurrentOnloads)+`-`+strconv.Itoa(config.MaxConcurrentOnloads)+`) to control how many files are
+uploaded at the same time; by default the value from your configuration is
+used, which can be changed with 'maplefile-cli config concurrent-onloads'.
+
+Examples:
+  # Upload one file
+  maplefile-cli files upload 507f1f77bcf86cd799439011 --password mypass
+
+  # Upload many files using 8 workers
+  maplefile-cli files upload FILE_ID_1 FILE_ID_2 FILE_ID_3 --concurrency 8 --password mypass
+`, filedto.MaxFileBatchSize),
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if password == "" {
+				fmt.Println("❌ Error: Password is required for E2EE operations.")
+				fmt.Println("Use --password flag to specify your account password.")
+				return
+			}
+			if cmd.Flags().Changed("concurrency") && (concurrency < config.MinConcurrentOnloads || concurrency > config.MaxConcurrentOnloads) {
+				fmt.Printf("❌ Error: Concurrency must be between %d and %d.\n", config.MinConcurrentOnloads, config.MaxConcurrentOnloads)
+				return
+			}
+
+			fileIDs := make([]gocql.UUID, 0, len(args))
+			for _, arg := range args {
+				fileID, err := gocql.ParseUUID(arg)
+				if err != nil {
+					fmt.Printf("❌ Error: Invalid file ID format %s: %v\n", arg, err)
+					return
+				}
+				fileIDs = append(fileIDs, fileID)
+			}
+
+			fmt.Printf("☁️  Uploading %d file(s) to the cloud...\n", len(fileIDs))
+
+			output, err := batchUploadService.Execute(cmd.Context(), &fileupload.BatchFileUploadInput{
+				FileIDs:      fileIDs,
+				UserPassword: password,
+				Concurrency:  concurrency,
+			})
+			if err != nil {
+				fmt.Printf("❌ Error uploading files: %v\n", err)
+				logger.Error("Failed to upload files", zap.Error(err))
+				return
+			}
+
+			for _, result := range output.Results {
+				if !result.Success {
+					fmt.Printf("   ❌ %s: %v\n", result.FileID.String(), result.Error)
+					continue
+				}
+				fmt.Printf("   ✅ %s (%d bytes)\n", result.FileID.String(), result.FileSizeBytes)
+			}
+
+			fmt.Printf("\n📊 Uploaded %d of %d file(s) in %d batch(es) using %d worker(s)\n",
+				output.SuccessCount, len(output.Results), output.BatchCount, output.Concurrency)
+			if output.FailureCount > 0 {
+				fmt.Printf("⚠️  %d file(s) failed to upload and are still local only.\n", output.FailureCount)
+			}
+
+			logger.Info("Batch upload completed",
+				zap.Int("successCount", output.SuccessCount),
+				zap.Int("failureCount", output.FailureCount),
+				zap.Int("batchCount", output.BatchCount),
+				zap.Int("concurrency", output.Concurrency))
+		},
+	}
+
+	cmd.Flags().IntVar(&concurrency, "concurrency", 0, fmt.Sprintf("Number of files to upload in parallel (%d-%d, defaults to configured value)", config.MinConcurrentOnloads, config.MaxConcurrentOnloads))
+	cmd.Flags().StringVar(&password, "password", "", "Your account password (required for E2EE)")
+	promptpassword.AddStdinFlag(cmd, &password, true)
+
+	return cmd
+}
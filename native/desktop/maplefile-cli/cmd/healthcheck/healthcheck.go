@@ -2,7 +2,6 @@
 package healthcheck
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -22,7 +21,7 @@ func HealthCheckCmd(configService config.ConfigService) *cobra.Command {
 			fmt.Println("Performing health check...")
 
 			// Get the server URL from configuration
-			ctx := context.Background()
+			ctx := cmd.Context()
 			serverURL, err := configService.GetCloudProviderAddress(ctx)
 			if err != nil {
 				fmt.Printf("Error loading configuration: %v\n", err)
@@ -0,0 +1,50 @@
+// native/desktop/maplefile-cli/cmd/journal/journal.go
+package journal
+
+import (
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	svc_journal "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/journal"
+)
+
+// JournalCmd creates the command for inspecting the local operation journal
+func JournalCmd(
+	configService config.ConfigService,
+	showService svc_journal.ShowService,
+	logger *zap.Logger,
+) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "journal",
+		Short: "Inspect the local operation journal",
+		Long: `
+Inspect the local operation journal.
+
+Every create, update, delete, onload and offload of a local file or collection
+is appended to the journal with its time and outcome, including the operations
+which failed. Use it to find out what happened to a file, for example when a
+file seems to have vanished. Once the journal is full the oldest entries are
+rotated out.
+
+Available commands:
+  show     Show journal entries
+  size     Get or set the number of entries kept
+
+Examples:
+  # Show the last 50 journal entries
+  maplefile-cli journal show
+
+  # Show everything that happened to a file
+  maplefile-cli journal show --id FILE_ID --limit 0
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(showJournalCmd(showService, logger))
+	cmd.AddCommand(sizeJournalCmd(configService))
+
+	return cmd
+}
@@ -0,0 +1,132 @@
+// native/desktop/maplefile-cli/cmd/journal/show.go
+package journal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	dom_journal "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
+	svc_journal "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/journal"
+)
+
+// showJournalCmd creates a command for showing the entries of the local operation journal
+func showJournalCmd(
+	showService svc_journal.ShowService,
+	logger *zap.Logger,
+) *cobra.Command {
+	var operation string
+	var entityType string
+	var entityID string
+	var outcome string
+	var since time.Duration
+	var limit int
+
+	var cmd = &cobra.Command{
+		Use:   "show",
+		Short: "Show journal entries",
+		Long: `
+Show the entries of the local operation journal, oldest first.
+
+Filters can be combined, only entries matching all of them are shown.
+
+Examples:
+  # Show the last 50 entries
+  maplefile-cli journal show
+
+  # Show every failed operation of the last day
+  maplefile-cli journal show --outcome failure --since 24h --limit 0
+
+  # Show the deletes of a file
+  maplefile-cli journal show --id FILE_ID --operation delete
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			filter := &dom_journal.Filter{
+				Operation:  dom_journal.Operation(operation),
+				EntityType: dom_journal.EntityType(entityType),
+				Outcome:    dom_journal.Outcome(outcome),
+				Limit:      limit,
+			}
+
+			switch filter.Operation {
+			case "", dom_journal.OperationCreate, dom_journal.OperationUpdate, dom_journal.OperationDelete,
+				dom_journal.OperationOnload, dom_journal.OperationOffload:
+			default:
+				fmt.Printf("❌ Error: Unknown operation %q, use create, update, delete, onload or offload.\n", operation)
+				return
+			}
+			switch filter.EntityType {
+			case "", dom_journal.EntityTypeFile, dom_journal.EntityTypeCollection:
+			default:
+				fmt.Printf("❌ Error: Unknown entity type %q, use file or collection.\n", entityType)
+				return
+			}
+			switch filter.Outcome {
+			case "", dom_journal.OutcomeSuccess, dom_journal.OutcomeFailure:
+			default:
+				fmt.Printf("❌ Error: Unknown outcome %q, use success or failure.\n", outcome)
+				return
+			}
+			if entityID != "" {
+				id, err := gocql.ParseUUID(entityID)
+				if err != nil {
+					fmt.Printf("❌ Error: Invalid ID format: %v\n", err)
+					return
+				}
+				filter.EntityID = &id
+			}
+			if since > 0 {
+				filter.Since = time.Now().Add(-since)
+			}
+			if limit < 0 {
+				fmt.Println("❌ Error: Limit cannot be negative.")
+				return
+			}
+
+			output, err := showService.Show(cmd.Context(), filter)
+			if err != nil {
+				fmt.Printf("❌ Error reading journal: %v\n", err)
+				logger.Error("Failed to show journal", zap.Error(err))
+				return
+			}
+
+			if len(output.Entries) == 0 {
+				fmt.Println("ℹ️  No matching journal entries.")
+			}
+			for _, entry := range output.Entries {
+				icon := "✅"
+				if entry.Outcome == dom_journal.OutcomeFailure {
+					icon = "❌"
+				}
+				fmt.Printf("%s %s  %-7s %-10s %s",
+					icon,
+					entry.Timestamp.Local().Format("2006-01-02 15:04:05"),
+					entry.Operation,
+					entry.EntityType,
+					entry.EntityID.String())
+				if entry.Details != "" {
+					fmt.Printf("  (%s)", entry.Details)
+				}
+				fmt.Println()
+				if entry.Error != "" {
+					fmt.Printf("      ↳ %s\n", entry.Error)
+				}
+			}
+
+			fmt.Printf("\n📊 Showing %d entries, the journal keeps %d of at most %d entries.\n",
+				len(output.Entries), output.TotalEntries, output.MaxEntries)
+		},
+	}
+
+	cmd.Flags().StringVar(&operation, "operation", "", "Only show this operation: create, update, delete, onload or offload")
+	cmd.Flags().StringVar(&entityType, "entity", "", "Only show this entity type: file or collection")
+	cmd.Flags().StringVar(&entityID, "id", "", "Only show entries of this file or collection ID")
+	cmd.Flags().StringVar(&outcome, "outcome", "", "Only show this outcome: success or failure")
+	cmd.Flags().DurationVar(&since, "since", 0, "Only show entries newer than this duration, for example 24h")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Number of most recent matching entries to show, 0 shows all")
+
+	return cmd
+}
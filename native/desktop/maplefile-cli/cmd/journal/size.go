@@ -0,0 +1,55 @@
+// native/desktop/maplefile-cli/cmd/journal/size.go
+package journal
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+)
+
+// sizeJournalCmd creates a command for getting or setting the number of journal entries kept
+func sizeJournalCmd(configService config.ConfigService) *cobra.Command {
+	return &cobra.Command{
+		Use:   "size [ENTRIES]",
+		Short: "Get or set the number of entries kept",
+		Long: fmt.Sprintf(`
+Get or set the number of entries kept in the local operation journal.
+
+Once the journal holds more entries the oldest ones are removed. The size must
+be between %d and %d entries, the default is %d.
+
+Examples:
+  # Show the current size
+  maplefile-cli journal size
+
+  # Keep the last 50000 entries
+  maplefile-cli journal size 50000
+`, config.MinJournalMaxEntries, config.MaxJournalMaxEntries, config.DefaultJournalMaxEntries),
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				maxEntries, err := configService.GetJournalMaxEntries(cmd.Context())
+				if err != nil {
+					fmt.Printf("❌ Error getting journal size: %v\n", err)
+					return
+				}
+				fmt.Printf("Journal size: %d entries\n", maxEntries)
+				return
+			}
+
+			maxEntries, err := strconv.Atoi(args[0])
+			if err != nil {
+				fmt.Printf("❌ Error: Invalid number of entries: %s\n", args[0])
+				return
+			}
+			if err := configService.SetJournalMaxEntries(cmd.Context(), maxEntries); err != nil {
+				fmt.Printf("❌ Error setting journal size: %v\n", err)
+				return
+			}
+			fmt.Printf("✅ Journal size set to %d entries, older entries are rotated out on the next operation.\n", maxEntries)
+		},
+	}
+}
@@ -0,0 +1,50 @@
+// native/desktop/maplefile-cli/cmd/keys/keys.go
+package keys
+
+import (
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/keytrust"
+)
+
+// KeysCmd creates the command for managing the public keys of recipients trusted on this device
+func KeysCmd(
+	trustedKeyService keytrust.TrustedKeyService,
+	logger *zap.Logger,
+) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "keys",
+		Short: "Manage trusted recipient keys",
+		Long: `
+Manage the public keys of the recipients you share collections with.
+
+The first time you share with a recipient, the verification ID of their
+public key is trusted on this device. Every later share compares the key the
+server returns with the trusted one and warns loudly if it changed, which
+happens when the recipient reset their account or when the server
+substituted their key.
+
+Available commands:
+  trusted       List the trusted recipient keys
+  reset-trust   Forget the key trusted for a recipient
+
+Examples:
+  # List the trusted recipient keys
+  maplefile-cli keys trusted
+
+  # Trust the new key of a recipient after confirming it with them
+  maplefile-cli keys reset-trust user@example.com
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			// Show help when no subcommand is specified
+			cmd.Help()
+		},
+	}
+
+	// Add keys subcommands
+	cmd.AddCommand(trustedCmd(trustedKeyService, logger))
+	cmd.AddCommand(resetTrustCmd(trustedKeyService, logger))
+
+	return cmd
+}
@@ -0,0 +1,48 @@
+// native/desktop/maplefile-cli/cmd/keys/reset_trust.go
+package keys
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/keytrust"
+)
+
+// resetTrustCmd creates a command for forgetting the key trusted for a recipient
+func resetTrustCmd(
+	trustedKeyService keytrust.TrustedKeyService,
+	logger *zap.Logger,
+) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "reset-trust EMAIL",
+		Short: "Forget the key trusted for a recipient",
+		Long: `
+Forget the public key trusted for a recipient, so the key fetched the next
+time you share with them is trusted instead.
+
+Only reset the trust after confirming the new verification ID with the
+recipient out of band, for example in person or over a call.
+
+Examples:
+  maplefile-cli keys reset-trust user@example.com
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			email := args[0]
+
+			if err := trustedKeyService.Reset(cmd.Context(), email); err != nil {
+				fmt.Printf("❌ Error: %v\n", err)
+				return
+			}
+
+			fmt.Printf("✅ Forgot the key trusted for %s.\n", email)
+			fmt.Println("💡 The key fetched the next time you share with them will be trusted.")
+
+			logger.Info("Trusted key reset", zap.String("email", email))
+		},
+	}
+
+	return cmd
+}
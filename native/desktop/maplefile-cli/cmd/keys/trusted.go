@@ -0,0 +1,53 @@
+// native/desktop/maplefile-cli/cmd/keys/trusted.go
+package keys
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/keytrust"
+)
+
+// trustedCmd creates a command for listing the trusted recipient keys
+func trustedCmd(
+	trustedKeyService keytrust.TrustedKeyService,
+	logger *zap.Logger,
+) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "trusted",
+		Short: "List the trusted recipient keys",
+		Long: `
+List the verification IDs of the recipient public keys trusted on this device,
+with when each was first and last seen.
+
+Examples:
+  maplefile-cli keys trusted
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			trustedKeys, err := trustedKeyService.List(cmd.Context())
+			if err != nil {
+				fmt.Printf("❌ Error: %v\n", err)
+				logger.Error("Failed to list trusted keys", zap.Error(err))
+				return
+			}
+
+			if len(trustedKeys) == 0 {
+				fmt.Println("📭 No recipient keys are trusted yet. Keys are trusted the first time you share with a recipient.")
+				return
+			}
+
+			fmt.Printf("🔑 Trusted recipient keys (%d):\n\n", len(trustedKeys))
+			for _, trusted := range trustedKeys {
+				fmt.Printf("  Email: %s\n", trusted.Email)
+				fmt.Printf("  User ID: %s\n", trusted.UserID)
+				fmt.Printf("  Verification ID: %s\n", trusted.VerificationID)
+				fmt.Printf("  First Seen: %s\n", trusted.FirstSeenAt.Format("2006-01-02 15:04:05"))
+				fmt.Printf("  Last Seen: %s\n\n", trusted.LastSeenAt.Format("2006-01-02 15:04:05"))
+			}
+		},
+	}
+
+	return cmd
+}
@@ -3,18 +3,16 @@ package login
 
 import (
 	"bufio"
-	"context"
 	"fmt"
 	"os"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
-	"golang.org/x/crypto/ssh/terminal"
 
 	svc_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/authdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
 )
 
 // LoginCmd creates a unified login command that handles the complete authentication flow
@@ -55,9 +53,12 @@ Examples:
 
   # Provide all details upfront (non-interactive)
   maplefile-cli login --email user@example.com --ott 123456 --password mypassword
+
+  # Pipe the password in, keeping it out of the process arguments and shell history
+  printf '%s\n' "$MAPLEFILE_PASSWORD" | maplefile-cli login --email user@example.com --ott 123456 --password-stdin
 `,
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			// Validate email is provided
 			if email == "" {
@@ -127,15 +128,12 @@ Examples:
 
 			// STEP 3: Get password and complete login
 			if password == "" {
-				fmt.Print("🔐 Step 3/3: Enter your password: ")
-				passwordBytes, err := terminal.ReadPassword(int(syscall.Stdin))
-				fmt.Println() // New line after password input
-
+				passwordInput, err := promptpassword.Read("🔐 Step 3/3: Enter your password: ")
 				if err != nil {
 					fmt.Printf("❌ Error reading password: %v\n", err)
 					return
 				}
-				password = string(passwordBytes)
+				password = passwordInput
 			}
 
 			if password == "" {
@@ -185,6 +183,7 @@ Examples:
 
 	// Mark required flags
 	cmd.MarkFlagRequired("email")
+	promptpassword.AddStdinFlag(cmd, &password, false)
 
 	return cmd
 }
@@ -214,7 +213,7 @@ Examples:
   maplefile-cli request-login-token --email user@example.com
 `,
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			if email == "" {
 				fmt.Println("❌ Error: Email is required")
@@ -260,7 +259,7 @@ Examples:
   maplefile-cli verify-login-token --email user@example.com --ott 123456
 `,
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			if email == "" || ott == "" {
 				fmt.Println("❌ Error: Both email and OTT are required")
@@ -311,7 +310,7 @@ Examples:
   maplefile-cli complete-login --email user@example.com --password mypassword
 `,
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			if email == "" {
 				fmt.Println("❌ Error: Email is required")
@@ -320,15 +319,12 @@ Examples:
 
 			// Prompt for password if not provided
 			if password == "" {
-				fmt.Print("🔐 Enter your password: ")
-				passwordBytes, err := terminal.ReadPassword(int(syscall.Stdin))
-				fmt.Println()
-
+				passwordInput, err := promptpassword.Read("🔐 Enter your password: ")
 				if err != nil {
 					fmt.Printf("❌ Error reading password: %v\n", err)
 					return
 				}
-				password = string(passwordBytes)
+				password = passwordInput
 			}
 
 			if password == "" {
@@ -358,6 +354,7 @@ Examples:
 	cmd.Flags().StringVarP(&password, "password", "p", "", "Password (will prompt if not provided)")
 	cmd.Flags().BoolVarP(&debugMode, "debug", "d", false, "Enable debug output")
 	cmd.MarkFlagRequired("email")
+	promptpassword.AddStdinFlag(cmd, &password, false)
 
 	return cmd
 }
@@ -2,7 +2,6 @@
 package logout
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -30,7 +29,7 @@ Examples:
 			fmt.Println("Logging out...")
 
 			// Create context
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			// Call the service to perform logout
 			if err := logoutService.Logout(ctx); err != nil {
@@ -5,6 +5,7 @@ import (
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/keyrotation"
 	svc_me "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/me"
 )
 
@@ -12,12 +13,13 @@ import (
 func MeCmd(
 	getMeService svc_me.GetMeService,
 	updateMeService svc_me.UpdateMeService,
+	masterKeyRotationService keyrotation.MasterKeyRotationService,
 	logger *zap.Logger,
 ) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "me",
 		Short: "Manage your user profile",
-		Long:  `View and manage your user profile information.`,
+		Long:  `View and manage your user profile information and encryption keys.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			// Show help when no subcommand is specified
 			cmd.Help()
@@ -27,6 +29,7 @@ func MeCmd(
 	// Add me subcommands
 	cmd.AddCommand(getCmd(getMeService, logger))
 	cmd.AddCommand(updateCmd(updateMeService, logger))
+	cmd.AddCommand(rotateMasterKeyCmd(masterKeyRotationService, logger))
 
 	return cmd
 }
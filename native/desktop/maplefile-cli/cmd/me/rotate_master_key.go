@@ -0,0 +1,116 @@
+// native/desktop/maplefile-cli/cmd/me/rotate_master_key.go
+package me
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/keyrotation"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
+)
+
+// rotateMasterKeyCmd creates a command for rotating the master key
+func rotateMasterKeyCmd(
+	masterKeyRotationService keyrotation.MasterKeyRotationService,
+	logger *zap.Logger,
+) *cobra.Command {
+	var password string
+	var confirm bool
+
+	var cmd = &cobra.Command{
+		Use:   "rotate-master-key",
+		Short: "Rotate your master key",
+		Long: `
+Replace your master key with a new one and re-encrypt every key protected by it.
+
+This command:
+1. Generates a new random master key
+2. Re-encrypts the keys of the collections you own with the new master key
+3. Re-encrypts your private key and recovery key with the new master key
+4. Encrypts the new master key with your password and your recovery key
+5. Saves the new keys in the cloud and on this device
+
+Your password and recovery key stay the same, and your files are not
+re-uploaded. Other devices must log in again after the rotation.
+
+The rotation is resumable: if it is interrupted, run the command again with
+the same password to complete it. Until it completes, your collections may
+not open on other devices.
+
+When the password is read with --password-stdin, pass --yes as stdin can't
+also answer the confirmation prompt.
+
+Examples:
+  maplefile-cli me rotate-master-key --password PASSWORD
+  echo "PASSWORD" | maplefile-cli me rotate-master-key --password-stdin --yes
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if password == "" {
+				fmt.Println("❌ Error: Password is required for E2EE operations.")
+				fmt.Println("Use --password flag to specify your account password.")
+				return
+			}
+
+			pending, err := masterKeyRotationService.Pending(cmd.Context())
+			if err != nil {
+				fmt.Printf("❌ Error: %v\n", err)
+				return
+			}
+
+			if pending != nil {
+				fmt.Printf("🔄 Resuming the master key rotation started on %s\n", pending.StartedAt.Format("2006-01-02 15:04:05"))
+			} else if !confirm {
+				fmt.Println("⚠️  This will replace your master key and re-encrypt all your collection keys!")
+				fmt.Print("Are you sure you want to continue? (y/N): ")
+
+				reader := bufio.NewReader(os.Stdin)
+				response, _ := reader.ReadString('\n')
+				response = strings.ToLower(strings.TrimSpace(response))
+
+				if response != "y" && response != "yes" {
+					fmt.Println("❌ Operation cancelled")
+					return
+				}
+			}
+
+			fmt.Println("\n🔐 Rotating master key...")
+
+			output, err := masterKeyRotationService.Rotate(cmd.Context(), &keyrotation.RotateMasterKeyInput{
+				UserPassword: password,
+			})
+			if err != nil {
+				fmt.Printf("❌ Error: %v\n", err)
+				fmt.Println("💡 Run the command again with the same password to resume the rotation.")
+				return
+			}
+
+			fmt.Println("\n✅ Master key rotated successfully!")
+			fmt.Printf("🔑 Key version: %d\n", output.KeyVersion)
+			fmt.Printf("📁 Collection keys re-encrypted: %d\n", output.CollectionsRotated)
+			if output.CollectionsUnchanged > 0 {
+				fmt.Printf("📁 Collection keys already re-encrypted: %d\n", output.CollectionsUnchanged)
+			}
+			if output.CollectionsFailed > 0 {
+				fmt.Printf("⚠️  Collection keys which could not be decrypted and were left as they are: %d\n", output.CollectionsFailed)
+			}
+			fmt.Println("\n💡 Log in again on your other devices to use the new master key.")
+
+			logger.Info("Master key rotated",
+				zap.Int("keyVersion", output.KeyVersion),
+				zap.Int("collectionsRotated", output.CollectionsRotated),
+				zap.Bool("resumed", output.Resumed))
+		},
+	}
+
+	// Define command flags
+	cmd.Flags().StringVar(&password, "password", "", "Your account password (required for E2EE)")
+	cmd.Flags().BoolVar(&confirm, "yes", false, "Skip confirmation prompt")
+	promptpassword.AddStdinFlag(cmd, &password, true)
+
+	return cmd
+}
@@ -4,18 +4,26 @@ package recovery
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
-	"golang.org/x/crypto/ssh/terminal"
 
+	dom_recovery "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/recovery"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/recovery"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
 )
 
+// maxPasswordAttempts is how often a password can be entered before the command fails. The cloud
+// and local rate limiting still applies to every attempt.
+const maxPasswordAttempts = 3
+
 // RecoveryCmd creates the recovery command group
 func RecoveryCmd(
 	recoveryService recovery.RecoveryService,
@@ -31,7 +39,12 @@ func RecoveryCmd(
 The recovery system follows the E2EE (End-to-End Encryption) architecture:
 - Your master key is encrypted with a recovery key
 - The recovery key allows you to reset your password without losing access to encrypted data
-- All encryption happens locally - the server never sees your keys`,
+- All encryption happens locally - the server never sees your keys
+
+Expired recovery sessions are cleaned up automatically before every recovery command.`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			cleanupExpiredRecovery(cmd.Context(), recoveryCleanupService, logger)
+		},
 	}
 
 	// Add subcommands
@@ -39,8 +52,65 @@ The recovery system follows the E2EE (End-to-End Encryption) architecture:
 	cmd.AddCommand(verifyRecoveryCmd(recoveryService, logger))
 	cmd.AddCommand(completeRecoveryCmd(recoveryService, logger))
 	cmd.AddCommand(statusRecoveryCmd(recoveryService, logger))
+	cmd.AddCommand(methodsRecoveryCmd(recoveryService, logger))
 	cmd.AddCommand(showRecoveryKeyCmd(recoveryKeyService, logger))
 	cmd.AddCommand(regenerateRecoveryKeyCmd(recoveryKeyService, logger))
+	cmd.AddCommand(cleanupRecoveryCmd(recoveryCleanupService, logger))
+
+	return cmd
+}
+
+// cleanupRecoveryCmd creates the command to clean up expired recovery sessions
+func cleanupRecoveryCmd(recoveryCleanupService recovery.RecoveryCleanupService, logger *zap.Logger) *cobra.Command {
+	var interval time.Duration
+
+	var cmd = &cobra.Command{
+		Use:   "cleanup",
+		Short: "Remove expired recovery sessions",
+		Long: `Remove expired recovery sessions and the recovery data stored for them.
+
+This runs automatically before every recovery command. Use --interval to keep
+cleaning up on a timer until interrupted, for example in a long running session.
+
+Example:
+  # Clean up expired recovery sessions once
+  maplefile-cli recovery cleanup
+
+  # Clean up expired recovery sessions every 10 minutes
+  maplefile-cli recovery cleanup --interval 10m`,
+		// The cleanup itself must report failures, so skip the automatic cleanup of the parent.
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			if interval < 0 {
+				fmt.Println("❌ Error: interval must not be negative")
+				return
+			}
+
+			fmt.Println("🧹 Cleaning up expired recovery sessions...")
+			if !printRecoveryCleanup(ctx, recoveryCleanupService) || interval == 0 {
+				return
+			}
+
+			fmt.Printf("\n⏰ Cleaning up every %s, press Ctrl+C to stop\n", interval)
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					fmt.Println("\n👋 Stopped cleaning up expired recovery sessions")
+					return
+				case <-ticker.C:
+					printRecoveryCleanup(ctx, recoveryCleanupService)
+				}
+			}
+		},
+	}
+
+	// Define command flags
+	cmd.Flags().DurationVar(&interval, "interval", 0, "Repeat the cleanup at this interval until interrupted, such as 10m")
 
 	return cmd
 }
@@ -48,6 +118,7 @@ The recovery system follows the E2EE (End-to-End Encryption) architecture:
 // startRecoveryCmd creates the command to start recovery
 func startRecoveryCmd(recoveryService recovery.RecoveryService, logger *zap.Logger) *cobra.Command {
 	var email string
+	var method string
 
 	var cmd = &cobra.Command{
 		Use:   "start",
@@ -59,11 +130,16 @@ This initiates the recovery process by:
 2. Receiving an encrypted challenge that only your recovery key can decrypt
 3. Preparing for password reset while maintaining E2EE
 
+Run 'maplefile-cli recovery methods' to list the recovery methods.
+
 Example:
   # Start recovery with email
-  maplefile-cli recovery start --email user@example.com`,
+  maplefile-cli recovery start --email user@example.com
+
+  # Start recovery with a specific recovery method
+  maplefile-cli recovery start --email user@example.com --method recovery_key`,
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			// Validate email
 			if email == "" {
@@ -75,7 +151,7 @@ Example:
 			fmt.Printf("📧 Email: %s\n\n", email)
 
 			// Start recovery
-			result, err := recoveryService.InitiateRecovery(ctx, email)
+			result, err := recoveryService.InitiateRecovery(ctx, email, method)
 			if err != nil {
 				fmt.Printf("❌ Error: %v\n", err)
 				if strings.Contains(err.Error(), "rate limit") {
@@ -86,6 +162,7 @@ Example:
 
 			fmt.Println("✅ Recovery initiated successfully!")
 			fmt.Printf("🔑 Session ID: %s\n", result.SessionID)
+			fmt.Printf("🧭 Method: %s\n", result.Method)
 			fmt.Printf("⏰ Expires at: %s\n", result.ExpiresAt.Format("15:04:05"))
 			fmt.Println("\n🔐 The server has sent an encrypted challenge.")
 			fmt.Println("📋 Next step: Verify your recovery key")
@@ -95,6 +172,7 @@ Example:
 
 	// Define command flags
 	cmd.Flags().StringVarP(&email, "email", "e", "", "Email address for the account (required)")
+	cmd.Flags().StringVar(&method, "method", dom_recovery.DefaultRecoveryMethod, "Recovery method, see 'maplefile-cli recovery methods'")
 	cmd.MarkFlagRequired("email")
 
 	return cmd
@@ -126,7 +204,7 @@ Example:
   # Verify with recovery key from file
   maplefile-cli recovery verify --session <session-id> --recovery-key-file ~/recovery.key`,
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			// Validate session ID
 			if sessionID == "" {
@@ -167,7 +245,7 @@ Example:
 			fmt.Println("\n🔐 Verifying recovery key...")
 
 			// Verify recovery
-			result, err := recoveryService.VerifyRecoveryKey(ctx, sessionID, recoveryKey)
+			result, err := recoveryService.VerifyRecovery(ctx, sessionID, recoveryKey)
 			if err != nil {
 				fmt.Printf("❌ Error: %v\n", err)
 				if strings.Contains(err.Error(), "incorrect") || strings.Contains(err.Error(), "invalid") {
@@ -197,6 +275,7 @@ func completeRecoveryCmd(recoveryService recovery.RecoveryService, logger *zap.L
 	var recoveryToken string
 	var showNewKey bool
 	var recoveryKey string
+	var newPassword string
 
 	var cmd = &cobra.Command{
 		Use:   "complete",
@@ -212,9 +291,12 @@ This final step:
 Your encrypted files remain accessible because the master key stays the same -
 only the password protecting it changes.
 
-If your recovery session was interrupted, you may need to provide your recovery key again.`,
+If your recovery session was interrupted, you may need to provide your recovery key again.
+
+For automation, pass --password-stdin to read the new password from the first
+line of stdin instead of prompting for it.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			// Check recovery status first
 			status, err := recoveryService.GetRecoveryStatus(ctx)
@@ -244,14 +326,8 @@ If your recovery session was interrupted, you may need to provide your recovery
 			fmt.Printf("📧 Completing recovery for: %s\n", status.Email)
 
 			// Prompt for new password
-			password, err := promptForNewPassword()
+			password, err := newPasswordOrPrompt(newPassword)
 			if err != nil {
-				fmt.Printf("❌ Error reading password: %v\n", err)
-				return
-			}
-
-			// Validate password
-			if err := validatePassword(password); err != nil {
 				fmt.Printf("❌ Error: %v\n", err)
 				return
 			}
@@ -278,7 +354,7 @@ If your recovery session was interrupted, you may need to provide your recovery
 
 					// Try to re-verify with the recovery key to restore recovery data
 					fmt.Println("🔄 Re-verifying recovery key to restore session data...")
-					_, verifyErr := recoveryService.VerifyRecoveryKey(ctx, status.SessionID, cleanKey)
+					_, verifyErr := recoveryService.VerifyRecovery(ctx, status.SessionID, cleanKey)
 					if verifyErr != nil {
 						fmt.Printf("❌ Failed to verify recovery key: %v\n", verifyErr)
 						fmt.Println("\n💡 Please ensure you're using the correct recovery key.")
@@ -314,15 +390,12 @@ If your recovery session was interrupted, you may need to provide your recovery
 			fmt.Println("\n✅ Password reset successfully!")
 			fmt.Printf("📧 Account recovered: %s\n", result.Email)
 
-			if showNewKey && strings.Contains(result.Message, "recovery key:") {
-				// Extract and display the new recovery key
-				parts := strings.Split(result.Message, "recovery key: ")
-				if len(parts) > 1 {
-					fmt.Println("\n🔑 Your NEW recovery key:")
-					fmt.Printf("\n%s\n", parts[1])
-					fmt.Println("\n⚠️  IMPORTANT: Save this new recovery key!")
-					fmt.Println("⚠️  Your old recovery key no longer works.")
-				}
+			if showNewKey && result.NewRecoveryKey != "" {
+				// Display the new recovery key
+				fmt.Println("\n🔑 Your NEW recovery key:")
+				fmt.Printf("\n%s\n", result.NewRecoveryKey)
+				fmt.Println("\n⚠️  IMPORTANT: Save this new recovery key!")
+				fmt.Println("⚠️  Your old recovery key no longer works.")
 			} else {
 				fmt.Println("\n💡 A new recovery key has been generated.")
 				fmt.Println("👉 View it with: maplefile-cli recovery show-key")
@@ -337,6 +410,7 @@ If your recovery session was interrupted, you may need to provide your recovery
 	cmd.Flags().StringVar(&recoveryToken, "token", "", "Recovery token (if you have it)")
 	cmd.Flags().BoolVar(&showNewKey, "show-new-key", true, "Display the new recovery key after reset")
 	cmd.Flags().StringVar(&recoveryKey, "recovery-key", "", "Recovery key (if recovery data was lost)")
+	promptpassword.AddStdinFlag(cmd, &newPassword, false)
 
 	return cmd
 }
@@ -348,7 +422,7 @@ func statusRecoveryCmd(recoveryService recovery.RecoveryService, logger *zap.Log
 		Short: "Check recovery session status",
 		Long:  `Check if there is an active recovery session and its current stage.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			status, err := recoveryService.GetRecoveryStatus(ctx)
 			if err != nil {
@@ -365,6 +439,9 @@ func statusRecoveryCmd(recoveryService recovery.RecoveryService, logger *zap.Log
 			fmt.Println("✅ Active recovery session found:")
 			fmt.Printf("📧 Email: %s\n", status.Email)
 			fmt.Printf("🔑 Session ID: %s\n", status.SessionID)
+			if status.Method != "" {
+				fmt.Printf("🧭 Method: %s\n", status.Method)
+			}
 			fmt.Printf("📊 Stage: %s\n", formatStage(status.Stage))
 			if status.ExpiresAt != nil {
 				fmt.Printf("⏰ Expires at: %s\n", status.ExpiresAt.Format("15:04:05"))
@@ -386,6 +463,54 @@ func statusRecoveryCmd(recoveryService recovery.RecoveryService, logger *zap.Log
 	return cmd
 }
 
+// methodsRecoveryCmd creates the command to list the recovery methods
+func methodsRecoveryCmd(recoveryService recovery.RecoveryService, logger *zap.Logger) *cobra.Command {
+	var email string
+
+	var cmd = &cobra.Command{
+		Use:   "methods",
+		Short: "List the recovery methods",
+		Long: `List the recovery methods supported by this client and whether they are
+configured for your account.
+
+Whether a method is configured is checked against the account data stored on
+this device, so log in on this device before you need to recover your account.
+Pass the method to 'maplefile-cli recovery start --method'.
+
+Example:
+  # List the recovery methods of an account
+  maplefile-cli recovery methods --email user@example.com`,
+		Run: func(cmd *cobra.Command, args []string) {
+			methods, err := recoveryService.ListRecoveryMethods(cmd.Context(), email)
+			if err != nil {
+				fmt.Printf("❌ Error listing recovery methods: %v\n", err)
+				logger.Error("Failed to list recovery methods", zap.Error(err))
+				return
+			}
+
+			fmt.Printf("🧭 Recovery methods for %s:\n\n", email)
+			for _, method := range methods {
+				status := "❌ not configured"
+				if method.Configured {
+					status = "✅ configured"
+				}
+				name := method.Name
+				if method.Default {
+					name += " (default)"
+				}
+				fmt.Printf("  %s  %s\n", name, status)
+				fmt.Printf("      %s\n", method.Description)
+			}
+		},
+	}
+
+	// Define command flags
+	cmd.Flags().StringVarP(&email, "email", "e", "", "Email address for the account (required)")
+	cmd.MarkFlagRequired("email")
+
+	return cmd
+}
+
 // showRecoveryKeyCmd creates the command to show the recovery key
 func showRecoveryKeyCmd(recoveryKeyService recovery.RecoveryKeyService, logger *zap.Logger) *cobra.Command {
 	var email string
@@ -402,7 +527,7 @@ This command:
 
 ⚠️  IMPORTANT: Store your recovery key in a safe place!`,
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			if email == "" {
 				fmt.Println("❌ Error: email is required")
@@ -412,17 +537,12 @@ This command:
 			fmt.Println("🔐 Retrieving your recovery key...")
 			fmt.Println("🔑 You'll need to enter your password to decrypt it.\n")
 
-			// Prompt for password
-			fmt.Print("Enter your password: ")
-			password, err := terminal.ReadPassword(int(syscall.Stdin))
-			fmt.Println()
-			if err != nil {
-				fmt.Printf("❌ Error reading password: %v\n", err)
-				return
-			}
-
-			// Get recovery key
-			result, err := recoveryKeyService.ShowRecoveryKey(ctx, email, string(password))
+			// Prompt for password and get recovery key
+			var result *recovery.RecoveryKeyOutput
+			err := promptForCurrentPassword(func(password string) (err error) {
+				result, err = recoveryKeyService.ShowRecoveryKey(ctx, email, password)
+				return err
+			})
 			if err != nil {
 				fmt.Printf("❌ Error: %v\n", err)
 				return
@@ -468,7 +588,7 @@ This command:
 
 Use this if you suspect your recovery key has been compromised.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			if email == "" {
 				fmt.Println("❌ Error: email is required")
@@ -492,17 +612,12 @@ Use this if you suspect your recovery key has been compromised.`,
 			fmt.Println("\n🔐 Generating new recovery key...")
 			fmt.Println("🔑 You'll need to enter your password.\n")
 
-			// Prompt for password
-			fmt.Print("Enter your password: ")
-			password, err := terminal.ReadPassword(int(syscall.Stdin))
-			fmt.Println()
-			if err != nil {
-				fmt.Printf("❌ Error reading password: %v\n", err)
-				return
-			}
-
-			// Generate new recovery key
-			result, err := recoveryKeyService.GenerateNewRecoveryKey(ctx, email, string(password))
+			// Prompt for password and generate new recovery key
+			var result *recovery.RecoveryKeyOutput
+			err := promptForCurrentPassword(func(password string) (err error) {
+				result, err = recoveryKeyService.GenerateNewRecoveryKey(ctx, email, password)
+				return err
+			})
 			if err != nil {
 				fmt.Printf("❌ Error: %v\n", err)
 				return
@@ -529,6 +644,38 @@ Use this if you suspect your recovery key has been compromised.`,
 
 // Helper functions
 
+// cleanupExpiredRecovery removes expired recovery sessions before a recovery command runs. A failure
+// is logged rather than returned, as the command can still run with the stale session in place.
+func cleanupExpiredRecovery(ctx context.Context, recoveryCleanupService recovery.RecoveryCleanupService, logger *zap.Logger) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, err := recoveryCleanupService.CleanupExpiredSessions(ctx); err != nil {
+		logger.Warn("⚠️ Failed to clean up expired recovery sessions", zap.Error(err))
+	}
+}
+
+// printRecoveryCleanup cleans up expired recovery sessions and prints what was removed, returning
+// false if the cleanup failed
+func printRecoveryCleanup(ctx context.Context, recoveryCleanupService recovery.RecoveryCleanupService) bool {
+	result, err := recoveryCleanupService.CleanupExpiredSessions(ctx)
+	if err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+		return false
+	}
+
+	if result.ExpiredStateCleared {
+		fmt.Println("✅ Removed an expired recovery session")
+	}
+	if result.RecoveryDataCleared {
+		fmt.Println("✅ Removed recovery data of an abandoned recovery")
+	}
+	if !result.ExpiredStateCleared && !result.RecoveryDataCleared {
+		fmt.Println("ℹ️  No expired recovery session found")
+	}
+	return true
+}
+
 // promptForRecoveryKey prompts the user to enter their recovery key
 func promptForRecoveryKey() (string, error) {
 	fmt.Println("Enter your recovery key:")
@@ -546,40 +693,72 @@ func promptForRecoveryKey() (string, error) {
 
 // cleanRecoveryKey removes formatting from a recovery key
 func cleanRecoveryKey(key string) string {
-	// Remove common formatting characters
-	key = strings.ReplaceAll(key, "-", "")
-	key = strings.ReplaceAll(key, " ", "")
-	key = strings.ReplaceAll(key, "\n", "")
-	key = strings.ReplaceAll(key, "\r", "")
-	key = strings.ReplaceAll(key, "\t", "")
-
-	return strings.TrimSpace(key)
+	return recovery.CleanRecoveryKey(key)
 }
 
-// promptForNewPassword prompts the user to enter and confirm a new password
+// promptForNewPassword prompts the user to enter and confirm a new password, giving them
+// maxPasswordAttempts tries to enter a valid password and confirm it without a typo
 func promptForNewPassword() (string, error) {
-	// First password
-	fmt.Print("Enter new password: ")
-	password1, err := terminal.ReadPassword(int(syscall.Stdin))
-	fmt.Println()
-	if err != nil {
-		return "", err
+	for attempt := 1; ; attempt++ {
+		password, err := promptpassword.ReadWithConfirmation("Enter new password: ", "Confirm new password: ")
+		if err == nil {
+			err = validatePassword(password)
+		} else if !errors.Is(err, promptpassword.ErrPasswordsDoNotMatch) {
+			return "", err
+		}
+		if err == nil {
+			return password, nil
+		}
+		if attempt == maxPasswordAttempts {
+			return "", err
+		}
+		fmt.Printf("❌ %v, please try again (%s)\n", capitalize(err.Error()), remainingAttempts(attempt))
 	}
+}
 
-	// Confirm password
-	fmt.Print("Confirm new password: ")
-	password2, err := terminal.ReadPassword(int(syscall.Stdin))
-	fmt.Println()
-	if err != nil {
+// newPasswordOrPrompt validates a new password read from stdin, or prompts for one if none was read
+func newPasswordOrPrompt(newPassword string) (string, error) {
+	if newPassword == "" {
+		return promptForNewPassword()
+	}
+	if err := validatePassword(newPassword); err != nil {
 		return "", err
 	}
+	return newPassword, nil
+}
 
-	// Check if passwords match
-	if string(password1) != string(password2) {
-		return "", fmt.Errorf("passwords do not match")
+// promptForCurrentPassword prompts for the user's password and passes it to check, prompting again
+// while check fails with an incorrect password, up to maxPasswordAttempts times
+func promptForCurrentPassword(check func(password string) error) error {
+	for attempt := 1; ; attempt++ {
+		password, err := promptpassword.Read("Enter your password: ")
+		if err != nil {
+			return err
+		}
+
+		err = check(password)
+		if err == nil || !errors.Is(err, recovery.ErrIncorrectPassword) || attempt == maxPasswordAttempts {
+			return err
+		}
+		fmt.Printf("❌ Incorrect password, please try again (%s)\n", remainingAttempts(attempt))
 	}
+}
+
+// remainingAttempts describes how many password attempts are left after the given attempt
+func remainingAttempts(attempt int) string {
+	remaining := maxPasswordAttempts - attempt
+	if remaining == 1 {
+		return "1 attempt remaining"
+	}
+	return fmt.Sprintf("%d attempts remaining", remaining)
+}
 
-	return string(password1), nil
+// capitalize upper cases the first letter of an error message for display
+func capitalize(message string) string {
+	if message == "" {
+		return message
+	}
+	return strings.ToUpper(message[:1]) + message[1:]
 }
 
 // validatePassword validates a password meets minimum requirements
@@ -614,12 +793,15 @@ func formatStage(stage string) string {
 func UnifiedRecoveryCmd(
 	recoveryService recovery.RecoveryService,
 	recoveryKeyService recovery.RecoveryKeyService,
+	recoveryCleanupService recovery.RecoveryCleanupService,
 	logger *zap.Logger,
 ) *cobra.Command {
 	var email string
+	var method string
 	var recoveryKeyFile string
 	var skipVerify bool
 	var skipComplete bool
+	var newPassword string
 
 	var cmd = &cobra.Command{
 		Use:   "recover",
@@ -643,7 +825,7 @@ Example:
   # With recovery key from file
   maplefile-cli recover --email user@example.com --recovery-key-file ~/recovery.key`,
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			// Validate email
 			if email == "" {
@@ -651,13 +833,15 @@ Example:
 				return
 			}
 
+			cleanupExpiredRecovery(ctx, recoveryCleanupService, logger)
+
 			fmt.Printf("🔐 Starting account recovery for: %s\n\n", email)
 
 			// STEP 1: Initiate recovery
 			if !skipVerify {
 				fmt.Println("📧 Step 1/3: Initiating recovery...")
 
-				result, err := recoveryService.InitiateRecovery(ctx, email)
+				result, err := recoveryService.InitiateRecovery(ctx, email, method)
 				if err != nil {
 					fmt.Printf("❌ Failed to initiate recovery: %v\n", err)
 					return
@@ -687,7 +871,7 @@ Example:
 				recoveryKey = cleanRecoveryKey(recoveryKey)
 				fmt.Println("\n🔐 Verifying recovery key...")
 
-				_, err = recoveryService.VerifyRecoveryKey(ctx, result.SessionID, recoveryKey)
+				_, err = recoveryService.VerifyRecovery(ctx, result.SessionID, recoveryKey)
 				if err != nil {
 					fmt.Printf("❌ Failed to verify recovery key: %v\n", err)
 					return
@@ -700,13 +884,8 @@ Example:
 			if !skipComplete {
 				fmt.Println("🔐 Step 3/3: Set new password")
 
-				password, err := promptForNewPassword()
+				password, err := newPasswordOrPrompt(newPassword)
 				if err != nil {
-					fmt.Printf("❌ Error reading password: %v\n", err)
-					return
-				}
-
-				if err := validatePassword(password); err != nil {
 					fmt.Printf("❌ Error: %v\n", err)
 					return
 				}
@@ -723,15 +902,12 @@ Example:
 				fmt.Printf("✅ Password reset for: %s\n", result.Email)
 
 				// Show new recovery key info
-				if strings.Contains(result.Message, "recovery key:") {
-					parts := strings.Split(result.Message, "recovery key: ")
-					if len(parts) > 1 {
-						fmt.Println("\n🔑 Your NEW recovery key:")
-						fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-						fmt.Printf("\n%s\n\n", parts[1])
-						fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-						fmt.Println("\n⚠️  Save this new recovery key - your old one no longer works!")
-					}
+				if result.NewRecoveryKey != "" {
+					fmt.Println("\n🔑 Your NEW recovery key:")
+					fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+					fmt.Printf("\n%s\n\n", result.NewRecoveryKey)
+					fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+					fmt.Println("\n⚠️  Save this new recovery key - your old one no longer works!")
 				}
 
 				fmt.Println("\n✅ You can now log in with your new password!")
@@ -742,9 +918,11 @@ Example:
 
 	// Define command flags
 	cmd.Flags().StringVarP(&email, "email", "e", "", "Email address (required)")
+	cmd.Flags().StringVar(&method, "method", dom_recovery.DefaultRecoveryMethod, "Recovery method, see 'maplefile-cli recovery methods'")
 	cmd.Flags().StringVarP(&recoveryKeyFile, "recovery-key-file", "f", "", "Path to recovery key file")
 	cmd.Flags().BoolVar(&skipVerify, "skip-verify", false, "Skip to password reset (if already verified)")
 	cmd.Flags().BoolVar(&skipComplete, "skip-complete", false, "Stop after verification")
+	promptpassword.AddStdinFlag(cmd, &newPassword, false)
 	cmd.MarkFlagRequired("email")
 
 	return cmd
@@ -2,18 +2,16 @@
 package refreshtoken
 
 import (
-	"context"
 	"fmt"
 	"log"
-	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
-	"golang.org/x/crypto/ssh/terminal"
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/refreshtoken"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
 )
 
 // RefreshTokenCmd creates a new command for refreshing authentication tokens
@@ -47,20 +45,17 @@ to decrypt the new tokens received from the server.
 		Run: func(cmd *cobra.Command, args []string) {
 			fmt.Println("🔄 Refreshing encrypted authentication tokens...")
 
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			// Handle password input
 			finalPassword := password
 			if promptPassword {
-				fmt.Print("🔐 Enter your password (required for encrypted token decryption): ")
-				passwordBytes, err := terminal.ReadPassword(int(syscall.Stdin))
-				fmt.Println() // New line after password input
-
+				passwordInput, err := promptpassword.Read("🔐 Enter your password (required for encrypted token decryption): ")
 				if err != nil {
 					fmt.Printf("❌ Error reading password: %v\n", err)
 					return
 				}
-				finalPassword = string(passwordBytes)
+				finalPassword = passwordInput
 			}
 
 			// Validate that we have a password
@@ -105,8 +100,11 @@ to decrypt the new tokens received from the server.
 	cmd.Flags().StringVar(&password, "password", "", "Password for encrypted token decryption (not recommended for security)")
 	cmd.Flags().BoolVar(&promptPassword, "prompt-password", false, "Prompt for password (recommended for encrypted tokens)")
 
+	promptpassword.AddStdinFlag(cmd, &password, false)
+
 	// Make one of the password options required
-	cmd.MarkFlagsOneRequired("password", "prompt-password")
+	cmd.MarkFlagsOneRequired("password", "prompt-password", promptpassword.StdinFlag)
+	cmd.MarkFlagsMutuallyExclusive("prompt-password", promptpassword.StdinFlag)
 
 	return cmd
 }
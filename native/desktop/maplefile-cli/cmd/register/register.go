@@ -2,13 +2,12 @@
 package register
 
 import (
-	"context"
 	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/register"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
 )
 
 // RegisterCmd creates the register command for the CLI
@@ -29,7 +28,7 @@ specify agreement to terms, promotions, and tracking, and specify the registrati
 Registration information will be saved locally before being sent to the cloud server.
 Use the --skip-cloud flag to only save locally without registering with the cloud server.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			// Validate required fields
 			if email == "" || password == "" || firstName == "" || lastName == "" {
@@ -52,7 +51,7 @@ Use the --skip-cloud flag to only save locally without registering with the clou
 
 			// Prepare the registration input
 			input := register.RegisterUserInput{
-				Email:           strings.ToLower(email),
+				Email:           email,
 				Password:        password,
 				FirstName:       firstName,
 				LastName:        lastName,
@@ -108,7 +107,7 @@ Use the --skip-cloud flag to only save locally without registering with the clou
 
 	// Mark required flags
 	cmd.MarkFlagRequired("email")
-	cmd.MarkFlagRequired("password")
+	promptpassword.AddStdinFlag(cmd, &password, true)
 	cmd.MarkFlagRequired("firstname")
 	cmd.MarkFlagRequired("lastname")
 
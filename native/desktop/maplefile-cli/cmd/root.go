@@ -10,8 +10,11 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/cmd/cloud"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/cmd/collections"
 	config_cmd "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/cmd/config"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/cmd/export"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/cmd/files"
 	healthcheck "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/cmd/healthcheck"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/cmd/journal"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/cmd/keys"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/cmd/login"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/cmd/logout"
 	cmd_md "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/cmd/me"
@@ -28,9 +31,13 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collection"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectionsharing"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectionsyncer"
+	svc_export "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/export"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filedownload"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filesyncer"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/fileupload"
+	svc_journal "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/journal"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/keyrotation"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/keytrust"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/localfile"
 	svc_me "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/me"
 	svc_recovery "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/recovery"
@@ -62,6 +69,9 @@ func NewRootCmd(
 	createCollectionService collection.CreateService,
 	collectionListService collection.ListService,
 	collectionSoftDeleteService collection.SoftDeleteService,
+	collectionArchiveService collection.ArchiveService,
+	collectionHistoryService collection.HistoryService,
+	collectionSyncPreferenceService collection.SyncPreferenceService,
 	listFromCloudService collectionsyncer.ListFromCloudService,
 	collectionSharingService collectionsharing.CollectionSharingService,
 	collectionGetMembersService collectionsharing.CollectionSharingGetMembersService,
@@ -71,11 +81,18 @@ func NewRootCmd(
 	listFileService localfile.ListService,
 	localOnlyDeleteService localfile.LocalOnlyDeleteService,
 	uploadFileService fileupload.FileUploadService,
+	batchUploadFileService fileupload.BatchFileUploadService,
 	downloadService filedownload.DownloadService,
+	exportService svc_export.ExportService,
 	lockService localfile.LockService,
 	unlockService localfile.UnlockService,
+	moveFileService localfile.MoveService,
+	findSimilarService localfile.FindSimilarService,
 	offloadService filesyncer.OffloadService,
 	onloadService filesyncer.OnloadService,
+	batchOnloadService filesyncer.BatchOnloadService,
+	autoOnloadService filesyncer.AutoOnloadService,
+	storageUsageService filesyncer.StorageUsageService,
 	cloudOnlyDeleteService filesyncer.CloudOnlyDeleteService,
 	getFileUseCase uc_file.GetFileUseCase,
 	getUserByIsLoggedInUseCase uc_user.GetByIsLoggedInUseCase,
@@ -85,10 +102,17 @@ func NewRootCmd(
 	syncFileService svc_sync.SyncFileService,
 	syncFullService svc_sync.SyncFullService,
 	syncDebugService svc_sync.SyncDebugService,
+	syncDoctorService svc_sync.SyncDoctorService,
+	syncDiffService svc_sync.SyncDiffService,
+	syncInspectService svc_sync.SyncInspectService,
+	journalShowService svc_journal.ShowService,
 	synchronizedSharingService collectionsharing.SynchronizedCollectionSharingService,
 	originalSharingService collectionsharing.CollectionSharingService,
+	rewrapMemberKeyService collectionsharing.RewrapMemberKeyService,
 	getMeService svc_me.GetMeService,
 	updateMeService svc_me.UpdateMeService,
+	masterKeyRotationService keyrotation.MasterKeyRotationService,
+	trustedKeyService keytrust.TrustedKeyService,
 ) *cobra.Command {
 	var rootCmd = &cobra.Command{
 		Use:   "maplefile-cli",
@@ -106,13 +130,16 @@ Quick start:
 
 Core commands:
   login         Log in to your account
-  collections   Manage collections (create, list, delete, restore, share)
+  collections   Manage collections (create, list, archive, delete, restore, share)
   files         Manage files (add, list, get, delete)
+  export        Export a read-only snapshot of your decrypted library
   sync          Synchronize with cloud (unified sync + debug)
   me            View and update your profile
+  keys          Manage trusted recipient keys
 
 Advanced:
   config        Configure CLI settings
+  journal       Inspect the log of local file and collection changes
   health        Check server connectivity
   recovery      Account recovery options
 
@@ -130,8 +157,10 @@ For detailed help: maplefile-cli COMMAND --help`,
 	rootCmd.AddCommand(cmd_md.MeCmd(
 		getMeService,
 		updateMeService,
+		masterKeyRotationService,
 		logger,
 	))
+	rootCmd.AddCommand(keys.KeysCmd(trustedKeyService, logger))
 
 	rootCmd.AddCommand(register.RegisterCmd(regService))
 	rootCmd.AddCommand(verifyemail.VerifyEmailCmd(emailVerificationService, logger))
@@ -157,6 +186,7 @@ For detailed help: maplefile-cli COMMAND --help`,
 	rootCmd.AddCommand(recovery.UnifiedRecoveryCmd(
 		recoveryService,
 		recoveryKeyService,
+		recoveryCleanupService,
 		logger,
 	))
 
@@ -168,6 +198,9 @@ For detailed help: maplefile-cli COMMAND --help`,
 		createCollectionService,
 		collectionListService,
 		collectionSoftDeleteService,
+		collectionArchiveService,
+		collectionHistoryService,
+		collectionSyncPreferenceService,
 		listFromCloudService,
 		collectionSharingService,
 		collectionGetMembersService,
@@ -175,6 +208,7 @@ For detailed help: maplefile-cli COMMAND --help`,
 		collectionRemoveMemberService,
 		synchronizedSharingService,
 		originalSharingService,
+		rewrapMemberKeyService,
 		logger,
 	))
 
@@ -186,19 +220,26 @@ For detailed help: maplefile-cli COMMAND --help`,
 		logger,
 		addFileService,
 		uploadFileService,
+		batchUploadFileService,
 		listFileService,
 		localOnlyDeleteService,
 		downloadService,
 		offloadService,
 		onloadService,
+		batchOnloadService,
 		cloudOnlyDeleteService,
+		storageUsageService,
 		lockService,
 		unlockService,
+		moveFileService,
+		findSimilarService,
 		getFileUseCase,
 		getUserByIsLoggedInUseCase,
 		getCollectionUseCase,
 	))
 
+	rootCmd.AddCommand(export.ExportCmd(exportService, logger))
+
 	// ========================================
 	// SYNC
 	// ========================================
@@ -207,7 +248,13 @@ For detailed help: maplefile-cli COMMAND --help`,
 	rootCmd.AddCommand(sync.SyncCmd(
 		syncCollectionService,
 		syncFileService,
+		autoOnloadService,
+		storageUsageService,
 		syncDebugService,
+		syncDoctorService,
+		syncDiffService,
+		syncInspectService,
+		configService,
 		logger,
 	))
 
@@ -216,11 +263,15 @@ For detailed help: maplefile-cli COMMAND --help`,
 	// ========================================
 	rootCmd.AddCommand(healthcheck.HealthCheckCmd(configService))
 	rootCmd.AddCommand(config_cmd.ConfigCmd(configService))
+	rootCmd.AddCommand(journal.JournalCmd(configService, journalShowService, logger))
 	rootCmd.AddCommand(version.VersionCmd())
 	rootCmd.AddCommand(cloud.CloudCmd(
 		configService,
 		getPublicLookupFromCloudUseCase,
 		logger))
 
+	// Must run last so every command registered above gets a deadline
+	addTimeoutFlag(rootCmd)
+
 	return rootCmd
 }
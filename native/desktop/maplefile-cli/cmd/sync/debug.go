@@ -2,12 +2,15 @@
 package sync
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
 	svc_sync "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/sync"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
 )
 
 // debugCmd creates a command for debugging sync operations
@@ -19,6 +22,7 @@ func debugCmd(
 	var checkAuth bool
 	var checkNetwork bool
 	var checkSyncState bool
+	var output string
 
 	var cmd = &cobra.Command{
 		Use:   "debug",
@@ -43,6 +47,9 @@ Examples:
 
   # Check all explicitly
   maplefile-cli sync debug --auth --network --sync-state --password mypass
+
+  # Print the network check as JSON, with timings and diagnosis codes
+  maplefile-cli sync debug --network --output json
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			// If no specific checks requested, default to all
@@ -59,7 +66,14 @@ Examples:
 				return
 			}
 
-			fmt.Println("🔍 Running sync diagnostics...")
+			if output != "text" && output != "json" {
+				fmt.Printf("❌ Error: Unknown output format %q, use text or json.\n", output)
+				return
+			}
+
+			if output == "text" {
+				fmt.Println("🔍 Running sync diagnostics...")
+			}
 
 			// Create input for debug service
 			input := &svc_sync.DebugSyncInput{
@@ -76,6 +90,16 @@ Examples:
 				return
 			}
 
+			if output == "json" {
+				data, err := json.MarshalIndent(result, "", "  ")
+				if err != nil {
+					fmt.Printf("❌ Error encoding diagnostics: %v\n", err)
+					return
+				}
+				fmt.Println(string(data))
+				return
+			}
+
 			// Display results
 			fmt.Println("\n📊 Diagnostic Results:")
 
@@ -85,6 +109,41 @@ Examples:
 
 			if checkNetwork {
 				fmt.Printf("🌐 Network: %s\n", result.NetworkStatus)
+				for _, endpoint := range result.Endpoints {
+					marker := " "
+					if endpoint.Active {
+						marker = "*"
+					}
+					health := "healthy"
+					if !endpoint.Healthy {
+						health = fmt.Sprintf("unhealthy until %s (%s)", endpoint.UnhealthyUntil.Local().Format("15:04:05"), endpoint.LastError)
+					}
+					fmt.Printf("   %s %s: %s\n", marker, endpoint.URL, health)
+				}
+				if breaker := result.CircuitBreaker; breaker != nil && breaker.State != httpclient.BreakerClosed {
+					fmt.Printf("🔌 Circuit breaker: %s", breaker.State)
+					if !breaker.OpenUntil.IsZero() {
+						fmt.Printf(" until %s", breaker.OpenUntil.Local().Format("15:04:05"))
+					}
+					fmt.Println()
+				}
+				if result.ClockSkew != 0 {
+					fmt.Printf("🕒 Clock skew: %v\n", result.ClockSkew)
+				}
+				if network := result.Network; network != nil && network.Reachable {
+					fmt.Printf("⏱️  Latency: %v (DNS %v, TLS %v", network.Latency, network.DNSLookup, network.TLSHandshake)
+					if network.ConnectionReused {
+						fmt.Print(", reused connection")
+					}
+					fmt.Println(")")
+				}
+				if network := result.Network; network != nil && network.Endpoint != "" {
+					if network.TokenValid {
+						fmt.Println("🎫 Access token: valid")
+					} else {
+						fmt.Println("🎫 Access token: expired or missing")
+					}
+				}
 			}
 
 			if checkSyncState {
@@ -129,6 +188,8 @@ Examples:
 	cmd.Flags().BoolVar(&checkAuth, "auth", false, "Check authentication status")
 	cmd.Flags().BoolVar(&checkNetwork, "network", false, "Check network connectivity")
 	cmd.Flags().BoolVar(&checkSyncState, "sync-state", false, "Check sync state consistency")
+	cmd.Flags().StringVar(&output, "output", "text", "Output format (text or json)")
+	promptpassword.AddStdinFlag(cmd, &password, false)
 
 	return cmd
 }
@@ -0,0 +1,129 @@
+// cmd/sync/diff.go - Preview the changes a sync would make
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	dom_syncdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/syncdto"
+	svc_sync "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/sync"
+)
+
+// diffCmd creates a command for comparing local and cloud state without syncing
+func diffCmd(
+	syncDiffService svc_sync.SyncDiffService,
+	logger *zap.Logger,
+) *cobra.Command {
+	var collections bool
+	var files bool
+	var batchSize int64
+	var maxBatches int
+	var output string
+
+	var cmd = &cobra.Command{
+		Use:   "diff",
+		Short: "Show what a sync would change locally",
+		Long: `
+Compare the cloud changes since your last sync against your local collections
+and files, and list every add, update, delete and conflict a sync would apply.
+Nothing is changed locally.
+
+A conflict is a cloud change to a record which has unsynced local modifications.
+
+Examples:
+  # Show all pending changes
+  maplefile-cli sync diff
+
+  # Show only pending file changes
+  maplefile-cli sync diff --files
+
+  # Machine readable output
+  maplefile-cli sync diff --output json
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if output != "text" && output != "json" {
+				fmt.Printf("❌ Error: Unsupported output format: %s (use text or json)\n", output)
+				return
+			}
+
+			// The same batch size is used for collections and files, so cap it at the lower maximum
+			batchSize = capBatchSize("batch-size", batchSize, dom_syncdto.MaxCollectionSyncBatchSize)
+
+			input := &svc_sync.DiffInput{
+				Collections: collections,
+				Files:       files,
+				BatchSize:   batchSize,
+				MaxBatches:  maxBatches,
+			}
+
+			result, err := syncDiffService.Diff(cmd.Context(), input)
+			if err != nil {
+				fmt.Printf("❌ Diff failed: %v\n", err)
+				return
+			}
+
+			if output == "json" {
+				data, err := json.MarshalIndent(result, "", "  ")
+				if err != nil {
+					fmt.Printf("❌ Error encoding diff: %v\n", err)
+					return
+				}
+				fmt.Println(string(data))
+				return
+			}
+
+			if len(result.Entries) == 0 {
+				fmt.Println("✅ Everything is up to date - a sync would not change anything.")
+			} else {
+				fmt.Printf("🔍 A sync would make %d change(s):\n\n", len(result.Entries))
+				for _, entry := range result.Entries {
+					localVersion := "-"
+					if entry.LocalVersion != nil {
+						localVersion = fmt.Sprintf("%d", *entry.LocalVersion)
+					}
+					fmt.Printf("   %s %-10s %-8s %s (local v%s → cloud v%d, %s)\n",
+						diffActionSymbol(entry.Action), entry.Type, entry.Action, entry.ID.String(),
+						localVersion, entry.CloudVersion, entry.CloudState)
+				}
+				fmt.Printf("\n📊 Adds: %d, Updates: %d, Deletes: %d, Conflicts: %d\n",
+					result.Adds, result.Updates, result.Deletes, result.Conflicts)
+			}
+			if result.HasMoreData {
+				fmt.Println("⚠️  More changes exist in the cloud than were fetched. Increase --max-batches to see them all.")
+			}
+
+			logger.Info("Sync diff completed",
+				zap.Int("adds", result.Adds),
+				zap.Int("updates", result.Updates),
+				zap.Int("deletes", result.Deletes),
+				zap.Int("conflicts", result.Conflicts))
+		},
+	}
+
+	cmd.Flags().BoolVar(&collections, "collections", false, "Compare only collections")
+	cmd.Flags().BoolVar(&files, "files", false, "Compare only files")
+	cmd.Flags().Int64Var(&batchSize, "batch-size", 50, fmt.Sprintf("Items per batch (max %d)", dom_syncdto.MaxCollectionSyncBatchSize))
+	cmd.Flags().IntVar(&maxBatches, "max-batches", 100, "Maximum batches to fetch")
+	cmd.Flags().StringVar(&output, "output", "text", "Output format (text or json)")
+
+	return cmd
+}
+
+// diffActionSymbol returns the symbol displayed in front of a diff entry
+func diffActionSymbol(action svc_sync.SyncAction) string {
+	switch action {
+	case svc_sync.SyncActionAdd:
+		return "➕"
+	case svc_sync.SyncActionUpdate:
+		return "🔄"
+	case svc_sync.SyncActionDelete:
+		return "🗑️"
+	case svc_sync.SyncActionConflict:
+		return "⚠️"
+	default:
+		return " "
+	}
+}
@@ -0,0 +1,120 @@
+// cmd/sync/doctor.go - Local consistency check between collections and files
+package sync
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	svc_sync "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/sync"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
+)
+
+// doctorCmd creates a command for checking consistency between local collections and files
+func doctorCmd(
+	syncDoctorService svc_sync.SyncDoctorService,
+	logger *zap.Logger,
+) *cobra.Command {
+	var password string
+	var repair bool
+	var checkCloud bool
+
+	var cmd = &cobra.Command{
+		Use:   "doctor",
+		Short: "Check consistency between local collections and files",
+		Long: `
+Check the referential consistency of your local data.
+
+After a partial sync a file may reference a collection which no longer exists
+locally, or a collection may be missing files which exist in the cloud. This
+command detects both cases and can optionally repair them by fetching the
+missing records from the cloud.
+
+Examples:
+  # Find files whose collection is missing locally
+  maplefile-cli sync doctor
+
+  # Also compare every local collection against its cloud files
+  maplefile-cli sync doctor --cloud --password mypass
+
+  # Detect and repair all problems
+  maplefile-cli sync doctor --cloud --repair --password mypass
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if (repair || checkCloud) && password == "" {
+				fmt.Println("❌ Error: Password is required to repair or check against the cloud.")
+				fmt.Println("Use --password flag to specify your account password.")
+				return
+			}
+
+			fmt.Println("🩺 Checking local collections and files...")
+
+			input := &svc_sync.DoctorInput{
+				Repair:     repair,
+				CheckCloud: checkCloud,
+				Password:   password,
+			}
+
+			result, err := syncDoctorService.Diagnose(cmd.Context(), input)
+			if err != nil {
+				fmt.Printf("❌ Consistency check failed: %v\n", err)
+				return
+			}
+
+			fmt.Printf("\n📊 Checked %d collection(s) and %d file(s)\n", result.CollectionsChecked, result.FilesChecked)
+
+			if len(result.OrphanedFiles) > 0 {
+				fmt.Printf("\n⚠️  Files without a local collection (%d):\n", len(result.OrphanedFiles))
+				for _, orphan := range result.OrphanedFiles {
+					fmt.Printf("   • %s (%s) → collection %s%s\n",
+						orphan.FileID.String(), orphan.Name, orphan.CollectionID.String(), repairStatus(repair, orphan.Repaired, orphan.Error))
+				}
+			}
+
+			if len(result.MissingFiles) > 0 {
+				fmt.Printf("\n⚠️  Cloud files missing locally (%d):\n", len(result.MissingFiles))
+				for _, missing := range result.MissingFiles {
+					fmt.Printf("   • %s in collection %s%s\n",
+						missing.FileID.String(), missing.CollectionID.String(), repairStatus(repair, missing.Repaired, missing.Error))
+				}
+			}
+
+			if len(result.Issues) == 0 {
+				fmt.Println("\n✅ No consistency issues detected!")
+			} else {
+				fmt.Printf("\n🔧 Found %d issue(s):\n", len(result.Issues))
+				for i, issue := range result.Issues {
+					fmt.Printf("   %d. %s\n", i+1, issue)
+				}
+				if !repair {
+					fmt.Println("\n💡 Run again with --repair --password PASSWORD to fetch the missing records from the cloud.")
+				}
+			}
+
+			logger.Info("Sync consistency check completed",
+				zap.Bool("repair", repair),
+				zap.Bool("checkCloud", checkCloud),
+				zap.Int("orphanedFiles", len(result.OrphanedFiles)),
+				zap.Int("missingFiles", len(result.MissingFiles)))
+		},
+	}
+
+	cmd.Flags().StringVar(&password, "password", "", "User password (required for --repair and --cloud)")
+	cmd.Flags().BoolVar(&repair, "repair", false, "Fetch missing collections and files from the cloud")
+	cmd.Flags().BoolVar(&checkCloud, "cloud", false, "Compare local collections against their cloud files")
+	promptpassword.AddStdinFlag(cmd, &password, false)
+
+	return cmd
+}
+
+// repairStatus returns a short suffix describing the outcome of a repair attempt
+func repairStatus(repair bool, repaired bool, errMsg string) string {
+	if !repair {
+		return ""
+	}
+	if repaired {
+		return " ✅ repaired"
+	}
+	return fmt.Sprintf(" ❌ not repaired: %s", errMsg)
+}
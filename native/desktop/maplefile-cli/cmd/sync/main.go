@@ -5,6 +5,8 @@ import (
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filesyncer"
 	svc_sync "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/sync"
 )
 
@@ -12,11 +14,17 @@ import (
 func SyncCmd(
 	syncCollectionService svc_sync.SyncCollectionService,
 	syncFileService svc_sync.SyncFileService,
+	autoOnloadService filesyncer.AutoOnloadService,
+	storageUsageService filesyncer.StorageUsageService,
 	syncDebugService svc_sync.SyncDebugService,
+	syncDoctorService svc_sync.SyncDoctorService,
+	syncDiffService svc_sync.SyncDiffService,
+	syncInspectService svc_sync.SyncInspectService,
+	configService config.ConfigService,
 	logger *zap.Logger,
 ) *cobra.Command {
 	// Create the main sync command (unified)
-	mainSyncCmd := syncCmd(syncCollectionService, syncFileService, logger)
+	mainSyncCmd := syncCmd(syncCollectionService, syncFileService, autoOnloadService, storageUsageService, logger)
 
 	// Set up the parent command that can have subcommands
 	var cmd = &cobra.Command{
@@ -25,7 +33,7 @@ func SyncCmd(
 		Long: `
 Synchronize your collections and files with the MapleFile cloud backend.
 
-This command has two modes:
+This command has the following modes:
 
 1. Direct sync (recommended):
    maplefile-cli sync [flags]
@@ -38,6 +46,26 @@ This command has two modes:
 
    Diagnoses sync issues and provides recommendations.
 
+3. Doctor mode:
+   maplefile-cli sync doctor [flags]
+
+   Checks consistency between local collections and files.
+
+4. Diff mode:
+   maplefile-cli sync diff [flags]
+
+   Lists the changes a sync would make without changing anything.
+
+5. Inspect mode:
+   maplefile-cli sync inspect ID [flags]
+
+   Compares the local and cloud state of a single collection or file.
+
+6. Watch mode:
+   maplefile-cli sync watch [flags]
+
+   Keeps syncing by polling the cloud for changes until interrupted.
+
 Examples:
   # Sync everything (recommended)
   maplefile-cli sync --password mypass
@@ -54,9 +82,22 @@ Examples:
   # Quick network check
   maplefile-cli sync debug --network
 
+  # Find and repair files whose collection is missing locally
+  maplefile-cli sync doctor --repair --password mypass
+
+  # Preview what a sync would change
+  maplefile-cli sync diff
+
+  # Find out why a single file won't sync
+  maplefile-cli sync inspect FILE_ID --password mypass
+
+  # Keep syncing until interrupted
+  maplefile-cli sync watch --password mypass
+
 The sync process is incremental and only processes changes since the last sync.
 `,
-		Run: mainSyncCmd.Run, // Delegate to the main sync command by default
+		PreRunE: mainSyncCmd.PreRunE, // Reads --password-stdin before delegating
+		Run:     mainSyncCmd.Run,     // Delegate to the main sync command by default
 	}
 
 	// Copy flags from main sync command to parent
@@ -65,5 +106,17 @@ The sync process is incremental and only processes changes since the last sync.
 	// Add debug subcommand
 	cmd.AddCommand(debugCmd(syncDebugService, logger))
 
+	// Add doctor subcommand
+	cmd.AddCommand(doctorCmd(syncDoctorService, logger))
+
+	// Add diff subcommand
+	cmd.AddCommand(diffCmd(syncDiffService, logger))
+
+	// Add inspect subcommand
+	cmd.AddCommand(inspectCmd(syncInspectService, logger))
+
+	// Add watch subcommand
+	cmd.AddCommand(watchCmd(syncCollectionService, syncFileService, configService, logger))
+
 	return cmd
 }
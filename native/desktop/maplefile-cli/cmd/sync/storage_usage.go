@@ -0,0 +1,42 @@
+// cmd/sync/storage_usage.go
+package sync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/repo/filedto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filesyncer"
+)
+
+// checkStorageUsage warns when the onloaded files exceed the storage quota, listing the least
+// recently accessed files to offload or the files offloaded automatically
+func checkStorageUsage(ctx context.Context, storageUsageService filesyncer.StorageUsageService, password string) {
+	output, err := storageUsageService.Check(ctx, &filesyncer.StorageUsageInput{UserPassword: password})
+	if err != nil {
+		fmt.Printf("⚠️  Could not check the storage quota: %v\n", err)
+		return
+	}
+
+	if len(output.Offloaded) > 0 {
+		fmt.Printf("\n🗑️  Auto offloaded %d file(s) to stay under the %s storage quota:\n",
+			len(output.Offloaded), filedto.FormatFileSize(output.QuotaBytes))
+		for _, file := range output.Offloaded {
+			fmt.Printf("   • %s (%s)\n", file.Name, filedto.FormatFileSize(file.LocalSize))
+		}
+	}
+	if !output.OverQuota {
+		return
+	}
+
+	fmt.Printf("\n⚠️  Local files use %s, over the %s storage quota.\n",
+		filedto.FormatFileSize(output.UsedBytes), filedto.FormatFileSize(output.QuotaBytes))
+	if len(output.Suggested) == 0 {
+		return
+	}
+	fmt.Println("💡 Consider offloading these least recently accessed files:")
+	for _, file := range output.Suggested {
+		fmt.Printf("   • %s (%s) - maplefile-cli files filesync offload --file-id %s\n",
+			file.Name, filedto.FormatFileSize(file.LocalSize), file.FileID.String())
+	}
+}
@@ -3,20 +3,28 @@ package sync
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/gocql/gocql"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	dom_syncdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/syncdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filesyncer"
 	svc_sync "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/sync"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
 )
 
 // syncCmd creates a unified command for synchronizing data
 func syncCmd(
 	syncCollectionService svc_sync.SyncCollectionService,
 	syncFileService svc_sync.SyncFileService,
+	autoOnloadService filesyncer.AutoOnloadService,
+	storageUsageService filesyncer.StorageUsageService,
 	logger *zap.Logger,
 ) *cobra.Command {
 	var collections bool
@@ -25,6 +33,10 @@ func syncCmd(
 	var fileBatchSize int64
 	var maxBatches int
 	var password string
+	var prune bool
+	var autoOnloadMode string
+	var autoOnloadCollections []string
+	var autoOnloadMaxSize string
 
 	var cmd = &cobra.Command{
 		Use:   "sync",
@@ -36,9 +48,25 @@ By default, syncs both collections and files. Use flags to sync only specific ty
   --collections    Sync only collections
   --files          Sync only file metadata
 
+Files deleted in the cloud are removed from the local index. Use --prune to
+also remove their downloaded data and thumbnails from the MapleFile data
+directory. Files outside of the data directory are never touched.
+
+Files added by the sync are cloud-only, only their metadata is stored locally.
+Use --auto-onload to also download and decrypt them once the sync completes:
+  never           Keep new files cloud-only (default)
+  all             Onload every new file
+  by-collection   Onload new files of the --auto-onload-collection collections
+  under-size      Onload new files up to --auto-onload-max-size
+Without the flag the policy from 'maplefile-cli config auto-onload' is used.
+Files which would leave less than 1GB of free disk space are never onloaded.
+
 The sync process is incremental, only processing changes since the last sync.
 File content remains in the cloud until explicitly downloaded.
 
+The cloud returns at most ` + strconv.FormatInt(dom_syncdto.MaxCollectionSyncBatchSize, 10) + ` collections and ` + strconv.FormatInt(dom_syncdto.MaxFileSyncBatchSize, 10) + ` files per batch. Larger
+batch sizes are capped at these with a warning.
+
 Examples:
   # Sync everything (collections + files) - recommended
   maplefile-cli sync --password mypass
@@ -54,6 +82,12 @@ Examples:
 
   # Custom batch sizes for large datasets
   maplefile-cli sync --collection-batch-size 25 --file-batch-size 30 --password mypass
+
+  # Also remove downloaded data of files deleted in the cloud
+  maplefile-cli sync --prune --password mypass
+
+  # Onload new files of up to 50MB
+  maplefile-cli sync --auto-onload under-size --auto-onload-max-size 50MB --password mypass
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			startTime := time.Now()
@@ -64,6 +98,15 @@ Examples:
 				return
 			}
 
+			autoOnloadPolicy, err := autoOnloadPolicyFromFlags(cmd, autoOnloadMode, autoOnloadCollections, autoOnloadMaxSize)
+			if err != nil {
+				fmt.Printf("❌ Error: %v\n", err)
+				return
+			}
+
+			collectionBatchSize = capBatchSize("collection-batch-size", collectionBatchSize, dom_syncdto.MaxCollectionSyncBatchSize)
+			fileBatchSize = capBatchSize("file-batch-size", fileBatchSize, dom_syncdto.MaxFileSyncBatchSize)
+
 			// Determine what to sync
 			syncCollections := collections
 			syncFiles := files
@@ -77,7 +120,7 @@ Examples:
 			fmt.Println("🔄 Starting synchronization...")
 			fmt.Println("📡 Connecting to cloud backend...")
 
-			var totalErrors []string
+			var totalErrors []dom_syncdto.SyncError
 			var collectionsResult *dom_syncdto.SyncResult
 			var filesResult *dom_syncdto.SyncResult
 
@@ -95,7 +138,7 @@ Examples:
 				collectionsResult, err = syncCollectionService.Execute(cmd.Context(), collectionInput)
 				if err != nil {
 					fmt.Printf("❌ Collection sync failed: %v\n", err)
-					totalErrors = append(totalErrors, fmt.Sprintf("Collections: %v", err))
+					totalErrors = append(totalErrors, svc_sync.NewSyncError("", "sync_collections", "collection sync failed", err))
 				} else {
 					fmt.Printf("✅ Collections synchronized!\n")
 					fmt.Printf("   • Processed: %d collections\n", collectionsResult.CollectionsProcessed)
@@ -124,13 +167,14 @@ Examples:
 					BatchSize:  fileBatchSize,
 					MaxBatches: maxBatches,
 					Password:   password,
+					Prune:      prune,
 				}
 
 				var err error
 				filesResult, err = syncFileService.Execute(cmd.Context(), fileInput)
 				if err != nil {
 					fmt.Printf("❌ File sync failed: %v\n", err)
-					totalErrors = append(totalErrors, fmt.Sprintf("Files: %v", err))
+					totalErrors = append(totalErrors, svc_sync.NewSyncError("", "sync_files", "file sync failed", err))
 				} else {
 					fmt.Printf("✅ File metadata synchronized!\n")
 					fmt.Printf("   • Processed: %d files\n", filesResult.FilesProcessed)
@@ -139,6 +183,8 @@ Examples:
 					}
 					if filesResult.FilesUpdated > 0 {
 						fmt.Printf("   • 🔄 Updated: %d\n", filesResult.FilesUpdated)
+						fmt.Printf("     - 🏷️  Metadata only: %d\n", filesResult.FilesMetadataOnlyUpdated)
+						fmt.Printf("     - 📦 Content changed: %d\n", filesResult.FilesContentUpdated)
 					}
 					if filesResult.FilesDeleted > 0 {
 						fmt.Printf("   • 🗑️  Deleted: %d\n", filesResult.FilesDeleted)
@@ -148,6 +194,8 @@ Examples:
 						fmt.Printf("   • ⚠️  Errors: %d\n", len(filesResult.Errors))
 						totalErrors = append(totalErrors, filesResult.Errors...)
 					}
+
+					totalErrors = append(totalErrors, autoOnload(cmd, autoOnloadService, autoOnloadPolicy, filesResult.AddedFileIDs, password)...)
 				}
 			}
 
@@ -157,20 +205,32 @@ Examples:
 
 			if len(totalErrors) > 0 {
 				fmt.Printf("⚠️  Synchronization completed with %d error(s):\n", len(totalErrors))
-				for i, err := range totalErrors {
+				for i, syncErr := range totalErrors {
 					if i < 5 { // Show first 5 errors
-						fmt.Printf("   %d. %s\n", i+1, err)
+						status := "permanent"
+						if syncErr.Retryable {
+							status = "retryable"
+						}
+						fmt.Printf("   %d. [%s] %s\n", i+1, status, syncErr.String())
 					}
 				}
 				if len(totalErrors) > 5 {
 					fmt.Printf("   ... and %d more errors\n", len(totalErrors)-5)
 				}
+				summary := &dom_syncdto.SyncResult{Errors: totalErrors}
+				if retryable := summary.RetryableErrors(); len(retryable) > 0 {
+					fmt.Printf("💡 %d error(s) may succeed if you run the sync again.\n", len(retryable))
+				}
 			} else {
 				fmt.Printf("✅ Synchronization completed successfully!\n")
 			}
 
 			fmt.Printf("⏱️  Duration: %v\n", duration.Round(time.Millisecond))
 
+			if syncFiles {
+				checkStorageUsage(cmd.Context(), storageUsageService, password)
+			}
+
 			// Summary
 			totalProcessed := 0
 			if collectionsResult != nil {
@@ -200,6 +260,7 @@ Examples:
 			logger.Info("Sync completed",
 				zap.Bool("syncedCollections", syncCollections),
 				zap.Bool("syncedFiles", syncFiles),
+				zap.Bool("prune", prune),
 				zap.Int("totalErrors", len(totalErrors)),
 				zap.Duration("duration", duration))
 		},
@@ -208,13 +269,103 @@ Examples:
 	// Define flags
 	cmd.Flags().BoolVar(&collections, "collections", false, "Sync only collections")
 	cmd.Flags().BoolVar(&files, "files", false, "Sync only file metadata")
-	cmd.Flags().Int64Var(&collectionBatchSize, "collection-batch-size", 50, "Collections per batch")
-	cmd.Flags().Int64Var(&fileBatchSize, "file-batch-size", 50, "Files per batch")
+	cmd.Flags().Int64Var(&collectionBatchSize, "collection-batch-size", 50, fmt.Sprintf("Collections per batch (max %d)", dom_syncdto.MaxCollectionSyncBatchSize))
+	cmd.Flags().Int64Var(&fileBatchSize, "file-batch-size", 50, fmt.Sprintf("Files per batch (max %d)", dom_syncdto.MaxFileSyncBatchSize))
 	cmd.Flags().IntVar(&maxBatches, "max-batches", 100, "Maximum batches to process")
 	cmd.Flags().StringVar(&password, "password", "", "Your account password (required for E2EE)")
+	cmd.Flags().BoolVar(&prune, "prune", false, "Also remove downloaded data of files deleted in the cloud")
+	cmd.Flags().StringVar(&autoOnloadMode, "auto-onload", "", "Onload new files after the sync: never, all, by-collection or under-size (defaults to configured policy)")
+	cmd.Flags().StringArrayVar(&autoOnloadCollections, "auto-onload-collection", nil, "Collection whose new files are onloaded by --auto-onload by-collection (repeatable)")
+	cmd.Flags().StringVar(&autoOnloadMaxSize, "auto-onload-max-size", "", "Largest file onloaded by --auto-onload, such as 50MB (required by under-size)")
 
 	// Mark required flags
-	cmd.MarkFlagRequired("password")
+	promptpassword.AddStdinFlag(cmd, &password, true)
 
 	return cmd
 }
+
+// capBatchSize caps a batch size flag at the largest batch the cloud accepts. The warning goes to
+// stderr so it doesn't break machine readable output.
+func capBatchSize(flagName string, batchSize int64, max int64) int64 {
+	if batchSize <= max {
+		return batchSize
+	}
+	fmt.Fprintf(os.Stderr, "⚠️ --%s %d exceeds the cloud maximum of %d, using %d\n", flagName, batchSize, max, max)
+	return max
+}
+
+// autoOnloadPolicyFromFlags builds the auto onload policy given on the command line, or returns nil
+// to use the configured policy when --auto-onload isn't passed.
+func autoOnloadPolicyFromFlags(cmd *cobra.Command, mode string, collectionIDs []string, maxSize string) (*config.AutoOnloadPolicy, error) {
+	if !cmd.Flags().Changed("auto-onload") {
+		if cmd.Flags().Changed("auto-onload-collection") || cmd.Flags().Changed("auto-onload-max-size") {
+			return nil, fmt.Errorf("--auto-onload-collection and --auto-onload-max-size require --auto-onload")
+		}
+		return nil, nil
+	}
+
+	policy := &config.AutoOnloadPolicy{
+		Mode:          mode,
+		CollectionIDs: collectionIDs,
+	}
+	for _, collectionID := range collectionIDs {
+		if _, err := gocql.ParseUUID(collectionID); err != nil {
+			return nil, fmt.Errorf("invalid collection ID format %s: %v", collectionID, err)
+		}
+	}
+	if maxSize != "" {
+		size, err := config.ParseByteSize(maxSize)
+		if err != nil {
+			return nil, err
+		}
+		policy.MaxFileSize = size
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// autoOnload onloads the files added by the sync according to the auto onload policy, returning the
+// failures as sync errors
+func autoOnload(
+	cmd *cobra.Command,
+	autoOnloadService filesyncer.AutoOnloadService,
+	policy *config.AutoOnloadPolicy,
+	addedFileIDs []gocql.UUID,
+	password string,
+) []dom_syncdto.SyncError {
+	output, err := autoOnloadService.OnloadAfterSync(cmd.Context(), &filesyncer.AutoOnloadInput{
+		FileIDs:      addedFileIDs,
+		Policy:       policy,
+		UserPassword: password,
+	})
+	if err != nil {
+		fmt.Printf("❌ Auto onload failed: %v\n", err)
+		return []dom_syncdto.SyncError{svc_sync.NewSyncError("", dom_syncdto.SyncOperationAutoOnload, "auto onload failed", err)}
+	}
+	if output.Batch == nil && output.SkippedTooLarge == 0 && output.SkippedDiskSpace == 0 {
+		return nil
+	}
+
+	fmt.Printf("\n📥 Auto onloading new files (%s)...\n", output.Mode)
+	var syncErrors []dom_syncdto.SyncError
+	if output.Batch != nil {
+		fmt.Printf("   • ✅ Onloaded: %d\n", output.Batch.SuccessCount)
+		for _, result := range output.Batch.Results {
+			if result.Error != nil {
+				syncErrors = append(syncErrors, svc_sync.NewSyncError(result.FileID.String(), dom_syncdto.SyncOperationAutoOnload, "onload failed", result.Error))
+			}
+		}
+		if len(syncErrors) > 0 {
+			fmt.Printf("   • ⚠️  Errors: %d\n", len(syncErrors))
+		}
+	}
+	if output.SkippedTooLarge > 0 {
+		fmt.Printf("   • ⏭️  Skipped, larger than the maximum file size: %d\n", output.SkippedTooLarge)
+	}
+	if output.SkippedDiskSpace > 0 {
+		fmt.Printf("   • ⏭️  Skipped, not enough free disk space: %d\n", output.SkippedDiskSpace)
+	}
+	return syncErrors
+}
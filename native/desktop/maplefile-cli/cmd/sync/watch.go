@@ -0,0 +1,153 @@
+// cmd/sync/watch.go - Keep the local data in sync until interrupted
+package sync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	svc_sync "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/sync"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/promptpassword"
+)
+
+// watchModePolling is the mode sync watch reports while it polls the cloud for changes
+const watchModePolling = "polling"
+
+// watchCmd creates a command which keeps syncing with the cloud until interrupted
+func watchCmd(
+	syncCollectionService svc_sync.SyncCollectionService,
+	syncFileService svc_sync.SyncFileService,
+	configService config.ConfigService,
+	logger *zap.Logger,
+) *cobra.Command {
+	var password string
+	var interval time.Duration
+
+	var cmd = &cobra.Command{
+		Use:   "watch",
+		Short: "Keep syncing with the cloud until interrupted",
+		Long: `
+Keep your collections and file metadata in sync with the MapleFile cloud backend
+until interrupted with Ctrl+C.
+
+The cloud doesn't push changes to clients, so watch polls it for changes at an
+interval. Polling only needs ordinary HTTPS requests, so it works behind any
+proxy which allows the regular sync, at the cost of picking up changes up to one
+interval late. Each poll is an incremental sync which only processes changes
+since the previous one.
+
+The interval defaults to the one configured with:
+  maplefile-cli config sync-watch-interval DURATION
+
+Examples:
+  # Watch for changes at the configured interval
+  maplefile-cli sync watch --password mypass
+
+  # Watch for changes every 30 seconds
+  maplefile-cli sync watch --interval 30s --password mypass
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			if password == "" {
+				fmt.Println("❌ Error: Password is required for E2EE operations.")
+				fmt.Println("Use --password flag to specify your account password.")
+				return
+			}
+
+			if !cmd.Flags().Changed("interval") {
+				var err error
+				if interval, err = configService.GetSyncWatchPollInterval(ctx); err != nil {
+					fmt.Printf("❌ Error getting sync watch interval: %v\n", err)
+					return
+				}
+			}
+			if interval < config.MinSyncWatchPollInterval {
+				fmt.Printf("❌ Error: interval must be at least %s\n", config.MinSyncWatchPollInterval)
+				return
+			}
+
+			fmt.Printf("👀 Watching for changes by polling the cloud every %s, press Ctrl+C to stop\n", interval)
+			logger.Info("👀 Watching for changes",
+				zap.String("mode", watchModePolling),
+				zap.Duration("interval", interval))
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				watchSyncPass(ctx, syncCollectionService, syncFileService, password, logger)
+
+				select {
+				case <-ctx.Done():
+					fmt.Println("\n👋 Stopped watching for changes")
+					return
+				case <-ticker.C:
+				}
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&password, "password", "", "Your account password (required for E2EE)")
+	cmd.Flags().DurationVar(&interval, "interval", config.DefaultSyncWatchPollInterval, "How often to poll the cloud for changes, such as 30s (defaults to the configured interval)")
+
+	promptpassword.AddStdinFlag(cmd, &password, true)
+
+	return cmd
+}
+
+// watchSyncPass syncs collections then file metadata once and prints a one line summary. Failures
+// are reported and left to the next pass, since the sync is incremental.
+func watchSyncPass(
+	ctx context.Context,
+	syncCollectionService svc_sync.SyncCollectionService,
+	syncFileService svc_sync.SyncFileService,
+	password string,
+	logger *zap.Logger,
+) {
+	timestamp := time.Now().Format("15:04:05")
+
+	var added, updated, deleted, errorCount int
+	collectionsResult, err := syncCollectionService.Execute(ctx, &svc_sync.SyncCollectionsInput{Password: password})
+	if err != nil {
+		if ctx.Err() == nil {
+			fmt.Printf("[%s] ❌ Collection sync failed, retrying at the next poll: %v\n", timestamp, err)
+			logger.Warn("⚠️ Collection sync failed while watching", zap.Error(err))
+		}
+		return
+	}
+	added += collectionsResult.CollectionsAdded
+	updated += collectionsResult.CollectionsUpdated
+	deleted += collectionsResult.CollectionsDeleted
+	errorCount += len(collectionsResult.Errors)
+
+	filesResult, err := syncFileService.Execute(ctx, &svc_sync.SyncFilesInput{Password: password})
+	if err != nil {
+		if ctx.Err() == nil {
+			fmt.Printf("[%s] ❌ File sync failed, retrying at the next poll: %v\n", timestamp, err)
+			logger.Warn("⚠️ File sync failed while watching", zap.Error(err))
+		}
+		return
+	}
+	added += filesResult.FilesAdded
+	updated += filesResult.FilesUpdated
+	deleted += filesResult.FilesDeleted
+	errorCount += len(filesResult.Errors)
+
+	if added+updated+deleted+errorCount == 0 {
+		logger.Debug("No changes found while watching")
+		return
+	}
+	fmt.Printf("[%s] 🔄 ➕ %d added, 🔄 %d updated, 🗑️  %d deleted", timestamp, added, updated, deleted)
+	if errorCount > 0 {
+		fmt.Printf(", ⚠️  %d error(s)", errorCount)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,84 @@
+// native/desktop/maplefile-cli/cmd/timeout.go
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// defaultCommandTimeout is the deadline of commands without a longer default
+	defaultCommandTimeout = 2 * time.Minute
+	// defaultTransferTimeout is the deadline of commands which may transfer many or large files
+	defaultTransferTimeout = 10 * time.Minute
+)
+
+// defaultCommandTimeouts overrides the default deadline of a command and its subcommands, keyed by
+// the command path without the name of the CLI. A zero duration means the command has no deadline.
+var defaultCommandTimeouts = map[string]time.Duration{
+	"sync":                 defaultTransferTimeout,
+	"export":               defaultTransferTimeout,
+	"files":                defaultTransferTimeout,
+	"me rotate-master-key": defaultTransferTimeout,
+	// Cleanup with --interval and sync watch run until they are interrupted
+	"recovery cleanup": 0,
+	"sync watch":       0,
+}
+
+// addTimeoutFlag adds the global --timeout flag and wraps every command so it runs with a context
+// which is cancelled once the deadline passes, guaranteeing that a hung backend can't block forever.
+func addTimeoutFlag(rootCmd *cobra.Command) {
+	var timeout time.Duration
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0,
+		fmt.Sprintf("Maximum time a command may run, 0 for no limit (default %s, or %s for sync, export and file transfers)",
+			defaultCommandTimeout, defaultTransferTimeout))
+
+	wrapWithTimeout(rootCmd, &timeout)
+}
+
+// wrapWithTimeout wraps the Run function of the command and all its subcommands
+func wrapWithTimeout(c *cobra.Command, timeout *time.Duration) {
+	if run := c.Run; run != nil {
+		c.Run = func(cmd *cobra.Command, args []string) {
+			deadline := commandTimeout(cmd)
+			if cmd.Flags().Changed("timeout") {
+				deadline = *timeout
+			}
+			if deadline <= 0 {
+				run(cmd, args)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), deadline)
+			defer cancel()
+			cmd.SetContext(ctx)
+
+			run(cmd, args)
+
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				fmt.Printf("\n⏱️ Command timed out after %s. Use --timeout to allow more time.\n", deadline)
+			}
+		}
+	}
+
+	for _, sub := range c.Commands() {
+		wrapWithTimeout(sub, timeout)
+	}
+}
+
+// commandTimeout returns the default deadline of the command, inherited from its closest ancestor
+// with an entry in defaultCommandTimeouts
+func commandTimeout(cmd *cobra.Command) time.Duration {
+	for c := cmd; c != nil && c.HasParent(); c = c.Parent() {
+		path := strings.TrimPrefix(c.CommandPath(), c.Root().Name()+" ")
+		if timeout, ok := defaultCommandTimeouts[path]; ok {
+			return timeout
+		}
+	}
+	return defaultCommandTimeout
+}
@@ -2,7 +2,6 @@
 package verifyemail
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -29,7 +28,7 @@ Example:
   # Verify email with a code provided as a flag
   verify-email --code 123456`,
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			// Check if code was provided as an argument
 			if len(args) > 0 && verificationCode == "" {
@@ -5,9 +5,14 @@ package config
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.uber.org/fx"
@@ -16,13 +21,294 @@ import (
 const (
 	// AppName is the name of the application, used for configuration directories
 	AppName = "maplefile-cli"
+
+	// DefaultRecoveryLockTimeout is used when no recovery lock timeout has been configured
+	DefaultRecoveryLockTimeout = 15 * time.Minute
+
+	// Supported local thumbnail formats
+	ThumbnailFormatJPEG = "jpeg"
+	ThumbnailFormatWebP = "webp"
+
+	// Defaults used when no thumbnail settings have been configured
+	DefaultThumbnailMaxWidth  = 256
+	DefaultThumbnailMaxHeight = 256
+	DefaultThumbnailFormat    = ThumbnailFormatJPEG
+
+	// Bounds of the number of files onloaded concurrently. Each worker holds a decrypted file
+	// in memory and writes it to disk, so values beyond the limit trade disk IO contention for
+	// little extra network throughput.
+	MinConcurrentOnloads = 1
+	MaxConcurrentOnloads = 16
+
+	// Bounds and default of the memory downloads may buffer at once. Each download holds both the
+	// encrypted and the decrypted file in memory, so concurrent onloads wait for their share of the
+	// budget instead of exhausting the memory of the machine.
+	DefaultDownloadMemoryBudget = 512 << 20
+	MinDownloadMemoryBudget     = 16 << 20
+
+	// Bounds and default of the number of entries kept in the local operation journal, older
+	// entries are rotated out once the journal is full.
+	DefaultJournalMaxEntries = 10000
+	MinJournalMaxEntries     = 100
+	MaxJournalMaxEntries     = 1000000
+
+	// Handling of synced collections whose name can't be decrypted with their collection key. The
+	// mark mode stores the collection flagged with a key problem, the strict mode fails its sync.
+	CollectionNameCheckMark   = "mark"
+	CollectionNameCheckStrict = "strict"
+
+	// Auto onload modes, selecting the cloud-only files added by a sync which are onloaded once
+	// the sync completes
+	AutoOnloadNever        = "never"
+	AutoOnloadAll          = "all"
+	AutoOnloadByCollection = "by-collection"
+	AutoOnloadUnderSize    = "under-size"
+
+	// Bounds and default of the interval at which sync watch polls the cloud for changes. The
+	// minimum keeps a watching client from flooding the cloud with sync requests.
+	DefaultSyncWatchPollInterval = time.Minute
+	MinSyncWatchPollInterval     = 10 * time.Second
+
+	// Defaults and bounds of the retry budget of requests to the cloud. A failing request is
+	// attempted up to the max attempts, and the circuit breaker fails requests fast for its cooldown
+	// after the threshold of consecutive failed requests.
+	DefaultCloudMaxAttempts      = 3
+	MaxCloudMaxAttempts          = 10
+	DefaultCloudBreakerThreshold = 5
+	DefaultCloudBreakerCooldown  = 30 * time.Second
+	MaxCloudBreakerCooldown      = 30 * time.Minute
+
+	// Bounds and default of how long decrypted collection keys are kept in memory. A longer TTL
+	// saves decrypting the key chain again, at the cost of keeping keys in memory for longer.
+	DefaultCollectionKeyCacheTTL = 5 * time.Minute
+	MinCollectionKeyCacheTTL     = time.Second
+	MaxCollectionKeyCacheTTL     = time.Hour
+
+	// AutoOnloadMinFreeDiskSpace is the free disk space auto onloads always leave, so a large sync
+	// can't fill the disk on its own.
+	AutoOnloadMinFreeDiskSpace = 1 << 30
+
+	// DefaultOnloadFallbackExtension is given to onloaded files whose type can't be determined when
+	// no fallback extension has been configured. OnloadFallbackExtensionNone disables the fallback
+	// so these files are saved without an extension.
+	DefaultOnloadFallbackExtension = ".dat"
+	OnloadFallbackExtensionNone    = "none"
+
+	// maxFileExtensionLength bounds the length of a configured extension, including the leading dot
+	maxFileExtensionLength = 16
 )
 
 // Config holds all application configuration in a flat structure
 type Config struct {
 	// CloudProviderAddress is the URI backend to make all calls to from this application.= for E2EE cloud operations.
-	CloudProviderAddress string       `json:"cloud_provider_address"`
-	Credentials          *Credentials `json:"credentials"`
+	CloudProviderAddress string `json:"cloud_provider_address"`
+	// CloudProviderFailoverAddresses are equivalent backends, in order of preference, which are
+	// used while the cloud provider address is unhealthy.
+	CloudProviderFailoverAddresses []string     `json:"cloud_provider_failover_addresses,omitempty"`
+	Credentials                    *Credentials `json:"credentials"`
+	// RecoveryLockTimeoutSeconds is how long a persistent recovery lock may be held before it is considered abandoned.
+	RecoveryLockTimeoutSeconds int64 `json:"recovery_lock_timeout_seconds,omitempty"`
+	// ThumbnailMaxWidth and ThumbnailMaxHeight bound the dimensions of thumbnails stored locally.
+	ThumbnailMaxWidth  int `json:"thumbnail_max_width,omitempty"`
+	ThumbnailMaxHeight int `json:"thumbnail_max_height,omitempty"`
+	// ThumbnailFormat is the preferred image format of thumbnails stored locally, either "jpeg" or "webp".
+	ThumbnailFormat string `json:"thumbnail_format,omitempty"`
+	// MaxConcurrentOnloads is the number of files downloaded and decrypted in parallel by batch onloads.
+	MaxConcurrentOnloads int `json:"max_concurrent_onloads,omitempty"`
+	// DownloadMemoryBudget is the number of bytes in-flight downloads may buffer in memory at once.
+	DownloadMemoryBudget int64 `json:"download_memory_budget,omitempty"`
+	// CollectionNameCheck handles synced collections whose name can't be decrypted, "mark" or "strict".
+	CollectionNameCheck string `json:"collection_name_check,omitempty"`
+	// JournalMaxEntries is the number of entries kept in the local operation journal.
+	JournalMaxEntries int `json:"journal_max_entries,omitempty"`
+	// ServerTimeSync applies the measured server clock offset to expiry checks of server issued times.
+	ServerTimeSync bool `json:"server_time_sync,omitempty"`
+	// ServerClockOffsetMilliseconds is the last measured offset of the server clock from the local clock.
+	ServerClockOffsetMilliseconds int64 `json:"server_clock_offset_milliseconds,omitempty"`
+	// DefaultCollectionID is the collection new files are added to when no collection is given.
+	DefaultCollectionID string `json:"default_collection_id,omitempty"`
+	// FileHashAlgorithm is the algorithm the integrity hash of newly added files is computed with.
+	FileHashAlgorithm string `json:"file_hash_algorithm,omitempty"`
+	// UploadFileTypePolicy restricts which types of files can be added, nil permits every type.
+	UploadFileTypePolicy *UploadFileTypePolicy `json:"upload_file_type_policy,omitempty"`
+	// AutoOnloadPolicy selects the newly synced files which are onloaded after a sync, nil never onloads.
+	AutoOnloadPolicy *AutoOnloadPolicy `json:"auto_onload_policy,omitempty"`
+	// StorageQuota bounds the disk space used by the local file store, nil disables the quota.
+	StorageQuota *StorageQuota `json:"storage_quota,omitempty"`
+	// OnloadFallbackExtension is given to onloaded files whose type can't be determined, "none" saves
+	// them without an extension.
+	OnloadFallbackExtension string `json:"onload_fallback_extension,omitempty"`
+	// PerceptualHash computes a perceptual hash of newly added images, stored in their encrypted
+	// metadata, so visually similar images can be found.
+	PerceptualHash bool `json:"perceptual_hash,omitempty"`
+	// SyncWatchPollIntervalSeconds is how often sync watch polls the cloud for changes.
+	SyncWatchPollIntervalSeconds int64 `json:"sync_watch_poll_interval_seconds,omitempty"`
+	// CloudRetryBudget bounds the retries of failing requests to the cloud, nil uses the defaults.
+	CloudRetryBudget *CloudRetryBudget `json:"cloud_retry_budget,omitempty"`
+	// CollectionKeyCacheTTLSeconds is how long decrypted collection keys are kept in memory.
+	CollectionKeyCacheTTLSeconds int64 `json:"collection_key_cache_ttl_seconds,omitempty"`
+}
+
+// ThumbnailSettings controls the quality and disk usage of thumbnails stored locally
+type ThumbnailSettings struct {
+	MaxWidth  int    `json:"max_width"`
+	MaxHeight int    `json:"max_height"`
+	Format    string `json:"format"`
+}
+
+// UploadFileTypePolicy restricts the types of files which can be added. File contents are end to end
+// encrypted, so the policy is enforced by this client on the type detected before encryption.
+// Extensions include the leading dot and every entry is lowercase.
+type UploadFileTypePolicy struct {
+	// AllowedExtensions and AllowedMimeTypes permit only the listed types when either is non-empty.
+	AllowedExtensions []string `json:"allowed_extensions,omitempty"`
+	AllowedMimeTypes  []string `json:"allowed_mime_types,omitempty"`
+	// DeniedExtensions and DeniedMimeTypes reject the listed types, even when they are also allowed.
+	DeniedExtensions []string `json:"denied_extensions,omitempty"`
+	DeniedMimeTypes  []string `json:"denied_mime_types,omitempty"`
+}
+
+// IsEmpty returns true if the policy permits every type of file
+func (p *UploadFileTypePolicy) IsEmpty() bool {
+	return p == nil ||
+		len(p.AllowedExtensions) == 0 && len(p.AllowedMimeTypes) == 0 &&
+			len(p.DeniedExtensions) == 0 && len(p.DeniedMimeTypes) == 0
+}
+
+// Permits returns true if a file with the extension and detected MIME type may be added
+func (p *UploadFileTypePolicy) Permits(extension, mimeType string) bool {
+	if p.IsEmpty() {
+		return true
+	}
+	extension = NormalizeFileExtension(extension)
+	mimeType = normalizeMimeType(mimeType)
+
+	if slices.Contains(p.DeniedExtensions, extension) || slices.Contains(p.DeniedMimeTypes, mimeType) {
+		return false
+	}
+	if len(p.AllowedExtensions) == 0 && len(p.AllowedMimeTypes) == 0 {
+		return true
+	}
+	return slices.Contains(p.AllowedExtensions, extension) || slices.Contains(p.AllowedMimeTypes, mimeType)
+}
+
+// NormalizeFileExtension lowercases an extension and adds the leading dot, so "EXE" matches ".exe"
+func NormalizeFileExtension(extension string) string {
+	extension = strings.ToLower(strings.TrimSpace(extension))
+	if extension != "" && !strings.HasPrefix(extension, ".") {
+		extension = "." + extension
+	}
+	return extension
+}
+
+// ValidateFileExtension returns an error unless the extension is a leading dot followed by letters
+// and digits, so it can't change the directory or name of the file it is appended to
+func ValidateFileExtension(extension string) error {
+	if len(extension) < 2 || len(extension) > maxFileExtensionLength || extension[0] != '.' {
+		return fmt.Errorf("invalid file extension %q: must be a dot followed by 1 to %d letters or digits", extension, maxFileExtensionLength-1)
+	}
+	for _, r := range extension[1:] {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return fmt.Errorf("invalid file extension %q: must be a dot followed by 1 to %d letters or digits", extension, maxFileExtensionLength-1)
+		}
+	}
+	return nil
+}
+
+// normalizeMimeType lowercases a MIME type and drops parameters such as the charset
+func normalizeMimeType(mimeType string) string {
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+	return strings.ToLower(strings.TrimSpace(mimeType))
+}
+
+// AutoOnloadPolicy selects the cloud-only files added by a sync which are onloaded, meaning downloaded
+// and decrypted to disk, once the sync completes.
+type AutoOnloadPolicy struct {
+	// Mode is one of "never", "all", "by-collection" or "under-size".
+	Mode string `json:"mode"`
+	// CollectionIDs are the collections whose files are onloaded in the "by-collection" mode.
+	CollectionIDs []string `json:"collection_ids,omitempty"`
+	// MaxFileSize is the size in bytes files must not exceed to be onloaded. It is required by the
+	// "under-size" mode and limits the other modes when set.
+	MaxFileSize int64 `json:"max_file_size,omitempty"`
+}
+
+// Validate returns an error if the policy is incomplete for its mode
+func (p *AutoOnloadPolicy) Validate() error {
+	switch p.Mode {
+	case AutoOnloadNever, AutoOnloadAll:
+	case AutoOnloadByCollection:
+		if len(p.CollectionIDs) == 0 {
+			return fmt.Errorf("the %s auto onload mode requires at least one collection", AutoOnloadByCollection)
+		}
+	case AutoOnloadUnderSize:
+		if p.MaxFileSize <= 0 {
+			return fmt.Errorf("the %s auto onload mode requires a maximum file size", AutoOnloadUnderSize)
+		}
+	default:
+		return fmt.Errorf("unsupported auto onload mode: %s (must be %s, %s, %s or %s)",
+			p.Mode, AutoOnloadNever, AutoOnloadAll, AutoOnloadByCollection, AutoOnloadUnderSize)
+	}
+	if p.MaxFileSize < 0 {
+		return fmt.Errorf("maximum file size must not be negative")
+	}
+	return nil
+}
+
+// StorageQuota bounds the disk space used by the local file store. Exceeding it prints a warning
+// after syncs and onloads suggesting the least recently accessed files to offload.
+type StorageQuota struct {
+	// MaxBytes is the disk space the local file store may use, zero disables the quota.
+	MaxBytes int64 `json:"max_bytes"`
+	// AutoOffload offloads the least recently accessed synced files to stay under the quota instead
+	// of only suggesting them.
+	AutoOffload bool `json:"auto_offload,omitempty"`
+}
+
+// CloudRetryBudget bounds how hard requests to the cloud are retried while it is failing, protecting
+// both the client from slow failures and the server from load during an outage
+type CloudRetryBudget struct {
+	// MaxAttempts is how often a request is attempted once every endpoint was unreachable.
+	MaxAttempts int `json:"max_attempts"`
+	// BreakerThreshold is the number of consecutive failed requests which open the circuit
+	// breaker, zero disables the breaker.
+	BreakerThreshold int `json:"breaker_threshold"`
+	// BreakerCooldownSeconds is how long the open circuit breaker fails requests fast before
+	// letting one through to test whether the cloud recovered.
+	BreakerCooldownSeconds int64 `json:"breaker_cooldown_seconds"`
+}
+
+// BreakerCooldown returns the cooldown of the circuit breaker as a duration
+func (b *CloudRetryBudget) BreakerCooldown() time.Duration {
+	return time.Duration(b.BreakerCooldownSeconds) * time.Second
+}
+
+// ParseByteSize parses a size such as "500", "20KB", "1.5MB" or "2GB" into bytes. Units are
+// multiples of 1024 and are case insensitive.
+func ParseByteSize(size string) (int64, error) {
+	value := strings.ToUpper(strings.TrimSpace(size))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix     string
+		multiplier int64
+	}{
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"B", 1},
+	} {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			multiplier = unit.multiplier
+			break
+		}
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("invalid size: %q", size)
+	}
+	return int64(number * float64(multiplier)), nil
 }
 
 // Credentials holds all user credentials for authentication and authorization. Values are decrypted for convenience purposes as we assume threat actor cannot access the decrypted values on the user's device.
@@ -40,6 +326,8 @@ type ConfigService interface {
 	GetAppDataDirPath(ctx context.Context) (string, error)
 	GetCloudProviderAddress(ctx context.Context) (string, error)
 	SetCloudProviderAddress(ctx context.Context, address string) error
+	GetCloudProviderFailoverAddresses(ctx context.Context) ([]string, error)
+	SetCloudProviderFailoverAddresses(ctx context.Context, addresses []string) error
 	GetLoggedInUserCredentials(ctx context.Context) (*Credentials, error)
 	SetLoggedInUserCredentials(
 		ctx context.Context,
@@ -50,6 +338,42 @@ type ConfigService interface {
 		refreshTokenExpiryTime *time.Time,
 	) error
 	ClearLoggedInUserCredentials(ctx context.Context) error
+	GetRecoveryLockTimeout(ctx context.Context) (time.Duration, error)
+	SetRecoveryLockTimeout(ctx context.Context, timeout time.Duration) error
+	GetThumbnailSettings(ctx context.Context) (*ThumbnailSettings, error)
+	SetThumbnailSettings(ctx context.Context, settings *ThumbnailSettings) error
+	GetMaxConcurrentOnloads(ctx context.Context) (int, error)
+	SetMaxConcurrentOnloads(ctx context.Context, concurrency int) error
+	GetDownloadMemoryBudget(ctx context.Context) (int64, error)
+	SetDownloadMemoryBudget(ctx context.Context, budget int64) error
+	GetCollectionNameCheck(ctx context.Context) (string, error)
+	SetCollectionNameCheck(ctx context.Context, mode string) error
+	GetJournalMaxEntries(ctx context.Context) (int, error)
+	SetJournalMaxEntries(ctx context.Context, maxEntries int) error
+	GetServerTimeSync(ctx context.Context) (bool, error)
+	SetServerTimeSync(ctx context.Context, enabled bool) error
+	GetServerClockOffset(ctx context.Context) (time.Duration, error)
+	SetServerClockOffset(ctx context.Context, offset time.Duration) error
+	GetDefaultCollectionID(ctx context.Context) (string, error)
+	SetDefaultCollectionID(ctx context.Context, collectionID string) error
+	GetFileHashAlgorithm(ctx context.Context) (string, error)
+	SetFileHashAlgorithm(ctx context.Context, algorithm string) error
+	GetUploadFileTypePolicy(ctx context.Context) (*UploadFileTypePolicy, error)
+	SetUploadFileTypePolicy(ctx context.Context, policy *UploadFileTypePolicy) error
+	GetAutoOnloadPolicy(ctx context.Context) (*AutoOnloadPolicy, error)
+	SetAutoOnloadPolicy(ctx context.Context, policy *AutoOnloadPolicy) error
+	GetStorageQuota(ctx context.Context) (*StorageQuota, error)
+	SetStorageQuota(ctx context.Context, quota *StorageQuota) error
+	GetOnloadFallbackExtension(ctx context.Context) (string, error)
+	SetOnloadFallbackExtension(ctx context.Context, extension string) error
+	GetPerceptualHash(ctx context.Context) (bool, error)
+	SetPerceptualHash(ctx context.Context, enabled bool) error
+	GetSyncWatchPollInterval(ctx context.Context) (time.Duration, error)
+	SetSyncWatchPollInterval(ctx context.Context, interval time.Duration) error
+	GetCloudRetryBudget(ctx context.Context) (*CloudRetryBudget, error)
+	SetCloudRetryBudget(ctx context.Context, budget *CloudRetryBudget) error
+	GetCollectionKeyCacheTTL(ctx context.Context) (time.Duration, error)
+	SetCollectionKeyCacheTTL(ctx context.Context, ttl time.Duration) error
 }
 
 // repository defines the interface for loading and saving configuration
@@ -158,6 +482,19 @@ func (r *fileRepository) SaveConfig(ctx context.Context, config *Config) error {
 	return os.WriteFile(r.configPath, data, 0644)
 }
 
+// DefaultMaxConcurrentOnloads returns the number of concurrent onloads used when none has been
+// configured, based on the number of CPUs available for decryption.
+func DefaultMaxConcurrentOnloads() int {
+	concurrency := runtime.NumCPU()
+	if concurrency < MinConcurrentOnloads {
+		return MinConcurrentOnloads
+	}
+	if concurrency > MaxConcurrentOnloads {
+		return MaxConcurrentOnloads
+	}
+	return concurrency
+}
+
 // getDefaultConfig returns the default configuration values
 func getDefaultConfig() *Config {
 	configDir, err := os.UserConfigDir()
@@ -172,7 +509,13 @@ func getDefaultConfig() *Config {
 	}
 
 	return &Config{
-		CloudProviderAddress: "http://localhost:8000",
+		CloudProviderAddress:       "http://localhost:8000",
+		RecoveryLockTimeoutSeconds: int64(DefaultRecoveryLockTimeout / time.Second),
+		ThumbnailMaxWidth:          DefaultThumbnailMaxWidth,
+		ThumbnailMaxHeight:         DefaultThumbnailMaxHeight,
+		ThumbnailFormat:            DefaultThumbnailFormat,
+		MaxConcurrentOnloads:       DefaultMaxConcurrentOnloads(),
+		JournalMaxEntries:          DefaultJournalMaxEntries,
 		Credentials: &Credentials{
 			Email:                  "",  // Leave blank because no user was authenticated.
 			AccessToken:            "",  // Leave blank because no user was authenticated.
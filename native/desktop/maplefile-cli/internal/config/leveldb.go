@@ -98,3 +98,45 @@ func NewLevelDBConfigurationProviderForRecoveryState(configService ConfigService
 
 	return leveldb.NewLevelDBConfigurationProvider(appDir, "recovery_state")
 }
+
+// NewLevelDBConfigurationProviderForKeyRotationState creates a LevelDB configuration provider for the state of a master key rotation in progress
+func NewLevelDBConfigurationProviderForKeyRotationState() leveldb.LevelDBConfigurationProvider {
+	// Get user config directory
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		log.Fatalf("Failed getting user config directory with error: %v\n", err)
+	}
+
+	// Use the app directory for storing the LevelDB database
+	appDir := filepath.Join(configDir, AppName)
+
+	return leveldb.NewLevelDBConfigurationProvider(appDir, "key_rotation_state")
+}
+
+// NewLevelDBConfigurationProviderForJournal returns a LevelDB configuration provider for the local operation journal
+func NewLevelDBConfigurationProviderForJournal() leveldb.LevelDBConfigurationProvider {
+	// Get user config directory
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		log.Fatalf("Failed getting user config directory with error: %v\n", err)
+	}
+
+	// Use the app directory for storing the LevelDB database
+	appDir := filepath.Join(configDir, AppName)
+
+	return leveldb.NewLevelDBConfigurationProvider(appDir, "journal")
+}
+
+// NewLevelDBConfigurationProviderForTrustedKeys creates a LevelDB configuration provider for the public keys of recipients trusted on first use
+func NewLevelDBConfigurationProviderForTrustedKeys() leveldb.LevelDBConfigurationProvider {
+	// Get user config directory
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		log.Fatalf("Failed getting user config directory with error: %v\n", err)
+	}
+
+	// Use the app directory for storing the LevelDB database
+	appDir := filepath.Join(configDir, AppName)
+
+	return leveldb.NewLevelDBConfigurationProvider(appDir, "trusted_keys")
+}
@@ -4,7 +4,12 @@ package config
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
 )
 
 // Implementation of ConfigService methods
@@ -44,6 +49,26 @@ func (s *configService) SetCloudProviderAddress(ctx context.Context, address str
 	return s.saveConfig(ctx, config)
 }
 
+// GetCloudProviderFailoverAddresses returns the backends used while the cloud provider address is unhealthy
+func (s *configService) GetCloudProviderFailoverAddresses(ctx context.Context) ([]string, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return config.CloudProviderFailoverAddresses, nil
+}
+
+// SetCloudProviderFailoverAddresses updates the failover backends, in order of preference
+func (s *configService) SetCloudProviderFailoverAddresses(ctx context.Context, addresses []string) error {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	config.CloudProviderFailoverAddresses = addresses
+	return s.saveConfig(ctx, config)
+}
+
 // SetLoggedInUserEmail updates the authenticated users email.
 func (s *configService) SetLoggedInUserCredentials(
 	ctx context.Context,
@@ -95,5 +120,546 @@ func (s *configService) ClearLoggedInUserCredentials(ctx context.Context) error
 	return s.saveConfig(ctx, config)
 }
 
+// GetRecoveryLockTimeout returns how long a recovery lock may be held before it is considered abandoned.
+func (s *configService) GetRecoveryLockTimeout(ctx context.Context) (time.Duration, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if config.RecoveryLockTimeoutSeconds <= 0 {
+		return DefaultRecoveryLockTimeout, nil
+	}
+	return time.Duration(config.RecoveryLockTimeoutSeconds) * time.Second, nil
+}
+
+// SetRecoveryLockTimeout updates how long a recovery lock may be held before it is considered abandoned.
+func (s *configService) SetRecoveryLockTimeout(ctx context.Context, timeout time.Duration) error {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	config.RecoveryLockTimeoutSeconds = int64(timeout / time.Second)
+	return s.saveConfig(ctx, config)
+}
+
+// GetThumbnailSettings returns the dimensions and format used for thumbnails stored locally.
+func (s *configService) GetThumbnailSettings(ctx context.Context) (*ThumbnailSettings, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &ThumbnailSettings{
+		MaxWidth:  config.ThumbnailMaxWidth,
+		MaxHeight: config.ThumbnailMaxHeight,
+		Format:    config.ThumbnailFormat,
+	}
+	if settings.MaxWidth <= 0 {
+		settings.MaxWidth = DefaultThumbnailMaxWidth
+	}
+	if settings.MaxHeight <= 0 {
+		settings.MaxHeight = DefaultThumbnailMaxHeight
+	}
+	if settings.Format != ThumbnailFormatJPEG && settings.Format != ThumbnailFormatWebP {
+		settings.Format = DefaultThumbnailFormat
+	}
+	return settings, nil
+}
+
+// SetThumbnailSettings updates the dimensions and format used for thumbnails stored locally.
+func (s *configService) SetThumbnailSettings(ctx context.Context, settings *ThumbnailSettings) error {
+	if settings == nil {
+		return fmt.Errorf("thumbnail settings are required")
+	}
+	if settings.MaxWidth <= 0 || settings.MaxHeight <= 0 {
+		return fmt.Errorf("thumbnail dimensions must be positive")
+	}
+	if settings.Format != ThumbnailFormatJPEG && settings.Format != ThumbnailFormatWebP {
+		return fmt.Errorf("unsupported thumbnail format: %s", settings.Format)
+	}
+
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	config.ThumbnailMaxWidth = settings.MaxWidth
+	config.ThumbnailMaxHeight = settings.MaxHeight
+	config.ThumbnailFormat = settings.Format
+	return s.saveConfig(ctx, config)
+}
+
+// GetMaxConcurrentOnloads returns the number of files onloaded in parallel by batch onloads.
+func (s *configService) GetMaxConcurrentOnloads(ctx context.Context) (int, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if config.MaxConcurrentOnloads < MinConcurrentOnloads || config.MaxConcurrentOnloads > MaxConcurrentOnloads {
+		return DefaultMaxConcurrentOnloads(), nil
+	}
+	return config.MaxConcurrentOnloads, nil
+}
+
+// SetMaxConcurrentOnloads updates the number of files onloaded in parallel by batch onloads.
+func (s *configService) SetMaxConcurrentOnloads(ctx context.Context, concurrency int) error {
+	if concurrency < MinConcurrentOnloads || concurrency > MaxConcurrentOnloads {
+		return fmt.Errorf("concurrency must be between %d and %d", MinConcurrentOnloads, MaxConcurrentOnloads)
+	}
+
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	config.MaxConcurrentOnloads = concurrency
+	return s.saveConfig(ctx, config)
+}
+
+// GetDownloadMemoryBudget returns the number of bytes in-flight downloads may buffer in memory at once.
+func (s *configService) GetDownloadMemoryBudget(ctx context.Context) (int64, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if config.DownloadMemoryBudget < MinDownloadMemoryBudget {
+		return DefaultDownloadMemoryBudget, nil
+	}
+	return config.DownloadMemoryBudget, nil
+}
+
+// SetDownloadMemoryBudget updates the number of bytes in-flight downloads may buffer in memory at once.
+func (s *configService) SetDownloadMemoryBudget(ctx context.Context, budget int64) error {
+	if budget < MinDownloadMemoryBudget {
+		return fmt.Errorf("download memory budget must be at least %dMB", MinDownloadMemoryBudget>>20)
+	}
+
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	config.DownloadMemoryBudget = budget
+	return s.saveConfig(ctx, config)
+}
+
+// GetCollectionNameCheck returns how synced collections whose name can't be decrypted are handled.
+func (s *configService) GetCollectionNameCheck(ctx context.Context) (string, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	if config.CollectionNameCheck == "" {
+		return CollectionNameCheckMark, nil
+	}
+	return config.CollectionNameCheck, nil
+}
+
+// SetCollectionNameCheck updates how synced collections whose name can't be decrypted are handled.
+func (s *configService) SetCollectionNameCheck(ctx context.Context, mode string) error {
+	if mode != CollectionNameCheckMark && mode != CollectionNameCheckStrict {
+		return fmt.Errorf("collection name check must be either %q or %q", CollectionNameCheckMark, CollectionNameCheckStrict)
+	}
+
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	config.CollectionNameCheck = mode
+	return s.saveConfig(ctx, config)
+}
+
+// GetJournalMaxEntries returns the number of entries kept in the local operation journal.
+func (s *configService) GetJournalMaxEntries(ctx context.Context) (int, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if config.JournalMaxEntries < MinJournalMaxEntries || config.JournalMaxEntries > MaxJournalMaxEntries {
+		return DefaultJournalMaxEntries, nil
+	}
+	return config.JournalMaxEntries, nil
+}
+
+// SetJournalMaxEntries updates the number of entries kept in the local operation journal.
+func (s *configService) SetJournalMaxEntries(ctx context.Context, maxEntries int) error {
+	if maxEntries < MinJournalMaxEntries || maxEntries > MaxJournalMaxEntries {
+		return fmt.Errorf("journal size must be between %d and %d entries", MinJournalMaxEntries, MaxJournalMaxEntries)
+	}
+
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	config.JournalMaxEntries = maxEntries
+	return s.saveConfig(ctx, config)
+}
+
+// GetServerTimeSync returns whether the measured server clock offset is applied to expiry checks.
+func (s *configService) GetServerTimeSync(ctx context.Context) (bool, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return false, err
+	}
+	return config.ServerTimeSync, nil
+}
+
+// SetServerTimeSync enables or disables applying the server clock offset to expiry checks.
+func (s *configService) SetServerTimeSync(ctx context.Context, enabled bool) error {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	config.ServerTimeSync = enabled
+	return s.saveConfig(ctx, config)
+}
+
+// GetServerClockOffset returns the last measured offset of the server clock from the local clock.
+func (s *configService) GetServerClockOffset(ctx context.Context) (time.Duration, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(config.ServerClockOffsetMilliseconds) * time.Millisecond, nil
+}
+
+// SetServerClockOffset updates the last measured offset of the server clock from the local clock.
+func (s *configService) SetServerClockOffset(ctx context.Context, offset time.Duration) error {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	config.ServerClockOffsetMilliseconds = offset.Milliseconds()
+	return s.saveConfig(ctx, config)
+}
+
+// GetDefaultCollectionID returns the collection new files are added to when none is given, empty if unset.
+func (s *configService) GetDefaultCollectionID(ctx context.Context) (string, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	return config.DefaultCollectionID, nil
+}
+
+// SetDefaultCollectionID updates the collection new files are added to, an empty ID removes it.
+func (s *configService) SetDefaultCollectionID(ctx context.Context, collectionID string) error {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	config.DefaultCollectionID = collectionID
+	return s.saveConfig(ctx, config)
+}
+
+// GetFileHashAlgorithm returns the algorithm the integrity hash of newly added files is computed with.
+func (s *configService) GetFileHashAlgorithm(ctx context.Context) (string, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	if config.FileHashAlgorithm == "" {
+		return crypto.DefaultHashAlgorithm, nil
+	}
+	return config.FileHashAlgorithm, nil
+}
+
+// SetFileHashAlgorithm updates the algorithm the integrity hash of newly added files is computed with.
+func (s *configService) SetFileHashAlgorithm(ctx context.Context, algorithm string) error {
+	if _, err := crypto.NewHash(algorithm); err != nil {
+		return err
+	}
+
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	config.FileHashAlgorithm = algorithm
+	return s.saveConfig(ctx, config)
+}
+
+// GetUploadFileTypePolicy returns the restrictions on the types of files which can be added, the
+// returned policy is never nil.
+func (s *configService) GetUploadFileTypePolicy(ctx context.Context) (*UploadFileTypePolicy, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if config.UploadFileTypePolicy == nil {
+		return &UploadFileTypePolicy{}, nil
+	}
+	return config.UploadFileTypePolicy, nil
+}
+
+// SetUploadFileTypePolicy updates the restrictions on the types of files which can be added, an
+// empty or nil policy permits every type again.
+func (s *configService) SetUploadFileTypePolicy(ctx context.Context, policy *UploadFileTypePolicy) error {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	if policy.IsEmpty() {
+		config.UploadFileTypePolicy = nil
+		return s.saveConfig(ctx, config)
+	}
+
+	config.UploadFileTypePolicy = &UploadFileTypePolicy{
+		AllowedExtensions: normalizeList(policy.AllowedExtensions, NormalizeFileExtension),
+		AllowedMimeTypes:  normalizeList(policy.AllowedMimeTypes, normalizeMimeType),
+		DeniedExtensions:  normalizeList(policy.DeniedExtensions, NormalizeFileExtension),
+		DeniedMimeTypes:   normalizeList(policy.DeniedMimeTypes, normalizeMimeType),
+	}
+	return s.saveConfig(ctx, config)
+}
+
+// GetAutoOnloadPolicy returns the policy selecting the files onloaded after a sync, the returned
+// policy is never nil and defaults to never onloading.
+func (s *configService) GetAutoOnloadPolicy(ctx context.Context) (*AutoOnloadPolicy, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if config.AutoOnloadPolicy == nil {
+		return &AutoOnloadPolicy{Mode: AutoOnloadNever}, nil
+	}
+	return config.AutoOnloadPolicy, nil
+}
+
+// SetAutoOnloadPolicy updates the policy selecting the files onloaded after a sync
+func (s *configService) SetAutoOnloadPolicy(ctx context.Context, policy *AutoOnloadPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	if policy.Mode == AutoOnloadNever {
+		config.AutoOnloadPolicy = nil
+		return s.saveConfig(ctx, config)
+	}
+
+	config.AutoOnloadPolicy = &AutoOnloadPolicy{
+		Mode:          policy.Mode,
+		CollectionIDs: normalizeList(policy.CollectionIDs, strings.TrimSpace),
+		MaxFileSize:   policy.MaxFileSize,
+	}
+	if policy.Mode != AutoOnloadByCollection {
+		config.AutoOnloadPolicy.CollectionIDs = nil
+	}
+	return s.saveConfig(ctx, config)
+}
+
+// GetStorageQuota returns the quota of the local file store, with zero bytes if none is configured
+func (s *configService) GetStorageQuota(ctx context.Context) (*StorageQuota, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if config.StorageQuota == nil {
+		return &StorageQuota{}, nil
+	}
+	return config.StorageQuota, nil
+}
+
+// SetStorageQuota updates the quota of the local file store, zero bytes disables the quota
+func (s *configService) SetStorageQuota(ctx context.Context, quota *StorageQuota) error {
+	if quota.MaxBytes < 0 {
+		return fmt.Errorf("storage quota must not be negative")
+	}
+
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	if quota.MaxBytes == 0 {
+		config.StorageQuota = nil
+		return s.saveConfig(ctx, config)
+	}
+
+	config.StorageQuota = &StorageQuota{
+		MaxBytes:    quota.MaxBytes,
+		AutoOffload: quota.AutoOffload,
+	}
+	return s.saveConfig(ctx, config)
+}
+
+// GetOnloadFallbackExtension returns the extension given to onloaded files whose type can't be
+// determined, or an empty string if they are saved without an extension.
+func (s *configService) GetOnloadFallbackExtension(ctx context.Context) (string, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	switch config.OnloadFallbackExtension {
+	case "":
+		return DefaultOnloadFallbackExtension, nil
+	case OnloadFallbackExtensionNone:
+		return "", nil
+	}
+	return config.OnloadFallbackExtension, nil
+}
+
+// SetOnloadFallbackExtension updates the extension given to onloaded files whose type can't be
+// determined, "none" saves them without an extension.
+func (s *configService) SetOnloadFallbackExtension(ctx context.Context, extension string) error {
+	extension = strings.ToLower(strings.TrimSpace(extension))
+	if extension != OnloadFallbackExtensionNone {
+		extension = NormalizeFileExtension(extension)
+		if err := ValidateFileExtension(extension); err != nil {
+			return err
+		}
+	}
+
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	config.OnloadFallbackExtension = extension
+	return s.saveConfig(ctx, config)
+}
+
+// normalizeList normalizes every entry and drops empty and duplicate entries
+func normalizeList(entries []string, normalize func(string) string) []string {
+	var normalized []string
+	for _, entry := range entries {
+		entry = normalize(entry)
+		if entry != "" && !slices.Contains(normalized, entry) {
+			normalized = append(normalized, entry)
+		}
+	}
+	return normalized
+}
+
 // Ensure our implementation satisfies the interface
 var _ ConfigService = (*configService)(nil)
+
+// GetPerceptualHash returns whether a perceptual hash is computed for newly added images.
+func (s *configService) GetPerceptualHash(ctx context.Context) (bool, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return false, err
+	}
+	return config.PerceptualHash, nil
+}
+
+// SetPerceptualHash enables or disables computing a perceptual hash for newly added images.
+func (s *configService) SetPerceptualHash(ctx context.Context, enabled bool) error {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	config.PerceptualHash = enabled
+	return s.saveConfig(ctx, config)
+}
+
+// GetSyncWatchPollInterval returns how often sync watch polls the cloud for changes.
+func (s *configService) GetSyncWatchPollInterval(ctx context.Context) (time.Duration, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if config.SyncWatchPollIntervalSeconds <= 0 {
+		return DefaultSyncWatchPollInterval, nil
+	}
+	return time.Duration(config.SyncWatchPollIntervalSeconds) * time.Second, nil
+}
+
+// SetSyncWatchPollInterval updates how often sync watch polls the cloud for changes.
+func (s *configService) SetSyncWatchPollInterval(ctx context.Context, interval time.Duration) error {
+	if interval < MinSyncWatchPollInterval {
+		return fmt.Errorf("sync watch poll interval must be at least %s", MinSyncWatchPollInterval)
+	}
+
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	config.SyncWatchPollIntervalSeconds = int64(interval / time.Second)
+	return s.saveConfig(ctx, config)
+}
+
+// GetCloudRetryBudget returns the retry budget of requests to the cloud, with the defaults if none
+// is configured.
+func (s *configService) GetCloudRetryBudget(ctx context.Context) (*CloudRetryBudget, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if config.CloudRetryBudget == nil {
+		return &CloudRetryBudget{
+			MaxAttempts:            DefaultCloudMaxAttempts,
+			BreakerThreshold:       DefaultCloudBreakerThreshold,
+			BreakerCooldownSeconds: int64(DefaultCloudBreakerCooldown / time.Second),
+		}, nil
+	}
+	budget := *config.CloudRetryBudget
+	return &budget, nil
+}
+
+// SetCloudRetryBudget updates the retry budget of requests to the cloud. It applies to the requests
+// made by the next run of the CLI.
+func (s *configService) SetCloudRetryBudget(ctx context.Context, budget *CloudRetryBudget) error {
+	if budget.MaxAttempts < 1 || budget.MaxAttempts > MaxCloudMaxAttempts {
+		return fmt.Errorf("max attempts must be between 1 and %d", MaxCloudMaxAttempts)
+	}
+	if budget.BreakerThreshold < 0 {
+		return fmt.Errorf("circuit breaker threshold must not be negative")
+	}
+	if budget.BreakerThreshold > 0 && (budget.BreakerCooldown() < time.Second || budget.BreakerCooldown() > MaxCloudBreakerCooldown) {
+		return fmt.Errorf("circuit breaker cooldown must be between 1s and %s", MaxCloudBreakerCooldown)
+	}
+
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	config.CloudRetryBudget = &CloudRetryBudget{
+		MaxAttempts:            budget.MaxAttempts,
+		BreakerThreshold:       budget.BreakerThreshold,
+		BreakerCooldownSeconds: budget.BreakerCooldownSeconds,
+	}
+	return s.saveConfig(ctx, config)
+}
+
+// GetCollectionKeyCacheTTL returns how long decrypted collection keys are kept in memory.
+func (s *configService) GetCollectionKeyCacheTTL(ctx context.Context) (time.Duration, error) {
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if config.CollectionKeyCacheTTLSeconds <= 0 {
+		return DefaultCollectionKeyCacheTTL, nil
+	}
+	return time.Duration(config.CollectionKeyCacheTTLSeconds) * time.Second, nil
+}
+
+// SetCollectionKeyCacheTTL updates how long decrypted collection keys are kept in memory.
+func (s *configService) SetCollectionKeyCacheTTL(ctx context.Context, ttl time.Duration) error {
+	if ttl < MinCollectionKeyCacheTTL || ttl > MaxCollectionKeyCacheTTL {
+		return fmt.Errorf("collection key cache TTL must be between %s and %s", MinCollectionKeyCacheTTL, MaxCollectionKeyCacheTTL)
+	}
+
+	config, err := s.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	config.CollectionKeyCacheTTLSeconds = int64(ttl / time.Second)
+	return s.saveConfig(ctx, config)
+}
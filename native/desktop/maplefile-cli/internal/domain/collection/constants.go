@@ -25,6 +25,10 @@ const (
 
 	// SyncStatusModifiedLocally indicates the collection exists in both places but has local changes
 	SyncStatusModifiedLocally
+
+	// SyncStatusKeyProblem indicates the collection was synced from the cloud but its data could not
+	// be decrypted with its collection key, so its name is a placeholder
+	SyncStatusKeyProblem
 )
 
 // String returns the string representation of SyncStatus
@@ -38,6 +42,8 @@ func (s SyncStatus) String() string {
 		return "synced"
 	case SyncStatusModifiedLocally:
 		return "modified_locally"
+	case SyncStatusKeyProblem:
+		return "key_problem"
 	default:
 		return "unknown"
 	}
@@ -58,6 +64,8 @@ const (
 	CollectionPermissionAdmin = "admin"
 )
 
+// Collection states. Archiving only hides a collection, it keeps its data and members and is
+// reversed with an unarchive. Deleting turns the collection into a tombstone which expires.
 const (
 	CollectionStateActive   = "active"
 	CollectionStateDeleted  = "deleted"
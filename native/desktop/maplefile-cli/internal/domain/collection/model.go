@@ -62,6 +62,10 @@ type Collection struct {
 	// Decrypted content details and local sync tracking
 	Name       string     `json:"name" bson:"name"`
 	SyncStatus SyncStatus `json:"sync_status" bson:"sync_status"`
+	// SyncDisabled is a local preference which keeps the file content of the collection from being
+	// synced to this device, its metadata still syncs. It is stored inverted so collections saved
+	// before the preference existed stay enabled.
+	SyncDisabled bool `json:"sync_disabled,omitempty" bson:"sync_disabled,omitempty"`
 
 	// State management
 	State            string    `bson:"state" json:"state"`                         // active, deleted, archived
@@ -69,6 +73,11 @@ type Collection struct {
 	TombstoneExpiry  time.Time `bson:"tombstone_expiry" json:"tombstone_expiry"`
 }
 
+// IsSyncEnabled reports whether the file content of the collection is synced to this device
+func (c *Collection) IsSyncEnabled() bool {
+	return !c.SyncDisabled
+}
+
 // CollectionMembership represents a user's access to a collection.
 // Each instance indicates that a specific RecipientID has a certain PermissionLevel for a given CollectionID.
 type CollectionMembership struct {
@@ -113,4 +122,15 @@ type CollectionMembership struct {
 	// InheritedFromID is the ID of the parent collection from which this membership was inherited, if IsInherited is true.
 	// It is omitted (nil) if the access was granted directly to this collection.
 	InheritedFromID gocql.UUID `bson:"inherited_from_id,omitempty" json:"inherited_from_id,omitempty"` // InheritedFromID identifies which parent collection granted this access
+
+	// Time-bounded access
+
+	// ExpiresAt is when the access granted by this membership ends. The cloud stops serving the collection
+	// to the recipient after it, and sync drops the local copy. The zero value never expires.
+	ExpiresAt time.Time `bson:"expires_at,omitempty" json:"expires_at,omitzero"`
+}
+
+// IsExpired reports whether the membership is time-bounded and its expiry has passed
+func (m *CollectionMembership) IsExpired(now time.Time) bool {
+	return !m.ExpiresAt.IsZero() && !now.Before(m.ExpiresAt)
 }
@@ -29,7 +29,7 @@ func IsValidStateTransition(fromState, toState string) error {
 	// Define allowed transitions
 	allowedTransitions := map[string][]string{
 		CollectionStateActive:   {CollectionStateDeleted, CollectionStateArchived},
-		CollectionStateDeleted:  {CollectionStateActive, CollectionStateArchived},
+		CollectionStateDeleted:  {CollectionStateActive},
 		CollectionStateArchived: {CollectionStateActive},
 	}
 
@@ -51,3 +51,30 @@ func IsValidStateTransition(fromState, toState string) error {
 func GetDefaultState() string {
 	return CollectionStateActive
 }
+
+// ValidateEncryptedKeys checks the stored encrypted keys of the collection, its members and its
+// children aren't corrupted, returning an error wrapping keys.ErrCorruptedEncryptedMaterial if one is.
+func (c *Collection) ValidateEncryptedKeys() error {
+	if c.EncryptedCollectionKey != nil {
+		if err := c.EncryptedCollectionKey.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, member := range c.Members {
+		if member == nil || member.EncryptedCollectionKey == nil {
+			continue
+		}
+		if err := member.EncryptedCollectionKey.ValidateSealed(); err != nil {
+			return fmt.Errorf("member %s: %w", member.RecipientID.String(), err)
+		}
+	}
+	for _, child := range c.Children {
+		if child == nil {
+			continue
+		}
+		if err := child.ValidateEncryptedKeys(); err != nil {
+			return fmt.Errorf("child collection %s: %w", child.ID.String(), err)
+		}
+	}
+	return nil
+}
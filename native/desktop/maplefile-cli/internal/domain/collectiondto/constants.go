@@ -1,6 +1,8 @@
 // monorepo/native/desktop/maplefile-cli/internal/domain/collectiondto/constants.go
 package collectiondto
 
+// Collection states. Archiving only hides a collection, it keeps its data and members and is
+// reversed with a restore. Deleting turns the collection into a tombstone which expires.
 const (
 	CollectionDTOStateActive   = "active"
 	CollectionDTOStateDeleted  = "deleted"
@@ -3,6 +3,7 @@ package collectiondto
 
 import (
 	"context"
+	"time"
 
 	"github.com/gocql/gocql"
 )
@@ -34,8 +35,59 @@ type CollectionDTORepository interface {
 	// should be returned if the ID does not exist.
 	SoftDeleteInCloudByID(ctx context.Context, id gocql.UUID) error
 
+	// UpdateInCloud updates the encrypted name, type and key of a CollectionDTO in the cloud service,
+	// which increments its version. It returns the updated CollectionDTO.
+	UpdateInCloud(ctx context.Context, request *UpdateCollectionRequestDTO) (*CollectionDTO, error)
+
+	// ArchiveInCloudByID archives a CollectionDTO in the cloud service, which hides it without
+	// deleting its data and increments its version.
+	ArchiveInCloudByID(ctx context.Context, id gocql.UUID) error
+
+	// RestoreInCloudByID restores an archived CollectionDTO in the cloud service to the active
+	// state and increments its version.
+	RestoreInCloudByID(ctx context.Context, id gocql.UUID) error
+
 	// GetFilteredCollectionsFromCloud retrieves filtered collections (owned/shared) from the cloud service
 	GetFilteredCollectionsFromCloud(ctx context.Context, request *GetFilteredCollectionsRequest) (*GetFilteredCollectionsResponse, error)
+
+	// GetMemberPublicKeysFromCloud fetches the public keys of every member of a collection in one
+	// call, for re-wrapping the collection key. Only the owner and admins of the collection may call it.
+	GetMemberPublicKeysFromCloud(ctx context.Context, id gocql.UUID) (*CollectionMemberPublicKeysResponse, error)
+
+	// GetHistoryFromCloud fetches the recorded changes of a collection, most recent first
+	GetHistoryFromCloud(ctx context.Context, id gocql.UUID) (*CollectionHistoryResponse, error)
+}
+
+// CollectionHistoryResponse represents the response from getting the change history of a collection
+type CollectionHistoryResponse struct {
+	CollectionID gocql.UUID          `json:"collection_id"`
+	Changes      []*CollectionChange `json:"changes"`
+}
+
+// CollectionChange is one recorded change of a collection
+type CollectionChange struct {
+	Version         uint64     `json:"version"`
+	ChangedAt       time.Time  `json:"changed_at"`
+	ChangedByUserID gocql.UUID `json:"changed_by_user_id"`
+	// ChangeType is one of created, renamed, moved, deleted, member-added, member-removed, ownership-transferred or key-rotated
+	ChangeType string `json:"change_type"`
+	// SubjectID is the member added or removed, the new parent or the new owner, depending on the change type
+	SubjectID gocql.UUID `json:"subject_id,omitzero"`
+}
+
+// CollectionMemberPublicKeysResponse represents the response from getting the member public keys of a collection
+type CollectionMemberPublicKeysResponse struct {
+	CollectionID gocql.UUID                   `json:"collection_id"`
+	Members      []*CollectionMemberPublicKey `json:"members"`
+}
+
+// CollectionMemberPublicKey is the public key of one member of a collection, the owner is never included
+type CollectionMemberPublicKey struct {
+	RecipientID     gocql.UUID `json:"recipient_id"`
+	RecipientEmail  string     `json:"recipient_email"`
+	PermissionLevel string     `json:"permission_level"`
+	PublicKey       []byte     `json:"public_key"`
+	Fingerprint     string     `json:"fingerprint"`
 }
 
 // GetFilteredCollectionsRequest represents the request for getting filtered collections
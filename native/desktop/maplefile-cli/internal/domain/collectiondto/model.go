@@ -83,4 +83,18 @@ type CollectionMembershipDTO struct {
 	// Sharing origin tracking
 	IsInherited     bool       `bson:"is_inherited" json:"is_inherited"`                               // Tracks whether access was granted directly or inherited from a parent
 	InheritedFromID gocql.UUID `bson:"inherited_from_id,omitempty" json:"inherited_from_id,omitempty"` // InheritedFromID identifies which parent collection granted this access
+
+	// Time-bounded access
+	ExpiresAt time.Time `bson:"expires_at,omitempty" json:"expires_at,omitzero"` // When the access ends, the zero value never expires
+}
+
+// UpdateCollectionRequestDTO represents the request payload for updating a collection in the cloud.
+// Version must be the current version of the collection in the cloud, otherwise the update is
+// rejected as the collection changed since it was fetched.
+type UpdateCollectionRequestDTO struct {
+	ID                     gocql.UUID                   `json:"id"`
+	EncryptedName          string                       `json:"encrypted_name"`
+	CollectionType         string                       `json:"collection_type,omitempty"`
+	EncryptedCollectionKey *keys.EncryptedCollectionKey `json:"encrypted_collection_key,omitempty"`
+	Version                uint64                       `json:"version,omitempty"`
 }
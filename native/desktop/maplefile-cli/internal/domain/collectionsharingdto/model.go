@@ -2,6 +2,8 @@
 package collectionsharingdto
 
 import (
+	"time"
+
 	"github.com/gocql/gocql"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
 )
@@ -14,6 +16,7 @@ type ShareCollectionRequestDTO struct {
 	PermissionLevel        string                       `json:"permission_level"`
 	EncryptedCollectionKey *keys.EncryptedCollectionKey `json:"encrypted_collection_key"`
 	ShareWithDescendants   bool                         `json:"share_with_descendants"`
+	ExpiresAt              time.Time                    `json:"expires_at,omitzero"`
 }
 
 // ShareCollectionResponseDTO represents the response from sharing a collection
@@ -1,5 +1,10 @@
 package file
 
+import (
+	"fmt"
+	"strings"
+)
+
 // SyncStatus defines the synchronization status of a file
 type SyncStatus int
 
@@ -17,6 +22,39 @@ const (
 	SyncStatusModifiedLocally
 )
 
+// String returns the string representation of SyncStatus
+func (s SyncStatus) String() string {
+	switch s {
+	case SyncStatusLocalOnly:
+		return "local_only"
+	case SyncStatusCloudOnly:
+		return "cloud_only"
+	case SyncStatusSynced:
+		return "synced"
+	case SyncStatusModifiedLocally:
+		return "modified_locally"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseSyncStatus returns the SyncStatus of its string representation, also accepting dashes in
+// place of underscores such as "cloud-only"
+func ParseSyncStatus(status string) (SyncStatus, error) {
+	switch strings.ReplaceAll(strings.ToLower(strings.TrimSpace(status)), "-", "_") {
+	case "local_only":
+		return SyncStatusLocalOnly, nil
+	case "cloud_only":
+		return SyncStatusCloudOnly, nil
+	case "synced":
+		return SyncStatusSynced, nil
+	case "modified_locally":
+		return SyncStatusModifiedLocally, nil
+	default:
+		return 0, fmt.Errorf("unsupported sync status: %s (must be local-only, cloud-only, synced or modified-locally)", status)
+	}
+}
+
 // Storage mode constants define which file versions to keep
 const (
 	StorageModeEncryptedOnly = "encrypted_only" // Only keep encrypted version (more secure)
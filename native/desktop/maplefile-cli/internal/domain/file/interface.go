@@ -18,6 +18,8 @@ type FileRepository interface {
 	GetByIDs(ctx context.Context, ids []gocql.UUID) ([]*File, error)
 	// GetByCollection retrieves all File records associated with a specific collection ID.
 	GetByCollection(ctx context.Context, collectionID gocql.UUID) ([]*File, error)
+	// List retrieves all File records matching the provided filter criteria.
+	List(ctx context.Context, filter FileFilter) ([]*File, error)
 	// Update modifies an existing File record in the storage.
 	Update(ctx context.Context, file *File) error
 	// Delete removes a single File record by its unique identifier (ID).
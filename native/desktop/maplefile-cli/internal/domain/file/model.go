@@ -27,6 +27,9 @@ type File struct {
 	EncryptionVersion string `json:"encryption_version" bson:"encryption_version"`
 	// Hash of the encrypted file for integrity checking
 	EncryptedHash string `json:"encrypted_hash" bson:"encrypted_hash"`
+	// Algorithm the hash was computed with (e.g. "sha3-256", "sha256", "blake2b"), empty for
+	// files hashed before it was recorded which use the default algorithm.
+	EncryptedHashAlgorithm string `json:"encrypted_hash_algorithm,omitempty" bson:"encrypted_hash_algorithm,omitempty"`
 	// Decrypted metadata for local use (client device side only)
 	Name     string        `json:"name" bson:"name"`
 	MimeType string        `json:"mime_type" bson:"mime_type"`
@@ -55,10 +58,15 @@ type File struct {
 	ThumbnailPath string `json:"thumbnail_path,omitempty" bson:"thumbnail_path,omitempty"`
 	// Size of the decrypted thumbnail in bytes. To be used for accounting and billing purposes.
 	ThumbnailSize int64 `json:"thumbnail_size" bson:"thumbnail_size"`
+	// Image format of the stored thumbnail (e.g. "jpeg", "webp") so viewers know how to decode it.
+	ThumbnailFormat string `json:"thumbnail_format,omitempty" bson:"thumbnail_format,omitempty"`
 
 	// Fields for tracking synchronization state
 	LastSyncedAt time.Time  `json:"last_synced_at" bson:"last_synced_at"`
 	SyncStatus   SyncStatus `json:"sync_status" bson:"sync_status"`
+	// When the local content of the file was last onloaded or opened, used to offload the least
+	// recently accessed files first (client device side only)
+	LastAccessedAt time.Time `json:"last_accessed_at,omitempty" bson:"last_accessed_at,omitempty"`
 	// Controls which file versions are kept (encrypted, decrypted, or both) (client device side only)
 	StorageMode string `json:"storage_mode" bson:"storage_mode"`
 
@@ -95,4 +103,7 @@ type FileMetadata struct {
 	EncryptedThumbnailSize int64  `json:"encrypted_thumbnai_size" bson:"encrypted_thumbnai_size"`
 	DecryptedThumbnailPath string `json:"decrypted_thumbnail_path,omitempty" bson:"decrypted_thumbnail_path,omitempty"`
 	DecryptedThumbnailSize int64  `json:"decrypted_thumbnail_size" bson:"decrypted_thumbnail_size"`
+	// Perceptual hash of image files, computed on the plaintext image when it was added so the server
+	// only ever sees it encrypted, empty for other files or when perceptual hashing was disabled.
+	PerceptualHash string `json:"perceptual_hash,omitempty" bson:"perceptual_hash,omitempty"`
 }
@@ -0,0 +1,8 @@
+// monorepo/native/desktop/maplefile-cli/internal/domain/filedto/errors.go
+package filedto
+
+import "errors"
+
+// ErrPresignedURLExpired is wrapped by the errors of uploads rejected by the cloud storage with a
+// 403, which is how an expired presigned URL is reported. A fresh URL can be requested to retry.
+var ErrPresignedURLExpired = errors.New("presigned URL expired")
@@ -34,6 +34,16 @@ type FileDTORepository interface {
 	// to 'active' state. This is Step 3 of the three-step upload process.
 	CompleteFileUploadInCloud(ctx context.Context, fileID gocql.UUID, request *CompleteFileUploadRequest) (*CompleteFileUploadResponse, error)
 
+	// Batch File Upload Process
+
+	// PrepareFileBatchUploadInCloud returns presigned URLs for uploading the content of a batch
+	// of files without creating any file records in the cloud.
+	PrepareFileBatchUploadInCloud(ctx context.Context, request *PrepareFileBatchUploadRequest) (*PrepareFileBatchUploadResponse, error)
+
+	// CreateFileBatchInCloud registers the metadata of a batch of uploaded files in a single
+	// transaction, so either every file of the batch is created or none is.
+	CreateFileBatchInCloud(ctx context.Context, request *CreateFileBatchRequest) (*CreateFileBatchResponse, error)
+
 	// GetPresignedUploadURLFromCloud generates new presigned upload URLs for an existing file.
 	// This can be used to re-upload or replace file content.
 	GetPresignedUploadURLFromCloud(ctx context.Context, fileID gocql.UUID, request *GetPresignedUploadURLRequest) (*GetPresignedUploadURLResponse, error)
@@ -44,17 +54,29 @@ type FileDTORepository interface {
 	// GetPresignedDownloadURLFromCloud generates presigned download URLs for an existing file.
 	GetPresignedDownloadURLFromCloud(ctx context.Context, fileID gocql.UUID, request *GetPresignedDownloadURLRequest) (*GetPresignedDownloadURLResponse, error)
 
+	// GetPresignedDownloadURLsFromCloud generates presigned download URLs for many files in a
+	// single request. Files without URLs are reported in the errors of the response.
+	GetPresignedDownloadURLsFromCloud(ctx context.Context, request *GetPresignedDownloadURLsRequest) (*GetPresignedDownloadURLsResponse, error)
+
 	// DownloadFileViaPresignedURLFromCloud downloads file content from a presigned URL.
 	DownloadFileViaPresignedURLFromCloud(ctx context.Context, presignedURL string) ([]byte, error)
 
 	// DownloadThumbnailViaPresignedURLFromCloud downloads thumbnail content from a presigned URL.
 	DownloadThumbnailViaPresignedURLFromCloud(ctx context.Context, presignedURL string) ([]byte, error)
 
+	// DownloadFileRangeViaPresignedURLFromCloud downloads the bytes [start, end) of file content
+	// from a presigned URL with an HTTP range request.
+	DownloadFileRangeViaPresignedURLFromCloud(ctx context.Context, presignedURL string, start, end int64) (*DownloadedRange, error)
+
 	// ListFromCloud lists FileDTOs from the cloud service based on the provided filter criteria.
 	ListFromCloud(ctx context.Context, filter FileFilter) ([]*FileDTO, error)
 
 	// DeleteByIDFromCloud deletes a FileDTO by its unique identifier from the cloud service.
 	DeleteByIDFromCloud(ctx context.Context, id gocql.UUID) error
+
+	// MoveFileInCloud moves a file into another collection in the cloud service, replacing its
+	// file key with the one the client re-wrapped with the key of the target collection.
+	MoveFileInCloud(ctx context.Context, fileID gocql.UUID, request *MoveFileRequest) (*FileDTO, error)
 }
 
 // Three-Step Upload Request/Response Types
@@ -67,6 +89,7 @@ type CreatePendingFileRequest struct {
 	EncryptedFileKey             keys.EncryptedFileKey `json:"encrypted_file_key"`
 	EncryptionVersion            string                `json:"encryption_version"`
 	EncryptedHash                string                `json:"encrypted_hash"`
+	EncryptedHashAlgorithm       string                `json:"encrypted_hash_algorithm,omitempty"`
 	ExpectedFileSizeInBytes      int64                 `json:"expected_file_size_in_bytes"`
 	ExpectedThumbnailSizeInBytes int64                 `json:"expected_thumbnail_size_in_bytes,omitempty"`
 }
@@ -100,6 +123,51 @@ type CompleteFileUploadResponse struct {
 	ThumbnailVerified   bool     `json:"thumbnail_verified"`
 }
 
+// MaxFileBatchSize is the maximum number of files the cloud accepts in a single batch
+const MaxFileBatchSize = 10
+
+// Batch Upload Request/Response Types
+
+// PrepareFileBatchUploadItem represents a single file of a batch upload
+type PrepareFileBatchUploadItem struct {
+	ID                           gocql.UUID `json:"id"`
+	ExpectedThumbnailSizeInBytes int64      `json:"expected_thumbnail_size_in_bytes,omitempty"`
+}
+
+// PrepareFileBatchUploadRequest represents the request to get upload URLs for a batch of files
+type PrepareFileBatchUploadRequest struct {
+	CollectionID gocql.UUID                    `json:"collection_id"`
+	Files        []*PrepareFileBatchUploadItem `json:"files"`
+}
+
+// PreparedFileUpload represents the upload URLs of a single file of a batch
+type PreparedFileUpload struct {
+	FileID                gocql.UUID `json:"file_id"`
+	PresignedUploadURL    string     `json:"presigned_upload_url"`
+	PresignedThumbnailURL string     `json:"presigned_thumbnail_url,omitempty"`
+}
+
+// PrepareFileBatchUploadResponse represents the response with the upload URLs of a batch of files
+type PrepareFileBatchUploadResponse struct {
+	Uploads                 []*PreparedFileUpload `json:"uploads"`
+	UploadURLExpirationTime time.Time             `json:"upload_url_expiration_time"`
+	Success                 bool                  `json:"success"`
+	Message                 string                `json:"message"`
+}
+
+// CreateFileBatchRequest represents the request to register the metadata of a batch of uploaded files
+type CreateFileBatchRequest struct {
+	CollectionID gocql.UUID                  `json:"collection_id"`
+	Files        []*CreatePendingFileRequest `json:"files"`
+}
+
+// CreateFileBatchResponse represents the response from registering a batch of files
+type CreateFileBatchResponse struct {
+	Files   []*FileDTO `json:"files"`
+	Success bool       `json:"success"`
+	Message string     `json:"message"`
+}
+
 // GetPresignedUploadURLRequest represents the request to get presigned upload URLs
 type GetPresignedUploadURLRequest struct {
 	URLDuration time.Duration `json:"url_duration,omitempty"` // Optional, defaults to 1 hour
@@ -115,6 +183,14 @@ type GetPresignedUploadURLResponse struct {
 	Message                 string    `json:"message"`
 }
 
+// MoveFileRequest represents the request to move a file into another collection
+type MoveFileRequest struct {
+	CollectionID     gocql.UUID            `json:"collection_id"`
+	EncryptedFileKey keys.EncryptedFileKey `json:"encrypted_file_key"`
+	// Version is the version of the file the move was made from, the cloud rejects stale moves.
+	Version uint64 `json:"version"`
+}
+
 // FileFilter defines filtering options for listing FileDTOs.
 type FileFilter struct {
 	// CollectionID filters files that belong to the specified collection.
@@ -123,6 +199,16 @@ type FileFilter struct {
 	State string `json:"state,omitempty"`
 }
 
+// DownloadedRange is a byte range of file content downloaded from a presigned URL
+type DownloadedRange struct {
+	Data []byte
+	// Start is the offset of Data in the file content. A server which ignores the range returns
+	// the whole content from 0.
+	Start int64
+	// TotalSize is the size of the whole file content.
+	TotalSize int64
+}
+
 // GetPresignedDownloadURLRequest represents the request to get presigned download URLs
 type GetPresignedDownloadURLRequest struct {
 	URLDuration time.Duration `json:"url_duration,omitempty"` // Optional, defaults to 1 hour
@@ -138,4 +224,24 @@ type GetPresignedDownloadURLResponse struct {
 	Message                   string    `json:"message"`
 }
 
+// MaxDownloadURLBatchSize is the maximum number of files the cloud presigns in a single request
+const MaxDownloadURLBatchSize = 100
+
+// GetPresignedDownloadURLsRequest represents the request to get presigned download URLs for many files
+type GetPresignedDownloadURLsRequest struct {
+	FileIDs     []gocql.UUID  `json:"file_ids"`
+	URLDuration time.Duration `json:"url_duration,omitempty"` // Optional, defaults to 1 hour
+}
+
+// GetPresignedDownloadURLsResponse represents the response with presigned download URLs for many files
+type GetPresignedDownloadURLsResponse struct {
+	DownloadURLs  map[gocql.UUID]string `json:"download_urls"`
+	ThumbnailURLs map[gocql.UUID]string `json:"thumbnail_urls,omitempty"`
+	// Errors explains why no URL was generated for a file
+	Errors                    map[gocql.UUID]string `json:"errors,omitempty"`
+	DownloadURLExpirationTime time.Time             `json:"download_url_expiration_time"`
+	Success                   bool                  `json:"success"`
+	Message                   string                `json:"message"`
+}
+
 // Add
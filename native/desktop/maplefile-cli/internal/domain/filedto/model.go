@@ -36,6 +36,8 @@ type FileDTO struct {
 	// Cryptographic hash of the *encrypted* file content stored in S3. Used for integrity
 	// verification upon download *before* decryption.
 	EncryptedHash string `bson:"encrypted_hash" json:"encrypted_hash"`
+	// Algorithm used to compute `EncryptedHash`, empty means the default algorithm.
+	EncryptedHashAlgorithm string `bson:"encrypted_hash_algorithm,omitempty" json:"encrypted_hash_algorithm,omitempty"`
 
 	// File Storage Object Details
 	// The unique key or path within the S3 bucket where the main encrypted file content is stored.
@@ -0,0 +1,19 @@
+// native/desktop/maplefile-cli/internal/domain/journal/interface.go
+package journal
+
+import (
+	"context"
+)
+
+// JournalRepository defines the interface for the append-only local operation journal
+type JournalRepository interface {
+	// Append records an entry and assigns its sequence. Once the journal holds more than
+	// maxEntries entries the oldest ones are removed.
+	Append(ctx context.Context, entry *Entry, maxEntries int) error
+
+	// List returns the matching entries, oldest first
+	List(ctx context.Context, filter *Filter) ([]*Entry, error)
+
+	// Count returns the number of entries currently kept
+	Count(ctx context.Context) (int, error)
+}
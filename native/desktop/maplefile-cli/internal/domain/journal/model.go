@@ -0,0 +1,84 @@
+// native/desktop/maplefile-cli/internal/domain/journal/model.go
+package journal
+
+import (
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// Operation is the kind of local mutation recorded in the journal
+type Operation string
+
+const (
+	OperationCreate  Operation = "create"
+	OperationUpdate  Operation = "update"
+	OperationDelete  Operation = "delete"
+	OperationOnload  Operation = "onload"
+	OperationOffload Operation = "offload"
+)
+
+// EntityType is the kind of entity a journal entry refers to
+type EntityType string
+
+const (
+	EntityTypeFile       EntityType = "file"
+	EntityTypeCollection EntityType = "collection"
+)
+
+// Outcome is the result of a recorded operation
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+)
+
+// Entry is a single append-only record of a local mutation
+type Entry struct {
+	// Sequence orders the entries, it is assigned when the entry is appended.
+	Sequence   uint64     `json:"sequence"`
+	Timestamp  time.Time  `json:"timestamp"`
+	Operation  Operation  `json:"operation"`
+	EntityType EntityType `json:"entity_type"`
+	EntityID   gocql.UUID `json:"entity_id"`
+	Outcome    Outcome    `json:"outcome"`
+	// Error is the reason of a failure, empty on success.
+	Error string `json:"error,omitempty"`
+	// Details is a short human readable description, for example the new sync status.
+	Details string `json:"details,omitempty"`
+}
+
+// Filter selects journal entries, zero values match everything
+type Filter struct {
+	Operation  Operation
+	EntityType EntityType
+	EntityID   *gocql.UUID
+	Outcome    Outcome
+	Since      time.Time
+	// Limit keeps only the most recent matching entries, zero returns every match.
+	Limit int
+}
+
+// Matches returns true if the entry is selected by the filter, the limit is not considered
+func (f *Filter) Matches(entry *Entry) bool {
+	if f == nil {
+		return true
+	}
+	if f.Operation != "" && entry.Operation != f.Operation {
+		return false
+	}
+	if f.EntityType != "" && entry.EntityType != f.EntityType {
+		return false
+	}
+	if f.EntityID != nil && entry.EntityID != *f.EntityID {
+		return false
+	}
+	if f.Outcome != "" && entry.Outcome != f.Outcome {
+		return false
+	}
+	if !f.Since.IsZero() && entry.Timestamp.Before(f.Since) {
+		return false
+	}
+	return true
+}
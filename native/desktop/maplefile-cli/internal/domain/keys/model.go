@@ -1,10 +1,11 @@
 package keys
 
 import (
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
 )
 
 // MasterKey represents the root encryption key for a user
@@ -106,8 +107,11 @@ func (eck *EncryptedCollectionKey) NeedsRotation(policy KeyRotationPolicy) bool
 func (eck *EncryptedCollectionKey) UnmarshalJSON(data []byte) error {
 	// Temporary struct to unmarshal into string fields
 	type Alias struct {
-		Ciphertext string `json:"ciphertext"`
-		Nonce      string `json:"nonce"`
+		Ciphertext   string                   `json:"ciphertext"`
+		Nonce        string                   `json:"nonce"`
+		KeyVersion   int                      `json:"key_version"`
+		RotatedAt    *time.Time               `json:"rotated_at,omitempty"`
+		PreviousKeys []EncryptedHistoricalKey `json:"previous_keys,omitempty"`
 	}
 	var alias Alias
 
@@ -115,34 +119,30 @@ func (eck *EncryptedCollectionKey) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("failed to unmarshal EncryptedCollectionKey into alias: %w", err)
 	}
 
-	// Decode Ciphertext from URL-safe base64
-	// libsodium-wrappers to_base64 often uses URLSAFE_NO_PADDING by default or as a common option.
-	// base64.RawURLEncoding handles URL-safe base64 without padding.
+	// Decode Ciphertext from base64
+	// libsodium-wrappers to_base64 often uses URLSAFE_NO_PADDING by default or as a common option,
+	// while other clients send standard base64, so accept either.
 	if alias.Ciphertext != "" {
-		ciphertextBytes, err := base64.RawURLEncoding.DecodeString(alias.Ciphertext)
+		ciphertextBytes, err := crypto.DecodeBase64Flexible(alias.Ciphertext)
 		if err != nil {
-			// Attempt to decode with standard encoding as a fallback if RawURLEncoding fails,
-			// though the error "illegal base64 data at input byte 0" for '_' indicates URL-safe.
-			ciphertextBytes, err = base64.StdEncoding.DecodeString(alias.Ciphertext)
-			if err != nil {
-				return fmt.Errorf("failed to decode ciphertext (tried RawURL and Std): %w", err)
-			}
+			return fmt.Errorf("failed to decode ciphertext: %w", err)
 		}
 		eck.Ciphertext = ciphertextBytes
 	}
 
-	// Decode Nonce from URL-safe base64
+	// Decode Nonce from base64
 	if alias.Nonce != "" {
-		nonceBytes, err := base64.RawURLEncoding.DecodeString(alias.Nonce)
+		nonceBytes, err := crypto.DecodeBase64Flexible(alias.Nonce)
 		if err != nil {
-			nonceBytes, err = base64.StdEncoding.DecodeString(alias.Nonce)
-			if err != nil {
-				return fmt.Errorf("failed to decode nonce (tried RawURL and Std): %w", err)
-			}
+			return fmt.Errorf("failed to decode nonce: %w", err)
 		}
 		eck.Nonce = nonceBytes
 	}
 
+	// The version and history are plain JSON
+	eck.KeyVersion = alias.KeyVersion
+	eck.RotatedAt = alias.RotatedAt
+	eck.PreviousKeys = alias.PreviousKeys
 	return nil
 }
 
@@ -174,8 +174,11 @@ func (eck *EncryptedFileKey) NeedsRotation(policy KeyRotationPolicy) bool {
 func (efk *EncryptedFileKey) UnmarshalJSON(data []byte) error {
 	// Temporary struct to unmarshal into string fields
 	type Alias struct {
-		Ciphertext string `json:"ciphertext"`
-		Nonce      string `json:"nonce"`
+		Ciphertext   string                   `json:"ciphertext"`
+		Nonce        string                   `json:"nonce"`
+		KeyVersion   int                      `json:"key_version"`
+		RotatedAt    *time.Time               `json:"rotated_at,omitempty"`
+		PreviousKeys []EncryptedHistoricalKey `json:"previous_keys,omitempty"`
 	}
 	var alias Alias
 
@@ -183,32 +186,28 @@ func (efk *EncryptedFileKey) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("failed to unmarshal EncryptedFileKey into alias: %w", err)
 	}
 
-	// Decode Ciphertext from URL-safe base64
+	// Decode Ciphertext from base64
 	if alias.Ciphertext != "" {
-		ciphertextBytes, err := base64.RawURLEncoding.DecodeString(alias.Ciphertext)
+		ciphertextBytes, err := crypto.DecodeBase64Flexible(alias.Ciphertext)
 		if err != nil {
-			// Fallback attempt for standard encoding, though URL-safe is expected from client
-			ciphertextBytes, err = base64.StdEncoding.DecodeString(alias.Ciphertext)
-			if err != nil {
-				return fmt.Errorf("failed to decode EncryptedFileKey.Ciphertext (tried RawURL and Std): %w", err)
-			}
+			return fmt.Errorf("failed to decode EncryptedFileKey.Ciphertext: %w", err)
 		}
 		efk.Ciphertext = ciphertextBytes
 	}
 
-	// Decode Nonce from URL-safe base64
+	// Decode Nonce from base64
 	if alias.Nonce != "" {
-		nonceBytes, err := base64.RawURLEncoding.DecodeString(alias.Nonce)
+		nonceBytes, err := crypto.DecodeBase64Flexible(alias.Nonce)
 		if err != nil {
-			// Fallback attempt for standard encoding
-			nonceBytes, err = base64.StdEncoding.DecodeString(alias.Nonce)
-			if err != nil {
-				return fmt.Errorf("failed to decode EncryptedFileKey.Nonce (tried RawURL and Std): %w", err)
-			}
+			return fmt.Errorf("failed to decode EncryptedFileKey.Nonce: %w", err)
 		}
 		efk.Nonce = nonceBytes
 	}
 
+	// The version and history are plain JSON
+	efk.KeyVersion = alias.KeyVersion
+	efk.RotatedAt = alias.RotatedAt
+	efk.PreviousKeys = alias.PreviousKeys
 	return nil
 }
 
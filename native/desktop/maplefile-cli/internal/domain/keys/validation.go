@@ -0,0 +1,72 @@
+// native/desktop/maplefile-cli/internal/domain/keys/validation.go
+package keys
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+)
+
+// ErrCorruptedEncryptedMaterial is returned when stored encrypted key material can't be valid
+// ciphertext, for example because its nonce was truncated. It tells apart data corruption from
+// decryption failures caused by a wrong password.
+var ErrCorruptedEncryptedMaterial = errors.New("stored encrypted material is corrupted")
+
+// validateSecretBox checks the nonce and the ciphertext of a key encrypted with ChaCha20-Poly1305
+// have the sizes encrypting a key of the size produces. Material which is entirely empty is missing
+// rather than corrupted, and is left to the callers.
+func validateSecretBox(field string, ciphertext, nonce []byte, keySize int) error {
+	if len(ciphertext) == 0 && len(nonce) == 0 {
+		return nil
+	}
+	if len(nonce) != crypto.ChaCha20Poly1305NonceSize {
+		return fmt.Errorf("%w: %s has a %d byte nonce, expected %d",
+			ErrCorruptedEncryptedMaterial, field, len(nonce), crypto.ChaCha20Poly1305NonceSize)
+	}
+	if minSize := keySize + crypto.ChaCha20Poly1305Overhead; len(ciphertext) < minSize {
+		return fmt.Errorf("%w: %s has a %d byte ciphertext, expected at least %d",
+			ErrCorruptedEncryptedMaterial, field, len(ciphertext), minSize)
+	}
+	return nil
+}
+
+// Validate checks the encrypted master key isn't corrupted
+func (emk *EncryptedMasterKey) Validate() error {
+	return validateSecretBox("encrypted master key", emk.Ciphertext, emk.Nonce, crypto.MasterKeySize)
+}
+
+// Validate checks the encrypted private key isn't corrupted
+func (epk *EncryptedPrivateKey) Validate() error {
+	return validateSecretBox("encrypted private key", epk.Ciphertext, epk.Nonce, crypto.BoxSecretKeySize)
+}
+
+// Validate checks the encrypted recovery key isn't corrupted
+func (erk *EncryptedRecoveryKey) Validate() error {
+	return validateSecretBox("encrypted recovery key", erk.Ciphertext, erk.Nonce, crypto.RecoveryKeySize)
+}
+
+// Validate checks the master key encrypted with the recovery key isn't corrupted
+func (mkr *MasterKeyEncryptedWithRecoveryKey) Validate() error {
+	return validateSecretBox("master key encrypted with recovery key", mkr.Ciphertext, mkr.Nonce, crypto.MasterKeySize)
+}
+
+// Validate checks the collection key encrypted with the master key of the owner isn't corrupted
+func (eck *EncryptedCollectionKey) Validate() error {
+	return validateSecretBox("encrypted collection key", eck.Ciphertext, eck.Nonce, crypto.CollectionKeySize)
+}
+
+// ValidateSealed checks the collection key sealed for a member with their public key isn't corrupted.
+// The sealed box may be stored whole in the ciphertext or split into a nonce and a ciphertext, so
+// only the combined size is checked.
+func (eck *EncryptedCollectionKey) ValidateSealed() error {
+	sealed := eck.ToBoxSealBytes()
+	if len(sealed) == 0 {
+		return nil
+	}
+	if minSize := crypto.BoxSealOverhead + crypto.CollectionKeySize; len(sealed) < minSize {
+		return fmt.Errorf("%w: sealed collection key is %d bytes, expected at least %d",
+			ErrCorruptedEncryptedMaterial, len(sealed), minSize)
+	}
+	return nil
+}
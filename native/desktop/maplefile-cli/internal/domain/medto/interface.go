@@ -17,4 +17,13 @@ type MeDTORepository interface {
 	// It takes an UpdateMeRequestDTO and returns the updated MeResponseDTO if successful,
 	// or an error if the operation fails.
 	UpdateMeInCloud(ctx context.Context, request *UpdateMeRequestDTO) (*MeResponseDTO, error)
+
+	// GetRotateMasterKeyChallengeFromCloud requests the challenge which proves the current keys of
+	// the user to the cloud service before a master key rotation.
+	GetRotateMasterKeyChallengeFromCloud(ctx context.Context) (*RotateMasterKeyChallengeResponseDTO, error)
+
+	// RotateMasterKeyInCloud replaces the wrapped keys of the current user and of their collections
+	// in the cloud service with the ones wrapped under a new master key. Submitting keys which are
+	// already current succeeds, so an interrupted rotation can be retried.
+	RotateMasterKeyInCloud(ctx context.Context, request *RotateMasterKeyRequestDTO) (*RotateMasterKeyResponseDTO, error)
 }
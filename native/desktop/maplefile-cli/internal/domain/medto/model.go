@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/gocql/gocql"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
 )
 
 // MeResponseDTO represents the response from the cloud service when getting or updating user profile
@@ -43,3 +45,48 @@ type UpdateMeRequestDTO struct {
 	AgreePromotions                                bool   `bson:"agree_promotions" json:"agree_promotions,omitempty"`
 	AgreeToTrackingAcrossThirdPartyAppsAndServices bool   `bson:"agree_to_tracking_across_third_party_apps_and_services" json:"agree_to_tracking_across_third_party_apps_and_services,omitempty"`
 }
+
+// RotateMasterKeyChallengeResponseDTO represents the challenge a master key rotation must answer
+type RotateMasterKeyChallengeResponseDTO struct {
+	ChallengeID string `json:"challenge_id"`
+	// EncryptedChallenge is the challenge sealed with the public key of the user, base64 encoded.
+	EncryptedChallenge string `json:"encrypted_challenge"`
+}
+
+// RotateMasterKeyRequestDTO represents the request payload for replacing the wrapped keys of the
+// user after a master key rotation. Every wrapped account key is the base64 URL encoding of the
+// nonce followed by the ciphertext.
+type RotateMasterKeyRequestDTO struct {
+	ChallengeID string `json:"challenge_id"`
+	// DecryptedChallenge is the challenge opened with the current private key, base64 encoded.
+	DecryptedChallenge                   string `json:"decrypted_challenge"`
+	NewEncryptedMasterKey                string `json:"new_encrypted_master_key"`
+	NewEncryptedPrivateKey               string `json:"new_encrypted_private_key"`
+	NewEncryptedRecoveryKey              string `json:"new_encrypted_recovery_key"`
+	NewMasterKeyEncryptedWithRecoveryKey string `json:"new_master_key_encrypted_with_recovery_key"`
+	// CollectionKeys are the keys of the owned collections re-wrapped under the new master key, which
+	// the cloud applies together with the account keys.
+	CollectionKeys []*RotatedCollectionKeyDTO `json:"collection_keys,omitempty"`
+}
+
+// RotatedCollectionKeyDTO represents the key of an owned collection wrapped under the new master key
+type RotatedCollectionKeyDTO struct {
+	CollectionID           gocql.UUID                   `json:"collection_id"`
+	EncryptedCollectionKey *keys.EncryptedCollectionKey `json:"encrypted_collection_key"`
+	// Version is the version of the collection the key was re-wrapped from.
+	Version uint64 `json:"version"`
+}
+
+// RotatedCollectionDTO represents the version of a collection after its key was replaced
+type RotatedCollectionDTO struct {
+	CollectionID gocql.UUID `json:"collection_id"`
+	Version      uint64     `json:"version"`
+}
+
+// RotateMasterKeyResponseDTO represents the response after rotating the master key
+type RotateMasterKeyResponseDTO struct {
+	Success     bool                    `json:"success"`
+	Message     string                  `json:"message"`
+	KeyVersion  int                     `json:"key_version"`
+	Collections []*RotatedCollectionDTO `json:"collections,omitempty"`
+}
@@ -12,4 +12,5 @@ type PublicLookupResponseDTO struct {
 	Name              string     `json:"name"`                 // Optional: for display
 	PublicKeyInBase64 string     `json:"public_key_in_base64"` // Base64 encoded
 	VerificationID    string     `json:"verification_id"`
+	Fingerprint       string     `json:"fingerprint"`
 }
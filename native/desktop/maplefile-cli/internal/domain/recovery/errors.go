@@ -15,6 +15,7 @@ var (
 	ErrSessionAlreadyCompleted = errors.New("recovery session already completed")
 	ErrSessionInvalid          = errors.New("recovery session is invalid")
 	ErrSessionNotVerified      = errors.New("recovery session not verified")
+	ErrSessionLocked           = errors.New("another recovery operation is in progress")
 
 	// Challenge errors
 	ErrChallengeNotFound = errors.New("recovery challenge not found")
@@ -14,6 +14,7 @@ type RecoverySession struct {
 	Email              string     `json:"email" bson:"email"`
 	UserID             gocql.UUID `json:"user_id" bson:"user_id"`
 	EncryptedChallenge []byte     `json:"encrypted_challenge" bson:"encrypted_challenge"`
+	Method             string     `json:"method,omitempty" bson:"method,omitempty"` // Empty for sessions started before methods were recorded, which used the recovery key
 	ExpiresAt          time.Time  `json:"expires_at" bson:"expires_at"`
 	IsVerified         bool       `json:"is_verified" bson:"is_verified"`
 	CreatedAt          time.Time  `json:"created_at" bson:"created_at"`
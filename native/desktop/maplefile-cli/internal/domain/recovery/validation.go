@@ -3,10 +3,12 @@ package recovery
 
 import (
 	"encoding/base64"
+	stderrors "errors"
 	"fmt"
-	"net/mail"
 	"strings"
 	"time"
+
+	pkg_email "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/email"
 )
 
 // ValidateRecoveryInitiateRequest validates the initiate recovery request
@@ -96,17 +98,13 @@ func ValidateEmail(email string) error {
 		return NewValidationError("email", "email is required")
 	}
 
-	// Parse and validate email format
-	_, err := mail.ParseAddress(email)
-	if err != nil {
+	if err := pkg_email.Validate(email); err != nil {
+		if stderrors.Is(err, pkg_email.ErrTooLong) {
+			return NewValidationError("email", "email too long (max 254 characters)")
+		}
 		return NewValidationError("email", "invalid email format")
 	}
 
-	// Additional email validation rules
-	if len(email) > 254 {
-		return NewValidationError("email", "email too long (max 254 characters)")
-	}
-
 	return nil
 }
 
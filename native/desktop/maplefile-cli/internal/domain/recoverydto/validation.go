@@ -2,10 +2,11 @@
 package recoverydto
 
 import (
-	"encoding/base64"
 	"fmt"
 	"net/mail"
 	"strings"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
 )
 
 // ValidateRecoveryInitiateRequestDTO validates the initiate recovery request
@@ -62,13 +63,9 @@ func ValidateRecoveryVerifyRequestDTO(request *RecoveryVerifyRequestDTO) error {
 	}
 
 	// Validate base64 encoding of decrypted challenge
-	_, err := base64.StdEncoding.DecodeString(request.DecryptedChallenge)
+	_, err := crypto.DecodeBase64Flexible(request.DecryptedChallenge)
 	if err != nil {
-		// Try URL-safe encoding
-		_, err = base64.RawURLEncoding.DecodeString(request.DecryptedChallenge)
-		if err != nil {
-			return fmt.Errorf("decrypted challenge must be valid base64")
-		}
+		return fmt.Errorf("decrypted challenge must be valid base64")
 	}
 
 	return nil
@@ -119,14 +116,10 @@ func validateBase64Field(fieldName, value string) error {
 		return fmt.Errorf("%s is required", fieldName)
 	}
 
-	// Try standard base64 first
-	_, err := base64.StdEncoding.DecodeString(value)
+	// Accept standard or URL-safe base64, padded or not
+	_, err := crypto.DecodeBase64Flexible(value)
 	if err != nil {
-		// Try URL-safe base64
-		_, err = base64.RawURLEncoding.DecodeString(value)
-		if err != nil {
-			return fmt.Errorf("%s contains invalid base64 data", fieldName)
-		}
+		return fmt.Errorf("%s contains invalid base64 data", fieldName)
 	}
 
 	return nil
@@ -2,11 +2,19 @@
 package syncdto
 
 import (
+	"errors"
 	"time"
 
 	"github.com/gocql/gocql"
 )
 
+// Largest batch sizes the cloud accepts for the sync endpoints. The cloud replaces larger limits
+// with its default page size instead of rejecting them, so clients cap their batch sizes at these.
+const (
+	MaxCollectionSyncBatchSize int64 = 5000
+	MaxFileSyncBatchSize       int64 = 10000
+)
+
 // SyncCursorDTO represents cursor-based pagination for sync operations
 type SyncCursorDTO struct {
 	LastModified time.Time  `json:"last_modified"`
@@ -49,15 +57,71 @@ type FileSyncResponseDTO struct {
 	HasMore    bool           `json:"has_more"`
 }
 
+// Sync operations recorded against a `SyncError`
+const (
+	SyncOperationGetLocal        = "get_local"
+	SyncOperationCreateLocal     = "create_local"
+	SyncOperationUpdateLocal     = "update_local"
+	SyncOperationDeleteLocal     = "delete_local"
+	SyncOperationSaveSyncState   = "save_sync_state"
+	SyncOperationProcessResponse = "process_response"
+	SyncOperationAutoOnload      = "auto_onload"
+)
+
+// Errors wrapped by the repository when the cloud answers a sync request with an error status, so
+// callers can tell a failure which may go away from a request the cloud will keep rejecting.
+var (
+	// ErrCloudUnavailable is wrapped when the cloud failed on its side or throttled the request.
+	ErrCloudUnavailable = errors.New("cloud unavailable")
+	// ErrRequestRejected is wrapped when the cloud rejected the request as invalid or unauthorized.
+	ErrRequestRejected = errors.New("request rejected by the cloud")
+)
+
+// SyncError represents a single failure recorded during a sync operation
+type SyncError struct {
+	// ID of the collection or file which failed, empty for failures affecting the whole sync.
+	ID        string `json:"id,omitempty"`
+	Operation string `json:"operation"`
+	Message   string `json:"message"`
+	// Retryable is true if running the sync again may succeed, false for permanent failures
+	// such as decryption errors or invalid data.
+	Retryable bool `json:"retryable"`
+}
+
+// String returns a human readable representation of the sync error
+func (e SyncError) String() string {
+	if e.ID == "" {
+		return e.Operation + ": " + e.Message
+	}
+	return e.Operation + " " + e.ID + ": " + e.Message
+}
+
 // SyncResult represents the result of a sync operation
 type SyncResult struct {
-	CollectionsProcessed int      `json:"collections_processed"`
-	FilesProcessed       int      `json:"files_processed"`
-	CollectionsAdded     int      `json:"collections_added"`
-	CollectionsUpdated   int      `json:"collections_updated"`
-	CollectionsDeleted   int      `json:"collections_deleted"`
-	FilesAdded           int      `json:"files_added"`
-	FilesUpdated         int      `json:"files_updated"`
-	FilesDeleted         int      `json:"files_deleted"`
-	Errors               []string `json:"errors,omitempty"`
+	CollectionsProcessed int `json:"collections_processed"`
+	FilesProcessed       int `json:"files_processed"`
+	CollectionsAdded     int `json:"collections_added"`
+	CollectionsUpdated   int `json:"collections_updated"`
+	CollectionsDeleted   int `json:"collections_deleted"`
+	FilesAdded           int `json:"files_added"`
+	FilesUpdated         int `json:"files_updated"`
+	// FilesMetadataOnlyUpdated and FilesContentUpdated split FilesUpdated by whether the encrypted
+	// content of the file changed, which is what costs bandwidth once the file is onloaded.
+	FilesMetadataOnlyUpdated int         `json:"files_metadata_only_updated"`
+	FilesContentUpdated      int         `json:"files_content_updated"`
+	FilesDeleted             int         `json:"files_deleted"`
+	Errors                   []SyncError `json:"errors,omitempty"`
+	// AddedFileIDs are the files created locally by the sync, which are cloud-only until onloaded.
+	AddedFileIDs []gocql.UUID `json:"added_file_ids,omitempty"`
+}
+
+// RetryableErrors returns the errors which may succeed if the sync is run again
+func (r *SyncResult) RetryableErrors() []SyncError {
+	retryable := make([]SyncError, 0)
+	for _, syncErr := range r.Errors {
+		if syncErr.Retryable {
+			retryable = append(retryable, syncErr)
+		}
+	}
+	return retryable
 }
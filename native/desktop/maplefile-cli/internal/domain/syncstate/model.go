@@ -13,4 +13,22 @@ type SyncState struct {
 	LastFileSync       time.Time  `json:"last_file_sync"`
 	LastCollectionID   gocql.UUID `json:"last_collection_id"`
 	LastFileID         gocql.UUID `json:"last_file_id"`
+
+	// CollectionCheckpoint and FileCheckpoint describe the last pass which saved the cursor, until
+	// the records it applied are confirmed to be on disk.
+	CollectionCheckpoint *SyncCheckpoint `json:"collection_checkpoint,omitempty"`
+	FileCheckpoint       *SyncCheckpoint `json:"file_checkpoint,omitempty"`
+}
+
+// SyncCheckpoint records where a sync pass started and the last record it applied locally. The
+// sync state and the records are kept in separate databases, so a crash can persist the cursor of
+// a pass while losing the records it wrote; the checkpoint lets that be detected and the cursor be
+// rewound to where the pass started.
+type SyncCheckpoint struct {
+	PreviousSync time.Time  `json:"previous_sync"`
+	PreviousID   gocql.UUID `json:"previous_id"`
+	// LastAppliedID and LastAppliedVersion identify the last record the pass created or updated,
+	// zero when the pass only skipped or deleted records.
+	LastAppliedID      gocql.UUID `json:"last_applied_id"`
+	LastAppliedVersion uint64     `json:"last_applied_version"`
 }
@@ -19,4 +19,5 @@ type Repository interface {
 	OpenTransaction() error
 	CommitTransaction() error
 	DiscardTransaction()
+	IsInTransaction() bool
 }
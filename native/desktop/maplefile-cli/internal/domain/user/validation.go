@@ -0,0 +1,17 @@
+// monorepo/native/desktop/maplefile-cli/internal/domain/user/validation.go
+package user
+
+// ValidateEncryptedKeys checks the stored encrypted keys of the user aren't corrupted, returning an
+// error wrapping keys.ErrCorruptedEncryptedMaterial if one is.
+func (u *User) ValidateEncryptedKeys() error {
+	if err := u.EncryptedMasterKey.Validate(); err != nil {
+		return err
+	}
+	if err := u.EncryptedPrivateKey.Validate(); err != nil {
+		return err
+	}
+	if err := u.EncryptedRecoveryKey.Validate(); err != nil {
+		return err
+	}
+	return u.MasterKeyEncryptedWithRecoveryKey.Validate()
+}
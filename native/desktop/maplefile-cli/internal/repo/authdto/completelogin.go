@@ -15,6 +15,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	dom_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/authdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
 )
 
 // completeLoginRepository implements CompleteLoginRepository interface
@@ -25,12 +26,12 @@ type completeLoginDTORepository struct {
 }
 
 // NewCompleteLoginDTORepository creates a new repository for login completion
-func NewCompleteLoginDTORepository(logger *zap.Logger, configService config.ConfigService) dom_authdto.CompleteLoginDTORepository {
+func NewCompleteLoginDTORepository(logger *zap.Logger, configService config.ConfigService, cloudTransport *httpclient.FailoverTransport) dom_authdto.CompleteLoginDTORepository {
 	logger = logger.Named("CompleteLoginRepository")
 	return &completeLoginDTORepository{
 		logger:        logger,
 		configService: configService,
-		httpClient:    &http.Client{Timeout: 30 * time.Second},
+		httpClient:    &http.Client{Timeout: 30 * time.Second, Transport: cloudTransport},
 	}
 }
 
@@ -15,6 +15,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	dom_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/authdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
 )
 
 // loginOTTDTORepository implements LoginOTTDTORepository interface
@@ -25,12 +26,12 @@ type loginOTTDTORepository struct {
 }
 
 // NewLoginOTTDTORepository creates a new repository for login OTT DTO operations
-func NewLoginOTTDTORepository(logger *zap.Logger, configService config.ConfigService) dom_authdto.LoginOTTDTORepository {
+func NewLoginOTTDTORepository(logger *zap.Logger, configService config.ConfigService, cloudTransport *httpclient.FailoverTransport) dom_authdto.LoginOTTDTORepository {
 	logger = logger.Named("LoginOTTDTORepository")
 	return &loginOTTDTORepository{
 		logger:        logger,
 		configService: configService,
-		httpClient:    &http.Client{Timeout: 30 * time.Second},
+		httpClient:    &http.Client{Timeout: 30 * time.Second, Transport: cloudTransport},
 	}
 }
 
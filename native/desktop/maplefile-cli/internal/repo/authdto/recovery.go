@@ -15,6 +15,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	dom_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/authdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
 )
 
 // recoveryRepository implements RecoveryRepository interface
@@ -25,12 +26,12 @@ type recoveryRepository struct {
 }
 
 // NewRecoveryRepository creates a new repository for recovery operations
-func NewRecoveryRepository(logger *zap.Logger, configService config.ConfigService) dom_authdto.RecoveryRepository {
+func NewRecoveryRepository(logger *zap.Logger, configService config.ConfigService, cloudTransport *httpclient.FailoverTransport) dom_authdto.RecoveryRepository {
 	logger = logger.Named("RecoveryRepository")
 	return &recoveryRepository{
 		logger:        logger,
 		configService: configService,
-		httpClient:    &http.Client{Timeout: 30 * time.Second},
+		httpClient:    &http.Client{Timeout: 30 * time.Second, Transport: cloudTransport},
 	}
 }
 
@@ -11,23 +11,27 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	dom_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/authdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
 )
 
 // tokenDTORepositoryImpl implements the TokenDTORepository interface
 type tokenDTORepositoryImpl struct {
 	logger        *zap.Logger
 	configService config.ConfigService
+	serverClock   *httpclient.ServerClock
 }
 
 // NewTokenDTORepository creates a new instance of TokenDTORepository
 func NewTokenDTORepository(
 	logger *zap.Logger,
 	configService config.ConfigService,
+	serverClock *httpclient.ServerClock,
 ) dom_authdto.TokenDTORepository {
 	logger = logger.Named("TokenRepository")
 	return &tokenDTORepositoryImpl{
 		logger:        logger,
 		configService: configService,
+		serverClock:   serverClock,
 	}
 }
 
@@ -51,15 +55,17 @@ func (s *tokenDTORepositoryImpl) GetAccessToken(ctx context.Context) (string, er
 		return "", fmt.Errorf("no logged in user credentials found")
 	}
 
-	// Check if token is expired or will expire soon (within 30 seconds as a buffer)
-	if creds.AccessToken == "" || time.Now().Add(30*time.Second).After(*creds.AccessTokenExpiryTime) {
+	// Check if token is expired or will expire soon (within 30 seconds as a buffer), the expiry
+	// times are issued by the server so they are compared with the server clock.
+	now := s.serverClock.Now()
+	if creds.AccessToken == "" || now.Add(30*time.Second).After(*creds.AccessTokenExpiryTime) {
 		// Check if we have a refresh token
 		if creds.RefreshToken == "" {
 			return "", errors.NewAppError("no refresh token available", nil)
 		}
 
 		// Check if refresh token is still valid
-		if time.Now().After(*creds.RefreshTokenExpiryTime) {
+		if now.After(*creds.RefreshTokenExpiryTime) {
 			return "", errors.NewAppError("refresh token has expired, please login again", nil)
 		}
 
@@ -15,6 +15,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	dom_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/authdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
 )
 
 // emailVerificationDTORepository implements EmailVerificationRepository interface
@@ -25,12 +26,12 @@ type emailVerificationDTORepository struct {
 }
 
 // NewEmailVerificationDTORepository creates a new repository for email verification
-func NewEmailVerificationDTORepository(logger *zap.Logger, configService config.ConfigService) dom_authdto.EmailVerificationDTORepository {
+func NewEmailVerificationDTORepository(logger *zap.Logger, configService config.ConfigService, cloudTransport *httpclient.FailoverTransport) dom_authdto.EmailVerificationDTORepository {
 	logger = logger.Named("EmailVerificationDTORepository")
 	return &emailVerificationDTORepository{
 		logger:        logger,
 		configService: configService,
-		httpClient:    &http.Client{Timeout: 30 * time.Second},
+		httpClient:    &http.Client{Timeout: 30 * time.Second, Transport: cloudTransport},
 	}
 }
 
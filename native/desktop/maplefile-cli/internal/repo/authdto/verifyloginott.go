@@ -15,6 +15,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	dom_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/authdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
 )
 
 // loginOTTVerificationDTORepository implements LoginOTTVerificationDTORepository interface
@@ -25,12 +26,12 @@ type loginOTTVerificationDTORepository struct {
 }
 
 // NewLoginOTTVerificationDTORepository creates a new repository for login OTT verification
-func NewLoginOTTVerificationDTORepository(logger *zap.Logger, configService config.ConfigService) dom_authdto.LoginOTTVerificationDTORepository {
+func NewLoginOTTVerificationDTORepository(logger *zap.Logger, configService config.ConfigService, cloudTransport *httpclient.FailoverTransport) dom_authdto.LoginOTTVerificationDTORepository {
 	logger = logger.Named("LoginOTTVerificationDTORepository")
 	return &loginOTTVerificationDTORepository{
 		logger:        logger,
 		configService: configService,
-		httpClient:    &http.Client{Timeout: 30 * time.Second},
+		httpClient:    &http.Client{Timeout: 30 * time.Second, Transport: cloudTransport},
 	}
 }
 
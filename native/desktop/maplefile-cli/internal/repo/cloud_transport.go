@@ -0,0 +1,93 @@
+// Location: monorepo/native/desktop/maplefile-cli/internal/repo/cloud_transport.go
+package repo
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/retry"
+)
+
+// NewCloudTransport creates the HTTP transport shared by every request made to the cloud. Requests
+// are built with the cloud provider address and are failed over to the configured failover
+// addresses while it is unhealthy, within the configured retry budget. The responses are used to
+// measure the server clock.
+func NewCloudTransport(
+	logger *zap.Logger,
+	configService config.ConfigService,
+	serverClock *httpclient.ServerClock,
+) *httpclient.FailoverTransport {
+	ctx := context.Background()
+	addresses := make([]string, 0)
+
+	primary, err := configService.GetCloudProviderAddress(ctx)
+	if err != nil {
+		logger.Warn("⚠️ Failed to get cloud provider address", zap.Error(err))
+	} else {
+		addresses = append(addresses, primary)
+	}
+
+	failovers, err := configService.GetCloudProviderFailoverAddresses(ctx)
+	if err != nil {
+		logger.Warn("⚠️ Failed to get cloud provider failover addresses", zap.Error(err))
+	}
+	addresses = append(addresses, failovers...)
+
+	// Skip invalid addresses instead of failing, the requests made with them fail on their own
+	// and the user must still be able to fix the configuration.
+	endpoints := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		if _, err := httpclient.ParseEndpoint(address); err != nil {
+			logger.Warn("⚠️ Ignoring invalid cloud endpoint", zap.Error(err))
+			continue
+		}
+		endpoints = append(endpoints, address)
+	}
+
+	transport, err := httpclient.NewFailoverTransport(logger, nil, endpoints)
+	if err != nil {
+		// Unreachable since every endpoint was validated above.
+		logger.Error("❌ Failed to create cloud transport", zap.Error(err))
+		transport, _ = httpclient.NewFailoverTransport(logger, nil, nil)
+	}
+	transport.SetServerClock(serverClock)
+
+	budget, err := configService.GetCloudRetryBudget(ctx)
+	if err != nil {
+		logger.Warn("⚠️ Failed to get cloud retry budget, using the defaults", zap.Error(err))
+		return transport
+	}
+	policy := retry.DefaultPolicy()
+	policy.MaxAttempts = budget.MaxAttempts
+	transport.SetRetryPolicy(policy)
+	transport.SetCircuitBreaker(budget.BreakerThreshold, budget.BreakerCooldown())
+	return transport
+}
+
+// NewServerClock creates the clock used to check the expiry of times issued by the cloud. It starts
+// from the offset measured during the previous run, since expiry is often checked before the first
+// request is made, and persists every noticeable change of the offset.
+func NewServerClock(logger *zap.Logger, configService config.ConfigService) *httpclient.ServerClock {
+	ctx := context.Background()
+
+	offset, err := configService.GetServerClockOffset(ctx)
+	if err != nil {
+		logger.Warn("⚠️ Failed to get server clock offset", zap.Error(err))
+	}
+	correct, err := configService.GetServerTimeSync(ctx)
+	if err != nil {
+		logger.Warn("⚠️ Failed to get server time sync setting", zap.Error(err))
+	}
+
+	clock := httpclient.NewServerClock(logger, offset, correct)
+	clock.OnMeasure(func(offset time.Duration) {
+		if err := configService.SetServerClockOffset(context.Background(), offset); err != nil {
+			logger.Warn("⚠️ Failed to save server clock offset", zap.Error(err))
+		}
+	})
+	return clock
+}
@@ -35,6 +35,15 @@ func (r *collectionRepository) GetByID(ctx context.Context, id gocql.UUID) (*col
 		r.logger.Error("Failed to deserialize collection", zap.Error(err))
 		return nil, errors.NewAppError("failed to deserialize collection", err)
 	}
+	if collection != nil {
+		// Catch corrupted keys here, they would otherwise surface as a wrong password on decryption.
+		if err := collection.ValidateEncryptedKeys(); err != nil {
+			r.logger.Error("Stored collection has corrupted encrypted keys",
+				zap.String("key", key),
+				zap.Error(err))
+			return nil, errors.NewAppError("failed to load collection", err)
+		}
+	}
 
 	return collection, nil
 }
@@ -32,6 +32,14 @@ func (r *collectionRepository) List(ctx context.Context, filter dom_collection.C
 			return nil // Continue iteration despite error
 		}
 
+		// Skip collections with corrupted keys, like GetByID refuses them. Sync replaces them with the cloud collection.
+		if err := collection.ValidateEncryptedKeys(); err != nil {
+			r.logger.Error("Stored collection has corrupted encrypted keys, skipping",
+				zap.String("key", keyStr),
+				zap.Error(err))
+			return nil
+		}
+
 		// Apply filters
 
 		// Filter by parentID if specified
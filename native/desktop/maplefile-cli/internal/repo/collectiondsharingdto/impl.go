@@ -10,6 +10,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	dom_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/authdto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collectionsharingdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
 )
 
 // collectionDTORepository implements the collection.RemoteCollectionRepository interface
@@ -25,12 +26,13 @@ func NewCollectionSharingDTORepository(
 	logger *zap.Logger,
 	configService config.ConfigService,
 	tokenRepository dom_authdto.TokenDTORepository,
+	cloudTransport *httpclient.FailoverTransport,
 ) collectionsharingdto.CollectionSharingDTORepository {
 	logger = logger.Named("CollectionSharingDTORepository")
 	return &collectionSharingDTORepository{
 		logger:          logger,
 		configService:   configService,
 		tokenRepository: tokenRepository,
-		httpClient:      &http.Client{Timeout: 30 * time.Second},
+		httpClient:      &http.Client{Timeout: 30 * time.Second, Transport: cloudTransport},
 	}
 }
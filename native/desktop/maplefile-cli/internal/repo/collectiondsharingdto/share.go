@@ -44,6 +44,9 @@ func (r *collectionSharingDTORepository) ShareCollectionInCloud(ctx context.Cont
 		"encrypted_collection_key": encryptedKeyBytes, // Send as bytes
 		"share_with_descendants":   request.ShareWithDescendants,
 	}
+	if !request.ExpiresAt.IsZero() {
+		requestBody["expires_at"] = request.ExpiresAt
+	}
 
 	r.logger.Debug("🔍 HTTP request body for sharing",
 		zap.Any("requestBody", requestBody),
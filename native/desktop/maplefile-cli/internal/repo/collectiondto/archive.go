@@ -0,0 +1,135 @@
+// monorepo/native/desktop/maplefile-cli/internal/repo/collectiondto/archive.go
+package collectiondto
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+)
+
+// ArchiveInCloudByID archives a collection in the cloud
+func (r *collectionDTORepository) ArchiveInCloudByID(ctx context.Context, id gocql.UUID) error {
+	return r.changeStateInCloud(ctx, id, "archive")
+}
+
+// RestoreInCloudByID restores an archived collection in the cloud
+func (r *collectionDTORepository) RestoreInCloudByID(ctx context.Context, id gocql.UUID) error {
+	return r.changeStateInCloud(ctx, id, "restore")
+}
+
+// changeStateInCloud calls the state changing endpoint of a collection named by the action
+func (r *collectionDTORepository) changeStateInCloud(ctx context.Context, id gocql.UUID, action string) error {
+	r.logger.Debug("📦 Changing collection state in cloud",
+		zap.String("collectionID", id.String()),
+		zap.String("action", action))
+
+	// Validate input
+	if id.String() == "" {
+		r.logger.Error("❌ Collection ID is required")
+		return errors.NewAppError("collection ID is required", nil)
+	}
+
+	// Get access token
+	accessToken, err := r.tokenRepository.GetAccessToken(ctx)
+	if err != nil {
+		r.logger.Error("❌ Failed to get access token", zap.Error(err))
+		return errors.NewAppError("failed to get access token", err)
+	}
+
+	// Get server URL from configuration
+	serverURL, err := r.configService.GetCloudProviderAddress(ctx)
+	if err != nil {
+		r.logger.Error("❌ Failed to get cloud provider address", zap.Error(err))
+		return errors.NewAppError("failed to get cloud provider address", err)
+	}
+
+	// Create state change URL
+	actionURL := fmt.Sprintf("%s/maplefile/api/v1/collections/%s/%s", serverURL, id.String(), action)
+	r.logger.Info("➡️ Making HTTP request to change collection state",
+		zap.String("method", "POST"),
+		zap.String("url", actionURL))
+
+	// Create HTTP request
+	req, err := http.NewRequestWithContext(ctx, "POST", actionURL, nil)
+	if err != nil {
+		r.logger.Error("❌ Failed to create HTTP request for changing collection state",
+			zap.String("url", actionURL),
+			zap.Error(err))
+		return errors.NewAppError("failed to create HTTP request", err)
+	}
+
+	// Set headers
+	req.Header.Set("Authorization", "JWT "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	// Execute the request
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		r.logger.Error("❌ Failed to execute HTTP request to change collection state",
+			zap.String("url", actionURL),
+			zap.Error(err))
+		return errors.NewAppError("failed to connect to server", err)
+	}
+	defer resp.Body.Close()
+
+	r.logger.Info("⬅️ Received HTTP response",
+		zap.String("status", resp.Status),
+		zap.Int("statusCode", resp.StatusCode))
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		r.logger.Error("❌ Failed to read HTTP response body", zap.Error(err))
+		return errors.NewAppError("failed to read response", err)
+	}
+
+	// Handle different status codes
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		r.logger.Info("✅ Successfully changed collection state in cloud",
+			zap.String("collectionID", id.String()),
+			zap.String("action", action))
+		return nil
+
+	case http.StatusNotFound:
+		r.logger.Warn("⚠️ Collection not found on server",
+			zap.String("collectionID", id.String()),
+			zap.String("status", resp.Status))
+		return errors.NewAppError("collection not found", nil)
+
+	case http.StatusForbidden:
+		r.logger.Error("🚫 Permission denied to change collection state",
+			zap.String("collectionID", id.String()),
+			zap.String("status", resp.Status))
+		return errors.NewAppError(fmt.Sprintf("permission denied - you don't have rights to %s this collection", action), nil)
+
+	case http.StatusUnauthorized:
+		r.logger.Error("🔐 Authentication failed",
+			zap.String("collectionID", id.String()),
+			zap.String("status", resp.Status))
+		return errors.NewAppError("authentication failed - please login again", nil)
+
+	default:
+		r.logger.Error("🚨 Server returned an error status code",
+			zap.String("status", resp.Status),
+			zap.Int("statusCode", resp.StatusCode),
+			zap.ByteString("body", body))
+
+		// Try to parse error message from response body
+		var errorResponse map[string]interface{}
+		if err := json.Unmarshal(body, &errorResponse); err == nil {
+			if errMsg, ok := errorResponse["message"].(string); ok {
+				return errors.NewAppError(fmt.Sprintf("server error: %s", errMsg), nil)
+			}
+		}
+
+		return errors.NewAppError(fmt.Sprintf("server returned error status: %s", resp.Status), nil)
+	}
+}
@@ -140,14 +140,8 @@ func (r *collectionDTORepository) GetFromCloudByID(ctx context.Context, id gocql
 	}
 
 	// Convert collection's own encrypted key if present
-	if len(apiResponse.EncryptedCollectionKey.Ciphertext) > 0 {
-		response.EncryptedCollectionKey = &keys.EncryptedCollectionKey{
-			Ciphertext: apiResponse.EncryptedCollectionKey.Ciphertext,
-			Nonce:      apiResponse.EncryptedCollectionKey.Nonce,
-			KeyVersion: 1, // Default version for existing keys
-			// RotatedAt:    &createdAt,
-			PreviousKeys: []keys.EncryptedHistoricalKey{},
-		}
+	if apiResponse.EncryptedCollectionKey != nil && len(apiResponse.EncryptedCollectionKey.Ciphertext) > 0 {
+		response.EncryptedCollectionKey = withKeyVersionDefaults(apiResponse.EncryptedCollectionKey)
 	}
 
 	r.logger.Debug("🔎 Looking at API response",
@@ -166,7 +160,7 @@ func (r *collectionDTORepository) GetFromCloudByID(ctx context.Context, id gocql
 		var encryptedCollectionKey *keys.EncryptedCollectionKey
 		if len(member.EncryptedCollectionKey) > 0 {
 			// Create EncryptedCollectionKey from box_seal bytes
-			encryptedCollectionKey = keys.NewEncryptedCollectionKeyFromBoxSeal(member.EncryptedCollectionKey)
+			encryptedCollectionKey = memberCollectionKey(member.EncryptedCollectionKey, response.EncryptedCollectionKey)
 		}
 
 		response.Members[i] = &collectiondto.CollectionMembershipDTO{
@@ -202,3 +196,28 @@ func (r *collectionDTORepository) GetFromCloudByID(ctx context.Context, id gocql
 		zap.String("collectionID", id.String()))
 	return response, nil
 }
+
+// withKeyVersionDefaults fills in the version and history of a collection key created before keys
+// were versioned
+func withKeyVersionDefaults(key *keys.EncryptedCollectionKey) *keys.EncryptedCollectionKey {
+	if key.KeyVersion == 0 {
+		key.KeyVersion = 1 // Default version for keys created before versioning
+	}
+	if key.PreviousKeys == nil {
+		key.PreviousKeys = []keys.EncryptedHistoricalKey{}
+	}
+	return key
+}
+
+// memberCollectionKey creates the collection key wrapped for a member from its box_seal bytes. The
+// cloud doesn't send a version with member keys, but it re-wraps every member key whenever the
+// collection key is rotated, so a member key is for the collection key sent alongside it. Storing
+// that version lets a membership saved locally be told apart from a newer collection key.
+func memberCollectionKey(boxSeal []byte, collectionKey *keys.EncryptedCollectionKey) *keys.EncryptedCollectionKey {
+	memberKey := keys.NewEncryptedCollectionKeyFromBoxSeal(boxSeal)
+	if collectionKey != nil && collectionKey.KeyVersion > 0 {
+		memberKey.KeyVersion = collectionKey.KeyVersion
+		memberKey.RotatedAt = collectionKey.RotatedAt
+	}
+	return memberKey
+}
@@ -0,0 +1,91 @@
+package collectiondto
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	dom_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/authdto"
+)
+
+// testCloudConfigService points the repository at a test server
+type testCloudConfigService struct {
+	config.ConfigService
+	address string
+}
+
+func (s *testCloudConfigService) GetCloudProviderAddress(ctx context.Context) (string, error) {
+	return s.address, nil
+}
+
+// testTokenRepository hands out a fixed access token
+type testTokenRepository struct {
+	dom_authdto.TokenDTORepository
+}
+
+func (testTokenRepository) GetAccessToken(ctx context.Context) (string, error) {
+	return "token", nil
+}
+
+func TestGetFromCloudByIDKeepsKeyVersionAndHistory(t *testing.T) {
+	id := gocql.TimeUUID()
+	ciphertext := base64.RawURLEncoding.EncodeToString([]byte{0xfb, 0xff, 0x01})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"id": "` + id.String() + `",
+			"encrypted_name": "bmFtZQ",
+			"encrypted_collection_key": {
+				"ciphertext": "` + ciphertext + `",
+				"nonce": "AQI",
+				"key_version": 2,
+				"rotated_at": "2026-10-01T12:00:00Z",
+				"previous_keys": [{"key_version": 1, "ciphertext": "AQI=", "nonce": "AwQ=", "rotated_at": "2026-01-01T00:00:00Z"}]
+			},
+			"members": [{"id": "` + gocql.TimeUUID().String() + `", "encrypted_collection_key": "BQYH"}]
+		}`))
+	}))
+	defer server.Close()
+
+	repo := &collectionDTORepository{
+		logger:          zap.NewNop(),
+		configService:   &testCloudConfigService{address: server.URL},
+		tokenRepository: testTokenRepository{},
+		httpClient:      server.Client(),
+	}
+
+	collection, err := repo.GetFromCloudByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetFromCloudByID() error = %v", err)
+	}
+
+	key := collection.EncryptedCollectionKey
+	if key == nil {
+		t.Fatal("EncryptedCollectionKey = nil")
+	}
+	if string(key.Ciphertext) != string([]byte{0xfb, 0xff, 0x01}) {
+		t.Errorf("Ciphertext = %x", key.Ciphertext)
+	}
+	if key.KeyVersion != 2 {
+		t.Errorf("KeyVersion = %d, want 2", key.KeyVersion)
+	}
+	if key.RotatedAt == nil || key.RotatedAt.Year() != 2026 {
+		t.Errorf("RotatedAt = %v, want the rotation time", key.RotatedAt)
+	}
+	if len(key.PreviousKeys) != 1 || key.PreviousKeys[0].KeyVersion != 1 || string(key.PreviousKeys[0].Ciphertext) != string([]byte{1, 2}) {
+		t.Errorf("PreviousKeys = %+v, want the version 1 key", key.PreviousKeys)
+	}
+
+	// The member key was wrapped for the collection key sent with it
+	if len(collection.Members) != 1 || collection.Members[0].EncryptedCollectionKey == nil {
+		t.Fatalf("Members = %+v, want one member with a key", collection.Members)
+	}
+	if memberKey := collection.Members[0].EncryptedCollectionKey; memberKey.KeyVersion != 2 || string(memberKey.Ciphertext) != string([]byte{5, 6, 7}) {
+		t.Errorf("member key = version %d %x, want version 2 050607", memberKey.KeyVersion, memberKey.Ciphertext)
+	}
+}
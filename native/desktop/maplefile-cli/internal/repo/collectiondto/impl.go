@@ -10,6 +10,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	dom_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/authdto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collectiondto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
 )
 
 // collectionDTORepository implements the collection.RemoteCollectionRepository interface
@@ -25,12 +26,13 @@ func NewCollectionDTORepository(
 	logger *zap.Logger,
 	configService config.ConfigService,
 	tokenRepository dom_authdto.TokenDTORepository,
+	cloudTransport *httpclient.FailoverTransport,
 ) collectiondto.CollectionDTORepository {
 	logger = logger.Named("CollectionDTORepository")
 	return &collectionDTORepository{
 		logger:          logger,
 		configService:   configService,
 		tokenRepository: tokenRepository,
-		httpClient:      &http.Client{Timeout: 30 * time.Second},
+		httpClient:      &http.Client{Timeout: 30 * time.Second, Transport: cloudTransport},
 	}
 }
@@ -128,7 +128,7 @@ func (r *collectionDTORepository) ListFromCloud(ctx context.Context, filter coll
 
 		// Convert collection's own encrypted key if present
 		if apiCollection.EncryptedCollectionKey != nil && len(apiCollection.EncryptedCollectionKey.Ciphertext) > 0 {
-			collection.EncryptedCollectionKey = apiCollection.EncryptedCollectionKey
+			collection.EncryptedCollectionKey = withKeyVersionDefaults(apiCollection.EncryptedCollectionKey)
 		}
 
 		// Convert members
@@ -137,7 +137,7 @@ func (r *collectionDTORepository) ListFromCloud(ctx context.Context, filter coll
 			var encryptedCollectionKey *keys.EncryptedCollectionKey
 			if len(member.EncryptedCollectionKey) > 0 {
 				// Create EncryptedCollectionKey from box_seal bytes
-				encryptedCollectionKey = keys.NewEncryptedCollectionKeyFromBoxSeal(member.EncryptedCollectionKey)
+				encryptedCollectionKey = memberCollectionKey(member.EncryptedCollectionKey, collection.EncryptedCollectionKey)
 			}
 
 			collection.Members[j] = &collectiondto.CollectionMembershipDTO{
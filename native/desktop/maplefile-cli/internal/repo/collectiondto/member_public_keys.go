@@ -0,0 +1,101 @@
+// monorepo/native/desktop/maplefile-cli/internal/repo/collectiondto/member_public_keys.go
+package collectiondto
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collectiondto"
+)
+
+// GetMemberPublicKeysFromCloud fetches the public keys of every member of a collection
+func (r *collectionDTORepository) GetMemberPublicKeysFromCloud(ctx context.Context, id gocql.UUID) (*collectiondto.CollectionMemberPublicKeysResponse, error) {
+	// Defensive programming
+	if id.String() == "" {
+		r.logger.Error("🚨 id is required")
+		return nil, errors.NewAppError("id is required", nil)
+	}
+
+	accessToken, err := r.tokenRepository.GetAccessToken(ctx)
+	if err != nil {
+		r.logger.Error("🚨 Failed to get access token", zap.Error(err))
+		return nil, errors.NewAppError("failed to get access token", err)
+	}
+
+	// Get server URL from configuration
+	serverURL, err := r.configService.GetCloudProviderAddress(ctx)
+	if err != nil {
+		r.logger.Error("🚨 Failed to get cloud provider address", zap.Error(err))
+		return nil, errors.NewAppError("failed to get cloud provider address", err)
+	}
+
+	// Create HTTP request
+	fetchURL := fmt.Sprintf("%s/maplefile/api/v1/collections/%s/members/public-keys", serverURL, id.String())
+	req, err := http.NewRequestWithContext(ctx, "GET", fetchURL, nil)
+	if err != nil {
+		r.logger.Error("🚨 Failed to create HTTP request", zap.String("url", fetchURL), zap.Error(err))
+		return nil, errors.NewAppError("failed to create HTTP request", err)
+	}
+
+	// Set headers
+	req.Header.Set("Authorization", "JWT "+accessToken)
+
+	// Execute the request
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		r.logger.Error("🚨 Failed to execute HTTP request", zap.Error(err))
+		return nil, errors.NewAppError("failed to connect to server", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		r.logger.Error("🚨 Failed to read response body", zap.Error(err))
+		return nil, errors.NewAppError("failed to read response", err)
+	}
+
+	// Handle different status codes
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Continue below
+
+	case http.StatusNotFound:
+		return nil, errors.NewAppError("collection not found", nil)
+
+	case http.StatusForbidden:
+		return nil, errors.NewAppError("permission denied - only the owner or an admin can get the member keys of this collection", nil)
+
+	case http.StatusTooManyRequests:
+		return nil, errors.NewAppError("too many member key requests, please try again in a minute", nil)
+
+	case http.StatusUnauthorized:
+		return nil, errors.NewAppError("authentication failed - please login again", nil)
+
+	default:
+		r.logger.Error("🚨 Server returned an error status code",
+			zap.String("status", resp.Status),
+			zap.Int("statusCode", resp.StatusCode),
+			zap.ByteString("body", body))
+		return nil, errors.NewAppError(fmt.Sprintf("server returned error status: %s", resp.Status), nil)
+	}
+
+	var response collectiondto.CollectionMemberPublicKeysResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		r.logger.Error("🚨 Failed to parse response body", zap.Error(err))
+		return nil, errors.NewAppError("failed to parse response", err)
+	}
+
+	r.logger.Debug("✅ Fetched member public keys from cloud",
+		zap.String("collectionID", id.String()),
+		zap.Int("memberCount", len(response.Members)))
+
+	return &response, nil
+}
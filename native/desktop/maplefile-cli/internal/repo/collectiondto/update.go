@@ -0,0 +1,140 @@
+// monorepo/native/desktop/maplefile-cli/internal/repo/collectiondto/update.go
+package collectiondto
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collectiondto"
+)
+
+// UpdateInCloud updates a collection in the cloud
+func (r *collectionDTORepository) UpdateInCloud(ctx context.Context, request *collectiondto.UpdateCollectionRequestDTO) (*collectiondto.CollectionDTO, error) {
+	// Validate input
+	if request == nil {
+		r.logger.Error("❌ Update collection request is required")
+		return nil, errors.NewAppError("update collection request is required", nil)
+	}
+
+	r.logger.Debug("✏️ Updating collection in cloud",
+		zap.String("collectionID", request.ID.String()),
+		zap.Uint64("version", request.Version))
+
+	// Get access token
+	accessToken, err := r.tokenRepository.GetAccessToken(ctx)
+	if err != nil {
+		r.logger.Error("❌ Failed to get access token", zap.Error(err))
+		return nil, errors.NewAppError("failed to get access token", err)
+	}
+
+	// Get server URL from configuration
+	serverURL, err := r.configService.GetCloudProviderAddress(ctx)
+	if err != nil {
+		r.logger.Error("❌ Failed to get cloud provider address", zap.Error(err))
+		return nil, errors.NewAppError("failed to get cloud provider address", err)
+	}
+
+	// Convert request to JSON
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		r.logger.Error("❌ Failed to marshal update collection request", zap.Error(err))
+		return nil, errors.NewAppError("failed to marshal request", err)
+	}
+
+	// Create update URL
+	updateURL := fmt.Sprintf("%s/maplefile/api/v1/collections/%s", serverURL, request.ID.String())
+	r.logger.Info("➡️ Making HTTP request to update collection",
+		zap.String("method", "PUT"),
+		zap.String("url", updateURL))
+
+	// Create HTTP request
+	req, err := http.NewRequestWithContext(ctx, "PUT", updateURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		r.logger.Error("❌ Failed to create HTTP request for updating collection",
+			zap.String("url", updateURL),
+			zap.Error(err))
+		return nil, errors.NewAppError("failed to create HTTP request", err)
+	}
+
+	// Set headers
+	req.Header.Set("Authorization", "JWT "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	// Execute the request
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		r.logger.Error("❌ Failed to execute HTTP request to update collection",
+			zap.String("url", updateURL),
+			zap.Error(err))
+		return nil, errors.NewAppError("failed to connect to server", err)
+	}
+	defer resp.Body.Close()
+
+	r.logger.Info("⬅️ Received HTTP response",
+		zap.String("status", resp.Status),
+		zap.Int("statusCode", resp.StatusCode))
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		r.logger.Error("❌ Failed to read HTTP response body", zap.Error(err))
+		return nil, errors.NewAppError("failed to read response", err)
+	}
+
+	// Handle different status codes
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var response collectiondto.CollectionDTO
+		if err := json.Unmarshal(body, &response); err != nil {
+			r.logger.Error("❌ Failed to parse update collection response",
+				zap.ByteString("body", body),
+				zap.Error(err))
+			return nil, errors.NewAppError("failed to parse response", err)
+		}
+		r.logger.Info("✅ Successfully updated collection in cloud",
+			zap.String("collectionID", request.ID.String()),
+			zap.Uint64("version", response.Version))
+		return &response, nil
+
+	case http.StatusNotFound:
+		r.logger.Warn("⚠️ Collection not found on server",
+			zap.String("collectionID", request.ID.String()),
+			zap.String("status", resp.Status))
+		return nil, errors.NewAppError("collection not found", nil)
+
+	case http.StatusForbidden:
+		r.logger.Error("🚫 Permission denied to update collection",
+			zap.String("collectionID", request.ID.String()),
+			zap.String("status", resp.Status))
+		return nil, errors.NewAppError("permission denied - you don't have rights to update this collection", nil)
+
+	case http.StatusUnauthorized:
+		r.logger.Error("🔐 Authentication failed",
+			zap.String("collectionID", request.ID.String()),
+			zap.String("status", resp.Status))
+		return nil, errors.NewAppError("authentication failed - please login again", nil)
+
+	default:
+		r.logger.Error("🚨 Server returned an error status code",
+			zap.String("status", resp.Status),
+			zap.Int("statusCode", resp.StatusCode),
+			zap.ByteString("body", body))
+
+		// Try to parse error message from response body
+		var errorResponse map[string]interface{}
+		if err := json.Unmarshal(body, &errorResponse); err == nil {
+			if errMsg, ok := errorResponse["message"].(string); ok {
+				return nil, errors.NewAppError(fmt.Sprintf("server error: %s", errMsg), nil)
+			}
+		}
+
+		return nil, errors.NewAppError(fmt.Sprintf("server returned error status: %s", resp.Status), nil)
+	}
+}
@@ -0,0 +1,116 @@
+// monorepo/native/desktop/maplefile-cli/internal/repo/filedto/create_batch.go
+package filedto
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
+)
+
+// PrepareFileBatchUploadInCloud gets presigned upload URLs for a batch of files
+func (r *fileDTORepository) PrepareFileBatchUploadInCloud(ctx context.Context, request *filedto.PrepareFileBatchUploadRequest) (*filedto.PrepareFileBatchUploadResponse, error) {
+	r.logger.Debug("📝 Preparing file batch upload in cloud",
+		zap.String("collectionID", request.CollectionID.String()),
+		zap.Int("fileCount", len(request.Files)))
+
+	var response filedto.PrepareFileBatchUploadResponse
+	if err := r.postBatchRequest(ctx, "/maplefile/api/v1/files/batch/prepare", request, &response); err != nil {
+		return nil, err
+	}
+
+	r.logger.Info("✅ Successfully prepared file batch upload",
+		zap.Int("uploadCount", len(response.Uploads)),
+		zap.Time("urlExpiration", response.UploadURLExpirationTime))
+
+	return &response, nil
+}
+
+// CreateFileBatchInCloud registers the metadata of a batch of uploaded files in a single transaction
+func (r *fileDTORepository) CreateFileBatchInCloud(ctx context.Context, request *filedto.CreateFileBatchRequest) (*filedto.CreateFileBatchResponse, error) {
+	r.logger.Debug("📝 Creating file batch in cloud",
+		zap.String("collectionID", request.CollectionID.String()),
+		zap.Int("fileCount", len(request.Files)))
+
+	var response filedto.CreateFileBatchResponse
+	if err := r.postBatchRequest(ctx, "/maplefile/api/v1/files/batch", request, &response); err != nil {
+		return nil, err
+	}
+
+	r.logger.Info("✅ Successfully created file batch",
+		zap.Int("fileCount", len(response.Files)))
+
+	return &response, nil
+}
+
+// postBatchRequest sends an authenticated JSON request to the batch endpoints and parses the response
+func (r *fileDTORepository) postBatchRequest(ctx context.Context, path string, request any, response any) error {
+	// Get server URL from configuration
+	serverURL, err := r.configService.GetCloudProviderAddress(ctx)
+	if err != nil {
+		return errors.NewAppError("failed to get cloud provider address", err)
+	}
+
+	// Get access token for authentication
+	accessToken, err := r.tokenRepo.GetAccessToken(ctx)
+	if err != nil {
+		return errors.NewAppError("failed to get access token", err)
+	}
+
+	// Convert request to JSON
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return errors.NewAppError("failed to marshal request", err)
+	}
+
+	// Create HTTP request
+	requestURL := fmt.Sprintf("%s%s", serverURL, path)
+	r.logger.Debug("📡 Making HTTP request", zap.String("url", requestURL))
+
+	req, err := http.NewRequestWithContext(ctx, "POST", requestURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return errors.NewAppError("failed to create HTTP request", err)
+	}
+
+	// Set headers
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("JWT %s", accessToken))
+
+	// Execute the request
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return errors.NewAppError("failed to connect to server", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.NewAppError("failed to read response", err)
+	}
+
+	// Check for error status codes
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		var errorResponse map[string]interface{}
+		if err := json.Unmarshal(body, &errorResponse); err == nil {
+			if errMsg, ok := errorResponse["message"].(string); ok {
+				return errors.NewAppError(fmt.Sprintf("server error: %s", errMsg), nil)
+			}
+		}
+		return errors.NewAppError(fmt.Sprintf("server returned error status: %s | reason: %s", resp.Status, string(body)), nil)
+	}
+
+	// Parse the response
+	if err := json.Unmarshal(body, response); err != nil {
+		return errors.NewAppError("failed to parse response", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// native/desktop/maplefile-cli/internal/repo/filedto/download_range_from_presigned_url.go
+package filedto
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
+)
+
+// DownloadFileRangeViaPresignedURLFromCloud downloads the bytes [start, end) of file content from
+// a presigned URL with an HTTP range request
+func (r *fileDTORepository) DownloadFileRangeViaPresignedURLFromCloud(ctx context.Context, presignedURL string, start, end int64) (*filedto.DownloadedRange, error) {
+	r.logger.Debug("⬇️ Downloading file range from presigned URL",
+		zap.Int64("start", start),
+		zap.Int64("end", end))
+
+	if presignedURL == "" {
+		return nil, errors.NewAppError("presigned URL is required", nil)
+	}
+	if start < 0 || end <= start {
+		return nil, errors.NewAppError(fmt.Sprintf("invalid byte range [%d, %d)", start, end), nil)
+	}
+
+	// Create HTTP GET request to the presigned URL for the range only
+	req, err := http.NewRequestWithContext(ctx, "GET", presignedURL, nil)
+	if err != nil {
+		return nil, errors.NewAppError("failed to create HTTP request for file range download", err)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end-1))
+
+	// Execute the request
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.NewAppError("failed to download file range from presigned URL", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		rangeStart, totalSize, err := parseContentRange(resp.Header.Get("Content-Range"))
+		if err != nil {
+			return nil, errors.NewAppError("invalid content range in file range download", err)
+		}
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, errors.NewAppError("failed to read downloaded file range", err)
+		}
+
+		r.logger.Debug("✅ Successfully downloaded file range from presigned URL",
+			zap.Int64("start", rangeStart),
+			zap.Int("dataSize", len(data)),
+			zap.Int64("totalSize", totalSize))
+
+		return &filedto.DownloadedRange{Data: data, Start: rangeStart, TotalSize: totalSize}, nil
+
+	case http.StatusOK:
+		// The server ignored the range and returned the whole content
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, errors.NewAppError("failed to read downloaded file data", err)
+		}
+
+		r.logger.Debug("ℹ️ Server ignored the range, downloaded the whole file",
+			zap.Int("dataSize", len(data)))
+
+		return &filedto.DownloadedRange{Data: data, Start: 0, TotalSize: int64(len(data))}, nil
+
+	default:
+		// Read response body for error details
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			r.logger.Warn("⚠️ Failed to read range download error response body", zap.Error(err))
+		}
+		return nil, errors.NewAppError(fmt.Sprintf("file range download failed with status %d: %s", resp.StatusCode, string(body)), nil)
+	}
+}
+
+// parseContentRange returns the start and total size from a "bytes start-end/total" header
+func parseContentRange(header string) (int64, int64, error) {
+	spec, ok := strings.CutPrefix(header, "bytes ")
+	if !ok {
+		return 0, 0, fmt.Errorf("unsupported content range %q", header)
+	}
+	byteRange, total, ok := strings.Cut(spec, "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("malformed content range %q", header)
+	}
+	first, _, ok := strings.Cut(byteRange, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("malformed content range %q", header)
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed content range %q: %w", header, err)
+	}
+	totalSize, err := strconv.ParseInt(total, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("content range %q has no total size: %w", header, err)
+	}
+	return start, totalSize, nil
+}
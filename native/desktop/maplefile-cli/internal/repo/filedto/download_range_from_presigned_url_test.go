@@ -0,0 +1,97 @@
+package filedto
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestDownloadFileRangeViaPresignedURLFromCloud(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 10)
+
+	rangeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "file", time.Time{}, bytes.NewReader(content))
+	}))
+	defer rangeServer.Close()
+
+	plainServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(content)
+	}))
+	defer plainServer.Close()
+
+	repo := &fileDTORepository{logger: zap.NewNop(), httpClient: rangeServer.Client()}
+
+	t.Run("range request", func(t *testing.T) {
+		got, err := repo.DownloadFileRangeViaPresignedURLFromCloud(context.Background(), rangeServer.URL, 25, 40)
+		if err != nil {
+			t.Fatalf("DownloadFileRangeViaPresignedURLFromCloud() error = %v", err)
+		}
+		if got.Start != 25 || got.TotalSize != int64(len(content)) || !bytes.Equal(got.Data, content[25:40]) {
+			t.Errorf("DownloadFileRangeViaPresignedURLFromCloud() = %q from %d of %d, want %q from 25 of %d",
+				got.Data, got.Start, got.TotalSize, content[25:40], len(content))
+		}
+	})
+
+	t.Run("range past the end is shortened", func(t *testing.T) {
+		got, err := repo.DownloadFileRangeViaPresignedURLFromCloud(context.Background(), rangeServer.URL, 90, 200)
+		if err != nil {
+			t.Fatalf("DownloadFileRangeViaPresignedURLFromCloud() error = %v", err)
+		}
+		if got.Start != 90 || !bytes.Equal(got.Data, content[90:]) {
+			t.Errorf("DownloadFileRangeViaPresignedURLFromCloud() = %q from %d, want %q from 90", got.Data, got.Start, content[90:])
+		}
+	})
+
+	t.Run("server ignoring the range", func(t *testing.T) {
+		got, err := repo.DownloadFileRangeViaPresignedURLFromCloud(context.Background(), plainServer.URL, 25, 40)
+		if err != nil {
+			t.Fatalf("DownloadFileRangeViaPresignedURLFromCloud() error = %v", err)
+		}
+		if got.Start != 0 || got.TotalSize != int64(len(content)) || !bytes.Equal(got.Data, content) {
+			t.Errorf("DownloadFileRangeViaPresignedURLFromCloud() = %d bytes from %d, want the whole content", len(got.Data), got.Start)
+		}
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		if _, err := repo.DownloadFileRangeViaPresignedURLFromCloud(context.Background(), rangeServer.URL, 40, 40); err == nil {
+			t.Error("DownloadFileRangeViaPresignedURLFromCloud() error = nil, want an error for an empty range")
+		}
+	})
+}
+
+func TestParseContentRange(t *testing.T) {
+	tests := []struct {
+		header    string
+		wantStart int64
+		wantTotal int64
+		wantErr   bool
+	}{
+		{header: "bytes 0-15/1024", wantStart: 0, wantTotal: 1024},
+		{header: "bytes 100-199/200", wantStart: 100, wantTotal: 200},
+		{header: "bytes 0-15/*", wantErr: true},
+		{header: "items 0-15/1024", wantErr: true},
+		{header: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			start, total, err := parseContentRange(tt.header)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseContentRange(%q) error = nil, want an error", tt.header)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseContentRange(%q) error = %v", tt.header, err)
+			}
+			if start != tt.wantStart || total != tt.wantTotal {
+				t.Errorf("parseContentRange(%q) = %d, %d, want %d, %d", tt.header, start, total, tt.wantStart, tt.wantTotal)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	dom_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/authdto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
 )
 
 // fileDTORepository implements the FileDTORepository interface
@@ -25,12 +26,13 @@ func NewFileDTORepository(
 	logger *zap.Logger,
 	configService config.ConfigService,
 	tokenRepo dom_authdto.TokenDTORepository,
+	cloudTransport *httpclient.FailoverTransport,
 ) filedto.FileDTORepository {
 	logger = logger.Named("FileDTORepository")
 	return &fileDTORepository{
 		logger:        logger.With(zap.String("repository", "filedto")),
 		configService: configService,
 		tokenRepo:     tokenRepo,
-		httpClient:    &http.Client{Timeout: 30 * time.Second},
+		httpClient:    &http.Client{Timeout: 30 * time.Second, Transport: cloudTransport},
 	}
 }
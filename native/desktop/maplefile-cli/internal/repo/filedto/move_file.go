@@ -0,0 +1,103 @@
+// native/desktop/maplefile-cli/internal/repo/filedto/move_file.go
+package filedto
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
+)
+
+// MoveFileInCloud moves a file into another collection in the cloud and returns the moved file
+func (r *fileDTORepository) MoveFileInCloud(ctx context.Context, fileID gocql.UUID, request *filedto.MoveFileRequest) (*filedto.FileDTO, error) {
+	r.logger.Debug("📦 Moving file in cloud",
+		zap.String("fileID", fileID.String()),
+		zap.String("collectionID", request.CollectionID.String()),
+		zap.Uint64("version", request.Version))
+
+	if fileID.String() == "" {
+		return nil, errors.NewAppError("file ID is required", nil)
+	}
+
+	// Get server URL from configuration
+	serverURL, err := r.configService.GetCloudProviderAddress(ctx)
+	if err != nil {
+		return nil, errors.NewAppError("failed to get cloud provider address", err)
+	}
+
+	// Get access token for authentication
+	accessToken, err := r.tokenRepo.GetAccessToken(ctx)
+	if err != nil {
+		return nil, errors.NewAppError("failed to get access token", err)
+	}
+
+	// Convert request to JSON
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.NewAppError("failed to marshal request", err)
+	}
+
+	// Create HTTP request
+	requestURL := fmt.Sprintf("%s/maplefile/api/v1/files/%s/move", serverURL, fileID.String())
+	r.logger.Debug("🔬 Making HTTP request", zap.String("url", requestURL))
+
+	req, err := http.NewRequestWithContext(ctx, "POST", requestURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, errors.NewAppError("failed to create HTTP request", err)
+	}
+
+	// Set headers
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("JWT %s", accessToken))
+
+	// Execute the request
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.NewAppError("failed to connect to server", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.NewAppError("failed to read response", err)
+	}
+
+	// Check for error status codes
+	if resp.StatusCode != http.StatusOK {
+		var errorResponse map[string]interface{}
+		if err := json.Unmarshal(body, &errorResponse); err == nil {
+			if errMsg, ok := errorResponse["message"].(string); ok {
+				r.logger.Debug("⚠️ server error",
+					zap.String("fileID", fileID.String()),
+					zap.String("error", errMsg))
+				return nil, errors.NewAppError(fmt.Sprintf("server error: %s", errMsg), nil)
+			}
+		}
+		return nil, errors.NewAppError(fmt.Sprintf("server returned error status: %s | message: %s", resp.Status, string(body)), nil)
+	}
+
+	// Parse the response
+	var fileDTO filedto.FileDTO
+	if err := json.Unmarshal(body, &fileDTO); err != nil {
+		r.logger.Debug("❌ failed to parse response",
+			zap.String("fileID", fileID.String()),
+			zap.Error(err))
+		return nil, errors.NewAppError("failed to parse response", err)
+	}
+
+	r.logger.Info("✅ Successfully moved file in cloud",
+		zap.String("fileID", fileID.String()),
+		zap.String("collectionID", fileDTO.CollectionID.String()),
+		zap.Uint64("version", fileDTO.Version))
+
+	return &fileDTO, nil
+}
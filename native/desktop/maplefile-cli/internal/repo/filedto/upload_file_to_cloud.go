@@ -11,6 +11,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
 )
 
 // UploadFileToCloud uploads the actual file content to cloud storage using presigned URL
@@ -51,6 +52,9 @@ func (r *fileDTORepository) UploadFileToCloud(ctx context.Context, presignedURL
 	}
 
 	// Check for successful upload
+	if resp.StatusCode == http.StatusForbidden {
+		return errors.NewAppError(fmt.Sprintf("file upload failed with status %d: %s", resp.StatusCode, string(body)), filedto.ErrPresignedURLExpired)
+	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		return errors.NewAppError(fmt.Sprintf("file upload failed with status %d: %s", resp.StatusCode, string(body)), nil)
 	}
@@ -101,6 +105,9 @@ func (r *fileDTORepository) UploadThumbnailToCloud(ctx context.Context, presigne
 	}
 
 	// Check for successful upload
+	if resp.StatusCode == http.StatusForbidden {
+		return errors.NewAppError(fmt.Sprintf("thumbnail upload failed with status %d: %s", resp.StatusCode, string(body)), filedto.ErrPresignedURLExpired)
+	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		return errors.NewAppError(fmt.Sprintf("thumbnail upload failed with status %d: %s", resp.StatusCode, string(body)), nil)
 	}
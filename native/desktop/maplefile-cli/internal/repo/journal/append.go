@@ -0,0 +1,94 @@
+// native/desktop/maplefile-cli/internal/repo/journal/append.go
+package journal
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
+)
+
+func (r *journalRepository) Append(ctx context.Context, entry *journal.Entry, maxEntries int) error {
+	if entry == nil {
+		return errors.NewAppError("journal entry is required", nil)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	meta, err := r.getMeta()
+	if err != nil {
+		return err
+	}
+
+	entry.Sequence = meta.NextSequence
+	entryBytes, err := json.Marshal(entry)
+	if err != nil {
+		return errors.NewAppError("failed to serialize journal entry", err)
+	}
+
+	// Write the entry, the rotation and the new meta together so a crash never leaves the
+	// meta pointing at entries which do not exist.
+	if err := r.dbClient.OpenTransaction(); err != nil {
+		return errors.NewAppError("failed to open journal transaction", err)
+	}
+
+	if err := r.dbClient.Set(entryKey(entry.Sequence), entryBytes); err != nil {
+		r.dbClient.DiscardTransaction()
+		return errors.NewAppError("failed to save journal entry", err)
+	}
+	meta.NextSequence++
+
+	// Rotate out the oldest entries once the journal is over capacity.
+	rotated := 0
+	for maxEntries > 0 && meta.NextSequence-meta.OldestSequence > uint64(maxEntries) {
+		if err := r.dbClient.Delete(entryKey(meta.OldestSequence)); err != nil {
+			r.dbClient.DiscardTransaction()
+			return errors.NewAppError("failed to rotate journal entry", err)
+		}
+		meta.OldestSequence++
+		rotated++
+	}
+
+	metaBytes, err := json.Marshal(meta)
+	if err != nil {
+		r.dbClient.DiscardTransaction()
+		return errors.NewAppError("failed to serialize journal meta", err)
+	}
+	if err := r.dbClient.Set(metaKey, metaBytes); err != nil {
+		r.dbClient.DiscardTransaction()
+		return errors.NewAppError("failed to save journal meta", err)
+	}
+
+	if err := r.dbClient.CommitTransaction(); err != nil {
+		r.dbClient.DiscardTransaction()
+		return errors.NewAppError("failed to commit journal entry", err)
+	}
+
+	if rotated > 0 {
+		r.logger.Debug("🔄 Rotated journal entries",
+			zap.Int("rotated", rotated),
+			zap.Int("maxEntries", maxEntries))
+	}
+
+	return nil
+}
+
+// getMeta returns the journal meta, or an empty meta for a new journal
+func (r *journalRepository) getMeta() (*journalMeta, error) {
+	metaBytes, err := r.dbClient.Get(metaKey)
+	if err != nil {
+		return nil, errors.NewAppError("failed to retrieve journal meta", err)
+	}
+	meta := &journalMeta{}
+	if metaBytes == nil {
+		return meta, nil
+	}
+	if err := json.Unmarshal(metaBytes, meta); err != nil {
+		return nil, errors.NewAppError("failed to deserialize journal meta", err)
+	}
+	return meta, nil
+}
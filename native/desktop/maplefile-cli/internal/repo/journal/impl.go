@@ -0,0 +1,50 @@
+// native/desktop/maplefile-cli/internal/repo/journal/impl.go
+package journal
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/storage"
+)
+
+const (
+	// entryKeyPrefix prefixes every entry, the sequence is zero padded so keys sort in append order
+	entryKeyPrefix = "entry_"
+	metaKey        = "journal_meta"
+)
+
+// journalMeta tracks the range of sequences kept in the journal
+type journalMeta struct {
+	// NextSequence is assigned to the next appended entry.
+	NextSequence uint64 `json:"next_sequence"`
+	// OldestSequence is the sequence of the oldest entry which was not rotated out.
+	OldestSequence uint64 `json:"oldest_sequence"`
+}
+
+// journalRepository implements the journal.JournalRepository interface
+type journalRepository struct {
+	logger   *zap.Logger
+	dbClient storage.Storage
+	// mutex serializes appends, entries are recorded concurrently by batch operations.
+	mutex sync.Mutex
+}
+
+// NewJournalRepository creates a new repository for the local operation journal
+func NewJournalRepository(
+	logger *zap.Logger,
+	dbClient storage.Storage,
+) journal.JournalRepository {
+	logger = logger.Named("JournalRepository")
+	return &journalRepository{
+		logger:   logger,
+		dbClient: dbClient,
+	}
+}
+
+func entryKey(sequence uint64) string {
+	return fmt.Sprintf("%s%020d", entryKeyPrefix, sequence)
+}
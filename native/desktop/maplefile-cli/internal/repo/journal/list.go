@@ -0,0 +1,57 @@
+// native/desktop/maplefile-cli/internal/repo/journal/list.go
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
+)
+
+func (r *journalRepository) List(ctx context.Context, filter *journal.Filter) ([]*journal.Entry, error) {
+	entries := make([]*journal.Entry, 0)
+
+	err := r.dbClient.Iterate(func(key, value []byte) error {
+		if !strings.HasPrefix(string(key), entryKeyPrefix) {
+			return nil
+		}
+
+		var entry journal.Entry
+		if err := json.Unmarshal(value, &entry); err != nil {
+			// Skip unreadable entries instead of hiding the rest of the journal.
+			r.logger.Warn("⚠️ Skipping unreadable journal entry",
+				zap.String("key", string(key)),
+				zap.Error(err))
+			return nil
+		}
+		if filter.Matches(&entry) {
+			entries = append(entries, &entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.NewAppError("failed to iterate journal", err)
+	}
+
+	// Keys sort in append order, so the most recent matches are at the end.
+	if filter != nil && filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[len(entries)-filter.Limit:]
+	}
+
+	return entries, nil
+}
+
+func (r *journalRepository) Count(ctx context.Context) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	meta, err := r.getMeta()
+	if err != nil {
+		return 0, err
+	}
+	return int(meta.NextSequence - meta.OldestSequence), nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	dom_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/authdto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/medto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
 )
 
 // meDTORepository implements the medto.MeDTORepository interface
@@ -25,12 +26,13 @@ func NewMeDTORepository(
 	logger *zap.Logger,
 	configService config.ConfigService,
 	tokenRepository dom_authdto.TokenDTORepository,
+	cloudTransport *httpclient.FailoverTransport,
 ) medto.MeDTORepository {
 	logger = logger.Named("MeDTORepository")
 	return &meDTORepository{
 		logger:          logger,
 		configService:   configService,
 		tokenRepository: tokenRepository,
-		httpClient:      &http.Client{Timeout: 30 * time.Second},
+		httpClient:      &http.Client{Timeout: 30 * time.Second, Transport: cloudTransport},
 	}
 }
@@ -0,0 +1,87 @@
+// native/desktop/maplefile-cli/internal/repo/medto/rotate_master_key_challenge.go
+package medto
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/medto"
+)
+
+func (r *meDTORepository) GetRotateMasterKeyChallengeFromCloud(ctx context.Context) (*medto.RotateMasterKeyChallengeResponseDTO, error) {
+	r.logger.Debug("🔑 Requesting master key rotation challenge from cloud")
+
+	// Get access token
+	accessToken, err := r.tokenRepository.GetAccessToken(ctx)
+	if err != nil {
+		r.logger.Error("❌ Failed to get access token", zap.Error(err))
+		return nil, errors.NewAppError("failed to get access token", err)
+	}
+
+	// Get server URL from configuration
+	serverURL, err := r.configService.GetCloudProviderAddress(ctx)
+	if err != nil {
+		r.logger.Error("❌ Failed to get cloud provider address", zap.Error(err))
+		return nil, errors.NewAppError("failed to get cloud provider address", err)
+	}
+
+	// Create HTTP request
+	challengeURL := fmt.Sprintf("%s/maplefile/api/v1/me/rotate-master-key/challenge", serverURL)
+	r.logger.Info("➡️ Making HTTP request for master key rotation challenge",
+		zap.String("method", "POST"),
+		zap.String("url", challengeURL))
+
+	req, err := http.NewRequestWithContext(ctx, "POST", challengeURL, nil)
+	if err != nil {
+		r.logger.Error("❌ Failed to create HTTP request for rotation challenge", zap.String("url", challengeURL), zap.Error(err))
+		return nil, errors.NewAppError("failed to create HTTP request", err)
+	}
+
+	// Set headers
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "JWT "+accessToken)
+
+	// Execute the request
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		r.logger.Error("❌ Failed to execute HTTP request for rotation challenge", zap.String("url", challengeURL), zap.Error(err))
+		return nil, errors.NewAppError("failed to connect to server", err)
+	}
+	defer resp.Body.Close()
+	r.logger.Info("⬅️ Received HTTP response", zap.String("status", resp.Status), zap.Int("statusCode", resp.StatusCode))
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		r.logger.Error("❌ Failed to read HTTP response body", zap.Error(err))
+		return nil, errors.NewAppError("failed to read response", err)
+	}
+
+	// Check for error status codes
+	if resp.StatusCode != http.StatusOK {
+		r.logger.Error("🚨 Server returned an error status code", zap.String("status", resp.Status), zap.Int("statusCode", resp.StatusCode), zap.ByteString("body", body))
+		var errorResponse map[string]interface{}
+		if err := json.Unmarshal(body, &errorResponse); err == nil {
+			if errMsg, ok := errorResponse["message"].(string); ok {
+				return nil, errors.NewAppError(fmt.Sprintf("server error: %s", errMsg), nil)
+			}
+		}
+		return nil, errors.NewAppError(fmt.Sprintf("server returned error status: %s", resp.Status), nil)
+	}
+
+	// Parse the response
+	var response medto.RotateMasterKeyChallengeResponseDTO
+	if err := json.Unmarshal(body, &response); err != nil {
+		r.logger.Error("❌ Failed to parse response body into RotateMasterKeyChallengeResponseDTO", zap.ByteString("body", body), zap.Error(err))
+		return nil, errors.NewAppError("failed to parse response", err)
+	}
+
+	r.logger.Debug("✅ Received master key rotation challenge", zap.String("challengeID", response.ChallengeID))
+	return &response, nil
+}
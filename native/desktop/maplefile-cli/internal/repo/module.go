@@ -12,6 +12,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/repo/collectiondto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/repo/file"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/repo/filedto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/repo/journal"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/repo/medto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/repo/publiclookupdto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/repo/recovery"
@@ -19,6 +20,8 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/repo/syncdto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/repo/syncstate"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/repo/transaction"
+	svc_keyrotation "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/keyrotation"
+	svc_keytrust "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/keytrust"
 	svc_recovery "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/recovery"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/storage/leveldb"
 )
@@ -59,6 +62,12 @@ func RepoModule() fx.Option {
 				fx.ResultTags(`name:"recovery_db_config_provider"`),
 			),
 		),
+		fx.Provide(
+			fx.Annotate(
+				config.NewLevelDBConfigurationProviderForJournal,
+				fx.ResultTags(`name:"journal_db_config_provider"`),
+			),
+		),
 		// NEW: Recovery State Storage Configuration Provider
 		fx.Provide(
 			fx.Annotate(
@@ -66,6 +75,18 @@ func RepoModule() fx.Option {
 				fx.ResultTags(`name:"recovery_state_db_config_provider"`),
 			),
 		),
+		fx.Provide(
+			fx.Annotate(
+				config.NewLevelDBConfigurationProviderForKeyRotationState,
+				fx.ResultTags(`name:"key_rotation_state_db_config_provider"`),
+			),
+		),
+		fx.Provide(
+			fx.Annotate(
+				config.NewLevelDBConfigurationProviderForTrustedKeys,
+				fx.ResultTags(`name:"trusted_keys_db_config_provider"`),
+			),
+		),
 
 		//----------------------------------------------
 		// Provide specific disk storage for our app
@@ -105,6 +126,13 @@ func RepoModule() fx.Option {
 				fx.ResultTags(`name:"recovery_db"`),
 			),
 		),
+		fx.Provide(
+			fx.Annotate(
+				leveldb.NewDiskStorage,
+				fx.ParamTags(`name:"journal_db_config_provider"`),
+				fx.ResultTags(`name:"journal_db"`),
+			),
+		),
 		// NEW: Recovery State Storage Instance
 		fx.Provide(
 			fx.Annotate(
@@ -113,6 +141,27 @@ func RepoModule() fx.Option {
 				fx.ResultTags(`name:"recovery_state_storage"`),
 			),
 		),
+		fx.Provide(
+			fx.Annotate(
+				leveldb.NewDiskStorage,
+				fx.ParamTags(`name:"key_rotation_state_db_config_provider"`),
+				fx.ResultTags(`name:"key_rotation_state_storage"`),
+			),
+		),
+		fx.Provide(
+			fx.Annotate(
+				leveldb.NewDiskStorage,
+				fx.ParamTags(`name:"trusted_keys_db_config_provider"`),
+				fx.ResultTags(`name:"trusted_keys_storage"`),
+			),
+		),
+
+		//----------------------------------------------
+		// Provide the HTTP transport shared by cloud requests
+		// and the clock measured with its responses
+		//----------------------------------------------
+		fx.Provide(NewServerClock),
+		fx.Provide(NewCloudTransport),
 
 		//----------------------------------------------
 		// Provide user repository
@@ -180,6 +229,16 @@ func RepoModule() fx.Option {
 			),
 		),
 
+		//----------------------------------------------
+		// Local operation journal repository
+		//----------------------------------------------
+		fx.Provide(
+			fx.Annotate(
+				journal.NewJournalRepository,
+				fx.ParamTags(``, `name:"journal_db"`),
+			),
+		),
+
 		//----------------------------------------------
 		// Cloud Sync DTO repository
 		//----------------------------------------------
@@ -221,7 +280,27 @@ func RepoModule() fx.Option {
 		fx.Provide(
 			fx.Annotate(
 				svc_recovery.NewRecoveryStateManager,
-				fx.ParamTags(``, `name:"recovery_state_storage"`, ``), // logger, storage, recoveryRepo
+				fx.ParamTags(``, `name:"recovery_state_storage"`, ``, ``), // logger, storage, recoveryRepo, configService
+			),
+		),
+
+		//----------------------------------------------
+		// Key Rotation State Manager
+		//----------------------------------------------
+		fx.Provide(
+			fx.Annotate(
+				svc_keyrotation.NewKeyRotationStateManager,
+				fx.ParamTags(``, `name:"key_rotation_state_storage"`), // logger, storage
+			),
+		),
+
+		//----------------------------------------------
+		// Trusted Recipient Keys
+		//----------------------------------------------
+		fx.Provide(
+			fx.Annotate(
+				svc_keytrust.NewTrustedKeyService,
+				fx.ParamTags(``, `name:"trusted_keys_storage"`), // logger, storage
 			),
 		),
 
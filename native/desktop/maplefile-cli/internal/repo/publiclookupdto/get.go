@@ -40,7 +40,7 @@ func (r *publicLookupDTORepository) GetFromCloud(ctx context.Context, req *publi
 	r.logger.Debug("🔍 Original email from request", zap.String("email", req.Email))
 
 	// ✅ ROBUST: Use url.Values for proper query parameter encoding
-	baseURL := fmt.Sprintf("%s/iam/api/v1/users/lookup", serverURL)
+	baseURL := fmt.Sprintf("%s/iam/api/v1/users/public-key", serverURL)
 
 	// Parse the base URL
 	parsedURL, err := url.Parse(baseURL)
@@ -90,9 +90,13 @@ func (r *publicLookupDTORepository) GetFromCloud(ctx context.Context, req *publi
 
 	// Check for error status codes
 	if resp.StatusCode != http.StatusOK {
-		if strings.Contains(string(body), "email") {
+		if resp.StatusCode == http.StatusTooManyRequests {
+			r.logger.Warn("⚠️ Server rate limited the public key lookup")
+			return nil, errors.NewAppError("too many user lookups, please wait a minute and try again", nil)
+		}
+		if resp.StatusCode == http.StatusNotFound || strings.Contains(string(body), "email") {
 			r.logger.Warn("⚠️ Server returned email not found error")
-			return nil, errors.NewAppError("email does not exist", nil)
+			return nil, errors.NewAppError("no registered user with a verified account exists for this email", nil)
 		}
 		r.logger.Error("🚨 Server returned an error status code",
 			zap.String("publicUserLookupURL", publicUserLookupURL),
@@ -109,7 +113,7 @@ func (r *publicLookupDTORepository) GetFromCloud(ctx context.Context, req *publi
 		return nil, errors.NewAppError("failed to parse response", err)
 	}
 
-	r.logger.Info("✨ Successfully fetched collection from cloud server",
+	r.logger.Info("✨ Successfully fetched public key from cloud server",
 		zap.String("email", req.Email),
 		zap.String("publicUserLookupURL", publicUserLookupURL))
 	return &response, nil
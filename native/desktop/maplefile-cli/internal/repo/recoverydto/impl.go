@@ -9,6 +9,7 @@ import (
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/recoverydto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
 )
 
 // recoveryDTORepository implements the recoverydto.RecoveryDTORepository interface for cloud API calls
@@ -22,11 +23,12 @@ type recoveryDTORepository struct {
 func NewRecoveryDTORepository(
 	logger *zap.Logger,
 	configService config.ConfigService,
+	cloudTransport *httpclient.FailoverTransport,
 ) recoverydto.RecoveryDTORepository {
 	logger = logger.Named("RecoveryDTORepository")
 	return &recoveryDTORepository{
 		logger:        logger,
 		configService: configService,
-		httpClient:    &http.Client{Timeout: 30 * time.Second},
+		httpClient:    &http.Client{Timeout: 30 * time.Second, Transport: cloudTransport},
 	}
 }
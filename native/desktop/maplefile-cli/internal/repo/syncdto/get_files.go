@@ -100,10 +100,10 @@ func (r *syncDTORepository) GetFileSyncDataFromCloud(ctx context.Context, cursor
 		if err := json.Unmarshal(body, &errorResponse); err == nil {
 			if errMsg, ok := errorResponse["message"].(string); ok {
 				r.logger.Error("🔥 Server returned error message in response body", zap.String("message", errMsg))
-				return nil, errors.NewAppError(fmt.Sprintf("server error: %s", errMsg), nil)
+				return nil, errors.NewAppError(fmt.Sprintf("server error: %s", errMsg), statusError(resp.StatusCode))
 			}
 		}
-		return nil, errors.NewAppError(fmt.Sprintf("server returned error status: %s", resp.Status), nil)
+		return nil, errors.NewAppError(fmt.Sprintf("server returned error status: %s", resp.Status), statusError(resp.StatusCode))
 	}
 
 	// Parse the response
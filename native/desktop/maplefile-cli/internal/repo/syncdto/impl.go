@@ -10,6 +10,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	dom_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/authdto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/syncdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
 )
 
 // syncDTORepository implements the syncdto.SyncDTORepository interface
@@ -25,12 +26,22 @@ func NewSyncDTORepository(
 	logger *zap.Logger,
 	configService config.ConfigService,
 	tokenRepository dom_authdto.TokenDTORepository,
+	cloudTransport *httpclient.FailoverTransport,
 ) syncdto.SyncDTORepository {
 	logger = logger.Named("SyncDTORepository")
 	return &syncDTORepository{
 		logger:          logger,
 		configService:   configService,
 		tokenRepository: tokenRepository,
-		httpClient:      &http.Client{Timeout: 30 * time.Second},
+		httpClient:      &http.Client{Timeout: 30 * time.Second, Transport: cloudTransport},
 	}
 }
+
+// statusError returns the error wrapped for an error status of the cloud. Server failures and
+// throttling may succeed later, while any other rejection will be repeated for the same request.
+func statusError(statusCode int) error {
+	if statusCode >= http.StatusInternalServerError || statusCode == http.StatusTooManyRequests {
+		return syncdto.ErrCloudUnavailable
+	}
+	return syncdto.ErrRequestRejected
+}
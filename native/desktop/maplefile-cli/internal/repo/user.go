@@ -47,6 +47,15 @@ func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*domain.User,
 			zap.Any("error", err))
 		return nil, err
 	}
+	if b != nil {
+		// Catch corrupted keys here, they would otherwise surface as a wrong password on decryption.
+		if err := b.ValidateEncryptedKeys(); err != nil {
+			r.logger.Error("❌ Stored user has corrupted encrypted keys",
+				zap.Any("email", email),
+				zap.Any("error", err))
+			return nil, errors.NewAppError("failed to load user", err)
+		}
+	}
 	return b, nil
 }
 
@@ -112,3 +121,7 @@ func (r *UserRepo) CommitTransaction() error {
 func (r *UserRepo) DiscardTransaction() {
 	r.dbClient.DiscardTransaction()
 }
+
+func (r *UserRepo) IsInTransaction() bool {
+	return r.dbClient.IsInTransaction()
+}
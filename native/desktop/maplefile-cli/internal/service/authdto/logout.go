@@ -10,6 +10,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/transaction"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectioncrypto"
 	uc_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/authdto"
 	uc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collection"
 	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
@@ -34,6 +35,7 @@ type logoutService struct {
 	deleteFileUseCase            uc_file.DeleteFileUseCase
 	deleteLocalFileUseCase       uc_localfile.DeleteFileUseCase
 	resetSyncStateUseCase        uc_syncstate.ResetSyncStateUseCase
+	collectionKeyCache           collectioncrypto.CollectionKeyCache
 }
 
 // NewLogoutService creates a new logout service
@@ -48,6 +50,7 @@ func NewLogoutService(
 	deleteFileUseCase uc_file.DeleteFileUseCase,
 	deleteLocalFileUseCase uc_localfile.DeleteFileUseCase,
 	resetSyncStateUseCase uc_syncstate.ResetSyncStateUseCase,
+	collectionKeyCache collectioncrypto.CollectionKeyCache,
 ) LogoutService {
 	logger = logger.Named("LogoutService")
 	return &logoutService{
@@ -61,6 +64,7 @@ func NewLogoutService(
 		deleteFileUseCase:            deleteFileUseCase,
 		deleteLocalFileUseCase:       deleteLocalFileUseCase,
 		resetSyncStateUseCase:        resetSyncStateUseCase,
+		collectionKeyCache:           collectionKeyCache,
 	}
 }
 
@@ -79,6 +83,9 @@ func (s *logoutService) Logout(ctx context.Context) error {
 	currentUserEmail := credentials.Email
 	s.logger.Info("🚪 Processing logout request with complete data cleanup", zap.String("email", currentUserEmail))
 
+	// Decrypted collection keys must not outlive the session, even if the cleanup below fails
+	defer s.collectionKeyCache.Clear()
+
 	// Begin transaction for atomic cleanup
 	if err := s.transactionManager.Begin(); err != nil {
 		s.logger.Error("❌ Failed to begin transaction for logout cleanup", zap.Error(err))
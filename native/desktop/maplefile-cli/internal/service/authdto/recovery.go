@@ -12,6 +12,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
 	uc_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/authdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
 )
 
 // RecoveryService provides high-level functionality for account recovery
@@ -39,6 +40,7 @@ type recoveryService struct {
 	useCase       uc_authdto.RecoveryUseCase
 	userRepo      user.Repository
 	configService config.ConfigService
+	serverClock   *httpclient.ServerClock
 
 	// In-memory storage for recovery session
 	mu           sync.Mutex
@@ -51,6 +53,7 @@ func NewRecoveryService(
 	useCase uc_authdto.RecoveryUseCase,
 	userRepo user.Repository,
 	configService config.ConfigService,
+	serverClock *httpclient.ServerClock,
 ) RecoveryService {
 	logger = logger.Named("RecoveryService")
 	return &recoveryService{
@@ -58,6 +61,7 @@ func NewRecoveryService(
 		useCase:       useCase,
 		userRepo:      userRepo,
 		configService: configService,
+		serverClock:   serverClock,
 	}
 }
 
@@ -94,7 +98,7 @@ func (s *recoveryService) SetNewPassword(ctx context.Context, newPassword string
 	}
 
 	// Check if session has expired
-	if time.Now().After(recoveryData.ExpiresAt) {
+	if s.serverClock.Now().After(recoveryData.ExpiresAt) {
 		s.mu.Lock()
 		s.recoveryData = nil
 		s.mu.Unlock()
@@ -161,7 +165,7 @@ func (s *recoveryService) GetRecoveryStatus(ctx context.Context) (*RecoveryStatu
 	}
 
 	// Check if expired
-	if time.Now().After(s.recoveryData.ExpiresAt) {
+	if s.serverClock.Now().After(s.recoveryData.ExpiresAt) {
 		s.recoveryData = nil
 		return &RecoveryStatus{
 			InProgress: false,
@@ -2,7 +2,6 @@
 package authdto
 
 import (
-	"encoding/base64"
 	"encoding/json"
 
 	"go.uber.org/zap"
@@ -56,13 +55,9 @@ func (s *tokenDecryptionService) DecryptTokens(encryptedTokens string, user *use
 		zap.Int("encrypted_length", len(encryptedTokens)))
 
 	// Decode the encrypted tokens from base64
-	encryptedData, err := base64.StdEncoding.DecodeString(encryptedTokens)
+	encryptedData, err := crypto.DecodeBase64Flexible(encryptedTokens)
 	if err != nil {
-		// Try URL-safe encoding as fallback
-		encryptedData, err = base64.RawURLEncoding.DecodeString(encryptedTokens)
-		if err != nil {
-			return "", "", errors.NewAppError("failed to decode encrypted tokens", err)
-		}
+		return "", "", errors.NewAppError("failed to decode encrypted tokens", err)
 	}
 
 	// Validate minimum size: 32 bytes ephemeral pubkey + 24 bytes nonce + overhead
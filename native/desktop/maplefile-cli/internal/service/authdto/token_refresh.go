@@ -15,6 +15,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
 )
 
 // TokenRefreshService handles token refresh with encryption support
@@ -31,6 +32,8 @@ type tokenRefreshService struct {
 	configService          config.ConfigService
 	userRepo               user.Repository
 	tokenDecryptionService TokenDecryptionService
+	cloudTransport         *httpclient.FailoverTransport
+	serverClock            *httpclient.ServerClock
 }
 
 // NewTokenRefreshService creates a new token refresh service
@@ -39,6 +42,8 @@ func NewTokenRefreshService(
 	configService config.ConfigService,
 	userRepo user.Repository,
 	tokenDecryptionService TokenDecryptionService,
+	cloudTransport *httpclient.FailoverTransport,
+	serverClock *httpclient.ServerClock,
 ) TokenRefreshService {
 	logger = logger.Named("TokenRefreshService")
 	return &tokenRefreshService{
@@ -46,6 +51,8 @@ func NewTokenRefreshService(
 		configService:          configService,
 		userRepo:               userRepo,
 		tokenDecryptionService: tokenDecryptionService,
+		cloudTransport:         cloudTransport,
+		serverClock:            serverClock,
 	}
 }
 
@@ -78,7 +85,7 @@ func (s *tokenRefreshService) GetValidAccessToken(ctx context.Context, password
 	}
 
 	// Check if token is expired or will expire soon (within 30 seconds as a buffer)
-	if creds.AccessToken == "" || time.Now().Add(30*time.Second).After(*creds.AccessTokenExpiryTime) {
+	if creds.AccessToken == "" || s.serverClock.Now().Add(30*time.Second).After(*creds.AccessTokenExpiryTime) {
 		s.logger.Info("Access token expired or expiring soon, refreshing",
 			zap.String("email", creds.Email))
 
@@ -105,7 +112,7 @@ func (s *tokenRefreshService) RefreshTokenWithPassword(ctx context.Context, pass
 	}
 
 	// Check if refresh token is still valid
-	if time.Now().After(*creds.RefreshTokenExpiryTime) {
+	if s.serverClock.Now().After(*creds.RefreshTokenExpiryTime) {
 		return "", errors.NewAppError("refresh token has expired, please login again", nil)
 	}
 
@@ -188,7 +195,7 @@ func (s *tokenRefreshService) refreshFromCloud(ctx context.Context, refreshToken
 
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := &http.Client{Timeout: 30 * time.Second, Transport: s.cloudTransport}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to server: %w", err)
@@ -29,32 +29,23 @@ func (t *userVerificationDataTransformer) UpdateUserWithVerificationData(user *u
 	}
 
 	// Store Encrypted Challenge
-	encryptedChallengeBytes, err := base64.StdEncoding.DecodeString(data.EncryptedChallenge)
+	encryptedChallengeBytes, err := crypto.DecodeBase64Flexible(data.EncryptedChallenge)
 	if err != nil {
-		encryptedChallengeBytes, err = base64.RawURLEncoding.DecodeString(data.EncryptedChallenge)
-		if err != nil {
-			return fmt.Errorf("💔 error decoding encrypted challenge: %v", err)
-		}
+		return fmt.Errorf("💔 error decoding encrypted challenge: %v", err)
 	}
 	user.EncryptedChallenge = encryptedChallengeBytes
 
 	// Store Salt
-	salt, err := base64.StdEncoding.DecodeString(data.Salt)
+	salt, err := crypto.DecodeBase64Flexible(data.Salt)
 	if err != nil {
-		salt, err = base64.RawURLEncoding.DecodeString(data.Salt)
-		if err != nil {
-			return fmt.Errorf("🔑💔 error decoding password salt: %v", err)
-		}
+		return fmt.Errorf("🔑💔 error decoding password salt: %v", err)
 	}
 	user.PasswordSalt = salt
 
 	// Store Public Key
-	publicKeyBytes, err := base64.StdEncoding.DecodeString(data.PublicKey)
+	publicKeyBytes, err := crypto.DecodeBase64Flexible(data.PublicKey)
 	if err != nil {
-		publicKeyBytes, err = base64.RawURLEncoding.DecodeString(data.PublicKey)
-		if err != nil {
-			return fmt.Errorf("🔑💔 error decoding public key: %v", err)
-		}
+		return fmt.Errorf("🔑💔 error decoding public key: %v", err)
 	}
 	user.PublicKey.Key = publicKeyBytes
 
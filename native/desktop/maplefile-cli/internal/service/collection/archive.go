@@ -0,0 +1,139 @@
+// internal/service/collection/archive.go
+package collection
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
+	uc "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collection"
+	uc_collectiondto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collectiondto"
+)
+
+// ArchiveService defines the interface for archiving collections. Unlike a soft delete, archiving
+// keeps the collection, its files and its members and only hides it from listings until it is
+// unarchived.
+type ArchiveService interface {
+	Archive(ctx context.Context, id gocql.UUID) (*collection.Collection, error)
+	Unarchive(ctx context.Context, id gocql.UUID) (*collection.Collection, error)
+}
+
+// archiveService implements the ArchiveService interface
+type archiveService struct {
+	logger                            *zap.Logger
+	getUseCase                        uc.GetCollectionUseCase
+	updateUseCase                     uc.UpdateCollectionUseCase
+	archiveCollectionInCloudUseCase   uc_collectiondto.ArchiveCollectionInCloudUseCase
+	unarchiveCollectionInCloudUseCase uc_collectiondto.UnarchiveCollectionInCloudUseCase
+}
+
+// NewArchiveService creates a new service for archiving collections
+func NewArchiveService(
+	logger *zap.Logger,
+	getUseCase uc.GetCollectionUseCase,
+	updateUseCase uc.UpdateCollectionUseCase,
+	archiveCollectionInCloudUseCase uc_collectiondto.ArchiveCollectionInCloudUseCase,
+	unarchiveCollectionInCloudUseCase uc_collectiondto.UnarchiveCollectionInCloudUseCase,
+) ArchiveService {
+	logger = logger.Named("CollectionArchiveService")
+	return &archiveService{
+		logger:                            logger,
+		getUseCase:                        getUseCase,
+		updateUseCase:                     updateUseCase,
+		archiveCollectionInCloudUseCase:   archiveCollectionInCloudUseCase,
+		unarchiveCollectionInCloudUseCase: unarchiveCollectionInCloudUseCase,
+	}
+}
+
+// Archive hides an active collection without deleting it
+func (s *archiveService) Archive(ctx context.Context, id gocql.UUID) (*collection.Collection, error) {
+	return s.changeState(ctx, id, collection.CollectionStateArchived, s.archiveCollectionInCloudUseCase.Execute)
+}
+
+// Unarchive makes an archived collection active again
+func (s *archiveService) Unarchive(ctx context.Context, id gocql.UUID) (*collection.Collection, error) {
+	return s.changeState(ctx, id, collection.CollectionStateActive, s.unarchiveCollectionInCloudUseCase.Execute)
+}
+
+// changeState moves a collection between the active and archived states, first in the cloud and
+// then locally so the local copy never claims a state the cloud rejected.
+func (s *archiveService) changeState(
+	ctx context.Context,
+	id gocql.UUID,
+	newState string,
+	changeInCloud func(ctx context.Context, collectionID gocql.UUID) error,
+) (*collection.Collection, error) {
+	//
+	// STEP 1: Validate the input and the state transition
+	//
+	if id.String() == "" {
+		s.logger.Error("❌ collection ID is required")
+		return nil, errors.NewAppError("collection ID is required", nil)
+	}
+
+	existingCollection, err := s.getUseCase.Execute(ctx, id)
+	if err != nil {
+		s.logger.Error("❌ failed to get collection",
+			zap.String("id", id.String()),
+			zap.Error(err))
+		return nil, err
+	}
+	if existingCollection == nil {
+		return nil, errors.NewAppError("collection not found", nil)
+	}
+
+	// Only archived collections can be unarchived, deleted ones are restored instead.
+	expectedState := collection.CollectionStateActive
+	if newState == collection.CollectionStateActive {
+		expectedState = collection.CollectionStateArchived
+	}
+	if existingCollection.State != expectedState {
+		s.logger.Warn("⚠️ invalid state for archive operation",
+			zap.String("id", id.String()),
+			zap.String("currentState", existingCollection.State),
+			zap.String("newState", newState))
+		return nil, errors.NewAppError("cannot change the archive state of a collection which is "+existingCollection.State, nil)
+	}
+
+	//
+	// STEP 2: Apply the change in the cloud, which bumps the version
+	//
+	updateInput := uc.UpdateCollectionInput{
+		ID:    id,
+		State: &newState,
+	}
+	if existingCollection.SyncStatus != collection.SyncStatusLocalOnly {
+		if err := changeInCloud(ctx, id); err != nil {
+			s.logger.Error("❌ failed to change collection state in cloud",
+				zap.String("id", id.String()),
+				zap.String("newState", newState),
+				zap.Error(err))
+			return nil, err
+		}
+		version := existingCollection.Version + 1
+		updateInput.Version = &version
+	}
+
+	//
+	// STEP 3: Apply the change locally
+	//
+	updatedCollection, err := s.updateUseCase.Execute(ctx, updateInput)
+	if err != nil {
+		s.logger.Error("❌ failed to change local collection state",
+			zap.String("id", id.String()),
+			zap.String("newState", newState),
+			zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("✅ collection archive state changed",
+		zap.String("id", id.String()),
+		zap.String("previousState", existingCollection.State),
+		zap.String("newState", newState),
+		zap.Uint64("version", updatedCollection.Version))
+
+	return updatedCollection, nil
+}
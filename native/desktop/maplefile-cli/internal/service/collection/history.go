@@ -0,0 +1,113 @@
+// internal/service/collection/history.go
+package collection
+
+import (
+	"context"
+	"time"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	uc "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collection"
+	uc_collectiondto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collectiondto"
+)
+
+// HistoryEntry is one change of a collection, with the users involved shown by email when known
+type HistoryEntry struct {
+	Version    uint64    `json:"version"`
+	ChangedAt  time.Time `json:"changed_at"`
+	ChangeType string    `json:"change_type"`
+	ChangedBy  string    `json:"changed_by"`
+	Subject    string    `json:"subject,omitempty"`
+}
+
+// HistoryOutput represents the change history of a collection, most recent change first
+type HistoryOutput struct {
+	CollectionID gocql.UUID      `json:"collection_id"`
+	Entries      []*HistoryEntry `json:"entries"`
+}
+
+// HistoryService defines the interface for getting the change history of a collection
+type HistoryService interface {
+	GetHistory(ctx context.Context, id gocql.UUID) (*HistoryOutput, error)
+}
+
+// historyService implements the HistoryService interface
+type historyService struct {
+	logger                     *zap.Logger
+	getUseCase                 uc.GetCollectionUseCase
+	getHistoryFromCloudUseCase uc_collectiondto.GetCollectionHistoryFromCloudUseCase
+}
+
+// NewHistoryService creates a new service for getting the change history of collections
+func NewHistoryService(
+	logger *zap.Logger,
+	getUseCase uc.GetCollectionUseCase,
+	getHistoryFromCloudUseCase uc_collectiondto.GetCollectionHistoryFromCloudUseCase,
+) HistoryService {
+	logger = logger.Named("CollectionHistoryService")
+	return &historyService{
+		logger:                     logger,
+		getUseCase:                 getUseCase,
+		getHistoryFromCloudUseCase: getHistoryFromCloudUseCase,
+	}
+}
+
+// GetHistory retrieves the change history of a collection from the cloud. User IDs are replaced
+// with the emails of the members of the local copy of the collection, when there is one.
+func (s *historyService) GetHistory(ctx context.Context, id gocql.UUID) (*HistoryOutput, error) {
+	if id.String() == "" {
+		s.logger.Error("❌ collection ID is required")
+		return nil, errors.NewAppError("collection ID is required", nil)
+	}
+
+	changes, err := s.getHistoryFromCloudUseCase.Execute(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The local copy is only used for display, so a missing one is not an error
+	emails := make(map[gocql.UUID]string)
+	if local, err := s.getUseCase.Execute(ctx, id); err != nil {
+		s.logger.Debug("Could not load local collection to resolve member emails",
+			zap.String("id", id.String()),
+			zap.Error(err))
+	} else if local != nil {
+		for _, member := range local.Members {
+			if member != nil && member.RecipientEmail != "" {
+				emails[member.RecipientID] = member.RecipientEmail
+			}
+		}
+	}
+
+	displayUser := func(userID gocql.UUID) string {
+		if userID == (gocql.UUID{}) {
+			return ""
+		}
+		if email, ok := emails[userID]; ok {
+			return email
+		}
+		return userID.String()
+	}
+
+	output := &HistoryOutput{
+		CollectionID: id,
+		Entries:      make([]*HistoryEntry, 0, len(changes)),
+	}
+	for _, change := range changes {
+		output.Entries = append(output.Entries, &HistoryEntry{
+			Version:    change.Version,
+			ChangedAt:  change.ChangedAt,
+			ChangeType: change.ChangeType,
+			ChangedBy:  displayUser(change.ChangedByUserID),
+			Subject:    displayUser(change.SubjectID),
+		})
+	}
+
+	s.logger.Debug("✅ Got collection history",
+		zap.String("id", id.String()),
+		zap.Int("changeCount", len(output.Entries)))
+
+	return output, nil
+}
@@ -23,6 +23,9 @@ type ListService interface {
 	ListRoots(ctx context.Context) (*ListOutput, error)
 	ListByParent(ctx context.Context, parentID gocql.UUID) (*ListOutput, error)
 	ListModifiedLocally(ctx context.Context) (*ListOutput, error)
+	ListByState(ctx context.Context, state string) (*ListOutput, error)
+	ListRootsIncludingArchived(ctx context.Context) (*ListOutput, error)
+	ListByParentIncludingArchived(ctx context.Context, parentID gocql.UUID) (*ListOutput, error)
 }
 
 // listService implements the ListService interface
@@ -95,3 +98,69 @@ func (s *listService) ListModifiedLocally(ctx context.Context) (*ListOutput, err
 		Count:       len(collections),
 	}, nil
 }
+
+// ListByState lists local collections in a specific state
+func (s *listService) ListByState(ctx context.Context, state string) (*ListOutput, error) {
+	collections, err := s.listUseCase.ListByState(ctx, state)
+	if err != nil {
+		s.logger.Error("❌ failed to list collections by state",
+			zap.String("state", state),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return &ListOutput{
+		Collections: collections,
+		Count:       len(collections),
+	}, nil
+}
+
+// ListRootsIncludingArchived lists root-level local collections which are active or archived
+func (s *listService) ListRootsIncludingArchived(ctx context.Context) (*ListOutput, error) {
+	active, err := s.listUseCase.ListRootsByState(ctx, collection.CollectionStateActive)
+	if err != nil {
+		s.logger.Error("❌ failed to list root collections", zap.Error(err))
+		return nil, err
+	}
+	archived, err := s.listUseCase.ListRootsByState(ctx, collection.CollectionStateArchived)
+	if err != nil {
+		s.logger.Error("❌ failed to list archived root collections", zap.Error(err))
+		return nil, err
+	}
+
+	collections := append(active, archived...)
+	return &ListOutput{
+		Collections: collections,
+		Count:       len(collections),
+	}, nil
+}
+
+// ListByParentIncludingArchived lists local collections under a specific parent which are active or archived
+func (s *listService) ListByParentIncludingArchived(ctx context.Context, parentID gocql.UUID) (*ListOutput, error) {
+	// Validate input
+	if parentID.String() == "" {
+		s.logger.Error("❌ parent ID is required")
+		return nil, errors.NewAppError("parent ID is required", nil)
+	}
+
+	active, err := s.listUseCase.ListByParentAndState(ctx, parentID, collection.CollectionStateActive)
+	if err != nil {
+		s.logger.Error("❌ failed to list collections by parent",
+			zap.String("parentID", parentID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+	archived, err := s.listUseCase.ListByParentAndState(ctx, parentID, collection.CollectionStateArchived)
+	if err != nil {
+		s.logger.Error("❌ failed to list archived collections by parent",
+			zap.String("parentID", parentID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	collections := append(active, archived...)
+	return &ListOutput{
+		Collections: collections,
+		Count:       len(collections),
+	}, nil
+}
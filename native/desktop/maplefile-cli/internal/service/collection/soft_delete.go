@@ -18,7 +18,6 @@ import (
 type SoftDeleteService interface {
 	SoftDelete(ctx context.Context, id gocql.UUID) error
 	SoftDeleteWithChildren(ctx context.Context, id gocql.UUID) error
-	Archive(ctx context.Context, id gocql.UUID) error
 	Restore(ctx context.Context, id gocql.UUID) error
 }
 
@@ -155,56 +154,7 @@ func (s *softDeleteService) SoftDeleteWithChildren(ctx context.Context, id gocql
 	return s.SoftDelete(ctx, id)
 }
 
-// Archive marks a collection as archived
-func (s *softDeleteService) Archive(ctx context.Context, id gocql.UUID) error {
-	// Validate input
-	if id.String() == "" {
-		s.logger.Error("❌ collection ID is required")
-		return errors.NewAppError("collection ID is required", nil)
-	}
-
-	// Get the collection to validate it exists and check current state
-	existingCollection, err := s.getUseCase.Execute(ctx, id)
-	if err != nil {
-		s.logger.Error("❌ failed to get collection for archive",
-			zap.String("id", id.String()),
-			zap.Error(err))
-		return err
-	}
-
-	// Check if state transition is valid
-	if err := collection.IsValidStateTransition(existingCollection.State, collection.CollectionStateArchived); err != nil {
-		s.logger.Error("⚠️ invalid state transition for archive",
-			zap.String("id", id.String()),
-			zap.String("currentState", existingCollection.State),
-			zap.Error(err))
-		return errors.NewAppError("cannot archive collection in current state", err)
-	}
-
-	// Update collection state to archived
-	newState := collection.CollectionStateArchived
-	updateInput := uc.UpdateCollectionInput{
-		ID:    id,
-		State: &newState,
-	}
-
-	_, err = s.updateUseCase.Execute(ctx, updateInput)
-	if err != nil {
-		s.logger.Error("❌ failed to archive collection",
-			zap.String("id", id.String()),
-			zap.Error(err))
-		return err
-	}
-
-	s.logger.Info("✅ collection archived successfully",
-		zap.String("id", id.String()),
-		zap.String("previousState", existingCollection.State),
-		zap.String("newState", collection.CollectionStateArchived))
-
-	return nil
-}
-
-// Restore marks a deleted or archived collection as active
+// Restore marks a deleted collection as active, archived collections are restored with the ArchiveService
 func (s *softDeleteService) Restore(ctx context.Context, id gocql.UUID) error {
 	// Validate input
 	if id.String() == "" {
@@ -221,6 +171,11 @@ func (s *softDeleteService) Restore(ctx context.Context, id gocql.UUID) error {
 		return err
 	}
 
+	// Archived collections are restored in the cloud as well, which this service does not do
+	if existingCollection.State == collection.CollectionStateArchived {
+		return errors.NewAppError("collection is archived, unarchive it instead", nil)
+	}
+
 	// Check if state transition is valid
 	if err := collection.IsValidStateTransition(existingCollection.State, collection.CollectionStateActive); err != nil {
 		s.logger.Error("⚠️ invalid state transition for restore",
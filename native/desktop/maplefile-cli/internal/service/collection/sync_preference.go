@@ -0,0 +1,91 @@
+// internal/service/collection/sync_preference.go
+package collection
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
+	uc "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collection"
+)
+
+// SyncPreferenceService defines the interface for choosing which collections sync their file
+// content to this device. The preference is local, other devices and the cloud never see it.
+type SyncPreferenceService interface {
+	SetSyncEnabled(ctx context.Context, id gocql.UUID, enabled bool) (*collection.Collection, error)
+}
+
+// syncPreferenceService implements the SyncPreferenceService interface
+type syncPreferenceService struct {
+	logger        *zap.Logger
+	getUseCase    uc.GetCollectionUseCase
+	updateUseCase uc.UpdateCollectionUseCase
+}
+
+// NewSyncPreferenceService creates a new service for changing the sync preference of collections
+func NewSyncPreferenceService(
+	logger *zap.Logger,
+	getUseCase uc.GetCollectionUseCase,
+	updateUseCase uc.UpdateCollectionUseCase,
+) SyncPreferenceService {
+	logger = logger.Named("CollectionSyncPreferenceService")
+	return &syncPreferenceService{
+		logger:        logger,
+		getUseCase:    getUseCase,
+		updateUseCase: updateUseCase,
+	}
+}
+
+// SetSyncEnabled enables or disables syncing the file content of a local collection. Files already
+// onloaded are kept, disabling only stops the sync from fetching more content.
+func (s *syncPreferenceService) SetSyncEnabled(ctx context.Context, id gocql.UUID, enabled bool) (*collection.Collection, error) {
+	//
+	// STEP 1: Validate the input
+	//
+	if id.String() == "" {
+		s.logger.Error("❌ collection ID is required")
+		return nil, errors.NewAppError("collection ID is required", nil)
+	}
+
+	existingCollection, err := s.getUseCase.Execute(ctx, id)
+	if err != nil {
+		s.logger.Error("❌ failed to get collection",
+			zap.String("id", id.String()),
+			zap.Error(err))
+		return nil, err
+	}
+	if existingCollection == nil {
+		s.logger.Error("❌ collection not found", zap.String("id", id.String()))
+		return nil, errors.NewAppError("collection not found; run 'maplefile-cli sync' if it was created on another device", nil)
+	}
+
+	if existingCollection.IsSyncEnabled() == enabled {
+		s.logger.Debug("✅ Collection sync preference is already set",
+			zap.String("id", id.String()),
+			zap.Bool("syncEnabled", enabled))
+		return existingCollection, nil
+	}
+
+	//
+	// STEP 2: Save the preference locally
+	//
+	updatedCollection, err := s.updateUseCase.Execute(ctx, uc.UpdateCollectionInput{
+		ID:          id,
+		SyncEnabled: &enabled,
+	})
+	if err != nil {
+		s.logger.Error("❌ failed to save collection sync preference",
+			zap.String("id", id.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("✅ Collection sync preference changed",
+		zap.String("id", id.String()),
+		zap.Bool("syncEnabled", enabled))
+
+	return updatedCollection, nil
+}
@@ -1,7 +1,18 @@
 // internal/service/collectioncrypto/cryptoerror.go
 package collectioncrypto
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCollectionKeyOutdated means the collection key wrapped for a member was made before the
+// collection key was rotated, so the membership must be fetched again from the cloud.
+var ErrCollectionKeyOutdated = errors.New("your access key is outdated")
+
+// errOwnerKeyNotWrappedForUser means the master key of the owner does not decrypt the collection key.
+// Ownership transfers leave the collection key wrapped under the master key of the former owner.
+var errOwnerKeyNotWrappedForUser = errors.New("collection key is not wrapped under your master key")
 
 // Standardized crypto error types
 type CryptoError struct {
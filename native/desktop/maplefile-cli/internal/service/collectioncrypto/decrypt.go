@@ -3,6 +3,7 @@ package collectioncrypto
 import (
 	"context"
 	"encoding/base64"
+	stderrors "errors"
 	"fmt"
 
 	"go.uber.org/zap"
@@ -30,17 +31,20 @@ type CollectionDecryptionService interface {
 type collectionDecryptionService struct {
 	logger                     *zap.Logger
 	getUserByIsLoggedInUseCase uc_user.GetByIsLoggedInUseCase
+	keyCache                   CollectionKeyCache
 }
 
 // NewCollectionDecryptionService creates a new collection decryption service
 func NewCollectionDecryptionService(
 	logger *zap.Logger,
 	getUserByIsLoggedInUseCase uc_user.GetByIsLoggedInUseCase,
+	keyCache CollectionKeyCache,
 ) CollectionDecryptionService {
 	logger = logger.Named("CollectionDecryptionService")
 	return &collectionDecryptionService{
 		logger:                     logger,
 		getUserByIsLoggedInUseCase: getUserByIsLoggedInUseCase,
+		keyCache:                   keyCache,
 	}
 }
 
@@ -50,6 +54,11 @@ func (s *collectionDecryptionService) ExecuteDecryptCollectionKeyChain(ctx conte
 		zap.String("collectionID", collection.ID.String()),
 		zap.String("collectionOwnerID", collection.OwnerID.String()))
 
+	// Batch operations ask for the same collection key many times, skip the key chain if we can.
+	if collectionKey, ok := s.keyCache.Get(user, password, collection); ok {
+		return collectionKey, nil
+	}
+
 	// STEP 1: Derive keyEncryptionKey from password
 	s.logger.Debug("🧠 Step 1: Deriving key encryption key from password")
 	keyEncryptionKey, err := crypto.DeriveKeyFromPassword(password, user.PasswordSalt)
@@ -60,6 +69,17 @@ func (s *collectionDecryptionService) ExecuteDecryptCollectionKeyChain(ctx conte
 	defer crypto.ClearBytes(keyEncryptionKey)
 	s.logger.Debug("✅ Successfully derived key encryption key")
 
+	collectionKey, err := s.decryptKeyChain(ctx, user, collection, keyEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	s.keyCache.Put(ctx, user, password, collection, collectionKey)
+	return collectionKey, nil
+}
+
+// decryptKeyChain decrypts the collection key with the key encryption key of the user
+func (s *collectionDecryptionService) decryptKeyChain(ctx context.Context, user *dom_user.User, collection *dom_collection.Collection, keyEncryptionKey []byte) ([]byte, error) {
 	// STEP 2: Check if user is the owner or a member
 	isOwner := collection.OwnerID == user.ID
 	s.logger.Debug("🔍 Checking user role",
@@ -67,13 +87,24 @@ func (s *collectionDecryptionService) ExecuteDecryptCollectionKeyChain(ctx conte
 		zap.String("userID", user.ID.String()),
 		zap.String("ownerID", collection.OwnerID.String()))
 
+	var (
+		collectionKey []byte
+		err           error
+	)
 	if isOwner {
 		// SCENARIO A: User is the owner - decrypt with master key
-		return s.decryptAsOwner(ctx, user, collection, keyEncryptionKey)
+		collectionKey, err = s.decryptAsOwner(ctx, user, collection, keyEncryptionKey)
+		if stderrors.Is(err, errOwnerKeyNotWrappedForUser) && hasMembership(collection, user) {
+			// The collection was transferred to the user, who still holds the key wrapped for them as a member
+			s.logger.Debug("🔁 Owner key not wrapped for user, decrypting with membership key",
+				zap.String("collectionID", collection.ID.String()))
+			collectionKey, err = s.decryptAsMember(ctx, user, collection, keyEncryptionKey)
+		}
 	} else {
 		// SCENARIO B: User is a member - decrypt with private key
-		return s.decryptAsMember(ctx, user, collection, keyEncryptionKey)
+		collectionKey, err = s.decryptAsMember(ctx, user, collection, keyEncryptionKey)
 	}
+	return collectionKey, err
 }
 
 // decryptAsOwner handles decryption when the user is the collection owner
@@ -124,7 +155,7 @@ func (s *collectionDecryptionService) decryptAsOwner(ctx context.Context, user *
 	)
 	if err != nil {
 		s.logger.Error("❌ Failed to decrypt collection key", zap.Error(err))
-		return nil, fmt.Errorf("failed to decrypt collection key: %w", err)
+		return nil, fmt.Errorf("%w: failed to decrypt collection key: %v", errOwnerKeyNotWrappedForUser, err)
 	}
 	s.logger.Debug("✅ Successfully decrypted collection key as owner")
 
@@ -216,6 +247,18 @@ func (s *collectionDecryptionService) decryptAsMember(ctx context.Context, user
 	s.logger.Debug("✅ Found user encrypted collection key",
 		zap.Int("encryptedKeySize", len(encryptedKeyBytes)))
 
+	// A key wrapped for us before the collection key was rotated can never decrypt the collection
+	if collection.EncryptedCollectionKey != nil && userMembership.EncryptedCollectionKey.KeyVersion < collection.EncryptedCollectionKey.KeyVersion {
+		s.logger.Warn("⚠️ Member's collection key is older than the collection key",
+			zap.String("collectionID", collection.ID.String()),
+			zap.Int("memberKeyVersion", userMembership.EncryptedCollectionKey.KeyVersion),
+			zap.Int("collectionKeyVersion", collection.EncryptedCollectionKey.KeyVersion))
+		return nil, fmt.Errorf("%w: member key version %d, collection key version %d",
+			ErrCollectionKeyOutdated,
+			userMembership.EncryptedCollectionKey.KeyVersion,
+			collection.EncryptedCollectionKey.KeyVersion)
+	}
+
 	// STEP 2: Decrypt masterKey with keyEncryptionKey to get private key
 	masterKey, err := crypto.DecryptWithSecretBox(
 		user.EncryptedMasterKey.Ciphertext,
@@ -249,6 +292,8 @@ func (s *collectionDecryptionService) decryptAsMember(ctx context.Context, user
 		privateKey,
 	)
 	if err != nil {
+		// The key versions matched above, so this is a corrupted or tampered key rather than an
+		// outdated one, and fetching the membership again won't help.
 		s.logger.Error("❌ Failed to decrypt member's collection key", zap.Error(err))
 		return nil, fmt.Errorf("failed to decrypt member's collection key: %w", err)
 	}
@@ -257,6 +302,16 @@ func (s *collectionDecryptionService) decryptAsMember(ctx context.Context, user
 	return collectionKey, nil
 }
 
+// hasMembership reports whether the collection holds a collection key wrapped for the user as a member
+func hasMembership(collection *dom_collection.Collection, user *dom_user.User) bool {
+	for _, member := range collection.Members {
+		if member != nil && member.RecipientID == user.ID && member.EncryptedCollectionKey != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *collectionDecryptionService) ExecuteDecryptData(ctx context.Context, encryptedData string, fileKey []byte) (string, error) {
 	s.logger.Debug("🔑 Decrypting collection data")
 
@@ -0,0 +1,194 @@
+package collectioncrypto
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	dom_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
+	dom_user "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+)
+
+// testAccount is a user with the keys of its key chain in the clear. The key encryption key is
+// random rather than derived from a password, which is too slow for unit tests.
+type testAccount struct {
+	user             *dom_user.User
+	keyEncryptionKey []byte
+	masterKey        []byte
+}
+
+func newTestAccount(t *testing.T) *testAccount {
+	t.Helper()
+	keyEncryptionKey, err := crypto.GenerateRandomBytes(crypto.Argon2KeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	masterKey, err := crypto.GenerateRandomBytes(crypto.MasterKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, privateKey, _, err := crypto.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptedMasterKey, err := crypto.EncryptWithSecretBox(masterKey, keyEncryptionKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptedPrivateKey, err := crypto.EncryptWithSecretBox(privateKey, masterKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testAccount{
+		user: &dom_user.User{
+			ID:                  gocql.TimeUUID(),
+			PublicKey:           keys.PublicKey{Key: publicKey},
+			EncryptedMasterKey:  keys.EncryptedMasterKey{Ciphertext: encryptedMasterKey.Ciphertext, Nonce: encryptedMasterKey.Nonce},
+			EncryptedPrivateKey: keys.EncryptedPrivateKey{Ciphertext: encryptedPrivateKey.Ciphertext, Nonce: encryptedPrivateKey.Nonce},
+		},
+		keyEncryptionKey: keyEncryptionKey,
+		masterKey:        masterKey,
+	}
+}
+
+func newTestCollectionKey(t *testing.T) []byte {
+	t.Helper()
+	collectionKey, err := crypto.GenerateRandomBytes(crypto.CollectionKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return collectionKey
+}
+
+// newOwnedCollection returns a collection of the owner whose key is wrapped under the owner's master key
+func newOwnedCollection(t *testing.T, owner *testAccount, collectionKey []byte) *dom_collection.Collection {
+	t.Helper()
+	encrypted, err := crypto.EncryptWithSecretBox(collectionKey, owner.masterKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &dom_collection.Collection{
+		ID:      gocql.TimeUUID(),
+		OwnerID: owner.user.ID,
+		EncryptedCollectionKey: &keys.EncryptedCollectionKey{
+			Ciphertext: encrypted.Ciphertext,
+			Nonce:      encrypted.Nonce,
+			KeyVersion: 1,
+		},
+	}
+}
+
+// addMember shares the collection key with the member by sealing it with their public key
+func addMember(t *testing.T, collection *dom_collection.Collection, member *testAccount, collectionKey []byte) {
+	t.Helper()
+	sealed, err := crypto.EncryptWithBoxSeal(collectionKey, member.user.PublicKey.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Members = append(collection.Members, &dom_collection.CollectionMembership{
+		ID:                     gocql.TimeUUID(),
+		CollectionID:           collection.ID,
+		RecipientID:            member.user.ID,
+		PermissionLevel:        dom_collection.CollectionPermissionAdmin,
+		EncryptedCollectionKey: keys.NewEncryptedCollectionKeyFromBoxSeal(sealed),
+	})
+}
+
+func newTestDecryptionService(t *testing.T) *collectionDecryptionService {
+	t.Helper()
+	return NewCollectionDecryptionService(zap.NewNop(), nil, newTestKeyCache(t, time.Minute)).(*collectionDecryptionService)
+}
+
+func TestDecryptKeyChainAfterOwnershipTransfer(t *testing.T) {
+	formerOwner, newOwner := newTestAccount(t), newTestAccount(t)
+	collectionKey, err := crypto.GenerateRandomBytes(crypto.CollectionKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The collection was shared with the new owner, then transferred to them. Its key is still
+	// wrapped under the master key of the former owner.
+	collection := newOwnedCollection(t, formerOwner, collectionKey)
+	addMember(t, collection, newOwner, collectionKey)
+	collection.OwnerID = newOwner.user.ID
+
+	got, err := newTestDecryptionService(t).decryptKeyChain(context.Background(), newOwner.user, collection, newOwner.keyEncryptionKey)
+	if err != nil {
+		t.Fatalf("decryptKeyChain() error = %v", err)
+	}
+	if !bytes.Equal(got, collectionKey) {
+		t.Fatal("decrypted collection key does not match")
+	}
+}
+
+func TestDecryptKeyChainOwnerWithoutMembership(t *testing.T) {
+	formerOwner, newOwner := newTestAccount(t), newTestAccount(t)
+	collectionKey, err := crypto.GenerateRandomBytes(crypto.CollectionKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection := newOwnedCollection(t, formerOwner, collectionKey)
+	collection.OwnerID = newOwner.user.ID
+
+	if _, err := newTestDecryptionService(t).decryptKeyChain(context.Background(), newOwner.user, collection, newOwner.keyEncryptionKey); err == nil {
+		t.Fatal("decryptKeyChain() should fail without a key wrapped for the owner")
+	}
+}
+
+func TestDecryptKeyChainAsOwner(t *testing.T) {
+	owner := newTestAccount(t)
+	collectionKey, err := crypto.GenerateRandomBytes(crypto.CollectionKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection := newOwnedCollection(t, owner, collectionKey)
+
+	got, err := newTestDecryptionService(t).decryptKeyChain(context.Background(), owner.user, collection, owner.keyEncryptionKey)
+	if err != nil {
+		t.Fatalf("decryptKeyChain() error = %v", err)
+	}
+	if !bytes.Equal(got, collectionKey) {
+		t.Fatal("decrypted collection key does not match")
+	}
+
+	// A wrong key encryption key, as from a wrong password, must not fall back to the membership key
+	addMember(t, collection, owner, collectionKey)
+	if _, err := newTestDecryptionService(t).decryptKeyChain(context.Background(), owner.user, collection, newTestAccount(t).keyEncryptionKey); err == nil {
+		t.Fatal("decryptKeyChain() with the wrong key encryption key should fail")
+	}
+}
+
+func TestDecryptKeyChainMemberKeyOutdatedOrCorrupted(t *testing.T) {
+	owner, member := newTestAccount(t), newTestAccount(t)
+	collectionKey := newTestCollectionKey(t)
+	collection := newOwnedCollection(t, owner, collectionKey)
+	addMember(t, collection, member, collectionKey)
+
+	// The collection key was rotated after the membership was stored
+	collection.EncryptedCollectionKey.KeyVersion = 2
+	_, err := newTestDecryptionService(t).decryptKeyChain(context.Background(), member.user, collection, member.keyEncryptionKey)
+	if !errors.Is(err, ErrCollectionKeyOutdated) {
+		t.Fatalf("decryptKeyChain() error = %v, want ErrCollectionKeyOutdated", err)
+	}
+
+	// A member key of the current version which doesn't open is corrupted, not outdated
+	memberKey := collection.Members[0].EncryptedCollectionKey
+	memberKey.KeyVersion = 2
+	memberKey.Ciphertext[len(memberKey.Ciphertext)-1] ^= 0xff
+	_, err = newTestDecryptionService(t).decryptKeyChain(context.Background(), member.user, collection, member.keyEncryptionKey)
+	if err == nil {
+		t.Fatal("decryptKeyChain() with a corrupted member key should fail")
+	}
+	if errors.Is(err, ErrCollectionKeyOutdated) {
+		t.Fatalf("decryptKeyChain() error = %v, a corrupted key must not be reported as outdated", err)
+	}
+}
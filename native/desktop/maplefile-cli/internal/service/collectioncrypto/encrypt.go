@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/gocql/gocql"
 	"go.uber.org/zap"
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
@@ -14,6 +15,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
 	dom_keys "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
 	dom_user "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
+	uc_collectiondto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collectiondto"
 	uc_user "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/user"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
 )
@@ -26,6 +28,9 @@ type CollectionEncryptionService interface {
 	EncryptCollectionKeyForSharing(ctx context.Context, user *dom_user.User, collection *collection.Collection, recipientPublicKey []byte, userPassword string) (*keys.EncryptedCollectionKey, error)
 	EncryptCollectionKeyForMultipleRecipients(ctx context.Context, user *dom_user.User, collection *collection.Collection, recipients []SharingRecipient, userPassword string) (map[string]*keys.EncryptedCollectionKey, error)
 	ValidateRecipientPublicKey(publicKey []byte) error
+	// EncryptCollectionKeyForMembers wraps a collection key for every member of the collection with
+	// the public keys fetched in one call, keyed by recipient ID. The owner isn't a recipient.
+	EncryptCollectionKeyForMembers(ctx context.Context, collectionID gocql.UUID, collectionKey []byte) (map[gocql.UUID]*keys.EncryptedCollectionKey, error)
 	RotateCollectionKey(
 		ctx context.Context,
 		user *dom_user.User,
@@ -55,6 +60,7 @@ type collectionEncryptionService struct {
 	logger                      *zap.Logger
 	getUserByIsLoggedInUseCase  uc_user.GetByIsLoggedInUseCase
 	collectionDecryptionService CollectionDecryptionService
+	getMemberPublicKeysUseCase  uc_collectiondto.GetCollectionMemberPublicKeysFromCloudUseCase
 }
 
 // NewCollectionEncryptionService creates a new enhanced collection encryption service
@@ -62,12 +68,14 @@ func NewCollectionEncryptionService(
 	logger *zap.Logger,
 	getUserByIsLoggedInUseCase uc_user.GetByIsLoggedInUseCase,
 	collectionDecryptionService CollectionDecryptionService,
+	getMemberPublicKeysUseCase uc_collectiondto.GetCollectionMemberPublicKeysFromCloudUseCase,
 ) CollectionEncryptionService {
 	logger = logger.Named("CollectionEncryptionService")
 	return &collectionEncryptionService{
 		logger:                      logger,
 		getUserByIsLoggedInUseCase:  getUserByIsLoggedInUseCase,
 		collectionDecryptionService: collectionDecryptionService,
+		getMemberPublicKeysUseCase:  getMemberPublicKeysUseCase,
 	}
 }
 
@@ -339,3 +347,62 @@ func (s *collectionEncryptionService) decryptMasterKey(user *dom_user.User, keyE
 		keyEncryptionKey,
 	)
 }
+
+// EncryptCollectionKeyForMembers wraps the collection key for all members at once, as needed after the
+// key was rotated. Members whose key can't be used are skipped and reported, like batch sharing does.
+func (s *collectionEncryptionService) EncryptCollectionKeyForMembers(
+	ctx context.Context,
+	collectionID gocql.UUID,
+	collectionKey []byte,
+) (map[gocql.UUID]*keys.EncryptedCollectionKey, error) {
+	// STEP 1: Fetch the public keys of every member in one call
+	members, err := s.getMemberPublicKeysUseCase.Execute(ctx, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member public keys: %w", err)
+	}
+
+	s.logger.Info("🔐 Wrapping collection key for members",
+		zap.String("collectionID", collectionID.String()),
+		zap.Int("memberCount", len(members)))
+
+	// STEP 2: Box seal the collection key for each member
+	results := make(map[gocql.UUID]*keys.EncryptedCollectionKey, len(members))
+	failures := make([]string, 0)
+	for _, member := range members {
+		if err := s.ValidateRecipientPublicKey(member.PublicKey); err != nil {
+			failures = append(failures, fmt.Sprintf("invalid public key for %s: %v", member.RecipientEmail, err))
+			continue
+		}
+
+		encryptedForMember, err := crypto.EncryptWithBoxSeal(collectionKey, member.PublicKey)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("encryption failed for %s: %v", member.RecipientEmail, err))
+			continue
+		}
+
+		encryptedCollectionKey := keys.NewEncryptedCollectionKeyFromBoxSeal(encryptedForMember)
+		if err := s.validateEncryptedKeyForSharing(encryptedCollectionKey, member.PublicKey); err != nil {
+			failures = append(failures, fmt.Sprintf("validation failed for %s: %v", member.RecipientEmail, err))
+			continue
+		}
+
+		results[member.RecipientID] = encryptedCollectionKey
+	}
+
+	// STEP 3: Report the members which could not be wrapped for
+	if len(failures) > 0 {
+		s.logger.Warn("⚠️ Some members failed encryption",
+			zap.String("collectionID", collectionID.String()),
+			zap.Strings("errors", failures))
+	}
+	if len(results) == 0 && len(members) > 0 {
+		return nil, fmt.Errorf("failed to encrypt collection key for any members. Errors: %v", failures)
+	}
+
+	s.logger.Info("✅ Wrapped collection key for members",
+		zap.String("collectionID", collectionID.String()),
+		zap.Int("successfulMembers", len(results)),
+		zap.Int("failedMembers", len(failures)))
+
+	return results, nil
+}
@@ -0,0 +1,212 @@
+// internal/service/collectioncrypto/keycache.go
+package collectioncrypto
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	dom_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
+	dom_user "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+)
+
+// CollectionKeyCache keeps decrypted collection keys in memory so batch operations on one
+// collection do not decrypt the key chain for every file. Keys are never written to disk, are
+// zeroed when they expire or are removed, and are handed out as copies so callers may clear them.
+// A key is only handed out to the user it was decrypted for, with the same password.
+type CollectionKeyCache interface {
+	// Get returns a copy of the cached key of the collection, if it is still valid and was cached
+	// for the user and password.
+	Get(user *dom_user.User, password string, collection *dom_collection.Collection) ([]byte, bool)
+	// Put caches a copy of the key for the user and password until it expires or the context is done.
+	Put(ctx context.Context, user *dom_user.User, password string, collection *dom_collection.Collection, collectionKey []byte)
+	// Remove zeroes and drops the cached keys of a collection.
+	Remove(collectionID gocql.UUID)
+	// Clear zeroes and drops every cached key.
+	Clear()
+}
+
+// collectionKeyCacheEntryID identifies the cached key of a collection for a user
+type collectionKeyCacheEntryID struct {
+	userID       gocql.UUID
+	collectionID gocql.UUID
+}
+
+// cachedCollectionKey is a decrypted collection key together with what it was decrypted from
+type cachedCollectionKey struct {
+	key                 []byte
+	fingerprint         [sha256.Size]byte
+	passwordFingerprint []byte
+	expiresAt           time.Time
+	timer               *time.Timer
+	stopOnDone          func() bool
+}
+
+// collectionKeyCache implements CollectionKeyCache
+type collectionKeyCache struct {
+	logger        *zap.Logger
+	configService config.ConfigService
+	// secret keys the password fingerprints, so they can't be checked against passwords without it
+	secret  []byte
+	mu      sync.Mutex
+	entries map[collectionKeyCacheEntryID]*cachedCollectionKey
+}
+
+// NewCollectionKeyCache creates a new in-memory cache of decrypted collection keys
+func NewCollectionKeyCache(logger *zap.Logger, configService config.ConfigService) (CollectionKeyCache, error) {
+	logger = logger.Named("CollectionKeyCache")
+	secret, err := crypto.GenerateRandomBytes(sha256.Size)
+	if err != nil {
+		return nil, errors.NewAppError("failed to generate collection key cache secret", err)
+	}
+	return &collectionKeyCache{
+		logger:        logger,
+		configService: configService,
+		secret:        secret,
+		entries:       make(map[collectionKeyCacheEntryID]*cachedCollectionKey),
+	}, nil
+}
+
+func (c *collectionKeyCache) Get(user *dom_user.User, password string, collection *dom_collection.Collection) ([]byte, bool) {
+	fingerprint, ok := collectionKeyFingerprint(collection)
+	if !ok || user == nil {
+		return nil, false
+	}
+	id := collectionKeyCacheEntryID{userID: user.ID, collectionID: collection.ID}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, found := c.entries[id]
+	if !found {
+		return nil, false
+	}
+
+	// A key that expired or was rotated since it was cached must be decrypted again.
+	if time.Now().After(entry.expiresAt) || entry.fingerprint != fingerprint {
+		c.removeLocked(id, entry)
+		return nil, false
+	}
+
+	// A wrong password must fail like it would without the cache, the entry stays for the right one.
+	if !hmac.Equal(entry.passwordFingerprint, c.passwordFingerprint(user, password)) {
+		c.logger.Debug("🔒 Cached collection key was decrypted with another password",
+			zap.String("collectionID", collection.ID.String()))
+		return nil, false
+	}
+
+	c.logger.Debug("🔑 Using cached collection key", zap.String("collectionID", collection.ID.String()))
+	return bytes.Clone(entry.key), true
+}
+
+func (c *collectionKeyCache) Put(ctx context.Context, user *dom_user.User, password string, collection *dom_collection.Collection, collectionKey []byte) {
+	fingerprint, ok := collectionKeyFingerprint(collection)
+	if !ok || user == nil || len(collectionKey) == 0 || ctx.Err() != nil {
+		return
+	}
+	ttl := c.ttl(ctx)
+	id := collectionKeyCacheEntryID{userID: user.ID, collectionID: collection.ID}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if existing, found := c.entries[id]; found {
+		c.removeLocked(id, existing)
+	}
+
+	entry := &cachedCollectionKey{
+		key:                 bytes.Clone(collectionKey),
+		fingerprint:         fingerprint,
+		passwordFingerprint: c.passwordFingerprint(user, password),
+		expiresAt:           time.Now().Add(ttl),
+	}
+	// Zero the key as soon as it expires instead of waiting for the next lookup.
+	entry.timer = time.AfterFunc(ttl, func() { c.evict(id, entry) })
+	// Drop the key once the operation that decrypted it is cancelled or finished.
+	entry.stopOnDone = context.AfterFunc(ctx, func() { c.evict(id, entry) })
+	c.entries[id] = entry
+}
+
+func (c *collectionKeyCache) Remove(collectionID gocql.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, entry := range c.entries {
+		if id.collectionID == collectionID {
+			c.removeLocked(id, entry)
+		}
+	}
+}
+
+func (c *collectionKeyCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, entry := range c.entries {
+		c.removeLocked(id, entry)
+	}
+	c.logger.Debug("🧹 Cleared collection key cache")
+}
+
+// ttl returns how long keys are cached, the default is used if the configuration can't be read
+func (c *collectionKeyCache) ttl(ctx context.Context) time.Duration {
+	ttl, err := c.configService.GetCollectionKeyCacheTTL(ctx)
+	if err != nil || ttl <= 0 {
+		c.logger.Warn("⚠️ Failed to get collection key cache TTL, using default",
+			zap.Duration("default", config.DefaultCollectionKeyCacheTTL),
+			zap.Error(err))
+		return config.DefaultCollectionKeyCacheTTL
+	}
+	return ttl
+}
+
+// passwordFingerprint identifies the password and salt of the user a key was decrypted with, without
+// keeping the password itself or anything it can be checked against without the cache secret.
+func (c *collectionKeyCache) passwordFingerprint(user *dom_user.User, password string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(user.ID.Bytes())
+	mac.Write(user.PasswordSalt)
+	mac.Write([]byte(password))
+	return mac.Sum(nil)
+}
+
+// evict removes the entry only if it is still the one cached for the collection
+func (c *collectionKeyCache) evict(id collectionKeyCacheEntryID, entry *cachedCollectionKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if current, found := c.entries[id]; found && current == entry {
+		c.removeLocked(id, entry)
+	}
+}
+
+// removeLocked zeroes and drops an entry, the caller must hold the lock
+func (c *collectionKeyCache) removeLocked(id collectionKeyCacheEntryID, entry *cachedCollectionKey) {
+	entry.timer.Stop()
+	entry.stopOnDone()
+	crypto.ClearBytes(entry.key)
+	delete(c.entries, id)
+}
+
+// collectionKeyFingerprint identifies the encrypted collection key a cached key was decrypted
+// from, so a rotated key is never served from the cache.
+func collectionKeyFingerprint(collection *dom_collection.Collection) ([sha256.Size]byte, bool) {
+	if collection == nil || collection.EncryptedCollectionKey == nil || len(collection.EncryptedCollectionKey.Ciphertext) == 0 {
+		return [sha256.Size]byte{}, false
+	}
+	h := sha256.New()
+	h.Write(collection.EncryptedCollectionKey.Ciphertext)
+	h.Write(collection.EncryptedCollectionKey.Nonce)
+	var fingerprint [sha256.Size]byte
+	copy(fingerprint[:], h.Sum(nil))
+	return fingerprint, true
+}
@@ -0,0 +1,171 @@
+package collectioncrypto
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+)
+
+// keyCacheConfigService returns a fixed collection key cache TTL
+type keyCacheConfigService struct {
+	config.ConfigService
+	ttl time.Duration
+}
+
+func (s *keyCacheConfigService) GetCollectionKeyCacheTTL(ctx context.Context) (time.Duration, error) {
+	return s.ttl, nil
+}
+
+func newTestKeyCache(t *testing.T, ttl time.Duration) *collectionKeyCache {
+	t.Helper()
+	cache, err := NewCollectionKeyCache(zap.NewNop(), &keyCacheConfigService{ttl: ttl})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cache.(*collectionKeyCache)
+}
+
+func TestCollectionKeyCacheGet(t *testing.T) {
+	owner, member := newTestAccount(t), newTestAccount(t)
+	collectionKey := newTestCollectionKey(t)
+	collection := newOwnedCollection(t, owner, collectionKey)
+
+	cache := newTestKeyCache(t, time.Minute)
+	cache.Put(context.Background(), owner.user, "password", collection, collectionKey)
+
+	t.Run("Same user and password", func(t *testing.T) {
+		got, ok := cache.Get(owner.user, "password", collection)
+		if !ok || !bytes.Equal(got, collectionKey) {
+			t.Fatal("Get() missed the cached key")
+		}
+		// Callers may clear the key they were given
+		got[0] ^= 0xff
+		if again, _ := cache.Get(owner.user, "password", collection); !bytes.Equal(again, collectionKey) {
+			t.Fatal("Get() handed out the cached key instead of a copy")
+		}
+	})
+
+	t.Run("Wrong password", func(t *testing.T) {
+		if _, ok := cache.Get(owner.user, "wrong password", collection); ok {
+			t.Fatal("Get() returned the key for a wrong password")
+		}
+		if _, ok := cache.Get(owner.user, "password", collection); !ok {
+			t.Fatal("a wrong password evicted the key")
+		}
+	})
+
+	t.Run("Another user", func(t *testing.T) {
+		if _, ok := cache.Get(member.user, "password", collection); ok {
+			t.Fatal("Get() returned the key of another user")
+		}
+	})
+
+	t.Run("Rotated key", func(t *testing.T) {
+		rotated := *collection
+		rotated.EncryptedCollectionKey = newOwnedCollection(t, owner, newTestCollectionKey(t)).EncryptedCollectionKey
+		if _, ok := cache.Get(owner.user, "password", &rotated); ok {
+			t.Fatal("Get() returned the key cached before the rotation")
+		}
+	})
+}
+
+func TestCollectionKeyCacheExpiry(t *testing.T) {
+	owner := newTestAccount(t)
+	collectionKey := newTestCollectionKey(t)
+	collection := newOwnedCollection(t, owner, collectionKey)
+
+	cache := newTestKeyCache(t, 20*time.Millisecond)
+	cache.Put(context.Background(), owner.user, "password", collection, collectionKey)
+	cache.mu.Lock()
+	cached := cache.entries[collectionKeyCacheEntryID{userID: owner.user.ID, collectionID: collection.ID}].key
+	cache.mu.Unlock()
+
+	time.Sleep(100 * time.Millisecond)
+
+	if _, ok := cache.Get(owner.user, "password", collection); ok {
+		t.Fatal("Get() returned an expired key")
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if len(cache.entries) != 0 {
+		t.Errorf("entries = %d, want the expired key dropped", len(cache.entries))
+	}
+	if !bytes.Equal(cached, make([]byte, len(cached))) {
+		t.Error("expired key was not zeroed")
+	}
+}
+
+func TestCollectionKeyCacheClear(t *testing.T) {
+	owner := newTestAccount(t)
+	first, second := newTestCollectionKey(t), newTestCollectionKey(t)
+	firstCollection, secondCollection := newOwnedCollection(t, owner, first), newOwnedCollection(t, owner, second)
+
+	cache := newTestKeyCache(t, time.Minute)
+	cache.Put(context.Background(), owner.user, "password", firstCollection, first)
+	cache.Put(context.Background(), owner.user, "password", secondCollection, second)
+
+	cache.Clear()
+
+	if _, ok := cache.Get(owner.user, "password", firstCollection); ok {
+		t.Error("Get() returned a key after Clear()")
+	}
+	if _, ok := cache.Get(owner.user, "password", secondCollection); ok {
+		t.Error("Get() returned a key after Clear()")
+	}
+}
+
+func TestCollectionKeyCacheRemove(t *testing.T) {
+	owner, member := newTestAccount(t), newTestAccount(t)
+	collectionKey := newTestCollectionKey(t)
+	collection := newOwnedCollection(t, owner, collectionKey)
+
+	cache := newTestKeyCache(t, time.Minute)
+	cache.Put(context.Background(), owner.user, "password", collection, collectionKey)
+	cache.Put(context.Background(), member.user, "password", collection, collectionKey)
+
+	cache.Remove(collection.ID)
+
+	if _, ok := cache.Get(owner.user, "password", collection); ok {
+		t.Error("Get() returned the key of the owner after Remove()")
+	}
+	if _, ok := cache.Get(member.user, "password", collection); ok {
+		t.Error("Get() returned the key of the member after Remove()")
+	}
+}
+
+func TestCollectionKeyCacheContextCancellation(t *testing.T) {
+	owner := newTestAccount(t)
+	collectionKey := newTestCollectionKey(t)
+	collection := newOwnedCollection(t, owner, collectionKey)
+
+	cache := newTestKeyCache(t, time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	cache.Put(ctx, owner.user, "password", collection, collectionKey)
+	if _, ok := cache.Get(owner.user, "password", collection); !ok {
+		t.Fatal("Get() missed the cached key")
+	}
+
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, ok := cache.Get(owner.user, "password", collection); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Get() still returns the key after the context was cancelled")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// A key decrypted for an operation which is already over is not cached
+	cache.Put(ctx, owner.user, "password", collection, collectionKey)
+	if _, ok := cache.Get(owner.user, "password", collection); ok {
+		t.Error("Put() cached a key with a cancelled context")
+	}
+}
@@ -21,6 +21,20 @@ func (s *collectionDecryptionService) ExecuteDecryptMultipleCollectionKeys(
 		zap.String("userID", user.ID.String()),
 		zap.Int("collectionCount", len(collections)))
 
+	// Serve what we can from the cache and only decrypt the key chain for the rest
+	results := make(map[string][]byte)
+	uncached := make([]*dom_collection.Collection, 0, len(collections))
+	for _, collection := range collections {
+		if collectionKey, ok := s.keyCache.Get(user, password, collection); ok {
+			results[collection.ID.String()] = collectionKey
+			continue
+		}
+		uncached = append(uncached, collection)
+	}
+	if len(uncached) == 0 {
+		return results, nil
+	}
+
 	// Decrypt master key once for efficiency
 	keyEncryptionKey, err := crypto.DeriveKeyFromPassword(password, user.PasswordSalt)
 	if err != nil {
@@ -38,9 +52,8 @@ func (s *collectionDecryptionService) ExecuteDecryptMultipleCollectionKeys(
 	}
 	defer crypto.ClearBytes(masterKey)
 
-	// Decrypt all collection keys
-	results := make(map[string][]byte)
-	for _, collection := range collections {
+	// Decrypt all remaining collection keys
+	for _, collection := range uncached {
 		if collection.EncryptedCollectionKey == nil {
 			s.logger.Warn("⚠️ Skipping collection with no encrypted key",
 				zap.String("collectionID", collection.ID.String()))
@@ -52,6 +65,10 @@ func (s *collectionDecryptionService) ExecuteDecryptMultipleCollectionKeys(
 			collection.EncryptedCollectionKey.Nonce,
 			masterKey,
 		)
+		if err != nil && hasMembership(collection, user) {
+			// Shared with the user, or transferred to them with the key still wrapped for the former owner
+			collectionKey, err = s.decryptAsMember(ctx, user, collection, keyEncryptionKey)
+		}
 		if err != nil {
 			s.logger.Warn("⚠️ Failed to decrypt collection key",
 				zap.String("collectionID", collection.ID.String()),
@@ -59,6 +76,7 @@ func (s *collectionDecryptionService) ExecuteDecryptMultipleCollectionKeys(
 			continue
 		}
 
+		s.keyCache.Put(ctx, user, password, collection, collectionKey)
 		results[collection.ID.String()] = collectionKey
 	}
 
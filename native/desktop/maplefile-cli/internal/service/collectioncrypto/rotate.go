@@ -28,7 +28,7 @@ func (s *collectionEncryptionService) RotateCollectionKey(
 	// 2. Generate new collection key
 	// 3. Re-encrypt all collection data with new key
 	// 4. Update historical keys
-	// 5. Re-encrypt for all members
+	// 5. Re-encrypt for all members with EncryptCollectionKeyForMembers
 
 	// This is a complex operation that would need careful implementation
 	return nil, fmt.Errorf("key rotation not yet implemented")
@@ -0,0 +1,146 @@
+// internal/service/collectionsharing/rewrap.go
+package collectionsharing
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collectiondto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/keytrust"
+	uc_collectiondto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collectiondto"
+)
+
+// RewrapMemberKeyOutput represents the result of re-wrapping the collection key of a member
+type RewrapMemberKeyOutput struct {
+	CollectionID    gocql.UUID `json:"collection_id"`
+	RecipientID     gocql.UUID `json:"recipient_id"`
+	RecipientEmail  string     `json:"recipient_email"`
+	PermissionLevel string     `json:"permission_level"`
+	ExpiresAt       time.Time  `json:"expires_at,omitzero"`
+	// HadKey is false when the membership had no encrypted collection key before the repair.
+	HadKey bool `json:"had_key"`
+	// RecipientKeyTrust compares the public key the collection key was encrypted with to the one
+	// trusted for the recipient.
+	RecipientKeyTrust *keytrust.TrustCheckResult `json:"recipient_key_trust,omitempty"`
+}
+
+// RewrapMemberKeyService repairs the collection key of a member whose wrapped key is stale or missing
+type RewrapMemberKeyService interface {
+	Execute(ctx context.Context, collectionID gocql.UUID, recipientEmail string, userPassword string) (*RewrapMemberKeyOutput, error)
+}
+
+// rewrapMemberKeyService implements the RewrapMemberKeyService interface
+type rewrapMemberKeyService struct {
+	logger                        *zap.Logger
+	getCollectionFromCloudUseCase uc_collectiondto.GetCollectionFromCloudUseCase
+	sharingService                CollectionSharingService
+}
+
+// NewRewrapMemberKeyService creates a new service for re-wrapping the collection key of a member
+func NewRewrapMemberKeyService(
+	logger *zap.Logger,
+	getCollectionFromCloudUseCase uc_collectiondto.GetCollectionFromCloudUseCase,
+	sharingService CollectionSharingService,
+) RewrapMemberKeyService {
+	logger = logger.Named("RewrapMemberKeyService")
+	return &rewrapMemberKeyService{
+		logger:                        logger,
+		getCollectionFromCloudUseCase: getCollectionFromCloudUseCase,
+		sharingService:                sharingService,
+	}
+}
+
+// Execute decrypts the collection key locally and encrypts it again under the current public key of
+// an existing member. The cloud replaces the key of a recipient who is already a member, so the
+// membership keeps its ID, permission level and expiry instead of being removed and added again.
+func (s *rewrapMemberKeyService) Execute(ctx context.Context, collectionID gocql.UUID, recipientEmail string, userPassword string) (*RewrapMemberKeyOutput, error) {
+	//
+	// STEP 1: Validate inputs
+	//
+	if collectionID.String() == "" {
+		s.logger.Error("❌ Collection ID is required")
+		return nil, errors.NewAppError("collection ID is required", nil)
+	}
+	if recipientEmail == "" {
+		s.logger.Error("❌ Recipient email is required")
+		return nil, errors.NewAppError("recipient email is required", nil)
+	}
+	if userPassword == "" {
+		s.logger.Error("❌ User password is required for E2EE operations")
+		return nil, errors.NewAppError("user password is required for E2EE operations", nil)
+	}
+
+	//
+	// STEP 2: Find the current membership of the recipient in the cloud
+	//
+	coll, err := s.getCollectionFromCloudUseCase.Execute(ctx, collectionID)
+	if err != nil {
+		s.logger.Error("❌ Failed to get collection from cloud",
+			zap.String("collectionID", collectionID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+	if coll == nil {
+		return nil, errors.NewAppError("collection not found", nil)
+	}
+
+	var member *collectiondto.CollectionMembershipDTO
+	for _, m := range coll.Members {
+		if m != nil && strings.EqualFold(m.RecipientEmail, recipientEmail) {
+			member = m
+			break
+		}
+	}
+	if member == nil {
+		return nil, errors.NewAppError("recipient is not a member of this collection, share the collection with them instead", nil)
+	}
+	if member.RecipientID == coll.OwnerID {
+		return nil, errors.NewAppError("the owner's collection key is not wrapped for sharing and cannot be re-wrapped", nil)
+	}
+	if !member.ExpiresAt.IsZero() && !time.Now().Before(member.ExpiresAt) {
+		return nil, errors.NewAppError("the access of this member has expired, share the collection with them again instead", nil)
+	}
+
+	hadKey := member.EncryptedCollectionKey != nil && len(member.EncryptedCollectionKey.Ciphertext) > 0
+	s.logger.Info("🔑 Re-wrapping collection key for member",
+		zap.String("collectionID", collectionID.String()),
+		zap.String("recipientID", member.RecipientID.String()),
+		zap.String("permissionLevel", member.PermissionLevel),
+		zap.Bool("hadKey", hadKey))
+
+	//
+	// STEP 3: Share again with the same access, which stores the newly wrapped key
+	//
+	shareOutput, err := s.sharingService.Execute(ctx, &ShareCollectionInput{
+		CollectionID:    collectionID,
+		RecipientEmail:  member.RecipientEmail,
+		PermissionLevel: member.PermissionLevel,
+		ExpiresAt:       member.ExpiresAt,
+	}, userPassword)
+	if err != nil {
+		s.logger.Error("❌ Failed to re-wrap collection key",
+			zap.String("collectionID", collectionID.String()),
+			zap.String("recipientID", member.RecipientID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("✅ Successfully re-wrapped collection key for member",
+		zap.String("collectionID", collectionID.String()),
+		zap.String("recipientID", member.RecipientID.String()))
+
+	return &RewrapMemberKeyOutput{
+		CollectionID:      collectionID,
+		RecipientID:       member.RecipientID,
+		RecipientEmail:    member.RecipientEmail,
+		PermissionLevel:   member.PermissionLevel,
+		ExpiresAt:         member.ExpiresAt,
+		HadKey:            hadKey,
+		RecipientKeyTrust: shareOutput.RecipientKeyTrust,
+	}, nil
+}
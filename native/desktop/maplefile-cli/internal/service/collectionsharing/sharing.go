@@ -3,8 +3,8 @@ package collectionsharing
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -15,10 +15,12 @@ import (
 	dom_publiclookupdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/publiclookupdto"
 	dom_user "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
 	svc_collectioncrypto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectioncrypto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/keytrust"
 	uc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collection"
 	uc "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collectionsharingdto"
 	uc_publiclookupdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/publiclookupdto"
 	uc_user "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/user"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
 )
 
 // ShareCollectionInput represents input for sharing a collection at the service level
@@ -27,6 +29,8 @@ type ShareCollectionInput struct {
 	RecipientEmail       string     `json:"recipient_email"`
 	PermissionLevel      string     `json:"permission_level"`
 	ShareWithDescendants bool       `json:"share_with_descendants"`
+	// ExpiresAt optionally ends the access of the recipient at the given time
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
 }
 
 // ShareCollectionOutput represents the output from sharing a collection
@@ -34,6 +38,9 @@ type ShareCollectionOutput struct {
 	Success            bool   `json:"success"`
 	Message            string `json:"message"`
 	MembershipsCreated int    `json:"memberships_created"`
+	// RecipientKeyTrust compares the public key the collection key was encrypted with to the one
+	// trusted for the recipient.
+	RecipientKeyTrust *keytrust.TrustCheckResult `json:"recipient_key_trust,omitempty"`
 }
 
 // CollectionSharingService defines the interface for collection sharing operations
@@ -62,10 +69,11 @@ type BatchShareCollectionOutput struct {
 }
 
 type IndividualSharingResult struct {
-	RecipientEmail     string `json:"recipient_email"`
-	Success            bool   `json:"success"`
-	MembershipsCreated int    `json:"memberships_created"`
-	Error              string `json:"error,omitempty"`
+	RecipientEmail     string                     `json:"recipient_email"`
+	Success            bool                       `json:"success"`
+	MembershipsCreated int                        `json:"memberships_created"`
+	Error              string                     `json:"error,omitempty"`
+	RecipientKeyTrust  *keytrust.TrustCheckResult `json:"recipient_key_trust,omitempty"`
 }
 
 // collectionSharingService implements the enhanced CollectionSharingService interface
@@ -76,6 +84,7 @@ type collectionSharingService struct {
 	getUserByIsLoggedInUseCase      uc_user.GetByIsLoggedInUseCase
 	shareCollectionUseCase          uc.ShareCollectionUseCase
 	collectionEncryptionService     svc_collectioncrypto.CollectionEncryptionService
+	trustedKeyService               keytrust.TrustedKeyService
 }
 
 // NewCollectionSharingService creates a new enhanced collection sharing service
@@ -86,6 +95,7 @@ func NewCollectionSharingService(
 	getUserByIsLoggedInUseCase uc_user.GetByIsLoggedInUseCase,
 	shareCollectionUseCase uc.ShareCollectionUseCase,
 	collectionEncryptionService svc_collectioncrypto.CollectionEncryptionService,
+	trustedKeyService keytrust.TrustedKeyService,
 ) CollectionSharingService {
 	logger = logger.Named("CollectionSharingService")
 	return &collectionSharingService{
@@ -95,6 +105,7 @@ func NewCollectionSharingService(
 		getUserByIsLoggedInUseCase:      getUserByIsLoggedInUseCase,
 		shareCollectionUseCase:          shareCollectionUseCase,
 		collectionEncryptionService:     collectionEncryptionService,
+		trustedKeyService:               trustedKeyService,
 	}
 }
 
@@ -139,8 +150,14 @@ func (s *collectionSharingService) Execute(ctx context.Context, input *ShareColl
 	}
 
 	//
-	// STEP 3: Get public key of the other user
+	// STEP 3: Get public key of the other user and compare it with the one trusted for them
 	//
+	keyTrust, err := s.trustedKeyService.Check(ctx, publicLookupResponse)
+	if err != nil {
+		s.logger.Error("❌ Failed to check recipient public key trust", zap.Error(err))
+		return nil, errors.NewAppError("failed to check recipient public key trust", err)
+	}
+
 	publicKeyBytes, err := s.decodePublicKey(publicLookupResponse.PublicKeyInBase64)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode recipient public key: %v", err)
@@ -173,6 +190,7 @@ func (s *collectionSharingService) Execute(ctx context.Context, input *ShareColl
 		PermissionLevel:        input.PermissionLevel,
 		EncryptedCollectionKey: encryptedCollectionKey,
 		ShareWithDescendants:   input.ShareWithDescendants,
+		ExpiresAt:              input.ExpiresAt,
 	}
 
 	response, err := s.shareCollectionUseCase.Execute(ctx, useCaseInput, userPassword)
@@ -190,6 +208,7 @@ func (s *collectionSharingService) Execute(ctx context.Context, input *ShareColl
 		Success:            response.Success,
 		Message:            response.Message,
 		MembershipsCreated: response.MembershipsCreated,
+		RecipientKeyTrust:  keyTrust,
 	}, nil
 }
 
@@ -218,6 +237,7 @@ func (s *collectionSharingService) ExecuteBatchSharing(ctx context.Context, inpu
 	// STEP 3: Lookup all recipients and build crypto service input
 	cryptoRecipients := make([]svc_collectioncrypto.SharingRecipient, 0, len(input.Recipients))
 	recipientMap := make(map[string]RecipientInfo)
+	keyTrusts := make(map[string]*keytrust.TrustCheckResult)
 
 	for _, recipient := range input.Recipients {
 		// Validate permission level
@@ -240,6 +260,15 @@ func (s *collectionSharingService) ExecuteBatchSharing(ctx context.Context, inpu
 			continue
 		}
 
+		// Compare the public key with the one trusted for the recipient
+		keyTrust, err := s.trustedKeyService.Check(ctx, publicLookupResponse)
+		if err != nil {
+			s.logger.Warn("⚠️ Skipping recipient due to key trust check failure",
+				zap.String("email", recipient.Email),
+				zap.Error(err))
+			continue
+		}
+
 		// Decode public key
 		publicKeyBytes, err := s.decodePublicKey(publicLookupResponse.PublicKeyInBase64)
 		if err != nil {
@@ -255,6 +284,7 @@ func (s *collectionSharingService) ExecuteBatchSharing(ctx context.Context, inpu
 			UserID:    publicLookupResponse.UserID.String(),
 		})
 		recipientMap[recipient.Email] = recipient
+		keyTrusts[recipient.Email] = keyTrust
 	}
 
 	// STEP 4: ✅ MAJOR EFFICIENCY GAIN: Batch encrypt using extended crypto service
@@ -295,9 +325,10 @@ func (s *collectionSharingService) ExecuteBatchSharing(ctx context.Context, inpu
 		response, err := s.shareCollectionUseCase.Execute(ctx, useCaseInput, userPassword)
 		if err != nil {
 			output.Results = append(output.Results, IndividualSharingResult{
-				RecipientEmail: email,
-				Success:        false,
-				Error:          err.Error(),
+				RecipientEmail:    email,
+				Success:           false,
+				Error:             err.Error(),
+				RecipientKeyTrust: keyTrusts[email],
 			})
 			continue
 		}
@@ -306,6 +337,7 @@ func (s *collectionSharingService) ExecuteBatchSharing(ctx context.Context, inpu
 			RecipientEmail:     email,
 			Success:            response.Success,
 			MembershipsCreated: response.MembershipsCreated,
+			RecipientKeyTrust:  keyTrusts[email],
 		})
 		output.TotalMembershipsCreated += response.MembershipsCreated
 	}
@@ -374,23 +406,9 @@ func (s *collectionSharingService) decodePublicKey(publicKeyBase64 string) ([]by
 		return nil, fmt.Errorf("public key cannot be empty")
 	}
 
-	// Try most common encodings
-	encodings := []struct {
-		name     string
-		encoding *base64.Encoding
-	}{
-		{"RawURL", base64.RawURLEncoding},
-		{"Standard", base64.StdEncoding},
-		{"URL", base64.URLEncoding},
-	}
-
-	for _, enc := range encodings {
-		if publicKeyBytes, err := enc.encoding.DecodeString(publicKeyBase64); err == nil {
-			s.logger.Debug("✅ Successfully decoded public key",
-				zap.String("encoding", enc.name))
-			return publicKeyBytes, nil
-		}
+	publicKeyBytes, err := crypto.DecodeBase64Flexible(publicKeyBase64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode public key: %w", err)
 	}
-
-	return nil, fmt.Errorf("failed to decode public key with any base64 encoding")
+	return publicKeyBytes, nil
 }
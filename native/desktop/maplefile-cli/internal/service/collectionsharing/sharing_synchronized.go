@@ -15,6 +15,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collectionsharingdto"
 	dom_publiclookupdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/publiclookupdto"
 	svc_collectioncrypto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectioncrypto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/keytrust"
 	uc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collection"
 	uc "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collectionsharingdto"
 	uc_publiclookupdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/publiclookupdto"
@@ -42,6 +43,9 @@ type synchronizedCollectionSharingService struct {
 
 	// Use collection encryption service
 	collectionEncryptionService svc_collectioncrypto.CollectionEncryptionService
+
+	// Detects recipient public keys which changed since they were first trusted
+	trustedKeyService keytrust.TrustedKeyService
 }
 
 // NewSynchronizedCollectionSharingService creates a new synchronized collection sharing service
@@ -54,6 +58,7 @@ func NewSynchronizedCollectionSharingService(
 	updateCollectionUseCase uc_collection.UpdateCollectionUseCase,
 	localCollectionRepository collection.CollectionRepository,
 	collectionEncryptionService svc_collectioncrypto.CollectionEncryptionService,
+	trustedKeyService keytrust.TrustedKeyService,
 ) SynchronizedCollectionSharingService {
 	logger = logger.Named("SynchronizedCollectionSharingService")
 	return &synchronizedCollectionSharingService{
@@ -65,6 +70,7 @@ func NewSynchronizedCollectionSharingService(
 		updateCollectionUseCase:         updateCollectionUseCase,
 		localCollectionRepository:       localCollectionRepository,
 		collectionEncryptionService:     collectionEncryptionService,
+		trustedKeyService:               trustedKeyService,
 	}
 }
 
@@ -153,6 +159,12 @@ func (s *synchronizedCollectionSharingService) executeCloudSharing(
 		return nil, fmt.Errorf("failed to lookup recipient: %w", err)
 	}
 
+	// Compare the public key with the one trusted for the recipient
+	keyTrust, err := s.trustedKeyService.Check(ctx, publicLookupResponse)
+	if err != nil {
+		return nil, errors.NewAppError("failed to check recipient public key trust", err)
+	}
+
 	// Get collection and validate sharing permissions
 	collectionToShare, err := s.getCollectionUseCase.Execute(ctx, input.CollectionID)
 	if err != nil {
@@ -205,6 +217,7 @@ func (s *synchronizedCollectionSharingService) executeCloudSharing(
 		PermissionLevel:        input.PermissionLevel,
 		EncryptedCollectionKey: encryptedCollectionKey,
 		ShareWithDescendants:   input.ShareWithDescendants,
+		ExpiresAt:              input.ExpiresAt,
 	}
 
 	s.logger.Debug("🔍 Sharing request details using crypto service",
@@ -221,6 +234,7 @@ func (s *synchronizedCollectionSharingService) executeCloudSharing(
 		Success:            response.Success,
 		Message:            response.Message,
 		MembershipsCreated: response.MembershipsCreated,
+		RecipientKeyTrust:  keyTrust,
 	}, nil
 }
 
@@ -284,6 +298,7 @@ func (s *synchronizedCollectionSharingService) updateLocalCollectionWithNewMembe
 		CreatedAt:              time.Now(),
 		IsInherited:            false,
 		// InheritedFromID:
+		ExpiresAt: input.ExpiresAt,
 	}
 
 	// Check if member already exists (shouldn't happen, but be defensive)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gocql/gocql"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	dom_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collectiondto"
 	dom_collectiondto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collectiondto"
@@ -25,6 +26,7 @@ type CreateLocalCollectionFromCloudCollectionService interface {
 // createLocalCollectionFromCloudCollectionService implements the CreateLocalCollectionFromCloudCollectionService interface
 type createLocalCollectionFromCloudCollectionService struct {
 	logger                      *zap.Logger
+	configService               config.ConfigService
 	cloudRepository             collectiondto.CollectionDTORepository
 	localRepository             dom_collection.CollectionRepository
 	getUserByIsLoggedInUseCase  uc_user.GetByIsLoggedInUseCase
@@ -34,6 +36,7 @@ type createLocalCollectionFromCloudCollectionService struct {
 // NewCreateLocalCollectionFromCloudCollectionService creates a new use case for creating cloud collections
 func NewCreateLocalCollectionFromCloudCollectionService(
 	logger *zap.Logger,
+	configService config.ConfigService,
 	cloudRepository collectiondto.CollectionDTORepository,
 	localRepository dom_collection.CollectionRepository,
 	getUserByIsLoggedInUseCase uc_user.GetByIsLoggedInUseCase,
@@ -42,6 +45,7 @@ func NewCreateLocalCollectionFromCloudCollectionService(
 	logger = logger.Named("CreateLocalCollectionFromCloudCollectionService")
 	return &createLocalCollectionFromCloudCollectionService{
 		logger:                      logger,
+		configService:               configService,
 		cloudRepository:             cloudRepository,
 		localRepository:             localRepository,
 		getUserByIsLoggedInUseCase:  getUserByIsLoggedInUseCase,
@@ -160,7 +164,7 @@ func (uc *createLocalCollectionFromCloudCollectionService) Execute(ctx context.C
 		newCollection.Name = "[Encrypted - No Access]"
 
 		// Still create the collection record for sync purposes, but mark it as problematic
-		newCollection.SyncStatus = dom_collection.SyncStatusCloudOnly // Or create a new status
+		newCollection.SyncStatus = dom_collection.SyncStatusKeyProblem
 
 		// DEBUGGING: Log the issue for investigation
 		uc.logger.Error("🚨 SYNC ISSUE: Collection accessible in sync but not decryptable",
@@ -187,16 +191,21 @@ func (uc *createLocalCollectionFromCloudCollectionService) Execute(ctx context.C
 	defer crypto.ClearBytes(collectionKey)
 
 	//
-	// Step 7: Decrypt any encrypted collection data
+	// Step 7: Decrypt any encrypted collection data, verifying the collection key decrypts it
 	//
-	collectionName, err := uc.collectionDecryptionService.ExecuteDecryptData(ctx, cloudCollectionDTO.EncryptedName, collectionKey)
+	collectionName, err := decryptCollectionName(ctx, uc.collectionDecryptionService, cloudCollectionDTO.EncryptedName, collectionKey)
 	if err != nil {
-		uc.logger.Error("failed to decrypt collection name", zap.Error(err))
-		return nil, errors.NewAppError("failed to decrypt collection name", err)
-	}
-	if collectionName == "" {
-		uc.logger.Error("failed to decrypt collection name - empty result", zap.Error(err))
-		return nil, errors.NewAppError("failed to decrypt collection name", err)
+		uc.logger.Error("🚨 Collection name cannot be decrypted with the collection key",
+			zap.String("collectionID", cloudCollectionDTO.ID.String()),
+			zap.Error(err))
+		if isStrictCollectionNameCheck(ctx, uc.configService, uc.logger) {
+			return nil, errors.NewAppError("failed to decrypt collection name", err)
+		}
+
+		// Store the collection flagged with a key problem instead of the undecryptable name, the
+		// next sync tries to decrypt it again
+		collectionName = keyProblemCollectionName
+		newCollection.SyncStatus = dom_collection.SyncStatusKeyProblem
 	}
 	newCollection.Name = collectionName
 
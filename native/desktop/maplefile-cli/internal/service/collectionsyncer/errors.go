@@ -0,0 +1,8 @@
+// internal/service/collectionsyncer/errors.go
+package collectionsyncer
+
+import "errors"
+
+// ErrCollectionKeyProblem is wrapped by the errors returned when a synced collection can't be
+// decrypted with its collection key, such as after a key mismatch between the cloud and this device.
+var ErrCollectionKeyProblem = errors.New("collection cannot be decrypted with its collection key")
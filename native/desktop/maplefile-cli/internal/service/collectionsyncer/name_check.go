@@ -0,0 +1,39 @@
+// internal/service/collectionsyncer/name_check.go
+package collectionsyncer
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectioncrypto"
+)
+
+// keyProblemCollectionName is the placeholder name of a collection whose name can't be decrypted
+const keyProblemCollectionName = "[Encrypted - Key Problem]"
+
+// decryptCollectionName decrypts the name of a synced collection, verifying the collection key
+// really decrypts it. The error wraps ErrCollectionKeyProblem if the name can't be decrypted.
+func decryptCollectionName(ctx context.Context, decryptionService collectioncrypto.CollectionDecryptionService, encryptedName string, collectionKey []byte) (string, error) {
+	name, err := decryptionService.ExecuteDecryptData(ctx, encryptedName, collectionKey)
+	if err != nil {
+		return "", fmt.Errorf("%w: failed to decrypt collection name: %w", ErrCollectionKeyProblem, err)
+	}
+	if name == "" {
+		return "", fmt.Errorf("%w: collection name decrypted to an empty name", ErrCollectionKeyProblem)
+	}
+	return name, nil
+}
+
+// isStrictCollectionNameCheck returns whether a collection whose name can't be decrypted fails its
+// sync rather than being stored with a key problem, marking it if the configuration can't be read
+func isStrictCollectionNameCheck(ctx context.Context, configService config.ConfigService, logger *zap.Logger) bool {
+	mode, err := configService.GetCollectionNameCheck(ctx)
+	if err != nil {
+		logger.Warn("⚠️ Failed to get collection name check, marking undecryptable collections", zap.Error(err))
+		return false
+	}
+	return mode == config.CollectionNameCheckStrict
+}
@@ -3,13 +3,16 @@ package collectionsyncer
 
 import (
 	"context"
+	stderrors "errors"
 
 	"go.uber.org/zap"
 
 	"github.com/gocql/gocql"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	dom_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collectiondto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectioncrypto"
 	uc_user "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/user"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
@@ -24,6 +27,7 @@ type UpdateLocalCollectionFromCloudCollectionService interface {
 // updateLocalCollectionFromCloudCollectionService implements the UpdateLocalCollectionFromCloudCollectionService interface
 type updateLocalCollectionFromCloudCollectionService struct {
 	logger                     *zap.Logger
+	configService              config.ConfigService
 	cloudRepository            collectiondto.CollectionDTORepository
 	localRepository            dom_collection.CollectionRepository
 	getUserByIsLoggedInUseCase uc_user.GetByIsLoggedInUseCase
@@ -33,6 +37,7 @@ type updateLocalCollectionFromCloudCollectionService struct {
 // NewUpdateLocalCollectionFromCloudCollectionService creates a new use case for updating local collection from the cloud
 func NewUpdateLocalCollectionFromCloudCollectionService(
 	logger *zap.Logger,
+	configService config.ConfigService,
 	cloudRepository collectiondto.CollectionDTORepository,
 	localRepository dom_collection.CollectionRepository,
 	getUserByIsLoggedInUseCase uc_user.GetByIsLoggedInUseCase,
@@ -41,6 +46,7 @@ func NewUpdateLocalCollectionFromCloudCollectionService(
 	logger = logger.Named("UpdateLocalCollectionFromCloudCollectionService")
 	return &updateLocalCollectionFromCloudCollectionService{
 		logger:                     logger,
+		configService:              configService,
 		cloudRepository:            cloudRepository,
 		localRepository:            localRepository,
 		getUserByIsLoggedInUseCase: getUserByIsLoggedInUseCase,
@@ -117,7 +123,8 @@ func (uc *updateLocalCollectionFromCloudCollectionService) Execute(ctx context.C
 	//
 
 	// CASE 1: Local collection is already same or newest version compared with the cloud collection.
-	if localCollection.Version >= cloudCollectionDTO.Version {
+	// Collections with a key problem are decrypted again until they succeed.
+	if localCollection.Version >= cloudCollectionDTO.Version && localCollection.SyncStatus != dom_collection.SyncStatusKeyProblem {
 		uc.logger.Debug("✅ Local collection is already same or newest version compared with the cloud collection",
 			zap.String("collection_id", cloudCollectionID.String()))
 		return nil, nil
@@ -143,9 +150,34 @@ func (uc *updateLocalCollectionFromCloudCollectionService) Execute(ctx context.C
 	// STEP 6: Decrypt the collection with provided password
 	//
 
-	collectionKey, err := uc.decryptionService.ExecuteDecryptCollectionKeyChain(ctx, user, localCollection, password)
+	// After a key rotation the cloud data is encrypted with a key our local copy doesn't know about
+	keySource := localCollection
+	if collectionKeyVersion(cloudCollectionDTO.EncryptedCollectionKey) != collectionKeyVersion(localCollection.EncryptedCollectionKey) {
+		uc.logger.Debug("🔑 Collection key was rotated, decrypting with the cloud membership",
+			zap.String("collectionID", cloudCollectionID.String()),
+			zap.Int("localKeyVersion", collectionKeyVersion(localCollection.EncryptedCollectionKey)),
+			zap.Int("cloudKeyVersion", collectionKeyVersion(cloudCollectionDTO.EncryptedCollectionKey)))
+		keySource = mapCollectionDTOToDomain(cloudCollectionDTO)
+	}
+
+	collectionKey, err := uc.decryptionService.ExecuteDecryptCollectionKeyChain(ctx, user, keySource, password)
+	if stderrors.Is(err, collectioncrypto.ErrCollectionKeyOutdated) {
+		// The collection key was rotated after our membership was stored locally, so re-fetch the
+		// membership with the key wrapped for the new collection key and try again.
+		uc.logger.Warn("🔁 Access key is outdated, re-fetching collection membership",
+			zap.String("collectionID", cloudCollectionID.String()),
+			zap.Error(err))
+		cloudCollectionDTO, err = uc.cloudRepository.GetFromCloudByID(ctx, cloudCollectionID)
+		if err != nil {
+			return nil, errors.NewAppError("failed to re-fetch collection from the cloud", err)
+		}
+		if cloudCollectionDTO == nil {
+			return nil, errors.NewAppError("cloud collection not found", nil)
+		}
+		collectionKey, err = uc.decryptionService.ExecuteDecryptCollectionKeyChain(ctx, user, mapCollectionDTOToDomain(cloudCollectionDTO), password)
+	}
 	if err != nil {
-		uc.logger.Warn("⚠️ Failed to decrypt collection key, using placeholder",
+		uc.logger.Warn("⚠️ Failed to decrypt collection key",
 			zap.String("collectionID", cloudCollectionDTO.ID.String()),
 			zap.Error(err))
 		return nil, err
@@ -153,16 +185,27 @@ func (uc *updateLocalCollectionFromCloudCollectionService) Execute(ctx context.C
 	defer crypto.ClearBytes(collectionKey)
 
 	//
-	// Step 7: Decrypt any encrypted collection data
+	// Step 7: Decrypt any encrypted collection data, verifying the collection key decrypts it
 	//
-	collectionName, err := uc.decryptionService.ExecuteDecryptData(ctx, cloudCollectionDTO.EncryptedName, collectionKey)
+	collectionName, err := decryptCollectionName(ctx, uc.decryptionService, cloudCollectionDTO.EncryptedName, collectionKey)
 	if err != nil {
-		uc.logger.Error("failed to decrypt file metadata", zap.Error(err))
-		return nil, errors.NewAppError("failed to decrypt file metadata", err)
-	}
-	if collectionName == "" {
-		uc.logger.Error("failed to decrypt collection name", zap.Error(err))
-		return nil, errors.NewAppError("failed to decrypt collection name", err)
+		uc.logger.Error("🚨 Collection name cannot be decrypted with the collection key",
+			zap.String("collectionID", cloudCollectionDTO.ID.String()),
+			zap.Error(err))
+		if isStrictCollectionNameCheck(ctx, uc.configService, uc.logger) {
+			return nil, errors.NewAppError("failed to decrypt collection name", err)
+		}
+
+		// Flag the local collection with a key problem and keep its version, so its last readable
+		// name is kept and the next sync tries to decrypt the cloud changes again
+		localCollection.SyncStatus = dom_collection.SyncStatusKeyProblem
+		if err := uc.localRepository.Save(ctx, localCollection); err != nil {
+			uc.logger.Error("❌ Failed to flag local collection with a key problem",
+				zap.String("id", localCollection.ID.String()),
+				zap.Error(err))
+			return nil, err
+		}
+		return localCollection, nil
 	}
 
 	//
@@ -175,6 +218,9 @@ func (uc *updateLocalCollectionFromCloudCollectionService) Execute(ctx context.C
 	// IMPORTANT: Assign our decrypted values to.
 	cloudCollection.Name = collectionName
 
+	// Keep the local preferences which the cloud doesn't know about
+	cloudCollection.SyncDisabled = localCollection.SyncDisabled
+
 	uc.logger.Debug("🔍 Full cloud collection DTO",
 		zap.String("id", cloudCollectionDTO.ID.String()),
 		zap.String("state", cloudCollectionDTO.State),
@@ -199,3 +245,11 @@ func (uc *updateLocalCollectionFromCloudCollectionService) Execute(ctx context.C
 	)
 	return cloudCollection, nil
 }
+
+// collectionKeyVersion returns the version of an encrypted collection key, or zero if it is missing
+func collectionKeyVersion(encryptedKey *keys.EncryptedCollectionKey) int {
+	if encryptedKey == nil {
+		return 0
+	}
+	return encryptedKey.KeyVersion
+}
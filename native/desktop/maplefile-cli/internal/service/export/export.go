@@ -0,0 +1,323 @@
+// internal/service/export/export.go
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	dom_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	svc_filedownload "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filedownload"
+	uc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collection"
+	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
+)
+
+// ExportInput represents the input for exporting the decrypted library
+type ExportInput struct {
+	// OutputDir is the directory the snapshot is written into. It must be empty or not exist yet.
+	OutputDir string `json:"output_dir"`
+	// IncludeCloudOnly downloads and decrypts cloud-only files into the snapshot. The local
+	// file records are left untouched so the files remain cloud-only afterwards.
+	IncludeCloudOnly bool   `json:"include_cloud_only"`
+	UserPassword     string `json:"user_password,omitempty"`
+}
+
+// SkippedFile represents a file which was not written to the snapshot
+type SkippedFile struct {
+	FileID       gocql.UUID `json:"file_id"`
+	CollectionID gocql.UUID `json:"collection_id"`
+	Name         string     `json:"name"`
+	Reason       string     `json:"reason"`
+}
+
+// ExportOutput represents the result of exporting the decrypted library
+type ExportOutput struct {
+	OutputDir          string         `json:"output_dir"`
+	CollectionsWritten int            `json:"collections_written"`
+	FilesWritten       int            `json:"files_written"`
+	BytesWritten       int64          `json:"bytes_written"`
+	SkippedFiles       []*SkippedFile `json:"skipped_files"`
+}
+
+// ExportService defines the interface for exporting a read-only snapshot of the decrypted library
+type ExportService interface {
+	Export(ctx context.Context, input *ExportInput) (*ExportOutput, error)
+}
+
+// exportService implements the ExportService interface
+type exportService struct {
+	logger                 *zap.Logger
+	listCollectionsUseCase uc_collection.ListCollectionsUseCase
+	listFilesUseCase       uc_file.ListFilesUseCase
+	downloadService        svc_filedownload.DownloadService
+}
+
+// NewExportService creates a new service for exporting the decrypted library
+func NewExportService(
+	logger *zap.Logger,
+	listCollectionsUseCase uc_collection.ListCollectionsUseCase,
+	listFilesUseCase uc_file.ListFilesUseCase,
+	downloadService svc_filedownload.DownloadService,
+) ExportService {
+	logger = logger.Named("ExportService")
+	return &exportService{
+		logger:                 logger,
+		listCollectionsUseCase: listCollectionsUseCase,
+		listFilesUseCase:       listFilesUseCase,
+		downloadService:        downloadService,
+	}
+}
+
+// Export writes every active collection and its decrypted files into a directory tree which mirrors
+// the collection hierarchy. Neither the local file records nor their `SyncStatus` are modified.
+func (s *exportService) Export(ctx context.Context, input *ExportInput) (*ExportOutput, error) {
+	//
+	// STEP 1: Validate inputs
+	//
+	if input == nil {
+		return nil, errors.NewAppError("input is required", nil)
+	}
+	if input.OutputDir == "" {
+		return nil, errors.NewAppError("output directory is required", nil)
+	}
+	if input.IncludeCloudOnly && input.UserPassword == "" {
+		return nil, errors.NewAppError("user password is required to export cloud-only files", nil)
+	}
+
+	outputDir, err := filepath.Abs(input.OutputDir)
+	if err != nil {
+		return nil, errors.NewAppError("invalid output directory", err)
+	}
+	if err := ensureEmptyDirectory(outputDir); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("📦 Starting library export",
+		zap.String("outputDir", outputDir),
+		zap.Bool("includeCloudOnly", input.IncludeCloudOnly))
+
+	//
+	// STEP 2: Resolve a human-readable directory for every active collection
+	//
+	collections, err := s.listCollectionsUseCase.ListActiveCollections(ctx)
+	if err != nil {
+		return nil, errors.NewAppError("failed to list local collections", err)
+	}
+
+	collectionDirs := resolveCollectionDirs(outputDir, collections)
+
+	output := &ExportOutput{
+		OutputDir:    outputDir,
+		SkippedFiles: make([]*SkippedFile, 0),
+	}
+
+	//
+	// STEP 3: Write the files of every collection
+	//
+	for _, collection := range collections {
+		collectionDir := collectionDirs[collection.ID]
+		if err := os.MkdirAll(collectionDir, 0755); err != nil {
+			return nil, errors.NewAppError("failed to create export directory", err)
+		}
+		output.CollectionsWritten++
+
+		collectionID := collection.ID
+		files, err := s.listFilesUseCase.Execute(ctx, dom_file.FileFilter{CollectionID: &collectionID})
+		if err != nil {
+			return nil, errors.NewAppError("failed to list local files", err)
+		}
+
+		usedNames := make(map[string]bool)
+		for _, file := range files {
+			if file.State != dom_file.FileStateActive {
+				continue
+			}
+
+			written, err := s.exportFile(ctx, file, collectionDir, usedNames, input)
+			if err != nil {
+				s.logger.Warn("⚠️ Skipping file during export",
+					zap.String("fileID", file.ID.String()),
+					zap.Error(err))
+				output.SkippedFiles = append(output.SkippedFiles, &SkippedFile{
+					FileID:       file.ID,
+					CollectionID: file.CollectionID,
+					Name:         file.Name,
+					Reason:       err.Error(),
+				})
+				continue
+			}
+
+			output.FilesWritten++
+			output.BytesWritten += written
+		}
+	}
+
+	s.logger.Info("✅ Library export completed",
+		zap.String("outputDir", outputDir),
+		zap.Int("collectionsWritten", output.CollectionsWritten),
+		zap.Int("filesWritten", output.FilesWritten),
+		zap.Int("skippedFiles", len(output.SkippedFiles)))
+
+	return output, nil
+}
+
+// exportFile writes a single decrypted file into the collection directory and returns the number of bytes written
+func (s *exportService) exportFile(
+	ctx context.Context,
+	file *dom_file.File,
+	collectionDir string,
+	usedNames map[string]bool,
+	input *ExportInput,
+) (int64, error) {
+	if file.SyncStatus == dom_file.SyncStatusCloudOnly {
+		if !input.IncludeCloudOnly {
+			return 0, fmt.Errorf("file is cloud-only")
+		}
+
+		result, err := s.downloadService.DownloadAndDecryptFile(ctx, file.ID, input.UserPassword, 1*time.Hour)
+		if err != nil {
+			return 0, err
+		}
+
+		name := file.Name
+		if result.DecryptedMetadata != nil && result.DecryptedMetadata.Name != "" {
+			name = result.DecryptedMetadata.Name
+		}
+
+		destPath := filepath.Join(collectionDir, uniqueName(usedNames, sanitizeName(name, file.ID.String())))
+		if err := os.WriteFile(destPath, result.DecryptedData, 0444); err != nil {
+			return 0, err
+		}
+		return int64(len(result.DecryptedData)), nil
+	}
+
+	if file.FilePath == "" {
+		return 0, fmt.Errorf("no decrypted copy is stored locally")
+	}
+
+	destPath := filepath.Join(collectionDir, uniqueName(usedNames, sanitizeName(file.Name, file.ID.String())))
+	return copyReadOnly(file.FilePath, destPath)
+}
+
+// resolveCollectionDirs maps every collection to its directory in the snapshot. Collections whose
+// parent is not available locally are placed at the root of the snapshot.
+func resolveCollectionDirs(outputDir string, collections []*dom_collection.Collection) map[gocql.UUID]string {
+	byID := make(map[gocql.UUID]*dom_collection.Collection, len(collections))
+	for _, collection := range collections {
+		byID[collection.ID] = collection
+	}
+
+	// Assign each collection a unique, human-readable name amongst its siblings.
+	siblingNames := make(map[gocql.UUID]map[string]bool)
+	names := make(map[gocql.UUID]string, len(collections))
+	for _, collection := range collections {
+		parentID := collection.ParentID
+		if _, ok := byID[parentID]; !ok {
+			parentID = gocql.UUID{}
+		}
+		if siblingNames[parentID] == nil {
+			siblingNames[parentID] = make(map[string]bool)
+		}
+		names[collection.ID] = uniqueName(siblingNames[parentID], sanitizeName(collection.Name, collection.ID.String()))
+	}
+
+	dirs := make(map[gocql.UUID]string, len(collections))
+	var resolve func(id gocql.UUID, visiting map[gocql.UUID]bool) string
+	resolve = func(id gocql.UUID, visiting map[gocql.UUID]bool) string {
+		if dir, ok := dirs[id]; ok {
+			return dir
+		}
+
+		parentDir := outputDir
+		parentID := byID[id].ParentID
+		if _, ok := byID[parentID]; ok && !visiting[parentID] {
+			visiting[id] = true
+			parentDir = resolve(parentID, visiting)
+		}
+
+		dirs[id] = filepath.Join(parentDir, names[id])
+		return dirs[id]
+	}
+
+	for _, collection := range collections {
+		resolve(collection.ID, make(map[gocql.UUID]bool))
+	}
+
+	return dirs
+}
+
+// sanitizeName makes a decrypted name safe to use as a single path element
+func sanitizeName(name string, fallback string) string {
+	name = strings.TrimSpace(name)
+	name = strings.NewReplacer("/", "_", "\\", "_", "\x00", "").Replace(name)
+	if name == "" || name == "." || name == ".." || name == "[Encrypted]" {
+		return fallback
+	}
+	return name
+}
+
+// uniqueName returns `name`, or `name (n)` if it has already been used, and records it as used
+func uniqueName(used map[string]bool, name string) string {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	candidate := name
+	for i := 1; used[strings.ToLower(candidate)]; i++ {
+		candidate = fmt.Sprintf("%s (%d)%s", base, i, ext)
+	}
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+// ensureEmptyDirectory creates the directory if needed and refuses to write into a non-empty one
+func ensureEmptyDirectory(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return errors.NewAppError("failed to create output directory", err)
+			}
+			return nil
+		}
+		return errors.NewAppError("failed to read output directory", err)
+	}
+
+	if len(entries) > 0 {
+		return errors.NewAppError(fmt.Sprintf("output directory is not empty: %s", dir), nil)
+	}
+	return nil
+}
+
+// copyReadOnly copies a file and marks the copy as read-only
+func copyReadOnly(srcPath string, destPath string) (int64, error) {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	dest, err := os.OpenFile(destPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0444)
+	if err != nil {
+		return 0, err
+	}
+
+	written, err := io.Copy(dest, src)
+	if closeErr := dest.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(destPath)
+		return 0, err
+	}
+
+	return written, nil
+}
@@ -0,0 +1,161 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	dom_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	svc_filedownload "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filedownload"
+	uc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collection"
+	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
+)
+
+// staticCollections returns the same active collections
+type staticCollections struct {
+	uc_collection.ListCollectionsUseCase
+	collections []*dom_collection.Collection
+}
+
+func (c *staticCollections) ListActiveCollections(ctx context.Context) ([]*dom_collection.Collection, error) {
+	return c.collections, nil
+}
+
+// staticFiles returns the files of the collection in the filter
+type staticFiles struct {
+	uc_file.ListFilesUseCase
+	files []*dom_file.File
+}
+
+func (f *staticFiles) Execute(ctx context.Context, filter dom_file.FileFilter) ([]*dom_file.File, error) {
+	var files []*dom_file.File
+	for _, file := range f.files {
+		if filter.CollectionID != nil && file.CollectionID == *filter.CollectionID {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// cloudDownloads returns the decrypted cloud-only files, or the error of the file
+type cloudDownloads struct {
+	svc_filedownload.DownloadService
+	results map[gocql.UUID]*svc_filedownload.DownloadResult
+	errs    map[gocql.UUID]error
+}
+
+func (d *cloudDownloads) DownloadAndDecryptFile(ctx context.Context, fileID gocql.UUID, userPassword string, urlDuration time.Duration) (*svc_filedownload.DownloadResult, error) {
+	if err, ok := d.errs[fileID]; ok {
+		return nil, err
+	}
+	return d.results[fileID], nil
+}
+
+func TestExportRoundTripsMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	photos := &dom_collection.Collection{ID: gocql.TimeUUID(), Name: "Photos"}
+	trip := &dom_collection.Collection{ID: gocql.TimeUUID(), Name: "Trip / 2024", ParentID: photos.ID}
+
+	localPath := filepath.Join(t.TempDir(), "decrypted")
+	if err := os.WriteFile(localPath, []byte("local content"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	localFile := &dom_file.File{ID: gocql.TimeUUID(), CollectionID: photos.ID, Name: "notes.txt", FilePath: localPath, SyncStatus: dom_file.SyncStatusSynced, State: dom_file.FileStateActive}
+	cloudFile := &dom_file.File{ID: gocql.TimeUUID(), CollectionID: trip.ID, Name: "[Encrypted]", SyncStatus: dom_file.SyncStatusCloudOnly, State: dom_file.FileStateActive}
+
+	s := NewExportService(zap.NewNop(),
+		&staticCollections{collections: []*dom_collection.Collection{trip, photos}},
+		&staticFiles{files: []*dom_file.File{localFile, cloudFile}},
+		&cloudDownloads{results: map[gocql.UUID]*svc_filedownload.DownloadResult{
+			cloudFile.ID: {
+				FileID:            cloudFile.ID,
+				DecryptedData:     []byte("cloud content"),
+				DecryptedMetadata: &svc_filedownload.DecryptedFileMetadata{Name: "beach.jpg"},
+			},
+		}},
+	)
+
+	outputDir := filepath.Join(t.TempDir(), "snapshot")
+	output, err := s.Export(ctx, &ExportInput{OutputDir: outputDir, IncludeCloudOnly: true, UserPassword: "password"})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if output.CollectionsWritten != 2 || output.FilesWritten != 2 || len(output.SkippedFiles) != 0 {
+		t.Fatalf("Export() = %+v, want 2 collections and 2 files written", output)
+	}
+
+	// The snapshot mirrors the hierarchy with the decrypted names, made safe to use as path elements
+	want := map[string]string{
+		filepath.Join(outputDir, "Photos", "notes.txt"):                "local content",
+		filepath.Join(outputDir, "Photos", "Trip _ 2024", "beach.jpg"): "cloud content",
+	}
+	for path, content := range want {
+		exported, err := os.ReadFile(path)
+		if err != nil {
+			t.Errorf("ReadFile(%s) error = %v", path, err)
+			continue
+		}
+		if string(exported) != content {
+			t.Errorf("%s content = %q, want %q", path, exported, content)
+		}
+		info, err := os.Stat(path)
+		if err == nil && info.Mode().Perm()&0222 != 0 {
+			t.Errorf("%s mode = %v, want read-only", path, info.Mode().Perm())
+		}
+	}
+	if cloudFile.SyncStatus != dom_file.SyncStatusCloudOnly {
+		t.Errorf("cloud-only file SyncStatus = %v after export, want it unchanged", cloudFile.SyncStatus)
+	}
+}
+
+func TestExportSkipsFileWhichFailsToDecrypt(t *testing.T) {
+	ctx := context.Background()
+
+	collection := &dom_collection.Collection{ID: gocql.TimeUUID(), Name: "Documents"}
+	broken := &dom_file.File{ID: gocql.TimeUUID(), CollectionID: collection.ID, Name: "taxes.pdf", SyncStatus: dom_file.SyncStatusCloudOnly, State: dom_file.FileStateActive}
+	readable := &dom_file.File{ID: gocql.TimeUUID(), CollectionID: collection.ID, Name: "letter.txt", SyncStatus: dom_file.SyncStatusCloudOnly, State: dom_file.FileStateActive}
+
+	decryptErr := errors.NewAppError("failed to decrypt file key", fmt.Errorf("%w: message authentication failed", svc_filedownload.ErrDecryptionFailed))
+	s := NewExportService(zap.NewNop(),
+		&staticCollections{collections: []*dom_collection.Collection{collection}},
+		&staticFiles{files: []*dom_file.File{broken, readable}},
+		&cloudDownloads{
+			results: map[gocql.UUID]*svc_filedownload.DownloadResult{
+				readable.ID: {FileID: readable.ID, DecryptedData: []byte("dear reader")},
+			},
+			errs: map[gocql.UUID]error{broken.ID: decryptErr},
+		},
+	)
+
+	outputDir := filepath.Join(t.TempDir(), "snapshot")
+	output, err := s.Export(ctx, &ExportInput{OutputDir: outputDir, IncludeCloudOnly: true, UserPassword: "password"})
+	if err != nil {
+		t.Fatalf("Export() error = %v, one file failing to decrypt should not fail the export", err)
+	}
+
+	if output.FilesWritten != 1 || len(output.SkippedFiles) != 1 {
+		t.Fatalf("Export() = %+v, want 1 file written and 1 skipped", output)
+	}
+	skipped := output.SkippedFiles[0]
+	if skipped.FileID != broken.ID || !strings.Contains(skipped.Reason, "failed to decrypt file key") {
+		t.Errorf("skipped file = %+v, want %s with the decryption error", skipped, broken.ID)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(outputDir, "Documents"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "letter.txt" {
+		t.Errorf("exported files = %v, want only letter.txt", entries)
+	}
+}
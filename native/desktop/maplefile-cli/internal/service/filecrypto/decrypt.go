@@ -23,11 +23,15 @@ type FileDecryptionService interface {
 	// DecryptFileMetadata decrypts file metadata using the file key
 	DecryptFileMetadata(ctx context.Context, encryptedMetadata string, fileKey []byte) (*dom_file.FileMetadata, error)
 
-	// DecryptFileContent decrypts file content using the file key
+	// DecryptFileContent decrypts file content using the file key. It returns the bytes given to
+	// EncryptFileContent unchanged, which are the source of truth for the local copy.
 	DecryptFileContent(ctx context.Context, encryptedData []byte, fileKey []byte) ([]byte, error)
 
 	// DecryptFileKeyChain performs the complete chain: collection key -> file key -> decrypted file key
 	DecryptFileKeyChain(ctx context.Context, encryptedFileKey keys.EncryptedFileKey, collectionKey []byte) ([]byte, error)
+
+	// VerifyFileHash checks decrypted file content against the encrypted hash recorded for the file
+	VerifyFileHash(ctx context.Context, encryptedHash string, algorithm string, data []byte, fileKey []byte) error
 }
 
 // fileDecryptionService implements FileDecryptionService
@@ -141,6 +145,27 @@ func (s *fileDecryptionService) DecryptFileContent(ctx context.Context, encrypte
 		return nil, errors.NewAppError("file key is required", nil)
 	}
 
+	// Content is encrypted in frames, or as a single message by earlier versions
+	if crypto.IsFramed(encryptedData) {
+		decryptedData, err := crypto.DecryptFramed(encryptedData, fileKey)
+		if err == nil {
+			s.logger.Debug("✅ Successfully decrypted framed file content",
+				zap.Int("decryptedSize", len(decryptedData)))
+			return decryptedData, nil
+		}
+		// A single message whose nonce happens to look like a framed header
+		if single, singleErr := s.decryptSingleMessage(encryptedData, fileKey); singleErr == nil {
+			return single, nil
+		}
+		s.logger.Error("❌ Failed to decrypt file content", zap.Error(err))
+		return nil, fmt.Errorf("failed to decrypt file content: %w", err)
+	}
+
+	return s.decryptSingleMessage(encryptedData, fileKey)
+}
+
+// decryptSingleMessage decrypts content encrypted as one ChaCha20-Poly1305 message
+func (s *fileDecryptionService) decryptSingleMessage(encryptedData []byte, fileKey []byte) ([]byte, error) {
 	// The encrypted data should be in the format: nonce (12 bytes) + ciphertext for ChaCha20-Poly1305
 	if len(encryptedData) < crypto.ChaCha20Poly1305NonceSize {
 		s.logger.Error("❌ Encrypted data too short",
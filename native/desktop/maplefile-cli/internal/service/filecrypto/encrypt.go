@@ -25,7 +25,9 @@ type FileEncryptionService interface {
 	// EncryptFileMetadata encrypts file metadata using the file key
 	EncryptFileMetadata(ctx context.Context, metadata *dom_file.FileMetadata, fileKey []byte) (string, error)
 
-	// EncryptFileContent encrypts file content using the file key
+	// EncryptFileContent encrypts file content using the file key. The content is opaque bytes: no
+	// text encoding, byte order mark or line ending is touched, and any transformation added to the
+	// pipeline, such as compression, must be reversed exactly by DecryptFileContent.
 	EncryptFileContent(ctx context.Context, fileData []byte, fileKey []byte) ([]byte, error)
 }
 
@@ -160,16 +162,13 @@ func (s *fileEncryptionService) EncryptFileContent(ctx context.Context, fileData
 		return nil, errors.NewAppError("file key is required", nil)
 	}
 
-	// Encrypt the file content
-	encryptedData, err := crypto.EncryptWithSecretBox(fileData, fileKey)
+	// Encrypt the file content in frames, so a byte range can be downloaded and decrypted on its own
+	combined, err := crypto.EncryptFramed(fileData, fileKey, crypto.DefaultFrameSize)
 	if err != nil {
 		s.logger.Error("❌ Failed to encrypt file content", zap.Error(err))
 		return nil, errors.NewAppError("failed to encrypt file content", err)
 	}
 
-	// Combine nonce and ciphertext for storage
-	combined := crypto.CombineNonceAndCiphertext(encryptedData.Nonce, encryptedData.Ciphertext)
-
 	s.logger.Debug("✅ Successfully encrypted file content",
 		zap.Int("originalSize", len(fileData)),
 		zap.Int("encryptedSize", len(combined)))
@@ -0,0 +1,59 @@
+// native/desktop/maplefile-cli/internal/service/filecrypto/verify.go
+package filecrypto
+
+import (
+	"context"
+	"crypto/subtle"
+	stderrors "errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+)
+
+var (
+	// ErrFileHashMismatch is returned when file content does not match the hash recorded for it
+	ErrFileHashMismatch = stderrors.New("file hash does not match")
+	// ErrFileHashUnreadable is returned when the recorded hash cannot be decoded or decrypted, for
+	// example because the file was uploaded by a client which records hashes differently
+	ErrFileHashUnreadable = stderrors.New("file hash cannot be read")
+)
+
+// VerifyFileHash decrypts the hash recorded for a file and compares it with the hash of the data,
+// computed with the algorithm the hash was recorded with.
+func (s *fileDecryptionService) VerifyFileHash(ctx context.Context, encryptedHash string, algorithm string, data []byte, fileKey []byte) error {
+	if algorithm == "" {
+		algorithm = crypto.DefaultHashAlgorithm
+	}
+	s.logger.Debug("🔍 Verifying file hash",
+		zap.String("algorithm", algorithm),
+		zap.Int("dataSize", len(data)))
+
+	// Reject unknown algorithms before doing any decryption work
+	hasher, err := crypto.NewHash(algorithm)
+	if err != nil {
+		s.logger.Error("❌ Unsupported file hash algorithm", zap.String("algorithm", algorithm), zap.Error(err))
+		return err
+	}
+
+	encryptedHashData, err := crypto.DecodeFromBase64(encryptedHash)
+	if err != nil {
+		return fmt.Errorf("%w: failed to decode: %v", ErrFileHashUnreadable, err)
+	}
+	expectedHash, err := s.DecryptFileContent(ctx, encryptedHashData, fileKey)
+	if err != nil {
+		return fmt.Errorf("%w: failed to decrypt: %v", ErrFileHashUnreadable, err)
+	}
+
+	hasher.Write(data)
+	actualHash := hasher.Sum(nil)
+
+	if subtle.ConstantTimeCompare(expectedHash, actualHash) != 1 {
+		s.logger.Error("❌ File hash does not match", zap.String("algorithm", algorithm))
+		return fmt.Errorf("%w (%s)", ErrFileHashMismatch, algorithm)
+	}
+
+	s.logger.Debug("✅ File hash verified", zap.String("algorithm", algorithm))
+	return nil
+}
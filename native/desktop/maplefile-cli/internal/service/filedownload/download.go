@@ -3,12 +3,19 @@ package filedownload
 
 import (
 	"context"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/gocql/gocql"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	dom_filedto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
 	svc_collectioncrypto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectioncrypto"
 	svc_filecrypto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filecrypto"
 	uc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collection"
@@ -48,23 +55,45 @@ type DownloadResult struct {
 // DownloadService handles file download operations with E2EE decryption
 type DownloadService interface {
 	DownloadAndDecryptFile(ctx context.Context, fileID gocql.UUID, userPassword string, urlDuration time.Duration) (*DownloadResult, error)
+	// VerifyLocalCopy checks a decrypted local copy of a file against its recorded hash.
+	VerifyLocalCopy(ctx context.Context, fileID gocql.UUID, userPassword string, path string) (*DecryptedFileMetadata, error)
+	// DownloadRange returns the decrypted bytes of a file from offset, at most length of them.
+	DownloadRange(ctx context.Context, fileID gocql.UUID, offset int64, length int64, userPassword string) (*RangeResult, error)
+	// PrefetchPresignedDownloadURLs presigns many files with one request per batch, so downloading
+	// them afterwards reuses the cached URLs.
+	PrefetchPresignedDownloadURLs(ctx context.Context, fileIDs []gocql.UUID, urlDuration time.Duration) error
 }
 
 type downloadService struct {
-	logger                         *zap.Logger
-	getPresignedDownloadURLUseCase filedto.GetPresignedDownloadURLUseCase
-	downloadFileUseCase            filedto.DownloadFileUseCase
-	getFileUseCase                 uc_file.GetFileUseCase
-	getUserByIsLoggedInUseCase     uc_user.GetByIsLoggedInUseCase
-	getCollectionUseCase           uc_collection.GetCollectionUseCase
-	collectionDecryptionService    svc_collectioncrypto.CollectionDecryptionService
-	fileDecryptionService          svc_filecrypto.FileDecryptionService
+	logger                          *zap.Logger
+	configService                   config.ConfigService
+	getPresignedDownloadURLUseCase  filedto.GetPresignedDownloadURLUseCase
+	getPresignedDownloadURLsUseCase filedto.GetPresignedDownloadURLsUseCase
+	downloadFileUseCase             filedto.DownloadFileUseCase
+	downloadFileRangeUseCase        filedto.DownloadFileRangeUseCase
+	getFileUseCase                  uc_file.GetFileUseCase
+	getUserByIsLoggedInUseCase      uc_user.GetByIsLoggedInUseCase
+	getCollectionUseCase            uc_collection.GetCollectionUseCase
+	collectionDecryptionService     svc_collectioncrypto.CollectionDecryptionService
+	fileDecryptionService           svc_filecrypto.FileDecryptionService
+
+	// Shared by every concurrent download to avoid redundant presign calls.
+	presignedURLCache *presignedURLCache
+	presignLimiter    *requestLimiter
+
+	// Shared by every concurrent download to bound the memory buffered at once, sized from the
+	// configuration on first use.
+	memoryBudget     *memoryBudget
+	memoryBudgetOnce sync.Once
 }
 
 func NewDownloadService(
 	logger *zap.Logger,
+	configService config.ConfigService,
 	getPresignedDownloadURLUseCase filedto.GetPresignedDownloadURLUseCase,
+	getPresignedDownloadURLsUseCase filedto.GetPresignedDownloadURLsUseCase,
 	downloadFileUseCase filedto.DownloadFileUseCase,
+	downloadFileRangeUseCase filedto.DownloadFileRangeUseCase,
 	getFileUseCase uc_file.GetFileUseCase,
 	getUserByIsLoggedInUseCase uc_user.GetByIsLoggedInUseCase,
 	getCollectionUseCase uc_collection.GetCollectionUseCase,
@@ -73,14 +102,19 @@ func NewDownloadService(
 ) DownloadService {
 	logger = logger.Named("DownloadService")
 	return &downloadService{
-		logger:                         logger,
-		getPresignedDownloadURLUseCase: getPresignedDownloadURLUseCase,
-		downloadFileUseCase:            downloadFileUseCase,
-		getFileUseCase:                 getFileUseCase,
-		getUserByIsLoggedInUseCase:     getUserByIsLoggedInUseCase,
-		getCollectionUseCase:           getCollectionUseCase,
-		collectionDecryptionService:    collectionDecryptionService,
-		fileDecryptionService:          fileDecryptionService,
+		logger:                          logger,
+		configService:                   configService,
+		getPresignedDownloadURLUseCase:  getPresignedDownloadURLUseCase,
+		getPresignedDownloadURLsUseCase: getPresignedDownloadURLsUseCase,
+		downloadFileUseCase:             downloadFileUseCase,
+		downloadFileRangeUseCase:        downloadFileRangeUseCase,
+		getFileUseCase:                  getFileUseCase,
+		getUserByIsLoggedInUseCase:      getUserByIsLoggedInUseCase,
+		getCollectionUseCase:            getCollectionUseCase,
+		collectionDecryptionService:     collectionDecryptionService,
+		fileDecryptionService:           fileDecryptionService,
+		presignedURLCache:               newPresignedURLCache(),
+		presignLimiter:                  newRequestLimiter(presignRequestInterval),
 	}
 }
 
@@ -97,15 +131,123 @@ func (s *downloadService) DownloadAndDecryptFile(ctx context.Context, fileID goc
 		return nil, errors.NewAppError("user password is required for E2EE decryption", nil)
 	}
 
+	//
+	// Step 2-6: Decrypt the E2EE key chain down to the file key, and the file metadata
+	//
+	file, fileKey, decryptedMetadata, err := s.decryptFileKeyChain(ctx, fileID, userPassword)
+	if err != nil {
+		return nil, err
+	}
+	defer crypto.ClearBytes(fileKey)
+
+	//
+	// Step 7: Get presigned download URLs
+	//
+	urlResponse, err := s.getPresignedDownloadURLs(ctx, fileID, urlDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	//
+	// Step 8: Download encrypted file content, once the buffers fit in the memory budget
+	//
+	reserved, err := s.getMemoryBudget(ctx).acquire(ctx, downloadMemorySize(file))
+	if err != nil {
+		return nil, errors.NewAppError("cancelled while waiting for download memory", err)
+	}
+	defer s.memoryBudget.release(reserved)
+
+	s.logger.Debug("📥 Downloading encrypted file content")
+	downloadRequest := &filedto.DownloadRequest{
+		PresignedURL:          urlResponse.PresignedDownloadURL,
+		PresignedThumbnailURL: urlResponse.PresignedThumbnailURL,
+	}
+
+	downloadResponse, err := s.downloadFileUseCase.Execute(ctx, downloadRequest)
+	if err != nil {
+		return nil, errors.NewAppError("failed to download file content", err)
+	}
+	s.logger.Debug("✅ Successfully downloaded encrypted file content")
+
+	//
+	// Step 9: Decrypt the file content
+	//
+	s.logger.Debug("🔑 Decrypting file content")
+	decryptedData, err := s.fileDecryptionService.DecryptFileContent(ctx, downloadResponse.FileData, fileKey)
+	if err != nil {
+		return nil, errors.NewAppError("failed to decrypt file content", fmt.Errorf("%w: %w", ErrDecryptionFailed, err))
+	}
+	s.logger.Debug("✅ Successfully decrypted file content")
+
+	//
+	// Step 10: Verify the integrity of the file content
+	//
+	if file.EncryptedHash == "" {
+		s.logger.Warn("⚠️ File has no recorded hash, skipping integrity verification",
+			zap.String("fileID", fileID.String()))
+	} else if err := s.fileDecryptionService.VerifyFileHash(ctx, file.EncryptedHash, file.EncryptedHashAlgorithm, decryptedData, fileKey); err != nil {
+		switch {
+		case stderrors.Is(err, crypto.ErrUnsupportedHashAlgorithm):
+			return nil, errors.NewAppError("cannot verify the integrity of the downloaded file, upgrade this client to support its hash algorithm", err)
+		case stderrors.Is(err, svc_filecrypto.ErrFileHashUnreadable):
+			s.logger.Warn("⚠️ Recorded file hash cannot be read, skipping integrity verification",
+				zap.String("fileID", fileID.String()),
+				zap.Error(err))
+		default:
+			return nil, errors.NewAppError("downloaded file failed integrity verification", fmt.Errorf("%w: %w", ErrIntegrityCheckFailed, err))
+		}
+	}
+
+	//
+	// Step 11: Decrypt thumbnail if present
+	//
+	var thumbnailData []byte
+	if downloadResponse.ThumbnailData != nil && len(downloadResponse.ThumbnailData) > 0 {
+		s.logger.Debug("🔑 Decrypting thumbnail data")
+		thumbnailData, err = s.fileDecryptionService.DecryptFileContent(ctx, downloadResponse.ThumbnailData, fileKey)
+		if err != nil {
+			s.logger.Warn("⚠️ Failed to decrypt thumbnail, continuing without it", zap.Error(err))
+			thumbnailData = nil
+		} else {
+			s.logger.Debug("✅ Successfully decrypted thumbnail data")
+		}
+	}
+
+	// Convert file metadata to the expected format
+	resultMetadata, err := toDecryptedFileMetadata(decryptedMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DownloadResult{
+		FileID:            fileID,
+		DecryptedData:     decryptedData,
+		DecryptedMetadata: resultMetadata,
+		ThumbnailData:     thumbnailData,
+		OriginalSize:      int64(len(decryptedData)),
+		ThumbnailSize:     int64(len(thumbnailData)),
+	}
+
+	s.logger.Info("✅ Successfully completed E2EE file download and decryption",
+		zap.String("fileID", fileID.String()),
+		zap.String("fileName", resultMetadata.Name),
+		zap.Int64("originalSize", result.OriginalSize))
+
+	return result, nil
+}
+
+// decryptFileKeyChain gets a file and decrypts its key chain down to the file key, which the caller
+// must clear, and its metadata
+func (s *downloadService) decryptFileKeyChain(ctx context.Context, fileID gocql.UUID, userPassword string) (*dom_file.File, []byte, *dom_file.FileMetadata, error) {
 	//
 	// Step 2: Get file metadata (contains encrypted file key and metadata)
 	//
 	file, err := s.getFileUseCase.Execute(ctx, fileID)
 	if err != nil {
-		return nil, errors.NewAppError("failed to get file metadata", err)
+		return nil, nil, nil, errors.NewAppError("failed to get file metadata", err)
 	}
 	if file == nil {
-		return nil, errors.NewAppError("file not found", nil)
+		return nil, nil, nil, errors.NewAppError("file not found", nil)
 	}
 
 	//
@@ -113,18 +255,18 @@ func (s *downloadService) DownloadAndDecryptFile(ctx context.Context, fileID goc
 	//
 	user, err := s.getUserByIsLoggedInUseCase.Execute(ctx)
 	if err != nil {
-		return nil, errors.NewAppError("failed to get logged in user", err)
+		return nil, nil, nil, errors.NewAppError("failed to get logged in user", err)
 	}
 	if user == nil {
-		return nil, errors.NewAppError("user not found", nil)
+		return nil, nil, nil, errors.NewAppError("user not found", nil)
 	}
 
 	collection, err := s.getCollectionUseCase.Execute(ctx, file.CollectionID)
 	if err != nil {
-		return nil, errors.NewAppError("failed to get collection", err)
+		return nil, nil, nil, errors.NewAppError("failed to get collection", err)
 	}
 	if collection == nil {
-		return nil, errors.NewAppError("collection not found", nil)
+		return nil, nil, nil, errors.NewAppError("collection not found", nil)
 	}
 
 	//
@@ -132,7 +274,7 @@ func (s *downloadService) DownloadAndDecryptFile(ctx context.Context, fileID goc
 	//
 	collectionKey, err := s.collectionDecryptionService.ExecuteDecryptCollectionKeyChain(ctx, user, collection, userPassword)
 	if err != nil {
-		return nil, errors.NewAppError("failed to decrypt collection key chain", err)
+		return nil, nil, nil, errors.NewAppError("failed to decrypt collection key chain", fmt.Errorf("%w: %w", ErrDecryptionFailed, err))
 	}
 	defer crypto.ClearBytes(collectionKey)
 
@@ -141,74 +283,63 @@ func (s *downloadService) DownloadAndDecryptFile(ctx context.Context, fileID goc
 	//
 	fileKey, err := s.fileDecryptionService.DecryptFileKey(ctx, file.EncryptedFileKey, collectionKey)
 	if err != nil {
-		return nil, errors.NewAppError("failed to decrypt file key", err)
+		return nil, nil, nil, errors.NewAppError("failed to decrypt file key", fmt.Errorf("%w: %w", ErrDecryptionFailed, err))
 	}
-	defer crypto.ClearBytes(fileKey)
 
 	//
 	// Step 6: Decrypt file metadata
 	//
 	decryptedMetadata, err := s.fileDecryptionService.DecryptFileMetadata(ctx, file.EncryptedMetadata, fileKey)
 	if err != nil {
-		return nil, errors.NewAppError("failed to decrypt file metadata", err)
+		crypto.ClearBytes(fileKey)
+		return nil, nil, nil, errors.NewAppError("failed to decrypt file metadata", fmt.Errorf("%w: %w", ErrDecryptionFailed, err))
 	}
 
-	//
-	// Step 7: Get presigned download URLs
-	//
-	s.logger.Debug("🌐 Getting presigned download URLs")
-	urlResponse, err := s.getPresignedDownloadURLUseCase.Execute(ctx, fileID, urlDuration)
-	if err != nil {
-		return nil, errors.NewAppError("failed to get presigned download URLs", err)
-	}
+	return file, fileKey, decryptedMetadata, nil
+}
 
-	if !urlResponse.Success {
-		return nil, errors.NewAppError("server failed to generate presigned URLs: "+urlResponse.Message, nil)
-	}
-	s.logger.Debug("✅ Successfully got presigned download URLs")
+// VerifyLocalCopy checks the decrypted content at path against the hash recorded when the file was
+// added, without downloading anything. It returns the decrypted metadata of an intact copy, and an
+// error wrapping ErrIntegrityCheckFailed if the copy is corrupted or can't be verified.
+func (s *downloadService) VerifyLocalCopy(ctx context.Context, fileID gocql.UUID, userPassword string, path string) (*DecryptedFileMetadata, error) {
+	s.logger.Debug("🔍 Verifying local copy of file",
+		zap.String("fileID", fileID.String()),
+		zap.String("path", path))
 
-	//
-	// Step 8: Download encrypted file content
-	//
-	s.logger.Debug("📥 Downloading encrypted file content")
-	downloadRequest := &filedto.DownloadRequest{
-		PresignedURL:          urlResponse.PresignedDownloadURL,
-		PresignedThumbnailURL: urlResponse.PresignedThumbnailURL,
+	if userPassword == "" {
+		return nil, errors.NewAppError("user password is required for E2EE decryption", nil)
 	}
 
-	downloadResponse, err := s.downloadFileUseCase.Execute(ctx, downloadRequest)
+	file, fileKey, decryptedMetadata, err := s.decryptFileKeyChain(ctx, fileID, userPassword)
 	if err != nil {
-		return nil, errors.NewAppError("failed to download file content", err)
+		return nil, err
 	}
-	s.logger.Debug("✅ Successfully downloaded encrypted file content")
+	defer crypto.ClearBytes(fileKey)
 
-	//
-	// Step 9: Decrypt the file content
-	//
-	s.logger.Debug("🔑 Decrypting file content")
-	decryptedData, err := s.fileDecryptionService.DecryptFileContent(ctx, downloadResponse.FileData, fileKey)
+	if file.EncryptedHash == "" {
+		return nil, errors.NewAppError("file has no recorded hash to verify the local copy against", ErrIntegrityCheckFailed)
+	}
+
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, errors.NewAppError("failed to decrypt file content", err)
+		return nil, errors.NewAppError("failed to read local copy", fmt.Errorf("%w: %w", ErrIntegrityCheckFailed, err))
 	}
-	s.logger.Debug("✅ Successfully decrypted file content")
 
-	//
-	// Step 10: Decrypt thumbnail if present
-	//
-	var thumbnailData []byte
-	if downloadResponse.ThumbnailData != nil && len(downloadResponse.ThumbnailData) > 0 {
-		s.logger.Debug("🔑 Decrypting thumbnail data")
-		thumbnailData, err = s.fileDecryptionService.DecryptFileContent(ctx, downloadResponse.ThumbnailData, fileKey)
-		if err != nil {
-			s.logger.Warn("⚠️ Failed to decrypt thumbnail, continuing without it", zap.Error(err))
-			thumbnailData = nil
-		} else {
-			s.logger.Debug("✅ Successfully decrypted thumbnail data")
-		}
+	if err := s.fileDecryptionService.VerifyFileHash(ctx, file.EncryptedHash, file.EncryptedHashAlgorithm, data, fileKey); err != nil {
+		return nil, errors.NewAppError("local copy failed integrity verification", fmt.Errorf("%w: %w", ErrIntegrityCheckFailed, err))
 	}
 
-	// Convert file metadata to the expected format
-	resultMetadata := &DecryptedFileMetadata{
+	s.logger.Debug("✅ Local copy of file is intact",
+		zap.String("fileID", fileID.String()),
+		zap.String("path", path))
+
+	return toDecryptedFileMetadata(decryptedMetadata)
+}
+
+// toDecryptedFileMetadata converts the decrypted metadata of a file to the format returned by
+// downloads, rejecting metadata which can't safely be used to name local files
+func toDecryptedFileMetadata(decryptedMetadata *dom_file.FileMetadata) (*DecryptedFileMetadata, error) {
+	metadata := &DecryptedFileMetadata{
 		Name:                   decryptedMetadata.Name,
 		MimeType:               decryptedMetadata.MimeType,
 		Size:                   decryptedMetadata.Size,
@@ -223,20 +354,58 @@ func (s *downloadService) DownloadAndDecryptFile(ctx context.Context, fileID goc
 		DecryptedThumbnailPath: decryptedMetadata.DecryptedThumbnailPath,
 		DecryptedThumbnailSize: decryptedMetadata.DecryptedThumbnailSize,
 	}
+	if err := metadata.Sanitize(); err != nil {
+		return nil, errors.NewAppError("decrypted file metadata is invalid", fmt.Errorf("%w: %w", ErrInvalidMetadata, err))
+	}
+	return metadata, nil
+}
 
-	result := &DownloadResult{
-		FileID:            fileID,
-		DecryptedData:     decryptedData,
-		DecryptedMetadata: resultMetadata,
-		ThumbnailData:     thumbnailData,
-		OriginalSize:      int64(len(decryptedData)),
-		ThumbnailSize:     int64(len(thumbnailData)),
+// getPresignedDownloadURLs returns the presigned download URLs of the file, reusing cached URLs
+// which have not expired and rate limiting requests to the cloud.
+func (s *downloadService) getPresignedDownloadURLs(ctx context.Context, fileID gocql.UUID, urlDuration time.Duration) (*dom_filedto.GetPresignedDownloadURLResponse, error) {
+	if cached := s.presignedURLCache.get(fileID); cached != nil {
+		s.logger.Debug("♻️ Reusing cached presigned download URLs", zap.String("fileID", fileID.String()))
+		return cached, nil
 	}
 
-	s.logger.Info("✅ Successfully completed E2EE file download and decryption",
-		zap.String("fileID", fileID.String()),
-		zap.String("fileName", resultMetadata.Name),
-		zap.Int64("originalSize", result.OriginalSize))
+	if err := s.presignLimiter.wait(ctx); err != nil {
+		return nil, errors.NewAppError("failed waiting to request presigned download URLs", err)
+	}
 
-	return result, nil
+	s.logger.Debug("🌐 Getting presigned download URLs")
+	urlResponse, err := s.getPresignedDownloadURLUseCase.Execute(ctx, fileID, urlDuration)
+	if err != nil {
+		return nil, errors.NewAppError("failed to get presigned download URLs", err)
+	}
+
+	if !urlResponse.Success {
+		return nil, errors.NewAppError("server failed to generate presigned URLs: "+urlResponse.Message, nil)
+	}
+	s.logger.Debug("✅ Successfully got presigned download URLs")
+
+	s.presignedURLCache.put(fileID, urlResponse)
+	return urlResponse, nil
+}
+
+// getMemoryBudget returns the memory budget shared by every download, falling back to the default
+// budget if the configuration can't be read
+func (s *downloadService) getMemoryBudget(ctx context.Context) *memoryBudget {
+	s.memoryBudgetOnce.Do(func() {
+		size, err := s.configService.GetDownloadMemoryBudget(ctx)
+		if err != nil {
+			s.logger.Warn("⚠️ Failed to get download memory budget, using the default", zap.Error(err))
+			size = config.DefaultDownloadMemoryBudget
+		}
+		s.memoryBudget = newMemoryBudget(size)
+	})
+	return s.memoryBudget
+}
+
+// downloadMemorySize estimates the bytes a download buffers at once, the encrypted content and the
+// content decrypted from it
+func downloadMemorySize(file *dom_file.File) int64 {
+	if file.FileSize > 0 {
+		return file.EncryptedFileSize + file.FileSize
+	}
+	return 2 * file.EncryptedFileSize
 }
@@ -0,0 +1,20 @@
+// internal/service/filedownload/errors.go
+package filedownload
+
+import "errors"
+
+// ErrDecryptionFailed is wrapped by every download error caused by a failure to decrypt the
+// E2EE key chain, file metadata or file content, which usually means the password was incorrect.
+var ErrDecryptionFailed = errors.New("decryption failed")
+
+// ErrIntegrityCheckFailed is wrapped by download errors where the decrypted file content does not
+// match the hash recorded when the file was added, meaning it was corrupted or tampered with.
+var ErrIntegrityCheckFailed = errors.New("integrity check failed")
+
+// ErrInvalidMetadata is wrapped by download errors where the decrypted file metadata is malformed,
+// such as a name which would escape the directory the file is saved to.
+var ErrInvalidMetadata = errors.New("invalid file metadata")
+
+// ErrInvalidRange is wrapped by range download errors where the requested byte range is negative,
+// empty or starts past the end of the file.
+var ErrInvalidRange = errors.New("invalid byte range")
@@ -0,0 +1,85 @@
+// internal/service/filedownload/memory.go
+package filedownload
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// memoryBudget is a weighted semaphore bounding the bytes buffered by concurrent downloads. Waiting
+// downloads are admitted in order, so a large file waiting for capacity isn't starved by smaller
+// files arriving after it.
+type memoryBudget struct {
+	mu      sync.Mutex
+	size    int64
+	used    int64
+	waiters list.List
+}
+
+// memoryBudgetWaiter is a download waiting for its share of the budget
+type memoryBudgetWaiter struct {
+	bytes int64
+	ready chan struct{}
+}
+
+func newMemoryBudget(size int64) *memoryBudget {
+	return &memoryBudget{
+		size: size,
+	}
+}
+
+// acquire blocks until the bytes fit in the budget or the context is done. Requests larger than the
+// whole budget are reduced to it, so they run once nothing else is in flight. The returned bytes
+// must be passed to release.
+func (b *memoryBudget) acquire(ctx context.Context, bytes int64) (int64, error) {
+	bytes = min(max(bytes, 0), b.size)
+
+	b.mu.Lock()
+	if b.waiters.Len() == 0 && b.size-b.used >= bytes {
+		b.used += bytes
+		b.mu.Unlock()
+		return bytes, nil
+	}
+	waiter := &memoryBudgetWaiter{bytes: bytes, ready: make(chan struct{})}
+	element := b.waiters.PushBack(waiter)
+	b.mu.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return bytes, nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		select {
+		case <-waiter.ready:
+			// Admitted while the context was done, give the bytes back.
+			b.used -= bytes
+		default:
+			b.waiters.Remove(element)
+		}
+		b.admitWaiters()
+		b.mu.Unlock()
+		return 0, ctx.Err()
+	}
+}
+
+// release returns bytes acquired from the budget
+func (b *memoryBudget) release(bytes int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.used -= bytes
+	b.admitWaiters()
+}
+
+// admitWaiters admits waiting downloads in order while they fit in the budget, the lock must be held
+func (b *memoryBudget) admitWaiters() {
+	for element := b.waiters.Front(); element != nil; element = b.waiters.Front() {
+		waiter := element.Value.(*memoryBudgetWaiter)
+		if b.size-b.used < waiter.bytes {
+			return
+		}
+		b.used += waiter.bytes
+		b.waiters.Remove(element)
+		close(waiter.ready)
+	}
+}
@@ -0,0 +1,74 @@
+package filedownload
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryBudgetWaitsForCapacity(t *testing.T) {
+	budget := newMemoryBudget(100)
+	ctx := context.Background()
+
+	first, err := budget.acquire(ctx, 60)
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+
+	acquired := make(chan int64)
+	go func() {
+		second, err := budget.acquire(ctx, 60)
+		if err != nil {
+			t.Errorf("acquire() error = %v", err)
+		}
+		acquired <- second
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("acquire() returned before the budget had capacity")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	budget.release(first)
+	select {
+	case second := <-acquired:
+		if second != 60 {
+			t.Errorf("acquire() = %v, want 60", second)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("acquire() did not return after the budget was released")
+	}
+}
+
+func TestMemoryBudgetCapsLargeRequests(t *testing.T) {
+	budget := newMemoryBudget(100)
+
+	reserved, err := budget.acquire(context.Background(), 1000)
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+	if reserved != 100 {
+		t.Errorf("acquire() = %v, want 100", reserved)
+	}
+}
+
+func TestMemoryBudgetCancelledWaiter(t *testing.T) {
+	budget := newMemoryBudget(100)
+
+	first, err := budget.acquire(context.Background(), 100)
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := budget.acquire(ctx, 50); err == nil {
+		t.Fatal("acquire() error = nil, want the context error")
+	}
+
+	budget.release(first)
+	if _, err := budget.acquire(context.Background(), 100); err != nil {
+		t.Fatalf("acquire() error = %v after a cancelled waiter", err)
+	}
+}
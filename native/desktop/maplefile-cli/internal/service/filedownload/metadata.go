@@ -0,0 +1,65 @@
+// internal/service/filedownload/metadata.go
+package filedownload
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	// maxFileNameLength is the longest file name most filesystems accept, in bytes
+	maxFileNameLength = 255
+	// maxFileExtensionLength bounds the length of an extension, including the leading dot
+	maxFileExtensionLength = 16
+)
+
+// Sanitize checks the fields of decrypted metadata which are used to derive local file names, since
+// anyone holding the file key could have crafted them. It rejects names containing path separators
+// or null bytes and normalizes the extension to start with a dot.
+func (m *DecryptedFileMetadata) Sanitize() error {
+	if err := validateFileName(m.Name); err != nil {
+		return err
+	}
+
+	extension, err := normalizeFileExtension(m.FileExtension)
+	if err != nil {
+		return err
+	}
+	m.FileExtension = extension
+	return nil
+}
+
+// validateFileName returns an error if the name is not a single path element, an empty name is valid
+func validateFileName(name string) error {
+	if len(name) > maxFileNameLength {
+		return fmt.Errorf("file name is longer than %d bytes", maxFileNameLength)
+	}
+	if strings.ContainsAny(name, "/\\\x00") {
+		return fmt.Errorf("file name %q contains a path separator or null byte", name)
+	}
+	if name == "." || name == ".." {
+		return fmt.Errorf("file name %q is not a valid file name", name)
+	}
+	return nil
+}
+
+// normalizeFileExtension trims the extension and adds the leading dot if it is missing, an empty
+// extension stays empty
+func normalizeFileExtension(extension string) (string, error) {
+	extension = strings.TrimSpace(extension)
+	if extension == "" {
+		return "", nil
+	}
+	if !strings.HasPrefix(extension, ".") {
+		extension = "." + extension
+	}
+
+	if len(extension) < 2 || len(extension) > maxFileExtensionLength {
+		return "", fmt.Errorf("file extension %q must be 1 to %d characters long", extension, maxFileExtensionLength-1)
+	}
+	if strings.ContainsAny(extension, "/\\\x00") || strings.IndexFunc(extension, unicode.IsSpace) >= 0 {
+		return "", fmt.Errorf("file extension %q contains a path separator, space or null byte", extension)
+	}
+	return extension, nil
+}
@@ -0,0 +1,40 @@
+package filedownload
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecryptedFileMetadataSanitize(t *testing.T) {
+	tests := []struct {
+		name          string
+		metadata      DecryptedFileMetadata
+		wantExtension string
+		wantErr       bool
+	}{
+		{name: "valid", metadata: DecryptedFileMetadata{Name: "report.pdf", FileExtension: ".pdf"}, wantExtension: ".pdf"},
+		{name: "extension without dot", metadata: DecryptedFileMetadata{Name: "report.pdf", FileExtension: " pdf "}, wantExtension: ".pdf"},
+		{name: "no extension", metadata: DecryptedFileMetadata{Name: "README"}, wantExtension: ""},
+		{name: "name with slash", metadata: DecryptedFileMetadata{Name: "../../.bashrc"}, wantErr: true},
+		{name: "name with backslash", metadata: DecryptedFileMetadata{Name: `..\evil.exe`}, wantErr: true},
+		{name: "name with null byte", metadata: DecryptedFileMetadata{Name: "report.pdf\x00.exe"}, wantErr: true},
+		{name: "parent directory name", metadata: DecryptedFileMetadata{Name: ".."}, wantErr: true},
+		{name: "name too long", metadata: DecryptedFileMetadata{Name: strings.Repeat("a", maxFileNameLength+1)}, wantErr: true},
+		{name: "extension with slash", metadata: DecryptedFileMetadata{Name: "a", FileExtension: "./../x"}, wantErr: true},
+		{name: "extension with space", metadata: DecryptedFileMetadata{Name: "a", FileExtension: ".tar gz"}, wantErr: true},
+		{name: "extension too long", metadata: DecryptedFileMetadata{Name: "a", FileExtension: ".abcdefghijklmnopq"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := tt.metadata
+			err := metadata.Sanitize()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Sanitize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && metadata.FileExtension != tt.wantExtension {
+				t.Errorf("FileExtension = %q, want %q", metadata.FileExtension, tt.wantExtension)
+			}
+		})
+	}
+}
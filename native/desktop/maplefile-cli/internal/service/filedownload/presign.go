@@ -0,0 +1,157 @@
+// internal/service/filedownload/presign.go
+package filedownload
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
+)
+
+const (
+	// presignRequestInterval is the minimum time between two presigned URL requests to the cloud,
+	// shared by every concurrent download so raising the onload concurrency cannot flood the backend.
+	presignRequestInterval = 100 * time.Millisecond
+
+	// presignExpiryMargin is subtracted from the expiry of a cached presigned URL so a download is
+	// never started with a URL which is about to expire.
+	presignExpiryMargin = 1 * time.Minute
+)
+
+// presignedURLCache caches presigned download URLs by file ID until shortly before they expire
+type presignedURLCache struct {
+	mu      sync.Mutex
+	entries map[gocql.UUID]*filedto.GetPresignedDownloadURLResponse
+}
+
+func newPresignedURLCache() *presignedURLCache {
+	return &presignedURLCache{
+		entries: make(map[gocql.UUID]*filedto.GetPresignedDownloadURLResponse),
+	}
+}
+
+// get returns the cached presigned URLs for the file, or nil if none are cached or they expire soon
+func (c *presignedURLCache) get(fileID gocql.UUID) *filedto.GetPresignedDownloadURLResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	response, ok := c.entries[fileID]
+	if !ok {
+		return nil
+	}
+	if time.Now().Add(presignExpiryMargin).After(response.DownloadURLExpirationTime) {
+		delete(c.entries, fileID)
+		return nil
+	}
+	return response
+}
+
+// put caches the presigned URLs for the file if they are valid long enough to be reused
+func (c *presignedURLCache) put(fileID gocql.UUID, response *filedto.GetPresignedDownloadURLResponse) {
+	if response == nil || !response.Success {
+		return
+	}
+	if time.Now().Add(presignExpiryMargin).After(response.DownloadURLExpirationTime) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[fileID] = response
+}
+
+// requestLimiter spaces out requests so they are made at most once per interval
+type requestLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRequestLimiter(interval time.Duration) *requestLimiter {
+	return &requestLimiter{
+		interval: interval,
+	}
+}
+
+// wait blocks until the caller is allowed to make its request or the context is done
+func (l *requestLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// PrefetchPresignedDownloadURLs presigns the files which have no cached URLs yet with one request
+// per `filedto.MaxDownloadURLBatchSize` files and caches the URLs, so downloading the files
+// afterwards doesn't need a round-trip per file. Files the cloud couldn't presign are left out of
+// the cache and presigned on their own when downloaded, which reports why they failed.
+func (s *downloadService) PrefetchPresignedDownloadURLs(ctx context.Context, fileIDs []gocql.UUID, urlDuration time.Duration) error {
+	pending := make([]gocql.UUID, 0, len(fileIDs))
+	seen := make(map[gocql.UUID]bool, len(fileIDs))
+	for _, fileID := range fileIDs {
+		if seen[fileID] || s.presignedURLCache.get(fileID) != nil {
+			continue
+		}
+		seen[fileID] = true
+		pending = append(pending, fileID)
+	}
+
+	for start := 0; start < len(pending); start += filedto.MaxDownloadURLBatchSize {
+		end := min(start+filedto.MaxDownloadURLBatchSize, len(pending))
+		batch := pending[start:end]
+
+		if err := s.presignLimiter.wait(ctx); err != nil {
+			return errors.NewAppError("failed waiting to request presigned download URLs", err)
+		}
+
+		s.logger.Debug("🌐 Getting presigned download URLs for a batch of files",
+			zap.Int("fileCount", len(batch)))
+		response, err := s.getPresignedDownloadURLsUseCase.Execute(ctx, batch, urlDuration)
+		if err != nil {
+			return errors.NewAppError("failed to get presigned download URLs", err)
+		}
+		if !response.Success {
+			return errors.NewAppError("server failed to generate presigned URLs: "+response.Message, nil)
+		}
+
+		for _, fileID := range batch {
+			downloadURL, ok := response.DownloadURLs[fileID]
+			if !ok {
+				s.logger.Debug("ℹ️ File was not presigned in the batch",
+					zap.String("fileID", fileID.String()),
+					zap.String("reason", response.Errors[fileID]))
+				continue
+			}
+			s.presignedURLCache.put(fileID, &filedto.GetPresignedDownloadURLResponse{
+				PresignedDownloadURL:      downloadURL,
+				PresignedThumbnailURL:     response.ThumbnailURLs[fileID],
+				DownloadURLExpirationTime: response.DownloadURLExpirationTime,
+				Success:                   true,
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package filedownload
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
+	uc_filedto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/filedto"
+)
+
+// fakePresignURLs presigns every requested file except the ones it is told to reject
+type fakePresignURLs struct {
+	uc_filedto.GetPresignedDownloadURLsUseCase
+	rejected map[gocql.UUID]bool
+	batches  [][]gocql.UUID
+}
+
+func (f *fakePresignURLs) Execute(ctx context.Context, fileIDs []gocql.UUID, urlDuration time.Duration) (*filedto.GetPresignedDownloadURLsResponse, error) {
+	f.batches = append(f.batches, fileIDs)
+	response := &filedto.GetPresignedDownloadURLsResponse{
+		DownloadURLs:              make(map[gocql.UUID]string),
+		Errors:                    make(map[gocql.UUID]string),
+		DownloadURLExpirationTime: time.Now().Add(urlDuration),
+		Success:                   true,
+	}
+	for _, fileID := range fileIDs {
+		if f.rejected[fileID] {
+			response.Errors[fileID] = "File not found"
+			continue
+		}
+		response.DownloadURLs[fileID] = "https://storage.example.com/" + fileID.String()
+	}
+	return response, nil
+}
+
+func TestPrefetchPresignedDownloadURLs(t *testing.T) {
+	fileIDs := make([]gocql.UUID, 2*filedto.MaxDownloadURLBatchSize+10)
+	for i := range fileIDs {
+		fileIDs[i] = gocql.TimeUUID()
+	}
+	cachedID, rejectedID := fileIDs[0], fileIDs[1]
+
+	presign := &fakePresignURLs{rejected: map[gocql.UUID]bool{rejectedID: true}}
+	s := &downloadService{
+		logger:                          zap.NewNop(),
+		getPresignedDownloadURLsUseCase: presign,
+		presignedURLCache:               newPresignedURLCache(),
+		presignLimiter:                  newRequestLimiter(time.Millisecond),
+	}
+	cached := &filedto.GetPresignedDownloadURLResponse{
+		PresignedDownloadURL:      "cached",
+		DownloadURLExpirationTime: time.Now().Add(time.Hour),
+		Success:                   true,
+	}
+	s.presignedURLCache.put(cachedID, cached)
+
+	if err := s.PrefetchPresignedDownloadURLs(context.Background(), append(fileIDs, fileIDs[2]), time.Hour); err != nil {
+		t.Fatalf("PrefetchPresignedDownloadURLs() error = %v", err)
+	}
+
+	// The already cached and duplicated files are not presigned again
+	requested := 0
+	for _, batch := range presign.batches {
+		if len(batch) > filedto.MaxDownloadURLBatchSize {
+			t.Errorf("requested %d files at once, want at most %d", len(batch), filedto.MaxDownloadURLBatchSize)
+		}
+		requested += len(batch)
+	}
+	if len(presign.batches) != 3 || requested != len(fileIDs)-1 {
+		t.Errorf("requested %d files in %d batches, want %d files in 3 batches", requested, len(presign.batches), len(fileIDs)-1)
+	}
+
+	if got := s.presignedURLCache.get(cachedID); got != cached {
+		t.Errorf("cached URLs of %s were replaced", cachedID)
+	}
+	if got := s.presignedURLCache.get(rejectedID); got != nil {
+		t.Errorf("file rejected by the cloud was cached with %q", got.PresignedDownloadURL)
+	}
+	for _, fileID := range fileIDs[2:] {
+		got := s.presignedURLCache.get(fileID)
+		if got == nil || got.PresignedDownloadURL != "https://storage.example.com/"+fileID.String() {
+			t.Fatalf("presigned URLs of %s were not cached", fileID)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// internal/service/filedownload/range.go
+package filedownload
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+)
+
+// rangeURLDuration is how long the presigned URLs requested for a range download stay valid
+const rangeURLDuration = 15 * time.Minute
+
+// RangeResult represents a decrypted byte range of a file
+type RangeResult struct {
+	FileID gocql.UUID `json:"file_id"`
+	Offset int64      `json:"offset"`
+	// Length is the number of bytes returned, shorter than requested when the range goes past the
+	// end of the file.
+	Length            int64                  `json:"length"`
+	FileSize          int64                  `json:"file_size"`
+	Data              []byte                 `json:"data"`
+	DecryptedMetadata *DecryptedFileMetadata `json:"decrypted_metadata"`
+}
+
+// DownloadRange returns the decrypted bytes of a file from offset, at most length of them, for
+// previews such as the first page of a PDF or the header of a video.
+//
+// Content encrypted in frames is fetched with HTTP range requests, the header first and then only
+// the frames holding the range. Each frame is authenticated on its own and bound to the header and
+// its position, so the range is verified without the rest of the file. Files uploaded before the
+// framed format are a single encrypted message, which is downloaded, decrypted and verified as a
+// whole before the range is cut out of it.
+func (s *downloadService) DownloadRange(ctx context.Context, fileID gocql.UUID, offset int64, length int64, userPassword string) (*RangeResult, error) {
+	s.logger.Debug("👇 Starting E2EE file range download",
+		zap.String("fileID", fileID.String()),
+		zap.Int64("offset", offset),
+		zap.Int64("length", length))
+
+	//
+	// Step 1: Validate inputs
+	//
+	if userPassword == "" {
+		return nil, errors.NewAppError("user password is required for E2EE decryption", nil)
+	}
+	if _, _, err := resolveRange(offset, length, 1<<62); err != nil {
+		return nil, err
+	}
+
+	//
+	// Step 2: Decrypt the E2EE key chain down to the file key, and the file metadata
+	//
+	file, fileKey, decryptedMetadata, err := s.decryptFileKeyChain(ctx, fileID, userPassword)
+	if err != nil {
+		return nil, err
+	}
+	defer crypto.ClearBytes(fileKey)
+
+	if file.FileSize > 0 {
+		if _, _, err := resolveRange(offset, length, file.FileSize); err != nil {
+			return nil, err
+		}
+	}
+
+	resultMetadata, err := toDecryptedFileMetadata(decryptedMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	//
+	// Step 3: Get presigned download URLs
+	//
+	urlResponse, err := s.getPresignedDownloadURLs(ctx, fileID, rangeURLDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	//
+	// Step 4: Download the header to find the frames of the range
+	//
+	headerRange, err := s.downloadFileRangeUseCase.Execute(ctx, urlResponse.PresignedDownloadURL, 0, crypto.FramedHeaderSize)
+	if err != nil {
+		return nil, errors.NewAppError("failed to download file header", err)
+	}
+
+	if int64(len(headerRange.Data)) == headerRange.TotalSize {
+		// The server returned the whole content, either because it ignored the range or because
+		// the file is tiny, so decrypt and verify it as a whole
+		decryptedData, err := s.fileDecryptionService.DecryptFileContent(ctx, headerRange.Data, fileKey)
+		if err != nil {
+			return nil, errors.NewAppError("failed to decrypt file content", fmt.Errorf("%w: %w", ErrDecryptionFailed, err))
+		}
+		if file.EncryptedHash != "" {
+			if err := s.fileDecryptionService.VerifyFileHash(ctx, file.EncryptedHash, file.EncryptedHashAlgorithm, decryptedData, fileKey); err != nil {
+				crypto.ClearBytes(decryptedData)
+				return nil, errors.NewAppError("downloaded file failed integrity verification", fmt.Errorf("%w: %w", ErrIntegrityCheckFailed, err))
+			}
+		}
+		return s.cutRange(fileID, offset, length, decryptedData, resultMetadata)
+	}
+
+	if !crypto.IsFramed(headerRange.Data) {
+		s.logger.Debug("ℹ️ File content is not framed, downloading the whole file",
+			zap.String("fileID", fileID.String()))
+
+		result, err := s.DownloadAndDecryptFile(ctx, fileID, userPassword, rangeURLDuration)
+		if err != nil {
+			return nil, err
+		}
+		crypto.ClearBytes(result.ThumbnailData)
+		return s.cutRange(fileID, offset, length, result.DecryptedData, result.DecryptedMetadata)
+	}
+
+	header, err := crypto.ParseFramedHeader(headerRange.Data)
+	if err != nil {
+		return nil, errors.NewAppError("failed to read file header", fmt.Errorf("%w: %w", ErrDecryptionFailed, err))
+	}
+	if header.EncryptedSize() != headerRange.TotalSize {
+		return nil, errors.NewAppError("downloaded file failed integrity verification",
+			fmt.Errorf("%w: file is %d bytes, its header expects %d", ErrIntegrityCheckFailed, headerRange.TotalSize, header.EncryptedSize()))
+	}
+
+	//
+	// Step 5: Download and decrypt the frames holding the range
+	//
+	start, end, err := resolveRange(offset, length, header.PlaintextSize)
+	if err != nil {
+		return nil, err
+	}
+	first, last := header.FrameRange(start, end)
+	framesStart, framesEnd := header.EncryptedFrameOffsets(first, last)
+
+	framesRange, err := s.downloadFileRangeUseCase.Execute(ctx, urlResponse.PresignedDownloadURL, framesStart, framesEnd)
+	if err != nil {
+		return nil, errors.NewAppError("failed to download file range", err)
+	}
+	if framesRange.Start != framesStart || int64(len(framesRange.Data)) != framesEnd-framesStart {
+		return nil, errors.NewAppError("downloaded file range does not match the requested range",
+			fmt.Errorf("%w: got %d bytes from %d, requested [%d, %d)", ErrIntegrityCheckFailed, len(framesRange.Data), framesRange.Start, framesStart, framesEnd))
+	}
+
+	frames, err := crypto.DecryptFrames(header, first, framesRange.Data, fileKey)
+	if err != nil {
+		return nil, errors.NewAppError("failed to decrypt file range", fmt.Errorf("%w: %w", ErrDecryptionFailed, err))
+	}
+
+	//
+	// Step 6: Cut the range out of the decrypted frames
+	//
+	framesOffset := first * header.FrameSize
+	data := make([]byte, end-start)
+	copy(data, frames[start-framesOffset:end-framesOffset])
+	crypto.ClearBytes(frames)
+
+	s.logger.Info("✅ Successfully downloaded file range",
+		zap.String("fileID", fileID.String()),
+		zap.Int64("offset", start),
+		zap.Int64("length", end-start),
+		zap.Int64("fileSize", header.PlaintextSize),
+		zap.Int64("frames", last-first+1))
+
+	return &RangeResult{
+		FileID:            fileID,
+		Offset:            start,
+		Length:            end - start,
+		FileSize:          header.PlaintextSize,
+		Data:              data,
+		DecryptedMetadata: resultMetadata,
+	}, nil
+}
+
+// cutRange returns the range of the whole decrypted content of a file, clearing the content
+func (s *downloadService) cutRange(fileID gocql.UUID, offset int64, length int64, decryptedData []byte, metadata *DecryptedFileMetadata) (*RangeResult, error) {
+	defer crypto.ClearBytes(decryptedData)
+
+	fileSize := int64(len(decryptedData))
+	start, end, err := resolveRange(offset, length, fileSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy the range so the rest of the decrypted file isn't kept in memory by the caller.
+	data := make([]byte, end-start)
+	copy(data, decryptedData[start:end])
+
+	s.logger.Info("✅ Successfully downloaded file range from the whole file",
+		zap.String("fileID", fileID.String()),
+		zap.Int64("offset", start),
+		zap.Int64("length", end-start),
+		zap.Int64("fileSize", fileSize))
+
+	return &RangeResult{
+		FileID:            fileID,
+		Offset:            start,
+		Length:            end - start,
+		FileSize:          fileSize,
+		Data:              data,
+		DecryptedMetadata: metadata,
+	}, nil
+}
+
+// resolveRange returns the start and end of the range of length bytes from offset in a file of the
+// given size. A range going past the end of the file is shortened, while a range starting past it
+// is invalid.
+func resolveRange(offset int64, length int64, size int64) (int64, int64, error) {
+	if offset < 0 {
+		return 0, 0, errors.NewAppError("invalid byte range", fmt.Errorf("%w: offset %d is negative", ErrInvalidRange, offset))
+	}
+	if length <= 0 {
+		return 0, 0, errors.NewAppError("invalid byte range", fmt.Errorf("%w: length %d must be positive", ErrInvalidRange, length))
+	}
+	if offset >= size {
+		return 0, 0, errors.NewAppError("invalid byte range", fmt.Errorf("%w: offset %d is past the end of the %d byte file", ErrInvalidRange, offset, size))
+	}
+	if length > size-offset {
+		return offset, size, nil
+	}
+	return offset, offset + length, nil
+}
@@ -0,0 +1,44 @@
+package filedownload
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolveRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		offset    int64
+		length    int64
+		size      int64
+		wantStart int64
+		wantEnd   int64
+		wantErr   bool
+	}{
+		{name: "inside the file", offset: 10, length: 20, size: 100, wantStart: 10, wantEnd: 30},
+		{name: "whole file", offset: 0, length: 100, size: 100, wantStart: 0, wantEnd: 100},
+		{name: "past the end is shortened", offset: 90, length: 20, size: 100, wantStart: 90, wantEnd: 100},
+		{name: "huge length", offset: 1, length: 1<<63 - 1, size: 100, wantStart: 1, wantEnd: 100},
+		{name: "starts at the end", offset: 100, length: 1, size: 100, wantErr: true},
+		{name: "negative offset", offset: -1, length: 1, size: 100, wantErr: true},
+		{name: "empty range", offset: 0, length: 0, size: 100, wantErr: true},
+		{name: "empty file", offset: 0, length: 1, size: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := resolveRange(tt.offset, tt.length, tt.size)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRange) {
+					t.Errorf("resolveRange() error = %v, want ErrInvalidRange", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveRange() error = %v", err)
+			}
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("resolveRange() = [%d, %d), want [%d, %d)", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
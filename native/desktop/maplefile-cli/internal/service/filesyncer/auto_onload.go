@@ -0,0 +1,195 @@
+// internal/service/filesyncer/auto_onload.go
+package filesyncer
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
+)
+
+// AutoOnloadInput represents the input for onloading the files added by a sync
+type AutoOnloadInput struct {
+	// FileIDs are the files added by the sync.
+	FileIDs []gocql.UUID `json:"file_ids"`
+	// Policy overrides the configured auto onload policy when set.
+	Policy       *config.AutoOnloadPolicy `json:"policy,omitempty"`
+	UserPassword string                   `json:"user_password"`
+}
+
+// AutoOnloadOutput represents the result of onloading the files added by a sync
+type AutoOnloadOutput struct {
+	Mode string `json:"mode"`
+	// Batch is nil when no file matched the policy.
+	Batch *BatchOnloadOutput `json:"batch,omitempty"`
+	// SkippedTooLarge counts the matching files larger than the maximum file size of the policy.
+	SkippedTooLarge int `json:"skipped_too_large"`
+	// SkippedDiskSpace counts the matching files which would not leave enough free disk space.
+	SkippedDiskSpace int `json:"skipped_disk_space"`
+}
+
+// AutoOnloadService defines the interface for onloading newly synced files according to the auto onload policy
+type AutoOnloadService interface {
+	OnloadAfterSync(ctx context.Context, input *AutoOnloadInput) (*AutoOnloadOutput, error)
+}
+
+// autoOnloadService implements the AutoOnloadService interface
+type autoOnloadService struct {
+	logger               *zap.Logger
+	configService        config.ConfigService
+	getFilesByIDsUseCase uc_file.GetFilesByIDsUseCase
+	batchOnloadService   BatchOnloadService
+}
+
+// NewAutoOnloadService creates a new service for onloading newly synced files
+func NewAutoOnloadService(
+	logger *zap.Logger,
+	configService config.ConfigService,
+	getFilesByIDsUseCase uc_file.GetFilesByIDsUseCase,
+	batchOnloadService BatchOnloadService,
+) AutoOnloadService {
+	logger = logger.Named("AutoOnloadService")
+	return &autoOnloadService{
+		logger:               logger,
+		configService:        configService,
+		getFilesByIDsUseCase: getFilesByIDsUseCase,
+		batchOnloadService:   batchOnloadService,
+	}
+}
+
+// OnloadAfterSync onloads the files added by a sync which match the auto onload policy. Files larger
+// than the maximum file size of the policy are skipped, and so are files which would leave less than
+// the minimum free disk space, smallest files first so as many files as possible are onloaded.
+func (s *autoOnloadService) OnloadAfterSync(ctx context.Context, input *AutoOnloadInput) (*AutoOnloadOutput, error) {
+	//
+	// STEP 1: Validate inputs and resolve the policy
+	//
+	if input == nil {
+		s.logger.Error("❌ input is required")
+		return nil, errors.NewAppError("input is required", ErrInvalidInput)
+	}
+
+	policy := input.Policy
+	if policy == nil {
+		configured, err := s.configService.GetAutoOnloadPolicy(ctx)
+		if err != nil {
+			return nil, errors.NewAppError("failed to get auto onload policy", err)
+		}
+		policy = configured
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, errors.NewAppError("invalid auto onload policy", fmt.Errorf("%w: %w", ErrInvalidInput, err))
+	}
+
+	output := &AutoOnloadOutput{Mode: policy.Mode}
+	if policy.Mode == config.AutoOnloadNever || len(input.FileIDs) == 0 {
+		return output, nil
+	}
+	if input.UserPassword == "" {
+		s.logger.Error("❌ user password is required for E2EE operations")
+		return nil, errors.NewAppError("user password is required for E2EE operations", ErrInvalidInput)
+	}
+
+	//
+	// STEP 2: Select the cloud-only files matching the policy
+	//
+	files, err := s.getFilesByIDsUseCase.Execute(ctx, input.FileIDs)
+	if err != nil {
+		s.logger.Error("❌ failed to get synced files", zap.Error(err))
+		return nil, errors.NewAppError("failed to get synced files", err)
+	}
+
+	matching := make([]*dom_file.File, 0, len(files))
+	for _, file := range files {
+		if file.SyncStatus != dom_file.SyncStatusCloudOnly {
+			continue
+		}
+		if policy.Mode == config.AutoOnloadByCollection && !slices.Contains(policy.CollectionIDs, file.CollectionID.String()) {
+			continue
+		}
+		if policy.MaxFileSize > 0 && onloadSize(file) > policy.MaxFileSize {
+			output.SkippedTooLarge++
+			continue
+		}
+		matching = append(matching, file)
+	}
+
+	//
+	// STEP 3: Keep the files which fit on the disk
+	//
+	slices.SortStableFunc(matching, func(a, b *dom_file.File) int {
+		return cmp.Compare(onloadSize(a), onloadSize(b))
+	})
+
+	fileIDs := make([]gocql.UUID, 0, len(matching))
+	budget, known := s.diskSpaceBudget(ctx)
+	for _, file := range matching {
+		if known {
+			if onloadSize(file) > budget {
+				output.SkippedDiskSpace++
+				continue
+			}
+			budget -= onloadSize(file)
+		}
+		fileIDs = append(fileIDs, file.ID)
+	}
+
+	if output.SkippedTooLarge > 0 || output.SkippedDiskSpace > 0 {
+		s.logger.Warn("⚠️ Skipped files during auto onload",
+			zap.Int("skippedTooLarge", output.SkippedTooLarge),
+			zap.Int("skippedDiskSpace", output.SkippedDiskSpace))
+	}
+	if len(fileIDs) == 0 {
+		return output, nil
+	}
+
+	//
+	// STEP 4: Onload the files
+	//
+	s.logger.Info("🔄 Auto onloading synced files",
+		zap.String("mode", policy.Mode),
+		zap.Int("fileCount", len(fileIDs)))
+
+	output.Batch, err = s.batchOnloadService.OnloadBatch(ctx, &BatchOnloadInput{
+		FileIDs:      fileIDs,
+		UserPassword: input.UserPassword,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// diskSpaceBudget returns the bytes which can be onloaded while leaving the minimum free disk space,
+// and false if the free disk space can't be determined on this platform.
+func (s *autoOnloadService) diskSpaceBudget(ctx context.Context) (int64, bool) {
+	appDataDir, err := s.configService.GetAppDataDirPath(ctx)
+	if err != nil {
+		s.logger.Warn("⚠️ Failed to get app data directory, not checking disk space", zap.Error(err))
+		return 0, false
+	}
+	available, ok := availableDiskSpace(appDataDir)
+	if !ok {
+		s.logger.Warn("⚠️ Failed to get free disk space, not checking disk space",
+			zap.String("path", appDataDir))
+		return 0, false
+	}
+	return max(available-config.AutoOnloadMinFreeDiskSpace, 0), true
+}
+
+// onloadSize returns the disk space onloading the file needs, falling back to the encrypted size for
+// files whose decrypted size is unknown.
+func onloadSize(file *dom_file.File) int64 {
+	if file.FileSize > 0 {
+		return file.FileSize
+	}
+	return file.EncryptedFileSize
+}
@@ -0,0 +1,209 @@
+// internal/service/filesyncer/batch_onload.go
+package filesyncer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	svc_filedownload "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filedownload"
+	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
+)
+
+// BatchOnloadInput represents the input for onloading many cloud-only files at once
+type BatchOnloadInput struct {
+	// FileIDs are the files to onload. Ignored if `CollectionID` is set.
+	FileIDs []gocql.UUID `json:"file_ids,omitempty"`
+	// CollectionID onloads every cloud-only file of the collection.
+	CollectionID *gocql.UUID `json:"collection_id,omitempty"`
+	UserPassword string      `json:"user_password"`
+	// Concurrency is the number of files onloaded in parallel, the configured value is used if zero.
+	Concurrency int `json:"concurrency,omitempty"`
+}
+
+// BatchOnloadResult represents the outcome of onloading a single file of a batch
+type BatchOnloadResult struct {
+	FileID gocql.UUID    `json:"file_id"`
+	Output *OnloadOutput `json:"output,omitempty"`
+	Error  error         `json:"-"`
+}
+
+// BatchOnloadOutput represents the result of onloading many cloud-only files
+type BatchOnloadOutput struct {
+	Results      []*BatchOnloadResult `json:"results"`
+	Concurrency  int                  `json:"concurrency"`
+	SuccessCount int                  `json:"success_count"`
+	FailureCount int                  `json:"failure_count"`
+}
+
+// BatchOnloadService defines the interface for onloading many cloud-only files concurrently
+type BatchOnloadService interface {
+	OnloadBatch(ctx context.Context, input *BatchOnloadInput) (*BatchOnloadOutput, error)
+}
+
+// batchOnloadService implements the BatchOnloadService interface
+type batchOnloadService struct {
+	logger                       *zap.Logger
+	configService                config.ConfigService
+	listFilesByCollectionUseCase uc_file.ListFilesByCollectionUseCase
+	onloadService                OnloadService
+	downloadService              svc_filedownload.DownloadService
+}
+
+// NewBatchOnloadService creates a new service for onloading many cloud-only files concurrently
+func NewBatchOnloadService(
+	logger *zap.Logger,
+	configService config.ConfigService,
+	listFilesByCollectionUseCase uc_file.ListFilesByCollectionUseCase,
+	onloadService OnloadService,
+	downloadService svc_filedownload.DownloadService,
+) BatchOnloadService {
+	logger = logger.Named("BatchOnloadService")
+	return &batchOnloadService{
+		logger:                       logger,
+		configService:                configService,
+		listFilesByCollectionUseCase: listFilesByCollectionUseCase,
+		onloadService:                onloadService,
+		downloadService:              downloadService,
+	}
+}
+
+// OnloadBatch onloads the requested files using a fixed size pool of workers. The files are
+// presigned up front with one request per batch, and every worker shares the same onload service,
+// and through it the same download service, so the workers reuse those presigned URLs and any
+// file still needing one is rate limited across the pool instead of per worker.
+func (s *batchOnloadService) OnloadBatch(ctx context.Context, input *BatchOnloadInput) (*BatchOnloadOutput, error) {
+	//
+	// STEP 1: Validate inputs
+	//
+	if input == nil {
+		s.logger.Error("❌ input is required")
+		return nil, errors.NewAppError("input is required", ErrInvalidInput)
+	}
+	if input.UserPassword == "" {
+		s.logger.Error("❌ user password is required for E2EE operations")
+		return nil, errors.NewAppError("user password is required for E2EE operations", ErrInvalidInput)
+	}
+
+	concurrency := input.Concurrency
+	if concurrency == 0 {
+		configured, err := s.configService.GetMaxConcurrentOnloads(ctx)
+		if err != nil {
+			return nil, errors.NewAppError("failed to get max concurrent onloads", err)
+		}
+		concurrency = configured
+	}
+	if concurrency < config.MinConcurrentOnloads || concurrency > config.MaxConcurrentOnloads {
+		return nil, errors.NewAppError(fmt.Sprintf("concurrency must be between %d and %d", config.MinConcurrentOnloads, config.MaxConcurrentOnloads), ErrInvalidInput)
+	}
+
+	//
+	// STEP 2: Resolve the files to onload
+	//
+	fileIDs := input.FileIDs
+	if input.CollectionID != nil {
+		files, err := s.listFilesByCollectionUseCase.Execute(ctx, *input.CollectionID)
+		if err != nil {
+			s.logger.Error("❌ failed to list files in collection",
+				zap.String("collectionID", input.CollectionID.String()),
+				zap.Error(err))
+			return nil, errors.NewAppError("failed to list files in collection", err)
+		}
+		fileIDs = make([]gocql.UUID, 0, len(files))
+		for _, file := range files {
+			if file.SyncStatus == dom_file.SyncStatusCloudOnly {
+				fileIDs = append(fileIDs, file.ID)
+			}
+		}
+	}
+
+	output := &BatchOnloadOutput{
+		Results:     make([]*BatchOnloadResult, len(fileIDs)),
+		Concurrency: concurrency,
+	}
+	if len(fileIDs) == 0 {
+		return output, nil
+	}
+	if concurrency > len(fileIDs) {
+		concurrency = len(fileIDs)
+	}
+
+	s.logger.Info("🔄 Starting batch onload",
+		zap.Int("fileCount", len(fileIDs)),
+		zap.Int("concurrency", concurrency))
+
+	//
+	// STEP 3: Presign the downloads of every file with one request per batch
+	//
+	if err := s.downloadService.PrefetchPresignedDownloadURLs(ctx, fileIDs, onloadURLDuration); err != nil {
+		// Not fatal, each onload presigns its file on its own if its URLs aren't cached
+		s.logger.Warn("⚠️ failed to presign the batch, presigning each file on its own",
+			zap.Error(err))
+	}
+
+	//
+	// STEP 4: Onload the files with a pool of workers
+	//
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				fileID := fileIDs[index]
+				result := &BatchOnloadResult{FileID: fileID}
+				result.Output, result.Error = s.onloadService.Onload(ctx, &OnloadInput{
+					FileID:       fileID,
+					UserPassword: input.UserPassword,
+				})
+				if result.Error != nil {
+					s.logger.Error("❌ failed to onload file in batch",
+						zap.String("fileID", fileID.String()),
+						zap.Error(result.Error))
+				}
+				output.Results[index] = result
+			}
+		}()
+	}
+
+	for index := range fileIDs {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- index
+	}
+	close(jobs)
+	wg.Wait()
+
+	//
+	// STEP 5: Summarize the results
+	//
+	for index, result := range output.Results {
+		if result == nil {
+			// The context was cancelled before the file was scheduled.
+			result = &BatchOnloadResult{
+				FileID: fileIDs[index],
+				Error:  errors.NewAppError("batch onload cancelled", ctx.Err()),
+			}
+			output.Results[index] = result
+		}
+		if result.Error != nil {
+			output.FailureCount++
+		} else {
+			output.SuccessCount++
+		}
+	}
+
+	s.logger.Info("✅ Batch onload completed",
+		zap.Int("successCount", output.SuccessCount),
+		zap.Int("failureCount", output.FailureCount))
+
+	return output, nil
+}
@@ -3,6 +3,7 @@ package filesyncer
 
 import (
 	"context"
+	"fmt"
 
 	"go.uber.org/zap"
 
@@ -84,7 +85,7 @@ func (s *createLocalFileFromCloudFileService) Execute(ctx context.Context, cloud
 		return nil, errors.NewAppError("failed to get file from the cloud", err)
 	}
 	if cloudFileDTO == nil {
-		err := errors.NewAppError("cloud file not found", nil)
+		err := errors.NewAppError("cloud file not found", ErrFileNotFound)
 		s.logger.Error("❌ Failed to fetch file from cloud",
 			zap.Error(err))
 		return nil, err
@@ -133,7 +134,7 @@ func (s *createLocalFileFromCloudFileService) Execute(ctx context.Context, cloud
 	collectionKey, err := s.collectionDecryptionService.ExecuteDecryptCollectionKeyChain(ctx, user, collection, password)
 	if err != nil {
 		s.logger.Error("failed to decrypt collection key chain", zap.Error(err))
-		return nil, errors.NewAppError("failed to decrypt collection key chain", err)
+		return nil, errors.NewAppError("failed to decrypt collection key chain", fmt.Errorf("%w: %w", ErrDecryptionFailed, err))
 	}
 	defer crypto.ClearBytes(collectionKey)
 
@@ -142,7 +143,7 @@ func (s *createLocalFileFromCloudFileService) Execute(ctx context.Context, cloud
 	//
 	newFileKey, err := s.fileDecryptionService.DecryptFileKey(ctx, newFile.EncryptedFileKey, collectionKey)
 	if err != nil {
-		return nil, errors.NewAppError("failed to decrypt file key", err)
+		return nil, errors.NewAppError("failed to decrypt file key", fmt.Errorf("%w: %w", ErrDecryptionFailed, err))
 	}
 	defer crypto.ClearBytes(newFileKey)
 
@@ -152,7 +153,7 @@ func (s *createLocalFileFromCloudFileService) Execute(ctx context.Context, cloud
 	decryptedMetadata, err := s.fileDecryptionService.DecryptFileMetadata(ctx, newFile.EncryptedMetadata, newFileKey)
 	if err != nil {
 		s.logger.Error("failed to decrypt file metadata", zap.Error(err))
-		return nil, errors.NewAppError("failed to decrypt file metadata", err)
+		return nil, errors.NewAppError("failed to decrypt file metadata", fmt.Errorf("%w: %w", ErrDecryptionFailed, err))
 	}
 
 	// Save our decrypted data.
@@ -0,0 +1,76 @@
+// internal/service/filesyncer/directory_locks.go
+package filesyncer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// maxFileNameCollisions is the number of `name (n)` suffixes tried before giving up
+const maxFileNameCollisions = 1000
+
+// directoryLocks serializes filename-collision resolution per destination directory so concurrent
+// onloads into the same collection directory never pick the same file name.
+type directoryLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// newDirectoryLocks creates an empty set of per-directory locks
+func newDirectoryLocks() *directoryLocks {
+	return &directoryLocks{
+		locks: make(map[string]*sync.Mutex),
+	}
+}
+
+// lock acquires the mutex for the given directory and returns a function which releases it
+func (d *directoryLocks) lock(dir string) func() {
+	dir = filepath.Clean(dir)
+
+	d.mu.Lock()
+	dirLock, ok := d.locks[dir]
+	if !ok {
+		dirLock = &sync.Mutex{}
+		d.locks[dir] = dirLock
+	}
+	d.mu.Unlock()
+
+	dirLock.Lock()
+	return dirLock.Unlock
+}
+
+// reserveUniquePath returns a path inside `dir` for `fileName` which is not yet in use, appending a
+// ` (n)` suffix before the extension on collision. The path is reserved by creating an empty file,
+// which the caller is expected to overwrite or remove.
+func (d *directoryLocks) reserveUniquePath(dir string, fileName string) (string, error) {
+	unlock := d.lock(dir)
+	defer unlock()
+
+	ext := filepath.Ext(fileName)
+	base := strings.TrimSuffix(fileName, ext)
+
+	for i := 0; i < maxFileNameCollisions; i++ {
+		candidate := fileName
+		if i > 0 {
+			candidate = fmt.Sprintf("%s (%d)%s", base, i, ext)
+		}
+		candidatePath := filepath.Join(dir, candidate)
+
+		f, err := os.OpenFile(candidatePath, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			if os.IsExist(err) {
+				continue
+			}
+			return "", err
+		}
+		if err := f.Close(); err != nil {
+			return "", err
+		}
+		return candidatePath, nil
+	}
+
+	return "", fmt.Errorf("could not find an unused file name for %s after %d attempts", fileName, maxFileNameCollisions)
+}
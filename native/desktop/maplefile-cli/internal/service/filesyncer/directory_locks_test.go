@@ -0,0 +1,71 @@
+package filesyncer
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestReserveUniquePath(t *testing.T) {
+	dir := t.TempDir()
+	locks := newDirectoryLocks()
+
+	first, err := locks.reserveUniquePath(dir, "report.pdf")
+	if err != nil {
+		t.Fatalf("reserveUniquePath() error = %v", err)
+	}
+	if want := filepath.Join(dir, "report.pdf"); first != want {
+		t.Errorf("reserveUniquePath() = %v, want %v", first, want)
+	}
+
+	second, err := locks.reserveUniquePath(dir, "report.pdf")
+	if err != nil {
+		t.Fatalf("reserveUniquePath() error = %v", err)
+	}
+	if want := filepath.Join(dir, "report (1).pdf"); second != want {
+		t.Errorf("reserveUniquePath() = %v, want %v", second, want)
+	}
+}
+
+func TestReserveUniquePathConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	locks := newDirectoryLocks()
+
+	const workers = 25
+	paths := make([]string, workers)
+	errs := make([]error, workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			paths[i], errs[i] = locks.reserveUniquePath(dir, "photo.jpg")
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool, workers)
+	for i, path := range paths {
+		if errs[i] != nil {
+			t.Fatalf("worker %d: reserveUniquePath() error = %v", i, errs[i])
+		}
+		if seen[path] {
+			t.Errorf("worker %d: path %v was reserved more than once", i, path)
+		}
+		seen[path] = true
+
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("worker %d: reserved path %v does not exist: %v", i, path, err)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != workers {
+		t.Errorf("directory contains %d files, want %d", len(entries), workers)
+	}
+}
@@ -0,0 +1,16 @@
+// internal/service/filesyncer/diskspace_unix.go
+//go:build !windows
+
+package filesyncer
+
+import "syscall"
+
+// availableDiskSpace returns the bytes available to this user on the filesystem of the path, and
+// false if it can't be determined.
+func availableDiskSpace(path string) (int64, bool) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, false
+	}
+	return int64(stat.Bavail) * int64(stat.Bsize), true
+}
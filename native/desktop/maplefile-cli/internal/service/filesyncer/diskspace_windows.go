@@ -0,0 +1,31 @@
+// internal/service/filesyncer/diskspace_windows.go
+//go:build windows
+
+package filesyncer
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// availableDiskSpace returns the bytes available to this user on the volume of the path, and
+// false if it can't be determined.
+func availableDiskSpace(path string) (int64, bool) {
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, false
+	}
+
+	getDiskFreeSpaceEx := syscall.NewLazyDLL("kernel32.dll").NewProc("GetDiskFreeSpaceExW")
+	var freeBytesAvailable uint64
+	result, _, _ := getDiskFreeSpaceEx.Call(
+		uintptr(unsafe.Pointer(pathPtr)),
+		uintptr(unsafe.Pointer(&freeBytesAvailable)),
+		0,
+		0,
+	)
+	if result == 0 {
+		return 0, false
+	}
+	return int64(freeBytesAvailable), true
+}
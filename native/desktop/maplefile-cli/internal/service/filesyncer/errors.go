@@ -0,0 +1,27 @@
+// internal/service/filesyncer/errors.go
+package filesyncer
+
+import (
+	"errors"
+
+	svc_filedownload "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filedownload"
+)
+
+// Onload and cloud sync errors. These are wrapped by the errors returned from `OnloadService.Onload`
+// and `CreateLocalFileFromCloudFileService.Execute` so callers can use `errors.Is` to decide whether
+// to retry, abort or prompt for the password again.
+var (
+	ErrFileNotFound    = errors.New("file not found")
+	ErrNotCloudOnly    = errors.New("file is not cloud-only")
+	ErrDownloadFailed  = errors.New("failed to download file")
+	ErrDiskWrite       = errors.New("failed to write file to disk")
+	ErrLocalCopyExists = errors.New("local copy of file already exists")
+	ErrInvalidInput    = errors.New("invalid input")
+)
+
+// The decryption and metadata errors are the ones of the download service, so `errors.Is` matches
+// them whether the error came from onloading or from downloading the file directly.
+var (
+	ErrDecryptionFailed = svc_filedownload.ErrDecryptionFailed
+	ErrInvalidMetadata  = svc_filedownload.ErrInvalidMetadata
+)
@@ -11,8 +11,10 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/fileupload"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
 	svc_fileupload "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/fileupload"
 	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
+	uc_journal "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/journal"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/localfile"
 )
 
@@ -39,11 +41,12 @@ type OffloadService interface {
 
 // offloadService implements the OffloadService interface
 type offloadService struct {
-	logger            *zap.Logger
-	getFileUseCase    uc_file.GetFileUseCase
-	updateFileUseCase uc_file.UpdateFileUseCase
-	fileUploadService svc_fileupload.FileUploadService
-	deleteFileUseCase localfile.DeleteFileUseCase
+	logger             *zap.Logger
+	getFileUseCase     uc_file.GetFileUseCase
+	updateFileUseCase  uc_file.UpdateFileUseCase
+	fileUploadService  svc_fileupload.FileUploadService
+	deleteFileUseCase  localfile.DeleteFileUseCase
+	recordEntryUseCase uc_journal.RecordEntryUseCase
 }
 
 // NewOffloadService creates a new service for offloading local files
@@ -53,19 +56,30 @@ func NewOffloadService(
 	updateFileUseCase uc_file.UpdateFileUseCase,
 	fileUploadService svc_fileupload.FileUploadService,
 	deleteFileUseCase localfile.DeleteFileUseCase,
+	recordEntryUseCase uc_journal.RecordEntryUseCase,
 ) OffloadService {
 	logger = logger.Named("OffloadService")
 	return &offloadService{
-		logger:            logger,
-		getFileUseCase:    getFileUseCase,
-		updateFileUseCase: updateFileUseCase,
-		fileUploadService: fileUploadService,
-		deleteFileUseCase: deleteFileUseCase,
+		logger:             logger,
+		getFileUseCase:     getFileUseCase,
+		updateFileUseCase:  updateFileUseCase,
+		fileUploadService:  fileUploadService,
+		deleteFileUseCase:  deleteFileUseCase,
+		recordEntryUseCase: recordEntryUseCase,
 	}
 }
 
 // Offload handles the offloading of a local file to the cloud
 func (s *offloadService) Offload(ctx context.Context, input *OffloadInput) (*OffloadOutput, error) {
+	output, err := s.offload(ctx, input)
+	if input != nil {
+		s.recordEntryUseCase.Execute(ctx, journal.OperationOffload, journal.EntityTypeFile, input.FileID, err, "")
+	}
+	return output, err
+}
+
+// offload performs the offload, its outcome is recorded in the journal by the exported method
+func (s *offloadService) offload(ctx context.Context, input *OffloadInput) (*OffloadOutput, error) {
 	//
 	// STEP 1: Validate inputs
 	//
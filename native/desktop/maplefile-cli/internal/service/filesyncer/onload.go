@@ -3,9 +3,11 @@ package filesyncer
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -14,15 +16,36 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
 	svc_filedownload "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filedownload"
 	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
+	uc_journal "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/journal"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/localfile"
 )
 
+// onloadURLDuration is how long the presigned URLs requested to onload a file stay valid
+const onloadURLDuration = 1 * time.Hour
+
+// Policies for onloading a file whose decrypted local copy already exists
+const (
+	// OnExistingOverwrite downloads the file again and replaces the local copy.
+	OnExistingOverwrite = "overwrite"
+	// OnExistingSkip keeps the local copy if it matches the hash of the file, and only downloads the
+	// file again to replace a corrupted copy.
+	OnExistingSkip = "skip"
+	// OnExistingFail aborts the onload with ErrLocalCopyExists.
+	OnExistingFail = "fail"
+	// OnExistingKeepBoth keeps the local copy and saves the downloaded file under a ` (n)` suffixed name.
+	OnExistingKeepBoth = "keep-both"
+)
+
 // OnloadInput represents the input for onloading a cloud-only file
 type OnloadInput struct {
 	FileID       gocql.UUID `json:"file_id"`
 	UserPassword string     `json:"user_password"`
+	// OnExisting is the policy applied when a local copy of the file already exists. If empty, the
+	// local copy is overwritten.
+	OnExisting string `json:"on_existing,omitempty"`
 }
 
 // OnloadOutput represents the result of onloading a cloud-only file
@@ -49,6 +72,8 @@ type onloadService struct {
 	downloadService        svc_filedownload.DownloadService
 	pathUtilsUseCase       localfile.PathUtilsUseCase
 	createDirectoryUseCase localfile.CreateDirectoryUseCase
+	directoryLocks         *directoryLocks
+	recordEntryUseCase     uc_journal.RecordEntryUseCase
 }
 
 // NewOnloadService creates a new service for onloading cloud-only files
@@ -60,6 +85,7 @@ func NewOnloadService(
 	downloadService svc_filedownload.DownloadService,
 	pathUtilsUseCase localfile.PathUtilsUseCase,
 	createDirectoryUseCase localfile.CreateDirectoryUseCase,
+	recordEntryUseCase uc_journal.RecordEntryUseCase,
 ) OnloadService {
 	logger = logger.Named("OnloadService")
 	return &onloadService{
@@ -70,11 +96,22 @@ func NewOnloadService(
 		downloadService:        downloadService,
 		pathUtilsUseCase:       pathUtilsUseCase,
 		createDirectoryUseCase: createDirectoryUseCase,
+		directoryLocks:         newDirectoryLocks(),
+		recordEntryUseCase:     recordEntryUseCase,
 	}
 }
 
 // Onload handles the onloading of a cloud-only file to local storage
 func (s *onloadService) Onload(ctx context.Context, input *OnloadInput) (*OnloadOutput, error) {
+	output, err := s.onload(ctx, input)
+	if input != nil {
+		s.recordEntryUseCase.Execute(ctx, journal.OperationOnload, journal.EntityTypeFile, input.FileID, err, "")
+	}
+	return output, err
+}
+
+// onload performs the onload, its outcome is recorded in the journal by the exported method
+func (s *onloadService) onload(ctx context.Context, input *OnloadInput) (*OnloadOutput, error) {
 	s.logger.Info("🔍 DEBUG: Starting onload process",
 		zap.String("fileID", input.FileID.String()))
 
@@ -93,6 +130,13 @@ func (s *onloadService) Onload(ctx context.Context, input *OnloadInput) (*Onload
 		s.logger.Error("❌ user password is required for E2EE operations")
 		return nil, errors.NewAppError("user password is required for E2EE operations", nil)
 	}
+	switch input.OnExisting {
+	case "", OnExistingOverwrite, OnExistingSkip, OnExistingFail, OnExistingKeepBoth:
+	default:
+		s.logger.Error("❌ invalid policy for an existing local copy", zap.String("onExisting", input.OnExisting))
+		return nil, errors.NewAppError(fmt.Sprintf("invalid policy for an existing local copy: %s (must be %s, %s, %s or %s)",
+			input.OnExisting, OnExistingOverwrite, OnExistingSkip, OnExistingFail, OnExistingKeepBoth), nil)
+	}
 
 	//
 	// STEP 2: Convert file ID string to ObjectID
@@ -115,7 +159,7 @@ func (s *onloadService) Onload(ctx context.Context, input *OnloadInput) (*Onload
 
 	if file == nil {
 		s.logger.Error("❌ file not found", zap.String("fileID", input.FileID.String()))
-		return nil, errors.NewAppError("file not found", nil)
+		return nil, errors.NewAppError("file not found", ErrFileNotFound)
 	}
 
 	previousStatus := file.SyncStatus
@@ -127,22 +171,81 @@ func (s *onloadService) Onload(ctx context.Context, input *OnloadInput) (*Onload
 			zap.Any("syncStatus", file.SyncStatus))
 		return nil, errors.NewAppError(
 			fmt.Sprintf("file is not cloud-only (current status: %v)", file.SyncStatus),
-			nil)
+			ErrNotCloudOnly)
 	}
 
 	//
-	// STEP 4: Download and decrypt file using the download service
+	// STEP 4: Apply the policy for an existing local copy of the file
+	//
+	existingPath, err := s.findLocalCopy(ctx, file)
+	if err != nil {
+		s.logger.Error("❌ failed to look for an existing local copy",
+			zap.String("fileID", input.FileID.String()),
+			zap.Error(err))
+		return nil, errors.NewAppError("failed to look for an existing local copy", err)
+	}
+
+	replacePath := ""
+	if existingPath != "" {
+		s.logger.Debug("🔍 Found an existing local copy of the file",
+			zap.String("fileID", input.FileID.String()),
+			zap.String("path", existingPath),
+			zap.String("onExisting", input.OnExisting))
+
+		switch input.OnExisting {
+		case OnExistingFail:
+			return nil, errors.NewAppError(fmt.Sprintf("a local copy of the file already exists at %s", existingPath), ErrLocalCopyExists)
+		case "", OnExistingOverwrite:
+			replacePath = existingPath
+		case OnExistingSkip:
+			metadata, err := s.downloadService.VerifyLocalCopy(ctx, input.FileID, input.UserPassword, existingPath)
+			if err == nil {
+				if err := s.updateOnloadedFile(ctx, file, existingPath, metadata, nil); err != nil {
+					return nil, err
+				}
+
+				s.logger.Info("✨ Kept the existing local copy of the file",
+					zap.String("fileID", input.FileID.String()),
+					zap.String("decryptedPath", existingPath))
+
+				return &OnloadOutput{
+					FileID:         input.FileID,
+					PreviousStatus: previousStatus,
+					NewStatus:      dom_file.SyncStatusSynced,
+					DecryptedPath:  existingPath,
+					Message:        "Existing local copy passed integrity verification, download skipped",
+				}, nil
+			}
+			if !stderrors.Is(err, svc_filedownload.ErrIntegrityCheckFailed) {
+				return nil, errors.NewAppError("failed to verify the existing local copy", err)
+			}
+
+			s.logger.Warn("⚠️ Existing local copy failed integrity verification, downloading the file again",
+				zap.String("fileID", input.FileID.String()),
+				zap.String("path", existingPath),
+				zap.Error(err))
+			replacePath = existingPath
+		}
+	}
+
+	//
+	// STEP 5: Download and decrypt file using the download service
 	//
 	s.logger.Info("⬇️ Downloading and decrypting file from cloud",
 		zap.String("fileID", input.FileID.String()))
 
-	urlDuration := 1 * time.Hour // Default duration for download URLs
-	downloadResult, err := s.downloadService.DownloadAndDecryptFile(ctx, input.FileID, input.UserPassword, urlDuration)
+	downloadResult, err := s.downloadService.DownloadAndDecryptFile(ctx, input.FileID, input.UserPassword, onloadURLDuration)
 	if err != nil {
 		s.logger.Error("❌ failed to download and decrypt file",
 			zap.String("fileID", input.FileID.String()),
 			zap.Error(err))
-		return nil, errors.NewAppError("failed to download and decrypt file", err)
+		// Distinguish a bad password from a network or server problem so the caller can react accordingly.
+		// Malformed metadata won't change by downloading again, so it isn't reported as a download failure
+		// either. Both errors already wrap the sentinel the caller checks for.
+		if stderrors.Is(err, ErrDecryptionFailed) || stderrors.Is(err, ErrInvalidMetadata) {
+			return nil, errors.NewAppError("failed to download and decrypt file", err)
+		}
+		return nil, errors.NewAppError("failed to download and decrypt file", fmt.Errorf("%w: %w", ErrDownloadFailed, err))
 	}
 
 	s.logger.Info("✅ Successfully downloaded and decrypted file",
@@ -154,21 +257,23 @@ func (s *onloadService) Onload(ctx context.Context, input *OnloadInput) (*Onload
 		zap.Int64("size", downloadResult.OriginalSize))
 
 	//
-	// STEP 5: Save decrypted file locally
+	// STEP 6: Save decrypted file locally
 	//
-	decryptedPath, err := s.saveDecryptedFileWithDebug(ctx, file, downloadResult.DecryptedData, downloadResult.DecryptedMetadata)
+	keepBoth := input.OnExisting == OnExistingKeepBoth
+	decryptedPath, err := s.saveDecryptedFileWithDebug(ctx, file, downloadResult.DecryptedData, downloadResult.DecryptedMetadata, replacePath, keepBoth)
 	if err != nil {
 		s.logger.Error("❌ failed to save decrypted file",
 			zap.String("fileID", input.FileID.String()),
 			zap.Error(err))
-		return nil, errors.NewAppError("failed to save decrypted file", err)
+		return nil, errors.NewAppError("failed to save decrypted file", fmt.Errorf("%w: %w", ErrDiskWrite, err))
 	}
 
 	//
-	// STEP 6: Save thumbnail if present
+	// STEP 7: Save thumbnail if present
 	//
+	var thumbnail *savedThumbnail
 	if downloadResult.ThumbnailData != nil && len(downloadResult.ThumbnailData) > 0 {
-		thumbnailPath, err := s.saveThumbnail(ctx, file, downloadResult.ThumbnailData, downloadResult.DecryptedMetadata.Name)
+		thumbnail, err = s.saveThumbnail(ctx, file, downloadResult.ThumbnailData, downloadResult.DecryptedMetadata.Name)
 		if err != nil {
 			s.logger.Warn("⚠️ Failed to save thumbnail, continuing without it",
 				zap.String("fileID", input.FileID.String()),
@@ -176,52 +281,120 @@ func (s *onloadService) Onload(ctx context.Context, input *OnloadInput) (*Onload
 		} else {
 			s.logger.Debug("✅ Successfully saved thumbnail",
 				zap.String("fileID", input.FileID.String()),
-				zap.String("thumbnailPath", thumbnailPath))
+				zap.String("thumbnailPath", thumbnail.Path),
+				zap.String("thumbnailFormat", thumbnail.Format))
 		}
 	}
 
 	//
-	// STEP 7: Update file record with new path and sync status
+	// STEP 8: Update file record with new path and sync status
 	//
+	if err := s.updateOnloadedFile(ctx, file, decryptedPath, downloadResult.DecryptedMetadata, thumbnail); err != nil {
+		return nil, err
+	}
+	newStatus := dom_file.SyncStatusSynced
+
+	s.logger.Info("✨ Successfully onloaded file",
+		zap.String("fileID", input.FileID.String()),
+		zap.String("decryptedPath", decryptedPath),
+		zap.Any("previousStatus", previousStatus),
+		zap.Any("newStatus", newStatus))
+
+	return &OnloadOutput{
+		FileID:         input.FileID,
+		PreviousStatus: previousStatus,
+		NewStatus:      newStatus,
+		DecryptedPath:  decryptedPath,
+		DownloadedSize: downloadResult.OriginalSize,
+		Message:        "File successfully onloaded and decrypted",
+	}, nil
+}
+
+// updateOnloadedFile marks the file as synced with its decrypted copy at decryptedPath
+func (s *onloadService) updateOnloadedFile(ctx context.Context, file *dom_file.File, decryptedPath string, metadata *svc_filedownload.DecryptedFileMetadata, thumbnail *savedThumbnail) error {
 	updateInput := uc_file.UpdateFileInput{
 		ID: file.ID,
 		// Developers note: We don't need to update the state, this is a strict local feature that doesn't affect the distributed clients and doesn't affect the cloud state.
 	}
 
 	newStatus := dom_file.SyncStatusSynced
+	accessedAt := time.Now()
 	updateInput.SyncStatus = &newStatus
 	updateInput.FilePath = &decryptedPath
+	updateInput.LastAccessedAt = &accessedAt
+
+	// Record where the thumbnail is stored and its format so a viewer knows how to decode it
+	if thumbnail != nil {
+		updateInput.ThumbnailPath = &thumbnail.Path
+		updateInput.ThumbnailSize = &thumbnail.Size
+		updateInput.ThumbnailFormat = &thumbnail.Format
+	}
 
 	// Update the file name and MIME type from decrypted metadata
-	if downloadResult.DecryptedMetadata.Name != "" {
-		updateInput.DecryptedName = &downloadResult.DecryptedMetadata.Name
+	if metadata != nil && metadata.Name != "" {
+		updateInput.DecryptedName = &metadata.Name
 	}
-	if downloadResult.DecryptedMetadata.MimeType != "" {
-		updateInput.DecryptedMimeType = &downloadResult.DecryptedMetadata.MimeType
+	if metadata != nil && metadata.MimeType != "" {
+		updateInput.DecryptedMimeType = &metadata.MimeType
 	}
 
-	_, err = s.updateFileUseCase.Execute(ctx, updateInput)
-	if err != nil {
+	if _, err := s.updateFileUseCase.Execute(ctx, updateInput); err != nil {
 		s.logger.Error("❌ failed to update file sync status during onload",
-			zap.String("fileID", input.FileID.String()),
+			zap.String("fileID", file.ID.String()),
 			zap.Error(err))
-		return nil, errors.NewAppError("failed to update file sync status during onload", err)
+		return errors.NewAppError("failed to update file sync status during onload", err)
 	}
+	return nil
+}
 
-	s.logger.Info("✨ Successfully onloaded file",
-		zap.String("fileID", input.FileID.String()),
-		zap.String("decryptedPath", decryptedPath),
-		zap.Any("previousStatus", previousStatus),
-		zap.Any("newStatus", newStatus))
+// findLocalCopy returns the path of an existing decrypted copy of the file in its collection
+// directory, or an empty string if there is none. Copies saved under a ` (n)` suffixed name are not
+// considered. Files whose type couldn't be determined may have been saved without an extension.
+func (s *onloadService) findLocalCopy(ctx context.Context, file *dom_file.File) (string, error) {
+	appDataDir, err := s.configService.GetAppDataDirPath(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get app data directory: %w", err)
+	}
 
-	return &OnloadOutput{
-		FileID:         input.FileID,
-		PreviousStatus: previousStatus,
-		NewStatus:      newStatus,
-		DecryptedPath:  decryptedPath,
-		DownloadedSize: downloadResult.OriginalSize,
-		Message:        "File successfully onloaded and decrypted",
-	}, nil
+	collectionDir := s.pathUtilsUseCase.Join(ctx, appDataDir, "files", "bin", file.CollectionID.String())
+	matches, err := filepath.Glob(filepath.Join(collectionDir, file.ID.String()+".*"))
+	if err != nil {
+		return "", err
+	}
+	matches = append(matches, filepath.Join(collectionDir, file.ID.String()))
+	for _, match := range matches {
+		// A temporary file left behind by an interrupted onload is not a copy of the file
+		if isOnloadTempFile(match) {
+			continue
+		}
+		if info, err := os.Stat(match); err == nil && info.Mode().IsRegular() {
+			return match, nil
+		}
+	}
+	return "", nil
+}
+
+// onloadTempSuffix ends the name of the temporary files a local copy is written to before it is
+// renamed into place
+const onloadTempSuffix = ".tmp"
+
+// isOnloadTempFile reports whether path is a temporary file created by replaceDecryptedFile, named
+// after the local copy followed by a random number and onloadTempSuffix
+func isOnloadTempFile(path string) bool {
+	name, ok := strings.CutSuffix(filepath.Base(path), onloadTempSuffix)
+	if !ok {
+		return false
+	}
+	dot := strings.LastIndex(name, ".")
+	if dot < 0 || dot == len(name)-1 {
+		return false
+	}
+	for _, r := range name[dot+1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
 }
 
 // saveDecryptedFile saves the decrypted file content to local storage
@@ -245,12 +418,12 @@ func (s *onloadService) saveDecryptedFile(ctx context.Context, file *dom_file.Fi
 	}
 
 	// Enhanced file extension determination
-	fileExtension := s.determineFileExtension(metadata, file.MimeType)
+	fileExtension := s.determineFileExtension(metadata, file.MimeType, s.getFallbackExtension(ctx))
 
 	destFileName := file.ID.String() + fileExtension
 	destFilePath := s.pathUtilsUseCase.Join(ctx, collectionDir, destFileName)
 
-	// Write the decrypted file
+	// Write the decrypted file verbatim, the original encoding and byte order mark are preserved
 	err = os.WriteFile(destFilePath, decryptedData, 0644)
 	if err != nil {
 		return "", fmt.Errorf("failed to write decrypted file: %w", err)
@@ -265,14 +438,26 @@ func (s *onloadService) saveDecryptedFile(ctx context.Context, file *dom_file.Fi
 	return destFilePath, nil
 }
 
-// saveThumbnail saves the decrypted thumbnail to local storage
-func (s *onloadService) saveThumbnail(ctx context.Context, file *dom_file.File, thumbnailData []byte, originalFileName string) (string, error) {
+// savedThumbnail describes a thumbnail stored in local storage
+type savedThumbnail struct {
+	Path   string
+	Size   int64
+	Format string
+}
+
+// saveThumbnail saves the decrypted thumbnail to local storage using the configured dimensions and format
+func (s *onloadService) saveThumbnail(ctx context.Context, file *dom_file.File, thumbnailData []byte, originalFileName string) (*savedThumbnail, error) {
 	s.logger.Debug("🖼️ Saving thumbnail locally", zap.String("fileID", file.ID.String()))
 
 	// Get app data directory
 	appDataDir, err := s.configService.GetAppDataDirPath(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to get app data directory: %w", err)
+		return nil, fmt.Errorf("failed to get app data directory: %w", err)
+	}
+
+	settings, err := s.configService.GetThumbnailSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thumbnail settings: %w", err)
 	}
 
 	// Create files storage directory structure
@@ -282,29 +467,55 @@ func (s *onloadService) saveThumbnail(ctx context.Context, file *dom_file.File,
 
 	// Create directories if they don't exist
 	if err := s.createDirectoryUseCase.ExecuteAll(ctx, collectionDir); err != nil {
-		return "", fmt.Errorf("failed to create collection directory: %w", err)
+		return nil, fmt.Errorf("failed to create collection directory: %w", err)
+	}
+
+	// Fit the thumbnail to the configured dimensions and format, naming it after its actual format
+	thumbnailData, format := prepareThumbnail(thumbnailData, settings)
+	if format != settings.Format {
+		s.logger.Debug("Storing thumbnail in a different format than configured",
+			zap.String("fileID", file.ID.String()),
+			zap.String("configuredFormat", settings.Format),
+			zap.String("format", format))
 	}
 
-	// Generate thumbnail file name
-	thumbnailFileName := file.ID.String() + "_thumbnail.jpg"
+	thumbnailFileName := file.ID.String() + "_thumbnail" + thumbnailExtension(format)
 	thumbnailPath := s.pathUtilsUseCase.Join(ctx, collectionDir, thumbnailFileName)
 
 	// Write the thumbnail
 	err = os.WriteFile(thumbnailPath, thumbnailData, 0644)
 	if err != nil {
-		return "", fmt.Errorf("failed to write thumbnail: %w", err)
+		return nil, fmt.Errorf("failed to write thumbnail: %w", err)
 	}
 
 	s.logger.Debug("✅ Successfully saved thumbnail",
 		zap.String("fileID", file.ID.String()),
 		zap.String("thumbnailPath", thumbnailPath),
+		zap.String("format", format),
 		zap.Int("size", len(thumbnailData)))
 
-	return thumbnailPath, nil
+	return &savedThumbnail{
+		Path:   thumbnailPath,
+		Size:   int64(len(thumbnailData)),
+		Format: format,
+	}, nil
 }
 
-// Enhanced file extension determination with multiple fallback strategies
-func (s *onloadService) determineFileExtension(metadata *svc_filedownload.DecryptedFileMetadata, mimeType string) string {
+// getFallbackExtension returns the configured extension of files whose type can't be determined
+func (s *onloadService) getFallbackExtension(ctx context.Context) string {
+	fallbackExtension, err := s.configService.GetOnloadFallbackExtension(ctx)
+	if err != nil {
+		s.logger.Warn("⚠️ Failed to get fallback extension, using default",
+			zap.String("default", config.DefaultOnloadFallbackExtension),
+			zap.Error(err))
+		return config.DefaultOnloadFallbackExtension
+	}
+	return fallbackExtension
+}
+
+// Enhanced file extension determination with multiple fallback strategies. If no strategy determines
+// the extension, the fallback extension is returned, which is empty when the fallback is disabled.
+func (s *onloadService) determineFileExtension(metadata *svc_filedownload.DecryptedFileMetadata, mimeType string, fallbackExtension string) string {
 	// Strategy 1: Use explicit file extension from metadata (preferred)
 	if metadata != nil && metadata.FileExtension != "" {
 		s.logger.Debug("Using file extension from metadata", zap.String("extension", metadata.FileExtension))
@@ -321,7 +532,7 @@ func (s *onloadService) determineFileExtension(metadata *svc_filedownload.Decryp
 
 	// Strategy 3: Use enhanced MIME type mapping
 	if mimeType != "" {
-		if ext := s.getExtensionFromMimeType(mimeType); ext != ".dat" {
+		if ext := s.getExtensionFromMimeType(mimeType); ext != "" {
 			s.logger.Debug("Using file extension from MIME type",
 				zap.String("mimeType", mimeType),
 				zap.String("extension", ext))
@@ -330,19 +541,22 @@ func (s *onloadService) determineFileExtension(metadata *svc_filedownload.Decryp
 	}
 
 	// Strategy 4: Final fallback
-	s.logger.Warn("No file extension could be determined, using .dat fallback",
+	s.logger.Warn("No file extension could be determined, using fallback",
 		zap.String("metadataName", func() string {
 			if metadata != nil {
 				return metadata.Name
 			}
 			return ""
 		}()),
-		zap.String("mimeType", mimeType))
-	return ".dat"
+		zap.String("mimeType", mimeType),
+		zap.String("fallbackExtension", fallbackExtension))
+	return fallbackExtension
 }
 
-// Enhanced saveDecryptedFile with extensive debugging
-func (s *onloadService) saveDecryptedFileWithDebug(ctx context.Context, file *dom_file.File, decryptedData []byte, metadata *svc_filedownload.DecryptedFileMetadata) (string, error) {
+// Enhanced saveDecryptedFile with extensive debugging. Unless keepBoth is set, the file is saved under
+// its own name, replacing the existing local copy at replacePath if any, so onloading the same file
+// again or concurrently leaves a single copy.
+func (s *onloadService) saveDecryptedFileWithDebug(ctx context.Context, file *dom_file.File, decryptedData []byte, metadata *svc_filedownload.DecryptedFileMetadata, replacePath string, keepBoth bool) (string, error) {
 	s.logger.Info("💾 DEBUG: Starting saveDecryptedFile",
 		zap.String("fileID", file.ID.String()),
 		zap.String("fileMimeType", file.MimeType),
@@ -375,23 +589,34 @@ func (s *onloadService) saveDecryptedFileWithDebug(ctx context.Context, file *do
 	}
 
 	// Enhanced file extension determination with debugging
-	fileExtension := s.determineFileExtensionWithDebug(metadata, file.MimeType)
+	fileExtension := s.determineFileExtensionWithDebug(metadata, file.MimeType, s.getFallbackExtension(ctx))
 
 	s.logger.Info("🔍 DEBUG: Final extension determination",
 		zap.String("fileID", file.ID.String()),
 		zap.String("finalExtension", fileExtension))
 
 	destFileName := file.ID.String() + fileExtension
-	destFilePath := s.pathUtilsUseCase.Join(ctx, collectionDir, destFileName)
+
+	if !keepBoth {
+		return s.replaceDecryptedFile(collectionDir, destFileName, replacePath, decryptedData)
+	}
+
+	// Reserve the destination while holding the directory lock so concurrent onloads into the
+	// same collection directory cannot pick the same file name.
+	destFilePath, err := s.directoryLocks.reserveUniquePath(collectionDir, destFileName)
+	if err != nil {
+		return "", fmt.Errorf("failed to reserve destination file: %w", err)
+	}
 
 	s.logger.Info("🔍 DEBUG: File paths",
 		zap.String("fileID", file.ID.String()),
 		zap.String("destFileName", destFileName),
 		zap.String("destFilePath", destFilePath))
 
-	// Write the decrypted file
+	// Write the decrypted file verbatim, the original encoding and byte order mark are preserved
 	err = os.WriteFile(destFilePath, decryptedData, 0644)
 	if err != nil {
+		_ = os.Remove(destFilePath)
 		return "", fmt.Errorf("failed to write decrypted file: %w", err)
 	}
 
@@ -404,8 +629,57 @@ func (s *onloadService) saveDecryptedFileWithDebug(ctx context.Context, file *do
 	return destFilePath, nil
 }
 
+// replaceDecryptedFile writes the decrypted file to destFileName in dir, replacing the existing local
+// copy at replacePath if set. The new content is written to a temporary file first so a failed write
+// leaves the existing copy intact.
+func (s *onloadService) replaceDecryptedFile(dir string, destFileName string, replacePath string, decryptedData []byte) (string, error) {
+	unlock := s.directoryLocks.lock(dir)
+	defer unlock()
+
+	destFilePath := filepath.Join(dir, destFileName)
+
+	tmp, err := os.CreateTemp(dir, destFileName+".*"+onloadTempSuffix)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(decryptedData); err != nil {
+		tmp.Close()
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to write decrypted file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to write decrypted file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	if err := os.Rename(tmpPath, destFilePath); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to replace existing local copy: %w", err)
+	}
+
+	// The extension may differ from the one of the replaced copy
+	if replacePath != "" && filepath.Clean(replacePath) != filepath.Clean(destFilePath) {
+		if err := os.Remove(replacePath); err != nil && !os.IsNotExist(err) {
+			s.logger.Warn("⚠️ Failed to remove the replaced local copy",
+				zap.String("path", replacePath),
+				zap.Error(err))
+		}
+	}
+
+	s.logger.Info("✅ Replaced existing local copy of the file",
+		zap.String("filePath", destFilePath),
+		zap.String("replacedPath", replacePath),
+		zap.Int("size", len(decryptedData)))
+
+	return destFilePath, nil
+}
+
 // Enhanced file extension determination with detailed debugging
-func (s *onloadService) determineFileExtensionWithDebug(metadata *svc_filedownload.DecryptedFileMetadata, mimeType string) string {
+func (s *onloadService) determineFileExtensionWithDebug(metadata *svc_filedownload.DecryptedFileMetadata, mimeType string, fallbackExtension string) string {
 	s.logger.Info("🔍 DEBUG: Starting extension determination")
 
 	// Strategy 1: Use explicit file extension from metadata (preferred)
@@ -453,7 +727,7 @@ func (s *onloadService) determineFileExtensionWithDebug(metadata *svc_filedownlo
 			zap.String("mimeType", mimeType),
 			zap.String("mappedExtension", ext))
 
-		if ext != ".dat" {
+		if ext != "" {
 			s.logger.Info("✅ DEBUG: Using extension from MIME type mapping",
 				zap.String("extension", ext))
 			return ext
@@ -463,18 +737,20 @@ func (s *onloadService) determineFileExtensionWithDebug(metadata *svc_filedownlo
 	}
 
 	// Strategy 4: Final fallback
-	s.logger.Warn("⚠️ DEBUG: Using .dat fallback - no extension could be determined",
+	s.logger.Warn("⚠️ DEBUG: Using fallback - no extension could be determined",
 		zap.String("metadataName", func() string {
 			if metadata != nil {
 				return metadata.Name
 			}
 			return "nil"
 		}()),
-		zap.String("mimeType", mimeType))
-	return ".dat"
+		zap.String("mimeType", mimeType),
+		zap.String("fallbackExtension", fallbackExtension))
+	return fallbackExtension
 }
 
-// Enhanced MIME type to extension mapping with debugging
+// Enhanced MIME type to extension mapping with debugging, returning an empty string for MIME types
+// without a mapping
 func (s *onloadService) getExtensionFromMimeType(mimeType string) string {
 	s.logger.Debug("Determining extension from MIME type", zap.String("mimeType", mimeType))
 
@@ -688,7 +964,7 @@ func (s *onloadService) getExtensionFromMimeType(mimeType string) string {
 		return ".pptm"
 
 	default:
-		s.logger.Debug("No MIME type mapping found, using .dat", zap.String("mimeType", mimeType))
-		return ".dat" // Generic data file extension
+		s.logger.Debug("No MIME type mapping found", zap.String("mimeType", mimeType))
+		return ""
 	}
 }
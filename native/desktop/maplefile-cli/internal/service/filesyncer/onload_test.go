@@ -0,0 +1,365 @@
+package filesyncer
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
+	svc_filecrypto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filecrypto"
+	svc_filedownload "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filedownload"
+	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/localfile"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+)
+
+// memoryConfigRepository keeps the configuration in memory
+type memoryConfigRepository struct {
+	config config.Config
+}
+
+func (r *memoryConfigRepository) LoadConfig(ctx context.Context) (*config.Config, error) {
+	loaded := r.config
+	return &loaded, nil
+}
+
+func (r *memoryConfigRepository) SaveConfig(ctx context.Context, config *config.Config) error {
+	r.config = *config
+	return nil
+}
+
+func TestDetermineFileExtensionFallback(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		configured string // empty leaves the fallback unconfigured
+		want       string
+	}{
+		{name: "default", want: ".dat"},
+		{name: "custom", configured: "BIN", want: ".bin"},
+		{name: "custom with dot", configured: ".raw", want: ".raw"},
+		{name: "disabled", configured: config.OnloadFallbackExtensionNone, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configService := config.NewForTesting(&memoryConfigRepository{})
+			if tt.configured != "" {
+				if err := configService.SetOnloadFallbackExtension(ctx, tt.configured); err != nil {
+					t.Fatalf("SetOnloadFallbackExtension(%q) error = %v", tt.configured, err)
+				}
+			}
+			s := &onloadService{logger: zap.NewNop(), configService: configService}
+
+			fallbackExtension := s.getFallbackExtension(ctx)
+			if got := s.determineFileExtension(nil, "application/x-unknown", fallbackExtension); got != tt.want {
+				t.Errorf("determineFileExtension() of an unknown type = %q, want %q", got, tt.want)
+			}
+			if got := s.determineFileExtensionWithDebug(&svc_filedownload.DecryptedFileMetadata{Name: "notes"}, "", fallbackExtension); got != tt.want {
+				t.Errorf("determineFileExtensionWithDebug() of an unknown type = %q, want %q", got, tt.want)
+			}
+
+			// The fallback only applies when no other strategy determines the extension
+			if got := s.determineFileExtension(nil, "text/plain", fallbackExtension); got != ".txt" {
+				t.Errorf("determineFileExtension() of a known MIME type = %q, want %q", got, ".txt")
+			}
+			if got := s.determineFileExtension(&svc_filedownload.DecryptedFileMetadata{Name: "photo.JPG"}, "", fallbackExtension); got != ".JPG" {
+				t.Errorf("determineFileExtension() of a named file = %q, want %q", got, ".JPG")
+			}
+		})
+	}
+}
+
+func TestSetOnloadFallbackExtensionRejectsInvalidExtensions(t *testing.T) {
+	configService := config.NewForTesting(&memoryConfigRepository{})
+	for _, extension := range []string{"", ".", "../evil", "a/b", ".tar.gz", ".waytoolongextension"} {
+		if err := configService.SetOnloadFallbackExtension(context.Background(), extension); err == nil {
+			t.Errorf("SetOnloadFallbackExtension(%q) error = nil, want an error", extension)
+		}
+	}
+}
+
+func TestOnloadPreservesTextEncodingBytes(t *testing.T) {
+	ctx := context.Background()
+
+	// UTF-16LE with a byte order mark and CRLF line endings, which any text handling would change
+	content := []byte{0xFF, 0xFE}
+	for _, unit := range utf16.Encode([]rune("Café ☕\r\nsecond line\r\n")) {
+		content = binary.LittleEndian.AppendUint16(content, unit)
+	}
+
+	fileKey, err := crypto.GenerateRandomBytes(crypto.SecretBoxKeySize)
+	if err != nil {
+		t.Fatalf("GenerateRandomBytes() error = %v", err)
+	}
+
+	// Upload encrypts the content as read from disk
+	encrypted, err := svc_filecrypto.NewFileEncryptionService(zap.NewNop()).EncryptFileContent(ctx, content, fileKey)
+	if err != nil {
+		t.Fatalf("EncryptFileContent() error = %v", err)
+	}
+
+	// Onload decrypts the downloaded content and writes it over the local copy
+	decrypted, err := svc_filecrypto.NewFileDecryptionService(zap.NewNop()).DecryptFileContent(ctx, encrypted, fileKey)
+	if err != nil {
+		t.Fatalf("DecryptFileContent() error = %v", err)
+	}
+
+	dir := t.TempDir()
+	s := &onloadService{logger: zap.NewNop(), directoryLocks: newDirectoryLocks()}
+	path, err := s.replaceDecryptedFile(dir, "notes.txt", filepath.Join(dir, "notes.txt"), decrypted)
+	if err != nil {
+		t.Fatalf("replaceDecryptedFile() error = %v", err)
+	}
+
+	onloaded, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !bytes.Equal(onloaded, content) {
+		t.Errorf("onloaded content = % x, want % x", onloaded, content)
+	}
+}
+
+// onloadConfigService keeps the app data in a test directory
+type onloadConfigService struct {
+	config.ConfigService
+	appDataDir string
+}
+
+func (c *onloadConfigService) GetAppDataDirPath(ctx context.Context) (string, error) {
+	return c.appDataDir, nil
+}
+
+func (c *onloadConfigService) GetOnloadFallbackExtension(ctx context.Context) (string, error) {
+	return config.DefaultOnloadFallbackExtension, nil
+}
+
+// cloudOnlyFile always returns the file as cloud-only, as every onload started before the others finished sees it
+type cloudOnlyFile struct {
+	uc_file.GetFileUseCase
+	file dom_file.File
+}
+
+func (g *cloudOnlyFile) Execute(ctx context.Context, id gocql.UUID) (*dom_file.File, error) {
+	file := g.file
+	return &file, nil
+}
+
+type discardFileUpdates struct {
+	uc_file.UpdateFileUseCase
+}
+
+func (discardFileUpdates) Execute(ctx context.Context, input uc_file.UpdateFileInput) (*dom_file.File, error) {
+	return &dom_file.File{ID: input.ID}, nil
+}
+
+type discardJournalEntries struct{}
+
+func (discardJournalEntries) Execute(ctx context.Context, operation journal.Operation, entityType journal.EntityType, entityID gocql.UUID, opErr error, details string) {
+}
+
+// staticDownload returns the same decrypted content, once every concurrent onload has started downloading
+type staticDownload struct {
+	svc_filedownload.DownloadService
+	content []byte
+	started *sync.WaitGroup
+	err     error
+}
+
+func (d *staticDownload) DownloadAndDecryptFile(ctx context.Context, fileID gocql.UUID, userPassword string, urlDuration time.Duration) (*svc_filedownload.DownloadResult, error) {
+	if d.started != nil {
+		d.started.Done()
+		d.started.Wait()
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return &svc_filedownload.DownloadResult{
+		FileID:            fileID,
+		DecryptedData:     d.content,
+		DecryptedMetadata: &svc_filedownload.DecryptedFileMetadata{Name: "notes.txt", FileExtension: ".txt"},
+		OriginalSize:      int64(len(d.content)),
+	}, nil
+}
+
+func newTestOnloadService(t *testing.T, file dom_file.File, download *staticDownload) (OnloadService, string) {
+	t.Helper()
+	appDataDir := t.TempDir()
+	logger := zap.NewNop()
+	return NewOnloadService(
+		logger,
+		&onloadConfigService{appDataDir: appDataDir},
+		&cloudOnlyFile{file: file},
+		discardFileUpdates{},
+		download,
+		localfile.NewPathUtilsUseCase(logger),
+		localfile.NewCreateDirectoryUseCase(logger),
+		discardJournalEntries{},
+	), filepath.Join(appDataDir, "files", "bin", file.CollectionID.String())
+}
+
+// collectionFiles lists the names of the files in a collection directory
+func collectionFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func TestOnloadExistingLocalCopy(t *testing.T) {
+	ctx := context.Background()
+	file := dom_file.File{ID: gocql.TimeUUID(), CollectionID: gocql.TimeUUID(), SyncStatus: dom_file.SyncStatusCloudOnly}
+
+	tests := []struct {
+		name       string
+		onExisting string
+		want       []string
+	}{
+		{name: "default overwrites", want: []string{file.ID.String() + ".txt"}},
+		{name: "overwrite", onExisting: OnExistingOverwrite, want: []string{file.ID.String() + ".txt"}},
+		{name: "keep both", onExisting: OnExistingKeepBoth, want: []string{file.ID.String() + " (1).txt", file.ID.String() + ".txt"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, dir := newTestOnloadService(t, file, &staticDownload{content: []byte("new content")})
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			existingPath := filepath.Join(dir, file.ID.String()+".txt")
+			if err := os.WriteFile(existingPath, []byte("old content"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			output, err := s.Onload(ctx, &OnloadInput{FileID: file.ID, UserPassword: "password", OnExisting: tt.onExisting})
+			if err != nil {
+				t.Fatalf("Onload() error = %v", err)
+			}
+
+			if got := collectionFiles(t, dir); !slices.Equal(got, tt.want) {
+				t.Errorf("collection directory = %v, want %v", got, tt.want)
+			}
+			onloaded, err := os.ReadFile(output.DecryptedPath)
+			if err != nil || string(onloaded) != "new content" {
+				t.Errorf("onloaded content = %q (%v), want the downloaded content", onloaded, err)
+			}
+		})
+	}
+}
+
+func TestOnloadDecryptionFailure(t *testing.T) {
+	ctx := context.Background()
+	file := dom_file.File{ID: gocql.TimeUUID(), CollectionID: gocql.TimeUUID(), SyncStatus: dom_file.SyncStatusCloudOnly}
+
+	downloadErr := errors.NewAppError("failed to decrypt file key", fmt.Errorf("%w: %w", svc_filedownload.ErrDecryptionFailed, stderrors.New("message authentication failed")))
+	s, _ := newTestOnloadService(t, file, &staticDownload{err: downloadErr})
+
+	_, err := s.Onload(ctx, &OnloadInput{FileID: file.ID, UserPassword: "wrong password"})
+	if !stderrors.Is(err, ErrDecryptionFailed) || !stderrors.Is(err, svc_filedownload.ErrDecryptionFailed) {
+		t.Errorf("Onload() error = %v, want ErrDecryptionFailed of both packages", err)
+	}
+	if stderrors.Is(err, ErrDownloadFailed) {
+		t.Errorf("Onload() error = %v, a bad password is not a download failure", err)
+	}
+}
+
+func TestConcurrentOnloadsOfTheSameFile(t *testing.T) {
+	const onloads = 8
+	ctx := context.Background()
+	file := dom_file.File{ID: gocql.TimeUUID(), CollectionID: gocql.TimeUUID(), SyncStatus: dom_file.SyncStatusCloudOnly}
+
+	// None of the onloads find a local copy, they all download before any of them saves the file
+	started := &sync.WaitGroup{}
+	started.Add(onloads)
+	s, dir := newTestOnloadService(t, file, &staticDownload{content: []byte("content"), started: started})
+
+	var wg sync.WaitGroup
+	paths := make([]string, onloads)
+	errs := make([]error, onloads)
+	for i := range onloads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			output, err := s.Onload(ctx, &OnloadInput{FileID: file.ID, UserPassword: "password"})
+			errs[i] = err
+			if output != nil {
+				paths[i] = output.DecryptedPath
+			}
+		}()
+	}
+	wg.Wait()
+
+	want := filepath.Join(dir, file.ID.String()+".txt")
+	for i := range onloads {
+		if errs[i] != nil {
+			t.Fatalf("Onload() error = %v", errs[i])
+		}
+		if paths[i] != want {
+			t.Errorf("DecryptedPath = %q, want %q", paths[i], want)
+		}
+	}
+	if got := collectionFiles(t, dir); !slices.Equal(got, []string{file.ID.String() + ".txt"}) {
+		t.Errorf("collection directory = %v, want a single copy of the file", got)
+	}
+}
+
+func TestOnloadIgnoresTemporaryFileOfInterruptedOnload(t *testing.T) {
+	ctx := context.Background()
+	file := dom_file.File{ID: gocql.TimeUUID(), CollectionID: gocql.TimeUUID(), SyncStatus: dom_file.SyncStatusCloudOnly}
+
+	s, dir := newTestOnloadService(t, file, &staticDownload{content: []byte("content")})
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// An onload was interrupted after creating its temporary file
+	if err := os.WriteFile(filepath.Join(dir, file.ID.String()+".txt.4041254953.tmp"), []byte("partial"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := s.Onload(ctx, &OnloadInput{FileID: file.ID, UserPassword: "password", OnExisting: OnExistingFail})
+	if err != nil {
+		t.Fatalf("Onload() error = %v, the temporary file is not a local copy", err)
+	}
+	if want := filepath.Join(dir, file.ID.String()+".txt"); output.DecryptedPath != want {
+		t.Errorf("DecryptedPath = %q, want %q", output.DecryptedPath, want)
+	}
+}
+
+func TestIsOnloadTempFile(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "id.pdf.123456.tmp", want: true},
+		{name: "id.123456.tmp", want: true},
+		{name: "id.pdf", want: false},
+		{name: "id.tmp", want: false},
+		{name: "id.draft.tmp", want: false},
+	}
+	for _, tt := range tests {
+		if got := isOnloadTempFile(filepath.Join("dir", tt.name)); got != tt.want {
+			t.Errorf("isOnloadTempFile(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// internal/service/filesyncer/storage_usage.go
+package filesyncer
+
+import (
+	"cmp"
+	"context"
+	"os"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
+)
+
+// StorageUsageInput represents the input for checking the disk usage of the local file store
+type StorageUsageInput struct {
+	// UserPassword is required to auto offload, without it files are only suggested.
+	UserPassword string `json:"user_password,omitempty"`
+}
+
+// StorageUsageFile represents a file suggested for offloading
+type StorageUsageFile struct {
+	FileID         gocql.UUID `json:"file_id"`
+	Name           string     `json:"name"`
+	LocalSize      int64      `json:"local_size"`
+	LastAccessedAt time.Time  `json:"last_accessed_at"`
+}
+
+// StorageUsageOutput represents the disk usage of the local file store against its quota
+type StorageUsageOutput struct {
+	UsedBytes int64 `json:"used_bytes"`
+	// QuotaBytes is zero if no quota is configured.
+	QuotaBytes int64 `json:"quota_bytes"`
+	OverQuota  bool  `json:"over_quota"`
+	// Suggested are the least recently accessed synced files whose offload brings the usage under
+	// the quota, left for the user to offload.
+	Suggested []*StorageUsageFile `json:"suggested,omitempty"`
+	// Offloaded are the files offloaded automatically to stay under the quota.
+	Offloaded     []*StorageUsageFile `json:"offloaded,omitempty"`
+	OffloadErrors int                 `json:"offload_errors"`
+}
+
+// StorageUsageService defines the interface for keeping the local file store under its quota
+type StorageUsageService interface {
+	Check(ctx context.Context, input *StorageUsageInput) (*StorageUsageOutput, error)
+}
+
+// storageUsageService implements the StorageUsageService interface
+type storageUsageService struct {
+	logger           *zap.Logger
+	configService    config.ConfigService
+	listFilesUseCase uc_file.ListFilesUseCase
+	offloadService   OffloadService
+}
+
+// NewStorageUsageService creates a new service for keeping the local file store under its quota
+func NewStorageUsageService(
+	logger *zap.Logger,
+	configService config.ConfigService,
+	listFilesUseCase uc_file.ListFilesUseCase,
+	offloadService OffloadService,
+) StorageUsageService {
+	logger = logger.Named("StorageUsageService")
+	return &storageUsageService{
+		logger:           logger,
+		configService:    configService,
+		listFilesUseCase: listFilesUseCase,
+		offloadService:   offloadService,
+	}
+}
+
+// Check measures the disk space used by the local content of every file against the storage quota.
+// Over the quota, the least recently accessed synced files which bring the usage back under it are
+// suggested for offloading, or offloaded if the quota enables auto offload and a password is given.
+func (s *storageUsageService) Check(ctx context.Context, input *StorageUsageInput) (*StorageUsageOutput, error) {
+	//
+	// STEP 1: Get the quota
+	//
+	if input == nil {
+		input = &StorageUsageInput{}
+	}
+	quota, err := s.configService.GetStorageQuota(ctx)
+	if err != nil {
+		return nil, errors.NewAppError("failed to get storage quota", err)
+	}
+
+	//
+	// STEP 2: Measure the local content of every file
+	//
+	files, err := s.listFilesUseCase.Execute(ctx, dom_file.FileFilter{})
+	if err != nil {
+		s.logger.Error("❌ failed to list local files", zap.Error(err))
+		return nil, errors.NewAppError("failed to list local files", err)
+	}
+
+	output := &StorageUsageOutput{QuotaBytes: quota.MaxBytes}
+	candidates := make([]*StorageUsageFile, 0)
+	for _, file := range files {
+		size := localContentSize(file)
+		output.UsedBytes += size
+		// Only synced files can be offloaded without uploading them first
+		if size > 0 && file.SyncStatus == dom_file.SyncStatusSynced {
+			candidates = append(candidates, &StorageUsageFile{
+				FileID:         file.ID,
+				Name:           file.Name,
+				LocalSize:      size,
+				LastAccessedAt: lastAccessedAt(file),
+			})
+		}
+	}
+
+	if quota.MaxBytes == 0 || output.UsedBytes <= quota.MaxBytes {
+		return output, nil
+	}
+	output.OverQuota = true
+
+	//
+	// STEP 3: Select the least recently accessed files to free the space over the quota
+	//
+	slices.SortStableFunc(candidates, func(a, b *StorageUsageFile) int {
+		return a.LastAccessedAt.Compare(b.LastAccessedAt)
+	})
+
+	excess := output.UsedBytes - quota.MaxBytes
+	for _, candidate := range candidates {
+		if excess <= 0 {
+			break
+		}
+		output.Suggested = append(output.Suggested, candidate)
+		excess -= candidate.LocalSize
+	}
+
+	s.logger.Warn("⚠️ Local file store exceeds its storage quota",
+		zap.Int64("usedBytes", output.UsedBytes),
+		zap.Int64("quotaBytes", quota.MaxBytes),
+		zap.Int("suggestedFiles", len(output.Suggested)))
+
+	if !quota.AutoOffload || input.UserPassword == "" {
+		return output, nil
+	}
+
+	//
+	// STEP 4: Auto offload the selected files
+	//
+	suggested := output.Suggested
+	output.Suggested = nil
+	for _, candidate := range suggested {
+		if _, err := s.offloadService.Offload(ctx, &OffloadInput{
+			FileID:       candidate.FileID,
+			UserPassword: input.UserPassword,
+		}); err != nil {
+			s.logger.Error("❌ failed to auto offload file",
+				zap.String("fileID", candidate.FileID.String()),
+				zap.Error(err))
+			output.OffloadErrors++
+			output.Suggested = append(output.Suggested, candidate)
+			continue
+		}
+		output.Offloaded = append(output.Offloaded, candidate)
+		output.UsedBytes -= candidate.LocalSize
+	}
+	output.OverQuota = output.UsedBytes > quota.MaxBytes
+
+	s.logger.Info("🗑️ Auto offloaded files to stay under the storage quota",
+		zap.Int("offloadedFiles", len(output.Offloaded)),
+		zap.Int("offloadErrors", output.OffloadErrors),
+		zap.Int64("usedBytes", output.UsedBytes))
+
+	return output, nil
+}
+
+// localContentSize returns the disk space used by the local content and thumbnails of a file.
+// Missing files count as empty, as the record may be ahead of the disk.
+func localContentSize(file *dom_file.File) int64 {
+	var size int64
+	for _, path := range []string{file.FilePath, file.EncryptedFilePath, file.ThumbnailPath, file.EncryptedThumbnailPath} {
+		if path == "" {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		}
+	}
+	return size
+}
+
+// lastAccessedAt returns when the local content of a file was last accessed, falling back to its
+// last modification for files onloaded before access times were recorded
+func lastAccessedAt(file *dom_file.File) time.Time {
+	return cmp.Or(file.LastAccessedAt, file.ModifiedAt)
+}
@@ -0,0 +1,112 @@
+// internal/service/filesyncer/thumbnail.go
+package filesyncer
+
+import (
+	"bytes"
+	"image"
+	_ "image/gif" // Register GIF decoder
+	"image/jpeg"
+	_ "image/png" // Register PNG decoder
+	"net/http"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+)
+
+const (
+	thumbnailFormatPNG = "png"
+	thumbnailFormatGIF = "gif"
+
+	thumbnailJPEGQuality = 85
+)
+
+// detectThumbnailFormat returns the image format of the thumbnail bytes, or an empty string if unknown
+func detectThumbnailFormat(data []byte) string {
+	switch http.DetectContentType(data) {
+	case "image/jpeg":
+		return config.ThumbnailFormatJPEG
+	case "image/webp":
+		return config.ThumbnailFormatWebP
+	case "image/png":
+		return thumbnailFormatPNG
+	case "image/gif":
+		return thumbnailFormatGIF
+	default:
+		return ""
+	}
+}
+
+// thumbnailExtension returns the file extension used when storing a thumbnail of the given format
+func thumbnailExtension(format string) string {
+	switch format {
+	case config.ThumbnailFormatWebP:
+		return ".webp"
+	case thumbnailFormatPNG:
+		return ".png"
+	case thumbnailFormatGIF:
+		return ".gif"
+	default:
+		return ".jpg"
+	}
+}
+
+// prepareThumbnail fits the thumbnail within the configured dimensions and converts it to the
+// configured format where possible. It returns the bytes to store along with their actual format.
+// WebP cannot be encoded with the standard library, so WebP thumbnails are only kept as provided.
+func prepareThumbnail(data []byte, settings *config.ThumbnailSettings) ([]byte, string) {
+	format := detectThumbnailFormat(data)
+	if format == "" {
+		// Unknown content, assume the cloud provided the preferred format.
+		return data, settings.Format
+	}
+
+	if settings.Format != config.ThumbnailFormatJPEG || format == config.ThumbnailFormatWebP {
+		return data, format
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return data, format
+	}
+
+	bounds := img.Bounds()
+	if format == config.ThumbnailFormatJPEG && bounds.Dx() <= settings.MaxWidth && bounds.Dy() <= settings.MaxHeight {
+		return data, format
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, resizeToFit(img, settings.MaxWidth, settings.MaxHeight), &jpeg.Options{Quality: thumbnailJPEGQuality}); err != nil {
+		return data, format
+	}
+	return buf.Bytes(), config.ThumbnailFormatJPEG
+}
+
+// resizeToFit scales the image down with nearest-neighbour sampling so it fits within the bounds,
+// preserving its aspect ratio. Images which already fit are returned unchanged.
+func resizeToFit(img image.Image, maxWidth int, maxHeight int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxWidth && height <= maxHeight {
+		return img
+	}
+
+	newWidth, newHeight := maxWidth, height*maxWidth/width
+	if newHeight > maxHeight {
+		newWidth, newHeight = width*maxHeight/height, maxHeight
+	}
+	if newWidth < 1 {
+		newWidth = 1
+	}
+	if newHeight < 1 {
+		newHeight = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, newWidth, newHeight))
+	for y := 0; y < newHeight; y++ {
+		srcY := bounds.Min.Y + y*height/newHeight
+		for x := 0; x < newWidth; x++ {
+			srcX := bounds.Min.X + x*width/newWidth
+			dst.Set(x, y, img.At(srcX, srcY))
+		}
+	}
+	return dst
+}
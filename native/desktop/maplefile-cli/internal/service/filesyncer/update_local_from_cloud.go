@@ -10,6 +10,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
+	uc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collection"
 	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httperror"
 )
@@ -21,11 +22,12 @@ type UpdateLocalFileFromCloudFileService interface {
 
 // updateLocalFileFromCloudFileService implements the UpdateLocalFileFromCloudFileService interface
 type updateLocalFileFromCloudFileService struct {
-	logger            *zap.Logger
-	cloudRepository   filedto.FileDTORepository
-	getFileUseCase    uc_file.GetFileUseCase
-	updateFileUseCase uc_file.UpdateFileUseCase
-	deleteFileUseCase uc_file.DeleteFileUseCase
+	logger               *zap.Logger
+	cloudRepository      filedto.FileDTORepository
+	getFileUseCase       uc_file.GetFileUseCase
+	updateFileUseCase    uc_file.UpdateFileUseCase
+	deleteFileUseCase    uc_file.DeleteFileUseCase
+	getCollectionUseCase uc_collection.GetCollectionUseCase
 }
 
 // NewUpdateLocalFileFromCloudFileService creates a new use case for updating local files from cloud
@@ -35,14 +37,16 @@ func NewUpdateLocalFileFromCloudFileService(
 	getFileUseCase uc_file.GetFileUseCase,
 	updateFileUseCase uc_file.UpdateFileUseCase,
 	deleteFileUseCase uc_file.DeleteFileUseCase,
+	getCollectionUseCase uc_collection.GetCollectionUseCase,
 ) UpdateLocalFileFromCloudFileService {
 	logger = logger.Named("UpdateLocalFileFromCloudFileService")
 	return &updateLocalFileFromCloudFileService{
-		logger:            logger,
-		cloudRepository:   cloudRepository,
-		getFileUseCase:    getFileUseCase,
-		updateFileUseCase: updateFileUseCase,
-		deleteFileUseCase: deleteFileUseCase,
+		logger:               logger,
+		cloudRepository:      cloudRepository,
+		getFileUseCase:       getFileUseCase,
+		updateFileUseCase:    updateFileUseCase,
+		deleteFileUseCase:    deleteFileUseCase,
+		getCollectionUseCase: getCollectionUseCase,
 	}
 }
 
@@ -99,6 +103,18 @@ func (s *updateLocalFileFromCloudFileService) Execute(ctx context.Context, cloud
 		zap.String("file_id", cloudFileID.String()),
 		zap.Any("sync_status", localFile.SyncStatus))
 
+	// Collections with sync disabled only keep their metadata up to date
+	collection, err := s.getCollectionUseCase.Execute(ctx, localFile.CollectionID)
+	if err != nil {
+		return nil, errors.NewAppError("failed to get local collection", err)
+	}
+	if collection != nil && !collection.IsSyncEnabled() {
+		s.logger.Debug("⏭️ Skipping file content update - sync is disabled for the collection",
+			zap.String("file_id", cloudFileID.String()),
+			zap.String("collection_id", localFile.CollectionID.String()))
+		return s.updateMetadataOnly(ctx, cloudFileID, localFile)
+	}
+
 	//
 	// STEP 4: Get the file from cloud for full update
 	//
@@ -107,7 +123,7 @@ func (s *updateLocalFileFromCloudFileService) Execute(ctx context.Context, cloud
 		return nil, errors.NewAppError("failed to get file from the cloud", err)
 	}
 	if cloudFileDTO == nil {
-		err := errors.NewAppError("cloud file not found", nil)
+		err := errors.NewAppError("cloud file not found", ErrFileNotFound)
 		s.logger.Error("❌ Failed to fetch file from cloud",
 			zap.Error(err))
 		return nil, err
@@ -168,6 +184,8 @@ func (s *updateLocalFileFromCloudFileService) Execute(ctx context.Context, cloud
 		cloudFile.MimeType = localFile.MimeType
 	}
 
+	cloudFile.EncryptedHashAlgorithm = hashAlgorithmFromCloud(localFile, cloudFile.EncryptedHash, cloudFile.EncryptedHashAlgorithm)
+
 	// Update the file
 	updateInput := uc_file.UpdateFileInput{
 		ID:                     cloudFile.ID,
@@ -176,6 +194,7 @@ func (s *updateLocalFileFromCloudFileService) Execute(ctx context.Context, cloud
 		EncryptedMetadata:      &cloudFile.EncryptedMetadata,
 		EncryptionVersion:      &cloudFile.EncryptionVersion,
 		EncryptedHash:          &cloudFile.EncryptedHash,
+		EncryptedHashAlgorithm: &cloudFile.EncryptedHashAlgorithm,
 		EncryptedFileSize:      &cloudFile.EncryptedFileSize,
 		EncryptedThumbnailSize: &cloudFile.EncryptedThumbnailSize,
 		ModifiedAt:             &cloudFile.ModifiedAt,
@@ -201,6 +220,16 @@ func (s *updateLocalFileFromCloudFileService) Execute(ctx context.Context, cloud
 	return updatedFile, nil
 }
 
+// hashAlgorithmFromCloud returns the hash algorithm to store with the cloud hash. Files uploaded before
+// the cloud stored the algorithm come back without one, so the local algorithm is kept while the hash
+// is unchanged.
+func hashAlgorithmFromCloud(localFile *dom_file.File, cloudHash, cloudHashAlgorithm string) string {
+	if cloudHashAlgorithm == "" && cloudHash == localFile.EncryptedHash {
+		return localFile.EncryptedHashAlgorithm
+	}
+	return cloudHashAlgorithm
+}
+
 // hasLocalFileContent checks if the local file has actual content downloaded
 // (not just metadata)
 func (s *updateLocalFileFromCloudFileService) hasLocalFileContent(localFile *dom_file.File) bool {
@@ -258,11 +287,13 @@ func (s *updateLocalFileFromCloudFileService) updateMetadataOnly(ctx context.Con
 	}
 
 	// Update only metadata fields, preserve local content references
+	hashAlgorithm := hashAlgorithmFromCloud(localFile, cloudFileDTO.EncryptedHash, cloudFileDTO.EncryptedHashAlgorithm)
 	updateInput := uc_file.UpdateFileInput{
 		ID:                     localFile.ID,
 		EncryptedMetadata:      &cloudFileDTO.EncryptedMetadata,
 		EncryptionVersion:      &cloudFileDTO.EncryptionVersion,
 		EncryptedHash:          &cloudFileDTO.EncryptedHash,
+		EncryptedHashAlgorithm: &hashAlgorithm,
 		EncryptedFileSize:      &cloudFileDTO.EncryptedFileSizeInBytes,
 		EncryptedThumbnailSize: &cloudFileDTO.EncryptedThumbnailSizeInBytes,
 		ModifiedAt:             &cloudFileDTO.ModifiedAt,
@@ -0,0 +1,30 @@
+package filesyncer
+
+import (
+	"testing"
+
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+)
+
+func TestHashAlgorithmFromCloud(t *testing.T) {
+	localFile := &dom_file.File{EncryptedHash: "local-hash", EncryptedHashAlgorithm: "sha256"}
+
+	tests := []struct {
+		name          string
+		cloudHash     string
+		cloudHashAlgo string
+		want          string
+	}{
+		{"cloud algorithm is used", "cloud-hash", "blake2b", "blake2b"},
+		{"cloud algorithm wins for the same hash", "local-hash", "blake2b", "blake2b"},
+		{"local algorithm is kept for the same hash without cloud algorithm", "local-hash", "", "sha256"},
+		{"new hash without algorithm uses the default", "cloud-hash", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hashAlgorithmFromCloud(localFile, tt.cloudHash, tt.cloudHashAlgo); got != tt.want {
+				t.Errorf("hashAlgorithmFromCloud() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ func mapFileDTOToDomain(dto *filedto.FileDTO) *dom_file.File {
 		EncryptedFileKey:       dto.EncryptedFileKey,
 		EncryptionVersion:      dto.EncryptionVersion,
 		EncryptedHash:          dto.EncryptedHash,
+		EncryptedHashAlgorithm: dto.EncryptedHashAlgorithm,
 		EncryptedFileSize:      dto.EncryptedFileSizeInBytes,
 		EncryptedThumbnailSize: dto.EncryptedThumbnailSizeInBytes,
 		Name:                   "[Encrypted]",              // Will be handled later in the execution flow
@@ -0,0 +1,435 @@
+// native/desktop/maplefile-cli/internal/service/fileupload/batch_upload.go
+package fileupload
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/fileupload"
+	dom_user "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
+	svc_collectioncrypto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectioncrypto"
+	uc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collection"
+	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
+	uc_fileupload "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/fileupload"
+	uc_user "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/user"
+	pkg_crypto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+)
+
+// BatchFileUploadInput represents the input for uploading many local files at once
+type BatchFileUploadInput struct {
+	FileIDs      []gocql.UUID `json:"file_ids"`
+	UserPassword string       `json:"user_password"`
+	// Concurrency is the number of files uploaded in parallel, the configured value is used if zero.
+	Concurrency int `json:"concurrency,omitempty"`
+}
+
+// BatchFileUploadOutput represents the result of uploading many local files
+type BatchFileUploadOutput struct {
+	Results      []*fileupload.FileUploadResult `json:"results"`
+	Concurrency  int                            `json:"concurrency"`
+	BatchCount   int                            `json:"batch_count"`
+	SuccessCount int                            `json:"success_count"`
+	FailureCount int                            `json:"failure_count"`
+}
+
+// BatchFileUploadService uploads many local files and registers their metadata in the cloud in
+// batches, where every batch is created atomically.
+type BatchFileUploadService interface {
+	Execute(ctx context.Context, input *BatchFileUploadInput) (*BatchFileUploadOutput, error)
+}
+
+type batchFileUploadService struct {
+	logger                      *zap.Logger
+	configService               config.ConfigService
+	fileDTORepo                 filedto.FileDTORepository
+	getFileUseCase              uc_file.GetFileUseCase
+	updateFileUseCase           uc_file.UpdateFileUseCase
+	prepareUploadUseCase        uc_fileupload.PrepareFileUploadUseCase
+	getUserByLoggedInUseCase    uc_user.GetByIsLoggedInUseCase
+	getCollectionUseCase        uc_collection.GetCollectionUseCase
+	collectionDecryptionService svc_collectioncrypto.CollectionDecryptionService
+}
+
+func NewBatchFileUploadService(
+	logger *zap.Logger,
+	configService config.ConfigService,
+	fileDTORepo filedto.FileDTORepository,
+	getFileUseCase uc_file.GetFileUseCase,
+	updateFileUseCase uc_file.UpdateFileUseCase,
+	prepareUploadUseCase uc_fileupload.PrepareFileUploadUseCase,
+	getUserByLoggedInUseCase uc_user.GetByIsLoggedInUseCase,
+	getCollectionUseCase uc_collection.GetCollectionUseCase,
+	collectionDecryptionService svc_collectioncrypto.CollectionDecryptionService,
+) BatchFileUploadService {
+	logger = logger.Named("BatchFileUploadService")
+	return &batchFileUploadService{
+		logger:                      logger,
+		configService:               configService,
+		fileDTORepo:                 fileDTORepo,
+		getFileUseCase:              getFileUseCase,
+		updateFileUseCase:           updateFileUseCase,
+		prepareUploadUseCase:        prepareUploadUseCase,
+		getUserByLoggedInUseCase:    getUserByLoggedInUseCase,
+		getCollectionUseCase:        getCollectionUseCase,
+		collectionDecryptionService: collectionDecryptionService,
+	}
+}
+
+// uploadBatch is a set of files of the same collection which is registered in one cloud transaction
+type uploadBatch struct {
+	collectionID gocql.UUID
+	files        []*dom_file.File
+	requests     []*filedto.CreatePendingFileRequest
+}
+
+// Execute uploads the requested files. Files are grouped per collection and split into batches of
+// at most `filedto.MaxFileBatchSize`. The content of a batch is uploaded with a pool of workers
+// and then all of its metadata is registered in a single transaction, so a failure midway never
+// leaves part of a batch in the cloud.
+func (s *batchFileUploadService) Execute(ctx context.Context, input *BatchFileUploadInput) (*BatchFileUploadOutput, error) {
+	//
+	// Step 1: Validate inputs
+	//
+	if input == nil {
+		return nil, errors.NewAppError("input is required", nil)
+	}
+	if len(input.FileIDs) == 0 {
+		return nil, errors.NewAppError("at least one file ID is required", nil)
+	}
+	if input.UserPassword == "" {
+		return nil, errors.NewAppError("user password is required for E2EE operations", nil)
+	}
+
+	// Uploads share the transfer concurrency setting with onloads.
+	concurrency := input.Concurrency
+	if concurrency == 0 {
+		configured, err := s.configService.GetMaxConcurrentOnloads(ctx)
+		if err != nil {
+			return nil, errors.NewAppError("failed to get max concurrent transfers", err)
+		}
+		concurrency = configured
+	}
+	if concurrency < config.MinConcurrentOnloads || concurrency > config.MaxConcurrentOnloads {
+		return nil, errors.NewAppError(fmt.Sprintf("concurrency must be between %d and %d", config.MinConcurrentOnloads, config.MaxConcurrentOnloads), nil)
+	}
+
+	user, err := s.getUserByLoggedInUseCase.Execute(ctx)
+	if err != nil {
+		return nil, errors.NewAppError("failed to get logged in user", err)
+	}
+	if user == nil {
+		return nil, errors.NewAppError("user not found", nil)
+	}
+
+	output := &BatchFileUploadOutput{
+		Results:     make([]*fileupload.FileUploadResult, 0, len(input.FileIDs)),
+		Concurrency: concurrency,
+	}
+
+	//
+	// Step 2: Group the local only files per collection
+	//
+	filesByCollection := make(map[gocql.UUID][]*dom_file.File)
+	collectionOrder := make([]gocql.UUID, 0)
+	for _, fileID := range input.FileIDs {
+		file, err := s.getFileUseCase.Execute(ctx, fileID)
+		if err != nil {
+			output.addFailure(fileID, errors.NewAppError("failed to get file", err))
+			continue
+		}
+		if file == nil {
+			output.addFailure(fileID, errors.NewAppError("file not found", nil))
+			continue
+		}
+		if file.SyncStatus != dom_file.SyncStatusLocalOnly {
+			output.addFailure(fileID, errors.NewAppError(fmt.Sprintf("file sync status must be LocalOnly, got: %v", file.SyncStatus), nil))
+			continue
+		}
+		if _, ok := filesByCollection[file.CollectionID]; !ok {
+			collectionOrder = append(collectionOrder, file.CollectionID)
+		}
+		filesByCollection[file.CollectionID] = append(filesByCollection[file.CollectionID], file)
+	}
+
+	//
+	// Step 3: Prepare the upload requests and split them into batches
+	//
+	batches := make([]*uploadBatch, 0)
+	for _, collectionID := range collectionOrder {
+		files := filesByCollection[collectionID]
+		requests, err := s.prepareRequests(ctx, user, collectionID, files, input.UserPassword)
+		if err != nil {
+			for _, file := range files {
+				output.addFailure(file.ID, err)
+			}
+			continue
+		}
+
+		for start := 0; start < len(files); start += filedto.MaxFileBatchSize {
+			end := min(start+filedto.MaxFileBatchSize, len(files))
+			batches = append(batches, &uploadBatch{
+				collectionID: collectionID,
+				files:        files[start:end],
+				requests:     requests[start:end],
+			})
+		}
+	}
+	output.BatchCount = len(batches)
+
+	s.logger.Info("✨ Starting batch file upload",
+		zap.Int("fileCount", len(input.FileIDs)),
+		zap.Int("batchCount", len(batches)),
+		zap.Int("concurrency", concurrency))
+
+	//
+	// Step 4: Upload and register every batch
+	//
+	for _, batch := range batches {
+		if ctx.Err() != nil {
+			for _, file := range batch.files {
+				output.addFailure(file.ID, errors.NewAppError("batch upload cancelled", ctx.Err()))
+			}
+			continue
+		}
+		output.Results = append(output.Results, s.uploadBatch(ctx, batch, concurrency)...)
+	}
+
+	for _, result := range output.Results {
+		if result.Success {
+			output.SuccessCount++
+		} else {
+			output.FailureCount++
+		}
+	}
+
+	s.logger.Info("✅ Batch file upload completed",
+		zap.Int("successCount", output.SuccessCount),
+		zap.Int("failureCount", output.FailureCount))
+
+	return output, nil
+}
+
+// prepareRequests builds the cloud requests of the files of a collection
+func (s *batchFileUploadService) prepareRequests(ctx context.Context, user *dom_user.User, collectionID gocql.UUID, files []*dom_file.File, userPassword string) ([]*filedto.CreatePendingFileRequest, error) {
+	collection, err := s.getCollectionUseCase.Execute(ctx, collectionID)
+	if err != nil {
+		return nil, errors.NewAppError("failed to get collection", err)
+	}
+	if collection == nil {
+		return nil, errors.NewAppError("collection not found", nil)
+	}
+
+	// Decrypt the collection key once for every file of the collection.
+	collectionKey, err := s.collectionDecryptionService.ExecuteDecryptCollectionKeyChain(ctx, user, collection, userPassword)
+	if err != nil {
+		return nil, errors.NewAppError("failed to decrypt collection key chain", err)
+	}
+	defer pkg_crypto.ClearBytes(collectionKey)
+
+	requests := make([]*filedto.CreatePendingFileRequest, 0, len(files))
+	for _, file := range files {
+		request, err := s.prepareUploadUseCase.Execute(ctx, file, collection, collectionKey)
+		if err != nil {
+			return nil, errors.NewAppError("failed to prepare upload request", err)
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+// uploadBatch uploads the content of a batch with a pool of workers and then registers the whole
+// batch in the cloud. The batch is only registered once every upload succeeded, so the cloud never
+// holds records whose content is missing.
+func (s *batchFileUploadService) uploadBatch(ctx context.Context, batch *uploadBatch, concurrency int) []*fileupload.FileUploadResult {
+	results := make([]*fileupload.FileUploadResult, len(batch.files))
+	failBatch := func(err error) []*fileupload.FileUploadResult {
+		for i, file := range batch.files {
+			if results[i] == nil || results[i].Error == nil {
+				results[i] = &fileupload.FileUploadResult{FileID: file.ID, Success: false, Error: err}
+			}
+		}
+		return results
+	}
+
+	//
+	// Step 1: Get the upload URLs of the batch
+	//
+	prepareRequest := &filedto.PrepareFileBatchUploadRequest{
+		CollectionID: batch.collectionID,
+		Files:        make([]*filedto.PrepareFileBatchUploadItem, 0, len(batch.requests)),
+	}
+	for _, request := range batch.requests {
+		prepareRequest.Files = append(prepareRequest.Files, &filedto.PrepareFileBatchUploadItem{
+			ID:                           request.ID,
+			ExpectedThumbnailSizeInBytes: request.ExpectedThumbnailSizeInBytes,
+		})
+	}
+
+	prepareResponse, err := s.fileDTORepo.PrepareFileBatchUploadInCloud(ctx, prepareRequest)
+	if err != nil {
+		s.logger.Error("❌ Failed to prepare batch upload",
+			zap.String("collectionID", batch.collectionID.String()),
+			zap.Error(err))
+		return failBatch(errors.NewAppError("failed to prepare batch upload", err))
+	}
+
+	itemsByFileID := make(map[gocql.UUID]*filedto.PrepareFileBatchUploadItem, len(prepareRequest.Files))
+	for _, item := range prepareRequest.Files {
+		itemsByFileID[item.ID] = item
+	}
+	uploadsByFileID := make(map[gocql.UUID]*filedto.PreparedFileUpload, len(prepareResponse.Uploads))
+	for _, upload := range prepareResponse.Uploads {
+		uploadsByFileID[upload.FileID] = upload
+	}
+
+	//
+	// Step 2: Upload the content with a pool of workers
+	//
+	workers := min(concurrency, len(batch.files))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				file := batch.files[index]
+				result := &fileupload.FileUploadResult{FileID: file.ID}
+				upload, ok := uploadsByFileID[file.ID]
+				if !ok {
+					result.Error = errors.NewAppError("cloud did not return an upload URL for the file", nil)
+				} else {
+					result.FileSizeBytes, result.ThumbnailSizeBytes, result.Error = s.uploadContent(ctx, batch.collectionID, file, itemsByFileID[file.ID], upload)
+				}
+				if result.Error != nil {
+					s.logger.Error("❌ Failed to upload file content in batch",
+						zap.String("fileID", file.ID.String()),
+						zap.Error(result.Error))
+				}
+				results[index] = result
+			}
+		}()
+	}
+	for index := range batch.files {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- index
+	}
+	close(jobs)
+	wg.Wait()
+
+	uploadFailed := false
+	for i, result := range results {
+		if result == nil {
+			results[i] = &fileupload.FileUploadResult{FileID: batch.files[i].ID, Error: errors.NewAppError("batch upload cancelled", ctx.Err())}
+		}
+		if results[i].Error != nil {
+			uploadFailed = true
+		}
+	}
+
+	// Registering the batch now would leave cloud records whose content is missing, and the IDs
+	// would then be taken when the batch is retried.
+	if uploadFailed {
+		return failBatch(errors.NewAppError("another file of the batch failed to upload", nil))
+	}
+
+	//
+	// Step 3: Register the metadata of the whole batch in one transaction
+	//
+	createResponse, err := s.fileDTORepo.CreateFileBatchInCloud(ctx, &filedto.CreateFileBatchRequest{
+		CollectionID: batch.collectionID,
+		Files:        batch.requests,
+	})
+	if err != nil {
+		s.logger.Error("❌ Failed to create file batch in cloud",
+			zap.String("collectionID", batch.collectionID.String()),
+			zap.Error(err))
+		return failBatch(errors.NewAppError("failed to create file batch in cloud", err))
+	}
+	if !createResponse.Success {
+		return failBatch(errors.NewAppError(fmt.Sprintf("cloud rejected file batch: %s", createResponse.Message), nil))
+	}
+
+	//
+	// Step 4: Mark the local files as synced
+	//
+	uploadedAt := time.Now()
+	newStatus := dom_file.SyncStatusSynced
+	for _, result := range results {
+		result.Success = true
+		result.UploadedAt = uploadedAt
+		if _, err := s.updateFileUseCase.Execute(ctx, uc_file.UpdateFileInput{
+			ID:         result.FileID,
+			SyncStatus: &newStatus,
+		}); err != nil {
+			// The upload itself succeeded, so only log the failed status update.
+			s.logger.Error("❌ Failed to update local file after successful batch upload",
+				zap.String("fileID", result.FileID.String()),
+				zap.Error(err))
+		}
+	}
+
+	s.logger.Info("✅ Uploaded file batch",
+		zap.String("collectionID", batch.collectionID.String()),
+		zap.Int("fileCount", len(results)))
+
+	return results
+}
+
+// uploadContent uploads the already encrypted content and thumbnail of a file
+func (s *batchFileUploadService) uploadContent(
+	ctx context.Context,
+	collectionID gocql.UUID,
+	file *dom_file.File,
+	item *filedto.PrepareFileBatchUploadItem,
+	upload *filedto.PreparedFileUpload,
+) (int64, int64, error) {
+	encryptedData, err := os.ReadFile(file.EncryptedFilePath)
+	if err != nil {
+		return 0, 0, errors.NewAppError("failed to read encrypted file", err)
+	}
+	uploader := newBatchPresignedUploader(s.logger, s.fileDTORepo, collectionID, item, upload.PresignedUploadURL, upload.PresignedThumbnailURL)
+	if err := uploader.uploadFile(ctx, encryptedData); err != nil {
+		return 0, 0, errors.NewAppError("failed to upload encrypted file content", err)
+	}
+
+	// The thumbnail is optional, a failure only loses the thumbnail.
+	var thumbnailSize int64
+	if file.EncryptedThumbnailPath != "" && upload.PresignedThumbnailURL != "" {
+		thumbnailData, err := os.ReadFile(file.EncryptedThumbnailPath)
+		if err != nil {
+			s.logger.Warn("⚠️ Failed to read encrypted thumbnail",
+				zap.String("fileID", file.ID.String()),
+				zap.Error(err))
+		} else if err := uploader.uploadThumbnail(ctx, thumbnailData); err != nil {
+			s.logger.Warn("⚠️ Failed to upload encrypted thumbnail",
+				zap.String("fileID", file.ID.String()),
+				zap.Error(err))
+		} else {
+			thumbnailSize = int64(len(thumbnailData))
+		}
+	}
+
+	return int64(len(encryptedData)), thumbnailSize, nil
+}
+
+// addFailure records a file which could not be uploaded
+func (o *BatchFileUploadOutput) addFailure(fileID gocql.UUID, err error) {
+	o.Results = append(o.Results, &fileupload.FileUploadResult{
+		FileID:  fileID,
+		Success: false,
+		Error:   err,
+	})
+}
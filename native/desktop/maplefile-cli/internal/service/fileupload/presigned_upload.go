@@ -0,0 +1,119 @@
+// internal/service/fileupload/presigned_upload.go
+package fileupload
+
+import (
+	"context"
+	stderrors "errors"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
+)
+
+// presignedUploader uploads the content and thumbnail of a file to their presigned URLs. Slow
+// uploads of large files can outlive the URLs, so an upload rejected as expired requests fresh URLs
+// for the file and is retried once instead of failing the whole upload.
+type presignedUploader struct {
+	logger       *zap.Logger
+	fileDTORepo  filedto.FileDTORepository
+	fileID       gocql.UUID
+	fileURL      string
+	thumbnailURL string
+	// fetchURLs requests fresh presigned URLs for the file
+	fetchURLs func(ctx context.Context) (fileURL, thumbnailURL string, err error)
+}
+
+// newPresignedUploader returns an uploader for a file whose metadata is already in the cloud, which
+// gets fresh URLs for the existing file record.
+func newPresignedUploader(logger *zap.Logger, fileDTORepo filedto.FileDTORepository, fileID gocql.UUID, fileURL, thumbnailURL string) *presignedUploader {
+	return &presignedUploader{
+		logger:       logger,
+		fileDTORepo:  fileDTORepo,
+		fileID:       fileID,
+		fileURL:      fileURL,
+		thumbnailURL: thumbnailURL,
+		fetchURLs: func(ctx context.Context) (string, string, error) {
+			response, err := fileDTORepo.GetPresignedUploadURLFromCloud(ctx, fileID, &filedto.GetPresignedUploadURLRequest{})
+			if err != nil || response == nil {
+				return "", "", err
+			}
+			return response.PresignedUploadURL, response.PresignedThumbnailURL, nil
+		},
+	}
+}
+
+// newBatchPresignedUploader returns an uploader for a file of a batch. Batch uploads register the
+// metadata only after the content is uploaded, so there is no file record to get fresh URLs for and
+// the file is prepared again on its own instead.
+func newBatchPresignedUploader(logger *zap.Logger, fileDTORepo filedto.FileDTORepository, collectionID gocql.UUID, item *filedto.PrepareFileBatchUploadItem, fileURL, thumbnailURL string) *presignedUploader {
+	return &presignedUploader{
+		logger:       logger,
+		fileDTORepo:  fileDTORepo,
+		fileID:       item.ID,
+		fileURL:      fileURL,
+		thumbnailURL: thumbnailURL,
+		fetchURLs: func(ctx context.Context) (string, string, error) {
+			response, err := fileDTORepo.PrepareFileBatchUploadInCloud(ctx, &filedto.PrepareFileBatchUploadRequest{
+				CollectionID: collectionID,
+				Files:        []*filedto.PrepareFileBatchUploadItem{item},
+			})
+			if err != nil || response == nil {
+				return "", "", err
+			}
+			for _, upload := range response.Uploads {
+				if upload.FileID == item.ID {
+					return upload.PresignedUploadURL, upload.PresignedThumbnailURL, nil
+				}
+			}
+			return "", "", nil
+		},
+	}
+}
+
+// uploadFile uploads the encrypted file content
+func (u *presignedUploader) uploadFile(ctx context.Context, data []byte) error {
+	err := u.fileDTORepo.UploadFileToCloud(ctx, u.fileURL, data)
+	if !stderrors.Is(err, filedto.ErrPresignedURLExpired) {
+		return err
+	}
+	if err := u.refresh(ctx, err); err != nil {
+		return err
+	}
+	return u.fileDTORepo.UploadFileToCloud(ctx, u.fileURL, data)
+}
+
+// uploadThumbnail uploads the encrypted thumbnail, using the refreshed thumbnail URL if the file
+// content needed fresh URLs
+func (u *presignedUploader) uploadThumbnail(ctx context.Context, data []byte) error {
+	err := u.fileDTORepo.UploadThumbnailToCloud(ctx, u.thumbnailURL, data)
+	if !stderrors.Is(err, filedto.ErrPresignedURLExpired) {
+		return err
+	}
+	if err := u.refresh(ctx, err); err != nil {
+		return err
+	}
+	return u.fileDTORepo.UploadThumbnailToCloud(ctx, u.thumbnailURL, data)
+}
+
+// refresh replaces the presigned URLs with fresh ones after an upload was rejected with cause
+func (u *presignedUploader) refresh(ctx context.Context, cause error) error {
+	u.logger.Warn("⏰ Presigned upload URL expired, requesting a fresh one",
+		zap.String("fileID", u.fileID.String()),
+		zap.Error(cause))
+
+	fileURL, thumbnailURL, err := u.fetchURLs(ctx)
+	if err != nil {
+		return errors.NewAppError("failed to refresh expired presigned upload URL", err)
+	}
+	if fileURL == "" {
+		return errors.NewAppError("failed to refresh expired presigned upload URL", cause)
+	}
+
+	u.fileURL = fileURL
+	if thumbnailURL != "" {
+		u.thumbnailURL = thumbnailURL
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package fileupload
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
+)
+
+// fakeUploadCloud rejects uploads to expired URLs and hands out fresh ones. Only the upload and
+// URL calls are implemented.
+type fakeUploadCloud struct {
+	filedto.FileDTORepository
+	expiredURL string
+	uploadedTo []string
+	prepared   []*filedto.PrepareFileBatchUploadRequest
+	refreshed  []gocql.UUID
+}
+
+func (f *fakeUploadCloud) UploadFileToCloud(ctx context.Context, presignedURL string, data []byte) error {
+	if presignedURL == f.expiredURL {
+		return filedto.ErrPresignedURLExpired
+	}
+	f.uploadedTo = append(f.uploadedTo, presignedURL)
+	return nil
+}
+
+func (f *fakeUploadCloud) PrepareFileBatchUploadInCloud(ctx context.Context, request *filedto.PrepareFileBatchUploadRequest) (*filedto.PrepareFileBatchUploadResponse, error) {
+	f.prepared = append(f.prepared, request)
+	uploads := make([]*filedto.PreparedFileUpload, 0, len(request.Files))
+	for _, item := range request.Files {
+		uploads = append(uploads, &filedto.PreparedFileUpload{FileID: item.ID, PresignedUploadURL: "https://fresh/" + item.ID.String()})
+	}
+	return &filedto.PrepareFileBatchUploadResponse{Uploads: uploads, Success: true}, nil
+}
+
+func (f *fakeUploadCloud) GetPresignedUploadURLFromCloud(ctx context.Context, fileID gocql.UUID, request *filedto.GetPresignedUploadURLRequest) (*filedto.GetPresignedUploadURLResponse, error) {
+	f.refreshed = append(f.refreshed, fileID)
+	return &filedto.GetPresignedUploadURLResponse{PresignedUploadURL: "https://fresh/" + fileID.String()}, nil
+}
+
+func TestPresignedUploaderRefreshesExpiredURL(t *testing.T) {
+	ctx := context.Background()
+	collectionID, fileID := gocql.TimeUUID(), gocql.TimeUUID()
+	freshURL := "https://fresh/" + fileID.String()
+
+	t.Run("Batch upload prepares the file again", func(t *testing.T) {
+		cloud := &fakeUploadCloud{expiredURL: "https://expired"}
+		item := &filedto.PrepareFileBatchUploadItem{ID: fileID, ExpectedThumbnailSizeInBytes: 10}
+		uploader := newBatchPresignedUploader(zap.NewNop(), cloud, collectionID, item, "https://expired", "")
+
+		if err := uploader.uploadFile(ctx, []byte("content")); err != nil {
+			t.Fatalf("uploadFile() error = %v", err)
+		}
+		if len(cloud.refreshed) != 0 {
+			t.Errorf("asked URLs for an existing file %d times, the file has no metadata in the cloud yet", len(cloud.refreshed))
+		}
+		if len(cloud.prepared) != 1 || cloud.prepared[0].CollectionID != collectionID || len(cloud.prepared[0].Files) != 1 || cloud.prepared[0].Files[0] != item {
+			t.Fatalf("prepared = %+v, want the file prepared again on its own", cloud.prepared)
+		}
+		if len(cloud.uploadedTo) != 1 || cloud.uploadedTo[0] != freshURL {
+			t.Errorf("uploaded to %v, want %s", cloud.uploadedTo, freshURL)
+		}
+	})
+
+	t.Run("Single upload gets URLs for the existing file", func(t *testing.T) {
+		cloud := &fakeUploadCloud{expiredURL: "https://expired"}
+		uploader := newPresignedUploader(zap.NewNop(), cloud, fileID, "https://expired", "")
+
+		if err := uploader.uploadFile(ctx, []byte("content")); err != nil {
+			t.Fatalf("uploadFile() error = %v", err)
+		}
+		if len(cloud.prepared) != 0 || len(cloud.refreshed) != 1 {
+			t.Errorf("prepared %d times and refreshed %d times, want only a refresh", len(cloud.prepared), len(cloud.refreshed))
+		}
+		if len(cloud.uploadedTo) != 1 || cloud.uploadedTo[0] != freshURL {
+			t.Errorf("uploaded to %v, want %s", cloud.uploadedTo, freshURL)
+		}
+	})
+}
+
+// fakeBatchCloud records the batches registered in the cloud
+type fakeBatchCloud struct {
+	fakeUploadCloud
+	created []*filedto.CreateFileBatchRequest
+}
+
+func (f *fakeBatchCloud) CreateFileBatchInCloud(ctx context.Context, request *filedto.CreateFileBatchRequest) (*filedto.CreateFileBatchResponse, error) {
+	f.created = append(f.created, request)
+	return &filedto.CreateFileBatchResponse{Success: true}, nil
+}
+
+func TestUploadBatchNotRegisteredAfterFailedUpload(t *testing.T) {
+	dir := t.TempDir()
+	uploaded := filepath.Join(dir, "uploaded.enc")
+	if err := os.WriteFile(uploaded, []byte("content"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	collectionID := gocql.TimeUUID()
+	batch := &uploadBatch{collectionID: collectionID}
+	// The second file's encrypted content is missing, so its upload fails
+	for _, path := range []string{uploaded, filepath.Join(dir, "missing.enc")} {
+		file := &dom_file.File{ID: gocql.TimeUUID(), CollectionID: collectionID, EncryptedFilePath: path}
+		batch.files = append(batch.files, file)
+		batch.requests = append(batch.requests, &filedto.CreatePendingFileRequest{ID: file.ID, CollectionID: collectionID})
+	}
+
+	cloud := &fakeBatchCloud{}
+	service := &batchFileUploadService{logger: zap.NewNop(), fileDTORepo: cloud}
+	results := service.uploadBatch(context.Background(), batch, 2)
+
+	if len(cloud.created) != 0 {
+		t.Fatalf("registered %d batches in the cloud, want none after a failed upload", len(cloud.created))
+	}
+	for _, result := range results {
+		if result.Success || result.Error == nil {
+			t.Errorf("result of file %s = success %v, error %v, want a failure", result.FileID, result.Success, result.Error)
+		}
+	}
+}
@@ -302,7 +302,8 @@ func (s *fileUploadService) uploadEncryptedContent(ctx context.Context, file *do
 	}
 
 	// Upload encrypted data directly (no re-encryption)
-	if err := s.fileDTORepo.UploadFileToCloud(ctx, pendingResponse.PresignedUploadURL, encryptedData); err != nil {
+	uploader := newPresignedUploader(s.logger, s.fileDTORepo, file.ID, pendingResponse.PresignedUploadURL, pendingResponse.PresignedThumbnailURL)
+	if err := uploader.uploadFile(ctx, encryptedData); err != nil {
 		return 0, 0, errors.NewAppError("failed to upload encrypted file content", err)
 	}
 
@@ -315,7 +316,7 @@ func (s *fileUploadService) uploadEncryptedContent(ctx context.Context, file *do
 				zap.String("fileID", file.ID.String()),
 				zap.Error(err))
 		} else {
-			if err := s.fileDTORepo.UploadThumbnailToCloud(ctx, pendingResponse.PresignedThumbnailURL, thumbnailData); err != nil {
+			if err := uploader.uploadThumbnail(ctx, thumbnailData); err != nil {
 				s.logger.Warn("⚠️ Failed to upload encrypted thumbnail",
 					zap.String("fileID", file.ID.String()),
 					zap.Error(err))
@@ -0,0 +1,78 @@
+// internal/service/journal/show.go
+package journal
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
+	uc_journal "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/journal"
+)
+
+// ShowOutput represents the entries of the local operation journal
+type ShowOutput struct {
+	Entries []*journal.Entry `json:"entries"`
+	// TotalEntries is the number of entries kept in the journal, regardless of the filter.
+	TotalEntries int `json:"total_entries"`
+	MaxEntries   int `json:"max_entries"`
+}
+
+// ShowService defines the interface for showing the local operation journal
+type ShowService interface {
+	Show(ctx context.Context, filter *journal.Filter) (*ShowOutput, error)
+}
+
+// showService implements the ShowService interface
+type showService struct {
+	logger              *zap.Logger
+	configService       config.ConfigService
+	listEntriesUseCase  uc_journal.ListEntriesUseCase
+	countEntriesUseCase uc_journal.CountEntriesUseCase
+}
+
+// NewShowService creates a new service for showing the local operation journal
+func NewShowService(
+	logger *zap.Logger,
+	configService config.ConfigService,
+	listEntriesUseCase uc_journal.ListEntriesUseCase,
+	countEntriesUseCase uc_journal.CountEntriesUseCase,
+) ShowService {
+	logger = logger.Named("JournalShowService")
+	return &showService{
+		logger:              logger,
+		configService:       configService,
+		listEntriesUseCase:  listEntriesUseCase,
+		countEntriesUseCase: countEntriesUseCase,
+	}
+}
+
+// Show returns the journal entries matching the filter, oldest first
+func (s *showService) Show(ctx context.Context, filter *journal.Filter) (*ShowOutput, error) {
+	s.logger.Debug("🔍 Showing local operation journal")
+
+	entries, err := s.listEntriesUseCase.Execute(ctx, filter)
+	if err != nil {
+		s.logger.Error("❌ failed to list journal entries", zap.Error(err))
+		return nil, err
+	}
+
+	total, err := s.countEntriesUseCase.Execute(ctx)
+	if err != nil {
+		s.logger.Error("❌ failed to count journal entries", zap.Error(err))
+		return nil, err
+	}
+
+	maxEntries, err := s.configService.GetJournalMaxEntries(ctx)
+	if err != nil {
+		return nil, errors.NewAppError("failed to get journal size", err)
+	}
+
+	return &ShowOutput{
+		Entries:      entries,
+		TotalEntries: total,
+		MaxEntries:   maxEntries,
+	}, nil
+}
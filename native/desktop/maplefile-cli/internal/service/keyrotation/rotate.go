@@ -0,0 +1,657 @@
+// internal/service/keyrotation/rotate.go
+package keyrotation
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	dom_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collectiondto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/medto"
+	dom_user "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/security"
+	uc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collection"
+	uc_collectiondto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collectiondto"
+	uc_medto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/medto"
+	uc_user "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/user"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+)
+
+// RotateMasterKeyInput represents the input for rotating the master key
+type RotateMasterKeyInput struct {
+	UserPassword string `json:"user_password"`
+}
+
+// RotateMasterKeyOutput represents the result of rotating the master key
+type RotateMasterKeyOutput struct {
+	// Resumed is set when an interrupted rotation was completed instead of starting a new one.
+	Resumed bool `json:"resumed"`
+	// CollectionsRotated counts the collection keys re-wrapped by this run.
+	CollectionsRotated int `json:"collections_rotated"`
+	// CollectionsUnchanged counts the collection keys already wrapped under the new master key.
+	CollectionsUnchanged int `json:"collections_unchanged"`
+	// CollectionsFailed counts the collection keys which could not be decrypted with either master
+	// key and were left as they are.
+	CollectionsFailed int `json:"collections_failed"`
+	KeyVersion        int `json:"key_version"`
+}
+
+// MasterKeyRotationService defines the interface for rotating the master key of the logged in user
+type MasterKeyRotationService interface {
+	Rotate(ctx context.Context, input *RotateMasterKeyInput) (*RotateMasterKeyOutput, error)
+	// Pending returns the rotation left unfinished by an earlier run, or nil if there is none.
+	Pending(ctx context.Context) (*KeyRotationState, error)
+}
+
+// masterKeyRotationService implements the MasterKeyRotationService interface
+type masterKeyRotationService struct {
+	logger                                      *zap.Logger
+	userRepo                                    dom_user.Repository
+	getByIsLoggedInUseCase                      uc_user.GetByIsLoggedInUseCase
+	upsertByEmailUseCase                        uc_user.UpsertByEmailUseCase
+	getCollectionUseCase                        uc_collection.GetCollectionUseCase
+	listCollectionsUseCase                      uc_collection.ListCollectionsUseCase
+	updateCollectionUseCase                     uc_collection.UpdateCollectionUseCase
+	getFilteredCollectionsFromCloudUseCase      uc_collectiondto.GetFilteredCollectionsFromCloudUseCase
+	getRotateMasterKeyChallengeFromCloudUseCase uc_medto.GetRotateMasterKeyChallengeFromCloudUseCase
+	rotateMasterKeyInCloudUseCase               uc_medto.RotateMasterKeyInCloudUseCase
+	stateManager                                KeyRotationStateManager
+	cryptoAuditService                          security.CryptoAuditService
+}
+
+// NewMasterKeyRotationService creates a new service for rotating the master key
+func NewMasterKeyRotationService(
+	logger *zap.Logger,
+	userRepo dom_user.Repository,
+	getByIsLoggedInUseCase uc_user.GetByIsLoggedInUseCase,
+	upsertByEmailUseCase uc_user.UpsertByEmailUseCase,
+	getCollectionUseCase uc_collection.GetCollectionUseCase,
+	listCollectionsUseCase uc_collection.ListCollectionsUseCase,
+	updateCollectionUseCase uc_collection.UpdateCollectionUseCase,
+	getFilteredCollectionsFromCloudUseCase uc_collectiondto.GetFilteredCollectionsFromCloudUseCase,
+	getRotateMasterKeyChallengeFromCloudUseCase uc_medto.GetRotateMasterKeyChallengeFromCloudUseCase,
+	rotateMasterKeyInCloudUseCase uc_medto.RotateMasterKeyInCloudUseCase,
+	stateManager KeyRotationStateManager,
+	cryptoAuditService security.CryptoAuditService,
+) MasterKeyRotationService {
+	logger = logger.Named("MasterKeyRotationService")
+	return &masterKeyRotationService{
+		logger:                                 logger,
+		userRepo:                               userRepo,
+		getByIsLoggedInUseCase:                 getByIsLoggedInUseCase,
+		upsertByEmailUseCase:                   upsertByEmailUseCase,
+		getCollectionUseCase:                   getCollectionUseCase,
+		listCollectionsUseCase:                 listCollectionsUseCase,
+		updateCollectionUseCase:                updateCollectionUseCase,
+		getFilteredCollectionsFromCloudUseCase: getFilteredCollectionsFromCloudUseCase,
+		getRotateMasterKeyChallengeFromCloudUseCase: getRotateMasterKeyChallengeFromCloudUseCase,
+		rotateMasterKeyInCloudUseCase:               rotateMasterKeyInCloudUseCase,
+		stateManager:                                stateManager,
+		cryptoAuditService:                          cryptoAuditService,
+	}
+}
+
+// Pending returns the rotation left unfinished by an earlier run
+func (s *masterKeyRotationService) Pending(ctx context.Context) (*KeyRotationState, error) {
+	return s.stateManager.LoadState(ctx)
+}
+
+// Rotate replaces the master key of the logged in user with a new random one. The keys wrapped
+// under the master key are re-wrapped under the new one: the collection keys owned by the user, the
+// private key and the recovery key. The new master key is wrapped under the key encryption key of
+// the unchanged password and under the recovery key. File keys are wrapped under collection keys,
+// which do not change, so they need no re-encryption.
+//
+// The cloud only accepts the new keys with the answer to a challenge sealed with the public key of
+// the account, and it replaces the keys of the account and of its collections in the same request,
+// so other devices don't find collection keys wrapped under a master key the account doesn't have
+// while a rotation is interrupted. Every step is idempotent and the progress is persisted, so a rotation which is
+// interrupted is completed by running it again with the same password. The local account is updated
+// in a transaction.
+func (s *masterKeyRotationService) Rotate(ctx context.Context, input *RotateMasterKeyInput) (*RotateMasterKeyOutput, error) {
+	//
+	// STEP 1: Validate inputs and get the logged in user
+	//
+	if input == nil || input.UserPassword == "" {
+		return nil, errors.NewAppError("password is required", nil)
+	}
+
+	user, err := s.getByIsLoggedInUseCase.Execute(ctx)
+	if err != nil {
+		return nil, errors.NewAppError("failed to get logged in user", err)
+	}
+	if user == nil {
+		return nil, errors.NewAppError("no user is logged in", nil)
+	}
+
+	//
+	// STEP 2: Derive key encryption key from password
+	//
+	keyEncryptionKey, err := crypto.DeriveKeyFromPassword(input.UserPassword, user.PasswordSalt)
+	if err != nil {
+		return nil, errors.NewAppError("failed to derive key from password", err)
+	}
+	defer crypto.ClearBytes(keyEncryptionKey)
+
+	//
+	// STEP 3: Start a new rotation or resume the one in progress
+	//
+	state, err := s.stateManager.LoadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &RotateMasterKeyOutput{Resumed: state != nil}
+	if state == nil {
+		if state, err = s.startRotation(ctx, user, keyEncryptionKey); err != nil {
+			return nil, err
+		}
+	} else if state.UserID != user.ID {
+		return nil, errors.NewAppError("a master key rotation for "+state.Email+" is in progress, log in as that user to complete it", nil)
+	}
+
+	oldMasterKey, err := crypto.DecryptWithSecretBox(state.OldEncryptedMasterKey.Ciphertext, state.OldEncryptedMasterKey.Nonce, keyEncryptionKey)
+	if err != nil {
+		s.cryptoAuditService.LogCryptoOperation(ctx, &security.CryptoAuditEvent{
+			Operation:    "rotate_master_key_decrypt_master",
+			UserID:       user.ID.String(),
+			Success:      false,
+			ErrorMessage: "incorrect password or corrupted master key",
+		})
+		return nil, errors.NewAppError("incorrect password", nil)
+	}
+	defer crypto.ClearBytes(oldMasterKey)
+
+	newMasterKey, err := crypto.DecryptWithSecretBox(state.NewEncryptedMasterKey.Ciphertext, state.NewEncryptedMasterKey.Nonce, keyEncryptionKey)
+	if err != nil {
+		return nil, errors.NewAppError("failed to decrypt new master key of the rotation in progress", err)
+	}
+	defer crypto.ClearBytes(newMasterKey)
+
+	s.logger.Info("🔄 Rotating master key",
+		zap.String("email", user.Email),
+		zap.String("stage", state.Stage),
+		zap.Bool("resumed", output.Resumed))
+
+	//
+	// STEP 4: Re-wrap the collection keys owned by the user
+	//
+	var collectionKeys []*medto.RotatedCollectionKeyDTO
+	if state.Stage == StageCollections {
+		if collectionKeys, err = s.rewrapCollections(ctx, user, state, oldMasterKey, newMasterKey, output); err != nil {
+			return nil, err
+		}
+	}
+
+	//
+	// STEP 5: Prove the current keys of the account to the cloud
+	//
+	challengeID, decryptedChallenge, err := s.answerChallenge(ctx, user, state, newMasterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	//
+	// STEP 6: Replace the wrapped keys of the account and its collections in the cloud in one request,
+	// then locally
+	//
+	response, err := s.rotateMasterKeyInCloudUseCase.Execute(ctx, &medto.RotateMasterKeyRequestDTO{
+		ChallengeID:                          challengeID,
+		DecryptedChallenge:                   decryptedChallenge,
+		NewEncryptedMasterKey:                encodeWrapped(state.NewEncryptedMasterKey.Nonce, state.NewEncryptedMasterKey.Ciphertext),
+		NewEncryptedPrivateKey:               encodeWrapped(state.NewEncryptedPrivateKey.Nonce, state.NewEncryptedPrivateKey.Ciphertext),
+		NewEncryptedRecoveryKey:              encodeWrapped(state.NewEncryptedRecoveryKey.Nonce, state.NewEncryptedRecoveryKey.Ciphertext),
+		NewMasterKeyEncryptedWithRecoveryKey: encodeWrapped(state.NewMasterKeyEncryptedWithRecoveryKey.Nonce, state.NewMasterKeyEncryptedWithRecoveryKey.Ciphertext),
+		CollectionKeys:                       collectionKeys,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Stage == StageCollections {
+		if err := s.updateLocalCollections(ctx, state, collectionKeys, response.Collections, output); err != nil {
+			return nil, err
+		}
+		state.Stage = StageAccount
+		if err := s.stateManager.SaveState(ctx, state); err != nil {
+			return nil, err
+		}
+	}
+	output.KeyVersion = response.KeyVersion
+
+	if err := s.updateLocalUser(ctx, user.Email, state, keyEncryptionKey, newMasterKey, response.KeyVersion); err != nil {
+		return nil, err
+	}
+
+	//
+	// STEP 7: Clear the state of the finished rotation
+	//
+	if err := s.stateManager.ClearState(ctx); err != nil {
+		return nil, err
+	}
+
+	s.cryptoAuditService.LogKeyOperation(ctx, &security.CryptoAuditEvent{
+		Operation: "rotate_master_key",
+		UserID:    user.ID.String(),
+		Success:   true,
+		Metadata: map[string]interface{}{
+			"key_version":         response.KeyVersion,
+			"collections_rotated": output.CollectionsRotated,
+			"resumed":             output.Resumed,
+		},
+	})
+
+	s.logger.Info("✅ Master key rotated successfully",
+		zap.String("email", user.Email),
+		zap.Int("keyVersion", response.KeyVersion),
+		zap.Int("collectionsRotated", output.CollectionsRotated),
+		zap.Int("collectionsUnchanged", output.CollectionsUnchanged),
+		zap.Int("collectionsFailed", output.CollectionsFailed))
+
+	return output, nil
+}
+
+// startRotation generates the new master key, wraps the keys of the account under it and persists
+// them as the state of a new rotation
+func (s *masterKeyRotationService) startRotation(ctx context.Context, user *dom_user.User, keyEncryptionKey []byte) (*KeyRotationState, error) {
+	masterKey, err := crypto.DecryptWithSecretBox(user.EncryptedMasterKey.Ciphertext, user.EncryptedMasterKey.Nonce, keyEncryptionKey)
+	if err != nil {
+		s.cryptoAuditService.LogCryptoOperation(ctx, &security.CryptoAuditEvent{
+			Operation:    "rotate_master_key_decrypt_master",
+			UserID:       user.ID.String(),
+			Success:      false,
+			ErrorMessage: "incorrect password or corrupted master key",
+		})
+		return nil, errors.NewAppError("incorrect password", nil)
+	}
+	defer crypto.ClearBytes(masterKey)
+
+	privateKey, err := crypto.DecryptWithSecretBox(user.EncryptedPrivateKey.Ciphertext, user.EncryptedPrivateKey.Nonce, masterKey)
+	if err != nil {
+		return nil, errors.NewAppError("failed to decrypt private key", err)
+	}
+	defer crypto.ClearBytes(privateKey)
+
+	recoveryKey, err := crypto.DecryptWithSecretBox(user.EncryptedRecoveryKey.Ciphertext, user.EncryptedRecoveryKey.Nonce, masterKey)
+	if err != nil {
+		return nil, errors.NewAppError("failed to decrypt recovery key", err)
+	}
+	defer crypto.ClearBytes(recoveryKey)
+
+	newMasterKey, err := crypto.GenerateRandomBytes(crypto.MasterKeySize)
+	if err != nil {
+		return nil, errors.NewAppError("failed to generate master key", err)
+	}
+	defer crypto.ClearBytes(newMasterKey)
+
+	encryptedMasterKey, err := crypto.EncryptWithSecretBox(newMasterKey, keyEncryptionKey)
+	if err != nil {
+		return nil, errors.NewAppError("failed to encrypt new master key", err)
+	}
+	encryptedPrivateKey, err := crypto.EncryptWithSecretBox(privateKey, newMasterKey)
+	if err != nil {
+		return nil, errors.NewAppError("failed to encrypt private key with new master key", err)
+	}
+	encryptedRecoveryKey, err := crypto.EncryptWithSecretBox(recoveryKey, newMasterKey)
+	if err != nil {
+		return nil, errors.NewAppError("failed to encrypt recovery key with new master key", err)
+	}
+	masterKeyEncryptedWithRecoveryKey, err := crypto.EncryptWithSecretBox(newMasterKey, recoveryKey)
+	if err != nil {
+		return nil, errors.NewAppError("failed to encrypt new master key with recovery key", err)
+	}
+
+	state := &KeyRotationState{
+		UserID: user.ID,
+		Email:  user.Email,
+		Stage:  StageCollections,
+		OldEncryptedMasterKey: keys.EncryptedMasterKey{
+			Ciphertext: user.EncryptedMasterKey.Ciphertext,
+			Nonce:      user.EncryptedMasterKey.Nonce,
+			KeyVersion: user.EncryptedMasterKey.KeyVersion,
+		},
+		NewEncryptedMasterKey: keys.EncryptedMasterKey{
+			Ciphertext: encryptedMasterKey.Ciphertext,
+			Nonce:      encryptedMasterKey.Nonce,
+		},
+		NewEncryptedPrivateKey: keys.EncryptedPrivateKey{
+			Ciphertext: encryptedPrivateKey.Ciphertext,
+			Nonce:      encryptedPrivateKey.Nonce,
+		},
+		NewEncryptedRecoveryKey: keys.EncryptedRecoveryKey{
+			Ciphertext: encryptedRecoveryKey.Ciphertext,
+			Nonce:      encryptedRecoveryKey.Nonce,
+		},
+		NewMasterKeyEncryptedWithRecoveryKey: keys.MasterKeyEncryptedWithRecoveryKey{
+			Ciphertext: masterKeyEncryptedWithRecoveryKey.Ciphertext,
+			Nonce:      masterKeyEncryptedWithRecoveryKey.Nonce,
+		},
+		StartedAt: time.Now(),
+	}
+	if err := s.stateManager.SaveState(ctx, state); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("🔑 Generated new master key, starting rotation", zap.String("email", user.Email))
+	return state, nil
+}
+
+// rewrapCollections re-wraps the key of every collection owned by the user. The keys of the
+// collections in the cloud are returned to be submitted with the account keys; those which are
+// already current in the cloud are copied to the local collections. The collections which only
+// exist locally are re-wrapped in place. The progress is saved after each local update.
+func (s *masterKeyRotationService) rewrapCollections(
+	ctx context.Context,
+	user *dom_user.User,
+	state *KeyRotationState,
+	oldMasterKey, newMasterKey []byte,
+	output *RotateMasterKeyOutput,
+) ([]*medto.RotatedCollectionKeyDTO, error) {
+	localCollections, err := s.listCollectionsUseCase.Execute(ctx, dom_collection.CollectionFilter{IncludeDeleted: true})
+	if err != nil {
+		return nil, errors.NewAppError("failed to list local collections", err)
+	}
+	localByID := make(map[gocql.UUID]*dom_collection.Collection, len(localCollections))
+	for _, collection := range localCollections {
+		localByID[collection.ID] = collection
+	}
+
+	cloudCollections, err := s.getFilteredCollectionsFromCloudUseCase.Execute(ctx, &collectiondto.GetFilteredCollectionsRequest{IncludeOwned: true})
+	if err != nil {
+		return nil, errors.NewAppError("failed to list owned collections in the cloud", err)
+	}
+
+	inCloud := make(map[gocql.UUID]bool, len(cloudCollections.OwnedCollections))
+	var collectionKeys []*medto.RotatedCollectionKeyDTO
+	for _, cloudCollection := range cloudCollections.OwnedCollections {
+		inCloud[cloudCollection.ID] = true
+		if state.IsCollectionRotated(cloudCollection.ID) {
+			continue
+		}
+
+		rewrapped, err := rewrapCollectionKey(cloudCollection.EncryptedCollectionKey, oldMasterKey, newMasterKey)
+		if err != nil {
+			s.logger.Warn("⚠️ Skipping collection whose key cannot be decrypted",
+				zap.String("collectionID", cloudCollection.ID.String()),
+				zap.Error(err))
+			output.CollectionsFailed++
+			continue
+		}
+
+		if rewrapped != nil {
+			collectionKeys = append(collectionKeys, &medto.RotatedCollectionKeyDTO{
+				CollectionID:           cloudCollection.ID,
+				EncryptedCollectionKey: rewrapped,
+				Version:                cloudCollection.Version,
+			})
+			continue
+		}
+
+		// Updated in the cloud by the run which was interrupted
+		output.CollectionsUnchanged++
+		if _, ok := localByID[cloudCollection.ID]; ok {
+			version := cloudCollection.Version
+			if _, err := s.updateCollectionUseCase.Execute(ctx, uc_collection.UpdateCollectionInput{
+				ID:                     cloudCollection.ID,
+				EncryptedCollectionKey: cloudCollection.EncryptedCollectionKey,
+				Version:                &version,
+			}); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.markCollectionRotated(ctx, state, cloudCollection.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, localCollection := range localCollections {
+		if localCollection.OwnerID != user.ID || inCloud[localCollection.ID] || state.IsCollectionRotated(localCollection.ID) {
+			continue
+		}
+
+		rewrapped, err := rewrapCollectionKey(localCollection.EncryptedCollectionKey, oldMasterKey, newMasterKey)
+		if err != nil {
+			s.logger.Warn("⚠️ Skipping local collection whose key cannot be decrypted",
+				zap.String("collectionID", localCollection.ID.String()),
+				zap.Error(err))
+			output.CollectionsFailed++
+			continue
+		}
+
+		if rewrapped == nil {
+			output.CollectionsUnchanged++
+		} else {
+			if _, err := s.updateCollectionUseCase.Execute(ctx, uc_collection.UpdateCollectionInput{
+				ID:                     localCollection.ID,
+				EncryptedCollectionKey: rewrapped,
+			}); err != nil {
+				return nil, err
+			}
+			output.CollectionsRotated++
+		}
+
+		if err := s.markCollectionRotated(ctx, state, localCollection.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return collectionKeys, nil
+}
+
+// updateLocalCollections copies the collection keys the cloud applied to the local collections,
+// with the versions the cloud assigned them
+func (s *masterKeyRotationService) updateLocalCollections(
+	ctx context.Context,
+	state *KeyRotationState,
+	collectionKeys []*medto.RotatedCollectionKeyDTO,
+	rotated []*medto.RotatedCollectionDTO,
+	output *RotateMasterKeyOutput,
+) error {
+	versions := make(map[gocql.UUID]uint64, len(rotated))
+	for _, collection := range rotated {
+		versions[collection.CollectionID] = collection.Version
+	}
+
+	for _, collectionKey := range collectionKeys {
+		version, ok := versions[collectionKey.CollectionID]
+		if !ok {
+			return errors.NewAppError("cloud did not confirm the new key of collection "+collectionKey.CollectionID.String(), nil)
+		}
+
+		local, err := s.getCollectionUseCase.Execute(ctx, collectionKey.CollectionID)
+		if err != nil {
+			return err
+		}
+		if local != nil {
+			if _, err := s.updateCollectionUseCase.Execute(ctx, uc_collection.UpdateCollectionInput{
+				ID:                     collectionKey.CollectionID,
+				EncryptedCollectionKey: collectionKey.EncryptedCollectionKey,
+				Version:                &version,
+			}); err != nil {
+				return err
+			}
+		}
+		output.CollectionsRotated++
+
+		if err := s.markCollectionRotated(ctx, state, collectionKey.CollectionID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// answerChallenge requests a rotation challenge from the cloud and opens it with the private key of
+// the account. The private key doesn't change during a rotation, so it is unwrapped from the state
+// with the new master key, which works at every stage.
+func (s *masterKeyRotationService) answerChallenge(ctx context.Context, user *dom_user.User, state *KeyRotationState, newMasterKey []byte) (string, string, error) {
+	challenge, err := s.getRotateMasterKeyChallengeFromCloudUseCase.Execute(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	encryptedChallenge, err := crypto.DecodeBase64Flexible(challenge.EncryptedChallenge)
+	if err != nil {
+		return "", "", errors.NewAppError("failed to decode master key rotation challenge", err)
+	}
+
+	privateKey, err := crypto.DecryptWithSecretBox(state.NewEncryptedPrivateKey.Ciphertext, state.NewEncryptedPrivateKey.Nonce, newMasterKey)
+	if err != nil {
+		return "", "", errors.NewAppError("failed to decrypt private key", err)
+	}
+	defer crypto.ClearBytes(privateKey)
+
+	decryptedChallenge, err := crypto.DecryptWithBoxAnonymous(encryptedChallenge, user.PublicKey.Key, privateKey)
+	if err != nil {
+		return "", "", errors.NewAppError("failed to decrypt master key rotation challenge", err)
+	}
+
+	return challenge.ChallengeID, crypto.EncodeToBase64(decryptedChallenge), nil
+}
+
+// markCollectionRotated records a collection whose key is wrapped under the new master key
+func (s *masterKeyRotationService) markCollectionRotated(ctx context.Context, state *KeyRotationState, id gocql.UUID) error {
+	state.RotatedCollectionIDs = append(state.RotatedCollectionIDs, id)
+	return s.stateManager.SaveState(ctx, state)
+}
+
+// updateLocalUser replaces the wrapped keys of the local account in a transaction, keeping the
+// outgoing master key in the key history. It does nothing if the local account was already updated.
+func (s *masterKeyRotationService) updateLocalUser(
+	ctx context.Context,
+	email string,
+	state *KeyRotationState,
+	keyEncryptionKey, newMasterKey []byte,
+	keyVersion int,
+) (err error) {
+	if err = s.userRepo.OpenTransaction(); err != nil {
+		return errors.NewAppError("failed to open transaction", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			s.userRepo.DiscardTransaction()
+		}
+	}()
+
+	user, err := s.getByIsLoggedInUseCase.Execute(ctx)
+	if err != nil || user == nil {
+		if err == nil {
+			err = errors.NewAppError("user not found", nil)
+		}
+		return err
+	}
+
+	if bytes.Equal(user.EncryptedMasterKey.Ciphertext, state.NewEncryptedMasterKey.Ciphertext) {
+		s.logger.Debug("Local user already has the new master key", zap.String("email", email))
+		return nil
+	}
+
+	now := time.Now()
+	previousKeys := append(user.EncryptedMasterKey.PreviousKeys, keys.EncryptedHistoricalKey{
+		KeyVersion:    user.EncryptedMasterKey.KeyVersion,
+		Ciphertext:    user.EncryptedMasterKey.Ciphertext,
+		Nonce:         user.EncryptedMasterKey.Nonce,
+		RotatedAt:     now,
+		RotatedReason: "Master key rotation",
+		Algorithm:     crypto.ChaCha20Poly1305Algorithm,
+	})
+	if len(previousKeys) > 5 { // Keep only last 5 keys, like the cloud
+		previousKeys = previousKeys[len(previousKeys)-5:]
+	}
+
+	user.EncryptedMasterKey = keys.EncryptedMasterKey{
+		Ciphertext:   state.NewEncryptedMasterKey.Ciphertext,
+		Nonce:        state.NewEncryptedMasterKey.Nonce,
+		KeyVersion:   keyVersion,
+		RotatedAt:    &now,
+		PreviousKeys: previousKeys,
+	}
+	user.EncryptedPrivateKey = state.NewEncryptedPrivateKey
+	user.EncryptedRecoveryKey = state.NewEncryptedRecoveryKey
+	user.MasterKeyEncryptedWithRecoveryKey = state.NewMasterKeyEncryptedWithRecoveryKey
+	user.CurrentKeyVersion = keyVersion
+	user.LastKeyRotation = &now
+
+	if err = s.upsertByEmailUseCase.Execute(ctx, user); err != nil {
+		return err
+	}
+
+	// Verify the saved user unlocks the new master key with the password before committing
+	savedUser, err := s.getByIsLoggedInUseCase.Execute(ctx)
+	if err != nil || savedUser == nil {
+		if err == nil {
+			err = errors.NewAppError("user not found after saving new master key", nil)
+		}
+		return err
+	}
+	savedMasterKey, err := crypto.DecryptWithSecretBox(savedUser.EncryptedMasterKey.Ciphertext, savedUser.EncryptedMasterKey.Nonce, keyEncryptionKey)
+	if err != nil || !bytes.Equal(savedMasterKey, newMasterKey) {
+		crypto.ClearBytes(savedMasterKey)
+		return errors.NewAppError("saved user does not unlock the new master key", err)
+	}
+	crypto.ClearBytes(savedMasterKey)
+
+	if err = s.userRepo.CommitTransaction(); err != nil {
+		return errors.NewAppError("failed to commit transaction", err)
+	}
+	committed = true
+	return nil
+}
+
+// rewrapCollectionKey returns the collection key wrapped under the new master key, or nil if it
+// already is. Earlier versions of the collection key which were wrapped under the old master key are
+// re-wrapped too.
+func rewrapCollectionKey(encryptedCollectionKey *keys.EncryptedCollectionKey, oldMasterKey, newMasterKey []byte) (*keys.EncryptedCollectionKey, error) {
+	if encryptedCollectionKey == nil || len(encryptedCollectionKey.Ciphertext) == 0 {
+		return nil, errors.NewAppError("collection has no encrypted key", nil)
+	}
+
+	if collectionKey, err := crypto.DecryptWithSecretBox(encryptedCollectionKey.Ciphertext, encryptedCollectionKey.Nonce, newMasterKey); err == nil {
+		crypto.ClearBytes(collectionKey)
+		return nil, nil
+	}
+
+	collectionKey, err := crypto.DecryptWithSecretBox(encryptedCollectionKey.Ciphertext, encryptedCollectionKey.Nonce, oldMasterKey)
+	if err != nil {
+		return nil, errors.NewAppError("collection key cannot be decrypted with the old or new master key", err)
+	}
+	defer crypto.ClearBytes(collectionKey)
+
+	encrypted, err := crypto.EncryptWithSecretBox(collectionKey, newMasterKey)
+	if err != nil {
+		return nil, errors.NewAppError("failed to encrypt collection key with new master key", err)
+	}
+
+	rewrapped := *encryptedCollectionKey
+	rewrapped.Ciphertext = encrypted.Ciphertext
+	rewrapped.Nonce = encrypted.Nonce
+	rewrapped.PreviousKeys = make([]keys.EncryptedHistoricalKey, len(encryptedCollectionKey.PreviousKeys))
+	for i, previous := range encryptedCollectionKey.PreviousKeys {
+		rewrapped.PreviousKeys[i] = previous
+		previousKey, err := crypto.DecryptWithSecretBox(previous.Ciphertext, previous.Nonce, oldMasterKey)
+		if err != nil {
+			continue // Not wrapped under the old master key, keep it as it is
+		}
+		encryptedPrevious, err := crypto.EncryptWithSecretBox(previousKey, newMasterKey)
+		crypto.ClearBytes(previousKey)
+		if err != nil {
+			return nil, errors.NewAppError("failed to encrypt previous collection key with new master key", err)
+		}
+		rewrapped.PreviousKeys[i].Ciphertext = encryptedPrevious.Ciphertext
+		rewrapped.PreviousKeys[i].Nonce = encryptedPrevious.Nonce
+	}
+
+	return &rewrapped, nil
+}
+
+// encodeWrapped encodes a nonce and ciphertext the way the cloud expects wrapped keys
+func encodeWrapped(nonce, ciphertext []byte) string {
+	return crypto.EncodeToBase64URL(crypto.CombineNonceAndCiphertext(nonce, ciphertext))
+}
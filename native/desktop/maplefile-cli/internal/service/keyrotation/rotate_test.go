@@ -0,0 +1,77 @@
+package keyrotation
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+)
+
+func wrapKey(t *testing.T, key, wrappingKey []byte) *crypto.EncryptedData {
+	t.Helper()
+	encrypted, err := crypto.EncryptWithSecretBox(key, wrappingKey)
+	if err != nil {
+		t.Fatalf("failed to wrap key: %v", err)
+	}
+	return encrypted
+}
+
+func randomKey(t *testing.T) []byte {
+	t.Helper()
+	key, err := crypto.GenerateRandomBytes(crypto.MasterKeySize)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return key
+}
+
+func TestRewrapCollectionKey(t *testing.T) {
+	oldMasterKey, newMasterKey := randomKey(t), randomKey(t)
+	collectionKey, previousCollectionKey := randomKey(t), randomKey(t)
+
+	current := wrapKey(t, collectionKey, oldMasterKey)
+	previous := wrapKey(t, previousCollectionKey, oldMasterKey)
+	encryptedCollectionKey := &keys.EncryptedCollectionKey{
+		Ciphertext: current.Ciphertext,
+		Nonce:      current.Nonce,
+		KeyVersion: 2,
+		PreviousKeys: []keys.EncryptedHistoricalKey{
+			{KeyVersion: 1, Ciphertext: previous.Ciphertext, Nonce: previous.Nonce},
+		},
+	}
+
+	rewrapped, err := rewrapCollectionKey(encryptedCollectionKey, oldMasterKey, newMasterKey)
+	if err != nil {
+		t.Fatalf("rewrapCollectionKey() error = %v", err)
+	}
+	if rewrapped == nil {
+		t.Fatal("rewrapCollectionKey() = nil, want the key wrapped under the new master key")
+	}
+	if rewrapped.KeyVersion != 2 {
+		t.Errorf("KeyVersion = %d, want 2", rewrapped.KeyVersion)
+	}
+
+	decrypted, err := crypto.DecryptWithSecretBox(rewrapped.Ciphertext, rewrapped.Nonce, newMasterKey)
+	if err != nil || !bytes.Equal(decrypted, collectionKey) {
+		t.Errorf("collection key does not decrypt with the new master key: %v", err)
+	}
+	decrypted, err = crypto.DecryptWithSecretBox(rewrapped.PreviousKeys[0].Ciphertext, rewrapped.PreviousKeys[0].Nonce, newMasterKey)
+	if err != nil || !bytes.Equal(decrypted, previousCollectionKey) {
+		t.Errorf("previous collection key does not decrypt with the new master key: %v", err)
+	}
+	if !bytes.Equal(encryptedCollectionKey.PreviousKeys[0].Ciphertext, previous.Ciphertext) {
+		t.Error("rewrapCollectionKey() modified the previous keys of its input")
+	}
+
+	// A key already wrapped under the new master key is left as it is, so a resumed rotation skips it
+	again, err := rewrapCollectionKey(rewrapped, oldMasterKey, newMasterKey)
+	if err != nil || again != nil {
+		t.Errorf("rewrapCollectionKey() of a rotated key = %v, %v, want nil, nil", again, err)
+	}
+
+	// A key wrapped under neither master key can't be rotated
+	if _, err := rewrapCollectionKey(encryptedCollectionKey, randomKey(t), newMasterKey); err == nil {
+		t.Error("rewrapCollectionKey() with the wrong old master key error = nil, want an error")
+	}
+}
@@ -0,0 +1,141 @@
+// internal/service/keyrotation/state_manager.go
+package keyrotation
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/storage"
+)
+
+const keyRotationStateKey = "current_key_rotation_state"
+
+// Stages of a master key rotation, in the order they run
+const (
+	// StageCollections re-wraps the collection keys owned by the user under the new master key and
+	// submits them to the cloud with the wrapped keys of the account.
+	StageCollections = "collections"
+	// StageAccount is reached once the cloud applied the new keys of the collections; the wrapped
+	// keys of the account remain to be confirmed with the cloud and replaced locally.
+	StageAccount = "account"
+)
+
+// KeyRotationState is the progress of a master key rotation, persisted so an interrupted rotation
+// resumes with the same new master key. Both master keys are only ever stored wrapped under the
+// key encryption key derived from the password, like the master key of the account.
+type KeyRotationState struct {
+	UserID gocql.UUID `json:"user_id"`
+	Email  string     `json:"email"`
+	Stage  string     `json:"stage"`
+
+	// OldEncryptedMasterKey is the master key being replaced, encrypted with the key encryption key.
+	OldEncryptedMasterKey keys.EncryptedMasterKey `json:"old_encrypted_master_key"`
+	// NewEncryptedMasterKey is the replacement master key, encrypted with the key encryption key.
+	NewEncryptedMasterKey keys.EncryptedMasterKey `json:"new_encrypted_master_key"`
+	// The keys of the account wrapped under the new master key, computed once so that retries submit
+	// the same values.
+	NewEncryptedPrivateKey               keys.EncryptedPrivateKey               `json:"new_encrypted_private_key"`
+	NewEncryptedRecoveryKey              keys.EncryptedRecoveryKey              `json:"new_encrypted_recovery_key"`
+	NewMasterKeyEncryptedWithRecoveryKey keys.MasterKeyEncryptedWithRecoveryKey `json:"new_master_key_encrypted_with_recovery_key"`
+
+	// RotatedCollectionIDs are the collections whose key is already wrapped under the new master key.
+	RotatedCollectionIDs []gocql.UUID `json:"rotated_collection_ids,omitempty"`
+
+	StartedAt time.Time `json:"started_at"`
+	SavedAt   time.Time `json:"saved_at"`
+}
+
+// IsCollectionRotated reports whether the key of a collection was already re-wrapped
+func (s *KeyRotationState) IsCollectionRotated(id gocql.UUID) bool {
+	return slices.Contains(s.RotatedCollectionIDs, id)
+}
+
+// KeyRotationStateManager handles the persistent state of a master key rotation in progress
+type KeyRotationStateManager interface {
+	SaveState(ctx context.Context, state *KeyRotationState) error
+	// LoadState returns nil if no rotation is in progress.
+	LoadState(ctx context.Context) (*KeyRotationState, error)
+	ClearState(ctx context.Context) error
+}
+
+type keyRotationStateManager struct {
+	logger  *zap.Logger
+	storage storage.Storage
+}
+
+// NewKeyRotationStateManager creates a new key rotation state manager
+func NewKeyRotationStateManager(
+	logger *zap.Logger,
+	storage storage.Storage,
+) KeyRotationStateManager {
+	logger = logger.Named("KeyRotationStateManager")
+	return &keyRotationStateManager{
+		logger:  logger,
+		storage: storage,
+	}
+}
+
+// SaveState saves the progress of the rotation to persistent storage
+func (m *keyRotationStateManager) SaveState(ctx context.Context, state *KeyRotationState) error {
+	if state == nil {
+		return m.ClearState(ctx)
+	}
+
+	state.SavedAt = time.Now()
+	data, err := json.Marshal(state)
+	if err != nil {
+		m.logger.Error("Failed to marshal key rotation state", zap.Error(err))
+		return errors.NewAppError("failed to save key rotation state", err)
+	}
+
+	if err := m.storage.Set(keyRotationStateKey, data); err != nil {
+		m.logger.Error("Failed to save key rotation state to storage", zap.Error(err))
+		return errors.NewAppError("failed to save key rotation state", err)
+	}
+
+	m.logger.Debug("Successfully saved key rotation state",
+		zap.String("stage", state.Stage),
+		zap.Int("rotatedCollections", len(state.RotatedCollectionIDs)))
+
+	return nil
+}
+
+// LoadState loads the progress of the rotation from persistent storage
+func (m *keyRotationStateManager) LoadState(ctx context.Context) (*KeyRotationState, error) {
+	data, err := m.storage.Get(keyRotationStateKey)
+	if err != nil {
+		m.logger.Error("Failed to load key rotation state from storage", zap.Error(err))
+		return nil, errors.NewAppError("failed to load key rotation state", err)
+	}
+
+	if data == nil {
+		m.logger.Debug("No key rotation state found in storage")
+		return nil, nil
+	}
+
+	var state KeyRotationState
+	if err := json.Unmarshal(data, &state); err != nil {
+		m.logger.Error("Failed to unmarshal key rotation state", zap.Error(err))
+		return nil, errors.NewAppError("failed to parse key rotation state", err)
+	}
+
+	return &state, nil
+}
+
+// ClearState removes the state of the rotation from persistent storage
+func (m *keyRotationStateManager) ClearState(ctx context.Context) error {
+	if err := m.storage.Delete(keyRotationStateKey); err != nil {
+		m.logger.Error("Failed to clear key rotation state from storage", zap.Error(err))
+		return errors.NewAppError("failed to clear key rotation state", err)
+	}
+
+	m.logger.Debug("Successfully cleared key rotation state")
+	return nil
+}
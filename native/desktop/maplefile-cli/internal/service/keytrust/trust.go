@@ -0,0 +1,208 @@
+// internal/service/keytrust/trust.go
+package keytrust
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	dom_publiclookupdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/publiclookupdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+	pkg_email "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/email"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/storage"
+)
+
+// TrustedKey is the verification ID of a recipient public key, trusted the first time it was seen
+type TrustedKey struct {
+	Email          string     `json:"email"`
+	UserID         gocql.UUID `json:"user_id"`
+	VerificationID string     `json:"verification_id"`
+	FirstSeenAt    time.Time  `json:"first_seen_at"`
+	LastSeenAt     time.Time  `json:"last_seen_at"`
+}
+
+// TrustCheckResult is the outcome of comparing a fetched public key with the trusted one
+type TrustCheckResult struct {
+	// VerificationID is the verification ID of the fetched public key.
+	VerificationID string `json:"verification_id"`
+	// FirstUse is true if no key was trusted for the recipient, in which case the fetched one now is.
+	FirstUse bool `json:"first_use"`
+	// Changed is true if the fetched key differs from the trusted one, which happens when the
+	// recipient reset their account or when the server substituted the key.
+	Changed bool `json:"changed"`
+	// TrustedVerificationID is the verification ID trusted for the recipient, set if Changed.
+	TrustedVerificationID string `json:"trusted_verification_id,omitempty"`
+}
+
+// TrustedKeyService remembers the public keys of recipients on first use and detects when they change
+type TrustedKeyService interface {
+	// Check compares the public key of a lookup with the one trusted for the recipient, trusting it
+	// if none is. A changed key is reported but never replaces the trusted one.
+	Check(ctx context.Context, lookup *dom_publiclookupdto.PublicLookupResponseDTO) (*TrustCheckResult, error)
+	// List returns every trusted key, sorted by email.
+	List(ctx context.Context) ([]*TrustedKey, error)
+	// Reset forgets the key trusted for the recipient, so the next share trusts whatever key is fetched.
+	Reset(ctx context.Context, email string) error
+}
+
+type trustedKeyService struct {
+	logger  *zap.Logger
+	storage storage.Storage
+}
+
+// NewTrustedKeyService creates a new trusted key service
+func NewTrustedKeyService(
+	logger *zap.Logger,
+	storage storage.Storage,
+) TrustedKeyService {
+	logger = logger.Named("TrustedKeyService")
+	return &trustedKeyService{
+		logger:  logger,
+		storage: storage,
+	}
+}
+
+// Check compares the public key of a lookup with the one trusted for the recipient
+func (s *trustedKeyService) Check(ctx context.Context, lookup *dom_publiclookupdto.PublicLookupResponseDTO) (*TrustCheckResult, error) {
+	if lookup == nil || lookup.Email == "" {
+		return nil, errors.NewAppError("recipient lookup is required", nil)
+	}
+	email, err := pkg_email.Normalize(lookup.Email)
+	if err != nil {
+		return nil, errors.NewAppError("invalid recipient email", err)
+	}
+
+	// The lookup use case already confirmed a returned verification ID belongs to the public key, so
+	// deriving it when the server omits it yields the same value.
+	verificationID := lookup.VerificationID
+	if verificationID == "" {
+		publicKey, err := crypto.DecodeBase64Flexible(lookup.PublicKeyInBase64)
+		if err != nil {
+			return nil, errors.NewAppError("failed to decode recipient public key", err)
+		}
+		if verificationID, err = crypto.GenerateVerificationID(publicKey); err != nil {
+			return nil, errors.NewAppError("failed to generate recipient verification ID", err)
+		}
+	}
+
+	trusted, err := s.get(email)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if trusted == nil {
+		trusted = &TrustedKey{
+			Email:          email,
+			UserID:         lookup.UserID,
+			VerificationID: verificationID,
+			FirstSeenAt:    now,
+			LastSeenAt:     now,
+		}
+		if err := s.save(trusted); err != nil {
+			return nil, err
+		}
+		s.logger.Info("🔑 Trusted recipient public key on first use",
+			zap.String("email", trusted.Email),
+			zap.String("verificationID", verificationID))
+		return &TrustCheckResult{VerificationID: verificationID, FirstUse: true}, nil
+	}
+
+	if trusted.VerificationID != verificationID {
+		s.logger.Warn("🚨 Recipient public key differs from the trusted one",
+			zap.String("email", trusted.Email),
+			zap.String("trustedVerificationID", trusted.VerificationID),
+			zap.String("fetchedVerificationID", verificationID))
+		return &TrustCheckResult{
+			VerificationID:        verificationID,
+			Changed:               true,
+			TrustedVerificationID: trusted.VerificationID,
+		}, nil
+	}
+
+	trusted.LastSeenAt = now
+	if err := s.save(trusted); err != nil {
+		return nil, err
+	}
+	return &TrustCheckResult{VerificationID: verificationID}, nil
+}
+
+// List returns every trusted key, sorted by email
+func (s *trustedKeyService) List(ctx context.Context) ([]*TrustedKey, error) {
+	trustedKeys := make([]*TrustedKey, 0)
+	err := s.storage.Iterate(func(key, value []byte) error {
+		var trusted TrustedKey
+		if err := json.Unmarshal(value, &trusted); err != nil {
+			s.logger.Warn("⚠️ Skipping unreadable trusted key", zap.String("key", string(key)), zap.Error(err))
+			return nil
+		}
+		trustedKeys = append(trustedKeys, &trusted)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.NewAppError("failed to list trusted keys", err)
+	}
+
+	sort.Slice(trustedKeys, func(i, j int) bool {
+		return trustedKeys[i].Email < trustedKeys[j].Email
+	})
+	return trustedKeys, nil
+}
+
+// Reset forgets the key trusted for the recipient
+func (s *trustedKeyService) Reset(ctx context.Context, email string) error {
+	email, err := pkg_email.Normalize(email)
+	if err != nil {
+		return errors.NewAppError("invalid email", err)
+	}
+
+	trusted, err := s.get(email)
+	if err != nil {
+		return err
+	}
+	if trusted == nil {
+		return errors.NewAppError("no key is trusted for "+email, nil)
+	}
+
+	if err := s.storage.Delete(email); err != nil {
+		return errors.NewAppError("failed to reset trusted key", err)
+	}
+
+	s.logger.Info("🔑 Reset trusted recipient public key",
+		zap.String("email", trusted.Email),
+		zap.String("verificationID", trusted.VerificationID))
+	return nil
+}
+
+// get returns the key trusted for the normalized email, or nil if none is
+func (s *trustedKeyService) get(email string) (*TrustedKey, error) {
+	data, err := s.storage.Get(email)
+	if err != nil {
+		return nil, errors.NewAppError("failed to load trusted key", err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var trusted TrustedKey
+	if err := json.Unmarshal(data, &trusted); err != nil {
+		return nil, errors.NewAppError("failed to parse trusted key", err)
+	}
+	return &trusted, nil
+}
+
+func (s *trustedKeyService) save(trusted *TrustedKey) error {
+	data, err := json.Marshal(trusted)
+	if err != nil {
+		return errors.NewAppError("failed to save trusted key", err)
+	}
+	if err := s.storage.Set(trusted.Email, data); err != nil {
+		return errors.NewAppError("failed to save trusted key", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package keytrust
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"go.uber.org/zap"
+
+	dom_publiclookupdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/publiclookupdto"
+	pkg_email "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/email"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/storage"
+)
+
+// mapStorage keeps the trusted keys in memory
+type mapStorage struct {
+	storage.Storage
+	values map[string][]byte
+}
+
+func (m *mapStorage) Get(key string) ([]byte, error) {
+	return m.values[key], nil
+}
+
+func (m *mapStorage) Set(key string, val []byte) error {
+	m.values[key] = val
+	return nil
+}
+
+func (m *mapStorage) Delete(key string) error {
+	delete(m.values, key)
+	return nil
+}
+
+func TestTrustedKeyEmailNormalization(t *testing.T) {
+	ctx := context.Background()
+	store := &mapStorage{values: make(map[string][]byte)}
+	s := NewTrustedKeyService(zap.NewNop(), store)
+
+	first, err := s.Check(ctx, &dom_publiclookupdto.PublicLookupResponseDTO{Email: "  Alice@Example.COM ", VerificationID: "id-1"})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if !first.FirstUse {
+		t.Fatal("Check() FirstUse = false for a new recipient")
+	}
+	if _, ok := store.values["alice@example.com"]; !ok || len(store.values) != 1 {
+		t.Fatalf("trusted keys stored under %v, want only alice@example.com", store.values)
+	}
+
+	// The same recipient typed differently finds the key trusted on first use
+	second, err := s.Check(ctx, &dom_publiclookupdto.PublicLookupResponseDTO{Email: "alice@example.com", VerificationID: "id-2"})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if second.FirstUse || !second.Changed || second.TrustedVerificationID != "id-1" {
+		t.Errorf("Check() = %+v, want the change from the key trusted on first use", second)
+	}
+
+	if err := s.Reset(ctx, "ALICE@example.com"); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if len(store.values) != 0 {
+		t.Errorf("trusted keys %v left after Reset()", store.values)
+	}
+}
+
+func TestTrustedKeyRejectsInvalidEmail(t *testing.T) {
+	ctx := context.Background()
+	store := &mapStorage{values: make(map[string][]byte)}
+	s := NewTrustedKeyService(zap.NewNop(), store)
+
+	_, err := s.Check(ctx, &dom_publiclookupdto.PublicLookupResponseDTO{Email: "alice@", VerificationID: "id-1"})
+	if !stderrors.Is(err, pkg_email.ErrInvalidFormat) {
+		t.Errorf("Check() error = %v, want ErrInvalidFormat", err)
+	}
+	if err := s.Reset(ctx, "not an email"); !stderrors.Is(err, pkg_email.ErrInvalidFormat) {
+		t.Errorf("Reset() error = %v, want ErrInvalidFormat", err)
+	}
+	if len(store.values) != 0 {
+		t.Errorf("trusted keys %v stored for an invalid email", store.values)
+	}
+}
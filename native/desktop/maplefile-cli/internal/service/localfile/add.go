@@ -3,8 +3,10 @@ package localfile
 
 import (
 	"context"
+	"fmt"
 	"mime"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gocql/gocql"
@@ -21,12 +23,15 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/localfile"
 	uc_user "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/user"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/imagehash"
 )
 
 // LocalFileAddInput represents the input for adding a local file
 type LocalFileAddInput struct {
-	FilePath     string     `json:"file_path"`
-	CollectionID gocql.UUID `json:"collection_id"`
+	FilePath string `json:"file_path"`
+	// CollectionID is the collection to add the file to, the configured default collection is
+	// used when it is left empty.
+	CollectionID gocql.UUID `json:"collection_id,omitempty"`
 	OwnerID      gocql.UUID `json:"owner_id"`
 	Name         string     `json:"name,omitempty"`
 	StorageMode  string     `json:"storage_mode"`
@@ -113,9 +118,12 @@ func (s *localFileAddService) Add(ctx context.Context, input *LocalFileAddInput,
 		s.logger.Error("❌ File path is required")
 		return nil, errors.NewAppError("file path is required", nil)
 	}
-	if input.CollectionID.String() == "" {
-		s.logger.Error("❌ Collection ID is required")
-		return nil, errors.NewAppError("collection ID is required", nil)
+	if input.CollectionID == (gocql.UUID{}) {
+		collectionID, err := s.resolveDefaultCollectionID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		input.CollectionID = collectionID
 	}
 	if input.OwnerID.String() == "" {
 		s.logger.Error("❌ Owner ID is required")
@@ -235,6 +243,20 @@ func (s *localFileAddService) Add(ctx context.Context, input *LocalFileAddInput,
 		mimeType = "application/octet-stream"
 	}
 
+	// Enforce the file type policy here, the server can't inspect the contents once encrypted.
+	policy, err := s.configService.GetUploadFileTypePolicy(ctx)
+	if err != nil {
+		s.logger.Error("❌ Failed to get upload file type policy", zap.Error(err))
+		return nil, errors.NewAppError("failed to get upload file type policy", err)
+	}
+	if !policy.Permits(fileExtension, mimeType) {
+		s.logger.Warn("🚫 File type not permitted by upload policy",
+			zap.String("filePath", cleanFilePath),
+			zap.String("extension", fileExtension),
+			zap.String("mimeType", mimeType))
+		return nil, errors.NewAppError(fmt.Sprintf("file type not permitted: %s (%s) is blocked by the upload file type policy", displayExtension(fileExtension), mimeType), nil)
+	}
+
 	// Generate unique file ID and create destination path
 	fileID := gocql.TimeUUID()
 	destFileName := fileID.String() + fileExtension
@@ -305,6 +327,7 @@ func (s *localFileAddService) Add(ctx context.Context, input *LocalFileAddInput,
 		EncryptedThumbnailSize: 0,  // Developer Note: Future feature
 		DecryptedThumbnailPath: "", // Developer Note: Future feature
 		DecryptedThumbnailSize: 0,  // Developer Note: Future feature
+		PerceptualHash:         s.computePerceptualHash(ctx, fileContent, mimeType),
 	}
 
 	encryptedMetadataString, err := s.fileEncryptionService.EncryptFileMetadata(ctx, metadata, fileKey)
@@ -313,7 +336,11 @@ func (s *localFileAddService) Add(ctx context.Context, input *LocalFileAddInput,
 	}
 
 	s.logger.Debug("🔐 Computing and encrypting file hash")
-	fileHashBytes, err := s.computeFileHashUseCase.ExecuteForBytes(ctx, destFilePath)
+	hashAlgorithm, err := s.configService.GetFileHashAlgorithm(ctx)
+	if err != nil {
+		return nil, errors.NewAppError("failed to get file hash algorithm", err)
+	}
+	fileHashBytes, err := s.computeFileHashUseCase.ExecuteForBytesWithAlgorithm(ctx, destFilePath, hashAlgorithm)
 	if err != nil {
 		return nil, errors.NewAppError("failed to compute file hash", err)
 	}
@@ -330,27 +357,28 @@ func (s *localFileAddService) Add(ctx context.Context, input *LocalFileAddInput,
 	//
 	currentTime := time.Now()
 	domainFile := &dom_file.File{
-		ID:                fileID,
-		CollectionID:      input.CollectionID,
-		OwnerID:           input.OwnerID,
-		EncryptedMetadata: encryptedMetadataString,
-		EncryptedFileKey:  *encryptedFileKey, // Use the struct from crypto service
-		EncryptionVersion: "1.0",
-		EncryptedHash:     encryptedHashString,
-		EncryptedFilePath: encryptedPath,
-		EncryptedFileSize: int64(len(encryptedFileData)),
-		Name:              fileName, // Keep plaintext for local use
-		MimeType:          mimeType,
-		Metadata:          metadata,     // Decrypted metadata.
-		FilePath:          destFilePath, // Decrypted file path (what we copied)
-		FileSize:          fileInfo.Size,
-		StorageMode:       input.StorageMode,
-		CreatedAt:         currentTime,
-		CreatedByUserID:   input.OwnerID,
-		ModifiedAt:        currentTime,
-		ModifiedByUserID:  input.OwnerID,
-		Version:           1,                            // Always set `version=1` at creation of a collection
-		SyncStatus:        dom_file.SyncStatusLocalOnly, // SET DEFAULT STATE
+		ID:                     fileID,
+		CollectionID:           input.CollectionID,
+		OwnerID:                input.OwnerID,
+		EncryptedMetadata:      encryptedMetadataString,
+		EncryptedFileKey:       *encryptedFileKey, // Use the struct from crypto service
+		EncryptionVersion:      "1.0",
+		EncryptedHash:          encryptedHashString,
+		EncryptedHashAlgorithm: hashAlgorithm,
+		EncryptedFilePath:      encryptedPath,
+		EncryptedFileSize:      int64(len(encryptedFileData)),
+		Name:                   fileName, // Keep plaintext for local use
+		MimeType:               mimeType,
+		Metadata:               metadata,     // Decrypted metadata.
+		FilePath:               destFilePath, // Decrypted file path (what we copied)
+		FileSize:               fileInfo.Size,
+		StorageMode:            input.StorageMode,
+		CreatedAt:              currentTime,
+		CreatedByUserID:        input.OwnerID,
+		ModifiedAt:             currentTime,
+		ModifiedByUserID:       input.OwnerID,
+		Version:                1,                            // Always set `version=1` at creation of a collection
+		SyncStatus:             dom_file.SyncStatusLocalOnly, // SET DEFAULT STATE
 	}
 
 	//
@@ -371,3 +399,60 @@ func (s *localFileAddService) Add(ctx context.Context, input *LocalFileAddInput,
 		CopiedFilePath: destFilePath,
 	}, nil
 }
+
+// resolveDefaultCollectionID returns the configured default collection for files added without one
+func (s *localFileAddService) resolveDefaultCollectionID(ctx context.Context) (gocql.UUID, error) {
+	defaultCollectionID, err := s.configService.GetDefaultCollectionID(ctx)
+	if err != nil {
+		s.logger.Error("❌ Failed to get default collection", zap.Error(err))
+		return gocql.UUID{}, errors.NewAppError("failed to get default collection", err)
+	}
+	if defaultCollectionID == "" {
+		s.logger.Error("❌ Collection ID is required")
+		return gocql.UUID{}, errors.NewAppError("collection ID is required: specify a collection or set a default one with 'maplefile-cli config set-default-collection COLLECTION_ID'", nil)
+	}
+
+	collectionID, err := gocql.ParseUUID(defaultCollectionID)
+	if err != nil {
+		s.logger.Error("❌ Invalid default collection", zap.String("defaultCollectionID", defaultCollectionID), zap.Error(err))
+		return gocql.UUID{}, errors.NewAppError("the configured default collection ID is invalid, set it again with 'maplefile-cli config set-default-collection COLLECTION_ID'", err)
+	}
+
+	s.logger.Debug("📁 Using default collection", zap.String("collectionID", collectionID.String()))
+	return collectionID, nil
+}
+
+// computePerceptualHash returns the perceptual hash of an image file if perceptual hashing is
+// enabled. It is computed on the plaintext and only stored in the encrypted metadata. Files which
+// aren't images or can't be decoded get no hash rather than failing to be added.
+func (s *localFileAddService) computePerceptualHash(ctx context.Context, content []byte, mimeType string) string {
+	if !strings.HasPrefix(mimeType, "image/") {
+		return ""
+	}
+
+	enabled, err := s.configService.GetPerceptualHash(ctx)
+	if err != nil {
+		s.logger.Warn("⚠️ Failed to get perceptual hash setting, skipping perceptual hash", zap.Error(err))
+		return ""
+	}
+	if !enabled {
+		return ""
+	}
+
+	hash, err := imagehash.Compute(content)
+	if err != nil {
+		s.logger.Debug("Skipping perceptual hash of image which can't be decoded",
+			zap.String("mimeType", mimeType),
+			zap.Error(err))
+		return ""
+	}
+	return hash.String()
+}
+
+// displayExtension names a file extension in error messages
+func displayExtension(extension string) string {
+	if extension == "" {
+		return "no extension"
+	}
+	return extension
+}
@@ -0,0 +1,154 @@
+// internal/service/localfile/find_similar.go
+package localfile
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/imagehash"
+)
+
+// DefaultSimilarityDistance is the largest perceptual hash distance at which images are reported as
+// similar when no distance is given
+const DefaultSimilarityDistance = 10
+
+// FindSimilarInput represents the input for finding images similar to a file
+type FindSimilarInput struct {
+	FileID gocql.UUID `json:"file_id"`
+	// MaxDistance is the largest number of differing perceptual hash bits, from 0 for visually
+	// identical images to 64, DefaultSimilarityDistance is used when it is zero.
+	MaxDistance int `json:"max_distance,omitempty"`
+}
+
+// SimilarFile is an image similar to the file searched for
+type SimilarFile struct {
+	File     *dom_file.File `json:"file"`
+	Distance int            `json:"distance"`
+}
+
+// FindSimilarOutput represents the images similar to a file, most similar first
+type FindSimilarOutput struct {
+	File    *dom_file.File `json:"file"`
+	Similar []*SimilarFile `json:"similar"`
+	// Compared is the number of other images with a perceptual hash which were compared.
+	Compared int `json:"compared"`
+}
+
+// FindSimilarService defines the interface for finding visually similar images
+type FindSimilarService interface {
+	// FindSimilar compares the perceptual hash of the file with every local image, without
+	// contacting the cloud since the hashes are only readable once the metadata is decrypted.
+	FindSimilar(ctx context.Context, input *FindSimilarInput) (*FindSimilarOutput, error)
+}
+
+// findSimilarService implements the FindSimilarService interface
+type findSimilarService struct {
+	logger           *zap.Logger
+	getFileUseCase   file.GetFileUseCase
+	listFilesUseCase file.ListFilesUseCase
+}
+
+// NewFindSimilarService creates a new service for finding visually similar images
+func NewFindSimilarService(
+	logger *zap.Logger,
+	getFileUseCase file.GetFileUseCase,
+	listFilesUseCase file.ListFilesUseCase,
+) FindSimilarService {
+	logger = logger.Named("FindSimilarService")
+	return &findSimilarService{
+		logger:           logger,
+		getFileUseCase:   getFileUseCase,
+		listFilesUseCase: listFilesUseCase,
+	}
+}
+
+// FindSimilar returns the local images whose perceptual hash is close to the one of the file
+func (s *findSimilarService) FindSimilar(ctx context.Context, input *FindSimilarInput) (*FindSimilarOutput, error) {
+	//
+	// STEP 1: Validate inputs
+	//
+	if input == nil {
+		return nil, errors.NewAppError("input is required", nil)
+	}
+	if input.FileID == (gocql.UUID{}) {
+		return nil, errors.NewAppError("file ID is required", nil)
+	}
+	maxDistance := input.MaxDistance
+	if maxDistance == 0 {
+		maxDistance = DefaultSimilarityDistance
+	}
+	if maxDistance < 0 || maxDistance > 64 {
+		return nil, errors.NewAppError("maximum distance must be between 0 and 64", nil)
+	}
+
+	//
+	// STEP 2: Get the perceptual hash of the file
+	//
+	target, err := s.getFileUseCase.Execute(ctx, input.FileID)
+	if err != nil {
+		return nil, errors.NewAppError("failed to get file", err)
+	}
+	if target == nil {
+		return nil, errors.NewAppError("file not found", nil)
+	}
+	targetHash, ok := perceptualHash(target)
+	if !ok {
+		return nil, errors.NewAppError("file has no perceptual hash, it is not an image or was added while perceptual hashing was disabled", nil)
+	}
+
+	//
+	// STEP 3: Compare it with every other local image
+	//
+	files, err := s.listFilesUseCase.Execute(ctx, dom_file.FileFilter{})
+	if err != nil {
+		return nil, errors.NewAppError("failed to list files", err)
+	}
+
+	output := &FindSimilarOutput{
+		File:    target,
+		Similar: make([]*SimilarFile, 0),
+	}
+	for _, candidate := range files {
+		if candidate.ID == target.ID || candidate.State == dom_file.FileStateDeleted {
+			continue
+		}
+		hash, ok := perceptualHash(candidate)
+		if !ok {
+			continue
+		}
+		output.Compared++
+
+		if distance := imagehash.Distance(targetHash, hash); distance <= maxDistance {
+			output.Similar = append(output.Similar, &SimilarFile{File: candidate, Distance: distance})
+		}
+	}
+
+	sort.SliceStable(output.Similar, func(i, j int) bool {
+		return output.Similar[i].Distance < output.Similar[j].Distance
+	})
+
+	s.logger.Debug("🔍 Found similar images",
+		zap.String("fileID", target.ID.String()),
+		zap.Int("compared", output.Compared),
+		zap.Int("similar", len(output.Similar)))
+
+	return output, nil
+}
+
+// perceptualHash returns the perceptual hash from the decrypted metadata of the file
+func perceptualHash(f *dom_file.File) (imagehash.Hash, bool) {
+	if f.Metadata == nil || f.Metadata.PerceptualHash == "" {
+		return 0, false
+	}
+	hash, err := imagehash.Parse(f.Metadata.PerceptualHash)
+	if err != nil {
+		return 0, false
+	}
+	return hash, true
+}
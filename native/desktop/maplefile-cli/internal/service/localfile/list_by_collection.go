@@ -17,6 +17,12 @@ type ListInput struct {
 	CollectionID gocql.UUID `json:"collection_id"`
 }
 
+// ListFilteredInput represents the input for listing files across collections, every field is optional
+type ListFilteredInput struct {
+	CollectionID *gocql.UUID          `json:"collection_id,omitempty"`
+	SyncStatus   *dom_file.SyncStatus `json:"sync_status,omitempty"`
+}
+
 // ListOutput represents the result of listing files by collection
 type ListOutput struct {
 	Files []*dom_file.File `json:"files"`
@@ -26,23 +32,28 @@ type ListOutput struct {
 // ListService defines the interface for listing local files by collection
 type ListService interface {
 	ListByCollection(ctx context.Context, input *ListInput) (*ListOutput, error)
+	// ListFiltered lists local files across all collections, optionally of one collection or sync status
+	ListFiltered(ctx context.Context, input *ListFilteredInput) (*ListOutput, error)
 }
 
 // listService implements the ListService interface
 type listService struct {
 	logger                       *zap.Logger
 	listFilesByCollectionUseCase file.ListFilesByCollectionUseCase
+	listFilesUseCase             file.ListFilesUseCase
 }
 
 // NewListService creates a new service for listing local files by collection
 func NewListService(
 	logger *zap.Logger,
 	listFilesByCollectionUseCase file.ListFilesByCollectionUseCase,
+	listFilesUseCase file.ListFilesUseCase,
 ) ListService {
 	logger = logger.Named("ListService")
 	return &listService{
 		logger:                       logger,
 		listFilesByCollectionUseCase: listFilesByCollectionUseCase,
+		listFilesUseCase:             listFilesUseCase,
 	}
 }
 
@@ -91,3 +102,40 @@ func (s *listService) ListByCollection(ctx context.Context, input *ListInput) (*
 		Count: len(files),
 	}, nil
 }
+
+// ListFiltered handles the listing of local files across collections, such as every cloud-only file
+// which can be onloaded
+func (s *listService) ListFiltered(ctx context.Context, input *ListFilteredInput) (*ListOutput, error) {
+	//
+	// STEP 1: Validate inputs
+	//
+	if input == nil {
+		s.logger.Error("❌ Input is required")
+		return nil, errors.NewAppError("input is required", nil)
+	}
+
+	//
+	// STEP 2: Execute the use case to list the matching files
+	//
+	filter := dom_file.FileFilter{
+		CollectionID: input.CollectionID,
+		SyncStatus:   input.SyncStatus,
+	}
+	s.logger.Debug("🔍 Listing files", zap.Any("filter", filter))
+
+	files, err := s.listFilesUseCase.Execute(ctx, filter)
+	if err != nil {
+		s.logger.Error("❌ Failed to list files", zap.Any("filter", filter), zap.Error(err))
+		return nil, errors.NewAppError("failed to list files", err)
+	}
+
+	//
+	// STEP 3: Return structured output
+	//
+	s.logger.Info("✅ Successfully listed files", zap.Int("fileCount", len(files)))
+
+	return &ListOutput{
+		Files: files,
+		Count: len(files),
+	}, nil
+}
@@ -0,0 +1,308 @@
+// internal/service/localfile/move.go
+package localfile
+
+import (
+	"context"
+	"time"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	dom_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
+	dom_tx "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/transaction"
+	svc_collectioncrypto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectioncrypto"
+	svc_filecrypto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filecrypto"
+	uc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collection"
+	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
+	uc_user "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/user"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+)
+
+// MoveInput represents the input for moving a file to another collection
+type MoveInput struct {
+	FileID             gocql.UUID `json:"file_id"`
+	TargetCollectionID gocql.UUID `json:"target_collection_id"`
+	Password           string     `json:"password"`
+}
+
+// MoveOutput represents the result of moving a file to another collection
+type MoveOutput struct {
+	File               *dom_file.File `json:"file"`
+	SourceCollectionID gocql.UUID     `json:"source_collection_id"`
+	TargetCollectionID gocql.UUID     `json:"target_collection_id"`
+	PreviousVersion    uint64         `json:"previous_version"`
+	NewVersion         uint64         `json:"new_version"`
+}
+
+// MoveService defines the interface for moving files between collections
+type MoveService interface {
+	Move(ctx context.Context, input *MoveInput) (*MoveOutput, error)
+}
+
+// moveService implements the MoveService interface
+type moveService struct {
+	logger                      *zap.Logger
+	transactionManager          dom_tx.Manager
+	cloudRepository             filedto.FileDTORepository
+	getFileUseCase              uc_file.GetFileUseCase
+	updateFileUseCase           uc_file.UpdateFileUseCase
+	getUserByIsLoggedInUseCase  uc_user.GetByIsLoggedInUseCase
+	getCollectionUseCase        uc_collection.GetCollectionUseCase
+	collectionDecryptionService svc_collectioncrypto.CollectionDecryptionService
+	fileDecryptionService       svc_filecrypto.FileDecryptionService
+	fileEncryptionService       svc_filecrypto.FileEncryptionService
+}
+
+// NewMoveService creates a new service for moving files between collections
+func NewMoveService(
+	logger *zap.Logger,
+	transactionManager dom_tx.Manager,
+	cloudRepository filedto.FileDTORepository,
+	getFileUseCase uc_file.GetFileUseCase,
+	updateFileUseCase uc_file.UpdateFileUseCase,
+	getUserByIsLoggedInUseCase uc_user.GetByIsLoggedInUseCase,
+	getCollectionUseCase uc_collection.GetCollectionUseCase,
+	collectionDecryptionService svc_collectioncrypto.CollectionDecryptionService,
+	fileDecryptionService svc_filecrypto.FileDecryptionService,
+	fileEncryptionService svc_filecrypto.FileEncryptionService,
+) MoveService {
+	logger = logger.Named("MoveService")
+	return &moveService{
+		logger:                      logger,
+		transactionManager:          transactionManager,
+		cloudRepository:             cloudRepository,
+		getFileUseCase:              getFileUseCase,
+		updateFileUseCase:           updateFileUseCase,
+		getUserByIsLoggedInUseCase:  getUserByIsLoggedInUseCase,
+		getCollectionUseCase:        getCollectionUseCase,
+		collectionDecryptionService: collectionDecryptionService,
+		fileDecryptionService:       fileDecryptionService,
+		fileEncryptionService:       fileEncryptionService,
+	}
+}
+
+// Move moves a file into another collection. The file key is decrypted with the source collection
+// key and re-encrypted with the target collection key on this device, so the file content and
+// metadata do not need to be re-encrypted and the server never sees the plaintext key. Files which
+// exist in the cloud are moved there as well.
+func (s *moveService) Move(ctx context.Context, input *MoveInput) (*MoveOutput, error) {
+	//
+	// STEP 1: Validate inputs
+	//
+	if input == nil {
+		s.logger.Error("❌ input is required")
+		return nil, errors.NewAppError("input is required", nil)
+	}
+	if input.FileID.String() == "" {
+		s.logger.Error("❌ file ID is required")
+		return nil, errors.NewAppError("file ID is required", nil)
+	}
+	if input.TargetCollectionID.String() == "" {
+		s.logger.Error("❌ target collection ID is required")
+		return nil, errors.NewAppError("target collection ID is required", nil)
+	}
+	if input.Password == "" {
+		s.logger.Error("❌ password is required for E2EE operations")
+		return nil, errors.NewAppError("password is required for E2EE operations", nil)
+	}
+
+	//
+	// STEP 2: Get file, user and both collections
+	//
+	file, err := s.getFileUseCase.Execute(ctx, input.FileID)
+	if err != nil {
+		return nil, errors.NewAppError("failed to get file", err)
+	}
+	if file == nil {
+		s.logger.Error("❌ file not found", zap.String("fileID", input.FileID.String()))
+		return nil, errors.NewAppError("file not found", nil)
+	}
+	if file.State == dom_file.FileStateDeleted {
+		return nil, errors.NewAppError("cannot move a deleted file", nil)
+	}
+	if file.CollectionID == input.TargetCollectionID {
+		return nil, errors.NewAppError("file is already in the target collection", nil)
+	}
+
+	user, err := s.getUserByIsLoggedInUseCase.Execute(ctx)
+	if err != nil {
+		return nil, errors.NewAppError("failed to get logged in user", err)
+	}
+	if user == nil {
+		return nil, errors.NewAppError("user not found", nil)
+	}
+
+	sourceCollection, err := s.getCollectionUseCase.Execute(ctx, file.CollectionID)
+	if err != nil {
+		return nil, errors.NewAppError("failed to get source collection", err)
+	}
+	if sourceCollection == nil {
+		return nil, errors.NewAppError("source collection not found", nil)
+	}
+
+	targetCollection, err := s.getCollectionUseCase.Execute(ctx, input.TargetCollectionID)
+	if err != nil {
+		return nil, errors.NewAppError("failed to get target collection", err)
+	}
+	if targetCollection == nil {
+		return nil, errors.NewAppError("target collection not found", nil)
+	}
+	if targetCollection.State == dom_collection.CollectionStateDeleted {
+		return nil, errors.NewAppError("cannot move a file into a deleted collection", nil)
+	}
+
+	//
+	// STEP 3: Validate the user can write to both collections
+	//
+	if !hasWritePermission(sourceCollection, user.ID) {
+		s.logger.Warn("⚠️ user does not have write permission on source collection",
+			zap.String("userID", user.ID.String()),
+			zap.String("collectionID", sourceCollection.ID.String()))
+		return nil, errors.NewAppError("you don't have permission to move files out of the source collection", nil)
+	}
+	if !hasWritePermission(targetCollection, user.ID) {
+		s.logger.Warn("⚠️ user does not have write permission on target collection",
+			zap.String("userID", user.ID.String()),
+			zap.String("collectionID", targetCollection.ID.String()))
+		return nil, errors.NewAppError("you don't have permission to add files to the target collection", nil)
+	}
+
+	//
+	// STEP 4: Re-wrap the file key with the target collection key
+	//
+	s.logger.Debug("🔐 Decrypting source collection key chain for move operation")
+	sourceCollectionKey, err := s.collectionDecryptionService.ExecuteDecryptCollectionKeyChain(ctx, user, sourceCollection, input.Password)
+	if err != nil {
+		return nil, errors.NewAppError("failed to decrypt source collection key chain", err)
+	}
+	defer crypto.ClearBytes(sourceCollectionKey)
+
+	fileKey, err := s.fileDecryptionService.DecryptFileKey(ctx, file.EncryptedFileKey, sourceCollectionKey)
+	if err != nil {
+		return nil, errors.NewAppError("failed to decrypt file key", err)
+	}
+	defer crypto.ClearBytes(fileKey)
+
+	s.logger.Debug("🔐 Decrypting target collection key chain for move operation")
+	targetCollectionKey, err := s.collectionDecryptionService.ExecuteDecryptCollectionKeyChain(ctx, user, targetCollection, input.Password)
+	if err != nil {
+		return nil, errors.NewAppError("failed to decrypt target collection key chain", err)
+	}
+	defer crypto.ClearBytes(targetCollectionKey)
+
+	encryptedFileKey, err := s.fileEncryptionService.EncryptFileKey(ctx, fileKey, targetCollectionKey)
+	if err != nil {
+		return nil, errors.NewAppError("failed to encrypt file key with target collection key", err)
+	}
+
+	// The key itself has not changed, only what it is wrapped with. Historical keys were wrapped
+	// with the source collection key and cannot be opened by members of the target collection.
+	encryptedFileKey.KeyVersion = file.EncryptedFileKey.KeyVersion
+	encryptedFileKey.RotatedAt = file.EncryptedFileKey.RotatedAt
+	encryptedFileKey.PreviousKeys = nil
+
+	//
+	// STEP 5: Move the file in the cloud and update the local file record
+	//
+	previousVersion := file.Version
+	updatedFile, err := s.saveMove(ctx, file, targetCollection.ID, encryptedFileKey, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	newVersion := updatedFile.Version
+
+	s.logger.Info("✅ Successfully moved file to target collection",
+		zap.String("fileID", file.ID.String()),
+		zap.String("sourceCollectionID", sourceCollection.ID.String()),
+		zap.String("targetCollectionID", targetCollection.ID.String()),
+		zap.Uint64("newVersion", newVersion))
+
+	return &MoveOutput{
+		File:               updatedFile,
+		SourceCollectionID: sourceCollection.ID,
+		TargetCollectionID: targetCollection.ID,
+		PreviousVersion:    previousVersion,
+		NewVersion:         newVersion,
+	}, nil
+}
+
+// saveMove records the move of the file with its re-wrapped key. Files which exist in the cloud are
+// moved there first, so the cloud checks write access to both collections and rejects stale
+// versions, and the local record takes the version the cloud returns. If the local update fails
+// after that, the next sync pulls the moved file. Files which were never uploaded are only moved
+// locally.
+func (s *moveService) saveMove(ctx context.Context, file *dom_file.File, targetCollectionID gocql.UUID, encryptedFileKey *keys.EncryptedFileKey, userID gocql.UUID) (*dom_file.File, error) {
+	newVersion := file.Version + 1
+	modifiedAt := time.Now()
+
+	if file.SyncStatus != dom_file.SyncStatusLocalOnly {
+		s.logger.Debug("☁️ Moving file in cloud",
+			zap.String("fileID", file.ID.String()),
+			zap.String("targetCollectionID", targetCollectionID.String()))
+
+		cloudFile, err := s.cloudRepository.MoveFileInCloud(ctx, file.ID, &filedto.MoveFileRequest{
+			CollectionID:     targetCollectionID,
+			EncryptedFileKey: *encryptedFileKey,
+			Version:          file.Version,
+		})
+		if err != nil {
+			s.logger.Error("❌ failed to move file in cloud",
+				zap.String("fileID", file.ID.String()),
+				zap.Error(err))
+			return nil, errors.NewAppError("failed to move file in the cloud", err)
+		}
+		newVersion = cloudFile.Version
+		modifiedAt = cloudFile.ModifiedAt
+	}
+
+	if err := s.transactionManager.Begin(); err != nil {
+		s.logger.Error("❌ failed to begin transaction", zap.Error(err))
+		return nil, errors.NewAppError("failed to begin transaction", err)
+	}
+
+	updatedFile, err := s.updateFileUseCase.Execute(ctx, uc_file.UpdateFileInput{
+		ID:               file.ID,
+		CollectionID:     &targetCollectionID,
+		EncryptedFileKey: encryptedFileKey,
+		Version:          &newVersion,
+		ModifiedAt:       &modifiedAt,
+		ModifiedByUserID: &userID,
+	})
+	if err != nil {
+		s.logger.Error("❌ failed to update file during move",
+			zap.String("fileID", file.ID.String()),
+			zap.Error(err))
+		s.transactionManager.Rollback()
+		return nil, errors.NewAppError("failed to update file during move", err)
+	}
+
+	if err := s.transactionManager.Commit(); err != nil {
+		s.logger.Error("❌ failed to commit transaction", zap.Error(err))
+		s.transactionManager.Rollback()
+		return nil, errors.NewAppError("failed to commit transaction", err)
+	}
+
+	return updatedFile, nil
+}
+
+// hasWritePermission returns true if the user owns the collection or is a member with read-write
+// or admin access.
+func hasWritePermission(collection *dom_collection.Collection, userID gocql.UUID) bool {
+	if collection.OwnerID == userID {
+		return true
+	}
+	for _, member := range collection.Members {
+		if member.RecipientID != userID {
+			continue
+		}
+		if member.PermissionLevel == dom_collection.CollectionPermissionReadWrite ||
+			member.PermissionLevel == dom_collection.CollectionPermissionAdmin {
+			return true
+		}
+	}
+	return false
+}
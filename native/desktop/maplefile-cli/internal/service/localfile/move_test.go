@@ -0,0 +1,148 @@
+package localfile
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
+	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
+)
+
+// fakeCloudFiles records the moves sent to the cloud. Only MoveFileInCloud is implemented.
+type fakeCloudFiles struct {
+	filedto.FileDTORepository
+	moves []*filedto.MoveFileRequest
+	err   error
+}
+
+func (f *fakeCloudFiles) MoveFileInCloud(ctx context.Context, fileID gocql.UUID, request *filedto.MoveFileRequest) (*filedto.FileDTO, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.moves = append(f.moves, request)
+	return &filedto.FileDTO{
+		ID:           fileID,
+		CollectionID: request.CollectionID,
+		Version:      request.Version + 1,
+		ModifiedAt:   time.Now(),
+	}, nil
+}
+
+// fakeUpdateFile applies updates to the files it holds
+type fakeUpdateFile map[gocql.UUID]*dom_file.File
+
+func (f fakeUpdateFile) Execute(ctx context.Context, input uc_file.UpdateFileInput) (*dom_file.File, error) {
+	file := f[input.ID]
+	if input.CollectionID != nil {
+		file.CollectionID = *input.CollectionID
+	}
+	if input.EncryptedFileKey != nil {
+		file.EncryptedFileKey = *input.EncryptedFileKey
+	}
+	if input.Version != nil {
+		file.Version = *input.Version
+	}
+	return file, nil
+}
+
+// fakeTransactionManager counts the transactions which were committed
+type fakeTransactionManager struct {
+	commits int
+}
+
+func (m *fakeTransactionManager) Begin() error          { return nil }
+func (m *fakeTransactionManager) Commit() error         { m.commits++; return nil }
+func (m *fakeTransactionManager) Rollback() error       { return nil }
+func (m *fakeTransactionManager) IsInTransaction() bool { return false }
+
+func newTestMoveService(cloud *fakeCloudFiles, files fakeUpdateFile) (*moveService, *fakeTransactionManager) {
+	tx := &fakeTransactionManager{}
+	return &moveService{
+		logger:             zap.NewNop(),
+		transactionManager: tx,
+		cloudRepository:    cloud,
+		updateFileUseCase:  files,
+	}, tx
+}
+
+func TestSaveMove(t *testing.T) {
+	ctx := context.Background()
+	userID, targetID := gocql.TimeUUID(), gocql.TimeUUID()
+	rewrapped := &keys.EncryptedFileKey{Ciphertext: []byte("rewrapped"), Nonce: []byte("nonce"), KeyVersion: 1}
+
+	newFile := func(status dom_file.SyncStatus) *dom_file.File {
+		return &dom_file.File{
+			ID:           gocql.TimeUUID(),
+			CollectionID: gocql.TimeUUID(),
+			Version:      5,
+			SyncStatus:   status,
+		}
+	}
+
+	t.Run("Uploaded file is moved in the cloud first", func(t *testing.T) {
+		file := newFile(dom_file.SyncStatusSynced)
+		cloud := &fakeCloudFiles{}
+		svc, tx := newTestMoveService(cloud, fakeUpdateFile{file.ID: file})
+
+		moved, err := svc.saveMove(ctx, file, targetID, rewrapped, userID)
+		if err != nil {
+			t.Fatalf("saveMove() error = %v", err)
+		}
+		if len(cloud.moves) != 1 {
+			t.Fatalf("moves sent to the cloud = %d, want 1", len(cloud.moves))
+		}
+		if got := cloud.moves[0]; got.CollectionID != targetID || got.Version != 5 || string(got.EncryptedFileKey.Ciphertext) != "rewrapped" {
+			t.Errorf("cloud move = %+v, want the target collection, version 5 and the re-wrapped key", got)
+		}
+		if moved.CollectionID != targetID || moved.Version != 6 {
+			t.Errorf("local file collection = %s version = %d, want %s and the cloud version 6", moved.CollectionID, moved.Version, targetID)
+		}
+		if moved.SyncStatus != dom_file.SyncStatusSynced {
+			t.Errorf("SyncStatus = %v, want it to stay synced", moved.SyncStatus)
+		}
+		if tx.commits != 1 {
+			t.Errorf("commits = %d, want 1", tx.commits)
+		}
+	})
+
+	t.Run("Local only file is not sent to the cloud", func(t *testing.T) {
+		file := newFile(dom_file.SyncStatusLocalOnly)
+		cloud := &fakeCloudFiles{}
+		svc, _ := newTestMoveService(cloud, fakeUpdateFile{file.ID: file})
+
+		moved, err := svc.saveMove(ctx, file, targetID, rewrapped, userID)
+		if err != nil {
+			t.Fatalf("saveMove() error = %v", err)
+		}
+		if len(cloud.moves) != 0 {
+			t.Errorf("moves sent to the cloud = %d, want 0", len(cloud.moves))
+		}
+		if moved.CollectionID != targetID || moved.Version != 6 {
+			t.Errorf("local file collection = %s version = %d, want %s and version 6", moved.CollectionID, moved.Version, targetID)
+		}
+	})
+
+	t.Run("Rejected cloud move leaves the local file as it is", func(t *testing.T) {
+		file := newFile(dom_file.SyncStatusCloudOnly)
+		sourceID := file.CollectionID
+		cloud := &fakeCloudFiles{err: errors.New("server error: You don't have permission to move files to this collection")}
+		svc, tx := newTestMoveService(cloud, fakeUpdateFile{file.ID: file})
+
+		if _, err := svc.saveMove(ctx, file, targetID, rewrapped, userID); err == nil {
+			t.Fatal("saveMove() error = nil, want the cloud error")
+		}
+		if file.CollectionID != sourceID || file.Version != 5 {
+			t.Errorf("local file collection = %s version = %d, want it unchanged", file.CollectionID, file.Version)
+		}
+		if tx.commits != 0 {
+			t.Errorf("commits = %d, want 0", tx.commits)
+		}
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/medto"
 	uc_medto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/medto"
+	pkg_email "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/email"
 )
 
 // UpdateInput represents the input for updating user profile
@@ -63,6 +64,15 @@ func (s *updateMeService) Update(ctx context.Context, input *UpdateInput) (*Upda
 		return nil, errors.NewAppError("input is required", nil)
 	}
 
+	if input.Email != "" {
+		email, err := pkg_email.Normalize(input.Email)
+		if err != nil {
+			s.logger.Error("❌ Invalid email", zap.Error(err))
+			return nil, errors.NewAppError("invalid email", err)
+		}
+		input.Email = email
+	}
+
 	s.logger.Debug("Updating user profile",
 		zap.String("email", input.Email),
 		zap.String("first_name", input.FirstName),
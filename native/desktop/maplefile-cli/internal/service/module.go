@@ -9,10 +9,13 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectioncrypto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectionsharing"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectionsyncer"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/export"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filecrypto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filedownload"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filesyncer"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/fileupload"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/journal"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/keyrotation"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/localfile"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/me"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/recovery"
@@ -55,9 +58,13 @@ func ServiceModule() fx.Option {
 		fx.Provide(collection.NewUpdateService),
 		fx.Provide(collection.NewDeleteService),
 		fx.Provide(collection.NewSoftDeleteService),
+		fx.Provide(collection.NewArchiveService),
+		fx.Provide(collection.NewHistoryService),
+		fx.Provide(collection.NewSyncPreferenceService),
 		fx.Provide(collection.NewMoveService),
 
 		// Collection encryption and decrpytion services
+		fx.Provide(collectioncrypto.NewCollectionKeyCache),
 		fx.Provide(collectioncrypto.NewCollectionDecryptionService),
 		fx.Provide(collectioncrypto.NewCollectionEncryptionService),
 
@@ -80,6 +87,8 @@ func ServiceModule() fx.Option {
 		fx.Provide(localfile.NewLocalOnlyDeleteService),
 		fx.Provide(localfile.NewLockService),
 		fx.Provide(localfile.NewUnlockService),
+		fx.Provide(localfile.NewMoveService),
+		fx.Provide(localfile.NewFindSimilarService),
 
 		// Collection sharing service
 		fx.Provide(collectionsharing.NewGetCollectionMembersService),
@@ -87,22 +96,34 @@ func ServiceModule() fx.Option {
 		fx.Provide(collectionsharing.NewCollectionSharingService),
 		fx.Provide(collectionsharing.NewRemoveMemberCollectionSharingService),
 		fx.Provide(collectionsharing.NewSynchronizedCollectionSharingService),
+		fx.Provide(collectionsharing.NewRewrapMemberKeyService),
 
 		// File syncer services (existing)
 		fx.Provide(filesyncer.NewOffloadService),
 		fx.Provide(filesyncer.NewOnloadService),
+		fx.Provide(filesyncer.NewBatchOnloadService),
+		fx.Provide(filesyncer.NewAutoOnloadService),
+		fx.Provide(filesyncer.NewStorageUsageService),
 		fx.Provide(filesyncer.NewCloudOnlyDeleteService),
 
 		// File Upload file services
 		fx.Provide(fileupload.NewFileUploadService),
+		fx.Provide(fileupload.NewBatchFileUploadService),
 
 		// Download file services
 		fx.Provide(filedownload.NewDownloadService),
 
+		// Library export services
+		fx.Provide(export.NewExportService),
+
+		// Local operation journal services
+		fx.Provide(journal.NewShowService),
+
 		// Sync state services
 		fx.Provide(syncstate.NewGetService),
 		fx.Provide(syncstate.NewSaveService),
 		fx.Provide(syncstate.NewResetService),
+		fx.Provide(syncstate.NewConsistencyService),
 
 		// Sync DTO services
 		fx.Provide(syncdto.NewGetCollectionsService),
@@ -115,6 +136,9 @@ func ServiceModule() fx.Option {
 		fx.Provide(sync.NewSyncFileService),
 		fx.Provide(sync.NewSyncFullService),
 		fx.Provide(sync.NewSyncDebugService),
+		fx.Provide(sync.NewSyncDoctorService),
+		fx.Provide(sync.NewSyncDiffService),
+		fx.Provide(sync.NewSyncInspectService),
 
 		// Cloud-based interaction with user profile DTO
 		fx.Provide(me.NewGetMeService),
@@ -124,5 +148,8 @@ func ServiceModule() fx.Option {
 		fx.Provide(recovery.NewRecoveryService),
 		fx.Provide(recovery.NewRecoveryCleanupService),
 		fx.Provide(recovery.NewRecoveryKeyService),
+
+		// Master key rotation
+		fx.Provide(keyrotation.NewMasterKeyRotationService),
 	)
 }
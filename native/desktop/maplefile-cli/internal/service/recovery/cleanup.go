@@ -10,40 +10,69 @@ import (
 	uc_recovery "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/recovery"
 )
 
+// CleanupResult reports the stale recovery state removed by a cleanup
+type CleanupResult struct {
+	// ExpiredStateCleared is true if the stored state of an expired session was removed.
+	ExpiredStateCleared bool `json:"expired_state_cleared"`
+	// RecoveryDataCleared is true if recovery data without a session in progress was removed.
+	RecoveryDataCleared bool `json:"recovery_data_cleared"`
+}
+
 // RecoveryCleanupService provides functionality for cleaning up expired recovery data
 type RecoveryCleanupService interface {
-	// CleanupExpiredSessions removes expired recovery sessions
-	CleanupExpiredSessions(ctx context.Context) error
+	// CleanupExpiredSessions removes expired recovery sessions and the recovery data stored for them
+	CleanupExpiredSessions(ctx context.Context) (*CleanupResult, error)
 }
 
 // recoveryCleanupService implements the RecoveryCleanupService interface
 type recoveryCleanupService struct {
 	logger                        *zap.Logger
 	cleanupExpiredRecoveryUseCase uc_recovery.CleanupExpiredRecoveryDataUseCase
+	stateManager                  RecoveryStateManager
 }
 
 // NewRecoveryCleanupService creates a new recovery cleanup service
 func NewRecoveryCleanupService(
 	logger *zap.Logger,
 	cleanupExpiredRecoveryUseCase uc_recovery.CleanupExpiredRecoveryDataUseCase,
+	stateManager RecoveryStateManager,
 ) RecoveryCleanupService {
 	logger = logger.Named("RecoveryCleanupService")
 	return &recoveryCleanupService{
 		logger:                        logger,
 		cleanupExpiredRecoveryUseCase: cleanupExpiredRecoveryUseCase,
+		stateManager:                  stateManager,
 	}
 }
 
-// CleanupExpiredSessions removes expired recovery sessions
-func (s *recoveryCleanupService) CleanupExpiredSessions(ctx context.Context) error {
+// CleanupExpiredSessions removes the persisted state of an expired recovery session with its recovery
+// data, then the expired sessions, challenges and tokens of the recovery repository
+func (s *recoveryCleanupService) CleanupExpiredSessions(ctx context.Context) (*CleanupResult, error) {
 	s.logger.Info("🧹 Starting cleanup of expired recovery sessions")
 
-	err := s.cleanupExpiredRecoveryUseCase.Execute(ctx)
+	//
+	// STEP 1: Clear the persisted state of an expired session
+	//
+	stateCleared, dataCleared, err := s.stateManager.ClearExpiredState(ctx)
 	if err != nil {
-		return errors.NewAppError("failed to cleanup expired recovery sessions", err)
+		return nil, errors.NewAppError("failed to cleanup expired recovery state", err)
+	}
+
+	//
+	// STEP 2: Clear the expired records of the recovery repository
+	//
+	if err := s.cleanupExpiredRecoveryUseCase.Execute(ctx); err != nil {
+		return nil, errors.NewAppError("failed to cleanup expired recovery sessions", err)
+	}
+
+	result := &CleanupResult{
+		ExpiredStateCleared: stateCleared,
+		RecoveryDataCleared: dataCleared,
 	}
 
-	s.logger.Info("✅ Successfully cleaned up expired recovery sessions")
+	s.logger.Info("✅ Successfully cleaned up expired recovery sessions",
+		zap.Bool("expiredStateCleared", result.ExpiredStateCleared),
+		zap.Bool("recoveryDataCleared", result.RecoveryDataCleared))
 
-	return nil
+	return result, nil
 }
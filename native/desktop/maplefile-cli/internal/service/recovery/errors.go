@@ -0,0 +1,8 @@
+// internal/service/recovery/errors.go
+package recovery
+
+import "errors"
+
+// ErrIncorrectPassword is wrapped by the errors of `RecoveryKeyService` when the password doesn't
+// decrypt the master key, so callers can use `errors.Is` to prompt for the password again.
+var ErrIncorrectPassword = errors.New("incorrect password")
@@ -0,0 +1,96 @@
+// native/desktop/maplefile-cli/internal/service/recovery/key_check.go
+package recovery
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/base64"
+	"strings"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+)
+
+// minRecoveryKeyDistinctBytes is the minimum number of distinct byte values a freshly generated
+// recovery key must contain. 32 random bytes contain about 30 distinct values, the chance of fewer
+// than 16 is negligible, so a lower count means the random number generator is broken.
+const minRecoveryKeyDistinctBytes = 16
+
+// CleanRecoveryKey removes the display formatting from a recovery key entered by the user
+func CleanRecoveryKey(key string) string {
+	// Remove common formatting characters
+	key = strings.ReplaceAll(key, "-", "")
+	key = strings.ReplaceAll(key, " ", "")
+	key = strings.ReplaceAll(key, "\n", "")
+	key = strings.ReplaceAll(key, "\r", "")
+	key = strings.ReplaceAll(key, "\t", "")
+
+	return strings.TrimSpace(key)
+}
+
+// checkRecoveryKeyEntropy is a sanity guard against a broken random number generator. It rejects
+// keys of the wrong size, all-zero keys and keys made of a short repeating pattern.
+func checkRecoveryKeyEntropy(key []byte) error {
+	if len(key) != crypto.RecoveryKeySize {
+		return errors.NewAppError("generated recovery key has an invalid size", nil)
+	}
+
+	distinct := make(map[byte]struct{}, len(key))
+	for _, b := range key {
+		distinct[b] = struct{}{}
+	}
+	if len(distinct) < minRecoveryKeyDistinctBytes {
+		return errors.NewAppError("generated recovery key has insufficient entropy", nil)
+	}
+
+	// Reject keys which repeat a pattern of up to half the key length, e.g. "abcdabcd...".
+	for period := 1; period <= len(key)/2; period++ {
+		if bytes.Equal(key[period:], key[:len(key)-period]) {
+			return errors.NewAppError("generated recovery key has insufficient entropy", nil)
+		}
+	}
+
+	return nil
+}
+
+// verifyRecoveryKeyRoundTrip ensures the formatted recovery key shown to the user decodes back to
+// exactly the generated key, so the user is never given a key which cannot recover their account.
+func verifyRecoveryKeyRoundTrip(key []byte, formattedKey string) error {
+	decoded, err := base64.StdEncoding.DecodeString(CleanRecoveryKey(formattedKey))
+	if err != nil {
+		return errors.NewAppError("formatted recovery key could not be decoded", err)
+	}
+	defer crypto.ClearBytes(decoded)
+
+	if subtle.ConstantTimeCompare(decoded, key) != 1 {
+		return errors.NewAppError("formatted recovery key does not match the generated key", nil)
+	}
+	return nil
+}
+
+// verifyOldRecoveryKeyInvalidated ensures the master key saved for recovery can be decrypted with the
+// new recovery key and no longer with the old one, so rotating the recovery key really invalidates
+// the old key. The old key is nil if it couldn't be recovered, then only the new key is checked.
+func verifyOldRecoveryKeyInvalidated(saved keys.MasterKeyEncryptedWithRecoveryKey, oldRecoveryKey, newRecoveryKey, masterKey []byte) error {
+	decrypted, err := crypto.DecryptWithSecretBox(saved.Ciphertext, saved.Nonce, newRecoveryKey)
+	if err != nil {
+		return errors.NewAppError("saved master key cannot be decrypted with the new recovery key", err)
+	}
+	defer crypto.ClearBytes(decrypted)
+	if subtle.ConstantTimeCompare(decrypted, masterKey) != 1 {
+		return errors.NewAppError("saved master key does not match after decrypting with the new recovery key", nil)
+	}
+
+	if oldRecoveryKey == nil {
+		return nil
+	}
+	if subtle.ConstantTimeCompare(oldRecoveryKey, newRecoveryKey) == 1 {
+		return errors.NewAppError("new recovery key is the same as the old recovery key", nil)
+	}
+	if stale, err := crypto.DecryptWithSecretBox(saved.Ciphertext, saved.Nonce, oldRecoveryKey); err == nil {
+		crypto.ClearBytes(stale)
+		return errors.NewAppError("old recovery key can still decrypt the saved master key", nil)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package recovery
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+)
+
+// sequentialKey returns a recovery sized key of distinct bytes which passes the entropy check
+func sequentialKey() []byte {
+	key := make([]byte, crypto.RecoveryKeySize)
+	for i := range key {
+		key[i] = byte(i * 7)
+	}
+	return key
+}
+
+func TestCheckRecoveryKeyEntropy(t *testing.T) {
+	randomKey, err := crypto.GenerateRandomBytes(crypto.RecoveryKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		key     []byte
+		wantErr bool
+	}{
+		{name: "random key", key: randomKey},
+		{name: "distinct bytes", key: sequentialKey()},
+		{name: "wrong size", key: randomKey[:crypto.RecoveryKeySize-1], wantErr: true},
+		{name: "all zero", key: make([]byte, crypto.RecoveryKeySize), wantErr: true},
+		{name: "few distinct bytes", key: []byte("abcdefghhgfedcbaacegbdfhhfdbgeca"), wantErr: true},
+		{name: "repeating pattern", key: bytes.Repeat(sequentialKey()[:16], 2), wantErr: true},
+		{name: "short repeating pattern", key: bytes.Repeat([]byte{0x13, 0x37, 0xc0, 0xde}, crypto.RecoveryKeySize/4), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkRecoveryKeyEntropy(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkRecoveryKeyEntropy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyRecoveryKeyRoundTrip(t *testing.T) {
+	s := &recoveryService{}
+	key := sequentialKey()
+	formatted := s.formatRecoveryKey(base64.StdEncoding.EncodeToString(key))
+
+	wrongKey := sequentialKey()
+	wrongKey[0] ^= 0xFF
+
+	tests := []struct {
+		name         string
+		formattedKey string
+		wantErr      bool
+	}{
+		{name: "formatted key", formattedKey: formatted},
+		{name: "key typed with spaces and line breaks", formattedKey: " " + formatted[:20] + "\n" + formatted[20:] + "\t"},
+		{name: "wrong key", formattedKey: s.formatRecoveryKey(base64.StdEncoding.EncodeToString(wrongKey)), wantErr: true},
+		{name: "truncated key", formattedKey: formatted[:len(formatted)-5], wantErr: true},
+		{name: "not base64", formattedKey: "not-a-recovery-key!", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyRecoveryKeyRoundTrip(key, tt.formattedKey)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifyRecoveryKeyRoundTrip() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyOldRecoveryKeyInvalidated(t *testing.T) {
+	masterKey, err := crypto.GenerateRandomBytes(crypto.MasterKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldRecoveryKey, err := crypto.GenerateRandomBytes(crypto.RecoveryKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newRecoveryKey, err := crypto.GenerateRandomBytes(crypto.RecoveryKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// encryptMasterKey wraps the master key as it is saved for recovery
+	encryptMasterKey := func(recoveryKey []byte) keys.MasterKeyEncryptedWithRecoveryKey {
+		encrypted, err := crypto.EncryptWithSecretBox(masterKey, recoveryKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return keys.MasterKeyEncryptedWithRecoveryKey{Ciphertext: encrypted.Ciphertext, Nonce: encrypted.Nonce}
+	}
+
+	tests := []struct {
+		name           string
+		saved          keys.MasterKeyEncryptedWithRecoveryKey
+		oldRecoveryKey []byte
+		newRecoveryKey []byte
+		wantErr        bool
+	}{
+		{name: "rotated key", saved: encryptMasterKey(newRecoveryKey), oldRecoveryKey: oldRecoveryKey, newRecoveryKey: newRecoveryKey},
+		{name: "old key unknown", saved: encryptMasterKey(newRecoveryKey), newRecoveryKey: newRecoveryKey},
+		{name: "still saved with the old key", saved: encryptMasterKey(oldRecoveryKey), oldRecoveryKey: oldRecoveryKey, newRecoveryKey: newRecoveryKey, wantErr: true},
+		{name: "new key same as the old key", saved: encryptMasterKey(oldRecoveryKey), oldRecoveryKey: oldRecoveryKey, newRecoveryKey: oldRecoveryKey, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyOldRecoveryKeyInvalidated(tt.saved, tt.oldRecoveryKey, tt.newRecoveryKey, masterKey)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifyOldRecoveryKeyInvalidated() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// internal/service/recovery/methods.go
+package recovery
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	dom_recovery "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/recovery"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+)
+
+// RecoveryMethod is a way of proving ownership of an account to recover it. Each method verifies its
+// own secret and recovers the master key with it; the challenge verification with the cloud and the
+// complete-recovery path are shared by all methods.
+type RecoveryMethod interface {
+	// Name identifies the method with the cloud and on the command line
+	Name() string
+
+	// Description explains the method and the secret it asks for
+	Description() string
+
+	// IsConfigured reports whether the account has what the method needs to recover it
+	IsConfigured(user *user.User) bool
+
+	// RecoverMasterKey verifies the secret and returns the master key it unlocks
+	RecoverMasterKey(user *user.User, secret string) ([]byte, error)
+}
+
+// RecoveryMethodInfo describes a recovery method and whether an account can use it
+type RecoveryMethodInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Default     bool   `json:"default"`
+	// Configured is false when the account has not set the method up, or when the account has no
+	// local data on this device to check it against.
+	Configured bool `json:"configured"`
+}
+
+// defaultRecoveryMethods returns the recovery methods supported by this client, in listing order
+func defaultRecoveryMethods() []RecoveryMethod {
+	return []RecoveryMethod{
+		&recoveryKeyMethod{},
+	}
+}
+
+// recoveryKeyMethod recovers the master key with the recovery key shown when the account was created
+type recoveryKeyMethod struct{}
+
+// Name returns the name of the recovery key method
+func (m *recoveryKeyMethod) Name() string {
+	return dom_recovery.RecoveryMethodRecoveryKey
+}
+
+// Description explains the recovery key method
+func (m *recoveryKeyMethod) Description() string {
+	return "The recovery key shown when the account was created or its recovery key was last regenerated"
+}
+
+// IsConfigured reports whether the master key of the account is encrypted with a recovery key
+func (m *recoveryKeyMethod) IsConfigured(user *user.User) bool {
+	return user != nil && len(user.MasterKeyEncryptedWithRecoveryKey.Ciphertext) > 0
+}
+
+// RecoverMasterKey decrypts the master key with the recovery key
+func (m *recoveryKeyMethod) RecoverMasterKey(user *user.User, secret string) ([]byte, error) {
+	if !m.IsConfigured(user) {
+		return nil, errors.NewAppError("no recovery key configured for this account", nil)
+	}
+
+	recoveryKeyBytes, err := crypto.DecodeBase64Flexible(normalizeRecoveryKey(secret))
+	if err != nil {
+		return nil, errors.NewAppError("invalid recovery key format", err)
+	}
+	defer crypto.ClearBytes(recoveryKeyBytes)
+
+	if len(recoveryKeyBytes) != crypto.RecoveryKeySize {
+		return nil, errors.NewAppError("invalid recovery key size", nil)
+	}
+
+	masterKey, err := crypto.DecryptWithSecretBox(
+		user.MasterKeyEncryptedWithRecoveryKey.Ciphertext,
+		user.MasterKeyEncryptedWithRecoveryKey.Nonce,
+		recoveryKeyBytes,
+	)
+	if err != nil {
+		return nil, errors.NewAppError("invalid recovery key", nil)
+	}
+
+	return masterKey, nil
+}
+
+// normalizeRecoveryKey cleans up the recovery key format to be a proper base64 string
+func normalizeRecoveryKey(recoveryKey string) string {
+	// Remove any whitespace
+	cleanKey := strings.TrimSpace(recoveryKey)
+
+	// Remove hyphens and spaces that might be used for formatting
+	cleanKey = strings.ReplaceAll(cleanKey, "-", "")
+	cleanKey = strings.ReplaceAll(cleanKey, " ", "")
+
+	return cleanKey
+}
+
+// findRecoveryMethod returns the recovery method with the given name, the default method if it is empty
+func findRecoveryMethod(methods []RecoveryMethod, name string) (RecoveryMethod, error) {
+	if name == "" {
+		name = dom_recovery.DefaultRecoveryMethod
+	}
+
+	for _, method := range methods {
+		if method.Name() == name {
+			return method, nil
+		}
+	}
+
+	return nil, errors.NewAppError(fmt.Sprintf("unsupported recovery method %q, run 'maplefile-cli recovery methods' to list the supported methods", name), nil)
+}
@@ -0,0 +1,76 @@
+package recovery
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
+	dom_recovery "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/recovery"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+)
+
+func TestRecoveryKeyMethodRecoversMasterKey(t *testing.T) {
+	masterKey, err := crypto.GenerateRandomBytes(crypto.MasterKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recoveryKey, err := crypto.GenerateRandomBytes(crypto.RecoveryKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := crypto.EncryptWithSecretBox(masterKey, recoveryKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := &user.User{
+		MasterKeyEncryptedWithRecoveryKey: keys.MasterKeyEncryptedWithRecoveryKey{
+			Ciphertext: encrypted.Ciphertext,
+			Nonce:      encrypted.Nonce,
+		},
+	}
+
+	method := &recoveryKeyMethod{}
+	if !method.IsConfigured(u) {
+		t.Fatal("recovery key method should be configured")
+	}
+
+	// The recovery key is accepted in the grouped format it is shown in.
+	encoded := base64.StdEncoding.EncodeToString(recoveryKey)
+	recovered, err := method.RecoverMasterKey(u, encoded[:8]+"-"+encoded[8:])
+	if err != nil {
+		t.Fatalf("RecoverMasterKey() error = %v", err)
+	}
+	if !bytes.Equal(recovered, masterKey) {
+		t.Fatal("recovered master key does not match")
+	}
+
+	wrongKey, err := crypto.GenerateRandomBytes(crypto.RecoveryKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := method.RecoverMasterKey(u, base64.StdEncoding.EncodeToString(wrongKey)); err == nil {
+		t.Fatal("RecoverMasterKey() with the wrong recovery key should fail")
+	}
+
+	if method.IsConfigured(&user.User{}) {
+		t.Fatal("recovery key method should not be configured without a wrapped master key")
+	}
+}
+
+func TestFindRecoveryMethod(t *testing.T) {
+	methods := defaultRecoveryMethods()
+
+	method, err := findRecoveryMethod(methods, "")
+	if err != nil {
+		t.Fatalf("findRecoveryMethod() error = %v", err)
+	}
+	if method.Name() != dom_recovery.DefaultRecoveryMethod {
+		t.Fatalf("findRecoveryMethod() = %q, want the default method", method.Name())
+	}
+
+	if _, err := findRecoveryMethod(methods, "carrier_pigeon"); err == nil {
+		t.Fatal("findRecoveryMethod() with an unknown method should fail")
+	}
+}
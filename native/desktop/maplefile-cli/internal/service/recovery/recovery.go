@@ -13,31 +13,37 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
+	dom_recovery "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/recovery"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
 	uc_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/authdto"
 	uc_medto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/medto"
 	uc_recovery "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/recovery"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+	pkg_email "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/email"
 )
 
 // RecoveryService provides high-level functionality for account recovery
 type RecoveryService interface {
-	// InitiateRecovery starts the recovery process
-	InitiateRecovery(ctx context.Context, email string) (*RecoveryInitiateOutput, error)
+	// InitiateRecovery starts the recovery process with the given recovery method, the default one if empty
+	InitiateRecovery(ctx context.Context, email string, method string) (*RecoveryInitiateOutput, error)
 
-	// VerifyRecoveryKey verifies the recovery key and prepares for password reset
-	VerifyRecoveryKey(ctx context.Context, sessionID string, recoveryKey string) (*RecoveryVerifyOutput, error)
+	// VerifyRecovery verifies the secret of the session's recovery method and prepares for password reset
+	VerifyRecovery(ctx context.Context, sessionID string, secret string) (*RecoveryVerifyOutput, error)
 
 	// CompleteRecovery sets new password and completes the recovery
 	CompleteRecovery(ctx context.Context, recoveryToken string, newPassword string) (*RecoveryCompleteOutput, error)
 
 	// GetRecoveryStatus returns the current recovery session status
 	GetRecoveryStatus(ctx context.Context) (*RecoveryStatus, error)
+
+	// ListRecoveryMethods returns the supported recovery methods and whether the account has configured them
+	ListRecoveryMethods(ctx context.Context, email string) ([]*RecoveryMethodInfo, error)
 }
 
 // RecoveryInitiateOutput represents the output of recovery initiation
 type RecoveryInitiateOutput struct {
 	SessionID          string    `json:"session_id"`
+	Method             string    `json:"method"`
 	ChallengeID        string    `json:"challenge_id"`
 	EncryptedChallenge string    `json:"encrypted_challenge"`
 	ExpiresAt          time.Time `json:"expires_at"`
@@ -55,6 +61,8 @@ type RecoveryCompleteOutput struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
 	Email   string `json:"email"`
+	// NewRecoveryKey is the formatted recovery key generated to replace the one used for the recovery.
+	NewRecoveryKey string `json:"new_recovery_key"`
 }
 
 // RecoveryStatus represents the current state of recovery
@@ -62,6 +70,7 @@ type RecoveryStatus struct {
 	InProgress bool       `json:"in_progress"`
 	SessionID  string     `json:"session_id,omitempty"`
 	Email      string     `json:"email,omitempty"`
+	Method     string     `json:"method,omitempty"`
 	Stage      string     `json:"stage,omitempty"` // "initiated", "verified", "completed"
 	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
 }
@@ -80,6 +89,7 @@ type recoveryService struct {
 	getRecoverySessionUseCase   uc_recovery.GetRecoverySessionUseCase
 	getMeFromCloudUseCase       uc_medto.GetMeFromCloudUseCase
 	stateManager                RecoveryStateManager
+	methods                     []RecoveryMethod
 
 	// In-memory storage for recovery session state
 	mu            sync.RWMutex
@@ -117,12 +127,26 @@ func NewRecoveryService(
 		getRecoverySessionUseCase:   getRecoverySessionUseCase,
 		getMeFromCloudUseCase:       getMeFromCloudUseCase,
 		stateManager:                stateManager,
+		methods:                     defaultRecoveryMethods(),
 	}
 }
 
 // InitiateRecovery starts the recovery process
-func (s *recoveryService) InitiateRecovery(ctx context.Context, email string) (*RecoveryInitiateOutput, error) {
-	s.logger.Info("🔐 Initiating account recovery", zap.String("email", email))
+func (s *recoveryService) InitiateRecovery(ctx context.Context, email string, methodName string) (*RecoveryInitiateOutput, error) {
+	method, err := findRecoveryMethod(s.methods, methodName)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("🔐 Initiating account recovery",
+		zap.String("email", email),
+		zap.String("method", method.Name()))
+
+	release, err := s.acquireLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 
 	// Get IP address for rate limiting (in real app, get from request context)
 	ipAddress := "127.0.0.1" // Default for CLI
@@ -133,7 +157,7 @@ func (s *recoveryService) InitiateRecovery(ctx context.Context, email string) (*
 	//
 	if err := s.checkRateLimitUseCase.Execute(ctx, email, ipAddress); err != nil {
 		// Track failed attempt
-		_ = s.trackRecoveryAttemptUseCase.Execute(ctx, email, ipAddress, "recovery_key", false, userAgent)
+		_ = s.trackRecoveryAttemptUseCase.Execute(ctx, email, ipAddress, method.Name(), false, userAgent)
 
 		s.logger.Warn("⚠️ Recovery rate limit exceeded",
 			zap.String("email", email),
@@ -144,17 +168,17 @@ func (s *recoveryService) InitiateRecovery(ctx context.Context, email string) (*
 	//
 	// STEP 2: Initiate recovery
 	//
-	response, err := s.initiateRecoveryUseCase.Execute(ctx, email, "recovery_key")
+	response, err := s.initiateRecoveryUseCase.Execute(ctx, email, method.Name())
 	if err != nil {
 		// Track failed attempt
-		_ = s.trackRecoveryAttemptUseCase.Execute(ctx, email, ipAddress, "recovery_key", false, userAgent)
+		_ = s.trackRecoveryAttemptUseCase.Execute(ctx, email, ipAddress, method.Name(), false, userAgent)
 
 		s.logger.Error("❌ Failed to initiate recovery", zap.Error(err))
 		return nil, err
 	}
 
 	// Track successful initiation
-	_ = s.trackRecoveryAttemptUseCase.Execute(ctx, email, ipAddress, "recovery_key", true, userAgent)
+	_ = s.trackRecoveryAttemptUseCase.Execute(ctx, email, ipAddress, method.Name(), true, userAgent)
 
 	//
 	// STEP 3: Update local status and save to persistent storage
@@ -166,6 +190,7 @@ func (s *recoveryService) InitiateRecovery(ctx context.Context, email string) (*
 		InProgress: true,
 		SessionID:  response.SessionID,
 		Email:      email,
+		Method:     method.Name(),
 		Stage:      "initiated",
 		ExpiresAt:  &expiresAt,
 	}
@@ -184,15 +209,22 @@ func (s *recoveryService) InitiateRecovery(ctx context.Context, email string) (*
 
 	return &RecoveryInitiateOutput{
 		SessionID:          response.SessionID,
+		Method:             method.Name(),
 		ChallengeID:        response.ChallengeID,
 		EncryptedChallenge: response.EncryptedChallenge,
 		ExpiresAt:          expiresAt,
 	}, nil
 }
 
-// VerifyRecoveryKey verifies the recovery key and prepares for password reset
-func (s *recoveryService) VerifyRecoveryKey(ctx context.Context, sessionID string, recoveryKey string) (*RecoveryVerifyOutput, error) {
-	s.logger.Info("🔐 Verifying recovery key", zap.String("sessionID", sessionID))
+// VerifyRecovery verifies the secret of the session's recovery method and prepares for password reset
+func (s *recoveryService) VerifyRecovery(ctx context.Context, sessionID string, secret string) (*RecoveryVerifyOutput, error) {
+	s.logger.Info("🔐 Verifying recovery", zap.String("sessionID", sessionID))
+
+	release, err := s.acquireLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 
 	//
 	// STEP 1: First try to get session from repository (persistent storage)
@@ -223,10 +255,12 @@ func (s *recoveryService) VerifyRecoveryKey(ctx context.Context, sessionID strin
 	}
 
 	//
-	// STEP 2: Clean and normalize the recovery key format
+	// STEP 2: Find the recovery method the session was started with
 	//
-	cleanRecoveryKey := s.normalizeRecoveryKey(recoveryKey)
-	s.logger.Debug("Normalized recovery key format")
+	method, err := findRecoveryMethod(s.methods, session.Method)
+	if err != nil {
+		return nil, err
+	}
 
 	//
 	// STEP 3: Get the user from local storage
@@ -243,20 +277,22 @@ func (s *recoveryService) VerifyRecoveryKey(ctx context.Context, sessionID strin
 	}
 
 	//
-	// STEP 4: Validate the recovery key against local user data
+	// STEP 4: Verify the secret with the recovery method, which recovers the master key
 	//
-	if err := s.validateRecoveryKeyLocally(ctx, user, cleanRecoveryKey); err != nil {
-		s.logger.Error("❌ Recovery key validation failed", zap.Error(err))
-		return nil, errors.NewAppError("invalid recovery key", err)
+	masterKey, err := method.RecoverMasterKey(user, secret)
+	if err != nil {
+		s.logger.Error("❌ Recovery secret verification failed",
+			zap.String("method", method.Name()),
+			zap.Error(err))
+		return nil, err
 	}
 
 	//
-	// STEP 5: Decrypt master key using recovery key to prepare recovery data
+	// STEP 5: Prepare the recovery data for the shared complete-recovery path
 	//
-	recoveryData, err := s.prepareRecoveryData(ctx, user, cleanRecoveryKey)
-	if err != nil {
-		s.logger.Error("❌ Failed to prepare recovery data", zap.Error(err))
-		return nil, err
+	recoveryData := &uc_authdto.RecoveryData{
+		Email:     user.Email,
+		MasterKey: masterKey,
 	}
 
 	//
@@ -267,6 +303,7 @@ func (s *recoveryService) VerifyRecoveryKey(ctx context.Context, sessionID strin
 		InProgress: true,
 		SessionID:  sessionID,
 		Email:      session.Email,
+		Method:     method.Name(),
 		Stage:      "initiated",
 		ExpiresAt:  &session.ExpiresAt,
 	}
@@ -275,8 +312,9 @@ func (s *recoveryService) VerifyRecoveryKey(ctx context.Context, sessionID strin
 	//
 	// STEP 7: Verify with cloud service
 	//
-	response, err := s.verifyRecoveryUseCase.Execute(ctx, sessionID, cleanRecoveryKey)
+	response, err := s.verifyRecoveryUseCase.Execute(ctx, sessionID, masterKey)
 	if err != nil {
+		crypto.ClearBytes(masterKey)
 		s.logger.Error("❌ Failed to verify recovery with cloud", zap.Error(err))
 		return nil, err
 	}
@@ -304,8 +342,9 @@ func (s *recoveryService) VerifyRecoveryKey(ctx context.Context, sessionID strin
 		// Continue anyway - this is not critical for the recovery process
 	}
 
-	s.logger.Info("✅ Recovery key verified successfully",
+	s.logger.Info("✅ Recovery verified successfully",
 		zap.String("sessionID", sessionID),
+		zap.String("method", method.Name()),
 		zap.Time("expiresAt", expiresAt))
 
 	return &RecoveryVerifyOutput{
@@ -315,89 +354,31 @@ func (s *recoveryService) VerifyRecoveryKey(ctx context.Context, sessionID strin
 	}, nil
 }
 
-// validateRecoveryKeyLocally validates the recovery key against local user data
-func (s *recoveryService) validateRecoveryKeyLocally(ctx context.Context, user *user.User, recoveryKey string) error {
-	// Decode recovery key
-	recoveryKeyBytes, err := base64.StdEncoding.DecodeString(recoveryKey)
+// acquireLock takes the persistent recovery lock and returns a function which releases it
+func (s *recoveryService) acquireLock(ctx context.Context) (func(), error) {
+	owner, err := s.stateManager.AcquireLock(ctx)
 	if err != nil {
-		// Try URL-safe encoding
-		recoveryKeyBytes, err = base64.RawURLEncoding.DecodeString(recoveryKey)
-		if err != nil {
-			return errors.NewAppError("invalid recovery key format", err)
-		}
-	}
-
-	// Check if user has encrypted master key with recovery key
-	if len(user.MasterKeyEncryptedWithRecoveryKey.Ciphertext) == 0 {
-		return errors.NewAppError("no recovery key configured for this account", nil)
-	}
-
-	// Try to decrypt master key with the provided recovery key
-	_, err = crypto.DecryptWithSecretBox(
-		user.MasterKeyEncryptedWithRecoveryKey.Ciphertext,
-		user.MasterKeyEncryptedWithRecoveryKey.Nonce,
-		recoveryKeyBytes,
-	)
-
-	if err != nil {
-		return errors.NewAppError("invalid recovery key", nil)
+		s.logger.Warn("⚠️ Could not acquire recovery lock", zap.Error(err))
+		return nil, err
 	}
 
-	return nil
-}
-
-// prepareRecoveryData prepares the recovery data needed for password reset
-func (s *recoveryService) prepareRecoveryData(ctx context.Context, user *user.User, recoveryKey string) (*uc_authdto.RecoveryData, error) {
-	// Decode recovery key
-	recoveryKeyBytes, err := base64.StdEncoding.DecodeString(recoveryKey)
-	if err != nil {
-		// Try URL-safe encoding
-		recoveryKeyBytes, err = base64.RawURLEncoding.DecodeString(recoveryKey)
-		if err != nil {
-			return nil, errors.NewAppError("invalid recovery key format", err)
+	return func() {
+		if err := s.stateManager.ReleaseLock(ctx, owner); err != nil {
+			s.logger.Warn("Failed to release recovery lock", zap.Error(err))
 		}
-	}
-
-	// Decrypt master key using recovery key
-	masterKey, err := crypto.DecryptWithSecretBox(
-		user.MasterKeyEncryptedWithRecoveryKey.Ciphertext,
-		user.MasterKeyEncryptedWithRecoveryKey.Nonce,
-		recoveryKeyBytes,
-	)
-	if err != nil {
-		return nil, errors.NewAppError("failed to decrypt master key with recovery key", err)
-	}
-
-	// Prepare recovery data
-	recoveryData := &uc_authdto.RecoveryData{
-		Email:     user.Email,
-		MasterKey: masterKey,
-		// Add other fields as needed based on the RecoveryData structure
-	}
-
-	return recoveryData, nil
-}
-
-// normalizeRecoveryKey cleans up the recovery key format to be a proper base64 string
-func (s *recoveryService) normalizeRecoveryKey(recoveryKey string) string {
-	// Remove any whitespace
-	cleanKey := strings.TrimSpace(recoveryKey)
-
-	// Remove hyphens and spaces that might be used for formatting
-	cleanKey = strings.ReplaceAll(cleanKey, "-", "")
-	cleanKey = strings.ReplaceAll(cleanKey, " ", "")
-
-	s.logger.Debug("Recovery key normalized",
-		zap.String("original_length", fmt.Sprintf("%d", len(recoveryKey))),
-		zap.String("cleaned_length", fmt.Sprintf("%d", len(cleanKey))))
-
-	return cleanKey
+	}, nil
 }
 
 // CompleteRecovery sets new password and completes the recovery
 func (s *recoveryService) CompleteRecovery(ctx context.Context, recoveryToken string, newPassword string) (*RecoveryCompleteOutput, error) {
 	s.logger.Info("🔐 Completing account recovery")
 
+	release, err := s.acquireLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	//
 	// STEP 1: Try to restore recovery state from persistent storage if not in memory
 	//
@@ -474,9 +455,9 @@ func (s *recoveryService) CompleteRecovery(ctx context.Context, recoveryToken st
 		return nil, errors.NewAppError("failed to open transaction", err)
 	}
 
-	// Ensure transaction cleanup
+	// Ensure transaction cleanup, the transaction is closed already if it was committed
 	defer func() {
-		if s.userRepo.OpenTransaction() == nil { // Check if still in transaction
+		if s.userRepo.IsInTransaction() {
 			s.userRepo.DiscardTransaction()
 		}
 	}()
@@ -573,6 +554,15 @@ func (s *recoveryService) CompleteRecovery(ctx context.Context, recoveryToken st
 	}
 	defer crypto.ClearBytes(newRecoveryKey)
 
+	// Fail closed if the key looks broken or cannot be shown to the user exactly as generated.
+	if err := checkRecoveryKeyEntropy(newRecoveryKey); err != nil {
+		return nil, err
+	}
+	formattedKey := s.formatRecoveryKey(base64.StdEncoding.EncodeToString(newRecoveryKey))
+	if err := verifyRecoveryKeyRoundTrip(newRecoveryKey, formattedKey); err != nil {
+		return nil, err
+	}
+
 	// Encrypt recovery key with master key
 	encryptedRecoveryKey, err := crypto.EncryptWithSecretBox(newRecoveryKey, recoveryData.MasterKey)
 	if err != nil {
@@ -651,17 +641,14 @@ func (s *recoveryService) CompleteRecovery(ctx context.Context, recoveryToken st
 		s.logger.Warn("Failed to clear recovery data", zap.Error(err))
 	}
 
-	// Display new recovery key to user
-	newRecoveryKeyDisplay := base64.StdEncoding.EncodeToString(newRecoveryKey)
-	formattedKey := s.formatRecoveryKey(newRecoveryKeyDisplay)
-
 	s.logger.Info("✅ Account recovery completed successfully",
 		zap.String("email", recoveryData.Email))
 
 	return &RecoveryCompleteOutput{
-		Success: true,
-		Message: fmt.Sprintf("Password reset successfully. Your new recovery key: %s", formattedKey),
-		Email:   recoveryData.Email,
+		Success:        true,
+		Message:        "Password reset successfully. A new recovery key was generated.",
+		Email:          recoveryData.Email,
+		NewRecoveryKey: formattedKey,
 	}, nil
 }
 
@@ -760,6 +747,7 @@ func (s *recoveryService) GetRecoveryStatus(ctx context.Context) (*RecoveryStatu
 			InProgress: memoryStatus.InProgress,
 			SessionID:  memoryStatus.SessionID,
 			Email:      memoryStatus.Email,
+			Method:     memoryStatus.Method,
 			Stage:      memoryStatus.Stage,
 			ExpiresAt:  memoryStatus.ExpiresAt,
 		}, nil
@@ -782,6 +770,34 @@ func (s *recoveryService) GetRecoveryStatus(ctx context.Context) (*RecoveryStatu
 	return persistentStatus, nil
 }
 
+// ListRecoveryMethods returns the recovery methods supported by this client. Whether the account has
+// configured a method is checked against its local data, so no method is configured for an account
+// which never logged in on this device.
+func (s *recoveryService) ListRecoveryMethods(ctx context.Context, email string) ([]*RecoveryMethodInfo, error) {
+	email, err := pkg_email.Normalize(email)
+	if err != nil {
+		return nil, errors.NewAppError("invalid email", err)
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		s.logger.Error("❌ Failed to get user", zap.Error(err))
+		return nil, errors.NewAppError("failed to get user data", err)
+	}
+
+	methods := make([]*RecoveryMethodInfo, 0, len(s.methods))
+	for _, method := range s.methods {
+		methods = append(methods, &RecoveryMethodInfo{
+			Name:        method.Name(),
+			Description: method.Description(),
+			Default:     method.Name() == dom_recovery.DefaultRecoveryMethod,
+			Configured:  user != nil && method.IsConfigured(user),
+		})
+	}
+
+	return methods, nil
+}
+
 // generateRecoveryKeyDisplay generates a display-friendly recovery key
 func (s *recoveryService) generateRecoveryKeyDisplay(user *user.User) string {
 	// In a real implementation, this would decrypt and display the actual recovery key
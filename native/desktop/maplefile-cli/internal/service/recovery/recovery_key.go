@@ -15,6 +15,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/security"
 	uc_user "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/user"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+	pkg_email "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/email"
 )
 
 // RecoveryKeyService provides functionality for managing recovery keys
@@ -83,7 +84,10 @@ func (s *recoveryKeyService) ShowRecoveryKey(ctx context.Context, email string,
 	}
 
 	// Sanitize email
-	email = strings.ToLower(strings.TrimSpace(email))
+	email, err := pkg_email.Normalize(email)
+	if err != nil {
+		return nil, errors.NewAppError("invalid email", err)
+	}
 
 	//
 	// STEP 2: Get user
@@ -128,7 +132,7 @@ func (s *recoveryKeyService) ShowRecoveryKey(ctx context.Context, email string,
 			Success:      false,
 			ErrorMessage: "incorrect password or corrupted master key",
 		})
-		return nil, errors.NewAppError("incorrect password", nil) // Don't include underlying crypto error
+		return nil, errors.NewAppError("failed to decrypt master key", ErrIncorrectPassword) // Don't include underlying crypto error
 	}
 
 	// Ensure masterKey is cleared
@@ -203,7 +207,10 @@ func (s *recoveryKeyService) GenerateNewRecoveryKey(ctx context.Context, email s
 	}
 
 	// Sanitize email
-	email = strings.ToLower(strings.TrimSpace(email))
+	email, err = pkg_email.Normalize(email)
+	if err != nil {
+		return nil, errors.NewAppError("invalid email", err)
+	}
 
 	//
 	// STEP 2: Start transaction
@@ -275,10 +282,24 @@ func (s *recoveryKeyService) GenerateNewRecoveryKey(ctx context.Context, email s
 			ErrorMessage: "incorrect password or corrupted master key",
 		})
 		// Return a generic "incorrect password" error to the user
-		return nil, errors.NewAppError("incorrect password", nil) // Don't expose decryption failure detail
+		return nil, errors.NewAppError("failed to decrypt master key", ErrIncorrectPassword) // Don't expose decryption failure detail
 	}
 	defer crypto.ClearBytes(masterKey) // Clear master key
 
+	// Keep the old recovery key to check it is invalidated once the new one is saved
+	oldRecoveryKey, oldKeyErr := crypto.DecryptWithSecretBox(
+		user.EncryptedRecoveryKey.Ciphertext,
+		user.EncryptedRecoveryKey.Nonce,
+		masterKey,
+	)
+	if oldKeyErr != nil {
+		s.logger.Warn("⚠️ Old recovery key cannot be decrypted, only checking the new recovery key after generation",
+			zap.String("email", email),
+			zap.Error(oldKeyErr))
+		oldRecoveryKey = nil
+	}
+	defer crypto.ClearBytes(oldRecoveryKey)
+
 	//
 	// STEP 6: Generate new recovery key
 	//
@@ -288,6 +309,16 @@ func (s *recoveryKeyService) GenerateNewRecoveryKey(ctx context.Context, email s
 	}
 	defer crypto.ClearBytes(newRecoveryKey) // Clear raw new recovery key after base64 encoding
 
+	// Fail closed if the key looks broken or cannot be shown to the user exactly as generated.
+	if err = checkRecoveryKeyEntropy(newRecoveryKey); err != nil {
+		return nil, err
+	}
+	recoveryKeyBase64 := base64.StdEncoding.EncodeToString(newRecoveryKey)
+	formattedKey := s.formatRecoveryKey(recoveryKeyBase64)
+	if err = verifyRecoveryKeyRoundTrip(newRecoveryKey, formattedKey); err != nil {
+		return nil, err
+	}
+
 	//
 	// STEP 7: Encrypt new recovery key with master key
 	//
@@ -326,7 +357,27 @@ func (s *recoveryKeyService) GenerateNewRecoveryKey(ctx context.Context, email s
 	}
 
 	//
-	// STEP 11: Commit transaction
+	// STEP 11: Verify the saved user can only be recovered with the new recovery key
+	//
+	savedUser, err := s.getByEmailUseCase.Execute(ctx, email)
+	if err != nil || savedUser == nil {
+		if err == nil {
+			err = errors.NewAppError("user not found after saving new recovery key", nil)
+		}
+		return nil, err
+	}
+	if err = verifyOldRecoveryKeyInvalidated(savedUser.MasterKeyEncryptedWithRecoveryKey, oldRecoveryKey, newRecoveryKey, masterKey); err != nil {
+		s.cryptoAuditService.LogCryptoOperation(ctx, &security.CryptoAuditEvent{
+			Operation:    "generate_recovery_key_verify_invalidation",
+			UserID:       user.ID.String(),
+			Success:      false,
+			ErrorMessage: err.Error(),
+		})
+		return nil, err
+	}
+
+	//
+	// STEP 12: Commit transaction
 	//
 	commitErr = s.userRepo.CommitTransaction() // Assign to commitErr for defer
 	if commitErr != nil {
@@ -336,12 +387,6 @@ func (s *recoveryKeyService) GenerateNewRecoveryKey(ctx context.Context, email s
 
 	// Sensitive data clear already handled by defers
 
-	//
-	// STEP 12: Format recovery key for display
-	//
-	recoveryKeyBase64 := base64.StdEncoding.EncodeToString(newRecoveryKey)
-	formattedKey := s.formatRecoveryKey(recoveryKeyBase64)
-
 	// Log successful operation
 	s.cryptoAuditService.LogKeyOperation(ctx, &security.CryptoAuditEvent{
 		Operation: "generate_new_recovery_key",
@@ -378,7 +423,10 @@ func (s *recoveryKeyService) ValidateRecoveryKey(ctx context.Context, email stri
 	}
 
 	// Sanitize email
-	email = strings.ToLower(strings.TrimSpace(email))
+	email, err := pkg_email.Normalize(email)
+	if err != nil {
+		return errors.NewAppError("invalid email", err)
+	}
 
 	//
 	// STEP 2: Get user
@@ -398,13 +446,9 @@ func (s *recoveryKeyService) ValidateRecoveryKey(ctx context.Context, email stri
 	cleanKey := strings.ReplaceAll(recoveryKey, "-", "")
 	cleanKey = strings.ReplaceAll(cleanKey, " ", "")
 
-	recoveryKeyBytes, err := base64.StdEncoding.DecodeString(cleanKey)
+	recoveryKeyBytes, err := crypto.DecodeBase64Flexible(cleanKey)
 	if err != nil {
-		// Try URL-safe encoding (though standard is more likely for display)
-		recoveryKeyBytes, err = base64.RawURLEncoding.DecodeString(cleanKey)
-		if err != nil {
-			return errors.NewAppError("invalid recovery key format", err)
-		}
+		return errors.NewAppError("invalid recovery key format", err)
 	}
 	defer crypto.ClearBytes(recoveryKeyBytes) // Clear decoded key after use
 
@@ -0,0 +1,96 @@
+package recovery
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/recoverydto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
+	uc_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/authdto"
+)
+
+// transactionCountingUserRepo records the transactions opened and discarded on the user repository
+type transactionCountingUserRepo struct {
+	user.Repository
+	inTransaction bool
+	opened        int
+	discarded     int
+}
+
+func (r *transactionCountingUserRepo) OpenTransaction() error {
+	r.opened++
+	r.inTransaction = true
+	return nil
+}
+
+func (r *transactionCountingUserRepo) CommitTransaction() error {
+	r.inTransaction = false
+	return nil
+}
+
+func (r *transactionCountingUserRepo) DiscardTransaction() {
+	r.discarded++
+	r.inTransaction = false
+}
+
+func (r *transactionCountingUserRepo) IsInTransaction() bool {
+	return r.inTransaction
+}
+
+// unlockedStateManager grants the recovery lock without persisting anything
+type unlockedStateManager struct {
+	RecoveryStateManager
+}
+
+func (unlockedStateManager) AcquireLock(ctx context.Context) (string, error) {
+	return "test", nil
+}
+
+func (unlockedStateManager) ReleaseLock(ctx context.Context, owner string) error {
+	return nil
+}
+
+// failingCompleteRecoveryUseCase fails like an unreachable cloud
+type failingCompleteRecoveryUseCase struct{}
+
+func (failingCompleteRecoveryUseCase) Execute(ctx context.Context, recoveryToken string, newPassword string, masterKeyFromRecovery []byte) (*recoverydto.RecoveryCompleteResponseDTO, error) {
+	return nil, errors.New("cloud unavailable")
+}
+
+func TestCompleteRecoveryDiscardsTransactionOnce(t *testing.T) {
+	userRepo := &transactionCountingUserRepo{}
+	s := &recoveryService{
+		logger:                  zap.NewNop(),
+		userRepo:                userRepo,
+		completeRecoveryUseCase: failingCompleteRecoveryUseCase{},
+		stateManager:            unlockedStateManager{},
+		currentStatus: &RecoveryStatus{
+			InProgress: true,
+			SessionID:  "session",
+			Email:      "user@example.com",
+			Stage:      "verified",
+		},
+		recoveryData: &uc_authdto.RecoveryData{
+			SessionID: "session",
+			Email:     "user@example.com",
+			MasterKey: make([]byte, 32),
+		},
+		recoveryToken: "token",
+	}
+
+	if _, err := s.CompleteRecovery(context.Background(), "", "new-password"); err == nil {
+		t.Fatal("CompleteRecovery() error = nil, want the cloud error")
+	}
+	if userRepo.opened != 1 {
+		t.Errorf("OpenTransaction() called %d times, want 1", userRepo.opened)
+	}
+	if userRepo.discarded != 1 {
+		t.Errorf("DiscardTransaction() called %d times, want 1", userRepo.discarded)
+	}
+	if userRepo.inTransaction {
+		t.Error("transaction still open after CompleteRecovery() returned")
+	}
+}
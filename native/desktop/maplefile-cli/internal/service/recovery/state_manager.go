@@ -5,11 +5,15 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/gocql/gocql"
 	"go.uber.org/zap"
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/recovery"
 	uc_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/authdto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/storage"
@@ -18,6 +22,9 @@ import (
 const (
 	recoveryStateKey = "current_recovery_state"
 	recoveryDataKey  = "current_recovery_data"
+
+	// recoveryLockFileName is the file in the app data directory which holds the recovery lock
+	recoveryLockFileName = "recovery.lock"
 )
 
 // RecoveryStateManager handles persistent recovery state
@@ -25,12 +32,20 @@ type RecoveryStateManager interface {
 	SaveState(ctx context.Context, status *RecoveryStatus) error
 	LoadState(ctx context.Context) (*RecoveryStatus, error)
 	ClearState(ctx context.Context) error
+	// ClearExpiredState removes the stored state once its session expired, along with recovery data
+	// which no longer belongs to a session in progress. It reports what was removed.
+	ClearExpiredState(ctx context.Context) (stateCleared bool, dataCleared bool, err error)
 	FindActiveSession(ctx context.Context) (*RecoveryStatus, error)
 
 	// Recovery data persistence methods
 	SaveRecoveryData(ctx context.Context, data *uc_authdto.RecoveryData, recoveryToken string) error
 	LoadRecoveryData(ctx context.Context) (*uc_authdto.RecoveryData, string, error)
 	ClearRecoveryData(ctx context.Context) error
+
+	// Lock methods guarding against concurrent recovery flows. AcquireLock returns
+	// an owner token which must be passed to ReleaseLock.
+	AcquireLock(ctx context.Context) (string, error)
+	ReleaseLock(ctx context.Context, owner string) error
 }
 
 // PersistentRecoveryState represents the recovery state stored in the database
@@ -51,10 +66,17 @@ type PersistentRecoveryData struct {
 	SavedAt       time.Time `json:"saved_at"`
 }
 
+// PersistentRecoveryLock represents the recovery lock stored in the lock file
+type PersistentRecoveryLock struct {
+	Owner      string    `json:"owner"`
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
 type recoveryStateManager struct {
-	logger       *zap.Logger
-	storage      storage.Storage
-	recoveryRepo recovery.RecoveryRepository
+	logger        *zap.Logger
+	storage       storage.Storage
+	recoveryRepo  recovery.RecoveryRepository
+	configService config.ConfigService
 }
 
 // NewRecoveryStateManager creates a new recovery state manager
@@ -62,12 +84,14 @@ func NewRecoveryStateManager(
 	logger *zap.Logger,
 	storage storage.Storage,
 	recoveryRepo recovery.RecoveryRepository,
+	configService config.ConfigService,
 ) RecoveryStateManager {
 	logger = logger.Named("RecoveryStateManager")
 	return &recoveryStateManager{
-		logger:       logger,
-		storage:      storage,
-		recoveryRepo: recoveryRepo,
+		logger:        logger,
+		storage:       storage,
+		recoveryRepo:  recoveryRepo,
+		configService: configService,
 	}
 }
 
@@ -156,6 +180,57 @@ func (rsm *recoveryStateManager) ClearState(ctx context.Context) error {
 	return nil
 }
 
+// ClearExpiredState removes the recovery state of an expired session and any recovery data left
+// without a session in progress, such as the master key of a recovery which was never completed.
+func (rsm *recoveryStateManager) ClearExpiredState(ctx context.Context) (bool, bool, error) {
+	stateData, err := rsm.storage.Get(recoveryStateKey)
+	if err != nil {
+		rsm.logger.Error("Failed to load recovery state from storage", zap.Error(err))
+		return false, false, errors.NewAppError("failed to load recovery state", err)
+	}
+
+	inProgress := false
+	stateCleared := false
+	if stateData != nil {
+		var persistentState PersistentRecoveryState
+		if err := json.Unmarshal(stateData, &persistentState); err != nil {
+			rsm.logger.Error("Failed to unmarshal recovery state", zap.Error(err))
+			return false, false, errors.NewAppError("failed to parse recovery state", err)
+		}
+
+		if persistentState.ExpiresAt != nil && time.Now().After(*persistentState.ExpiresAt) {
+			rsm.logger.Info("Clearing expired recovery state",
+				zap.String("sessionID", persistentState.SessionID),
+				zap.Time("expiresAt", *persistentState.ExpiresAt))
+			if err := rsm.ClearState(ctx); err != nil {
+				return false, false, err
+			}
+			stateCleared = true
+		} else {
+			inProgress = persistentState.InProgress
+		}
+	}
+
+	if inProgress {
+		return stateCleared, false, nil
+	}
+
+	recoveryData, err := rsm.storage.Get(recoveryDataKey)
+	if err != nil {
+		rsm.logger.Error("Failed to load recovery data from storage", zap.Error(err))
+		return stateCleared, false, errors.NewAppError("failed to load recovery data", err)
+	}
+	if recoveryData == nil {
+		return stateCleared, false, nil
+	}
+
+	rsm.logger.Info("Clearing recovery data without a session in progress")
+	if err := rsm.ClearRecoveryData(ctx); err != nil {
+		return stateCleared, false, err
+	}
+	return stateCleared, true, nil
+}
+
 // SaveRecoveryData saves the recovery data to persistent storage
 func (rsm *recoveryStateManager) SaveRecoveryData(ctx context.Context, data *uc_authdto.RecoveryData, recoveryToken string) error {
 	if data == nil {
@@ -239,6 +314,231 @@ func (rsm *recoveryStateManager) ClearRecoveryData(ctx context.Context) error {
 	return nil
 }
 
+// AcquireLock takes the persistent recovery lock. The lock is a file created exclusively, so of
+// several processes or goroutines racing for it exactly one succeeds. A lock held for longer than
+// the configured timeout is considered abandoned and reclaimed, unless the stored session has not
+// yet expired.
+func (rsm *recoveryStateManager) AcquireLock(ctx context.Context) (string, error) {
+	path, err := rsm.lockFilePath(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	lock := &PersistentRecoveryLock{
+		Owner:      gocql.TimeUUID().String(),
+		AcquiredAt: time.Now(),
+	}
+	data, err := json.Marshal(lock)
+	if err != nil {
+		rsm.logger.Error("Failed to marshal recovery lock", zap.Error(err))
+		return "", errors.NewAppError("failed to acquire recovery lock", err)
+	}
+
+	// A reclaimed lock is retried once, losing to whoever creates the lock file first
+	for attempt := 0; attempt < 2; attempt++ {
+		created, err := createLockFile(path, data)
+		if err != nil {
+			rsm.logger.Error("Failed to create recovery lock file", zap.Error(err))
+			return "", errors.NewAppError("failed to acquire recovery lock", err)
+		}
+		if created {
+			rsm.logger.Debug("Successfully acquired recovery lock", zap.String("owner", lock.Owner))
+			return lock.Owner, nil
+		}
+
+		if err := rsm.reclaimAbandonedLock(ctx, path); err != nil {
+			return "", err
+		}
+	}
+
+	rsm.logger.Debug("Recovery lock was taken by another operation while reclaiming it")
+	return "", errors.NewAppError("failed to acquire recovery lock", recovery.ErrSessionLocked)
+}
+
+// reclaimAbandonedLock removes the lock file at path if its holder abandoned it, and returns
+// ErrSessionLocked if the lock is still held
+func (rsm *recoveryStateManager) reclaimAbandonedLock(ctx context.Context, path string) error {
+	existing, err := readLockFile(path)
+	if err != nil {
+		rsm.logger.Error("Failed to read recovery lock file", zap.Error(err))
+		return errors.NewAppError("failed to load recovery lock", err)
+	}
+	if existing == nil {
+		// Released since we tried to create it
+		return nil
+	}
+
+	timeout, err := rsm.configService.GetRecoveryLockTimeout(ctx)
+	if err != nil {
+		rsm.logger.Error("Failed to get recovery lock timeout", zap.Error(err))
+		return errors.NewAppError("failed to get recovery lock timeout", err)
+	}
+
+	heldFor := time.Since(existing.AcquiredAt)
+	if heldFor < timeout {
+		rsm.logger.Debug("Recovery lock is held by another operation",
+			zap.String("owner", existing.Owner),
+			zap.Duration("heldFor", heldFor))
+		return errors.NewAppError("failed to acquire recovery lock", recovery.ErrSessionLocked)
+	}
+
+	// The lock looks abandoned, but do not reclaim it while the session it guards is still valid.
+	active, err := rsm.hasUnexpiredState()
+	if err != nil {
+		return err
+	}
+	if active {
+		rsm.logger.Debug("Recovery lock is stale but its session has not expired",
+			zap.String("owner", existing.Owner),
+			zap.Duration("heldFor", heldFor))
+		return errors.NewAppError("failed to acquire recovery lock", recovery.ErrSessionLocked)
+	}
+
+	// Move the abandoned lock aside rather than deleting it, so that of several processes
+	// reclaiming it only one succeeds, and a lock taken in the meantime can be put back.
+	reclaimedPath := path + "." + gocql.TimeUUID().String() + ".reclaimed"
+	if err := os.Rename(path, reclaimedPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		rsm.logger.Error("Failed to reclaim recovery lock file", zap.Error(err))
+		return errors.NewAppError("failed to reclaim recovery lock", err)
+	}
+	defer os.Remove(reclaimedPath)
+
+	reclaimed, err := readLockFile(reclaimedPath)
+	if err != nil || reclaimed == nil || reclaimed.Owner != existing.Owner {
+		// Another operation took the lock after we read it, so hand it back
+		if err := os.Link(reclaimedPath, path); err != nil {
+			rsm.logger.Warn("⚠️ Failed to restore a recovery lock taken while reclaiming it", zap.Error(err))
+		}
+		return errors.NewAppError("failed to acquire recovery lock", recovery.ErrSessionLocked)
+	}
+
+	rsm.logger.Warn("⚠️ Reclaimed abandoned recovery lock",
+		zap.String("owner", existing.Owner),
+		zap.Time("acquiredAt", existing.AcquiredAt),
+		zap.Duration("timeout", timeout))
+	return nil
+}
+
+// ReleaseLock removes the persistent recovery lock if it is still held by the given owner
+func (rsm *recoveryStateManager) ReleaseLock(ctx context.Context, owner string) error {
+	path, err := rsm.lockFilePath(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing, err := readLockFile(path)
+	if err != nil {
+		rsm.logger.Error("Failed to read recovery lock file", zap.Error(err))
+		return errors.NewAppError("failed to load recovery lock", err)
+	}
+
+	if existing == nil {
+		return nil
+	}
+
+	if existing.Owner != owner {
+		rsm.logger.Warn("⚠️ Recovery lock is held by a different owner, leaving it in place",
+			zap.String("owner", owner),
+			zap.String("currentOwner", existing.Owner))
+		return nil
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		rsm.logger.Error("Failed to remove recovery lock file", zap.Error(err))
+		return errors.NewAppError("failed to release recovery lock", err)
+	}
+
+	rsm.logger.Debug("Successfully released recovery lock", zap.String("owner", owner))
+	return nil
+}
+
+// lockFilePath returns the path of the recovery lock file in the app data directory
+func (rsm *recoveryStateManager) lockFilePath(ctx context.Context) (string, error) {
+	appDataDir, err := rsm.configService.GetAppDataDirPath(ctx)
+	if err != nil {
+		rsm.logger.Error("Failed to get app data directory", zap.Error(err))
+		return "", errors.NewAppError("failed to get app data directory", err)
+	}
+	if err := os.MkdirAll(appDataDir, 0700); err != nil {
+		return "", errors.NewAppError("failed to create app data directory", err)
+	}
+	return filepath.Join(appDataDir, recoveryLockFileName), nil
+}
+
+// createLockFile creates the lock file at path with the given content, reporting false if it
+// already exists
+func createLockFile(path string, data []byte) (bool, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		if os.IsExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(path)
+		return false, err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(path)
+		return false, err
+	}
+	return true, nil
+}
+
+// readLockFile reads the lock file at path, returning nil if no lock is held. A lock file whose
+// content can't be parsed is still being written by its creator, or was left half written, so it
+// is reported as acquired when the file was last modified.
+func readLockFile(path string) (*PersistentRecoveryLock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var lock PersistentRecoveryLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		info, err := os.Stat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return &PersistentRecoveryLock{AcquiredAt: info.ModTime()}, nil
+	}
+	return &lock, nil
+}
+
+// hasUnexpiredState reports whether the stored recovery state belongs to a session which has not yet expired
+func (rsm *recoveryStateManager) hasUnexpiredState() (bool, error) {
+	data, err := rsm.storage.Get(recoveryStateKey)
+	if err != nil {
+		rsm.logger.Error("Failed to load recovery state from storage", zap.Error(err))
+		return false, errors.NewAppError("failed to load recovery state", err)
+	}
+
+	if data == nil {
+		return false, nil
+	}
+
+	var persistentState PersistentRecoveryState
+	if err := json.Unmarshal(data, &persistentState); err != nil {
+		rsm.logger.Error("Failed to unmarshal recovery state", zap.Error(err))
+		return false, errors.NewAppError("failed to parse recovery state", err)
+	}
+
+	return persistentState.InProgress &&
+		persistentState.ExpiresAt != nil &&
+		time.Now().Before(*persistentState.ExpiresAt), nil
+}
+
 // FindActiveSession searches for an active recovery session in the recovery repository
 func (rsm *recoveryStateManager) FindActiveSession(ctx context.Context) (*RecoveryStatus, error) {
 	rsm.logger.Debug("Searching for active recovery sessions")
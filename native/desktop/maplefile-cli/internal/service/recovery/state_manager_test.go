@@ -0,0 +1,135 @@
+package recovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/recovery"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/storage"
+)
+
+// lockConfigService keeps the app data in a test directory
+type lockConfigService struct {
+	config.ConfigService
+	appDataDir  string
+	lockTimeout time.Duration
+}
+
+func (c *lockConfigService) GetAppDataDirPath(ctx context.Context) (string, error) {
+	return c.appDataDir, nil
+}
+
+func (c *lockConfigService) GetRecoveryLockTimeout(ctx context.Context) (time.Duration, error) {
+	return c.lockTimeout, nil
+}
+
+// emptyStorage holds no recovery state
+type emptyStorage struct {
+	storage.Storage
+}
+
+func (emptyStorage) Get(key string) ([]byte, error) {
+	return nil, nil
+}
+
+// newTestStateManager returns a state manager with its own storage sharing the app data directory,
+// like another process of the CLI
+func newTestStateManager(configService *lockConfigService) *recoveryStateManager {
+	return NewRecoveryStateManager(zap.NewNop(), emptyStorage{}, nil, configService).(*recoveryStateManager)
+}
+
+func TestAcquireLockContention(t *testing.T) {
+	const contenders = 16
+	ctx := context.Background()
+	configService := &lockConfigService{appDataDir: t.TempDir(), lockTimeout: time.Hour}
+
+	var wg sync.WaitGroup
+	owners := make([]string, contenders)
+	errs := make([]error, contenders)
+	start := make(chan struct{})
+	for i := range contenders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			owners[i], errs[i] = newTestStateManager(configService).AcquireLock(ctx)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	winner := ""
+	for i := range contenders {
+		if errs[i] == nil {
+			if winner != "" {
+				t.Fatalf("both %s and %s acquired the recovery lock", winner, owners[i])
+			}
+			winner = owners[i]
+		} else if !errors.Is(errs[i], recovery.ErrSessionLocked) {
+			t.Errorf("AcquireLock() error = %v, want ErrSessionLocked", errs[i])
+		}
+	}
+	if winner == "" {
+		t.Fatal("no contender acquired the recovery lock")
+	}
+
+	// Only the winner can release it, then the lock can be taken again
+	manager := newTestStateManager(configService)
+	if err := manager.ReleaseLock(ctx, "someone else"); err != nil {
+		t.Fatalf("ReleaseLock() error = %v", err)
+	}
+	if _, err := manager.AcquireLock(ctx); !errors.Is(err, recovery.ErrSessionLocked) {
+		t.Fatalf("AcquireLock() error = %v after releasing another owner's lock, want ErrSessionLocked", err)
+	}
+	if err := manager.ReleaseLock(ctx, winner); err != nil {
+		t.Fatalf("ReleaseLock() error = %v", err)
+	}
+	if _, err := manager.AcquireLock(ctx); err != nil {
+		t.Fatalf("AcquireLock() error = %v after the lock was released", err)
+	}
+}
+
+func TestAcquireLockReclaimsAbandonedLock(t *testing.T) {
+	ctx := context.Background()
+	configService := &lockConfigService{appDataDir: t.TempDir(), lockTimeout: time.Minute}
+
+	abandoned, err := json.Marshal(&PersistentRecoveryLock{Owner: "crashed", AcquiredAt: time.Now().Add(-time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(configService.appDataDir, recoveryLockFileName), abandoned, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Of the operations reclaiming the abandoned lock at once, only one gets it
+	const contenders = 8
+	var wg sync.WaitGroup
+	acquired := make(chan string, contenders)
+	for range contenders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if owner, err := newTestStateManager(configService).AcquireLock(ctx); err == nil {
+				acquired <- owner
+			}
+		}()
+	}
+	wg.Wait()
+	close(acquired)
+
+	if len(acquired) != 1 {
+		t.Fatalf("%d operations acquired the reclaimed lock, want 1", len(acquired))
+	}
+	if owner := <-acquired; owner == "crashed" {
+		t.Fatal("AcquireLock() returned the owner of the abandoned lock")
+	}
+}
@@ -4,12 +4,12 @@ package register
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/transaction"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
 	registerUseCase "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/register"
 	userUseCase "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/user"
+	pkg_email "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/email"
 )
 
 // RegisterUserInput contains the inputs required for user registration
@@ -70,6 +70,11 @@ func NewRegisterService(
 
 // RegisterUser handles the complete registration process
 func (s *registerService) RegisterUser(ctx context.Context, input RegisterUserInput) (*RegisterUserOutput, error) {
+	email, err := pkg_email.Normalize(input.Email)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email: %w", err)
+	}
+
 	// Generate E2EE credentials
 	credentials, err := s.generateCredentialsUseCase.Execute(ctx, input.Password)
 	if err != nil {
@@ -78,7 +83,7 @@ func (s *registerService) RegisterUser(ctx context.Context, input RegisterUserIn
 
 	// Create local user
 	userInput := registerUseCase.CreateLocalUserInput{
-		Email:           strings.ToLower(input.Email),
+		Email:           email,
 		FirstName:       input.FirstName,
 		LastName:        input.LastName,
 		Timezone:        input.Timezone,
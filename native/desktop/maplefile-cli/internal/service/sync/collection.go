@@ -3,12 +3,16 @@ package sync
 
 import (
 	"context"
+	stderrors "errors"
 
 	"go.uber.org/zap"
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	dom_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/syncdto"
 	dom_syncdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/syncdto"
+	dom_syncstate "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/syncstate"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectionsyncer"
 	syncdtoSvc "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/syncdto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/syncstate"
@@ -41,6 +45,7 @@ type syncCollectionService struct {
 	syncStateGetService   syncstate.GetService
 	syncStateSaveService  syncstate.SaveService
 	syncStateResetService syncstate.ResetService
+	syncStateConsistency  syncstate.ConsistencyService
 
 	// Service for fetching collection data from the remote source (cloud)
 	syncDTOProgressService        syncdtoSvc.SyncProgressService
@@ -63,6 +68,7 @@ func NewSyncCollectionService(
 	syncStateGetService syncstate.GetService,
 	syncStateSaveService syncstate.SaveService,
 	syncStateResetService syncstate.ResetService,
+	syncStateConsistency syncstate.ConsistencyService,
 	syncDTOProgressService syncdtoSvc.SyncProgressService,
 	getCollectionFromCloudUseCase uc_collectiondto.GetCollectionFromCloudUseCase,
 	createLocalCollectionFromCloudCollectionService collectionsyncer.CreateLocalCollectionFromCloudCollectionService,
@@ -79,6 +85,7 @@ func NewSyncCollectionService(
 		syncStateGetService:   syncStateGetService,
 		syncStateSaveService:  syncStateSaveService,
 		syncStateResetService: syncStateResetService,
+		syncStateConsistency:  syncStateConsistency,
 
 		syncDTOProgressService:                          syncDTOProgressService,
 		getCollectionFromCloudUseCase:                   getCollectionFromCloudUseCase,
@@ -113,6 +120,12 @@ func (s *syncCollectionService) Execute(ctx context.Context, input *SyncCollecti
 		zap.Int("batchSize", int(input.BatchSize)),   // Cast to int for logging
 		zap.Int("maxBatches", int(input.MaxBatches))) // Cast to int for logging
 
+	// Rewind the cursor first if the records of the pass which saved it were lost in a crash
+	if _, err := s.syncStateConsistency.Check(ctx); err != nil {
+		s.logger.Error("❌ Failed to check the sync state against the local collections", zap.Error(err))
+		return nil, errors.NewAppError("failed to check sync state consistency", err)
+	}
+
 	// Retrieve the current sync state to determine the starting point for the sync
 	s.logger.Debug("⏰ Getting current sync state for collections")
 	syncStateOutput, err := s.syncStateGetService.GetSyncState(ctx)
@@ -124,6 +137,12 @@ func (s *syncCollectionService) Execute(ctx context.Context, input *SyncCollecti
 		zap.Time("lastCollectionSync", syncStateOutput.SyncState.LastCollectionSync),
 		zap.String("lastCollectionID", syncStateOutput.SyncState.LastCollectionID.String())) // Convert ObjectID to string for logging
 
+	// The checkpoint saved with the new cursor records where this pass started and what it applied
+	checkpoint := &dom_syncstate.SyncCheckpoint{
+		PreviousSync: syncStateOutput.SyncState.LastCollectionSync,
+		PreviousID:   syncStateOutput.SyncState.LastCollectionID,
+	}
+
 	// Build the sync cursor based on the retrieved sync state
 	var currentSyncCursor *dom_syncdto.SyncCursorDTO
 	if !(syncStateOutput.SyncState.LastCollectionSync.String() == "") {
@@ -197,6 +216,13 @@ func (s *syncCollectionService) Execute(ctx context.Context, input *SyncCollecti
 
 			// Attempt to lookup the existing local collection record using the ID from the cloud data.
 			existingLocalCollection, err := s.getCollectionUseCase.Execute(ctx, cloudCollection.ID)
+			if stderrors.Is(err, keys.ErrCorruptedEncryptedMaterial) {
+				// The cloud collection is authoritative, so the corrupted local record is created again from it.
+				s.logger.Warn("⚠️ Local collection has corrupted encrypted keys, replacing it with the cloud collection",
+					zap.String("id", cloudCollection.ID.String()),
+					zap.Error(err))
+				existingLocalCollection, err = nil, nil
+			}
 			if err != nil {
 				// Log error if lookup fails but continue processing other items
 				s.logger.Error("❌ Failed to get local collection",
@@ -216,22 +242,32 @@ func (s *syncCollectionService) Execute(ctx context.Context, input *SyncCollecti
 					zap.String("id", cloudCollection.ID.String()))
 
 				// Make sure the cloud collection hasn't been deleted.
-				if cloudCollection.TombstoneVersion > 0 {
+				if isCloudCollectionDeleted(&cloudCollection, nil) {
 					s.logger.Debug("🚫 Skipping local collection creation from the cloud because it has been marked for deletion in the cloud",
 						zap.String("id", cloudCollection.ID.String()))
 					continue // Go to the next item in the loop and do not continue in this function.
 				}
 
-				localCollection, err := s.createLocalCollectionFromCloudCollectionService.Execute(ctx, cloudCollection.ID, input.Password)
+				var localCollection *dom_collection.Collection
+				err := retryItem(ctx, s.logger, cloudCollection.ID.String(), dom_syncdto.SyncOperationCreateLocal, func() error {
+					var err error
+					localCollection, err = s.createLocalCollectionFromCloudCollectionService.Execute(ctx, cloudCollection.ID, input.Password)
+					return err
+				})
 				if err != nil {
 					s.logger.Error("❌ Failed to get cloud collection and create it locally",
 						zap.String("id", cloudCollection.ID.String()),
 						zap.Error(err))
+					if syncErr, ok := newCollectionKeyOutdatedError(cloudCollection.ID.String(), dom_syncdto.SyncOperationCreateLocal, err); ok {
+						collectionSyncResult.Errors = append(collectionSyncResult.Errors, syncErr)
+					}
 					// Depending on error type, might need to handle specifically (e.g., not found vs actual DB error)
 					continue // Skip processing this collection if local create fails
 				}
 
 				if localCollection != nil {
+					checkpoint.LastAppliedID = localCollection.ID
+					checkpoint.LastAppliedVersion = localCollection.Version
 					collectionSyncResult.CollectionsAdded++
 				}
 				continue // Go to the next item in the loop and do not continue in this function.
@@ -242,14 +278,15 @@ func (s *syncCollectionService) Execute(ctx context.Context, input *SyncCollecti
 			//
 
 			// We must handle local deletion of the collection.
-			if cloudCollection.TombstoneVersion > existingLocalCollection.Version || cloudCollection.State == "deleted" {
+			if isCloudCollectionDeleted(&cloudCollection, existingLocalCollection) {
 				if err := s.deleteCollectionUseCase.Execute(ctx, existingLocalCollection.ID); err != nil {
 					s.logger.Error("❌ Failed to delete local collection",
 						zap.String("collection_id", existingLocalCollection.ID.String()),
 						zap.Uint64("local_version", existingLocalCollection.Version),
 						zap.Uint64("cloud_version", cloudCollection.Version),
 						zap.Error(err))
-					return nil, err
+					collectionSyncResult.Errors = append(collectionSyncResult.Errors, NewSyncError(existingLocalCollection.ID.String(), dom_syncdto.SyncOperationDeleteLocal, "failed to delete local collection", err))
+					continue // Skip processing this collection if local delete fails
 				}
 				s.logger.Debug("🗑️ Local collection is marked as deleted",
 					zap.String("collection_id", existingLocalCollection.ID.String()),
@@ -275,17 +312,27 @@ func (s *syncCollectionService) Execute(ctx context.Context, input *SyncCollecti
 				continue // Skip processing this collection
 			}
 
-			localCollection, err := s.updateLocalCollectionFromCloudCollectionService.Execute(ctx, cloudCollection.ID, input.Password)
+			var localCollection *dom_collection.Collection
+			err = retryItem(ctx, s.logger, cloudCollection.ID.String(), dom_syncdto.SyncOperationUpdateLocal, func() error {
+				var err error
+				localCollection, err = s.updateLocalCollectionFromCloudCollectionService.Execute(ctx, cloudCollection.ID, input.Password)
+				return err
+			})
 			if err != nil {
 				s.logger.Error("❌ Failed to get cloud collection and save/delete it locally",
 					zap.String("id", cloudCollection.ID.String()),
 					zap.Error(err))
+				if syncErr, ok := newCollectionKeyOutdatedError(cloudCollection.ID.String(), dom_syncdto.SyncOperationUpdateLocal, err); ok {
+					collectionSyncResult.Errors = append(collectionSyncResult.Errors, syncErr)
+				}
 				// Depending on error type, might need to handle specifically (e.g., not found vs actual DB error)
 				continue // Skip processing this collection if local create fails
 			}
 
 			// If localCollection is not empty then it means it was updated.
 			if localCollection != nil {
+				checkpoint.LastAppliedID = localCollection.ID
+				checkpoint.LastAppliedVersion = localCollection.Version
 				// For now, just incrementing updated count as a placeholder
 				collectionSyncResult.CollectionsUpdated++
 			}
@@ -295,8 +342,9 @@ func (s *syncCollectionService) Execute(ctx context.Context, input *SyncCollecti
 	// Update sync state if we processed any data and got a final cursor
 	if progressOutput.TotalItems > 0 && progressOutput.FinalCursor != nil {
 		saveInput := &syncstate.SaveInput{
-			LastCollectionSync: &progressOutput.FinalCursor.LastModified,
-			LastCollectionID:   &progressOutput.FinalCursor.LastID, // Use pointer to ObjectID if SaveInput expects pointer
+			LastCollectionSync:   &progressOutput.FinalCursor.LastModified,
+			LastCollectionID:     &progressOutput.FinalCursor.LastID, // Use pointer to ObjectID if SaveInput expects pointer
+			CollectionCheckpoint: checkpoint,
 		}
 		s.logger.Debug("💾 Attempting to save sync state for collections",
 			zap.Time("lastCollectionSync", *saveInput.LastCollectionSync),
@@ -306,14 +354,14 @@ func (s *syncCollectionService) Execute(ctx context.Context, input *SyncCollecti
 		if err != nil {
 			s.logger.Error("❌ Failed to update sync state for collections", zap.Error(err))
 			// Don't fail the entire operation for sync state update failure, just log and add to errors
-			collectionSyncResult.Errors = append(collectionSyncResult.Errors, "failed to update sync state: "+err.Error())
+			collectionSyncResult.Errors = append(collectionSyncResult.Errors, NewSyncError("", dom_syncdto.SyncOperationSaveSyncState, "failed to update sync state", err))
 		} else {
 			s.logger.Info("✅ Successfully updated sync state for collections")
 		}
 	} else if progressOutput.TotalItems > 0 && progressOutput.FinalCursor == nil {
 		// This case indicates an issue where items were processed but no final cursor was provided.
 		s.logger.Warn("⚠️ Processed items but did not receive a final cursor for collections. Sync state not updated.")
-		collectionSyncResult.Errors = append(collectionSyncResult.Errors, "processed items but no final sync cursor received")
+		collectionSyncResult.Errors = append(collectionSyncResult.Errors, NewSyncError("", dom_syncdto.SyncOperationSaveSyncState, "processed items but no final sync cursor received", nil))
 	} else {
 		// No items processed, likely nothing new to sync in this run.
 		s.logger.Info("💤 No items processed for collections. Sync state not updated.")
@@ -0,0 +1,90 @@
+// internal/service/sync/compare.go
+package sync
+
+import (
+	dom_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	dom_syncdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/syncdto"
+)
+
+// SyncAction is the change a sync would make to a local record
+type SyncAction string
+
+const (
+	SyncActionNone     SyncAction = "none"
+	SyncActionAdd      SyncAction = "add"
+	SyncActionUpdate   SyncAction = "update"
+	SyncActionDelete   SyncAction = "delete"
+	SyncActionConflict SyncAction = "conflict"
+)
+
+// isCloudCollectionDeleted returns true if the cloud collection was deleted after the local version.
+// Without a local collection any tombstone or deleted state means there is nothing to create. The
+// cloud also reports a collection as deleted once the membership it was shared through has expired.
+func isCloudCollectionDeleted(cloudCollection *dom_syncdto.CollectionSyncItem, localCollection *dom_collection.Collection) bool {
+	if localCollection == nil {
+		return cloudCollection.TombstoneVersion > 0 || cloudCollection.State == "deleted"
+	}
+	return cloudCollection.TombstoneVersion > localCollection.Version || cloudCollection.State == "deleted"
+}
+
+// isCloudFileDeleted returns true if the cloud file was deleted after the local version.
+// Without a local file any tombstone or deleted state means there is nothing to create.
+func isCloudFileDeleted(cloudFile *dom_syncdto.FileSyncItem, localFile *dom_file.File) bool {
+	if localFile == nil {
+		return cloudFile.TombstoneVersion > 0 || cloudFile.State == "deleted"
+	}
+	return cloudFile.TombstoneVersion > localFile.Version || cloudFile.State == "deleted"
+}
+
+// isFileContentChanged returns true if an update changed the encrypted content of the file rather
+// than only its metadata. The hash and size of the encrypted content change whenever it does.
+func isFileContentChanged(before, after *dom_file.File) bool {
+	return before.EncryptedHash != after.EncryptedHash || before.EncryptedFileSize != after.EncryptedFileSize
+}
+
+// compareCollection returns the change a sync would make to the local collection. Changes which
+// would overwrite unsynced local modifications are reported as conflicts.
+func compareCollection(cloudCollection *dom_syncdto.CollectionSyncItem, localCollection *dom_collection.Collection) SyncAction {
+	if localCollection == nil {
+		if isCloudCollectionDeleted(cloudCollection, nil) {
+			return SyncActionNone
+		}
+		return SyncActionAdd
+	}
+
+	action := SyncActionNone
+	if isCloudCollectionDeleted(cloudCollection, localCollection) {
+		action = SyncActionDelete
+	} else if localCollection.Version < cloudCollection.Version {
+		action = SyncActionUpdate
+	}
+
+	if action != SyncActionNone && localCollection.SyncStatus == dom_collection.SyncStatusModifiedLocally {
+		return SyncActionConflict
+	}
+	return action
+}
+
+// compareFile returns the change a sync would make to the local file. Changes which would
+// overwrite unsynced local modifications are reported as conflicts.
+func compareFile(cloudFile *dom_syncdto.FileSyncItem, localFile *dom_file.File) SyncAction {
+	if localFile == nil {
+		if isCloudFileDeleted(cloudFile, nil) {
+			return SyncActionNone
+		}
+		return SyncActionAdd
+	}
+
+	action := SyncActionNone
+	if isCloudFileDeleted(cloudFile, localFile) {
+		action = SyncActionDelete
+	} else if localFile.Version < cloudFile.Version {
+		action = SyncActionUpdate
+	}
+
+	if action != SyncActionNone && localFile.SyncStatus == dom_file.SyncStatusModifiedLocally {
+		return SyncActionConflict
+	}
+	return action
+}
@@ -3,12 +3,20 @@ package sync
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/syncstate"
 	uc_user "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/user"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
 )
 
 // DebugSyncInput represents input for debugging sync operations
@@ -26,6 +34,70 @@ type DebugSyncOutput struct {
 	SyncStateStatus string   `json:"sync_state_status"`
 	Issues          []string `json:"issues"`
 	Recommendations []string `json:"recommendations"`
+	// ActiveEndpoint is the cloud endpoint requests are currently sent to.
+	ActiveEndpoint string                      `json:"active_endpoint,omitempty"`
+	Endpoints      []httpclient.EndpointStatus `json:"endpoints,omitempty"`
+	// ClockSkew is the offset of the server clock from the local clock measured by the network check.
+	ClockSkew time.Duration `json:"clock_skew,omitempty"`
+	// Network is the structured result of the network check, nil when it was not requested.
+	Network *NetworkCheckResult `json:"network,omitempty"`
+	// CircuitBreaker is the state of the circuit breaker of requests to the cloud after the network check.
+	CircuitBreaker *httpclient.BreakerStatus `json:"circuit_breaker,omitempty"`
+}
+
+// DiagnosisCode identifies a problem found by the network check, so scripts can act on it
+// without parsing messages
+type DiagnosisCode string
+
+const (
+	DiagnosisEndpointNotConfigured DiagnosisCode = "ENDPOINT_NOT_CONFIGURED"
+	DiagnosisDNSResolutionFailed   DiagnosisCode = "DNS_RESOLUTION_FAILED"
+	DiagnosisTLSHandshakeFailed    DiagnosisCode = "TLS_HANDSHAKE_FAILED"
+	DiagnosisEndpointUnreachable   DiagnosisCode = "ENDPOINT_UNREACHABLE"
+	DiagnosisEndpointUnhealthy     DiagnosisCode = "ENDPOINT_UNHEALTHY"
+	DiagnosisCircuitOpen           DiagnosisCode = "CIRCUIT_OPEN"
+	DiagnosisFailoverActive        DiagnosisCode = "FAILOVER_ACTIVE"
+	DiagnosisNotLoggedIn           DiagnosisCode = "NOT_LOGGED_IN"
+	DiagnosisTokenExpired          DiagnosisCode = "TOKEN_EXPIRED"
+	DiagnosisSessionExpired        DiagnosisCode = "SESSION_EXPIRED"
+	DiagnosisClockSkewed           DiagnosisCode = "CLOCK_SKEWED"
+)
+
+// NetworkDiagnosis is a problem found by the network check and the action which fixes it
+type NetworkDiagnosis struct {
+	Code    DiagnosisCode `json:"code"`
+	Message string        `json:"message"`
+	Action  string        `json:"action,omitempty"`
+}
+
+// NetworkCheckResult is the machine-readable result of the network check
+type NetworkCheckResult struct {
+	// Endpoint is the configured cloud provider address.
+	Endpoint   string `json:"endpoint"`
+	Reachable  bool   `json:"reachable"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+	// Latency is the time until the first byte of the healthcheck response.
+	Latency time.Duration `json:"latency"`
+	// DNSLookup and TLSHandshake are zero when an open connection was reused.
+	DNSLookup        time.Duration `json:"dns_lookup,omitempty"`
+	TLSHandshake     time.Duration `json:"tls_handshake,omitempty"`
+	ConnectionReused bool          `json:"connection_reused"`
+	// TokenValid is true when the access token is not expired according to the server clock.
+	TokenValid          bool               `json:"token_valid"`
+	TokenExpiresAt      *time.Time         `json:"token_expires_at,omitempty"`
+	RefreshTokenValid   bool               `json:"refresh_token_valid"`
+	RefreshTokenExpires *time.Time         `json:"refresh_token_expires_at,omitempty"`
+	Diagnoses           []NetworkDiagnosis `json:"diagnoses"`
+}
+
+// diagnose records a problem in the structured result and in the human readable issues
+func (r *NetworkCheckResult) diagnose(output *DebugSyncOutput, code DiagnosisCode, message, action string) {
+	r.Diagnoses = append(r.Diagnoses, NetworkDiagnosis{Code: code, Message: message, Action: action})
+	output.Issues = append(output.Issues, message)
+	if action != "" {
+		output.Recommendations = append(output.Recommendations, action)
+	}
 }
 
 // SyncDebugService defines the interface for debugging sync operations
@@ -38,6 +110,9 @@ type syncDebugService struct {
 	logger                     *zap.Logger
 	getUserByIsLoggedInUseCase uc_user.GetByIsLoggedInUseCase
 	syncStateGetService        syncstate.GetService
+	configService              config.ConfigService
+	cloudTransport             *httpclient.FailoverTransport
+	serverClock                *httpclient.ServerClock
 }
 
 // NewSyncDebugService creates a new service for debugging sync operations
@@ -45,12 +120,18 @@ func NewSyncDebugService(
 	logger *zap.Logger,
 	getUserByIsLoggedInUseCase uc_user.GetByIsLoggedInUseCase,
 	syncStateGetService syncstate.GetService,
+	configService config.ConfigService,
+	cloudTransport *httpclient.FailoverTransport,
+	serverClock *httpclient.ServerClock,
 ) SyncDebugService {
 	logger = logger.Named("SyncDebugService")
 	return &syncDebugService{
 		logger:                     logger,
 		getUserByIsLoggedInUseCase: getUserByIsLoggedInUseCase,
 		syncStateGetService:        syncStateGetService,
+		configService:              configService,
+		cloudTransport:             cloudTransport,
+		serverClock:                serverClock,
 	}
 }
 
@@ -68,6 +149,11 @@ func (s *syncDebugService) DiagnoseSync(ctx context.Context, input *DebugSyncInp
 		s.checkAuthStatus(ctx, input.Password, output)
 	}
 
+	// Check network connectivity
+	if input.CheckNetwork {
+		s.checkNetwork(ctx, output)
+	}
+
 	// Check sync state
 	if input.CheckSyncState {
 		s.checkSyncState(ctx, output)
@@ -143,3 +229,155 @@ func (s *syncDebugService) checkSyncState(ctx context.Context, output *DebugSync
 		output.SyncStateStatus = fmt.Sprintf("Last synced: %v", syncStateOutput.SyncState.LastCollectionSync)
 	}
 }
+
+// checkNetwork verifies the cloud backend is reachable, measures the connection and reports the
+// health of every endpoint and the validity of the access token
+func (s *syncDebugService) checkNetwork(ctx context.Context, output *DebugSyncOutput) {
+	s.logger.Debug("🌐 Checking network connectivity")
+
+	result := &NetworkCheckResult{Diagnoses: make([]NetworkDiagnosis, 0)}
+	output.Network = result
+
+	serverURL, err := s.configService.GetCloudProviderAddress(ctx)
+	if err != nil || serverURL == "" {
+		output.NetworkStatus = "Failed to get cloud provider address"
+		result.diagnose(output, DiagnosisEndpointNotConfigured,
+			"Cannot read the cloud provider address from the configuration",
+			"Run 'maplefile-cli config set ADDRESS' to configure the cloud provider")
+		return
+	}
+	result.Endpoint = serverURL
+
+	//
+	// STEP 1: Time the healthcheck request.
+	//
+
+	var start, dnsStart, tlsStart, firstByte time.Time
+	trace := &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { dnsStart = time.Now() },
+		DNSDone:              func(httptrace.DNSDoneInfo) { result.DNSLookup = time.Since(dnsStart) },
+		TLSHandshakeStart:    func() { tlsStart = time.Now() },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { result.TLSHandshake = time.Since(tlsStart) },
+		GotConn:              func(info httptrace.GotConnInfo) { result.ConnectionReused = info.Reused },
+		GotFirstResponseByte: func() { firstByte = time.Now() },
+	}
+
+	// The request goes through the shared transport, so it updates the endpoint health and
+	// fails over exactly like the requests made during a sync.
+	client := &http.Client{Timeout: 10 * time.Second, Transport: s.cloudTransport}
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace), http.MethodGet, fmt.Sprintf("%s/healthcheck", serverURL), nil)
+	if err == nil {
+		var resp *http.Response
+		start = time.Now()
+		resp, err = client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			result.StatusCode = resp.StatusCode
+			result.Reachable = true
+			if resp.StatusCode != http.StatusOK {
+				err = fmt.Errorf("unexpected status: %s", resp.Status)
+			}
+		}
+	}
+	if !firstByte.IsZero() {
+		result.Latency = firstByte.Sub(start)
+	}
+
+	output.ActiveEndpoint = s.cloudTransport.ActiveEndpoint()
+	output.Endpoints = s.cloudTransport.Status()
+	breaker := s.cloudTransport.BreakerStatus()
+	output.CircuitBreaker = &breaker
+
+	//
+	// STEP 2: Diagnose the connection.
+	//
+
+	if err != nil {
+		result.Error = err.Error()
+		output.NetworkStatus = fmt.Sprintf("Cloud unreachable: %v", err)
+
+		var dnsErr *net.DNSError
+		var certErr *tls.CertificateVerificationError
+		var recordErr tls.RecordHeaderError
+		switch {
+		case errors.Is(err, httpclient.ErrCircuitOpen):
+			result.diagnose(output, DiagnosisCircuitOpen,
+				fmt.Sprintf("Requests fail fast after %d consecutive failures, the last one was: %s", breaker.ConsecutiveFailures, breaker.LastError),
+				"Wait for the circuit breaker cooldown to end, or tune it with 'maplefile-cli config retry-budget'")
+		case result.Reachable:
+			result.diagnose(output, DiagnosisEndpointUnhealthy,
+				fmt.Sprintf("Endpoint reachable but the healthcheck failed with status %d", result.StatusCode),
+				"The cloud backend is having problems, try again later or configure failover endpoints with 'maplefile-cli config failover'")
+		case errors.As(err, &dnsErr):
+			result.diagnose(output, DiagnosisDNSResolutionFailed,
+				fmt.Sprintf("Cannot resolve the host %s", dnsErr.Name),
+				"Check your internet connection and DNS settings, or the spelling of the address with 'maplefile-cli config get'")
+		case errors.As(err, &certErr), errors.As(err, &recordErr):
+			result.diagnose(output, DiagnosisTLSHandshakeFailed,
+				"The TLS handshake with the cloud backend failed",
+				"Check the address uses the right scheme and that no proxy intercepts the connection")
+		default:
+			result.diagnose(output, DiagnosisEndpointUnreachable,
+				"Cannot reach the cloud backend",
+				"Check your internet connection or configure failover endpoints with 'maplefile-cli config failover'")
+		}
+	} else {
+		output.NetworkStatus = fmt.Sprintf("Connected via %s", output.ActiveEndpoint)
+		if output.ActiveEndpoint != "" && len(output.Endpoints) > 0 && output.ActiveEndpoint != output.Endpoints[0].URL {
+			result.diagnose(output, DiagnosisFailoverActive,
+				fmt.Sprintf("Primary endpoint %s is unhealthy, using failover endpoint", output.Endpoints[0].URL), "")
+		}
+
+		// The healthcheck response carries the server time, so the skew is fresh at this point.
+		output.ClockSkew, _ = s.serverClock.Skew()
+		if s.serverClock.IsSkewed() {
+			action := ""
+			if s.serverClock.Offset() == 0 {
+				action = "Synchronize your system clock or run 'maplefile-cli config clock --sync on' to correct expiry checks"
+			}
+			result.diagnose(output, DiagnosisClockSkewed,
+				fmt.Sprintf("Local clock is off by %v from the server clock", output.ClockSkew), action)
+		}
+	}
+
+	//
+	// STEP 3: Check the tokens against the server clock.
+	//
+
+	s.checkTokens(ctx, result, output)
+}
+
+// checkTokens reports whether the access and refresh tokens are still valid
+func (s *syncDebugService) checkTokens(ctx context.Context, result *NetworkCheckResult, output *DebugSyncOutput) {
+	creds, err := s.configService.GetLoggedInUserCredentials(ctx)
+	if err != nil || creds == nil || creds.AccessToken == "" {
+		result.diagnose(output, DiagnosisNotLoggedIn,
+			"No access token is stored, requests to the cloud backend are not authenticated",
+			"Run 'maplefile-cli login' to log in")
+		return
+	}
+
+	now := s.serverClock.Now()
+	result.TokenExpiresAt = creds.AccessTokenExpiryTime
+	result.RefreshTokenExpires = creds.RefreshTokenExpiryTime
+	result.TokenValid = creds.AccessTokenExpiryTime == nil || now.Before(*creds.AccessTokenExpiryTime)
+	result.RefreshTokenValid = creds.RefreshToken != "" &&
+		(creds.RefreshTokenExpiryTime == nil || now.Before(*creds.RefreshTokenExpiryTime))
+
+	prefix := "Endpoint unreachable and"
+	if result.Reachable {
+		prefix = "Endpoint reachable but"
+	}
+	switch {
+	case result.TokenValid:
+		return
+	case result.RefreshTokenValid:
+		result.diagnose(output, DiagnosisTokenExpired,
+			fmt.Sprintf("%s the access token expired", prefix),
+			"Run 'maplefile-cli refreshtoken' to get a new access token")
+	default:
+		result.diagnose(output, DiagnosisSessionExpired,
+			fmt.Sprintf("%s the session expired", prefix),
+			"Run 'maplefile-cli login' to log in again")
+	}
+}
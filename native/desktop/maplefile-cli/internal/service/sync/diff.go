@@ -0,0 +1,226 @@
+// native/desktop/maplefile-cli/internal/service/sync/diff.go
+package sync
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
+	dom_syncdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/syncdto"
+	syncdtoSvc "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/syncdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/syncstate"
+	uc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collection"
+	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
+)
+
+// DiffInput represents input for comparing local and cloud state
+type DiffInput struct {
+	Collections bool  `json:"collections"`
+	Files       bool  `json:"files"`
+	BatchSize   int64 `json:"batch_size,omitempty"`
+	MaxBatches  int   `json:"max_batches,omitempty"`
+}
+
+// DiffEntry represents a single difference between a local record and its cloud counterpart
+type DiffEntry struct {
+	Type             string     `json:"type"` // "collection" or "file"
+	ID               gocql.UUID `json:"id"`
+	Action           SyncAction `json:"action"`
+	LocalVersion     *uint64    `json:"local_version,omitempty"`
+	CloudVersion     uint64     `json:"cloud_version"`
+	CloudState       string     `json:"cloud_state"`
+	CloudModifiedAt  time.Time  `json:"cloud_modified_at"`
+	TombstoneVersion uint64     `json:"tombstone_version,omitempty"`
+}
+
+// DiffOutput represents the differences a sync would apply locally
+type DiffOutput struct {
+	Entries   []*DiffEntry `json:"entries"`
+	Adds      int          `json:"adds"`
+	Updates   int          `json:"updates"`
+	Deletes   int          `json:"deletes"`
+	Conflicts int          `json:"conflicts"`
+	// HasMoreData is true if the cloud had more changes than the batch limits allowed to fetch.
+	HasMoreData bool `json:"has_more_data"`
+}
+
+// SyncDiffService defines the interface for previewing the changes a sync would make
+type SyncDiffService interface {
+	Diff(ctx context.Context, input *DiffInput) (*DiffOutput, error)
+}
+
+// syncDiffService implements the SyncDiffService interface
+type syncDiffService struct {
+	logger                 *zap.Logger
+	syncStateGetService    syncstate.GetService
+	syncDTOProgressService syncdtoSvc.SyncProgressService
+	getCollectionUseCase   uc_collection.GetCollectionUseCase
+	getFileUseCase         uc_file.GetFileUseCase
+}
+
+// NewSyncDiffService creates a new service for previewing the changes a sync would make
+func NewSyncDiffService(
+	logger *zap.Logger,
+	syncStateGetService syncstate.GetService,
+	syncDTOProgressService syncdtoSvc.SyncProgressService,
+	getCollectionUseCase uc_collection.GetCollectionUseCase,
+	getFileUseCase uc_file.GetFileUseCase,
+) SyncDiffService {
+	logger = logger.Named("SyncDiffService")
+	return &syncDiffService{
+		logger:                 logger,
+		syncStateGetService:    syncStateGetService,
+		syncDTOProgressService: syncDTOProgressService,
+		getCollectionUseCase:   getCollectionUseCase,
+		getFileUseCase:         getFileUseCase,
+	}
+}
+
+// Diff fetches the cloud changes since the last sync and compares them against the local records
+// using the same rules as the sync services. Nothing is written locally, not even the sync cursor.
+func (s *syncDiffService) Diff(ctx context.Context, input *DiffInput) (*DiffOutput, error) {
+	s.logger.Info("🔍 Starting sync diff")
+
+	if input == nil {
+		input = &DiffInput{}
+	}
+	if !input.Collections && !input.Files {
+		input.Collections = true
+		input.Files = true
+	}
+	if input.BatchSize <= 0 {
+		input.BatchSize = 50
+	}
+	if input.MaxBatches <= 0 {
+		input.MaxBatches = 100
+	}
+
+	syncStateOutput, err := s.syncStateGetService.GetSyncState(ctx)
+	if err != nil {
+		return nil, errors.NewAppError("failed to get sync state", err)
+	}
+	syncState := syncStateOutput.SyncState
+
+	output := &DiffOutput{
+		Entries: make([]*DiffEntry, 0),
+	}
+
+	if input.Collections {
+		progressOutput, err := s.syncDTOProgressService.GetAllCollections(ctx, &syncdtoSvc.SyncProgressInput{
+			SyncType:    "collections",
+			StartCursor: buildSyncCursor(syncState.LastCollectionSync, syncState.LastCollectionID),
+			BatchSize:   input.BatchSize,
+			MaxBatches:  input.MaxBatches,
+		})
+		if err != nil {
+			return nil, errors.NewAppError("failed to get collections sync data", err)
+		}
+		output.HasMoreData = output.HasMoreData || progressOutput.HasMoreData
+
+		for _, batch := range progressOutput.CollectionBatches {
+			for i := range batch.Collections {
+				cloudCollection := &batch.Collections[i]
+				localCollection, err := s.getCollectionUseCase.Execute(ctx, cloudCollection.ID)
+				if stderrors.Is(err, keys.ErrCorruptedEncryptedMaterial) {
+					// Sync replaces a corrupted local collection with the cloud collection
+					localCollection, err = nil, nil
+				}
+				if err != nil {
+					return nil, errors.NewAppError("failed to get local collection", err)
+				}
+
+				entry := &DiffEntry{
+					Type:             "collection",
+					ID:               cloudCollection.ID,
+					Action:           compareCollection(cloudCollection, localCollection),
+					CloudVersion:     cloudCollection.Version,
+					CloudState:       cloudCollection.State,
+					CloudModifiedAt:  cloudCollection.ModifiedAt,
+					TombstoneVersion: cloudCollection.TombstoneVersion,
+				}
+				if localCollection != nil {
+					entry.LocalVersion = &localCollection.Version
+				}
+				output.add(entry)
+			}
+		}
+	}
+
+	if input.Files {
+		progressOutput, err := s.syncDTOProgressService.GetAllFiles(ctx, &syncdtoSvc.SyncProgressInput{
+			SyncType:    "files",
+			StartCursor: buildSyncCursor(syncState.LastFileSync, syncState.LastFileID),
+			BatchSize:   input.BatchSize,
+			MaxBatches:  input.MaxBatches,
+		})
+		if err != nil {
+			return nil, errors.NewAppError("failed to get files sync data", err)
+		}
+		output.HasMoreData = output.HasMoreData || progressOutput.HasMoreData
+
+		for _, batch := range progressOutput.FileBatches {
+			for i := range batch.Files {
+				cloudFile := &batch.Files[i]
+				localFile, err := s.getFileUseCase.Execute(ctx, cloudFile.ID)
+				if err != nil {
+					return nil, errors.NewAppError("failed to get local file", err)
+				}
+
+				entry := &DiffEntry{
+					Type:             "file",
+					ID:               cloudFile.ID,
+					Action:           compareFile(cloudFile, localFile),
+					CloudVersion:     cloudFile.Version,
+					CloudState:       cloudFile.State,
+					CloudModifiedAt:  cloudFile.ModifiedAt,
+					TombstoneVersion: cloudFile.TombstoneVersion,
+				}
+				if localFile != nil {
+					entry.LocalVersion = &localFile.Version
+				}
+				output.add(entry)
+			}
+		}
+	}
+
+	s.logger.Info("✅ Sync diff completed",
+		zap.Int("adds", output.Adds),
+		zap.Int("updates", output.Updates),
+		zap.Int("deletes", output.Deletes),
+		zap.Int("conflicts", output.Conflicts))
+
+	return output, nil
+}
+
+// add records the entry if it represents a change and updates the counts
+func (o *DiffOutput) add(entry *DiffEntry) {
+	switch entry.Action {
+	case SyncActionAdd:
+		o.Adds++
+	case SyncActionUpdate:
+		o.Updates++
+	case SyncActionDelete:
+		o.Deletes++
+	case SyncActionConflict:
+		o.Conflicts++
+	default:
+		return
+	}
+	o.Entries = append(o.Entries, entry)
+}
+
+// buildSyncCursor returns the cursor to resume syncing from, or nil to start from the beginning
+func buildSyncCursor(lastSync time.Time, lastID gocql.UUID) *dom_syncdto.SyncCursorDTO {
+	if lastSync.IsZero() {
+		return nil
+	}
+	return &dom_syncdto.SyncCursorDTO{
+		LastModified: lastSync,
+		LastID:       lastID,
+	}
+}
@@ -0,0 +1,253 @@
+// native/desktop/maplefile-cli/internal/service/sync/doctor.go
+package sync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	dom_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectionsyncer"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filesyncer"
+	uc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collection"
+	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
+)
+
+// DoctorInput represents input for the local consistency check
+type DoctorInput struct {
+	// Repair attempts to fix the detected problems by fetching missing records from the cloud.
+	Repair bool `json:"repair"`
+	// CheckCloud compares local collections against the cloud to find missing files.
+	CheckCloud bool   `json:"check_cloud"`
+	Password   string `json:"password,omitempty"`
+}
+
+// OrphanedFile represents a local file whose collection is not present locally
+type OrphanedFile struct {
+	FileID       gocql.UUID `json:"file_id"`
+	CollectionID gocql.UUID `json:"collection_id"`
+	Name         string     `json:"name"`
+	Repaired     bool       `json:"repaired"`
+	Error        string     `json:"error,omitempty"`
+}
+
+// MissingFile represents a cloud file which is missing from its local collection
+type MissingFile struct {
+	FileID       gocql.UUID `json:"file_id"`
+	CollectionID gocql.UUID `json:"collection_id"`
+	Repaired     bool       `json:"repaired"`
+	Error        string     `json:"error,omitempty"`
+}
+
+// DoctorOutput represents the result of the local consistency check
+type DoctorOutput struct {
+	CollectionsChecked int             `json:"collections_checked"`
+	FilesChecked       int             `json:"files_checked"`
+	OrphanedFiles      []*OrphanedFile `json:"orphaned_files"`
+	MissingFiles       []*MissingFile  `json:"missing_files"`
+	Issues             []string        `json:"issues"`
+}
+
+// SyncDoctorService defines the interface for checking the referential consistency
+// between local collections and local files.
+type SyncDoctorService interface {
+	Diagnose(ctx context.Context, input *DoctorInput) (*DoctorOutput, error)
+}
+
+// syncDoctorService implements the SyncDoctorService interface
+type syncDoctorService struct {
+	logger                                          *zap.Logger
+	fileDTORepository                               filedto.FileDTORepository
+	listFilesUseCase                                uc_file.ListFilesUseCase
+	getCollectionUseCase                            uc_collection.GetCollectionUseCase
+	listCollectionsUseCase                          uc_collection.ListCollectionsUseCase
+	createLocalCollectionFromCloudCollectionService collectionsyncer.CreateLocalCollectionFromCloudCollectionService
+	createLocalFileFromCloudFileService             filesyncer.CreateLocalFileFromCloudFileService
+}
+
+// NewSyncDoctorService creates a new service for checking local sync consistency
+func NewSyncDoctorService(
+	logger *zap.Logger,
+	fileDTORepository filedto.FileDTORepository,
+	listFilesUseCase uc_file.ListFilesUseCase,
+	getCollectionUseCase uc_collection.GetCollectionUseCase,
+	listCollectionsUseCase uc_collection.ListCollectionsUseCase,
+	createLocalCollectionFromCloudCollectionService collectionsyncer.CreateLocalCollectionFromCloudCollectionService,
+	createLocalFileFromCloudFileService filesyncer.CreateLocalFileFromCloudFileService,
+) SyncDoctorService {
+	logger = logger.Named("SyncDoctorService")
+	return &syncDoctorService{
+		logger:                 logger,
+		fileDTORepository:      fileDTORepository,
+		listFilesUseCase:       listFilesUseCase,
+		getCollectionUseCase:   getCollectionUseCase,
+		listCollectionsUseCase: listCollectionsUseCase,
+		createLocalCollectionFromCloudCollectionService: createLocalCollectionFromCloudCollectionService,
+		createLocalFileFromCloudFileService:             createLocalFileFromCloudFileService,
+	}
+}
+
+// Diagnose finds files whose collection is missing locally and, when requested, collections whose
+// cloud files are missing locally. With `Repair` enabled it fetches the missing records from the cloud.
+func (s *syncDoctorService) Diagnose(ctx context.Context, input *DoctorInput) (*DoctorOutput, error) {
+	s.logger.Info("🩺 Starting sync consistency check")
+
+	if input == nil {
+		input = &DoctorInput{}
+	}
+	if (input.Repair || input.CheckCloud) && input.Password == "" {
+		return nil, errors.NewAppError("password is required to repair or check against the cloud", nil)
+	}
+
+	output := &DoctorOutput{
+		OrphanedFiles: make([]*OrphanedFile, 0),
+		MissingFiles:  make([]*MissingFile, 0),
+		Issues:        make([]string, 0),
+	}
+
+	//
+	// STEP 1: Find files whose collection does not exist locally.
+	//
+
+	localFiles, err := s.listFilesUseCase.Execute(ctx, dom_file.FileFilter{})
+	if err != nil {
+		return nil, errors.NewAppError("failed to list local files", err)
+	}
+	output.FilesChecked = len(localFiles)
+
+	// Group the orphaned files by their collection so each missing collection is only fetched once.
+	orphansByCollection := make(map[gocql.UUID][]*OrphanedFile)
+	knownCollections := make(map[gocql.UUID]bool)
+	for _, localFile := range localFiles {
+		exists, ok := knownCollections[localFile.CollectionID]
+		if !ok {
+			collection, err := s.getCollectionUseCase.Execute(ctx, localFile.CollectionID)
+			if err != nil {
+				return nil, errors.NewAppError("failed to get local collection", err)
+			}
+			exists = collection != nil
+			knownCollections[localFile.CollectionID] = exists
+		}
+		if exists {
+			continue
+		}
+
+		orphan := &OrphanedFile{
+			FileID:       localFile.ID,
+			CollectionID: localFile.CollectionID,
+			Name:         localFile.Name,
+		}
+		orphansByCollection[localFile.CollectionID] = append(orphansByCollection[localFile.CollectionID], orphan)
+		output.OrphanedFiles = append(output.OrphanedFiles, orphan)
+	}
+
+	for collectionID, orphans := range orphansByCollection {
+		s.logger.Warn("⚠️ Found files referencing a missing local collection",
+			zap.String("collection_id", collectionID.String()),
+			zap.Int("file_count", len(orphans)))
+		output.Issues = append(output.Issues,
+			fmt.Sprintf("%d file(s) reference collection %s which does not exist locally", len(orphans), collectionID.String()))
+
+		if !input.Repair {
+			continue
+		}
+
+		var repairErr string
+		if _, err := s.createLocalCollectionFromCloudCollectionService.Execute(ctx, collectionID, input.Password); err != nil {
+			s.logger.Error("❌ Failed to fetch missing collection from cloud",
+				zap.String("collection_id", collectionID.String()),
+				zap.Error(err))
+			repairErr = err.Error()
+		}
+		for _, orphan := range orphans {
+			orphan.Repaired = repairErr == ""
+			orphan.Error = repairErr
+		}
+	}
+
+	//
+	// STEP 2: Find collections whose cloud files are missing locally.
+	//
+
+	collections, err := s.listCollectionsUseCase.ListActiveCollections(ctx)
+	if err != nil {
+		return nil, errors.NewAppError("failed to list local collections", err)
+	}
+	output.CollectionsChecked = len(collections)
+
+	if input.CheckCloud {
+		localFileIDs := make(map[gocql.UUID]bool, len(localFiles))
+		for _, localFile := range localFiles {
+			localFileIDs[localFile.ID] = true
+		}
+
+		for _, collection := range collections {
+			s.checkCollectionFiles(ctx, collection, localFileIDs, input, output)
+		}
+	}
+
+	s.logger.Info("🩺 Sync consistency check completed",
+		zap.Int("collectionsChecked", output.CollectionsChecked),
+		zap.Int("filesChecked", output.FilesChecked),
+		zap.Int("orphanedFiles", len(output.OrphanedFiles)),
+		zap.Int("missingFiles", len(output.MissingFiles)))
+
+	return output, nil
+}
+
+// checkCollectionFiles compares the active cloud files of a collection against the local files.
+func (s *syncDoctorService) checkCollectionFiles(
+	ctx context.Context,
+	collection *dom_collection.Collection,
+	localFileIDs map[gocql.UUID]bool,
+	input *DoctorInput,
+	output *DoctorOutput,
+) {
+	collectionID := collection.ID
+	cloudFiles, err := s.fileDTORepository.ListFromCloud(ctx, filedto.FileFilter{
+		CollectionID: &collectionID,
+		State:        filedto.FileDTOStateActive,
+	})
+	if err != nil {
+		s.logger.Error("❌ Failed to list cloud files for collection",
+			zap.String("collection_id", collectionID.String()),
+			zap.Error(err))
+		output.Issues = append(output.Issues,
+			fmt.Sprintf("could not list cloud files for collection %s: %v", collectionID.String(), err))
+		return
+	}
+
+	missingCount := 0
+	for _, cloudFile := range cloudFiles {
+		if localFileIDs[cloudFile.ID] {
+			continue
+		}
+		missingCount++
+
+		missing := &MissingFile{
+			FileID:       cloudFile.ID,
+			CollectionID: collectionID,
+		}
+		if input.Repair {
+			if _, err := s.createLocalFileFromCloudFileService.Execute(ctx, cloudFile.ID, input.Password); err != nil {
+				s.logger.Error("❌ Failed to fetch missing file from cloud",
+					zap.String("file_id", cloudFile.ID.String()),
+					zap.Error(err))
+				missing.Error = err.Error()
+			} else {
+				missing.Repaired = true
+			}
+		}
+		output.MissingFiles = append(output.MissingFiles, missing)
+	}
+
+	if missingCount > 0 {
+		output.Issues = append(output.Issues,
+			fmt.Sprintf("collection %s is missing %d file(s) that exist in the cloud", collectionID.String(), missingCount))
+	}
+}
@@ -0,0 +1,58 @@
+// internal/service/sync/errors.go
+package sync
+
+import (
+	stderrors "errors"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/syncdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectioncrypto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filesyncer"
+)
+
+// NewSyncError records a failure of the operation on the item, classifying whether running
+// the sync again may succeed. The ID is empty for failures affecting the whole sync.
+func NewSyncError(id string, operation string, message string, err error) syncdto.SyncError {
+	if err != nil {
+		message = message + ": " + err.Error()
+	}
+	return syncdto.SyncError{
+		ID:        id,
+		Operation: operation,
+		Message:   message,
+		Retryable: isRetryable(err),
+	}
+}
+
+// newCollectionKeyOutdatedError reports a collection whose key was rotated after the member's copy
+// was wrapped. The cryptic decryption failure is replaced with a message the user can act on, and
+// it is retryable because the next sync picks up the membership once the key is wrapped again.
+func newCollectionKeyOutdatedError(id string, operation string, err error) (syncdto.SyncError, bool) {
+	if !stderrors.Is(err, collectioncrypto.ErrCollectionKeyOutdated) {
+		return syncdto.SyncError{}, false
+	}
+	return NewSyncError(id, operation, "your access key is outdated, re-syncing", nil), true
+}
+
+// isRetryable returns false for failures which will keep failing no matter how often the sync is
+// repeated, such as data which cannot be decrypted, is malformed or no longer exists in the cloud,
+// invalid input and requests the cloud rejects. Everything else (network failures, cloud errors and
+// throttling, local storage failures) is considered transient.
+func isRetryable(err error) bool {
+	if err == nil {
+		return true
+	}
+	for _, permanent := range []error{
+		filesyncer.ErrDecryptionFailed,
+		filesyncer.ErrFileNotFound,
+		filesyncer.ErrInvalidMetadata,
+		filesyncer.ErrNotCloudOnly,
+		filesyncer.ErrLocalCopyExists,
+		filesyncer.ErrInvalidInput,
+		syncdto.ErrRequestRejected,
+	} {
+		if stderrors.Is(err, permanent) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package sync
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/syncdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filesyncer"
+)
+
+func TestNewSyncErrorRetryable(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantRetryable bool
+	}{
+		{name: "network failure", err: errors.NewAppError("failed to connect to server", &net.OpError{Op: "dial", Err: stderrors.New("connection refused")}), wantRetryable: true},
+		{name: "timeout", err: errors.NewAppError("failed to connect to server", context.DeadlineExceeded), wantRetryable: true},
+		{name: "cloud failure", err: errors.NewAppError("server returned error status: 503 Service Unavailable", syncdto.ErrCloudUnavailable), wantRetryable: true},
+		{name: "download failure", err: fmt.Errorf("%w: connection reset", filesyncer.ErrDownloadFailed), wantRetryable: true},
+		{name: "request rejected", err: errors.NewAppError("server error: invalid cursor", syncdto.ErrRequestRejected), wantRetryable: false},
+		{name: "decryption failure", err: errors.NewAppError("failed to download and decrypt file", fmt.Errorf("%w: bad key", filesyncer.ErrDecryptionFailed)), wantRetryable: false},
+		{name: "invalid metadata", err: fmt.Errorf("%w: name escapes the directory", filesyncer.ErrInvalidMetadata), wantRetryable: false},
+		{name: "invalid input", err: errors.NewAppError("user password is required for E2EE operations", filesyncer.ErrInvalidInput), wantRetryable: false},
+		{name: "local copy exists", err: filesyncer.ErrLocalCopyExists, wantRetryable: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syncErr := NewSyncError("id", syncdto.SyncOperationAutoOnload, "onload failed", tt.err)
+			if syncErr.Retryable != tt.wantRetryable {
+				t.Errorf("NewSyncError(%v).Retryable = %v, want %v", tt.err, syncErr.Retryable, tt.wantRetryable)
+			}
+		})
+	}
+}
+
+func TestSyncResultRetryableErrors(t *testing.T) {
+	result := &syncdto.SyncResult{Errors: []syncdto.SyncError{
+		NewSyncError("", "sync_files", "file sync failed", syncdto.ErrCloudUnavailable),
+		NewSyncError("a", syncdto.SyncOperationAutoOnload, "onload failed", filesyncer.ErrDecryptionFailed),
+		NewSyncError("b", syncdto.SyncOperationAutoOnload, "onload failed", filesyncer.ErrDownloadFailed),
+	}}
+
+	retryable := result.RetryableErrors()
+	if len(retryable) != 2 || retryable[0].Operation != "sync_files" || retryable[1].ID != "b" {
+		t.Errorf("RetryableErrors() = %v, want the cloud and download failures", retryable)
+	}
+}
@@ -8,13 +8,19 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/gocql/gocql"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/syncdto"
 	dom_syncdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/syncdto"
+	dom_syncstate "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/syncstate"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filesyncer"
 	syncdtoSvc "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/syncdto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/syncstate"
+	uc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collection"
 	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
+	uc_localfile "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/localfile"
 )
 
 // SyncFilesInput represents input for syncing files
@@ -22,6 +28,8 @@ type SyncFilesInput struct {
 	BatchSize  int64  `json:"batch_size,omitempty"`
 	MaxBatches int    `json:"max_batches,omitempty"`
 	Password   string `json:"password,omitempty"`
+	// Prune also removes the on-disk data of files deleted in the cloud, limited to the managed file store.
+	Prune bool `json:"prune,omitempty"`
 }
 
 // SyncFileService defines the interface for synchronization operations
@@ -32,12 +40,14 @@ type SyncFileService interface {
 
 // syncFileService implements the SyncFileService interface
 type syncFileService struct {
-	logger *zap.Logger // Logger instance for structured logging.
+	logger        *zap.Logger // Logger instance for structured logging.
+	configService config.ConfigService
 
 	// Services for managing the sync state (cursor)
 	syncStateGetService   syncstate.GetService
 	syncStateSaveService  syncstate.SaveService
 	syncStateResetService syncstate.ResetService
+	syncStateConsistency  syncstate.ConsistencyService
 
 	// Service for fetching file metadata / data from the remote source (cloud)
 	syncDTOProgressService syncdtoSvc.SyncProgressService
@@ -49,31 +59,45 @@ type syncFileService struct {
 	// Use cases for interacting with the local file repository
 	getFileUseCase    uc_file.GetFileUseCase
 	deleteFileUseCase uc_file.DeleteFileUseCase
+
+	// Use case for reading the sync preference of the local collections
+	getCollectionUseCase uc_collection.GetCollectionUseCase
+
+	// Use case for removing file data from disk when pruning
+	deleteFileDataUseCase uc_localfile.DeleteFileUseCase
 }
 
 // NewSyncFileService creates a new sync file service
 func NewSyncFileService(
 	logger *zap.Logger,
+	configService config.ConfigService,
 	syncStateGetService syncstate.GetService,
 	syncStateSaveService syncstate.SaveService,
 	syncStateResetService syncstate.ResetService,
+	syncStateConsistency syncstate.ConsistencyService,
 	syncDTOProgressService syncdtoSvc.SyncProgressService,
 	createLocalFileFromCloudFileService filesyncer.CreateLocalFileFromCloudFileService,
 	updateLocalFileFromCloudFileService filesyncer.UpdateLocalFileFromCloudFileService,
 	getFileUseCase uc_file.GetFileUseCase,
 	deleteFileUseCase uc_file.DeleteFileUseCase,
+	deleteFileDataUseCase uc_localfile.DeleteFileUseCase,
+	getCollectionUseCase uc_collection.GetCollectionUseCase,
 ) SyncFileService {
 	logger = logger.Named("SyncFileService")
 	return &syncFileService{
 		logger:                              logger,
+		configService:                       configService,
 		syncStateGetService:                 syncStateGetService,
 		syncStateSaveService:                syncStateSaveService,
 		syncStateResetService:               syncStateResetService,
+		syncStateConsistency:                syncStateConsistency,
 		syncDTOProgressService:              syncDTOProgressService,
 		createLocalFileFromCloudFileService: createLocalFileFromCloudFileService,
 		updateLocalFileFromCloudFileService: updateLocalFileFromCloudFileService,
 		getFileUseCase:                      getFileUseCase,
 		deleteFileUseCase:                   deleteFileUseCase,
+		deleteFileDataUseCase:               deleteFileDataUseCase,
+		getCollectionUseCase:                getCollectionUseCase,
 	}
 }
 
@@ -94,7 +118,14 @@ func (s *syncFileService) Execute(ctx context.Context, input *SyncFilesInput) (*
 
 	s.logger.Debug("⚙️ File sync input parameters",
 		zap.Int("batchSize", int(input.BatchSize)),   // Cast to int for logging
-		zap.Int("maxBatches", int(input.MaxBatches))) // Cast to int for logging
+		zap.Int("maxBatches", int(input.MaxBatches)), // Cast to int for logging
+		zap.Bool("prune", input.Prune))
+
+	// Rewind the cursor first if the records of the pass which saved it were lost in a crash
+	if _, err := s.syncStateConsistency.Check(ctx); err != nil {
+		s.logger.Error("❌ Failed to check the sync state against the local files", zap.Error(err))
+		return nil, errors.NewAppError("failed to check sync state consistency", err)
+	}
 
 	// Retrieve the current sync state to determine the starting point for the sync
 	s.logger.Debug("⏰ Getting current sync state for files")
@@ -107,6 +138,15 @@ func (s *syncFileService) Execute(ctx context.Context, input *SyncFilesInput) (*
 		zap.Time("lastFileSync", syncStateOutput.SyncState.LastFileSync),
 		zap.String("lastFileID", syncStateOutput.SyncState.LastFileID.String())) // Convert ObjectID to string for logging
 
+	// The checkpoint saved with the new cursor records where this pass started and what it applied
+	checkpoint := &dom_syncstate.SyncCheckpoint{
+		PreviousSync: syncStateOutput.SyncState.LastFileSync,
+		PreviousID:   syncStateOutput.SyncState.LastFileID,
+	}
+
+	// Sync preference of the collections seen in this pass
+	syncEnabledByCollection := make(map[gocql.UUID]bool)
+
 	// Build the sync cursor based on the retrieved sync state
 	var currentSyncCursor *dom_syncdto.SyncCursorDTO
 	if !(syncStateOutput.SyncState.LastFileSync.String() == "") {
@@ -193,7 +233,7 @@ func (s *syncFileService) Execute(ctx context.Context, input *SyncFilesInput) (*
 				s.logger.Error("❌ Failed to get local file",
 					zap.String("id", cloudFile.ID.String()),
 					zap.Error(err))
-				fileSyncResult.Errors = append(fileSyncResult.Errors, "failed to get local file "+cloudFile.ID.String()+": "+err.Error())
+				fileSyncResult.Errors = append(fileSyncResult.Errors, NewSyncError(cloudFile.ID.String(), dom_syncdto.SyncOperationGetLocal, "failed to get local file", err))
 				continue // Skip processing this file if local lookup fails
 			}
 
@@ -207,23 +247,34 @@ func (s *syncFileService) Execute(ctx context.Context, input *SyncFilesInput) (*
 					zap.String("id", cloudFile.ID.String()))
 
 				// Make sure the cloud file hasn't been deleted.
-				if cloudFile.TombstoneVersion > 0 || cloudFile.State == "deleted" {
+				if isCloudFileDeleted(&cloudFile, nil) {
 					s.logger.Debug("🚫 Skipping local file creation from the cloud because it has been marked for deletion in the cloud",
 						zap.String("id", cloudFile.ID.String()))
 					continue // Go to the next item in the loop and do not continue in this function.
 				}
 
-				localFile, err := s.createLocalFileFromCloudFileService.Execute(ctx, cloudFile.ID, input.Password)
+				var localFile *dom_file.File
+				err := retryItem(ctx, s.logger, cloudFile.ID.String(), dom_syncdto.SyncOperationCreateLocal, func() error {
+					var err error
+					localFile, err = s.createLocalFileFromCloudFileService.Execute(ctx, cloudFile.ID, input.Password)
+					return err
+				})
 				if err != nil {
 					s.logger.Error("❌ Failed to get cloud file and create it locally",
 						zap.String("id", cloudFile.ID.String()),
 						zap.Error(err))
-					fileSyncResult.Errors = append(fileSyncResult.Errors, "failed to create local file from cloud "+cloudFile.ID.String()+": "+err.Error())
+					fileSyncResult.Errors = append(fileSyncResult.Errors, NewSyncError(cloudFile.ID.String(), dom_syncdto.SyncOperationCreateLocal, "failed to create local file from cloud", err))
 					continue // Skip processing this file if local create fails
 				}
 
 				if localFile != nil {
+					checkpoint.LastAppliedID = localFile.ID
+					checkpoint.LastAppliedVersion = localFile.Version
 					fileSyncResult.FilesAdded++
+					// Files of collections with sync disabled stay cloud-only and are never auto onloaded
+					if s.isCollectionSyncEnabled(ctx, syncEnabledByCollection, localFile.CollectionID) {
+						fileSyncResult.AddedFileIDs = append(fileSyncResult.AddedFileIDs, localFile.ID)
+					}
 				}
 				continue // Go to the next item in the loop and do not continue in this function.
 			}
@@ -233,16 +284,19 @@ func (s *syncFileService) Execute(ctx context.Context, input *SyncFilesInput) (*
 			//
 
 			// We must handle local deletion of the file.
-			if cloudFile.TombstoneVersion > existingLocalFile.Version || cloudFile.State == "deleted" {
+			if isCloudFileDeleted(&cloudFile, existingLocalFile) {
 				if err := s.deleteFileUseCase.Execute(ctx, existingLocalFile.ID); err != nil {
 					s.logger.Error("❌ Failed to delete local file",
 						zap.String("file_id", existingLocalFile.ID.String()),
 						zap.Uint64("local_version", existingLocalFile.Version),
 						zap.Uint64("cloud_version", cloudFile.Version),
 						zap.Error(err))
-					fileSyncResult.Errors = append(fileSyncResult.Errors, "failed to delete local file "+existingLocalFile.ID.String()+": "+err.Error())
+					fileSyncResult.Errors = append(fileSyncResult.Errors, NewSyncError(existingLocalFile.ID.String(), dom_syncdto.SyncOperationDeleteLocal, "failed to delete local file", err))
 					continue
 				}
+				if input.Prune {
+					s.pruneFileData(ctx, existingLocalFile)
+				}
 				s.logger.Debug("🗑️ Local file is marked as deleted",
 					zap.String("file_id", existingLocalFile.ID.String()),
 					zap.Uint64("local_version", existingLocalFile.Version),
@@ -267,19 +321,30 @@ func (s *syncFileService) Execute(ctx context.Context, input *SyncFilesInput) (*
 				continue // Skip processing this file
 			}
 
-			localFile, err := s.updateLocalFileFromCloudFileService.Execute(ctx, cloudFile.ID, input.Password)
+			var localFile *dom_file.File
+			err = retryItem(ctx, s.logger, cloudFile.ID.String(), dom_syncdto.SyncOperationUpdateLocal, func() error {
+				var err error
+				localFile, err = s.updateLocalFileFromCloudFileService.Execute(ctx, cloudFile.ID, input.Password)
+				return err
+			})
 			if err != nil {
 				s.logger.Error("❌ Failed to get cloud file and save/delete it locally",
 					zap.String("id", cloudFile.ID.String()),
 					zap.Error(err))
-				fileSyncResult.Errors = append(fileSyncResult.Errors, "failed to update local file from cloud "+cloudFile.ID.String()+": "+err.Error())
+				fileSyncResult.Errors = append(fileSyncResult.Errors, NewSyncError(cloudFile.ID.String(), dom_syncdto.SyncOperationUpdateLocal, "failed to update local file from cloud", err))
 				continue // Skip processing this file if local update fails
 			}
 
 			// If localFile is not empty then it means it was updated.
 			if localFile != nil {
-				// For now, just incrementing updated count as a placeholder
+				checkpoint.LastAppliedID = localFile.ID
+				checkpoint.LastAppliedVersion = localFile.Version
 				fileSyncResult.FilesUpdated++
+				if isFileContentChanged(existingLocalFile, localFile) {
+					fileSyncResult.FilesContentUpdated++
+				} else {
+					fileSyncResult.FilesMetadataOnlyUpdated++
+				}
 			}
 		}
 	}
@@ -287,8 +352,9 @@ func (s *syncFileService) Execute(ctx context.Context, input *SyncFilesInput) (*
 	// Update sync state if we processed any data and got a final cursor
 	if progressOutput.TotalItems > 0 && progressOutput.FinalCursor != nil {
 		saveInput := &syncstate.SaveInput{
-			LastFileSync: &progressOutput.FinalCursor.LastModified,
-			LastFileID:   &progressOutput.FinalCursor.LastID,
+			LastFileSync:   &progressOutput.FinalCursor.LastModified,
+			LastFileID:     &progressOutput.FinalCursor.LastID,
+			FileCheckpoint: checkpoint,
 		}
 		s.logger.Debug("💾 Attempting to save sync state for files",
 			zap.Time("lastFileSync", *saveInput.LastFileSync),
@@ -298,14 +364,14 @@ func (s *syncFileService) Execute(ctx context.Context, input *SyncFilesInput) (*
 		if err != nil {
 			s.logger.Error("❌ Failed to update sync state for files", zap.Error(err))
 			// Don't fail the entire operation for sync state update failure, just log and add to errors
-			fileSyncResult.Errors = append(fileSyncResult.Errors, "failed to update sync state: "+err.Error())
+			fileSyncResult.Errors = append(fileSyncResult.Errors, NewSyncError("", dom_syncdto.SyncOperationSaveSyncState, "failed to update sync state", err))
 		} else {
 			s.logger.Info("✅ Successfully updated sync state for files")
 		}
 	} else if progressOutput.TotalItems > 0 && progressOutput.FinalCursor == nil {
 		// This case indicates an issue where items were processed but no final cursor was provided.
 		s.logger.Warn("⚠️ Processed items but did not receive a final cursor for files. Sync state not updated.")
-		fileSyncResult.Errors = append(fileSyncResult.Errors, "processed items but no final sync cursor received")
+		fileSyncResult.Errors = append(fileSyncResult.Errors, NewSyncError("", dom_syncdto.SyncOperationSaveSyncState, "processed items but no final sync cursor received", nil))
 	} else {
 		// No items processed, likely nothing new to sync in this run.
 		s.logger.Info("💤 No items processed for files. Sync state not updated.")
@@ -313,11 +379,32 @@ func (s *syncFileService) Execute(ctx context.Context, input *SyncFilesInput) (*
 
 	// Log final summary of the synchronization process
 	s.logger.Info("🎉 File synchronization completed",
-		zap.Int("processed", fileSyncResult.FilesProcessed), // Total items received from sync service
-		zap.Int("added", fileSyncResult.FilesAdded),         // Items locally created
-		zap.Int("updated", fileSyncResult.FilesUpdated),     // Items locally updated
-		zap.Int("deleted", fileSyncResult.FilesDeleted),     // Items marked for local deletion
-		zap.Int("errors", len(fileSyncResult.Errors)))       // Number of errors encountered during processing
+		zap.Int("processed", fileSyncResult.FilesProcessed),                     // Total items received from sync service
+		zap.Int("added", fileSyncResult.FilesAdded),                             // Items locally created
+		zap.Int("updated", fileSyncResult.FilesUpdated),                         // Items locally updated
+		zap.Int("metadataOnlyUpdated", fileSyncResult.FilesMetadataOnlyUpdated), // Updates which left the content as it was
+		zap.Int("contentUpdated", fileSyncResult.FilesContentUpdated),           // Updates which changed the content
+		zap.Int("deleted", fileSyncResult.FilesDeleted),                         // Items marked for local deletion
+		zap.Int("errors", len(fileSyncResult.Errors)))                           // Number of errors encountered during processing
 
 	return fileSyncResult, nil
 }
+
+// isCollectionSyncEnabled reports whether the file content of a collection is synced, caching the
+// answer for the pass. Collections which can't be read are treated as enabled.
+func (s *syncFileService) isCollectionSyncEnabled(ctx context.Context, cache map[gocql.UUID]bool, collectionID gocql.UUID) bool {
+	if enabled, ok := cache[collectionID]; ok {
+		return enabled
+	}
+	enabled := true
+	collection, err := s.getCollectionUseCase.Execute(ctx, collectionID)
+	if err != nil {
+		s.logger.Warn("⚠️ Failed to get the sync preference of the collection",
+			zap.String("collection_id", collectionID.String()),
+			zap.Error(err))
+	} else if collection != nil {
+		enabled = collection.IsSyncEnabled()
+	}
+	cache[collectionID] = enabled
+	return enabled
+}
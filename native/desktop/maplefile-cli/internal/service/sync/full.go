@@ -15,6 +15,7 @@ type FullSyncInput struct {
 	FileBatchSize       int64  `json:"file_batch_size,omitempty"`
 	MaxBatches          int    `json:"max_batches,omitempty"`
 	Password            string `json:"password,omitempty"`
+	Prune               bool   `json:"prune,omitempty"`
 }
 
 // SyncFullService defines the interface for full synchronization operations
@@ -107,6 +108,7 @@ func (s *syncFullService) Execute(ctx context.Context, input *FullSyncInput) (*s
 		BatchSize:  input.FileBatchSize,
 		MaxBatches: input.MaxBatches,
 		Password:   input.Password,
+		Prune:      input.Prune,
 	}
 
 	fileResult, err := s.syncFileService.Execute(ctx, fileInput)
@@ -118,7 +120,10 @@ func (s *syncFullService) Execute(ctx context.Context, input *FullSyncInput) (*s
 	// Merge file results
 	combinedResult.FilesProcessed = fileResult.FilesProcessed
 	combinedResult.FilesAdded = fileResult.FilesAdded
+	combinedResult.AddedFileIDs = fileResult.AddedFileIDs
 	combinedResult.FilesUpdated = fileResult.FilesUpdated
+	combinedResult.FilesMetadataOnlyUpdated = fileResult.FilesMetadataOnlyUpdated
+	combinedResult.FilesContentUpdated = fileResult.FilesContentUpdated
 	combinedResult.FilesDeleted = fileResult.FilesDeleted
 	combinedResult.Errors = append(combinedResult.Errors, fileResult.Errors...)
 
@@ -126,6 +131,8 @@ func (s *syncFullService) Execute(ctx context.Context, input *FullSyncInput) (*s
 		zap.Int("processed", fileResult.FilesProcessed),
 		zap.Int("added", fileResult.FilesAdded),
 		zap.Int("updated", fileResult.FilesUpdated),
+		zap.Int("metadataOnlyUpdated", fileResult.FilesMetadataOnlyUpdated),
+		zap.Int("contentUpdated", fileResult.FilesContentUpdated),
 		zap.Int("deleted", fileResult.FilesDeleted))
 
 	// Log final summary
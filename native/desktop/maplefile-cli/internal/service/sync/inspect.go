@@ -0,0 +1,351 @@
+// native/desktop/maplefile-cli/internal/service/sync/inspect.go
+package sync
+
+import (
+	"context"
+	"time"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	dom_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
+	dom_collectiondto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collectiondto"
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	dom_filedto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
+	dom_syncdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/syncdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectioncrypto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/filecrypto"
+	uc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collection"
+	uc_collectiondto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collectiondto"
+	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
+	uc_user "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/user"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+)
+
+// Types of items which can be inspected
+const (
+	InspectTypeCollection = "collection"
+	InspectTypeFile       = "file"
+)
+
+// InspectInput represents input for inspecting a single collection or file
+type InspectInput struct {
+	ID gocql.UUID `json:"id"`
+	// Type is "collection" or "file", left empty it is inferred from the local records and then the cloud.
+	Type string `json:"type,omitempty"`
+	// Password enables checking that the encrypted key of the item decrypts, it is skipped without it.
+	Password string `json:"password,omitempty"`
+}
+
+// InspectRecord is the sync relevant state of one side of an item
+type InspectRecord struct {
+	Version          uint64    `json:"version"`
+	State            string    `json:"state"`
+	SyncStatus       string    `json:"sync_status,omitempty"`
+	TombstoneVersion uint64    `json:"tombstone_version,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	ModifiedAt       time.Time `json:"modified_at"`
+	LastSyncedAt     time.Time `json:"last_synced_at,omitempty"`
+}
+
+// InspectKeyCheck is the outcome of decrypting the encrypted key of the item
+type InspectKeyCheck struct {
+	// Source is the record whose key was decrypted, "cloud" or "local".
+	Source   string `json:"source"`
+	Decrypts bool   `json:"decrypts"`
+	Error    string `json:"error,omitempty"`
+}
+
+// InspectOutput represents the local and cloud state of an item side by side
+type InspectOutput struct {
+	Type string     `json:"type"`
+	ID   gocql.UUID `json:"id"`
+	// CollectionID is the collection a file belongs to, preferring the cloud record.
+	CollectionID gocql.UUID     `json:"collection_id,omitempty"`
+	Local        *InspectRecord `json:"local,omitempty"`
+	Cloud        *InspectRecord `json:"cloud,omitempty"`
+	// CloudError is set if the cloud record could not be fetched.
+	CloudError string `json:"cloud_error,omitempty"`
+	// Action is the change the next sync would make to the local record, using the sync rules.
+	Action SyncAction `json:"action"`
+	// KeyCheck is nil if no password was given.
+	KeyCheck *InspectKeyCheck `json:"key_check,omitempty"`
+}
+
+// SyncInspectService defines the interface for inspecting why a single item won't sync
+type SyncInspectService interface {
+	Inspect(ctx context.Context, input *InspectInput) (*InspectOutput, error)
+}
+
+// syncInspectService implements the SyncInspectService interface
+type syncInspectService struct {
+	logger                        *zap.Logger
+	getCollectionUseCase          uc_collection.GetCollectionUseCase
+	getFileUseCase                uc_file.GetFileUseCase
+	getCollectionFromCloudUseCase uc_collectiondto.GetCollectionFromCloudUseCase
+	fileDTORepository             dom_filedto.FileDTORepository
+	getUserByIsLoggedInUseCase    uc_user.GetByIsLoggedInUseCase
+	collectionDecryptionService   collectioncrypto.CollectionDecryptionService
+	fileDecryptionService         filecrypto.FileDecryptionService
+}
+
+// NewSyncInspectService creates a new service for inspecting a single item
+func NewSyncInspectService(
+	logger *zap.Logger,
+	getCollectionUseCase uc_collection.GetCollectionUseCase,
+	getFileUseCase uc_file.GetFileUseCase,
+	getCollectionFromCloudUseCase uc_collectiondto.GetCollectionFromCloudUseCase,
+	fileDTORepository dom_filedto.FileDTORepository,
+	getUserByIsLoggedInUseCase uc_user.GetByIsLoggedInUseCase,
+	collectionDecryptionService collectioncrypto.CollectionDecryptionService,
+	fileDecryptionService filecrypto.FileDecryptionService,
+) SyncInspectService {
+	logger = logger.Named("SyncInspectService")
+	return &syncInspectService{
+		logger:                        logger,
+		getCollectionUseCase:          getCollectionUseCase,
+		getFileUseCase:                getFileUseCase,
+		getCollectionFromCloudUseCase: getCollectionFromCloudUseCase,
+		fileDTORepository:             fileDTORepository,
+		getUserByIsLoggedInUseCase:    getUserByIsLoggedInUseCase,
+		collectionDecryptionService:   collectionDecryptionService,
+		fileDecryptionService:         fileDecryptionService,
+	}
+}
+
+// Inspect fetches the cloud record of the item live and compares it with the local record. Nothing
+// is written locally.
+func (s *syncInspectService) Inspect(ctx context.Context, input *InspectInput) (*InspectOutput, error) {
+	if input == nil || input.ID == (gocql.UUID{}) {
+		return nil, errors.NewAppError("ID is required", nil)
+	}
+	if input.Type != "" && input.Type != InspectTypeCollection && input.Type != InspectTypeFile {
+		return nil, errors.NewAppError("type must be either 'collection' or 'file'", nil)
+	}
+
+	s.logger.Info("🔍 Inspecting item", zap.String("id", input.ID.String()), zap.String("type", input.Type))
+
+	// Infer the type from the local records, falling back to asking the cloud
+	var localCollection *dom_collection.Collection
+	var localFile *dom_file.File
+	var err error
+	if input.Type != InspectTypeFile {
+		if localCollection, err = s.getCollectionUseCase.Execute(ctx, input.ID); err != nil {
+			return nil, errors.NewAppError("failed to get local collection", err)
+		}
+	}
+	if input.Type != InspectTypeCollection && localCollection == nil {
+		if localFile, err = s.getFileUseCase.Execute(ctx, input.ID); err != nil {
+			return nil, errors.NewAppError("failed to get local file", err)
+		}
+	}
+
+	switch {
+	case input.Type == InspectTypeCollection || localCollection != nil:
+		return s.inspectCollection(ctx, input, localCollection)
+	case input.Type == InspectTypeFile || localFile != nil:
+		return s.inspectFile(ctx, input, localFile)
+	}
+
+	// Unknown locally, so whichever the cloud has is the item
+	if output, err := s.inspectFile(ctx, input, nil); err == nil && output.Cloud != nil {
+		return output, nil
+	}
+	output, err := s.inspectCollection(ctx, input, nil)
+	if err != nil {
+		return nil, err
+	}
+	if output.Cloud == nil {
+		return nil, errors.NewAppError("no collection or file with this ID exists locally or in the cloud", nil)
+	}
+	return output, nil
+}
+
+// inspectCollection compares the local collection, which may be nil, with its cloud record
+func (s *syncInspectService) inspectCollection(ctx context.Context, input *InspectInput, localCollection *dom_collection.Collection) (*InspectOutput, error) {
+	output := &InspectOutput{
+		Type:   InspectTypeCollection,
+		ID:     input.ID,
+		Action: SyncActionNone,
+	}
+	if localCollection != nil {
+		output.Local = &InspectRecord{
+			Version:          localCollection.Version,
+			State:            localCollection.State,
+			SyncStatus:       localCollection.SyncStatus.String(),
+			TombstoneVersion: localCollection.TombstoneVersion,
+			CreatedAt:        localCollection.CreatedAt,
+			ModifiedAt:       localCollection.ModifiedAt,
+		}
+	}
+
+	cloudCollection, err := s.getCollectionFromCloudUseCase.Execute(ctx, input.ID)
+	if err != nil {
+		s.logger.Warn("⚠️ Failed to get cloud collection", zap.String("id", input.ID.String()), zap.Error(err))
+		output.CloudError = err.Error()
+	} else if cloudCollection != nil {
+		output.Cloud = &InspectRecord{
+			Version:          cloudCollection.Version,
+			State:            cloudCollection.State,
+			TombstoneVersion: cloudCollection.TombstoneVersion,
+			CreatedAt:        cloudCollection.CreatedAt,
+			ModifiedAt:       cloudCollection.ModifiedAt,
+		}
+		output.Action = compareCollection(&dom_syncdto.CollectionSyncItem{
+			ID:               cloudCollection.ID,
+			Version:          cloudCollection.Version,
+			ModifiedAt:       cloudCollection.ModifiedAt,
+			State:            cloudCollection.State,
+			TombstoneVersion: cloudCollection.TombstoneVersion,
+			TombstoneExpiry:  cloudCollection.TombstoneExpiry,
+		}, localCollection)
+	}
+
+	if input.Password != "" {
+		// The sync decrypts the key of the cloud record, the local one only matters without it
+		switch {
+		case cloudCollection != nil:
+			output.KeyCheck = s.checkCollectionKey(ctx, keyChainCollection(cloudCollection), input.Password, "cloud")
+		case localCollection != nil:
+			output.KeyCheck = s.checkCollectionKey(ctx, localCollection, input.Password, "local")
+		}
+	}
+	return output, nil
+}
+
+// inspectFile compares the local file, which may be nil, with its cloud record
+func (s *syncInspectService) inspectFile(ctx context.Context, input *InspectInput, localFile *dom_file.File) (*InspectOutput, error) {
+	output := &InspectOutput{
+		Type:   InspectTypeFile,
+		ID:     input.ID,
+		Action: SyncActionNone,
+	}
+	if localFile != nil {
+		output.CollectionID = localFile.CollectionID
+		output.Local = &InspectRecord{
+			Version:          localFile.Version,
+			State:            localFile.State,
+			SyncStatus:       localFile.SyncStatus.String(),
+			TombstoneVersion: localFile.TombstoneVersion,
+			CreatedAt:        localFile.CreatedAt,
+			ModifiedAt:       localFile.ModifiedAt,
+			LastSyncedAt:     localFile.LastSyncedAt,
+		}
+	}
+
+	cloudFile, err := s.fileDTORepository.DownloadByIDFromCloud(ctx, input.ID)
+	if err != nil {
+		s.logger.Warn("⚠️ Failed to get cloud file", zap.String("id", input.ID.String()), zap.Error(err))
+		output.CloudError = err.Error()
+	} else if cloudFile != nil {
+		output.CollectionID = cloudFile.CollectionID
+		output.Cloud = &InspectRecord{
+			Version:    cloudFile.Version,
+			State:      cloudFile.State,
+			CreatedAt:  cloudFile.CreatedAt,
+			ModifiedAt: cloudFile.ModifiedAt,
+		}
+		output.Action = compareFile(&dom_syncdto.FileSyncItem{
+			ID:           cloudFile.ID,
+			CollectionID: cloudFile.CollectionID,
+			Version:      cloudFile.Version,
+			ModifiedAt:   cloudFile.ModifiedAt,
+			State:        cloudFile.State,
+		}, localFile)
+	}
+
+	if input.Password != "" {
+		switch {
+		case cloudFile != nil:
+			output.KeyCheck = s.checkFileKey(ctx, cloudFile.CollectionID, cloudFile.EncryptedFileKey, input.Password, "cloud")
+		case localFile != nil:
+			output.KeyCheck = s.checkFileKey(ctx, localFile.CollectionID, localFile.EncryptedFileKey, input.Password, "local")
+		}
+	}
+	return output, nil
+}
+
+// checkCollectionKey decrypts the key chain of the collection the way the sync does
+func (s *syncInspectService) checkCollectionKey(ctx context.Context, collection *dom_collection.Collection, password string, source string) *InspectKeyCheck {
+	check := &InspectKeyCheck{Source: source}
+
+	user, err := s.getUserByIsLoggedInUseCase.Execute(ctx)
+	if err != nil || user == nil {
+		check.Error = "failed to get logged in user"
+		return check
+	}
+
+	collectionKey, err := s.collectionDecryptionService.ExecuteDecryptCollectionKeyChain(ctx, user, collection, password)
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+	crypto.ClearBytes(collectionKey)
+
+	check.Decrypts = true
+	return check
+}
+
+// checkFileKey decrypts the file key with the key of its local collection, the way the sync does
+func (s *syncInspectService) checkFileKey(ctx context.Context, collectionID gocql.UUID, encryptedFileKey keys.EncryptedFileKey, password string, source string) *InspectKeyCheck {
+	check := &InspectKeyCheck{Source: source}
+
+	user, err := s.getUserByIsLoggedInUseCase.Execute(ctx)
+	if err != nil || user == nil {
+		check.Error = "failed to get logged in user"
+		return check
+	}
+	collection, err := s.getCollectionUseCase.Execute(ctx, collectionID)
+	if err != nil || collection == nil {
+		check.Error = "the collection of the file is not synced locally, sync collections first"
+		return check
+	}
+
+	collectionKey, err := s.collectionDecryptionService.ExecuteDecryptCollectionKeyChain(ctx, user, collection, password)
+	if err != nil {
+		check.Error = "failed to decrypt collection key chain: " + err.Error()
+		return check
+	}
+	defer crypto.ClearBytes(collectionKey)
+
+	fileKey, err := s.fileDecryptionService.DecryptFileKey(ctx, encryptedFileKey, collectionKey)
+	if err != nil {
+		check.Error = "failed to decrypt file key: " + err.Error()
+		return check
+	}
+	crypto.ClearBytes(fileKey)
+
+	check.Decrypts = true
+	return check
+}
+
+// keyChainCollection maps the fields of a cloud collection which its key chain is decrypted from
+func keyChainCollection(dto *dom_collectiondto.CollectionDTO) *dom_collection.Collection {
+	members := make([]*dom_collection.CollectionMembership, 0, len(dto.Members))
+	for _, member := range dto.Members {
+		if member == nil {
+			continue
+		}
+		members = append(members, &dom_collection.CollectionMembership{
+			ID:                     member.ID,
+			CollectionID:           member.CollectionID,
+			RecipientID:            member.RecipientID,
+			RecipientEmail:         member.RecipientEmail,
+			GrantedByID:            member.GrantedByID,
+			EncryptedCollectionKey: member.EncryptedCollectionKey,
+			PermissionLevel:        member.PermissionLevel,
+			IsInherited:            member.IsInherited,
+		})
+	}
+	return &dom_collection.Collection{
+		ID:                     dto.ID,
+		OwnerID:                dto.OwnerID,
+		EncryptedName:          dto.EncryptedName,
+		EncryptedCollectionKey: dto.EncryptedCollectionKey,
+		Members:                members,
+		Version:                dto.Version,
+		State:                  dto.State,
+	}
+}
@@ -0,0 +1,82 @@
+// internal/service/sync/prune.go
+package sync
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+)
+
+// pruneFileData removes the on-disk data recorded for a file whose metadata was deleted because of a
+// cloud tombstone. Deleting the metadata record never touches the disk, so without pruning the
+// decrypted file and thumbnail written during onload are left behind. Only paths inside the managed
+// file store are removed; anything else (for example the original path of an imported file) is skipped.
+func (s *syncFileService) pruneFileData(ctx context.Context, file *dom_file.File) {
+	appDataDir, err := s.configService.GetAppDataDirPath(ctx)
+	if err != nil {
+		s.logger.Error("❌ Failed to get app data directory, skipping prune",
+			zap.String("file_id", file.ID.String()),
+			zap.Error(err))
+		return
+	}
+	managedDir := filepath.Join(appDataDir, "files", "bin")
+
+	paths := []string{
+		file.FilePath,
+		file.EncryptedFilePath,
+		file.ThumbnailPath,
+		file.EncryptedThumbnailPath,
+	}
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		if !isWithinDir(managedDir, path) {
+			s.logger.Warn("⚠️ Refusing to prune file outside of the managed file store",
+				zap.String("file_id", file.ID.String()),
+				zap.String("path", path))
+			continue
+		}
+		if err := s.deleteFileDataUseCase.Execute(ctx, path); err != nil {
+			// The user may have already removed the file from disk, so just log and move on.
+			s.logger.Warn("⚠️ Failed to prune file data",
+				zap.String("file_id", file.ID.String()),
+				zap.String("path", path),
+				zap.Error(err))
+			continue
+		}
+		s.logger.Debug("🧹 Pruned file data",
+			zap.String("file_id", file.ID.String()),
+			zap.String("path", path))
+	}
+}
+
+// isWithinDir returns true if the path resolves to a location strictly inside the directory.
+func isWithinDir(dir string, path string) bool {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+
+	// Resolve symlinks where possible so a link inside the store cannot point outside of it.
+	if resolved, err := filepath.EvalSymlinks(absDir); err == nil {
+		absDir = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(absPath); err == nil {
+		absPath = resolved
+	}
+
+	rel, err := filepath.Rel(absDir, absPath)
+	if err != nil {
+		return false
+	}
+	return rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
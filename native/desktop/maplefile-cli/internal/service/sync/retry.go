@@ -0,0 +1,40 @@
+// internal/service/sync/retry.go
+package sync
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/service/collectioncrypto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/retry"
+)
+
+// itemRetryPolicy bounds how often a single item is retried before it is reported as failed, so a
+// brief network hiccup doesn't fail an item which the next sync would have to pick up again.
+var itemRetryPolicy = retry.Policy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Second,
+	MaxDelay:    5 * time.Second,
+	Jitter:      0.2,
+}
+
+// retryItem calls fn until it succeeds or fails in a way retrying within this sync can't fix
+func retryItem(ctx context.Context, logger *zap.Logger, id string, operation string, fn func() error) error {
+	policy := itemRetryPolicy
+	policy.Retryable = func(err error) bool {
+		// An outdated collection key is only rewrapped by the next sync
+		return isRetryable(err) && !stderrors.Is(err, collectioncrypto.ErrCollectionKeyOutdated)
+	}
+	policy.OnRetry = func(attempt int, err error, delay time.Duration) {
+		logger.Warn("⚠️ Sync operation failed, retrying",
+			zap.String("id", id),
+			zap.String("operation", operation),
+			zap.Int("attempt", attempt),
+			zap.Duration("delay", delay),
+			zap.Error(err))
+	}
+	return retry.Do(ctx, policy, fn)
+}
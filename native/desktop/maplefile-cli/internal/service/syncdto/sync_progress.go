@@ -70,6 +70,12 @@ func (s *syncProgressService) GetAllCollections(ctx context.Context, input *Sync
 	if input.BatchSize <= 0 {
 		input.BatchSize = 50
 	}
+	if input.BatchSize > syncdto.MaxCollectionSyncBatchSize {
+		s.logger.Warn("⚠️ Batch size exceeds the cloud maximum, capping it",
+			zap.Int64("requested", input.BatchSize),
+			zap.Int64("max", syncdto.MaxCollectionSyncBatchSize))
+		input.BatchSize = syncdto.MaxCollectionSyncBatchSize
+	}
 	if input.MaxBatches <= 0 {
 		input.MaxBatches = 100 // Prevent infinite loops
 	}
@@ -176,6 +182,12 @@ func (s *syncProgressService) GetAllFiles(ctx context.Context, input *SyncProgre
 	if input.BatchSize <= 0 {
 		input.BatchSize = 50
 	}
+	if input.BatchSize > syncdto.MaxFileSyncBatchSize {
+		s.logger.Warn("⚠️ Batch size exceeds the cloud maximum, capping it",
+			zap.Int64("requested", input.BatchSize),
+			zap.Int64("max", syncdto.MaxFileSyncBatchSize))
+		input.BatchSize = syncdto.MaxFileSyncBatchSize
+	}
 	if input.MaxBatches <= 0 {
 		input.MaxBatches = 100
 	}
@@ -0,0 +1,135 @@
+// internal/service/syncstate/consistency.go
+package syncstate
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/syncstate"
+	uc_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/collection"
+	uc_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
+)
+
+// ConsistencyOutput represents the result of checking the sync cursors against the local records
+type ConsistencyOutput struct {
+	CollectionCursorRewound bool `json:"collection_cursor_rewound"`
+	FileCursorRewound       bool `json:"file_cursor_rewound"`
+}
+
+// ConsistencyService defines the interface for detecting sync cursors which point beyond the
+// records committed locally
+type ConsistencyService interface {
+	// Check confirms the last record applied by the pass which saved each cursor is on disk, and
+	// rewinds the cursor to where that pass started when it is not. Reapplying records is harmless
+	// because the sync skips records whose local version is already current.
+	Check(ctx context.Context) (*ConsistencyOutput, error)
+}
+
+// consistencyService implements the ConsistencyService interface
+type consistencyService struct {
+	logger               *zap.Logger
+	syncStateRepo        syncstate.SyncStateRepository
+	getCollectionUseCase uc_collection.GetCollectionUseCase
+	getFileUseCase       uc_file.GetFileUseCase
+}
+
+// NewConsistencyService creates a new service for checking the sync cursors
+func NewConsistencyService(
+	logger *zap.Logger,
+	syncStateRepo syncstate.SyncStateRepository,
+	getCollectionUseCase uc_collection.GetCollectionUseCase,
+	getFileUseCase uc_file.GetFileUseCase,
+) ConsistencyService {
+	logger = logger.Named("ConsistencyService")
+	return &consistencyService{
+		logger:               logger,
+		syncStateRepo:        syncStateRepo,
+		getCollectionUseCase: getCollectionUseCase,
+		getFileUseCase:       getFileUseCase,
+	}
+}
+
+// Check verifies the collection and file checkpoints and rewinds the cursors they don't confirm
+func (s *consistencyService) Check(ctx context.Context) (*ConsistencyOutput, error) {
+	state, err := s.syncStateRepo.GetSyncState(ctx)
+	if err != nil {
+		s.logger.Error("❌ failed to get current sync state", zap.Error(err))
+		return nil, errors.NewAppError("failed to get current sync state", err)
+	}
+	if state.CollectionCheckpoint == nil && state.FileCheckpoint == nil {
+		return &ConsistencyOutput{}, nil
+	}
+
+	output := &ConsistencyOutput{}
+
+	if checkpoint := state.CollectionCheckpoint; checkpoint != nil {
+		committed, err := s.isApplied(checkpoint, func(id gocql.UUID) (uint64, bool, error) {
+			local, err := s.getCollectionUseCase.Execute(ctx, id)
+			if err != nil || local == nil {
+				return 0, false, err
+			}
+			return local.Version, true, nil
+		})
+		if err != nil {
+			return nil, errors.NewAppError("failed to check the collection sync checkpoint", err)
+		}
+		if !committed {
+			s.logger.Warn("⚠️ Collection sync cursor is ahead of the local records, rewinding it",
+				zap.Time("cursor", state.LastCollectionSync),
+				zap.Time("rewoundTo", checkpoint.PreviousSync),
+				zap.String("missingID", checkpoint.LastAppliedID.String()))
+			state.LastCollectionSync = checkpoint.PreviousSync
+			state.LastCollectionID = checkpoint.PreviousID
+			output.CollectionCursorRewound = true
+		}
+		state.CollectionCheckpoint = nil
+	}
+
+	if checkpoint := state.FileCheckpoint; checkpoint != nil {
+		committed, err := s.isApplied(checkpoint, func(id gocql.UUID) (uint64, bool, error) {
+			local, err := s.getFileUseCase.Execute(ctx, id)
+			if err != nil || local == nil {
+				return 0, false, err
+			}
+			return local.Version, true, nil
+		})
+		if err != nil {
+			return nil, errors.NewAppError("failed to check the file sync checkpoint", err)
+		}
+		if !committed {
+			s.logger.Warn("⚠️ File sync cursor is ahead of the local records, rewinding it",
+				zap.Time("cursor", state.LastFileSync),
+				zap.Time("rewoundTo", checkpoint.PreviousSync),
+				zap.String("missingID", checkpoint.LastAppliedID.String()))
+			state.LastFileSync = checkpoint.PreviousSync
+			state.LastFileID = checkpoint.PreviousID
+			output.FileCursorRewound = true
+		}
+		state.FileCheckpoint = nil
+	}
+
+	// Checkpoints are only checked once, records deleted locally afterwards must not rewind the cursor
+	if err := s.syncStateRepo.SaveSyncState(ctx, state); err != nil {
+		s.logger.Error("❌ failed to save checked sync state", zap.Error(err))
+		return nil, errors.NewAppError("failed to save checked sync state", err)
+	}
+
+	return output, nil
+}
+
+// isApplied reports whether the last record applied by the checkpointed pass is on disk with at
+// least the version the pass wrote. LevelDB replays its log in order, so when the last record
+// written to a database survived a crash every earlier record did too.
+func (s *consistencyService) isApplied(checkpoint *syncstate.SyncCheckpoint, getLocalVersion func(id gocql.UUID) (uint64, bool, error)) (bool, error) {
+	if checkpoint.LastAppliedID == (gocql.UUID{}) {
+		return true, nil
+	}
+	version, found, err := getLocalVersion(checkpoint.LastAppliedID)
+	if err != nil {
+		return false, err
+	}
+	return found && version >= checkpoint.LastAppliedVersion, nil
+}
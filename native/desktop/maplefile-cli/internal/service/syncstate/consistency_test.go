@@ -0,0 +1,114 @@
+package syncstate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/syncstate"
+)
+
+// memorySyncStateRepo keeps the sync state in memory like the LevelDB repository after a reopen
+type memorySyncStateRepo struct {
+	state syncstate.SyncState
+}
+
+func (r *memorySyncStateRepo) GetSyncState(ctx context.Context) (*syncstate.SyncState, error) {
+	state := r.state
+	return &state, nil
+}
+
+func (r *memorySyncStateRepo) SaveSyncState(ctx context.Context, state *syncstate.SyncState) error {
+	r.state = *state
+	return nil
+}
+
+func (r *memorySyncStateRepo) ResetSyncState(ctx context.Context) error {
+	r.state = syncstate.SyncState{}
+	return nil
+}
+
+// localCollections is the set of collections which survived the simulated crash
+type localCollections map[gocql.UUID]*collection.Collection
+
+func (c localCollections) Execute(ctx context.Context, id gocql.UUID) (*collection.Collection, error) {
+	return c[id], nil
+}
+
+// localFiles is the set of files which survived the simulated crash
+type localFiles map[gocql.UUID]*file.File
+
+func (f localFiles) Execute(ctx context.Context, id gocql.UUID) (*file.File, error) {
+	return f[id], nil
+}
+
+func TestCheckRewindsCursorWhenAppliedRecordWasLost(t *testing.T) {
+	previous := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	previousID := gocql.TimeUUID()
+	lostID := gocql.TimeUUID()
+
+	// The cursor reached disk but the collection written before it did not
+	repo := &memorySyncStateRepo{state: syncstate.SyncState{
+		LastCollectionSync: previous.Add(time.Hour),
+		LastCollectionID:   lostID,
+		CollectionCheckpoint: &syncstate.SyncCheckpoint{
+			PreviousSync:       previous,
+			PreviousID:         previousID,
+			LastAppliedID:      lostID,
+			LastAppliedVersion: 3,
+		},
+	}}
+	s := NewConsistencyService(zap.NewNop(), repo, localCollections{}, localFiles{})
+
+	output, err := s.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if !output.CollectionCursorRewound || output.FileCursorRewound {
+		t.Fatalf("Check() = %+v, want only the collection cursor rewound", output)
+	}
+	if !repo.state.LastCollectionSync.Equal(previous) || repo.state.LastCollectionID != previousID {
+		t.Errorf("cursor = %v/%v, want %v/%v", repo.state.LastCollectionSync, repo.state.LastCollectionID, previous, previousID)
+	}
+	if repo.state.CollectionCheckpoint != nil {
+		t.Error("checkpoint was not cleared after the check")
+	}
+}
+
+func TestCheckKeepsCursorWhenAppliedRecordIsPresent(t *testing.T) {
+	previous := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	appliedID := gocql.TimeUUID()
+	cursor := previous.Add(time.Hour)
+
+	// The file and the cursor both reached disk before the crash
+	repo := &memorySyncStateRepo{state: syncstate.SyncState{
+		LastFileSync: cursor,
+		LastFileID:   appliedID,
+		FileCheckpoint: &syncstate.SyncCheckpoint{
+			PreviousSync:       previous,
+			LastAppliedID:      appliedID,
+			LastAppliedVersion: 2,
+		},
+	}}
+	files := localFiles{appliedID: {ID: appliedID, Version: 2}}
+	s := NewConsistencyService(zap.NewNop(), repo, localCollections{}, files)
+
+	output, err := s.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if output.FileCursorRewound {
+		t.Fatal("Check() rewound a cursor whose records are present")
+	}
+	if !repo.state.LastFileSync.Equal(cursor) {
+		t.Errorf("cursor = %v, want %v", repo.state.LastFileSync, cursor)
+	}
+	if repo.state.FileCheckpoint != nil {
+		t.Error("checkpoint was not cleared after the check")
+	}
+}
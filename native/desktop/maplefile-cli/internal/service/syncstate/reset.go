@@ -73,6 +73,7 @@ func (s *resetService) ResetCollectionSync(ctx context.Context) (*ResetOutput, e
 		LastCollectionSync: currentState.LastCollectionSync.Truncate(0), // Reset to zero time
 		LastFileSync:       currentState.LastFileSync,                   // Preserve file sync
 		LastFileID:         currentState.LastFileID,                     // Preserve file sync ID
+		FileCheckpoint:     currentState.FileCheckpoint,                 // Preserve file sync checkpoint
 	}
 
 	// Save the updated state
@@ -101,9 +102,10 @@ func (s *resetService) ResetFileSync(ctx context.Context) (*ResetOutput, error)
 
 	// Create updated state with reset file sync but preserved collection sync
 	updatedState := &syncstate.SyncState{
-		LastCollectionSync: currentState.LastCollectionSync,       // Preserve collection sync
-		LastFileSync:       currentState.LastFileSync.Truncate(0), // Reset to zero time
-		LastCollectionID:   currentState.LastCollectionID,         // Preserve collection sync ID
+		LastCollectionSync:   currentState.LastCollectionSync,       // Preserve collection sync
+		LastFileSync:         currentState.LastFileSync.Truncate(0), // Reset to zero time
+		LastCollectionID:     currentState.LastCollectionID,         // Preserve collection sync ID
+		CollectionCheckpoint: currentState.CollectionCheckpoint,     // Preserve collection sync checkpoint
 	}
 
 	// Save the updated state
@@ -18,6 +18,9 @@ type SaveInput struct {
 	LastFileSync       *time.Time  `json:"last_file_sync,omitempty"`
 	LastCollectionID   *gocql.UUID `json:"last_collection_id,omitempty"`
 	LastFileID         *gocql.UUID `json:"last_file_id,omitempty"`
+	// CollectionCheckpoint and FileCheckpoint replace the checkpoint of the pass saving its cursor
+	CollectionCheckpoint *syncstate.SyncCheckpoint `json:"collection_checkpoint,omitempty"`
+	FileCheckpoint       *syncstate.SyncCheckpoint `json:"file_checkpoint,omitempty"`
 }
 
 // SaveOutput represents the result of saving sync state
@@ -73,6 +76,9 @@ func (s *saveService) SaveSyncState(ctx context.Context, input *SaveInput) (*Sav
 		LastFileSync:       currentState.LastFileSync,
 		LastCollectionID:   currentState.LastCollectionID,
 		LastFileID:         currentState.LastFileID,
+
+		CollectionCheckpoint: currentState.CollectionCheckpoint,
+		FileCheckpoint:       currentState.FileCheckpoint,
 	}
 
 	if input.LastCollectionSync != nil {
@@ -87,6 +93,12 @@ func (s *saveService) SaveSyncState(ctx context.Context, input *SaveInput) (*Sav
 	if input.LastFileID != nil {
 		updatedState.LastFileID = *input.LastFileID
 	}
+	if input.CollectionCheckpoint != nil {
+		updatedState.CollectionCheckpoint = input.CollectionCheckpoint
+	}
+	if input.FileCheckpoint != nil {
+		updatedState.FileCheckpoint = input.FileCheckpoint
+	}
 
 	// Save the updated state
 	if err := s.syncStateRepo.SaveSyncState(ctx, updatedState); err != nil {
@@ -4,7 +4,6 @@ package authdto
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -13,6 +12,7 @@ import (
 	dom_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/authdto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+	pkg_email "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/email"
 )
 
 // CompleteLoginUseCase defines the interface for login completion use cases
@@ -55,7 +55,10 @@ func (uc *completeLoginUseCase) CompleteLogin(ctx context.Context, email, passwo
 	}
 
 	// Sanitize inputs
-	email = strings.ToLower(strings.TrimSpace(email))
+	email, err := pkg_email.Normalize(email)
+	if err != nil {
+		return nil, nil, errors.NewAppError("invalid email", err)
+	}
 
 	// Get user from repository
 	userData, err := uc.userRepo.GetByEmail(ctx, email)
@@ -70,7 +73,7 @@ func (uc *completeLoginUseCase) CompleteLogin(ctx context.Context, email, passwo
 	// Get challenge ID
 	challengeID := userData.VerificationID
 	if challengeID == "" {
-		return nil, nil, errors.NewAppError("no challenge ID found; please run verifyloginott first", nil)
+		return nil, nil, errors.NewAppError("no challenge ID found; please run verify-login-token first", nil)
 	}
 
 	uc.logger.Debug("Processing login completion",
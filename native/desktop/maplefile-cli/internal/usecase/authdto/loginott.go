@@ -3,12 +3,12 @@ package authdto
 
 import (
 	"context"
-	"strings"
 
 	"go.uber.org/zap"
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	dom_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/authdto"
+	pkg_email "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/email"
 )
 
 // LoginOTTUseCase defines the interface for login OTT use cases
@@ -39,7 +39,10 @@ func (uc *loginOTTUseCase) RequestLoginOTT(ctx context.Context, email string) (*
 	}
 
 	// Sanitize input
-	email = strings.ToLower(strings.TrimSpace(email))
+	email, err := pkg_email.Normalize(email)
+	if err != nil {
+		return nil, errors.NewAppError("invalid email", err)
+	}
 
 	// Log the operation
 	uc.logger.Info("Requesting login OTT", zap.String("email", email))
@@ -16,6 +16,8 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+	pkg_email "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/email"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
 )
 
 // RecoveryUseCase defines the interface for recovery use cases
@@ -45,6 +47,7 @@ type recoveryUseCase struct {
 	recoveryRepo    dom_authdto.RecoveryRepository
 	userRepo        user.Repository
 	tokenRepository dom_authdto.TokenDTORepository
+	serverClock     *httpclient.ServerClock
 }
 
 // NewRecoveryUseCase creates a new recovery use case
@@ -53,6 +56,7 @@ func NewRecoveryUseCase(
 	recoveryRepo dom_authdto.RecoveryRepository,
 	userRepo user.Repository,
 	tokenRepository dom_authdto.TokenDTORepository,
+	serverClock *httpclient.ServerClock,
 ) RecoveryUseCase {
 	logger = logger.Named("RecoveryUseCase")
 	return &recoveryUseCase{
@@ -60,6 +64,7 @@ func NewRecoveryUseCase(
 		recoveryRepo:    recoveryRepo,
 		userRepo:        userRepo,
 		tokenRepository: tokenRepository,
+		serverClock:     serverClock,
 	}
 }
 
@@ -74,17 +79,16 @@ func (uc *recoveryUseCase) InitiateRecovery(ctx context.Context, email, recovery
 	}
 
 	// Sanitize inputs
-	email = strings.ToLower(strings.TrimSpace(email))
+	email, err := pkg_email.Normalize(email)
+	if err != nil {
+		return nil, errors.NewAppError("invalid email", err)
+	}
 	recoveryKeyStr = strings.TrimSpace(recoveryKeyStr)
 
 	// Decode recovery key from base64
-	recoveryKey, err := base64.StdEncoding.DecodeString(recoveryKeyStr)
+	recoveryKey, err := crypto.DecodeBase64Flexible(recoveryKeyStr)
 	if err != nil {
-		// Try URL-safe base64 as fallback
-		recoveryKey, err = base64.RawURLEncoding.DecodeString(recoveryKeyStr)
-		if err != nil {
-			return nil, errors.NewAppError("invalid recovery key format", err)
-		}
+		return nil, errors.NewAppError("invalid recovery key format", err)
 	}
 
 	// Validate recovery key length
@@ -107,12 +111,9 @@ func (uc *recoveryUseCase) InitiateRecovery(ctx context.Context, email, recovery
 	}
 
 	// Decode encrypted master key (encrypted with recovery key)
-	encMasterKeyBytes, err := base64.StdEncoding.DecodeString(response.MasterKeyEncryptedWithRecoveryKey)
+	encMasterKeyBytes, err := crypto.DecodeBase64Flexible(response.MasterKeyEncryptedWithRecoveryKey)
 	if err != nil {
-		encMasterKeyBytes, err = base64.RawURLEncoding.DecodeString(response.MasterKeyEncryptedWithRecoveryKey)
-		if err != nil {
-			return nil, errors.NewAppError("failed to decode encrypted master key", err)
-		}
+		return nil, errors.NewAppError("failed to decode encrypted master key", err)
 	}
 
 	// Split nonce and ciphertext for master key
@@ -130,12 +131,9 @@ func (uc *recoveryUseCase) InitiateRecovery(ctx context.Context, email, recovery
 	}
 
 	// Decode encrypted private key
-	encPrivateKeyBytes, err := base64.StdEncoding.DecodeString(response.EncryptedPrivateKey)
+	encPrivateKeyBytes, err := crypto.DecodeBase64Flexible(response.EncryptedPrivateKey)
 	if err != nil {
-		encPrivateKeyBytes, err = base64.RawURLEncoding.DecodeString(response.EncryptedPrivateKey)
-		if err != nil {
-			return nil, errors.NewAppError("failed to decode encrypted private key", err)
-		}
+		return nil, errors.NewAppError("failed to decode encrypted private key", err)
 	}
 
 	// Split nonce and ciphertext for private key
@@ -153,21 +151,15 @@ func (uc *recoveryUseCase) InitiateRecovery(ctx context.Context, email, recovery
 	}
 
 	// Decode public key
-	publicKey, err := base64.StdEncoding.DecodeString(response.PublicKey)
+	publicKey, err := crypto.DecodeBase64Flexible(response.PublicKey)
 	if err != nil {
-		publicKey, err = base64.RawURLEncoding.DecodeString(response.PublicKey)
-		if err != nil {
-			return nil, errors.NewAppError("failed to decode public key", err)
-		}
+		return nil, errors.NewAppError("failed to decode public key", err)
 	}
 
 	// Decode salt
-	salt, err := base64.StdEncoding.DecodeString(response.Salt)
+	salt, err := crypto.DecodeBase64Flexible(response.Salt)
 	if err != nil {
-		salt, err = base64.RawURLEncoding.DecodeString(response.Salt)
-		if err != nil {
-			return nil, errors.NewAppError("failed to decode salt", err)
-		}
+		return nil, errors.NewAppError("failed to decode salt", err)
 	}
 
 	// Parse KDF params
@@ -201,8 +193,8 @@ func (uc *recoveryUseCase) CompleteRecovery(ctx context.Context, recoveryData *R
 		return nil, nil, errors.NewAppError("new password is required", nil)
 	}
 
-	// Check if recovery session has expired
-	if time.Now().After(recoveryData.ExpiresAt) {
+	// Check if recovery session has expired, the expiry time is issued by the server
+	if uc.serverClock.Now().After(recoveryData.ExpiresAt) {
 		return nil, nil, errors.NewAppError("recovery session has expired", nil)
 	}
 
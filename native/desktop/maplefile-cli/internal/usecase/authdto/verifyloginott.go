@@ -3,6 +3,7 @@ package authdto
 
 import (
 	"context"
+	stderrors "errors"
 	"strings"
 	"time"
 
@@ -10,7 +11,9 @@ import (
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	dom_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/authdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
+	pkg_email "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/email"
 )
 
 // LoginOTTVerificationUseCase defines the interface for login OTT verification use cases
@@ -53,11 +56,21 @@ func (uc *loginOTTVerificationUseCase) VerifyLoginOTT(ctx context.Context, email
 	}
 
 	// Sanitize inputs
-	email = strings.ToLower(strings.TrimSpace(email))
+	email, err := pkg_email.Normalize(email)
+	if err != nil {
+		return nil, nil, errors.NewAppError("invalid email", err)
+	}
 	ott = strings.TrimSpace(ott)
 
 	// Check if user exists
 	existingUser, err := uc.userRepo.GetByEmail(ctx, email)
+	if stderrors.Is(err, keys.ErrCorruptedEncryptedMaterial) {
+		// The keys are replaced with the ones from the verification below, so logging in repairs the user.
+		uc.logger.Warn("local user has corrupted encrypted keys, replacing them with the ones from the cloud",
+			zap.String("email", email),
+			zap.Error(err))
+		existingUser, err = nil, nil
+	}
 	if err != nil {
 		return nil, nil, errors.NewAppError("failed to retrieve user", err)
 	}
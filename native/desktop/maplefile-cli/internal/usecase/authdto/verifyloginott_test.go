@@ -0,0 +1,59 @@
+package authdto
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"go.uber.org/zap"
+
+	dom_authdto "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/authdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
+)
+
+// corruptedUserRepo refuses to load the stored user, whose keys are corrupted, and records the upserts
+type corruptedUserRepo struct {
+	user.Repository
+	upserted []*user.User
+}
+
+func (r *corruptedUserRepo) GetByEmail(ctx context.Context, email string) (*user.User, error) {
+	return nil, fmt.Errorf("failed to load user: %w", keys.ErrCorruptedEncryptedMaterial)
+}
+
+func (r *corruptedUserRepo) UpsertByEmail(ctx context.Context, u *user.User) error {
+	saved := *u
+	r.upserted = append(r.upserted, &saved)
+	return nil
+}
+
+type verifiedLoginOTT struct{}
+
+func (verifiedLoginOTT) VerifyLoginOTT(ctx context.Context, request *dom_authdto.VerifyLoginOTTRequestDTO) (*dom_authdto.VerifyLoginOTTResponseDTO, error) {
+	return &dom_authdto.VerifyLoginOTTResponseDTO{ChallengeID: "challenge"}, nil
+}
+
+// challengeTransformer only copies the challenge ID, standing in for the keys from the cloud
+type challengeTransformer struct{}
+
+func (challengeTransformer) UpdateUserWithVerificationData(u *user.User, data *dom_authdto.VerifyLoginOTTResponseDTO) error {
+	u.VerificationID = data.ChallengeID
+	return nil
+}
+
+func TestVerifyLoginOTTReplacesUserWithCorruptedKeys(t *testing.T) {
+	userRepo := &corruptedUserRepo{}
+	uc := NewLoginOTTVerificationUseCase(zap.NewNop(), verifiedLoginOTT{}, userRepo, challengeTransformer{})
+
+	_, verified, err := uc.VerifyLoginOTT(context.Background(), "user@example.com", "123456")
+	if err != nil {
+		t.Fatalf("VerifyLoginOTT() error = %v, want the corrupted user to be replaced", err)
+	}
+	if verified.Email != "user@example.com" || verified.VerificationID != "challenge" {
+		t.Errorf("verified user = %+v, want a new user with the verification data", verified)
+	}
+	if n := len(userRepo.upserted); n == 0 || userRepo.upserted[n-1].VerificationID != "challenge" {
+		t.Error("user with the verification data was not saved over the corrupted one")
+	}
+}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
+	uc_journal "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/journal"
 )
 
 // CreateCollectionUseCase defines the interface for creating a local collection
@@ -18,19 +20,22 @@ type CreateCollectionUseCase interface {
 
 // createCollectionUseCase implements the CreateCollectionUseCase interface
 type createCollectionUseCase struct {
-	logger     *zap.Logger
-	repository collection.CollectionRepository
+	logger             *zap.Logger
+	repository         collection.CollectionRepository
+	recordEntryUseCase uc_journal.RecordEntryUseCase
 }
 
 // NewCreateCollectionUseCase creates a new use case for creating local collections
 func NewCreateCollectionUseCase(
 	logger *zap.Logger,
 	repository collection.CollectionRepository,
+	recordEntryUseCase uc_journal.RecordEntryUseCase,
 ) CreateCollectionUseCase {
 	logger = logger.Named("CreateCollectionUseCase")
 	return &createCollectionUseCase{
-		logger:     logger,
-		repository: repository,
+		logger:             logger,
+		repository:         repository,
+		recordEntryUseCase: recordEntryUseCase,
 	}
 }
 
@@ -75,6 +80,7 @@ func (uc *createCollectionUseCase) Execute(ctx context.Context, data *collection
 
 	// Save the collection
 	err := uc.repository.Create(ctx, data)
+	uc.recordEntryUseCase.Execute(ctx, journal.OperationCreate, journal.EntityTypeCollection, data.ID, err, fmt.Sprintf("state %s", data.State))
 	if err != nil {
 		return errors.NewAppError("failed to create local collection", err)
 	}
@@ -9,6 +9,8 @@ import (
 	"github.com/gocql/gocql"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
+	uc_journal "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/journal"
 )
 
 // DeleteCollectionUseCase defines the interface for deleting a local collection
@@ -19,9 +21,10 @@ type DeleteCollectionUseCase interface {
 
 // deleteCollectionUseCase implements the DeleteCollectionUseCase interface
 type deleteCollectionUseCase struct {
-	logger      *zap.Logger
-	repository  collection.CollectionRepository
-	listUseCase ListCollectionsUseCase
+	logger             *zap.Logger
+	repository         collection.CollectionRepository
+	listUseCase        ListCollectionsUseCase
+	recordEntryUseCase uc_journal.RecordEntryUseCase
 }
 
 // NewDeleteCollectionUseCase creates a new use case for deleting local collections
@@ -29,12 +32,14 @@ func NewDeleteCollectionUseCase(
 	logger *zap.Logger,
 	repository collection.CollectionRepository,
 	listUseCase ListCollectionsUseCase,
+	recordEntryUseCase uc_journal.RecordEntryUseCase,
 ) DeleteCollectionUseCase {
 	logger = logger.Named("DeleteCollectionUseCase")
 	return &deleteCollectionUseCase{
-		logger:      logger,
-		repository:  repository,
-		listUseCase: listUseCase,
+		logger:             logger,
+		repository:         repository,
+		listUseCase:        listUseCase,
+		recordEntryUseCase: recordEntryUseCase,
 	}
 }
 
@@ -50,6 +55,7 @@ func (uc *deleteCollectionUseCase) Execute(
 
 	// Delete the collection
 	err := uc.repository.Delete(ctx, id)
+	uc.recordEntryUseCase.Execute(ctx, journal.OperationDelete, journal.EntityTypeCollection, id, err, "")
 	if err != nil {
 		return errors.NewAppError("failed to delete local collection", err)
 	}
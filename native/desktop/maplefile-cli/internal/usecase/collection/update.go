@@ -3,6 +3,7 @@ package collection
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"go.uber.org/zap"
@@ -10,6 +11,9 @@ import (
 	"github.com/gocql/gocql"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	dom_collection "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collection"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
+	uc_journal "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/journal"
 )
 
 // UpdateCollectionInput defines the input for updating a local collection
@@ -19,6 +23,12 @@ type UpdateCollectionInput struct {
 	DecryptedName  *string
 	CollectionType *string
 	State          *string
+	// EncryptedCollectionKey replaces the wrapped collection key, such as after a master key rotation.
+	EncryptedCollectionKey *keys.EncryptedCollectionKey
+	// Version is set when the change was also applied in the cloud, which bumped the version.
+	Version *uint64
+	// SyncEnabled changes the local preference for syncing the file content of the collection.
+	SyncEnabled *bool
 }
 
 // UpdateCollectionUseCase defines the interface for updating a local collection
@@ -28,9 +38,10 @@ type UpdateCollectionUseCase interface {
 
 // updateCollectionUseCase implements the UpdateCollectionUseCase interface
 type updateCollectionUseCase struct {
-	logger     *zap.Logger
-	repository dom_collection.CollectionRepository
-	getUseCase GetCollectionUseCase
+	logger             *zap.Logger
+	repository         dom_collection.CollectionRepository
+	getUseCase         GetCollectionUseCase
+	recordEntryUseCase uc_journal.RecordEntryUseCase
 }
 
 // NewUpdateCollectionUseCase creates a new use case for updating local collections
@@ -38,12 +49,14 @@ func NewUpdateCollectionUseCase(
 	logger *zap.Logger,
 	repository dom_collection.CollectionRepository,
 	getUseCase GetCollectionUseCase,
+	recordEntryUseCase uc_journal.RecordEntryUseCase,
 ) UpdateCollectionUseCase {
 	logger = logger.Named("UpdateCollectionUseCase")
 	return &updateCollectionUseCase{
-		logger:     logger,
-		repository: repository,
-		getUseCase: getUseCase,
+		logger:             logger,
+		repository:         repository,
+		getUseCase:         getUseCase,
+		recordEntryUseCase: recordEntryUseCase,
 	}
 }
 
@@ -115,12 +128,25 @@ func (uc *updateCollectionUseCase) Execute(
 		collection.State = newState
 	}
 
+	if input.EncryptedCollectionKey != nil {
+		collection.EncryptedCollectionKey = input.EncryptedCollectionKey
+	}
+
+	if input.Version != nil {
+		collection.Version = *input.Version
+	}
+
+	if input.SyncEnabled != nil {
+		collection.SyncDisabled = !*input.SyncEnabled
+	}
+
 	// Update timestamps and modification status
 	collection.ModifiedAt = time.Now()
 	// collection.IsModifiedLocally = true // Figure out what to do here.
 
 	// Save the updated collection
 	err = uc.repository.Save(ctx, collection)
+	uc.recordEntryUseCase.Execute(ctx, journal.OperationUpdate, journal.EntityTypeCollection, collection.ID, err, fmt.Sprintf("state %s", collection.State))
 	if err != nil {
 		return nil, errors.NewAppError("failed to update local collection", err)
 	}
@@ -0,0 +1,101 @@
+// monorepo/native/desktop/maplefile-cli/internal/usecase/collectiondto/archive.go
+package collectiondto
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collectiondto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httperror"
+)
+
+// ArchiveCollectionInCloudUseCase defines the interface for archiving a collection in the cloud
+type ArchiveCollectionInCloudUseCase interface {
+	Execute(ctx context.Context, collectionID gocql.UUID) error
+}
+
+// archiveCollectionInCloudUseCase implements the ArchiveCollectionInCloudUseCase interface
+type archiveCollectionInCloudUseCase struct {
+	logger     *zap.Logger
+	repository collectiondto.CollectionDTORepository
+}
+
+// NewArchiveCollectionInCloudUseCase creates a new use case for archiving collections in the cloud
+func NewArchiveCollectionInCloudUseCase(
+	logger *zap.Logger,
+	repository collectiondto.CollectionDTORepository,
+) ArchiveCollectionInCloudUseCase {
+	logger = logger.Named("ArchiveCollectionInCloudUseCase")
+	return &archiveCollectionInCloudUseCase{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+// Execute archives a collection in the cloud
+func (uc *archiveCollectionInCloudUseCase) Execute(ctx context.Context, collectionID gocql.UUID) error {
+	if collectionID.String() == "" {
+		return httperror.NewForBadRequestWithSingleField("collection_id", "Collection ID is required")
+	}
+
+	uc.logger.Debug("Executing archive collection in cloud use case",
+		zap.String("collectionID", collectionID.String()))
+
+	if err := uc.repository.ArchiveInCloudByID(ctx, collectionID); err != nil {
+		uc.logger.Error("Failed to archive collection in cloud",
+			zap.Error(err),
+			zap.String("collectionID", collectionID.String()))
+		return errors.NewAppError("failed to archive collection in the cloud", err)
+	}
+
+	uc.logger.Info("Successfully archived collection in cloud",
+		zap.String("collectionID", collectionID.String()))
+	return nil
+}
+
+// UnarchiveCollectionInCloudUseCase defines the interface for restoring an archived collection in the cloud
+type UnarchiveCollectionInCloudUseCase interface {
+	Execute(ctx context.Context, collectionID gocql.UUID) error
+}
+
+// unarchiveCollectionInCloudUseCase implements the UnarchiveCollectionInCloudUseCase interface
+type unarchiveCollectionInCloudUseCase struct {
+	logger     *zap.Logger
+	repository collectiondto.CollectionDTORepository
+}
+
+// NewUnarchiveCollectionInCloudUseCase creates a new use case for restoring archived collections in the cloud
+func NewUnarchiveCollectionInCloudUseCase(
+	logger *zap.Logger,
+	repository collectiondto.CollectionDTORepository,
+) UnarchiveCollectionInCloudUseCase {
+	logger = logger.Named("UnarchiveCollectionInCloudUseCase")
+	return &unarchiveCollectionInCloudUseCase{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+// Execute restores an archived collection in the cloud
+func (uc *unarchiveCollectionInCloudUseCase) Execute(ctx context.Context, collectionID gocql.UUID) error {
+	if collectionID.String() == "" {
+		return httperror.NewForBadRequestWithSingleField("collection_id", "Collection ID is required")
+	}
+
+	uc.logger.Debug("Executing unarchive collection in cloud use case",
+		zap.String("collectionID", collectionID.String()))
+
+	if err := uc.repository.RestoreInCloudByID(ctx, collectionID); err != nil {
+		uc.logger.Error("Failed to unarchive collection in cloud",
+			zap.Error(err),
+			zap.String("collectionID", collectionID.String()))
+		return errors.NewAppError("failed to unarchive collection in the cloud", err)
+	}
+
+	uc.logger.Info("Successfully unarchived collection in cloud",
+		zap.String("collectionID", collectionID.String()))
+	return nil
+}
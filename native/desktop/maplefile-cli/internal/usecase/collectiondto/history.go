@@ -0,0 +1,56 @@
+// monorepo/native/desktop/maplefile-cli/internal/usecase/collectiondto/history.go
+package collectiondto
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collectiondto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httperror"
+)
+
+// GetCollectionHistoryFromCloudUseCase defines the interface for getting the change history of a collection
+type GetCollectionHistoryFromCloudUseCase interface {
+	Execute(ctx context.Context, collectionID gocql.UUID) ([]*collectiondto.CollectionChange, error)
+}
+
+// getCollectionHistoryFromCloudUseCase implements the GetCollectionHistoryFromCloudUseCase interface
+type getCollectionHistoryFromCloudUseCase struct {
+	logger     *zap.Logger
+	repository collectiondto.CollectionDTORepository
+}
+
+// NewGetCollectionHistoryFromCloudUseCase creates a new use case for getting collection history from the cloud
+func NewGetCollectionHistoryFromCloudUseCase(
+	logger *zap.Logger,
+	repository collectiondto.CollectionDTORepository,
+) GetCollectionHistoryFromCloudUseCase {
+	logger = logger.Named("GetCollectionHistoryFromCloudUseCase")
+	return &getCollectionHistoryFromCloudUseCase{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+// Execute gets the recorded changes of the collection, most recent first
+func (uc *getCollectionHistoryFromCloudUseCase) Execute(ctx context.Context, collectionID gocql.UUID) ([]*collectiondto.CollectionChange, error) {
+	if collectionID.String() == "" {
+		return nil, httperror.NewForBadRequestWithSingleField("collection_id", "Collection ID is required")
+	}
+
+	response, err := uc.repository.GetHistoryFromCloud(ctx, collectionID)
+	if err != nil {
+		uc.logger.Error("Failed to get collection history from cloud",
+			zap.Error(err),
+			zap.String("collectionID", collectionID.String()))
+		return nil, errors.NewAppError("failed to get collection history from the cloud", err)
+	}
+
+	uc.logger.Debug("Successfully got collection history from cloud",
+		zap.String("collectionID", collectionID.String()),
+		zap.Int("changeCount", len(response.Changes)))
+	return response.Changes, nil
+}
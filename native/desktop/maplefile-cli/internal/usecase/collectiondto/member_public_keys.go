@@ -0,0 +1,73 @@
+// monorepo/native/desktop/maplefile-cli/internal/usecase/collectiondto/member_public_keys.go
+package collectiondto
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collectiondto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httperror"
+)
+
+// GetCollectionMemberPublicKeysFromCloudUseCase defines the interface for getting the public keys of
+// every member of a collection in one call
+type GetCollectionMemberPublicKeysFromCloudUseCase interface {
+	Execute(ctx context.Context, collectionID gocql.UUID) ([]*collectiondto.CollectionMemberPublicKey, error)
+}
+
+// getCollectionMemberPublicKeysFromCloudUseCase implements the GetCollectionMemberPublicKeysFromCloudUseCase interface
+type getCollectionMemberPublicKeysFromCloudUseCase struct {
+	logger     *zap.Logger
+	repository collectiondto.CollectionDTORepository
+}
+
+// NewGetCollectionMemberPublicKeysFromCloudUseCase creates a new use case for getting member public keys from the cloud
+func NewGetCollectionMemberPublicKeysFromCloudUseCase(
+	logger *zap.Logger,
+	repository collectiondto.CollectionDTORepository,
+) GetCollectionMemberPublicKeysFromCloudUseCase {
+	logger = logger.Named("GetCollectionMemberPublicKeysFromCloudUseCase")
+	return &getCollectionMemberPublicKeysFromCloudUseCase{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+// Execute gets the public keys of the members, checking every fingerprint matches its key so the
+// keys can be compared with the ones the members see
+func (uc *getCollectionMemberPublicKeysFromCloudUseCase) Execute(ctx context.Context, collectionID gocql.UUID) ([]*collectiondto.CollectionMemberPublicKey, error) {
+	if collectionID.String() == "" {
+		return nil, httperror.NewForBadRequestWithSingleField("collection_id", "Collection ID is required")
+	}
+
+	response, err := uc.repository.GetMemberPublicKeysFromCloud(ctx, collectionID)
+	if err != nil {
+		uc.logger.Error("Failed to get member public keys from cloud",
+			zap.Error(err),
+			zap.String("collectionID", collectionID.String()))
+		return nil, errors.NewAppError("failed to get member public keys from the cloud", err)
+	}
+
+	for _, member := range response.Members {
+		fingerprint, err := crypto.GeneratePublicKeyFingerprint(member.PublicKey)
+		if err != nil {
+			return nil, errors.NewAppError("failed to generate member public key fingerprint", err)
+		}
+		if member.Fingerprint != "" && member.Fingerprint != fingerprint {
+			uc.logger.Error("Fingerprint does not match the member public key",
+				zap.String("collectionID", collectionID.String()),
+				zap.String("recipientID", member.RecipientID.String()))
+			return nil, errors.NewAppError("the fingerprint returned by the server does not match the public key of "+member.RecipientEmail, nil)
+		}
+		member.Fingerprint = fingerprint
+	}
+
+	uc.logger.Debug("Successfully got member public keys from cloud",
+		zap.String("collectionID", collectionID.String()),
+		zap.Int("memberCount", len(response.Members)))
+	return response.Members, nil
+}
@@ -0,0 +1,74 @@
+// monorepo/native/desktop/maplefile-cli/internal/usecase/collectiondto/update.go
+package collectiondto
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/collectiondto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httperror"
+)
+
+// UpdateCollectionInCloudUseCase defines the interface for updating a collection in the cloud
+type UpdateCollectionInCloudUseCase interface {
+	Execute(ctx context.Context, request *collectiondto.UpdateCollectionRequestDTO) (*collectiondto.CollectionDTO, error)
+}
+
+// updateCollectionInCloudUseCase implements the UpdateCollectionInCloudUseCase interface
+type updateCollectionInCloudUseCase struct {
+	logger     *zap.Logger
+	repository collectiondto.CollectionDTORepository
+}
+
+// NewUpdateCollectionInCloudUseCase creates a new use case for updating collections in the cloud
+func NewUpdateCollectionInCloudUseCase(
+	logger *zap.Logger,
+	repository collectiondto.CollectionDTORepository,
+) UpdateCollectionInCloudUseCase {
+	logger = logger.Named("UpdateCollectionInCloudUseCase")
+	return &updateCollectionInCloudUseCase{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+// Execute updates a collection in the cloud
+func (uc *updateCollectionInCloudUseCase) Execute(ctx context.Context, request *collectiondto.UpdateCollectionRequestDTO) (*collectiondto.CollectionDTO, error) {
+	e := make(map[string]string)
+	if request == nil {
+		e["request"] = "Request is required"
+	} else {
+		if request.ID.String() == "" {
+			e["id"] = "Collection ID is required"
+		}
+		if request.EncryptedName == "" {
+			e["encrypted_name"] = "Encrypted name is required"
+		}
+		if request.EncryptedCollectionKey == nil || len(request.EncryptedCollectionKey.Ciphertext) == 0 {
+			e["encrypted_collection_key"] = "Encrypted collection key is required"
+		}
+	}
+	if len(e) != 0 {
+		uc.logger.Warn("Failed validation for update collection request", zap.Any("errors", e))
+		return nil, httperror.NewForBadRequest(&e)
+	}
+
+	uc.logger.Debug("Executing update collection in cloud use case",
+		zap.String("collectionID", request.ID.String()),
+		zap.Uint64("version", request.Version))
+
+	response, err := uc.repository.UpdateInCloud(ctx, request)
+	if err != nil {
+		uc.logger.Error("Failed to update collection in cloud",
+			zap.Error(err),
+			zap.String("collectionID", request.ID.String()))
+		return nil, errors.NewAppError("failed to update collection in the cloud", err)
+	}
+
+	uc.logger.Info("Successfully updated collection in cloud",
+		zap.String("collectionID", request.ID.String()),
+		zap.Uint64("version", response.Version))
+	return response, nil
+}
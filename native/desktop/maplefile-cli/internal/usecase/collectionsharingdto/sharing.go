@@ -3,6 +3,7 @@ package collectionsharingdto
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -19,7 +20,8 @@ type ShareCollectionInputDTO struct {
 	RecipientEmail         string     `json:"recipient_email"`
 	PermissionLevel        string     `json:"permission_level"`
 	EncryptedCollectionKey *keys.EncryptedCollectionKey
-	ShareWithDescendants   bool `json:"share_with_descendants"`
+	ShareWithDescendants   bool      `json:"share_with_descendants"`
+	ExpiresAt              time.Time `json:"expires_at"`
 }
 
 // ShareCollectionUseCase defines the interface for sharing collections
@@ -69,6 +71,9 @@ func (uc *shareCollectionUseCase) Execute(ctx context.Context, input *ShareColle
 	if err := collectionsharingdto.ValidatePermissionLevel(input.PermissionLevel); err != nil {
 		return nil, errors.NewAppError("invalid permission level", err)
 	}
+	if !input.ExpiresAt.IsZero() && !input.ExpiresAt.After(time.Now()) {
+		return nil, errors.NewAppError("share expiry must be in the future", nil)
+	}
 
 	// Create share request
 	shareRequest := &collectionsharingdto.ShareCollectionRequestDTO{
@@ -78,6 +83,7 @@ func (uc *shareCollectionUseCase) Execute(ctx context.Context, input *ShareColle
 		PermissionLevel:        input.PermissionLevel,
 		EncryptedCollectionKey: input.EncryptedCollectionKey,
 		ShareWithDescendants:   input.ShareWithDescendants,
+		ExpiresAt:              input.ExpiresAt,
 	}
 
 	// Execute share operation via repository
@@ -9,6 +9,8 @@ import (
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
+	uc_journal "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/journal"
 )
 
 // CreateFileUseCase defines the interface for creating a local file
@@ -18,19 +20,22 @@ type CreateFileUseCase interface {
 
 // createFileUseCase implements the CreateFileUseCase interface
 type createFileUseCase struct {
-	logger     *zap.Logger
-	repository file.FileRepository
+	logger             *zap.Logger
+	repository         file.FileRepository
+	recordEntryUseCase uc_journal.RecordEntryUseCase
 }
 
 // NewCreateFileUseCase creates a new use case for creating local files
 func NewCreateFileUseCase(
 	logger *zap.Logger,
 	repository file.FileRepository,
+	recordEntryUseCase uc_journal.RecordEntryUseCase,
 ) CreateFileUseCase {
 	logger = logger.Named("CreateFileUseCase")
 	return &createFileUseCase{
-		logger:     logger,
-		repository: repository,
+		logger:             logger,
+		repository:         repository,
+		recordEntryUseCase: recordEntryUseCase,
 	}
 }
 
@@ -100,6 +105,7 @@ func (uc *createFileUseCase) Execute(ctx context.Context, data *file.File) error
 
 	// Save the file
 	err := uc.repository.Create(ctx, data)
+	uc.recordEntryUseCase.Execute(ctx, journal.OperationCreate, journal.EntityTypeFile, data.ID, err, fmt.Sprintf("sync status %v", data.SyncStatus))
 	if err != nil {
 		return errors.NewAppError("failed to create local file", err)
 	}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
+	uc_journal "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/journal"
 )
 
 // CreateFilesUseCase defines the interface for creating multiple local files
@@ -18,19 +20,22 @@ type CreateFilesUseCase interface {
 
 // createFilesUseCase implements the CreateFilesUseCase interface
 type createFilesUseCase struct {
-	logger     *zap.Logger
-	repository dom_file.FileRepository
+	logger             *zap.Logger
+	repository         dom_file.FileRepository
+	recordEntryUseCase uc_journal.RecordEntryUseCase
 }
 
 // NewCreateFilesUseCase creates a new use case for creating multiple local files
 func NewCreateFilesUseCase(
 	logger *zap.Logger,
 	repository dom_file.FileRepository,
+	recordEntryUseCase uc_journal.RecordEntryUseCase,
 ) CreateFilesUseCase {
 	logger = logger.Named("CreateFilesUseCase")
 	return &createFilesUseCase{
-		logger:     logger,
-		repository: repository,
+		logger:             logger,
+		repository:         repository,
+		recordEntryUseCase: recordEntryUseCase,
 	}
 }
 
@@ -72,6 +77,9 @@ func (uc *createFilesUseCase) Execute(ctx context.Context, data []*dom_file.File
 
 	// Save all files
 	err := uc.repository.CreateMany(ctx, data)
+	for _, fileData := range data {
+		uc.recordEntryUseCase.Execute(ctx, journal.OperationCreate, journal.EntityTypeFile, fileData.ID, err, fmt.Sprintf("sync status %v", fileData.SyncStatus))
+	}
 	if err != nil {
 		return errors.NewAppError("failed to create local files", err)
 	}
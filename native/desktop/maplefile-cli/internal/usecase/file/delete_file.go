@@ -9,6 +9,8 @@ import (
 	"github.com/gocql/gocql"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
+	uc_journal "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/journal"
 )
 
 // DeleteFileUseCase defines the interface for deleting a local file
@@ -18,19 +20,22 @@ type DeleteFileUseCase interface {
 
 // deleteFileUseCase implements the DeleteFileUseCase interface
 type deleteFileUseCase struct {
-	logger     *zap.Logger
-	repository file.FileRepository
+	logger             *zap.Logger
+	repository         file.FileRepository
+	recordEntryUseCase uc_journal.RecordEntryUseCase
 }
 
 // NewDeleteFileUseCase creates a new use case for deleting local files
 func NewDeleteFileUseCase(
 	logger *zap.Logger,
 	repository file.FileRepository,
+	recordEntryUseCase uc_journal.RecordEntryUseCase,
 ) DeleteFileUseCase {
 	logger = logger.Named("DeleteFileUseCase")
 	return &deleteFileUseCase{
-		logger:     logger,
-		repository: repository,
+		logger:             logger,
+		repository:         repository,
+		recordEntryUseCase: recordEntryUseCase,
 	}
 }
 
@@ -46,6 +51,7 @@ func (uc *deleteFileUseCase) Execute(
 
 	// Delete the file
 	err := uc.repository.Delete(ctx, id)
+	uc.recordEntryUseCase.Execute(ctx, journal.OperationDelete, journal.EntityTypeFile, id, err, "")
 	if err != nil {
 		return errors.NewAppError("failed to delete local file", err)
 	}
@@ -10,6 +10,8 @@ import (
 	"github.com/gocql/gocql"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
+	uc_journal "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/journal"
 )
 
 // DeleteFilesUseCase defines the interface for deleting multiple local files
@@ -20,9 +22,10 @@ type DeleteFilesUseCase interface {
 
 // deleteFilesUseCase implements the DeleteFilesUseCase interface
 type deleteFilesUseCase struct {
-	logger      *zap.Logger
-	repository  dom_file.FileRepository
-	listUseCase ListFilesByCollectionUseCase
+	logger             *zap.Logger
+	repository         dom_file.FileRepository
+	listUseCase        ListFilesByCollectionUseCase
+	recordEntryUseCase uc_journal.RecordEntryUseCase
 }
 
 // NewDeleteFilesUseCase creates a new use case for deleting multiple local files
@@ -30,12 +33,14 @@ func NewDeleteFilesUseCase(
 	logger *zap.Logger,
 	repository dom_file.FileRepository,
 	listUseCase ListFilesByCollectionUseCase,
+	recordEntryUseCase uc_journal.RecordEntryUseCase,
 ) DeleteFilesUseCase {
 	logger = logger.Named("DeleteFilesUseCase")
 	return &deleteFilesUseCase{
-		logger:      logger,
-		repository:  repository,
-		listUseCase: listUseCase,
+		logger:             logger,
+		repository:         repository,
+		listUseCase:        listUseCase,
+		recordEntryUseCase: recordEntryUseCase,
 	}
 }
 
@@ -57,6 +62,9 @@ func (uc *deleteFilesUseCase) Execute(
 
 	// Delete the files
 	err := uc.repository.DeleteMany(ctx, ids)
+	for _, id := range ids {
+		uc.recordEntryUseCase.Execute(ctx, journal.OperationDelete, journal.EntityTypeFile, id, err, "")
+	}
 	if err != nil {
 		return errors.NewAppError("failed to delete local files", err)
 	}
@@ -0,0 +1,47 @@
+// internal/usecase/file/list_files.go
+package file
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+)
+
+// ListFilesUseCase defines the interface for listing local files across all collections
+type ListFilesUseCase interface {
+	Execute(ctx context.Context, filter dom_file.FileFilter) ([]*dom_file.File, error)
+}
+
+// listFilesUseCase implements the ListFilesUseCase interface
+type listFilesUseCase struct {
+	logger     *zap.Logger
+	repository dom_file.FileRepository
+}
+
+// NewListFilesUseCase creates a new use case for listing local files
+func NewListFilesUseCase(
+	logger *zap.Logger,
+	repository dom_file.FileRepository,
+) ListFilesUseCase {
+	logger = logger.Named("ListFilesUseCase")
+	return &listFilesUseCase{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+// Execute lists local files matching the filter criteria
+func (uc *listFilesUseCase) Execute(
+	ctx context.Context,
+	filter dom_file.FileFilter,
+) ([]*dom_file.File, error) {
+	files, err := uc.repository.List(ctx, filter)
+	if err != nil {
+		return nil, errors.NewAppError("failed to list local files", err)
+	}
+
+	return files, nil
+}
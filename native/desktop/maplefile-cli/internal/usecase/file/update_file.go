@@ -12,6 +12,9 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
 	dom_file "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/file"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
+	uc_journal "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/journal"
 )
 
 // UpdateFileInput defines the input for updating a local file
@@ -20,8 +23,10 @@ type UpdateFileInput struct {
 	CollectionID           *gocql.UUID
 	OwnerID                *gocql.UUID
 	EncryptedMetadata      *string
+	EncryptedFileKey       *keys.EncryptedFileKey
 	EncryptionVersion      *string
 	EncryptedHash          *string
+	EncryptedHashAlgorithm *string
 	EncryptedFileSize      *int64
 	EncryptedThumbnailSize *int64
 	DecryptedName          *string
@@ -30,8 +35,11 @@ type UpdateFileInput struct {
 	FilePath               *string
 	EncryptedThumbnailPath *string
 	ThumbnailPath          *string
+	ThumbnailSize          *int64
+	ThumbnailFormat        *string
 	StorageMode            *string
 	SyncStatus             *file.SyncStatus
+	LastAccessedAt         *time.Time
 	Version                *uint64
 	ModifiedAt             *time.Time
 	ModifiedByUserID       *gocql.UUID
@@ -45,9 +53,10 @@ type UpdateFileUseCase interface {
 
 // updateFileUseCase implements the UpdateFileUseCase interface
 type updateFileUseCase struct {
-	logger     *zap.Logger
-	repository dom_file.FileRepository
-	getUseCase GetFileUseCase
+	logger             *zap.Logger
+	repository         dom_file.FileRepository
+	getUseCase         GetFileUseCase
+	recordEntryUseCase uc_journal.RecordEntryUseCase
 }
 
 // NewUpdateFileUseCase creates a new use case for updating local files
@@ -55,12 +64,14 @@ func NewUpdateFileUseCase(
 	logger *zap.Logger,
 	repository dom_file.FileRepository,
 	getUseCase GetFileUseCase,
+	recordEntryUseCase uc_journal.RecordEntryUseCase,
 ) UpdateFileUseCase {
 	logger = logger.Named("UpdateFileUseCase")
 	return &updateFileUseCase{
-		logger:     logger,
-		repository: repository,
-		getUseCase: getUseCase,
+		logger:             logger,
+		repository:         repository,
+		getUseCase:         getUseCase,
+		recordEntryUseCase: recordEntryUseCase,
 	}
 }
 
@@ -97,6 +108,10 @@ func (uc *updateFileUseCase) Execute(
 		file.EncryptedMetadata = *input.EncryptedMetadata
 	}
 
+	if input.EncryptedFileKey != nil {
+		file.EncryptedFileKey = *input.EncryptedFileKey
+	}
+
 	if input.EncryptionVersion != nil {
 		file.EncryptionVersion = *input.EncryptionVersion
 	}
@@ -105,6 +120,10 @@ func (uc *updateFileUseCase) Execute(
 		file.EncryptedHash = *input.EncryptedHash
 	}
 
+	if input.EncryptedHashAlgorithm != nil {
+		file.EncryptedHashAlgorithm = *input.EncryptedHashAlgorithm
+	}
+
 	if input.EncryptedFileSize != nil {
 		file.EncryptedFileSize = *input.EncryptedFileSize
 	}
@@ -137,10 +156,22 @@ func (uc *updateFileUseCase) Execute(
 		file.ThumbnailPath = *input.ThumbnailPath
 	}
 
+	if input.ThumbnailSize != nil {
+		file.ThumbnailSize = *input.ThumbnailSize
+	}
+
+	if input.ThumbnailFormat != nil {
+		file.ThumbnailFormat = *input.ThumbnailFormat
+	}
+
 	if input.SyncStatus != nil {
 		file.SyncStatus = *input.SyncStatus
 	}
 
+	if input.LastAccessedAt != nil {
+		file.LastAccessedAt = *input.LastAccessedAt
+	}
+
 	if input.Version != nil {
 		file.Version = *input.Version
 	}
@@ -172,6 +203,7 @@ func (uc *updateFileUseCase) Execute(
 
 	// Save the updated file
 	err = uc.repository.Update(ctx, file)
+	uc.recordEntryUseCase.Execute(ctx, journal.OperationUpdate, journal.EntityTypeFile, file.ID, err, fmt.Sprintf("version %d, sync status %v", file.Version, file.SyncStatus))
 	if err != nil {
 		return nil, errors.NewAppError("failed to update local file", err)
 	}
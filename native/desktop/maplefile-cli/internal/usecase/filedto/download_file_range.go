@@ -0,0 +1,51 @@
+// native/desktop/maplefile-cli/internal/usecase/filedto/download_file_range.go
+package filedto
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
+)
+
+// DownloadFileRangeUseCase defines the interface for downloading a byte range of file content
+type DownloadFileRangeUseCase interface {
+	Execute(ctx context.Context, presignedURL string, start, end int64) (*filedto.DownloadedRange, error)
+}
+
+// downloadFileRangeUseCase implements the DownloadFileRangeUseCase interface
+type downloadFileRangeUseCase struct {
+	logger      *zap.Logger
+	fileDTORepo filedto.FileDTORepository
+}
+
+// NewDownloadFileRangeUseCase creates a new use case for downloading a byte range of file content
+func NewDownloadFileRangeUseCase(
+	logger *zap.Logger,
+	fileDTORepo filedto.FileDTORepository,
+) DownloadFileRangeUseCase {
+	logger = logger.Named("DownloadFileRangeUseCase")
+	return &downloadFileRangeUseCase{
+		logger:      logger,
+		fileDTORepo: fileDTORepo,
+	}
+}
+
+// Execute downloads the bytes [start, end) of file content using a presigned URL
+func (uc *downloadFileRangeUseCase) Execute(ctx context.Context, presignedURL string, start, end int64) (*filedto.DownloadedRange, error) {
+	// Validate inputs
+	if presignedURL == "" {
+		return nil, errors.NewAppError("presigned URL is required", nil)
+	}
+	if start < 0 || end <= start {
+		return nil, errors.NewAppError("invalid byte range", nil)
+	}
+
+	downloaded, err := uc.fileDTORepo.DownloadFileRangeViaPresignedURLFromCloud(ctx, presignedURL, start, end)
+	if err != nil {
+		return nil, errors.NewAppError("failed to download file range", err)
+	}
+	return downloaded, nil
+}
@@ -0,0 +1,77 @@
+// native/desktop/maplefile-cli/internal/usecase/filedto/get_presigned_download_urls.go
+package filedto
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/filedto"
+)
+
+// GetPresignedDownloadURLsUseCase defines the interface for getting presigned download URLs of many files at once
+type GetPresignedDownloadURLsUseCase interface {
+	Execute(ctx context.Context, fileIDs []gocql.UUID, urlDuration time.Duration) (*filedto.GetPresignedDownloadURLsResponse, error)
+}
+
+// getPresignedDownloadURLsUseCase implements the GetPresignedDownloadURLsUseCase interface
+type getPresignedDownloadURLsUseCase struct {
+	logger      *zap.Logger
+	fileDTORepo filedto.FileDTORepository
+}
+
+// NewGetPresignedDownloadURLsUseCase creates a new use case for getting presigned download URLs of many files at once
+func NewGetPresignedDownloadURLsUseCase(
+	logger *zap.Logger,
+	fileDTORepo filedto.FileDTORepository,
+) GetPresignedDownloadURLsUseCase {
+	logger = logger.Named("GetPresignedDownloadURLsUseCase")
+	return &getPresignedDownloadURLsUseCase{
+		logger:      logger,
+		fileDTORepo: fileDTORepo,
+	}
+}
+
+// Execute gets presigned download URLs for at most `filedto.MaxDownloadURLBatchSize` files
+func (uc *getPresignedDownloadURLsUseCase) Execute(
+	ctx context.Context,
+	fileIDs []gocql.UUID,
+	urlDuration time.Duration,
+) (*filedto.GetPresignedDownloadURLsResponse, error) {
+	// Validate inputs
+	if len(fileIDs) == 0 {
+		return nil, errors.NewAppError("file IDs are required", nil)
+	}
+	if len(fileIDs) > filedto.MaxDownloadURLBatchSize {
+		return nil, errors.NewAppError(fmt.Sprintf("cannot get presigned download URLs for more than %d files at once", filedto.MaxDownloadURLBatchSize), nil)
+	}
+
+	// Set default duration if not provided
+	if urlDuration == 0 {
+		urlDuration = 1 * time.Hour
+	}
+
+	// Validate duration is reasonable
+	maxDuration := 24 * time.Hour
+	if urlDuration > maxDuration {
+		return nil, errors.NewAppError("URL duration cannot exceed 24 hours", nil)
+	}
+
+	// Create request
+	request := &filedto.GetPresignedDownloadURLsRequest{
+		FileIDs:     fileIDs,
+		URLDuration: urlDuration,
+	}
+
+	// Get presigned URLs from cloud
+	response, err := uc.fileDTORepo.GetPresignedDownloadURLsFromCloud(ctx, request)
+	if err != nil {
+		return nil, errors.NewAppError("failed to get presigned download URLs", err)
+	}
+
+	return response, nil
+}
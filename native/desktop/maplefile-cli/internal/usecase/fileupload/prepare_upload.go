@@ -47,7 +47,7 @@ func (uc *prepareFileUploadUseCase) Execute(
 	} else {
 		// For decrypted-only mode, we'll encrypt on the fly
 		// Add overhead for encryption
-		expectedFileSize = file.FileSize + crypto.SecretBoxOverhead
+		expectedFileSize = crypto.FramedEncryptedSize(file.FileSize, crypto.DefaultFrameSize)
 	}
 
 	// Create request
@@ -58,6 +58,7 @@ func (uc *prepareFileUploadUseCase) Execute(
 		EncryptedFileKey:             file.EncryptedFileKey,
 		EncryptionVersion:            "v1",
 		EncryptedHash:                file.EncryptedHash,
+		EncryptedHashAlgorithm:       file.EncryptedHashAlgorithm,
 		ExpectedFileSizeInBytes:      expectedFileSize,
 		ExpectedThumbnailSizeInBytes: file.EncryptedThumbnailSize,
 	}
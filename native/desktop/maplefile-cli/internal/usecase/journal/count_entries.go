@@ -0,0 +1,44 @@
+// internal/usecase/journal/count_entries.go
+package journal
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
+)
+
+// CountEntriesUseCase defines the interface for counting the entries kept in the journal
+type CountEntriesUseCase interface {
+	Execute(ctx context.Context) (int, error)
+}
+
+// countEntriesUseCase implements the CountEntriesUseCase interface
+type countEntriesUseCase struct {
+	logger     *zap.Logger
+	repository journal.JournalRepository
+}
+
+// NewCountEntriesUseCase creates a new use case for counting journal entries
+func NewCountEntriesUseCase(
+	logger *zap.Logger,
+	repository journal.JournalRepository,
+) CountEntriesUseCase {
+	logger = logger.Named("CountEntriesUseCase")
+	return &countEntriesUseCase{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+// Execute returns the number of entries currently kept in the journal
+func (uc *countEntriesUseCase) Execute(ctx context.Context) (int, error) {
+	count, err := uc.repository.Count(ctx)
+	if err != nil {
+		uc.logger.Error("Failed to count journal entries", zap.Error(err))
+		return 0, errors.NewAppError("failed to count journal entries", err)
+	}
+	return count, nil
+}
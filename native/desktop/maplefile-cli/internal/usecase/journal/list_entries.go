@@ -0,0 +1,49 @@
+// internal/usecase/journal/list_entries.go
+package journal
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
+)
+
+// ListEntriesUseCase defines the interface for listing journal entries
+type ListEntriesUseCase interface {
+	Execute(ctx context.Context, filter *journal.Filter) ([]*journal.Entry, error)
+}
+
+// listEntriesUseCase implements the ListEntriesUseCase interface
+type listEntriesUseCase struct {
+	logger     *zap.Logger
+	repository journal.JournalRepository
+}
+
+// NewListEntriesUseCase creates a new use case for listing journal entries
+func NewListEntriesUseCase(
+	logger *zap.Logger,
+	repository journal.JournalRepository,
+) ListEntriesUseCase {
+	logger = logger.Named("ListEntriesUseCase")
+	return &listEntriesUseCase{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+// Execute returns the matching journal entries, oldest first
+func (uc *listEntriesUseCase) Execute(ctx context.Context, filter *journal.Filter) ([]*journal.Entry, error) {
+	if filter != nil && filter.Limit < 0 {
+		return nil, errors.NewAppError("limit cannot be negative", nil)
+	}
+
+	entries, err := uc.repository.List(ctx, filter)
+	if err != nil {
+		uc.logger.Error("Failed to list journal entries", zap.Error(err))
+		return nil, errors.NewAppError("failed to list journal entries", err)
+	}
+
+	return entries, nil
+}
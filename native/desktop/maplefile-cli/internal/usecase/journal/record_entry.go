@@ -0,0 +1,78 @@
+// internal/usecase/journal/record_entry.go
+package journal
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gocql/gocql"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/journal"
+)
+
+// RecordEntryUseCase defines the interface for recording a local mutation in the journal
+type RecordEntryUseCase interface {
+	// Execute records the outcome of an operation, a nil opErr records a success. Failing to
+	// write the journal is only logged so it never fails the operation being recorded.
+	Execute(ctx context.Context, operation journal.Operation, entityType journal.EntityType, entityID gocql.UUID, opErr error, details string)
+}
+
+// recordEntryUseCase implements the RecordEntryUseCase interface
+type recordEntryUseCase struct {
+	logger        *zap.Logger
+	configService config.ConfigService
+	repository    journal.JournalRepository
+}
+
+// NewRecordEntryUseCase creates a new use case for recording journal entries
+func NewRecordEntryUseCase(
+	logger *zap.Logger,
+	configService config.ConfigService,
+	repository journal.JournalRepository,
+) RecordEntryUseCase {
+	logger = logger.Named("RecordEntryUseCase")
+	return &recordEntryUseCase{
+		logger:        logger,
+		configService: configService,
+		repository:    repository,
+	}
+}
+
+// Execute appends an entry to the journal
+func (uc *recordEntryUseCase) Execute(
+	ctx context.Context,
+	operation journal.Operation,
+	entityType journal.EntityType,
+	entityID gocql.UUID,
+	opErr error,
+	details string,
+) {
+	entry := &journal.Entry{
+		Timestamp:  time.Now(),
+		Operation:  operation,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Outcome:    journal.OutcomeSuccess,
+		Details:    details,
+	}
+	if opErr != nil {
+		entry.Outcome = journal.OutcomeFailure
+		entry.Error = opErr.Error()
+	}
+
+	maxEntries, err := uc.configService.GetJournalMaxEntries(ctx)
+	if err != nil {
+		uc.logger.Warn("Failed to get journal size, using default", zap.Error(err))
+		maxEntries = config.DefaultJournalMaxEntries
+	}
+
+	if err := uc.repository.Append(ctx, entry, maxEntries); err != nil {
+		uc.logger.Warn("Failed to record journal entry",
+			zap.String("operation", string(operation)),
+			zap.String("entityType", string(entityType)),
+			zap.String("entityID", entityID.String()),
+			zap.Error(err))
+	}
+}
@@ -7,16 +7,16 @@ import (
 	"io"
 	"os"
 
-	"golang.org/x/crypto/sha3"
-
 	"go.uber.org/zap"
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
 )
 
 // ComputeFileHashUseCase defines the interface for getting file information
 type ComputeFileHashUseCase interface {
 	ExecuteForBytes(ctx context.Context, filePath string) ([]byte, error)
+	ExecuteForBytesWithAlgorithm(ctx context.Context, filePath string, algorithm string) ([]byte, error)
 	ExecuteForString(ctx context.Context, filePath string) (string, error)
 }
 
@@ -35,8 +35,13 @@ func NewComputeFileHashUseCase(
 	}
 }
 
-// Execute gets information about a file
+// ExecuteForBytes computes the hash of a file with the default algorithm
 func (uc *computeFileHashUseCase) ExecuteForBytes(ctx context.Context, filePath string) ([]byte, error) {
+	return uc.ExecuteForBytesWithAlgorithm(ctx, filePath, crypto.DefaultHashAlgorithm)
+}
+
+// ExecuteForBytesWithAlgorithm computes the hash of a file with the given algorithm
+func (uc *computeFileHashUseCase) ExecuteForBytesWithAlgorithm(ctx context.Context, filePath string, algorithm string) ([]byte, error) {
 	uc.logger.Debug("Getting file info", zap.String("filePath", filePath))
 
 	if filePath == "" {
@@ -50,8 +55,10 @@ func (uc *computeFileHashUseCase) ExecuteForBytes(ctx context.Context, filePath
 	}
 	defer f.Close()
 
-	// Create a new SHA3-256 hasher
-	hasher := sha3.New256()
+	hasher, err := crypto.NewHash(algorithm)
+	if err != nil {
+		return nil, err
+	}
 
 	// Developer Note:
 	// To efficiently calculate the hash, we read the file in chunks.
@@ -67,7 +74,7 @@ func (uc *computeFileHashUseCase) ExecuteForBytes(ctx context.Context, filePath
 			break
 		}
 		if _, err := hasher.Write(buf[:n]); err != nil {
-			return nil, fmt.Errorf("failed to write to SHA3-256 hasher: %v", err)
+			return nil, fmt.Errorf("failed to write to %s hasher: %v", algorithm, err)
 		}
 	}
 
@@ -0,0 +1,88 @@
+// native/desktop/maplefile-cli/internal/usecase/medto/rotate_master_key.go
+package medto
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/medto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httperror"
+)
+
+// RotateMasterKeyInCloudUseCase defines the interface for replacing the wrapped keys of the user in cloud
+type RotateMasterKeyInCloudUseCase interface {
+	Execute(ctx context.Context, request *medto.RotateMasterKeyRequestDTO) (*medto.RotateMasterKeyResponseDTO, error)
+}
+
+// rotateMasterKeyInCloudUseCase implements the RotateMasterKeyInCloudUseCase interface
+type rotateMasterKeyInCloudUseCase struct {
+	logger     *zap.Logger
+	repository medto.MeDTORepository
+}
+
+// NewRotateMasterKeyInCloudUseCase creates a new use case for rotating the master key in cloud
+func NewRotateMasterKeyInCloudUseCase(
+	logger *zap.Logger,
+	repository medto.MeDTORepository,
+) RotateMasterKeyInCloudUseCase {
+	logger = logger.Named("RotateMasterKeyInCloudUseCase")
+	return &rotateMasterKeyInCloudUseCase{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+// Execute replaces the wrapped keys of the current user in cloud
+func (uc *rotateMasterKeyInCloudUseCase) Execute(ctx context.Context, request *medto.RotateMasterKeyRequestDTO) (*medto.RotateMasterKeyResponseDTO, error) {
+	//
+	// STEP 1: Validate the input
+	//
+
+	e := make(map[string]string)
+
+	if request == nil {
+		e["request"] = "Request is required"
+	} else {
+		if request.ChallengeID == "" {
+			e["challenge_id"] = "Challenge ID is required"
+		}
+		if request.DecryptedChallenge == "" {
+			e["decrypted_challenge"] = "Decrypted challenge is required"
+		}
+		if request.NewEncryptedMasterKey == "" {
+			e["new_encrypted_master_key"] = "Encrypted master key is required"
+		}
+		if request.NewEncryptedPrivateKey == "" {
+			e["new_encrypted_private_key"] = "Encrypted private key is required"
+		}
+		if request.NewEncryptedRecoveryKey == "" {
+			e["new_encrypted_recovery_key"] = "Encrypted recovery key is required"
+		}
+		if request.NewMasterKeyEncryptedWithRecoveryKey == "" {
+			e["new_master_key_encrypted_with_recovery_key"] = "Master key encrypted with recovery key is required"
+		}
+	}
+
+	// If any errors were found, return bad request error
+	if len(e) != 0 {
+		uc.logger.Warn("Failed validation for rotate master key request", zap.Any("errors", e))
+		return nil, httperror.NewForBadRequest(&e)
+	}
+
+	//
+	// STEP 2: Call repository to rotate the master key in cloud
+	//
+
+	response, err := uc.repository.RotateMasterKeyInCloud(ctx, request)
+	if err != nil {
+		uc.logger.Error("Failed to rotate master key in cloud", zap.Error(err))
+		return nil, errors.NewAppError("failed to rotate master key in the cloud", err)
+	}
+
+	uc.logger.Info("Successfully rotated master key in cloud",
+		zap.Int("keyVersion", response.KeyVersion))
+
+	return response, nil
+}
@@ -0,0 +1,50 @@
+// native/desktop/maplefile-cli/internal/usecase/medto/rotate_master_key_challenge.go
+package medto
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/medto"
+)
+
+// GetRotateMasterKeyChallengeFromCloudUseCase defines the interface for requesting the challenge a
+// master key rotation must answer
+type GetRotateMasterKeyChallengeFromCloudUseCase interface {
+	Execute(ctx context.Context) (*medto.RotateMasterKeyChallengeResponseDTO, error)
+}
+
+// getRotateMasterKeyChallengeFromCloudUseCase implements the GetRotateMasterKeyChallengeFromCloudUseCase interface
+type getRotateMasterKeyChallengeFromCloudUseCase struct {
+	logger     *zap.Logger
+	repository medto.MeDTORepository
+}
+
+// NewGetRotateMasterKeyChallengeFromCloudUseCase creates a new use case for requesting a master key rotation challenge
+func NewGetRotateMasterKeyChallengeFromCloudUseCase(
+	logger *zap.Logger,
+	repository medto.MeDTORepository,
+) GetRotateMasterKeyChallengeFromCloudUseCase {
+	logger = logger.Named("GetRotateMasterKeyChallengeFromCloudUseCase")
+	return &getRotateMasterKeyChallengeFromCloudUseCase{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+// Execute requests a master key rotation challenge from cloud
+func (uc *getRotateMasterKeyChallengeFromCloudUseCase) Execute(ctx context.Context) (*medto.RotateMasterKeyChallengeResponseDTO, error) {
+	response, err := uc.repository.GetRotateMasterKeyChallengeFromCloud(ctx)
+	if err != nil {
+		uc.logger.Error("Failed to get master key rotation challenge from cloud", zap.Error(err))
+		return nil, errors.NewAppError("failed to get master key rotation challenge from the cloud", err)
+	}
+
+	if response.ChallengeID == "" || response.EncryptedChallenge == "" {
+		return nil, errors.NewAppError("cloud returned an incomplete master key rotation challenge", nil)
+	}
+
+	return response, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/file"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/filedto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/fileupload"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/journal"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/localfile"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/medto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/usecase/publiclookupdto"
@@ -46,6 +47,11 @@ func UseCaseModule() fx.Option {
 		fx.Provide(collectiondto.NewGetCollectionFromCloudUseCase),
 		fx.Provide(collectiondto.NewListCollectionsFromCloudUseCase),
 		fx.Provide(collectiondto.NewSoftDeleteCollectionFromCloudUseCase),
+		fx.Provide(collectiondto.NewUpdateCollectionInCloudUseCase),
+		fx.Provide(collectiondto.NewArchiveCollectionInCloudUseCase),
+		fx.Provide(collectiondto.NewUnarchiveCollectionInCloudUseCase),
+		fx.Provide(collectiondto.NewGetCollectionMemberPublicKeysFromCloudUseCase),
+		fx.Provide(collectiondto.NewGetCollectionHistoryFromCloudUseCase),
 		// Local-based collection use cases
 		fx.Provide(collection.NewCreateCollectionUseCase),
 		fx.Provide(collection.NewGetCollectionUseCase),
@@ -67,6 +73,7 @@ func UseCaseModule() fx.Option {
 		fx.Provide(file.NewGetFileUseCase),
 		fx.Provide(file.NewGetFilesByIDsUseCase),
 		fx.Provide(file.NewListFilesByCollectionUseCase),
+		fx.Provide(file.NewListFilesUseCase),
 		fx.Provide(file.NewUpdateFileUseCase),
 		fx.Provide(file.NewDeleteFileUseCase),
 		fx.Provide(file.NewDeleteFilesUseCase),
@@ -101,7 +108,9 @@ func UseCaseModule() fx.Option {
 
 		// File DTO use cases
 		fx.Provide(filedto.NewGetPresignedDownloadURLUseCase),
+		fx.Provide(filedto.NewGetPresignedDownloadURLsUseCase),
 		fx.Provide(filedto.NewDownloadFileUseCase),
+		fx.Provide(filedto.NewDownloadFileRangeUseCase),
 
 		// Registration use cases
 		fx.Provide(register.NewGenerateCredentialsUseCase),
@@ -118,6 +127,11 @@ func UseCaseModule() fx.Option {
 		fx.Provide(syncstate.NewUpdateCollectionSyncUseCase),
 		fx.Provide(syncstate.NewUpdateFileSyncUseCase),
 
+		// Local operation journal use cases
+		fx.Provide(journal.NewRecordEntryUseCase),
+		fx.Provide(journal.NewListEntriesUseCase),
+		fx.Provide(journal.NewCountEntriesUseCase),
+
 		// Sync DTO use cases
 		fx.Provide(syncdto.NewGetCollectionSyncDataUseCase),
 		fx.Provide(syncdto.NewGetFileSyncDataUseCase),
@@ -130,5 +144,7 @@ func UseCaseModule() fx.Option {
 		// Cloud-based interaction with user profile DTO
 		fx.Provide(medto.NewGetMeFromCloudUseCase),
 		fx.Provide(medto.NewUpdateMeInCloudUseCase),
+		fx.Provide(medto.NewRotateMasterKeyInCloudUseCase),
+		fx.Provide(medto.NewGetRotateMasterKeyChallengeFromCloudUseCase),
 	)
 }
@@ -3,11 +3,13 @@ package publiclookupdto
 
 import (
 	"context"
+	"encoding/base64"
 
 	"go.uber.org/zap"
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/publiclookupdto"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/crypto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httperror"
 )
 
@@ -65,7 +67,33 @@ func (uc *getPublicLookupFromCloudUseCase) Execute(ctx context.Context, req *pub
 	}
 
 	//
-	// STEP 3: Return our lookup response from the cloud.
+	// STEP 3: Confirm the verification ID and fingerprint belong to the public key, so what the user
+	// compares with the recipient is the key the collection gets encrypted with.
+	//
+
+	publicKey, err := base64.StdEncoding.DecodeString(cloudPublicLookupDTO.PublicKeyInBase64)
+	if err != nil {
+		uc.logger.Error("❌ Failed to decode recipient public key", zap.Error(err))
+		return nil, errors.NewAppError("failed to decode recipient public key", err)
+	}
+	if cloudPublicLookupDTO.VerificationID != "" && !crypto.VerifyVerificationID(publicKey, cloudPublicLookupDTO.VerificationID) {
+		uc.logger.Error("❌ Verification ID does not match the recipient public key",
+			zap.String("email", req.Email))
+		return nil, errors.NewAppError("the verification ID returned by the server does not match the recipient public key", nil)
+	}
+	fingerprint, err := crypto.GeneratePublicKeyFingerprint(publicKey)
+	if err != nil {
+		return nil, errors.NewAppError("failed to generate recipient public key fingerprint", err)
+	}
+	if cloudPublicLookupDTO.Fingerprint != "" && cloudPublicLookupDTO.Fingerprint != fingerprint {
+		uc.logger.Error("❌ Fingerprint does not match the recipient public key",
+			zap.String("email", req.Email))
+		return nil, errors.NewAppError("the fingerprint returned by the server does not match the recipient public key", nil)
+	}
+	cloudPublicLookupDTO.Fingerprint = fingerprint
+
+	//
+	// STEP 4: Return our lookup response from the cloud.
 	//
 
 	return cloudPublicLookupDTO, nil
@@ -3,16 +3,18 @@ package recovery
 
 import (
 	"context"
-	"strings"
+	stderrors "errors"
 	"time"
 
 	"github.com/gocql/gocql"
 	"go.uber.org/zap"
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/common/errors"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/keys"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/recovery"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/recoverydto"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
+	pkg_email "github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/email"
 )
 
 // InitiateRecoveryUseCase defines the interface for initiating account recovery
@@ -57,12 +59,22 @@ func (uc *initiateRecoveryUseCase) Execute(ctx context.Context, email string, me
 	}
 
 	// Sanitize inputs
-	email = strings.ToLower(strings.TrimSpace(email))
+	email, err := pkg_email.Normalize(email)
+	if err != nil {
+		return nil, errors.NewAppError("invalid email", err)
+	}
 
 	//
 	// STEP 2: Check if user exists locally
 	//
 	existingUser, err := uc.userRepo.GetByEmail(ctx, email)
+	if stderrors.Is(err, keys.ErrCorruptedEncryptedMaterial) {
+		// Recovery replaces the keys of the local user, so a corrupted one doesn't stop it
+		uc.logger.Warn("Local user has corrupted encrypted keys, proceeding with cloud recovery",
+			zap.String("email", email),
+			zap.Error(err))
+		existingUser, err = nil, nil
+	}
 	if err != nil {
 		uc.logger.Error("Failed to check user existence", zap.String("email", email), zap.Error(err))
 		return nil, errors.NewAppError("failed to check user existence", err)
@@ -117,6 +129,7 @@ func (uc *initiateRecoveryUseCase) Execute(ctx context.Context, email string, me
 		Email:              email,
 		UserID:             gocql.TimeUUID(),                    // Generate a temporary UUID if user doesn't exist
 		EncryptedChallenge: []byte(response.EncryptedChallenge), // Store as reference
+		Method:             method,
 		ExpiresAt:          time.Now().Add(time.Duration(response.ExpiresIn) * time.Second),
 		IsVerified:         false,
 		CreatedAt:          time.Now(),
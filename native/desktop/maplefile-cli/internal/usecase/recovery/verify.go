@@ -19,7 +19,7 @@ import (
 
 // VerifyRecoveryUseCase defines the interface for verifying recovery challenge
 type VerifyRecoveryUseCase interface {
-	Execute(ctx context.Context, sessionID string, recoveryKey string) (*recoverydto.RecoveryVerifyResponseDTO, error)
+	Execute(ctx context.Context, sessionID string, masterKey []byte) (*recoverydto.RecoveryVerifyResponseDTO, error)
 }
 
 // verifyRecoveryUseCase implements the VerifyRecoveryUseCase interface
@@ -46,21 +46,21 @@ func NewVerifyRecoveryUseCase(
 	}
 }
 
-// Execute verifies the recovery challenge with the provided recovery key
-func (uc *verifyRecoveryUseCase) Execute(ctx context.Context, sessionID string, recoveryKey string) (*recoverydto.RecoveryVerifyResponseDTO, error) {
+// Execute verifies the recovery challenge with the master key recovered by the recovery method of
+// the session. The caller keeps ownership of the master key and clears it.
+func (uc *verifyRecoveryUseCase) Execute(ctx context.Context, sessionID string, masterKey []byte) (*recoverydto.RecoveryVerifyResponseDTO, error) {
 	//
 	// STEP 1: Validate inputs
 	//
 	if sessionID == "" {
 		return nil, errors.NewAppError("session ID is required", nil)
 	}
-	if recoveryKey == "" {
-		return nil, errors.NewAppError("recovery key is required", nil)
+	if len(masterKey) == 0 {
+		return nil, errors.NewAppError("master key is required", nil)
 	}
 
 	// Sanitize inputs
 	sessionID = strings.TrimSpace(sessionID)
-	recoveryKey = strings.TrimSpace(recoveryKey)
 
 	//
 	// STEP 2: Get local recovery session
@@ -100,44 +100,7 @@ func (uc *verifyRecoveryUseCase) Execute(ctx context.Context, sessionID string,
 	}
 
 	//
-	// STEP 4: Decode and validate recovery key
-	//
-	recoveryKeyBytes, err := base64.StdEncoding.DecodeString(recoveryKey)
-	if err != nil {
-		// Try URL-safe base64 as fallback
-		recoveryKeyBytes, err = base64.RawURLEncoding.DecodeString(recoveryKey)
-		if err != nil {
-			return nil, errors.NewAppError("invalid recovery key format", err)
-		}
-	}
-
-	// Validate recovery key size
-	if len(recoveryKeyBytes) != crypto.RecoveryKeySize {
-		return nil, errors.NewAppError("invalid recovery key size", nil)
-	}
-
-	//
-	// STEP 5: Decrypt master key using recovery key
-	//
-	if len(user.MasterKeyEncryptedWithRecoveryKey.Ciphertext) == 0 {
-		return nil, errors.NewAppError("no recovery key data found for user", nil)
-	}
-
-	masterKey, err := crypto.DecryptWithSecretBox(
-		user.MasterKeyEncryptedWithRecoveryKey.Ciphertext,
-		user.MasterKeyEncryptedWithRecoveryKey.Nonce,
-		recoveryKeyBytes,
-	)
-	if err != nil {
-		uc.logger.Error("Failed to decrypt master key with recovery key", zap.Error(err))
-		return nil, errors.NewAppError("invalid recovery key", nil)
-	}
-
-	// Clear master key after use
-	defer crypto.ClearBytes(masterKey)
-
-	//
-	// STEP 6: Decrypt private key using master key
+	// STEP 4: Decrypt private key using master key
 	//
 	privateKey, err := crypto.DecryptWithSecretBox(
 		user.EncryptedPrivateKey.Ciphertext,
@@ -153,7 +116,7 @@ func (uc *verifyRecoveryUseCase) Execute(ctx context.Context, sessionID string,
 	defer crypto.ClearBytes(privateKey)
 
 	//
-	// STEP 7: Decrypt the challenge using the private key
+	// STEP 5: Decrypt the challenge using the private key
 	//
 	if len(localSession.EncryptedChallenge) == 0 {
 		return nil, errors.NewAppError("no encrypted challenge found in session", nil)
@@ -163,13 +126,9 @@ func (uc *verifyRecoveryUseCase) Execute(ctx context.Context, sessionID string,
 	encryptedChallengeStr := string(localSession.EncryptedChallenge)
 
 	// Decode the base64 encrypted challenge
-	encryptedChallengeBytes, err := base64.StdEncoding.DecodeString(encryptedChallengeStr)
+	encryptedChallengeBytes, err := crypto.DecodeBase64Flexible(encryptedChallengeStr)
 	if err != nil {
-		// Try URL-safe base64
-		encryptedChallengeBytes, err = base64.RawURLEncoding.DecodeString(encryptedChallengeStr)
-		if err != nil {
-			return nil, errors.NewAppError("invalid encrypted challenge format", err)
-		}
+		return nil, errors.NewAppError("invalid encrypted challenge format", err)
 	}
 
 	// Decrypt the challenge using box_open (public key cryptography)
@@ -191,7 +150,7 @@ func (uc *verifyRecoveryUseCase) Execute(ctx context.Context, sessionID string,
 		zap.Int("challengeLength", len(decryptedChallenge)))
 
 	//
-	// STEP 8: Create verify request with the properly decrypted challenge
+	// STEP 6: Create verify request with the properly decrypted challenge
 	//
 	request := &recoverydto.RecoveryVerifyRequestDTO{
 		SessionID:          sessionID,
@@ -199,7 +158,7 @@ func (uc *verifyRecoveryUseCase) Execute(ctx context.Context, sessionID string,
 	}
 
 	//
-	// STEP 9: Call cloud service to verify recovery
+	// STEP 7: Call cloud service to verify recovery
 	//
 	uc.logger.Debug("Verifying recovery with cloud", zap.String("sessionID", sessionID))
 
@@ -210,7 +169,7 @@ func (uc *verifyRecoveryUseCase) Execute(ctx context.Context, sessionID string,
 	}
 
 	//
-	// STEP 10: Update local session as verified
+	// STEP 8: Update local session as verified
 	//
 	now := time.Now()
 	localSession.IsVerified = true
@@ -222,7 +181,7 @@ func (uc *verifyRecoveryUseCase) Execute(ctx context.Context, sessionID string,
 	}
 
 	//
-	// STEP 11: Create recovery token record locally
+	// STEP 9: Create recovery token record locally
 	//
 	token := &recovery.RecoveryToken{
 		Token:     response.RecoveryToken,
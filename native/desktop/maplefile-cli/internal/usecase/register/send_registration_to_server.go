@@ -14,6 +14,7 @@ import (
 
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/config"
 	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/internal/domain/user"
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/httpclient"
 )
 
 // RegisterRequest represents the data structure needed for user registration with the server
@@ -55,13 +56,15 @@ type SendRegistrationToServerUseCase interface {
 }
 
 type sendRegistrationToServerUseCase struct {
-	configService config.ConfigService
+	configService  config.ConfigService
+	cloudTransport *httpclient.FailoverTransport
 }
 
 // NewSendRegistrationToServerUseCase creates a new SendRegistrationToServerUseCase
-func NewSendRegistrationToServerUseCase(configService config.ConfigService) SendRegistrationToServerUseCase {
+func NewSendRegistrationToServerUseCase(configService config.ConfigService, cloudTransport *httpclient.FailoverTransport) SendRegistrationToServerUseCase {
 	return &sendRegistrationToServerUseCase{
-		configService: configService,
+		configService:  configService,
+		cloudTransport: cloudTransport,
 	}
 }
 
@@ -126,14 +129,14 @@ func (uc *sendRegistrationToServerUseCase) Execute(ctx context.Context, input Se
 	registerURL := fmt.Sprintf("%s/iam/api/v1/register", serverURL)
 
 	// Create and execute the HTTP request
-	req, err := http.NewRequest("POST", registerURL, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", registerURL, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", fmt.Errorf("error creating HTTP request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := &http.Client{Timeout: 30 * time.Second, Transport: uc.cloudTransport}
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("error connecting to server: %w", err)
@@ -134,7 +134,12 @@ func (uc *processSyncResponseUseCase) ProcessCollectionResponse(ctx context.Cont
 			result.CollectionsDeleted++
 		default:
 			// Unknown state, count as error
-			result.Errors = append(result.Errors, "unknown collection state: "+collection.State)
+			result.Errors = append(result.Errors, syncdto.SyncError{
+				ID:        collection.ID.String(),
+				Operation: syncdto.SyncOperationProcessResponse,
+				Message:   "unknown collection state: " + collection.State,
+				Retryable: false,
+			})
 		}
 	}
 
@@ -172,7 +177,12 @@ func (uc *processSyncResponseUseCase) ProcessFileResponse(ctx context.Context, r
 			result.FilesDeleted++
 		default:
 			// Unknown state, count as error
-			result.Errors = append(result.Errors, "unknown file state: "+file.State)
+			result.Errors = append(result.Errors, syncdto.SyncError{
+				ID:        file.ID.String(),
+				Operation: syncdto.SyncOperationProcessResponse,
+				Message:   "unknown file state: " + file.State,
+				Retryable: false,
+			})
 		}
 	}
 
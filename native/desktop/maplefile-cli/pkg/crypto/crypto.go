@@ -5,10 +5,12 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"log"
+	"strings"
 
 	"github.com/tyler-smith/go-bip39"
 	"golang.org/x/crypto/argon2"
@@ -107,6 +109,23 @@ func VerifyVerificationID(publicKey []byte, verificationID string) bool {
 	return expectedID == verificationID
 }
 
+// GeneratePublicKeyFingerprint creates a short representation of a public key which users can compare
+// out of band, the SHA256 hash of the key in groups of four uppercase hex digits
+func GeneratePublicKeyFingerprint(publicKey []byte) (string, error) {
+	if len(publicKey) == 0 {
+		return "", errors.New("public key cannot be empty")
+	}
+
+	hash := sha256.Sum256(publicKey)
+	encoded := strings.ToUpper(hex.EncodeToString(hash[:]))
+
+	groups := make([]string, 0, len(encoded)/4)
+	for i := 0; i < len(encoded); i += 4 {
+		groups = append(groups, encoded[i:i+4])
+	}
+	return strings.Join(groups, " "), nil
+}
+
 // GenerateKeyPair generates a NaCl box keypair for asymmetric encryption
 func GenerateKeyPair() (publicKey []byte, privateKey []byte, verificationID string, err error) {
 	pubKey, privKey, err := box.GenerateKey(rand.Reader)
@@ -384,6 +403,23 @@ func DecodeFromBase64URL(s string) ([]byte, error) {
 	return base64.RawURLEncoding.DecodeString(s)
 }
 
+// DecodeBase64Flexible decodes a base64 string whatever its encoding, trying standard, raw standard,
+// URL-safe and raw URL-safe encodings in that order and returning the first success. The server and
+// older clients don't agree on a single encoding, so values from either must be accepted.
+func DecodeBase64Flexible(s string) ([]byte, error) {
+	for _, encoding := range []*base64.Encoding{
+		base64.StdEncoding,
+		base64.RawStdEncoding,
+		base64.URLEncoding,
+		base64.RawURLEncoding,
+	} {
+		if decoded, err := encoding.DecodeString(s); err == nil {
+			return decoded, nil
+		}
+	}
+	return nil, errors.New("invalid base64: not decodable with standard or URL-safe encoding, padded or unpadded")
+}
+
 // CombineNonceAndCiphertext combines nonce and ciphertext into a single byte slice
 // This is useful for storing encrypted data as a single blob
 func CombineNonceAndCiphertext(nonce, ciphertext []byte) []byte {
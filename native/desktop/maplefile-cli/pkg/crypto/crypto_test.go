@@ -0,0 +1,43 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+)
+
+func TestDecodeBase64Flexible(t *testing.T) {
+	// 0xfb 0xff 0xbf encodes to "+/+/" in standard and "-_-_" in URL-safe base64, and the extra
+	// byte forces padding, so every encoding produces a distinct string.
+	data := []byte{0xfb, 0xff, 0xbf, 0x01}
+
+	tests := []struct {
+		name     string
+		encoding *base64.Encoding
+	}{
+		{"standard", base64.StdEncoding},
+		{"raw standard", base64.RawStdEncoding},
+		{"url", base64.URLEncoding},
+		{"raw url", base64.RawURLEncoding},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := tt.encoding.EncodeToString(data)
+			decoded, err := DecodeBase64Flexible(encoded)
+			if err != nil {
+				t.Fatalf("DecodeBase64Flexible(%q) error = %v", encoded, err)
+			}
+			if !bytes.Equal(decoded, data) {
+				t.Errorf("DecodeBase64Flexible(%q) = %v, want %v", encoded, decoded, data)
+			}
+		})
+	}
+}
+
+func TestDecodeBase64FlexibleInvalid(t *testing.T) {
+	for _, encoded := range []string{"not base64!", "+/-_", "A"} {
+		if _, err := DecodeBase64Flexible(encoded); err == nil {
+			t.Errorf("DecodeBase64Flexible(%q) error = nil, want error", encoded)
+		}
+	}
+}
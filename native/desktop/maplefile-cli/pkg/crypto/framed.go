@@ -0,0 +1,198 @@
+// monorepo/native/desktop/maplefile-cli/pkg/crypto/framed.go
+package crypto
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Framed encryption splits content into frames which are encrypted with ChaCha20-Poly1305 on their
+// own, so a byte range can be fetched and decrypted without the rest of the content.
+//
+// The content starts with a header, then the frames follow each other:
+//
+//	header: magic (4 bytes) | frame size (uint32) | plaintext size (uint64)
+//	frame:  nonce (12 bytes) | ciphertext of frame size bytes, the last frame shorter | tag (16 bytes)
+//
+// Every frame is authenticated with the header and its index as additional data, so frames can't
+// be reordered, moved between contents or dropped without decryption failing.
+const (
+	// FramedHeaderSize is the size of the header which starts framed content
+	FramedHeaderSize = 16
+	// DefaultFrameSize is the number of plaintext bytes in a frame
+	DefaultFrameSize = 64 * 1024
+	// MaxFrameSize bounds the frame size read from a header
+	MaxFrameSize = 16 * 1024 * 1024
+)
+
+// framedMagic identifies framed content, the last byte is the version of the format
+var framedMagic = []byte{'M', 'F', 'F', 0x01}
+
+// ErrInvalidFramedHeader is returned for framed content whose header is malformed
+var ErrInvalidFramedHeader = errors.New("invalid framed content header")
+
+// FramedHeader describes framed content
+type FramedHeader struct {
+	FrameSize     int64
+	PlaintextSize int64
+	raw           []byte
+}
+
+// IsFramed reports whether data starts with the header of framed content. Content encrypted as a
+// single message starts with a random nonce, so this can rarely be true for it too: callers fall
+// back to single message decryption if framed decryption fails.
+func IsFramed(data []byte) bool {
+	return len(data) >= FramedHeaderSize && bytes.Equal(data[:len(framedMagic)], framedMagic)
+}
+
+// ParseFramedHeader reads the header at the start of framed content
+func ParseFramedHeader(data []byte) (*FramedHeader, error) {
+	if !IsFramed(data) {
+		return nil, ErrInvalidFramedHeader
+	}
+	frameSize := int64(binary.BigEndian.Uint32(data[4:8]))
+	plaintextSize := binary.BigEndian.Uint64(data[8:16])
+	if frameSize <= 0 || frameSize > MaxFrameSize || plaintextSize > 1<<62 {
+		return nil, fmt.Errorf("%w: frame size %d, plaintext size %d", ErrInvalidFramedHeader, frameSize, plaintextSize)
+	}
+	return &FramedHeader{
+		FrameSize:     frameSize,
+		PlaintextSize: int64(plaintextSize),
+		raw:           bytes.Clone(data[:FramedHeaderSize]),
+	}, nil
+}
+
+// FrameCount returns the number of frames of the content
+func (h *FramedHeader) FrameCount() int64 {
+	return (h.PlaintextSize + h.FrameSize - 1) / h.FrameSize
+}
+
+// EncryptedSize returns the size of the whole framed content, header included
+func (h *FramedHeader) EncryptedSize() int64 {
+	return FramedEncryptedSize(h.PlaintextSize, h.FrameSize)
+}
+
+// FramedEncryptedSize returns the size of plaintextSize bytes encrypted in frames of frameSize bytes
+func FramedEncryptedSize(plaintextSize int64, frameSize int64) int64 {
+	frameCount := (plaintextSize + frameSize - 1) / frameSize
+	return FramedHeaderSize + frameCount*(ChaCha20Poly1305NonceSize+ChaCha20Poly1305Overhead) + plaintextSize
+}
+
+// FrameRange returns the frames holding the plaintext bytes [start, end), which must be within the content
+func (h *FramedHeader) FrameRange(start, end int64) (first, last int64) {
+	return start / h.FrameSize, (end - 1) / h.FrameSize
+}
+
+// EncryptedFrameOffsets returns where frames first to last, inclusive, start and end in the content
+func (h *FramedHeader) EncryptedFrameOffsets(first, last int64) (start, end int64) {
+	encryptedFrameSize := ChaCha20Poly1305NonceSize + h.FrameSize + ChaCha20Poly1305Overhead
+	start = FramedHeaderSize + first*encryptedFrameSize
+	end = FramedHeaderSize + (last+1)*encryptedFrameSize
+	if size := h.EncryptedSize(); end > size {
+		end = size
+	}
+	return start, end
+}
+
+// additionalData binds a frame to the header and its position
+func (h *FramedHeader) additionalData(index int64) []byte {
+	ad := make([]byte, FramedHeaderSize+8)
+	copy(ad, h.raw)
+	binary.BigEndian.PutUint64(ad[FramedHeaderSize:], uint64(index))
+	return ad
+}
+
+// EncryptFramed encrypts data with a symmetric key as framed content of frameSize byte frames
+func EncryptFramed(data, key []byte, frameSize int) ([]byte, error) {
+	if len(key) != ChaCha20Poly1305KeySize {
+		return nil, fmt.Errorf("invalid key size: expected %d, got %d", ChaCha20Poly1305KeySize, len(key))
+	}
+	if frameSize <= 0 || frameSize > MaxFrameSize {
+		return nil, fmt.Errorf("invalid frame size: %d", frameSize)
+	}
+
+	cipher, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	raw := make([]byte, FramedHeaderSize)
+	copy(raw, framedMagic)
+	binary.BigEndian.PutUint32(raw[4:8], uint32(frameSize))
+	binary.BigEndian.PutUint64(raw[8:16], uint64(len(data)))
+	header := &FramedHeader{FrameSize: int64(frameSize), PlaintextSize: int64(len(data)), raw: raw}
+
+	out := make([]byte, 0, header.EncryptedSize())
+	out = append(out, raw...)
+	for index := int64(0); index < header.FrameCount(); index++ {
+		start := index * header.FrameSize
+		end := min(start+header.FrameSize, header.PlaintextSize)
+
+		nonce, err := GenerateRandomBytes(ChaCha20Poly1305NonceSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate nonce: %w", err)
+		}
+		out = append(out, nonce...)
+		out = cipher.Seal(out, nonce, data[start:end], header.additionalData(index))
+	}
+	return out, nil
+}
+
+// DecryptFramed decrypts the whole of framed content with a symmetric key
+func DecryptFramed(data, key []byte) ([]byte, error) {
+	header, err := ParseFramedHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) != header.EncryptedSize() {
+		return nil, fmt.Errorf("framed content is %d bytes, expected %d", len(data), header.EncryptedSize())
+	}
+	if header.FrameCount() == 0 {
+		return []byte{}, nil
+	}
+
+	start, _ := header.EncryptedFrameOffsets(0, 0)
+	return DecryptFrames(header, 0, data[start:], key)
+}
+
+// DecryptFrames decrypts consecutive frames from index first, as fetched from the content at the
+// offset given by EncryptedFrameOffsets, and returns their plaintext
+func DecryptFrames(header *FramedHeader, first int64, frames, key []byte) ([]byte, error) {
+	if len(key) != ChaCha20Poly1305KeySize {
+		return nil, fmt.Errorf("invalid key size: expected %d, got %d", ChaCha20Poly1305KeySize, len(key))
+	}
+
+	cipher, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	plaintext := make([]byte, 0, len(frames))
+	for index := first; len(frames) > 0; index++ {
+		if index >= header.FrameCount() {
+			ClearBytes(plaintext)
+			return nil, fmt.Errorf("framed content has more than %d frames", header.FrameCount())
+		}
+		plaintextSize := min(header.FrameSize, header.PlaintextSize-index*header.FrameSize)
+		encryptedSize := ChaCha20Poly1305NonceSize + plaintextSize + ChaCha20Poly1305Overhead
+		if int64(len(frames)) < encryptedSize {
+			ClearBytes(plaintext)
+			return nil, fmt.Errorf("frame %d is truncated", index)
+		}
+
+		nonce := frames[:ChaCha20Poly1305NonceSize]
+		ciphertext := frames[ChaCha20Poly1305NonceSize:encryptedSize]
+		decrypted, err := cipher.Open(plaintext, nonce, ciphertext, header.additionalData(index))
+		if err != nil {
+			ClearBytes(plaintext)
+			return nil, fmt.Errorf("failed to decrypt frame %d: %w", index, err)
+		}
+		plaintext = decrypted
+		frames = frames[encryptedSize:]
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,127 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFramedRoundTrip(t *testing.T) {
+	key, err := GenerateRandomBytes(ChaCha20Poly1305KeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, size := range []int{0, 1, 15, 16, 17, 64, 100} {
+		data := bytes.Repeat([]byte{0xab}, size)
+		encrypted, err := EncryptFramed(data, key, 16)
+		if err != nil {
+			t.Fatalf("EncryptFramed(%d bytes) error = %v", size, err)
+		}
+		if !IsFramed(encrypted) {
+			t.Fatalf("EncryptFramed(%d bytes) is not framed", size)
+		}
+		header, err := ParseFramedHeader(encrypted)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if header.EncryptedSize() != int64(len(encrypted)) {
+			t.Errorf("EncryptedSize() = %d, want %d", header.EncryptedSize(), len(encrypted))
+		}
+
+		decrypted, err := DecryptFramed(encrypted, key)
+		if err != nil {
+			t.Fatalf("DecryptFramed(%d bytes) error = %v", size, err)
+		}
+		if !bytes.Equal(decrypted, data) {
+			t.Errorf("DecryptFramed(%d bytes) = %d bytes, want the original", size, len(decrypted))
+		}
+	}
+}
+
+func TestDecryptFramesOfRange(t *testing.T) {
+	key, err := GenerateRandomBytes(ChaCha20Poly1305KeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := make([]byte, 100)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	encrypted, err := EncryptFramed(data, key, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, err := ParseFramedHeader(encrypted[:FramedHeaderSize])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		start, end int64
+		wantFirst  int64
+		wantLast   int64
+	}{
+		{name: "inside one frame", start: 18, end: 20, wantFirst: 1, wantLast: 1},
+		{name: "across frames", start: 10, end: 40, wantFirst: 0, wantLast: 2},
+		{name: "last frame", start: 97, end: 100, wantFirst: 6, wantLast: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, last := header.FrameRange(tt.start, tt.end)
+			if first != tt.wantFirst || last != tt.wantLast {
+				t.Fatalf("FrameRange() = [%d, %d], want [%d, %d]", first, last, tt.wantFirst, tt.wantLast)
+			}
+			from, to := header.EncryptedFrameOffsets(first, last)
+			plaintext, err := DecryptFrames(header, first, encrypted[from:to], key)
+			if err != nil {
+				t.Fatalf("DecryptFrames() error = %v", err)
+			}
+			skip := tt.start - first*header.FrameSize
+			if got := plaintext[skip : skip+tt.end-tt.start]; !bytes.Equal(got, data[tt.start:tt.end]) {
+				t.Errorf("range = %v, want %v", got, data[tt.start:tt.end])
+			}
+		})
+	}
+}
+
+func TestDecryptFramedRejectsTampering(t *testing.T) {
+	key, err := GenerateRandomBytes(ChaCha20Poly1305KeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := EncryptFramed(bytes.Repeat([]byte{1}, 40), key, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, err := ParseFramedHeader(encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstStart, firstEnd := header.EncryptedFrameOffsets(0, 0)
+	secondStart, secondEnd := header.EncryptedFrameOffsets(1, 1)
+
+	tests := []struct {
+		name   string
+		tamper func([]byte) []byte
+	}{
+		{name: "flipped bit", tamper: func(b []byte) []byte { b[len(b)-1] ^= 1; return b }},
+		{name: "truncated", tamper: func(b []byte) []byte { return b[:len(b)-1] }},
+		{name: "last frame dropped", tamper: func(b []byte) []byte { return b[:secondEnd] }},
+		{name: "frames swapped", tamper: func(b []byte) []byte {
+			swapped := append([]byte{}, b[:firstStart]...)
+			swapped = append(swapped, b[secondStart:secondEnd]...)
+			swapped = append(swapped, b[firstStart:firstEnd]...)
+			return append(swapped, b[secondEnd:]...)
+		}},
+		{name: "plaintext size changed", tamper: func(b []byte) []byte { b[15]--; return b[:len(b)-1] }},
+		{name: "wrong key", tamper: func(b []byte) []byte { key = bytes.Repeat([]byte{2}, ChaCha20Poly1305KeySize); return b }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecryptFramed(tt.tamper(bytes.Clone(encrypted)), key); err == nil {
+				t.Error("DecryptFramed() succeeded on tampered content")
+			}
+		})
+	}
+}
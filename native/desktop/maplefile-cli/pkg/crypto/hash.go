@@ -0,0 +1,41 @@
+// monorepo/native/desktop/maplefile-cli/pkg/crypto/hash.go
+package crypto
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
+)
+
+// Hash algorithms supported for file integrity verification
+const (
+	HashAlgorithmSHA3_256    = "sha3-256"
+	HashAlgorithmSHA256      = "sha256"
+	HashAlgorithmBLAKE2b_256 = "blake2b"
+
+	// DefaultHashAlgorithm is used for new files, and for files hashed before the algorithm was
+	// recorded along with the hash.
+	DefaultHashAlgorithm = HashAlgorithmSHA3_256
+)
+
+// ErrUnsupportedHashAlgorithm is returned for hash algorithms this client does not know
+var ErrUnsupportedHashAlgorithm = errors.New("unsupported hash algorithm")
+
+// NewHash returns a hasher for the algorithm, an empty algorithm selects DefaultHashAlgorithm
+func NewHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case "", HashAlgorithmSHA3_256:
+		return sha3.New256(), nil
+	case HashAlgorithmSHA256:
+		return sha256.New(), nil
+	case HashAlgorithmBLAKE2b_256:
+		return blake2b.New256(nil)
+	default:
+		return nil, fmt.Errorf("%w: %q (supported: %s, %s, %s)", ErrUnsupportedHashAlgorithm, algorithm,
+			HashAlgorithmSHA3_256, HashAlgorithmSHA256, HashAlgorithmBLAKE2b_256)
+	}
+}
@@ -0,0 +1,183 @@
+// monorepo/native/desktop/maplefile-cli/pkg/email/email.go
+package email
+
+// This package normalizes and validates email addresses so every part of the application looks up
+// users by the same canonical form.
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultMaxLength is the maximum length of an address in a SMTP path (RFC 5321).
+	DefaultMaxLength = 254
+
+	// maxLocalPartLength and maxLabelLength are the RFC 5321 limits of the local part and of a
+	// single domain label.
+	maxLocalPartLength = 64
+	maxLabelLength     = 63
+)
+
+var (
+	ErrEmpty         = errors.New("email is required")
+	ErrTooLong       = errors.New("email is too long")
+	ErrInvalidFormat = errors.New("invalid email format")
+	ErrInvalidLocal  = errors.New("invalid email local part")
+	ErrInvalidDomain = errors.New("invalid email domain")
+)
+
+// Error is returned for every address which fails validation. It wraps one of the package errors
+// so callers can use `errors.Is` to find out why the address was rejected.
+type Error struct {
+	Email string
+	Err   error
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if e.Email == "" {
+		return e.Err.Error()
+	}
+	return e.Err.Error() + ": " + e.Email
+}
+
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Options configures how addresses are normalized and validated
+type Options struct {
+	// MaxLength is the maximum length of the normalized address, DefaultMaxLength if zero.
+	MaxLength int
+	// AllowUnicode accepts internationalized local parts and domains (RFC 6531).
+	AllowUnicode bool
+}
+
+// Normalizer normalizes and validates email addresses
+type Normalizer struct {
+	options Options
+}
+
+// New creates a normalizer with the given options
+func New(options Options) *Normalizer {
+	if options.MaxLength <= 0 {
+		options.MaxLength = DefaultMaxLength
+	}
+	return &Normalizer{options: options}
+}
+
+// defaultNormalizer accepts internationalized addresses up to the RFC 5321 length limit.
+var defaultNormalizer = New(Options{AllowUnicode: true})
+
+// Normalize trims and lowercases the address and validates it using the default options.
+func Normalize(address string) (string, error) {
+	return defaultNormalizer.Normalize(address)
+}
+
+// Validate checks the address using the default options without returning the normalized form.
+func Validate(address string) error {
+	_, err := defaultNormalizer.Normalize(address)
+	return err
+}
+
+// Normalize trims surrounding whitespace, lowercases the address and validates its format. The
+// validation follows the RFC 5322 dot-atom form, which covers every address a user would type;
+// quoted local parts, comments and IP literal domains are rejected.
+func (n *Normalizer) Normalize(address string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(address))
+	if normalized == "" {
+		return "", &Error{Email: address, Err: ErrEmpty}
+	}
+	if !utf8.ValidString(normalized) {
+		return "", &Error{Email: address, Err: ErrInvalidFormat}
+	}
+	if len(normalized) > n.options.MaxLength {
+		return "", &Error{Email: address, Err: ErrTooLong}
+	}
+
+	at := strings.LastIndex(normalized, "@")
+	if at <= 0 || at == len(normalized)-1 {
+		return "", &Error{Email: address, Err: ErrInvalidFormat}
+	}
+	localPart, domain := normalized[:at], normalized[at+1:]
+
+	if err := n.validateLocalPart(localPart); err != nil {
+		return "", &Error{Email: address, Err: err}
+	}
+	if err := n.validateDomain(domain); err != nil {
+		return "", &Error{Email: address, Err: err}
+	}
+
+	return normalized, nil
+}
+
+// validateLocalPart checks the part before the "@" is a dot separated list of atoms
+func (n *Normalizer) validateLocalPart(localPart string) error {
+	if len(localPart) > maxLocalPartLength {
+		return ErrInvalidLocal
+	}
+	for _, atom := range strings.Split(localPart, ".") {
+		if atom == "" {
+			// Leading, trailing or consecutive dots.
+			return ErrInvalidLocal
+		}
+		for _, r := range atom {
+			if !n.isAtomRune(r) {
+				return ErrInvalidLocal
+			}
+		}
+	}
+	return nil
+}
+
+// validateDomain checks the part after the "@" is a host name with at least two labels
+func (n *Normalizer) validateDomain(domain string) error {
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return ErrInvalidDomain
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > maxLabelLength {
+			return ErrInvalidDomain
+		}
+		if strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return ErrInvalidDomain
+		}
+		for _, r := range label {
+			if !n.isLabelRune(r) {
+				return ErrInvalidDomain
+			}
+		}
+	}
+
+	// A top level domain is never numeric, which also rejects bare IPv4 addresses.
+	tld := labels[len(labels)-1]
+	if strings.IndexFunc(tld, func(r rune) bool { return !unicode.IsDigit(r) }) < 0 {
+		return ErrInvalidDomain
+	}
+	return nil
+}
+
+// isAtomRune returns true for the characters allowed in an unquoted local part (RFC 5322 atext)
+func (n *Normalizer) isAtomRune(r rune) bool {
+	if r < utf8.RuneSelf {
+		return isASCIIAlphaNumeric(r) || strings.ContainsRune("!#$%&'*+/=?^_`{|}~-", r)
+	}
+	return n.options.AllowUnicode && (unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r))
+}
+
+// isLabelRune returns true for the characters allowed in a domain label
+func (n *Normalizer) isLabelRune(r rune) bool {
+	if r < utf8.RuneSelf {
+		return isASCIIAlphaNumeric(r) || r == '-'
+	}
+	return n.options.AllowUnicode && (unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r))
+}
+
+func isASCIIAlphaNumeric(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
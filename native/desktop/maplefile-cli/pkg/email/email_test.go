@@ -0,0 +1,186 @@
+package email
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "simple address",
+			input: "user@example.com",
+			want:  "user@example.com",
+		},
+		{
+			name:  "uppercase is lowered",
+			input: "User.Name@Example.COM",
+			want:  "user.name@example.com",
+		},
+		{
+			name:  "leading and trailing whitespace",
+			input: "  \tuser@example.com \n",
+			want:  "user@example.com",
+		},
+		{
+			name:  "plus addressing",
+			input: "user+maplefile@example.com",
+			want:  "user+maplefile@example.com",
+		},
+		{
+			name:  "subdomain",
+			input: "user@mail.example.co.uk",
+			want:  "user@mail.example.co.uk",
+		},
+		{
+			name:  "unicode domain",
+			input: "user@bücher.de",
+			want:  "user@bücher.de",
+		},
+		{
+			name:  "unicode local part",
+			input: "Ünïcödé@example.com",
+			want:  "ünïcödé@example.com",
+		},
+		{
+			name:  "punycode domain",
+			input: "user@xn--bcher-kva.de",
+			want:  "user@xn--bcher-kva.de",
+		},
+		{
+			name:    "empty",
+			input:   "",
+			wantErr: ErrEmpty,
+		},
+		{
+			name:    "whitespace only",
+			input:   "   ",
+			wantErr: ErrEmpty,
+		},
+		{
+			name:    "missing at sign",
+			input:   "user.example.com",
+			wantErr: ErrInvalidFormat,
+		},
+		{
+			name:    "missing local part",
+			input:   "@example.com",
+			wantErr: ErrInvalidFormat,
+		},
+		{
+			name:    "missing domain",
+			input:   "user@",
+			wantErr: ErrInvalidFormat,
+		},
+		{
+			name:    "two at signs",
+			input:   "user@name@example.com",
+			wantErr: ErrInvalidLocal,
+		},
+		{
+			name:    "space inside",
+			input:   "user name@example.com",
+			wantErr: ErrInvalidLocal,
+		},
+		{
+			name:    "consecutive dots in local part",
+			input:   "user..name@example.com",
+			wantErr: ErrInvalidLocal,
+		},
+		{
+			name:    "leading dot in local part",
+			input:   ".user@example.com",
+			wantErr: ErrInvalidLocal,
+		},
+		{
+			name:    "display name",
+			input:   "User <user@example.com>",
+			wantErr: ErrInvalidLocal,
+		},
+		{
+			name:    "single label domain",
+			input:   "user@localhost",
+			wantErr: ErrInvalidDomain,
+		},
+		{
+			name:    "domain label starting with hyphen",
+			input:   "user@-example.com",
+			wantErr: ErrInvalidDomain,
+		},
+		{
+			name:    "trailing dot in domain",
+			input:   "user@example.com.",
+			wantErr: ErrInvalidDomain,
+		},
+		{
+			name:    "ip address domain",
+			input:   "user@127.0.0.1",
+			wantErr: ErrInvalidDomain,
+		},
+		{
+			name:    "local part too long",
+			input:   strings.Repeat("a", 65) + "@example.com",
+			wantErr: ErrInvalidLocal,
+		},
+		{
+			name:    "address too long",
+			input:   "user@" + strings.Repeat("a", 62) + "." + strings.Repeat("b", 62) + "." + strings.Repeat("c", 62) + "." + strings.Repeat("d", 62) + ".com",
+			wantErr: ErrTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Normalize(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+				}
+				var emailErr *Error
+				if !errors.As(err, &emailErr) {
+					t.Errorf("Normalize(%q) error type = %T, want *Error", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Normalize(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizerOptions(t *testing.T) {
+	asciiOnly := New(Options{AllowUnicode: false})
+	if _, err := asciiOnly.Normalize("user@bücher.de"); !errors.Is(err, ErrInvalidDomain) {
+		t.Errorf("expected unicode domain to be rejected, got %v", err)
+	}
+	if _, err := asciiOnly.Normalize("ünï@example.com"); !errors.Is(err, ErrInvalidLocal) {
+		t.Errorf("expected unicode local part to be rejected, got %v", err)
+	}
+	if _, err := asciiOnly.Normalize("user@xn--bcher-kva.de"); err != nil {
+		t.Errorf("expected punycode domain to be accepted, got %v", err)
+	}
+
+	short := New(Options{MaxLength: 16})
+	if _, err := short.Normalize("longer.user@example.com"); !errors.Is(err, ErrTooLong) {
+		t.Errorf("expected address to be rejected as too long, got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate("user@example.com"); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+	if err := Validate("not-an-email"); err == nil {
+		t.Error("Validate() expected error for invalid address")
+	}
+}
@@ -0,0 +1,143 @@
+// monorepo/native/desktop/maplefile-cli/pkg/httpclient/breaker.go
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBreakerThreshold is the number of consecutive failed requests, after failover and
+	// retries, which open the circuit breaker.
+	DefaultBreakerThreshold = 5
+
+	// DefaultBreakerCooldown is how long an open circuit breaker fails requests fast before letting
+	// one through to test whether the cloud recovered.
+	DefaultBreakerCooldown = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned without contacting the cloud while the circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker open, the cloud is failing")
+
+// BreakerState is the state of a circuit breaker
+type BreakerState string
+
+const (
+	// BreakerClosed lets every request through.
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen fails every request fast until the cooldown expires.
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen lets a single request through to test recovery, the others fail fast.
+	BreakerHalfOpen BreakerState = "half_open"
+	// BreakerDisabled never fails requests fast.
+	BreakerDisabled BreakerState = "disabled"
+)
+
+// BreakerStatus describes the state of the circuit breaker
+type BreakerStatus struct {
+	State               BreakerState  `json:"state"`
+	ConsecutiveFailures int           `json:"consecutive_failures"`
+	Threshold           int           `json:"threshold"`
+	Cooldown            time.Duration `json:"cooldown"`
+	OpenUntil           time.Time     `json:"open_until,omitzero"`
+	LastError           string        `json:"last_error,omitempty"`
+}
+
+// circuitBreaker stops requests to a failing cloud for a cooldown after too many consecutive
+// failures, which gives the client fast failures and the server room to recover
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mutex               sync.Mutex
+	state               BreakerState
+	consecutiveFailures int
+	openUntil           time.Time
+	probing             bool
+	lastError           string
+}
+
+// newCircuitBreaker creates a closed circuit breaker, a threshold below 1 disables it
+func newCircuitBreaker(threshold int, cooldown time.Duration, now func() time.Time) *circuitBreaker {
+	b := &circuitBreaker{threshold: threshold, cooldown: cooldown, now: now, state: BreakerClosed}
+	if threshold < 1 {
+		b.state = BreakerDisabled
+	}
+	return b
+}
+
+// allow returns ErrCircuitOpen if the request must fail fast. Once the cooldown expired a single
+// request is let through, whose outcome must be recorded to close or reopen the circuit.
+func (b *circuitBreaker) allow() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Before(b.openUntil) {
+			return fmt.Errorf("%w, retrying after %s", ErrCircuitOpen, b.openUntil.Local().Format("15:04:05"))
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return fmt.Errorf("%w, testing recovery", ErrCircuitOpen)
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// record updates the breaker with the outcome of an allowed request and returns true if it opened
+// the circuit. Requests which neither succeeded nor failed, such as cancelled ones, only end a
+// recovery test.
+func (b *circuitBreaker) record(success bool, failure error) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.state == BreakerDisabled {
+		return false
+	}
+	b.probing = false
+
+	switch {
+	case success:
+		b.state = BreakerClosed
+		b.consecutiveFailures = 0
+		b.openUntil = time.Time{}
+		b.lastError = ""
+	case failure != nil:
+		b.consecutiveFailures++
+		b.lastError = failure.Error()
+		if b.state == BreakerHalfOpen || (b.state == BreakerClosed && b.consecutiveFailures >= b.threshold) {
+			b.state = BreakerOpen
+			b.openUntil = b.now().Add(b.cooldown)
+			return true
+		}
+	}
+	return false
+}
+
+// status returns the current state of the breaker
+func (b *circuitBreaker) status() BreakerStatus {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	status := BreakerStatus{
+		State:               b.state,
+		ConsecutiveFailures: b.consecutiveFailures,
+		Threshold:           b.threshold,
+		Cooldown:            b.cooldown,
+		LastError:           b.lastError,
+	}
+	if b.state == BreakerOpen {
+		status.OpenUntil = b.openUntil
+	}
+	return status
+}
@@ -0,0 +1,137 @@
+// monorepo/native/desktop/maplefile-cli/pkg/httpclient/clock.go
+package httpclient
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultClockSkewThreshold is the difference between the local and the server clock above
+	// which the user is warned, it matches the margin used when checking token expiry.
+	DefaultClockSkewThreshold = 30 * time.Second
+
+	// clockOffsetReportStep is how much a measured offset must change before it is reported
+	// again, the `Date` header only has a precision of one second.
+	clockOffsetReportStep = time.Second
+)
+
+// ServerClock estimates the offset of the local clock from the server clock using the `Date`
+// header of the responses received from the server. Expiry times issued by the server should be
+// compared with `Now` instead of `time.Now` so they stay correct on a skewed machine.
+type ServerClock struct {
+	logger    *zap.Logger
+	threshold time.Duration
+	now       func() time.Time
+
+	mutex        sync.Mutex
+	offset       time.Duration
+	measured     bool
+	correct      bool
+	warned       bool
+	lastReported time.Duration
+	onMeasure    func(offset time.Duration)
+}
+
+// NewServerClock creates a clock starting from a previously measured offset. When correct is false
+// the offset is still measured and reported but `Now` returns the local time.
+func NewServerClock(logger *zap.Logger, offset time.Duration, correct bool) *ServerClock {
+	return &ServerClock{
+		logger:       logger.Named("ServerClock"),
+		threshold:    DefaultClockSkewThreshold,
+		now:          time.Now,
+		offset:       offset,
+		correct:      correct,
+		lastReported: offset,
+	}
+}
+
+// OnMeasure registers a function called with the new offset whenever it changes noticeably, for
+// example to persist it for the next run.
+func (c *ServerClock) OnMeasure(fn func(offset time.Duration)) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.onMeasure = fn
+}
+
+// Observe measures the offset from the `Date` header of a response to a request sent at sentAt.
+// Responses without a valid `Date` header are ignored.
+func (c *ServerClock) Observe(resp *http.Response, sentAt time.Time) {
+	if resp == nil {
+		return
+	}
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+	receivedAt := c.now()
+
+	// The server wrote the header somewhere between sending and receiving, assume halfway. The
+	// header is truncated to the second so the server time is on average half a second later.
+	localTime := sentAt.Add(receivedAt.Sub(sentAt) / 2)
+	offset := serverTime.Add(500 * time.Millisecond).Sub(localTime).Round(time.Second)
+
+	c.mutex.Lock()
+	c.offset = offset
+	c.measured = true
+	warn := !c.warned && abs(offset) > c.threshold
+	if warn {
+		c.warned = true
+	}
+	var report func(offset time.Duration)
+	if abs(offset-c.lastReported) >= clockOffsetReportStep {
+		c.lastReported = offset
+		report = c.onMeasure
+	}
+	correct := c.correct
+	c.mutex.Unlock()
+
+	if warn {
+		c.logger.Warn("⚠️ Local clock differs from the server clock, expiry checks may be wrong",
+			zap.Duration("skew", offset),
+			zap.Bool("correcting", correct))
+	}
+	if report != nil {
+		report(offset)
+	}
+}
+
+// Offset returns the offset applied by `Now`, zero when correction is disabled
+func (c *ServerClock) Offset() time.Duration {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.correct {
+		return 0
+	}
+	return c.offset
+}
+
+// Skew returns the last known offset of the server clock from the local clock and whether it was
+// measured during this run rather than loaded from a previous one.
+func (c *ServerClock) Skew() (time.Duration, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.offset, c.measured
+}
+
+// IsSkewed returns true if the last known offset exceeds the warning threshold
+func (c *ServerClock) IsSkewed() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return abs(c.offset) > c.threshold
+}
+
+// Now returns the current time corrected by the offset
+func (c *ServerClock) Now() time.Time {
+	return c.now().Add(c.Offset())
+}
+
+func abs(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
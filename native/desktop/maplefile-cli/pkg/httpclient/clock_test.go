@@ -0,0 +1,66 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestServerClockMeasuresOffsetThroughTransport(t *testing.T) {
+	skew := 2 * time.Minute
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(skew).UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, transport := newClient(t, server.URL)
+	clock := NewServerClock(zap.NewNop(), 0, true)
+	transport.SetServerClock(clock)
+
+	var reported time.Duration
+	clock.OnMeasure(func(offset time.Duration) { reported = offset })
+
+	resp, err := client.Get(server.URL + "/healthcheck")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	resp.Body.Close()
+
+	offset, measured := clock.Skew()
+	if !measured {
+		t.Fatal("Skew() measured = false, want true after a response")
+	}
+	if diff := abs(offset - skew); diff > time.Second {
+		t.Errorf("Skew() = %v, want about %v", offset, skew)
+	}
+	if reported != offset {
+		t.Errorf("reported offset = %v, want %v", reported, offset)
+	}
+	if !clock.IsSkewed() {
+		t.Error("IsSkewed() = false, want true")
+	}
+	if diff := abs(clock.Now().Sub(time.Now().Add(skew))); diff > 2*time.Second {
+		t.Errorf("Now() is %v away from the server time", diff)
+	}
+}
+
+func TestServerClockWithoutCorrection(t *testing.T) {
+	clock := NewServerClock(zap.NewNop(), time.Hour, false)
+
+	if got := clock.Offset(); got != 0 {
+		t.Errorf("Offset() = %v, want 0 when correction is disabled", got)
+	}
+	if offset, measured := clock.Skew(); offset != time.Hour || measured {
+		t.Errorf("Skew() = %v, %t, want the loaded offset which was not measured", offset, measured)
+	}
+
+	// Responses without a usable Date header are ignored.
+	clock.Observe(&http.Response{Header: http.Header{"Date": []string{"not a date"}}}, time.Now())
+	if _, measured := clock.Skew(); measured {
+		t.Error("Skew() measured = true after an invalid Date header")
+	}
+}
@@ -0,0 +1,363 @@
+// monorepo/native/desktop/maplefile-cli/pkg/httpclient/failover.go
+package httpclient
+
+// This package provides an HTTP transport which spreads the requests made to the cloud over a list of
+// equivalent endpoints, failing over to the next endpoint while the preferred one is unhealthy.
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mapleapps-ca/monorepo/native/desktop/maplefile-cli/pkg/retry"
+)
+
+const (
+	// DefaultFailureThreshold is the number of consecutive failures after which an endpoint is
+	// considered unhealthy.
+	DefaultFailureThreshold = 3
+
+	// DefaultCooldown is how long an unhealthy endpoint is skipped before it is tried again.
+	DefaultCooldown = time.Minute
+)
+
+// EndpointStatus describes the health of an endpoint
+type EndpointStatus struct {
+	URL                 string    `json:"url"`
+	Active              bool      `json:"active"`
+	Healthy             bool      `json:"healthy"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	UnhealthyUntil      time.Time `json:"unhealthy_until,omitempty"`
+	LastError           string    `json:"last_error,omitempty"`
+}
+
+type endpoint struct {
+	url                 *url.URL
+	consecutiveFailures int
+	unhealthyUntil      time.Time
+	lastError           string
+}
+
+// FailoverTransport is an `http.RoundTripper` which sends the requests made to any of its endpoints
+// to the most preferred healthy endpoint. An endpoint becomes unhealthy after repeated connection
+// errors or 5xx responses and is skipped until its cooldown expires. Requests which could not even
+// connect are retried on the next endpoint straight away since the server never received them, and
+// once every endpoint was unreachable the whole round is retried with backoff. A circuit breaker
+// fails requests fast for a cooldown once too many of them failed in a row.
+// Requests to other hosts, such as presigned storage URLs, are passed through unchanged.
+type FailoverTransport struct {
+	logger           *zap.Logger
+	base             http.RoundTripper
+	failureThreshold int
+	cooldown         time.Duration
+	retryPolicy      retry.Policy
+	breaker          *circuitBreaker
+	now              func() time.Time
+
+	mutex     sync.Mutex
+	endpoints []*endpoint
+	clock     *ServerClock
+}
+
+// NewFailoverTransport creates a transport for the endpoints, ordered from most to least preferred.
+// A nil base uses `http.DefaultTransport`. Without endpoints every request is passed through.
+func NewFailoverTransport(logger *zap.Logger, base http.RoundTripper, endpointURLs []string) (*FailoverTransport, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	endpoints := make([]*endpoint, 0, len(endpointURLs))
+	for _, rawURL := range endpointURLs {
+		u, err := ParseEndpoint(rawURL)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, &endpoint{url: u})
+	}
+
+	t := &FailoverTransport{
+		logger:           logger.Named("FailoverTransport"),
+		base:             base,
+		failureThreshold: DefaultFailureThreshold,
+		cooldown:         DefaultCooldown,
+		retryPolicy:      retry.DefaultPolicy(),
+		now:              time.Now,
+		endpoints:        endpoints,
+	}
+	t.breaker = newCircuitBreaker(DefaultBreakerThreshold, DefaultBreakerCooldown, t.currentTime)
+	return t, nil
+}
+
+// ParseEndpoint validates an endpoint URL, only the scheme, host and an optional path prefix are kept
+func ParseEndpoint(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", rawURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid endpoint %q: scheme must be http or https", rawURL)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint %q: host is required", rawURL)
+	}
+	return &url.URL{
+		Scheme: u.Scheme,
+		Host:   u.Host,
+		Path:   strings.TrimSuffix(u.Path, "/"),
+	}, nil
+}
+
+// RoundTrip implements the `http.RoundTripper` interface
+func (t *FailoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	matched := t.match(req.URL)
+	if matched == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	if err := t.breaker.allow(); err != nil {
+		return nil, err
+	}
+
+	// Only retry requests which never reached the server, anything else could have been processed
+	// already and is left to the caller.
+	policy := t.retryPolicy
+	policy.Retryable = func(err error) bool {
+		return isConnectError(err) && canReplay(req)
+	}
+	policy.OnRetry = func(attempt int, err error, delay time.Duration) {
+		t.logger.Warn("⚠️ Every endpoint unreachable, retrying",
+			zap.Int("attempt", attempt),
+			zap.Duration("delay", delay),
+			zap.Error(err))
+	}
+
+	var resp *http.Response
+	err := retry.Do(req.Context(), policy, func() error {
+		var err error
+		resp, err = t.roundTripEndpoints(req, matched)
+		return err
+	})
+
+	var opened bool
+	switch {
+	case err == nil && resp.StatusCode < http.StatusInternalServerError:
+		opened = t.breaker.record(true, nil)
+	case err == nil:
+		opened = t.breaker.record(false, errors.New(resp.Status))
+	case req.Context().Err() == nil:
+		opened = t.breaker.record(false, err)
+	default:
+		// The caller gave up, which says nothing about the health of the cloud.
+		opened = t.breaker.record(false, nil)
+	}
+	if opened {
+		status := t.breaker.status()
+		t.logger.Warn("⚠️ Circuit breaker open, failing requests fast",
+			zap.Int("consecutiveFailures", status.ConsecutiveFailures),
+			zap.Time("openUntil", status.OpenUntil),
+			zap.String("lastError", status.LastError))
+	}
+	return resp, err
+}
+
+// roundTripEndpoints sends the request to the preferred endpoint, moving on to the next one for as
+// long as the endpoints tried so far were unreachable
+func (t *FailoverTransport) roundTripEndpoints(req *http.Request, matched *endpoint) (*http.Response, error) {
+	tried := make(map[*endpoint]bool, len(t.endpoints))
+	for {
+		target := t.selectEndpoint(tried)
+		tried[target] = true
+
+		attempt, err := t.rewrite(req, matched, target)
+		if err != nil {
+			return nil, err
+		}
+
+		sentAt := t.now()
+		resp, err := t.base.RoundTrip(attempt)
+		t.record(target, resp, err)
+		if err == nil && t.clock != nil {
+			t.clock.Observe(resp, sentAt)
+		}
+
+		if err != nil && isConnectError(err) && len(tried) < len(t.endpoints) && canReplay(req) {
+			t.logger.Warn("⚠️ Endpoint unreachable, retrying on the next endpoint",
+				zap.String("endpoint", target.url.String()),
+				zap.Error(err))
+			continue
+		}
+		return resp, err
+	}
+}
+
+// SetServerClock makes the transport measure the server clock with the responses of its endpoints.
+// It must be called before the transport is used.
+func (t *FailoverTransport) SetServerClock(clock *ServerClock) {
+	t.clock = clock
+}
+
+// SetRetryPolicy sets how often a request is retried once every endpoint was unreachable, the
+// classifier of the policy is ignored. It must be called before the transport is used.
+func (t *FailoverTransport) SetRetryPolicy(policy retry.Policy) {
+	t.retryPolicy = policy
+}
+
+// SetCircuitBreaker sets the number of consecutive failed requests which open the circuit breaker
+// and how long it stays open, a threshold below 1 disables it. It must be called before the
+// transport is used.
+func (t *FailoverTransport) SetCircuitBreaker(threshold int, cooldown time.Duration) {
+	t.breaker = newCircuitBreaker(threshold, cooldown, t.currentTime)
+}
+
+// BreakerStatus returns the state of the circuit breaker
+func (t *FailoverTransport) BreakerStatus() BreakerStatus {
+	return t.breaker.status()
+}
+
+// currentTime returns the time of the transport clock, which tests replace
+func (t *FailoverTransport) currentTime() time.Time {
+	return t.now()
+}
+
+// ActiveEndpoint returns the endpoint the next request will be sent to, empty without endpoints
+func (t *FailoverTransport) ActiveEndpoint() string {
+	active := t.selectEndpoint(nil)
+	if active == nil {
+		return ""
+	}
+	return active.url.String()
+}
+
+// Status returns the health of every endpoint, ordered from most to least preferred
+func (t *FailoverTransport) Status() []EndpointStatus {
+	active := t.selectEndpoint(nil)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := t.now()
+	statuses := make([]EndpointStatus, 0, len(t.endpoints))
+	for _, e := range t.endpoints {
+		status := EndpointStatus{
+			URL:                 e.url.String(),
+			Active:              e == active,
+			Healthy:             !now.Before(e.unhealthyUntil),
+			ConsecutiveFailures: e.consecutiveFailures,
+			LastError:           e.lastError,
+		}
+		if !status.Healthy {
+			status.UnhealthyUntil = e.unhealthyUntil
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// match returns the endpoint the request URL was built for, nil for any other host
+func (t *FailoverTransport) match(u *url.URL) *endpoint {
+	for _, e := range t.endpoints {
+		if u.Scheme == e.url.Scheme && u.Host == e.url.Host && strings.HasPrefix(u.Path, e.url.Path) {
+			return e
+		}
+	}
+	return nil
+}
+
+// selectEndpoint returns the most preferred healthy endpoint which was not tried yet. If every
+// candidate is unhealthy the one which recovers first is used so requests keep being attempted.
+func (t *FailoverTransport) selectEndpoint(tried map[*endpoint]bool) *endpoint {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := t.now()
+	var fallback *endpoint
+	for _, e := range t.endpoints {
+		if tried[e] {
+			continue
+		}
+		if !now.Before(e.unhealthyUntil) {
+			return e
+		}
+		if fallback == nil || e.unhealthyUntil.Before(fallback.unhealthyUntil) {
+			fallback = e
+		}
+	}
+	if fallback == nil && len(t.endpoints) > 0 {
+		// Every endpoint was tried, which only happens when called without a retry in progress.
+		return t.endpoints[0]
+	}
+	return fallback
+}
+
+// record updates the health of an endpoint with the outcome of a request
+func (t *FailoverTransport) record(e *endpoint, resp *http.Response, err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if err == nil && resp.StatusCode < http.StatusInternalServerError {
+		if !e.unhealthyUntil.IsZero() {
+			t.logger.Info("✅ Endpoint recovered", zap.String("endpoint", e.url.String()))
+		}
+		e.consecutiveFailures = 0
+		e.unhealthyUntil = time.Time{}
+		e.lastError = ""
+		return
+	}
+
+	e.consecutiveFailures++
+	if err != nil {
+		e.lastError = err.Error()
+	} else {
+		e.lastError = resp.Status
+	}
+
+	// A failed connection is a strong enough signal on its own, 5xx responses must repeat.
+	if e.consecutiveFailures >= t.failureThreshold || (err != nil && isConnectError(err)) {
+		e.unhealthyUntil = t.now().Add(t.cooldown)
+		t.logger.Warn("⚠️ Endpoint marked unhealthy",
+			zap.String("endpoint", e.url.String()),
+			zap.Int("consecutiveFailures", e.consecutiveFailures),
+			zap.Duration("cooldown", t.cooldown),
+			zap.String("lastError", e.lastError))
+	}
+}
+
+// rewrite returns a copy of the request sent to the target endpoint instead of the matched one
+func (t *FailoverTransport) rewrite(req *http.Request, matched, target *endpoint) (*http.Request, error) {
+	attempt := req.Clone(req.Context())
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to replay request body: %w", err)
+		}
+		attempt.Body = body
+	}
+	if matched == target {
+		return attempt, nil
+	}
+
+	attempt.URL.Scheme = target.url.Scheme
+	attempt.URL.Host = target.url.Host
+	attempt.URL.Path = target.url.Path + strings.TrimPrefix(req.URL.Path, matched.url.Path)
+	attempt.URL.RawPath = ""
+	attempt.Host = ""
+	return attempt, nil
+}
+
+// isConnectError returns true if the request failed before a connection to the server was made
+func isConnectError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// canReplay returns true if the body of the request can be sent again
+func canReplay(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
@@ -0,0 +1,280 @@
+package httpclient
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// unreachableURL returns the URL of a port nothing listens on
+func unreachableURL(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to reserve port: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+	return "http://" + addr
+}
+
+func newClient(t *testing.T, endpoints ...string) (*http.Client, *FailoverTransport) {
+	t.Helper()
+	transport, err := NewFailoverTransport(zap.NewNop(), nil, endpoints)
+	if err != nil {
+		t.Fatalf("NewFailoverTransport() error = %v", err)
+	}
+	return &http.Client{Transport: transport, Timeout: 5 * time.Second}, transport
+}
+
+func TestFailoverTransportRetriesUnreachableEndpoint(t *testing.T) {
+	var gotPath, gotBody string
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer secondary.Close()
+
+	primary := unreachableURL(t)
+	client, transport := newClient(t, primary, secondary.URL)
+
+	resp, err := client.Post(primary+"/maplefile/api/v1/files", "application/json", bytes.NewBufferString(`{"id":"1"}`))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	if gotPath != "/maplefile/api/v1/files" {
+		t.Errorf("path = %q, want the original path", gotPath)
+	}
+	if gotBody != `{"id":"1"}` {
+		t.Errorf("body = %q, want the original body", gotBody)
+	}
+	if got := transport.ActiveEndpoint(); got != secondary.URL {
+		t.Errorf("ActiveEndpoint() = %q, want %q", got, secondary.URL)
+	}
+
+	statuses := transport.Status()
+	if statuses[0].Healthy || statuses[0].Active {
+		t.Errorf("primary status = %+v, want unhealthy and inactive", statuses[0])
+	}
+	if !statuses[1].Healthy || !statuses[1].Active {
+		t.Errorf("secondary status = %+v, want healthy and active", statuses[1])
+	}
+}
+
+func TestFailoverTransportFailsOverAfterRepeatedServerErrors(t *testing.T) {
+	primaryHits := 0
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryHits++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer primary.Close()
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer secondary.Close()
+
+	client, _ := newClient(t, primary.URL, secondary.URL)
+
+	// 5xx responses are returned to the caller and are not retried, since the server may have
+	// processed the request.
+	for i := 0; i < DefaultFailureThreshold; i++ {
+		resp, err := client.Get(primary.URL + "/healthcheck")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadGateway {
+			t.Fatalf("request %d status = %d, want %d", i, resp.StatusCode, http.StatusBadGateway)
+		}
+	}
+
+	resp, err := client.Get(primary.URL + "/healthcheck")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status after failover = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if primaryHits != DefaultFailureThreshold {
+		t.Errorf("primary hits = %d, want %d", primaryHits, DefaultFailureThreshold)
+	}
+}
+
+func TestFailoverTransportRecoversAfterCooldown(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer primary.Close()
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer secondary.Close()
+
+	_, transport := newClient(t, primary.URL, secondary.URL)
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+
+	transport.endpoints[0].unhealthyUntil = now.Add(DefaultCooldown)
+	if got := transport.ActiveEndpoint(); got != secondary.URL {
+		t.Fatalf("ActiveEndpoint() = %q, want %q while the primary cools down", got, secondary.URL)
+	}
+
+	now = now.Add(DefaultCooldown)
+	if got := transport.ActiveEndpoint(); got != primary.URL {
+		t.Errorf("ActiveEndpoint() = %q, want %q once the cooldown expired", got, primary.URL)
+	}
+}
+
+func TestFailoverTransportPassesThroughOtherHosts(t *testing.T) {
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer storage.Close()
+
+	client, transport := newClient(t, "http://cloud.example.invalid", unreachableURL(t))
+
+	for i := 0; i < DefaultFailureThreshold+1; i++ {
+		resp, err := client.Get(storage.URL + "/bucket/object")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	// Failures of other hosts must not affect the health of the endpoints.
+	for _, status := range transport.Status() {
+		if !status.Healthy || status.ConsecutiveFailures != 0 {
+			t.Errorf("endpoint status = %+v, want untouched", status)
+		}
+	}
+}
+
+func TestParseEndpoint(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr string
+	}{
+		{input: "https://api.example.com", want: "https://api.example.com"},
+		{input: " https://api.example.com/ ", want: "https://api.example.com"},
+		{input: "https://api.example.com/prefix/", want: "https://api.example.com/prefix"},
+		{input: "ftp://api.example.com", wantErr: "scheme"},
+		{input: "https://", wantErr: "host"},
+		{input: "api.example.com", wantErr: "scheme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseEndpoint(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseEndpoint() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseEndpoint() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("ParseEndpoint() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFailoverTransportCircuitBreaker(t *testing.T) {
+	hits := 0
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client, transport := newClient(t, server.URL)
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+	transport.SetCircuitBreaker(2, time.Minute)
+
+	get := func() error {
+		resp, err := client.Get(server.URL + "/healthcheck")
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	// Server errors open the breaker once the threshold is reached
+	for i := 0; i < 2; i++ {
+		if err := get(); err != nil {
+			t.Fatalf("Get() #%d error = %v, want the server error response", i+1, err)
+		}
+	}
+	if got := transport.BreakerStatus().State; got != BreakerOpen {
+		t.Fatalf("breaker state = %q, want %q", got, BreakerOpen)
+	}
+
+	// Requests fail fast without reaching the server while the breaker is open
+	if err := get(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Get() error = %v, want ErrCircuitOpen", err)
+	}
+	if hits != 2 {
+		t.Errorf("server hits = %d, want 2", hits)
+	}
+
+	// A failed recovery test reopens the breaker
+	now = now.Add(time.Minute)
+	if err := get(); err != nil {
+		t.Fatalf("Get() error = %v, want the recovery test to reach the server", err)
+	}
+	if got := transport.BreakerStatus().State; got != BreakerOpen {
+		t.Errorf("breaker state = %q after a failed recovery test, want %q", got, BreakerOpen)
+	}
+
+	// A successful recovery test closes it
+	now = now.Add(time.Minute)
+	status = http.StatusOK
+	if err := get(); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got := transport.BreakerStatus(); got.State != BreakerClosed || got.ConsecutiveFailures != 0 {
+		t.Errorf("breaker status = %+v, want closed without failures", got)
+	}
+}
+
+func TestFailoverTransportCircuitBreakerDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, transport := newClient(t, server.URL)
+	transport.SetCircuitBreaker(0, time.Minute)
+
+	for i := 0; i < DefaultBreakerThreshold+1; i++ {
+		resp, err := client.Get(server.URL + "/healthcheck")
+		if err != nil {
+			t.Fatalf("Get() #%d error = %v, want requests to keep reaching the server", i+1, err)
+		}
+		resp.Body.Close()
+	}
+	if got := transport.BreakerStatus().State; got != BreakerDisabled {
+		t.Errorf("breaker state = %q, want %q", got, BreakerDisabled)
+	}
+}
@@ -0,0 +1,162 @@
+// monorepo/native/desktop/maplefile-cli/pkg/imagehash/phash.go
+package imagehash
+
+// This package computes perceptual hashes of images, which stay nearly identical when an image is
+// resized, re-encoded or slightly edited, so visually similar images can be found by comparing them.
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // Register GIF decoder
+	_ "image/jpeg" // Register JPEG decoder
+	_ "image/png"  // Register PNG decoder
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+)
+
+const (
+	// sampleSize is the width and height of the grayscale image the DCT is computed on
+	sampleSize = 32
+	// hashSize is the width and height of the low frequency DCT coefficients kept in the hash
+	hashSize = 8
+)
+
+// ErrUnsupportedImage is returned for data which can't be decoded as a JPEG, PNG or GIF image
+var ErrUnsupportedImage = errors.New("unsupported image format")
+
+// Hash is a 64-bit perceptual hash, visually similar images have hashes a small Distance apart
+type Hash uint64
+
+// String returns the hash as 16 hexadecimal digits
+func (h Hash) String() string {
+	return fmt.Sprintf("%016x", uint64(h))
+}
+
+// Parse parses a hash formatted by String
+func Parse(s string) (Hash, error) {
+	if len(s) != 16 {
+		return 0, fmt.Errorf("invalid perceptual hash %q: must be 16 hexadecimal digits", s)
+	}
+	value, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid perceptual hash %q: %w", s, err)
+	}
+	return Hash(value), nil
+}
+
+// Distance returns the number of bits which differ between the hashes, from 0 for identical hashes
+// to 64. Images at a distance of 10 or less usually look alike.
+func Distance(a, b Hash) int {
+	return bits.OnesCount64(uint64(a ^ b))
+}
+
+// Compute decodes the image data and returns its perceptual hash
+func Compute(data []byte) (Hash, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return 0, ErrUnsupportedImage
+		}
+		return 0, fmt.Errorf("failed to decode image: %w", err)
+	}
+	return ComputeImage(img), nil
+}
+
+// ComputeImage returns the perceptual hash of the image. The image is reduced to a 32x32 grayscale
+// sample whose lowest 8x8 DCT frequencies are compared with their median, one bit per frequency.
+func ComputeImage(img image.Image) Hash {
+	coefficients := dct2D(grayscaleSample(img))
+
+	// The DC coefficient is the average brightness and is left out of the median so it doesn't skew it
+	lowFrequencies := make([]float64, 0, hashSize*hashSize)
+	for y := 0; y < hashSize; y++ {
+		for x := 0; x < hashSize; x++ {
+			lowFrequencies = append(lowFrequencies, coefficients[y][x])
+		}
+	}
+	sorted := append([]float64(nil), lowFrequencies[1:]...)
+	sort.Float64s(sorted)
+	median := (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+
+	var hash Hash
+	for i, coefficient := range lowFrequencies {
+		if coefficient > median {
+			hash |= 1 << uint(len(lowFrequencies)-1-i)
+		}
+	}
+	return hash
+}
+
+// grayscaleSample averages the luminance of the image over a grid of sampleSize by sampleSize cells
+func grayscaleSample(img image.Image) [][]float64 {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	var sums, counts [sampleSize][sampleSize]float64
+	for y := 0; y < height; y++ {
+		cellY := y * sampleSize / height
+		for x := 0; x < width; x++ {
+			cellX := x * sampleSize / width
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			sums[cellY][cellX] += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+			counts[cellY][cellX]++
+		}
+	}
+
+	// Images smaller than the sample leave cells empty, which take the value of the nearest pixel
+	sample := make([][]float64, sampleSize)
+	for y := range sample {
+		sample[y] = make([]float64, sampleSize)
+		for x := range sample[y] {
+			if counts[y][x] > 0 {
+				sample[y][x] = sums[y][x] / counts[y][x]
+			} else if width > 0 && height > 0 {
+				r, g, b, _ := img.At(bounds.Min.X+x*width/sampleSize, bounds.Min.Y+y*height/sampleSize).RGBA()
+				sample[y][x] = 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+			}
+		}
+	}
+	return sample
+}
+
+// dct2D returns the type-II discrete cosine transform of the square matrix
+func dct2D(matrix [][]float64) [][]float64 {
+	n := len(matrix)
+	rows := make([][]float64, n)
+	for y := range matrix {
+		rows[y] = dct1D(matrix[y])
+	}
+
+	result := make([][]float64, n)
+	for y := range result {
+		result[y] = make([]float64, n)
+	}
+	column := make([]float64, n)
+	for x := 0; x < n; x++ {
+		for y := 0; y < n; y++ {
+			column[y] = rows[y][x]
+		}
+		for y, value := range dct1D(column) {
+			result[y][x] = value
+		}
+	}
+	return result
+}
+
+// dct1D returns the type-II discrete cosine transform of the values
+func dct1D(values []float64) []float64 {
+	n := len(values)
+	result := make([]float64, n)
+	for k := range result {
+		var sum float64
+		for i, value := range values {
+			sum += value * math.Cos(math.Pi/float64(n)*(float64(i)+0.5)*float64(k))
+		}
+		result[k] = sum
+	}
+	return result
+}
@@ -0,0 +1,72 @@
+package imagehash
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// pattern draws a diagonal gradient with a bright square, scaled to the given size
+func pattern(size int, inverted bool) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			value := uint8((x + y) * 255 / (2 * size))
+			if x > size/4 && x < size/2 && y > size/4 && y < size/2 {
+				value = 255
+			}
+			if inverted {
+				value = 255 - value
+			}
+			img.Set(x, y, color.RGBA{R: value, G: value, B: value, A: 255})
+		}
+	}
+	return img
+}
+
+func TestComputeMatchesReencodedAndResizedImages(t *testing.T) {
+	var original bytes.Buffer
+	if err := png.Encode(&original, pattern(256, false)); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	var resized bytes.Buffer
+	if err := jpeg.Encode(&resized, pattern(100, false), &jpeg.Options{Quality: 60}); err != nil {
+		t.Fatalf("jpeg.Encode() error = %v", err)
+	}
+
+	originalHash, err := Compute(original.Bytes())
+	if err != nil {
+		t.Fatalf("Compute() error = %v", err)
+	}
+	resizedHash, err := Compute(resized.Bytes())
+	if err != nil {
+		t.Fatalf("Compute() error = %v", err)
+	}
+	if distance := Distance(originalHash, resizedHash); distance > 6 {
+		t.Errorf("Distance() of the re-encoded image = %d, want at most 6", distance)
+	}
+
+	if distance := Distance(originalHash, ComputeImage(pattern(256, true))); distance < 20 {
+		t.Errorf("Distance() of a different image = %d, want at least 20", distance)
+	}
+}
+
+func TestComputeRejectsNonImages(t *testing.T) {
+	if _, err := Compute([]byte("%PDF-1.7")); err != ErrUnsupportedImage {
+		t.Errorf("Compute() error = %v, want %v", err, ErrUnsupportedImage)
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	hash := Hash(0x0123456789abcdef)
+	parsed, err := Parse(hash.String())
+	if err != nil || parsed != hash {
+		t.Errorf("Parse(%q) = %v, %v, want %v", hash.String(), parsed, err, hash)
+	}
+	if _, err := Parse("xyz"); err == nil {
+		t.Error("Parse() of an invalid hash succeeded")
+	}
+}
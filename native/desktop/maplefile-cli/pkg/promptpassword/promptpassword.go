@@ -0,0 +1,93 @@
+// monorepo/native/desktop/maplefile-cli/pkg/promptpassword/promptpassword.go
+package promptpassword
+
+// This package reads passwords from the terminal without echoing them. When stdin is not a
+// terminal, for example when a password is piped in by a script, one line is read instead.
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// ErrPasswordsDoNotMatch is returned when the confirmation differs from the password
+var ErrPasswordsDoNotMatch = errors.New("passwords do not match")
+
+// Read prints the prompt and reads a password from stdin
+func Read(prompt string) (string, error) {
+	return read(os.Stdin, os.Stdout, prompt)
+}
+
+// ReadWithConfirmation reads a password and then reads it again to rule out typos
+func ReadWithConfirmation(prompt string, confirmPrompt string) (string, error) {
+	return readWithConfirmation(os.Stdin, os.Stdout, prompt, confirmPrompt)
+}
+
+func readWithConfirmation(in io.Reader, out io.Writer, prompt string, confirmPrompt string) (string, error) {
+	password, err := read(in, out, prompt)
+	if err != nil {
+		return "", err
+	}
+	confirmation, err := read(in, out, confirmPrompt)
+	if err != nil {
+		return "", err
+	}
+	if password != confirmation {
+		return "", ErrPasswordsDoNotMatch
+	}
+	return password, nil
+}
+
+func read(in io.Reader, out io.Writer, prompt string) (string, error) {
+	fmt.Fprint(out, prompt)
+
+	var buf []byte
+	var err error
+	if f, ok := in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		buf, err = term.ReadPassword(int(f.Fd()))
+		fmt.Fprintln(out) // The newline typed by the user isn't echoed either
+	} else {
+		buf, err = readLine(in)
+	}
+	// Go strings can't be cleared, but at least the buffer doesn't linger in memory.
+	defer clear(buf)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+
+	return string(trimCarriageReturn(buf)), nil
+}
+
+// readLine reads up to the next newline one byte at a time, so no input after the line is
+// consumed and later prompts can still read it
+func readLine(in io.Reader) ([]byte, error) {
+	line := make([]byte, 0, 64)
+	b := make([]byte, 1)
+	defer clear(b)
+	for {
+		n, err := in.Read(b)
+		if n == 1 {
+			if b[0] == '\n' {
+				return line, nil
+			}
+			if len(line) == cap(line) {
+				// Grow by hand, append would leave a copy of the password behind.
+				grown := make([]byte, len(line), 2*cap(line))
+				copy(grown, line)
+				clear(line)
+				line = grown
+			}
+			line = append(line, b[0])
+		}
+		if err == io.EOF && len(line) > 0 {
+			return line, nil
+		}
+		if err != nil {
+			clear(line)
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package promptpassword
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReadFromPipe(t *testing.T) {
+	in := strings.NewReader("s3cret\r\nnext line\n")
+	var out bytes.Buffer
+
+	password, err := read(in, &out, "Password: ")
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if password != "s3cret" {
+		t.Errorf("expected %q, got %q", "s3cret", password)
+	}
+	if out.String() != "Password: " {
+		t.Errorf("expected the prompt to be printed, got %q", out.String())
+	}
+
+	// The rest of the input must be left for later prompts.
+	rest, _ := io.ReadAll(in)
+	if string(rest) != "next line\n" {
+		t.Errorf("expected the next line to be unread, got %q", rest)
+	}
+}
+
+func TestReadLastLineWithoutNewline(t *testing.T) {
+	password, err := read(strings.NewReader(strings.Repeat("x", 100)), io.Discard, "")
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if password != strings.Repeat("x", 100) {
+		t.Errorf("expected the whole line, got %q", password)
+	}
+}
+
+func TestReadEmptyInput(t *testing.T) {
+	if _, err := read(strings.NewReader(""), io.Discard, ""); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestReadWithConfirmation(t *testing.T) {
+	password, err := readWithConfirmation(strings.NewReader("s3cret\ns3cret\n"), io.Discard, "", "")
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if password != "s3cret" {
+		t.Errorf("expected %q, got %q", "s3cret", password)
+	}
+
+	_, err = readWithConfirmation(strings.NewReader("s3cret\ns3cert\n"), io.Discard, "", "")
+	if !errors.Is(err, ErrPasswordsDoNotMatch) {
+		t.Errorf("expected ErrPasswordsDoNotMatch, got %v", err)
+	}
+}
+
+func TestReadStdin(t *testing.T) {
+	in := strings.NewReader("s3cret\r\n123456\n")
+	password, err := ReadStdin(in)
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if password != "s3cret" {
+		t.Errorf("expected %q, got %q", "s3cret", password)
+	}
+
+	// Only the first line is the password, later prompts read the rest.
+	rest, _ := io.ReadAll(in)
+	if string(rest) != "123456\n" {
+		t.Errorf("expected the next line to be unread, got %q", rest)
+	}
+
+	for _, input := range []string{"", "\n"} {
+		if _, err := ReadStdin(strings.NewReader(input)); err == nil {
+			t.Errorf("expected an error for input %q", input)
+		}
+	}
+}
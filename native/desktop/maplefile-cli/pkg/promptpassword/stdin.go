@@ -0,0 +1,76 @@
+// monorepo/native/desktop/maplefile-cli/pkg/promptpassword/stdin.go
+package promptpassword
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// StdinFlag is the flag which makes a command read the password from the first line of stdin, so
+// scripts can pipe it in without exposing it in the process arguments or the shell history.
+const StdinFlag = "password-stdin"
+
+// passwordFlag is the flag commands accept the password on directly
+const passwordFlag = "password"
+
+// AddStdinFlag adds the --password-stdin flag to the command. When it is passed, the first line
+// of stdin is read into password before the command runs, and the command must not prompt for
+// the password. The flag can't be combined with --password, and when required is true one of the
+// two must be passed.
+func AddStdinFlag(cmd *cobra.Command, password *string, required bool) {
+	var fromStdin bool
+	cmd.Flags().BoolVar(&fromStdin, StdinFlag, false, "Read the password from the first line of stdin")
+
+	if cmd.Flags().Lookup(passwordFlag) != nil {
+		cmd.MarkFlagsMutuallyExclusive(passwordFlag, StdinFlag)
+		if required {
+			cmd.MarkFlagsOneRequired(passwordFlag, StdinFlag)
+		}
+	} else if required {
+		cmd.MarkFlagRequired(StdinFlag)
+	}
+
+	preRunE := cmd.PreRunE
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if fromStdin {
+			// Flag groups are only validated after this hook, don't read stdin for an invalid command.
+			if err := cmd.ValidateFlagGroups(); err != nil {
+				return err
+			}
+			stdinPassword, err := ReadStdin(os.Stdin)
+			if err != nil {
+				return err
+			}
+			*password = stdinPassword
+		}
+		if preRunE != nil {
+			return preRunE(cmd, args)
+		}
+		return nil
+	}
+}
+
+// ReadStdin reads the password from the first line of the input, leaving the rest of it unread
+func ReadStdin(in io.Reader) (string, error) {
+	buf, err := readLine(in)
+	defer clear(buf)
+	if errors.Is(err, io.EOF) || (err == nil && len(buf) == 0) {
+		return "", fmt.Errorf("--%s was passed but no password was read from stdin", StdinFlag)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read password from stdin: %w", err)
+	}
+	return string(trimCarriageReturn(buf)), nil
+}
+
+// trimCarriageReturn drops the carriage return of a line ending in CRLF
+func trimCarriageReturn(line []byte) []byte {
+	if n := len(line); n > 0 && line[n-1] == '\r' {
+		return line[:n-1]
+	}
+	return line
+}
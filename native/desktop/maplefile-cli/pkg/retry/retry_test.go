@@ -0,0 +1,102 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errTransient = errors.New("transient")
+
+func TestDoRetriesUntilSuccess(t *testing.T) {
+	attempts := 0
+	retried := 0
+	policy := Policy{
+		MaxAttempts: 5,
+		BaseDelay:   time.Millisecond,
+		OnRetry:     func(attempt int, err error, delay time.Duration) { retried++ },
+	}
+
+	err := Do(context.Background(), policy, func() error {
+		attempts++
+		if attempts < 3 {
+			return errTransient
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if attempts != 3 || retried != 2 {
+		t.Errorf("attempts = %d, retries = %d, want 3 and 2", attempts, retried)
+	}
+}
+
+func TestDoStopsAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	err := Do(context.Background(), Policy{MaxAttempts: 3, BaseDelay: time.Millisecond}, func() error {
+		attempts++
+		return errTransient
+	})
+	if !errors.Is(err, errTransient) {
+		t.Errorf("Do() error = %v, want %v", err, errTransient)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestDoStopsOnPermanentError(t *testing.T) {
+	errPermanent := errors.New("permanent")
+	attempts := 0
+	policy := Policy{
+		MaxAttempts: 5,
+		BaseDelay:   time.Millisecond,
+		Retryable:   func(err error) bool { return errors.Is(err, errTransient) },
+	}
+
+	err := Do(context.Background(), policy, func() error {
+		attempts++
+		return errPermanent
+	})
+	if !errors.Is(err, errPermanent) || attempts != 1 {
+		t.Errorf("Do() = %v after %d attempts, want %v after 1", err, attempts, errPermanent)
+	}
+}
+
+func TestDoStopsWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := Policy{
+		MaxAttempts: 5,
+		BaseDelay:   time.Hour,
+		OnRetry:     func(attempt int, err error, delay time.Duration) { cancel() },
+	}
+
+	err := Do(ctx, policy, func() error { return errTransient })
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, errTransient) {
+		t.Errorf("Do() error = %v, want both %v and %v", err, context.Canceled, errTransient)
+	}
+}
+
+func TestPolicyDelay(t *testing.T) {
+	policy := Policy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt, want := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		4:  800 * time.Millisecond,
+		5:  time.Second,
+		64: time.Second,
+	} {
+		if got := policy.Delay(attempt); got != want {
+			t.Errorf("Delay(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for range 100 {
+		if got := policy.Delay(2); got < 100*time.Millisecond || got > 200*time.Millisecond {
+			t.Fatalf("Delay(2) with jitter = %v, want between 100ms and 200ms", got)
+		}
+	}
+}
@@ -26,4 +26,7 @@ type Storage interface {
 	CommitTransaction() error
 
 	DiscardTransaction()
+
+	// IsInTransaction reports whether a transaction is open and not yet committed or discarded.
+	IsInTransaction() bool
 }
@@ -216,3 +216,7 @@ func (impl *storageImpl) DiscardTransaction() {
 	}()
 	impl.transaction.Discard()
 }
+
+func (impl *storageImpl) IsInTransaction() bool {
+	return impl.transaction != nil
+}